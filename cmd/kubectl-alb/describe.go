@@ -0,0 +1,294 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Tags the controller applies to the AWS resources of an ingress.
+const (
+	tagKeyNamespace   = "kubernetes.io/namespace"
+	tagKeyIngressName = "kubernetes.io/ingress-name"
+)
+
+type describeOptions struct {
+	Namespace     string
+	IngressName   string
+	ClusterName   string
+	Region        string
+	ControllerURL string
+	EventLimit    int
+}
+
+func describe(out io.Writer, clientSet kubernetes.Interface, opts describeOptions) error {
+	ingress, err := clientSet.ExtensionsV1beta1().Ingresses(opts.Namespace).Get(opts.IngressName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintf(w, "Ingress:\t%v/%v\n", ingress.Namespace, ingress.Name)
+	for _, lbIngress := range ingress.Status.LoadBalancer.Ingress {
+		fmt.Fprintf(w, "Address:\t%v\n", lbIngress.Hostname)
+	}
+
+	awsCfg := aws.Config{}
+	if opts.Region != "" {
+		awsCfg.Region = aws.String(opts.Region)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            awsCfg,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create AWS session due to %v", err)
+	}
+	elbv2svc := elbv2.New(sess)
+	rgt := resourcegroupstaggingapi.New(sess)
+
+	lbArns, err := taggedResources(rgt, opts, "elasticloadbalancing:loadbalancer")
+	if err != nil {
+		return err
+	}
+	tgArns, err := taggedResources(rgt, opts, "elasticloadbalancing:targetgroup")
+	if err != nil {
+		return err
+	}
+	tgNames := make(map[string]string)
+	var tgs []*elbv2.TargetGroup
+	if len(tgArns) > 0 {
+		resp, err := elbv2svc.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{TargetGroupArns: aws.StringSlice(tgArns)})
+		if err != nil {
+			return fmt.Errorf("failed to describe targetGroups due to %v", err)
+		}
+		tgs = resp.TargetGroups
+		for _, tg := range tgs {
+			tgNames[aws.StringValue(tg.TargetGroupArn)] = aws.StringValue(tg.TargetGroupName)
+		}
+	}
+
+	if len(lbArns) == 0 {
+		fmt.Fprintf(w, "\nLoadBalancer:\t<none>\n")
+	} else {
+		if err := describeLoadBalancers(w, elbv2svc, lbArns, tgNames); err != nil {
+			return err
+		}
+	}
+	if err := describeTargetGroups(w, elbv2svc, tgs); err != nil {
+		return err
+	}
+	if err := describeEvents(w, clientSet, opts); err != nil {
+		return err
+	}
+	if opts.ControllerURL != "" {
+		if err := describeController(w, opts.ControllerURL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// taggedResources returns ARNs of resources of resourceType tagged for the ingress.
+func taggedResources(rgt *resourcegroupstaggingapi.ResourceGroupsTaggingAPI, opts describeOptions, resourceType string) ([]string, error) {
+	tagFilters := []*resourcegroupstaggingapi.TagFilter{
+		{Key: aws.String(tagKeyNamespace), Values: aws.StringSlice([]string{opts.Namespace})},
+		{Key: aws.String(tagKeyIngressName), Values: aws.StringSlice([]string{opts.IngressName})},
+	}
+	if opts.ClusterName != "" {
+		tagFilters = append(tagFilters, &resourcegroupstaggingapi.TagFilter{Key: aws.String("kubernetes.io/cluster/" + opts.ClusterName)})
+	}
+	var arns []string
+	err := rgt.GetResourcesPages(&resourcegroupstaggingapi.GetResourcesInput{
+		TagFilters:          tagFilters,
+		ResourceTypeFilters: aws.StringSlice([]string{resourceType}),
+	}, func(output *resourcegroupstaggingapi.GetResourcesOutput, _ bool) bool {
+		for _, mapping := range output.ResourceTagMappingList {
+			arns = append(arns, aws.StringValue(mapping.ResourceARN))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %v resources due to %v", resourceType, err)
+	}
+	sort.Strings(arns)
+	return arns, nil
+}
+
+func describeLoadBalancers(w io.Writer, elbv2svc *elbv2.ELBV2, lbArns []string, tgNames map[string]string) error {
+	resp, err := elbv2svc.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{LoadBalancerArns: aws.StringSlice(lbArns)})
+	if err != nil {
+		return fmt.Errorf("failed to describe LoadBalancers due to %v", err)
+	}
+	for _, lb := range resp.LoadBalancers {
+		fmt.Fprintf(w, "\nLoadBalancer:\t%v\n", aws.StringValue(lb.LoadBalancerName))
+		fmt.Fprintf(w, "  ARN:\t%v\n", aws.StringValue(lb.LoadBalancerArn))
+		fmt.Fprintf(w, "  DNSName:\t%v\n", aws.StringValue(lb.DNSName))
+		fmt.Fprintf(w, "  Scheme:\t%v\n", aws.StringValue(lb.Scheme))
+		if lb.State != nil {
+			fmt.Fprintf(w, "  State:\t%v %v\n", aws.StringValue(lb.State.Code), aws.StringValue(lb.State.Reason))
+		}
+		var zones []string
+		for _, az := range lb.AvailabilityZones {
+			zones = append(zones, fmt.Sprintf("%v(%v)", aws.StringValue(az.ZoneName), aws.StringValue(az.SubnetId)))
+		}
+		fmt.Fprintf(w, "  AvailabilityZones:\t%v\n", strings.Join(zones, ", "))
+		fmt.Fprintf(w, "  SecurityGroups:\t%v\n", strings.Join(aws.StringValueSlice(lb.SecurityGroups), ", "))
+
+		listeners, err := elbv2svc.DescribeListeners(&elbv2.DescribeListenersInput{LoadBalancerArn: lb.LoadBalancerArn})
+		if err != nil {
+			return fmt.Errorf("failed to describe listeners due to %v", err)
+		}
+		sort.Slice(listeners.Listeners, func(i, j int) bool {
+			return aws.Int64Value(listeners.Listeners[i].Port) < aws.Int64Value(listeners.Listeners[j].Port)
+		})
+		for _, ls := range listeners.Listeners {
+			fmt.Fprintf(w, "  Listener:\t%v:%v\n", aws.StringValue(ls.Protocol), aws.Int64Value(ls.Port))
+			rules, err := elbv2svc.DescribeRules(&elbv2.DescribeRulesInput{ListenerArn: ls.ListenerArn})
+			if err != nil {
+				return fmt.Errorf("failed to describe rules due to %v", err)
+			}
+			for _, rule := range rules.Rules {
+				fmt.Fprintf(w, "    %v\t%v\t-> %v\n", aws.StringValue(rule.Priority), formatConditions(rule.Conditions), formatActions(rule.Actions, tgNames))
+			}
+		}
+	}
+	return nil
+}
+
+func describeTargetGroups(w io.Writer, elbv2svc *elbv2.ELBV2, tgs []*elbv2.TargetGroup) error {
+	fmt.Fprintf(w, "\nTargetGroups:\n")
+	if len(tgs) == 0 {
+		fmt.Fprintf(w, "  <none>\n")
+	}
+	for _, tg := range tgs {
+		fmt.Fprintf(w, "  %v\t%v:%v\t%v\thealth check %v %v\n", aws.StringValue(tg.TargetGroupName), aws.StringValue(tg.Protocol), aws.Int64Value(tg.Port),
+			aws.StringValue(tg.TargetType), aws.StringValue(tg.HealthCheckPort), aws.StringValue(tg.HealthCheckPath))
+		health, err := elbv2svc.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{TargetGroupArn: tg.TargetGroupArn})
+		if err != nil {
+			return fmt.Errorf("failed to describe target health due to %v", err)
+		}
+		for _, desc := range health.TargetHealthDescriptions {
+			state, reason := "", ""
+			if desc.TargetHealth != nil {
+				state = aws.StringValue(desc.TargetHealth.State)
+				reason = aws.StringValue(desc.TargetHealth.Reason)
+			}
+			fmt.Fprintf(w, "    %v:%v\t%v\t%v\n", aws.StringValue(desc.Target.Id), aws.Int64Value(desc.Target.Port), state, reason)
+		}
+	}
+	return nil
+}
+
+// describeEvents prints the most recent warning events of ingress, which include failed reconciles.
+func describeEvents(w io.Writer, clientSet kubernetes.Interface, opts describeOptions) error {
+	events, err := clientSet.CoreV1().Events(opts.Namespace).List(metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.kind=Ingress,involvedObject.name=%v,type=%v", opts.IngressName, corev1.EventTypeWarning),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list events due to %v", err)
+	}
+	items := events.Items
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].LastTimestamp.After(items[j].LastTimestamp.Time)
+	})
+	if len(items) > opts.EventLimit {
+		items = items[:opts.EventLimit]
+	}
+	fmt.Fprintf(w, "\nRecent warnings:\n")
+	if len(items) == 0 {
+		fmt.Fprintf(w, "  <none>\n")
+	}
+	for _, event := range items {
+		age := time.Since(event.LastTimestamp.Time).Round(time.Second)
+		fmt.Fprintf(w, "  %v ago\t%v\t%v\n", age, event.Reason, event.Message)
+	}
+	return nil
+}
+
+// describeController prints the diagnostics served by the controller at controllerURL.
+func describeController(w io.Writer, controllerURL string) error {
+	resp, err := http.Get(strings.TrimSuffix(controllerURL, "/") + "/debug/controller")
+	if err != nil {
+		return fmt.Errorf("failed to get controller diagnostics due to %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to get controller diagnostics due to %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get controller diagnostics, got status %v, diagnostics must be enabled with --diagnostics", resp.StatusCode)
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, body, "  ", "  "); err != nil {
+		return fmt.Errorf("failed to parse controller diagnostics due to %v", err)
+	}
+	fmt.Fprintf(w, "\nController:\n  %v\n", indented.String())
+	return nil
+}
+
+func formatConditions(conditions []*elbv2.RuleCondition) string {
+	if len(conditions) == 0 {
+		return "default"
+	}
+	var parts []string
+	for _, condition := range conditions {
+		parts = append(parts, fmt.Sprintf("%v=%v", aws.StringValue(condition.Field), strings.Join(aws.StringValueSlice(condition.Values), ",")))
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatActions(actions []*elbv2.Action, tgNames map[string]string) string {
+	var parts []string
+	for _, action := range actions {
+		switch aws.StringValue(action.Type) {
+		case elbv2.ActionTypeEnumForward:
+			tgArn := aws.StringValue(action.TargetGroupArn)
+			if name, ok := tgNames[tgArn]; ok {
+				tgArn = name
+			}
+			parts = append(parts, "forward "+tgArn)
+		case elbv2.ActionTypeEnumRedirect:
+			cfg := action.RedirectConfig
+			parts = append(parts, fmt.Sprintf("redirect %v %v://%v:%v%v", aws.StringValue(cfg.StatusCode), aws.StringValue(cfg.Protocol),
+				aws.StringValue(cfg.Host), aws.StringValue(cfg.Port), aws.StringValue(cfg.Path)))
+		case elbv2.ActionTypeEnumFixedResponse:
+			parts = append(parts, "fixed-response "+aws.StringValue(action.FixedResponseConfig.StatusCode))
+		default:
+			parts = append(parts, aws.StringValue(action.Type))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
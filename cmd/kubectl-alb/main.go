@@ -0,0 +1,90 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kubectl-alb is a kubectl plugin that describes the AWS resources of an ingress managed by the controller.
+//
+// Usage:
+//
+//	kubectl alb describe <ingress> [-n namespace] [--cluster-name name] [--region region] [--controller-url url]
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const usage = `kubectl alb describe <ingress> [flags]
+
+Describes the ALB, listeners, rules, targetGroups and target health of an ingress,
+along with the recent warning events reported by the controller.
+
+Flags:
+`
+
+func main() {
+	fs := pflag.NewFlagSet("kubectl-alb", pflag.ExitOnError)
+	var opts describeOptions
+	kubeConfigFlags := clientcmd.NewDefaultClientConfigLoadingRules()
+	overrides := &clientcmd.ConfigOverrides{}
+	fs.StringVar(&kubeConfigFlags.ExplicitPath, "kubeconfig", "", "Path to the kubeconfig file")
+	fs.StringVar(&overrides.CurrentContext, "context", "", "Name of the kubeconfig context to use")
+	fs.StringVarP(&overrides.Context.Namespace, "namespace", "n", "", "Namespace of the ingress")
+	fs.StringVar(&opts.ClusterName, "cluster-name", "", "Cluster name the controller runs with, restricts AWS resources to those owned by that cluster")
+	fs.StringVar(&opts.Region, "region", "", "AWS region of the ALB, defaults to the region of the AWS configuration")
+	fs.StringVar(&opts.ControllerURL, "controller-url", "", "URL of the controller's healthz port, e.g. http://localhost:10254 through kubectl port-forward, to include its diagnostics")
+	fs.IntVar(&opts.EventLimit, "events", 10, "Maximum number of recent warning events to print")
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		os.Exit(2)
+	}
+	args := fs.Args()
+	if len(args) != 2 || args[0] != "describe" {
+		fs.Usage()
+		os.Exit(2)
+	}
+	opts.IngressName = args[1]
+
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(kubeConfigFlags, overrides)
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		fatal(err)
+	}
+	opts.Namespace = namespace
+	restCfg, err := clientConfig.ClientConfig()
+	if err != nil {
+		fatal(err)
+	}
+	clientSet, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		fatal(err)
+	}
+
+	if err := describe(os.Stdout, clientSet, opts); err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "error: %v\n", err)
+	os.Exit(1)
+}
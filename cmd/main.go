@@ -27,9 +27,13 @@ import (
 	"time"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/schema"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/diagnostics"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric/collectors"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/standby"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/ticketmaster/aws-sdk-go-cache/cache"
@@ -41,6 +45,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/signals"
 )
@@ -52,6 +57,8 @@ const (
 	// High enough Burst to fit all expected use cases. Burst=0 is not set here, because
 	// client code is overriding it.
 	defaultBurst = 1e6
+	// eventFlushPeriod is how long the controller waits on termination for events emitted by the last reconciles to be sent.
+	eventFlushPeriod = 2 * time.Second
 )
 
 func main() {
@@ -65,6 +72,7 @@ func main() {
 	if options.ShowVersion {
 		os.Exit(0)
 	}
+	log.SetLevels(options.LogLevels)
 
 	restCfg, err := buildRestConfig(options)
 	if err != nil {
@@ -73,13 +81,22 @@ func main() {
 	mgr, err := manager.New(restCfg, manager.Options{
 		Namespace:               options.WatchNamespace,
 		SyncPeriod:              &options.SyncPeriod,
-		LeaderElection:          options.LeaderElection,
+		LeaderElection:          options.LeaderElection && !options.StandbyWarmCache,
 		LeaderElectionID:        options.LeaderElectionID,
 		LeaderElectionNamespace: options.LeaderElectionNamespace,
 	})
 	if err != nil {
 		glog.Fatal(err)
 	}
+	if options.LeaderElection && options.StandbyWarmCache {
+		mgr, err = standby.New(mgr, standby.Options{
+			LeaderElectionID:        options.LeaderElectionID,
+			LeaderElectionNamespace: options.LeaderElectionNamespace,
+		})
+		if err != nil {
+			glog.Fatal(err)
+		}
+	}
 
 	reg := prometheus.NewRegistry()
 	reg.MustRegister(prometheus.NewGoCollector())
@@ -93,11 +110,22 @@ func main() {
 	}
 	mc.Start()
 
-	cloud, err := aws.New(options.cloudConfig, options.ingressCTLConfig.ClusterName, mc, cc)
+	diag := diagnostics.New(ctrlmetrics.Registry)
+	var auditLog *aws.AuditLog
+	if options.cloudConfig.AuditLog {
+		auditLog = aws.NewAuditLog(os.Stdout, options.ingressCTLConfig.ClusterName)
+	}
+	cloud, err := aws.New(options.cloudConfig, options.ingressCTLConfig.ClusterName, mc, cc, diag, auditLog)
 	if err != nil {
 		glog.Fatal(err)
 	}
-	if err := controller.Initialize(&options.ingressCTLConfig, mgr, mc, cloud); err != nil {
+	if auditLog != nil {
+		if err := mgr.Add(auditLog); err != nil {
+			glog.Fatal(err)
+		}
+	}
+	forceSync := controller.NewForceSync(cloud)
+	if err := controller.Initialize(&options.ingressCTLConfig, mgr, mc, cloud, diag, forceSync); err != nil {
 		glog.Fatal(err)
 	}
 
@@ -105,6 +133,10 @@ func main() {
 	if options.ProfilingEnabled {
 		registerProfiler(mux)
 	}
+	if options.DiagnosticsEnabled {
+		mux.Handle("/debug/controller", diag)
+	}
+	mux.Handle("/sync", forceSync)
 	registerHealthz(mux, aws.NewHealthChecker(cloud))
 	registerMetrics(mux, reg)
 	registerHandlers(mux)
@@ -113,6 +145,31 @@ func main() {
 	if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
 		glog.Fatal(err)
 	}
+	shutdown(diag, options.ShutdownGracePeriod)
+	if auditLog != nil {
+		// records of the calls made by the last reconciles are sent to sinks before exiting.
+		auditLog.Flush()
+	}
+}
+
+// shutdown waits up to gracePeriod for in-flight reconciles and AWS calls to finish once the manager stopped accepting new work,
+// so that their changes aren't left half-applied. Events are sent asynchronously, so the rest of gracePeriod, up to eventFlushPeriod,
+// is left for the events emitted by the last reconciles to be sent.
+func shutdown(diag *diagnostics.Diagnostics, gracePeriod time.Duration) {
+	defer glog.Flush()
+	glog.Infof("shutting down, waiting up to %v for in-flight reconciles to finish", gracePeriod)
+	start := time.Now()
+	if !diag.WaitIdle(gracePeriod) {
+		glog.Warningf("in-flight reconciles didn't finish within %v, exiting anyway", gracePeriod)
+		return
+	}
+	flushPeriod := gracePeriod - time.Since(start)
+	if flushPeriod > eventFlushPeriod {
+		flushPeriod = eventFlushPeriod
+	}
+	if flushPeriod > 0 {
+		time.Sleep(flushPeriod)
+	}
 }
 
 // buildRestConfig creates a new Kubernetes REST configuration. apiserverHost is
@@ -140,6 +197,8 @@ func registerHandlers(mux *http.ServeMux) {
 		_, _ = w.Write(b)
 	})
 
+	mux.Handle("/annotations/schema", schema.Handler())
+
 	mux.HandleFunc("/stop", func(w http.ResponseWriter, r *http.Request) {
 		err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
 		if err != nil {
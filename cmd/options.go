@@ -28,6 +28,7 @@ import (
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/net"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	apiv1 "k8s.io/api/core/v1"
 )
 
@@ -35,11 +36,14 @@ const (
 	defaultLeaderElection          = true
 	defaultLeaderElectionID        = "ingress-controller-leader-alb"
 	defaultLeaderElectionNamespace = ""
+	defaultStandbyWarmCache        = false
 	defaultWatchNamespace          = apiv1.NamespaceAll
 	defaultSyncPeriod              = 60 * time.Minute
 	defaultHealthCheckPeriod       = 1 * time.Minute
 	defaultHealthzPort             = 10254
 	defaultProfilingEnabled        = true
+	defaultDiagnosticsEnabled      = false
+	defaultShutdownGracePeriod     = 25 * time.Second
 )
 
 // Options defines the commandline interface of this binary
@@ -52,12 +56,20 @@ type Options struct {
 	LeaderElection          bool
 	LeaderElectionID        string
 	LeaderElectionNamespace string
+	StandbyWarmCache        bool
 
-	WatchNamespace    string
-	SyncPeriod        time.Duration
-	HealthCheckPeriod time.Duration
-	HealthzPort       int
-	ProfilingEnabled  bool
+	WatchNamespace     string
+	SyncPeriod         time.Duration
+	HealthCheckPeriod  time.Duration
+	HealthzPort        int
+	ProfilingEnabled   bool
+	DiagnosticsEnabled bool
+
+	// ShutdownGracePeriod is how long in-flight reconciles are given to finish on termination.
+	ShutdownGracePeriod time.Duration
+
+	// LogLevels are the log levels of controller modules.
+	LogLevels log.Levels
 
 	// aws cloud specific configuration
 	cloudConfig aws.CloudConfig
@@ -81,6 +93,8 @@ func (options *Options) BindFlags(fs *pflag.FlagSet) {
 		`Namespace of leader-election configmap for ingress controller`)
 	fs.StringVar(&options.LeaderElectionNamespace, "election-namespace", defaultLeaderElectionNamespace,
 		`Namespace of leader-election configmap for ingress controller. If unspecified, the namespace of this controller pod will be used`)
+	fs.BoolVar(&options.StandbyWarmCache, "election-standby-warm-cache", defaultStandbyWarmCache,
+		`Whether replicas waiting for leader election keep their caches synced, so a new leader starts reconciling without waiting for caches to sync`)
 	fs.StringVar(&options.WatchNamespace, "watch-namespace", defaultWatchNamespace,
 		`Namespace the controller watches for updates to Kubernetes objects.
 		This includes Ingresses, Services and all configuration resources. All
@@ -93,6 +107,12 @@ func (options *Options) BindFlags(fs *pflag.FlagSet) {
 		`Port to use for the healthz endpoint.`)
 	fs.BoolVar(&options.ProfilingEnabled, "profiling", defaultProfilingEnabled,
 		`Enable profiling via web interface host:port/debug/pprof/`)
+	fs.BoolVar(&options.DiagnosticsEnabled, "diagnostics", defaultDiagnosticsEnabled,
+		`Enable runtime diagnostics of controller, such as queue depths and informer cache sizes, via web interface host:port/debug/controller`)
+	fs.DurationVar(&options.ShutdownGracePeriod, "shutdown-grace-period", defaultShutdownGracePeriod,
+		`Period in-flight reconciles are given to finish their AWS changes on termination, before the controller exits. Should be below the terminationGracePeriodSeconds of the pod`)
+	fs.Var(&options.LogLevels, "log-level",
+		`Log levels of controller modules as comma separated module=level pairs, e.g. lb=warn,ls=debug, where module is a package such as lb, ls or tg and level is one of debug, info, warn or error. A level without module applies to the other modules`)
 	options.cloudConfig.BindFlags(fs)
 	options.ingressCTLConfig.BindFlags(fs)
 
@@ -114,6 +134,9 @@ func (options *Options) Validate() error {
 	if !net.IsPortAvailable(options.HealthzPort) {
 		return fmt.Errorf("port %v is already in use. Please check the flag --healthz-port", options.HealthzPort)
 	}
+	if options.ShutdownGracePeriod < 0 {
+		return fmt.Errorf("--shutdown-grace-period must be non-negative")
+	}
 	if err := options.ingressCTLConfig.Validate(); err != nil {
 		return err
 	}
@@ -5,7 +5,7 @@ require (
 	github.com/alecthomas/units v0.0.0-20151022065526-2efee857e7cf // indirect
 	github.com/aws/aws-sdk-go v1.16.11
 	github.com/blang/semver v3.5.1+incompatible
-	github.com/ghodss/yaml v1.0.0 // indirect
+	github.com/ghodss/yaml v1.0.0
 	github.com/go-ini/ini v1.38.1 // indirect
 	github.com/go-logr/glogr v0.0.0-20180706173232-03aa3c320058
 	github.com/go-logr/logr v0.0.0-20180629235805-9fb12b3b21c5 // indirect
@@ -0,0 +1,339 @@
+package cert
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// idempotencyTokenLength is the maximum length of idempotency tokens of ACM certificate requests.
+	idempotencyTokenLength = 32
+
+	// validationRecordTTL is the TTL in seconds of DNS records validating public certificates.
+	validationRecordTTL = 300
+)
+
+// TagGenerator generates the tags of certificates issued for an ingress.
+type TagGenerator interface {
+	TagCertificate(namespace string, ingressName string) map[string]string
+}
+
+// Controller manages certificates in ACM for TLS hosts of ingresses.
+// Certificates are either imported from the TLS secrets of ingresses, issued by an ACM Private CA,
+// or public certificates validated by DNS records in a Route53 hosted zone.
+type Controller interface {
+	// Reconcile returns the ARNs of certificates covering TLS hosts of ingress, in order of hosts.
+	// TLS secrets are imported, and certificates are requested for other hosts not matched by any issued certificate,
+	// an error is returned until all of them are issued.
+	Reconcile(ctx context.Context, ingress *extensions.Ingress) ([]string, error)
+
+	// GC deletes certificates imported or requested for ingress that aren't in inUse.
+	GC(ctx context.Context, ingressKey types.NamespacedName, inUse []string) error
+
+	// Delete deletes all certificates imported or requested for ingress.
+	Delete(ctx context.Context, ingressKey types.NamespacedName) error
+}
+
+// Options configures how Controller provides certificates.
+type Options struct {
+	// PrivateCAArn is the ARN of the ACM Private CA to request certificates from.
+	PrivateCAArn string
+	// ValidationZoneID is the ID of the Route53 hosted zone to create DNS validation records in for public certificates,
+	// they're requested if PrivateCAArn is empty.
+	ValidationZoneID string
+	// ImportTLSSecrets is whether certificates of TLS secrets referenced by ingresses are imported.
+	ImportTLSSecrets bool
+}
+
+// NewController creates a Controller providing certificates as configured by opts, TLS secrets are read from client.
+func NewController(cloud aws.CloudAPI, client client.Reader, tagGen TagGenerator, opts Options) Controller {
+	return &defaultController{
+		cloud:  cloud,
+		client: client,
+		tagGen: tagGen,
+		opts:   opts,
+	}
+}
+
+type defaultController struct {
+	cloud  aws.CloudAPI
+	client client.Reader
+	tagGen TagGenerator
+	opts   Options
+}
+
+func (c *defaultController) Reconcile(ctx context.Context, ingress *extensions.Ingress) ([]string, error) {
+	var certArns []string
+	if c.opts.ImportTLSSecrets {
+		var err error
+		if certArns, err = c.importTLSSecrets(ctx, ingress); err != nil {
+			return nil, err
+		}
+	}
+	hosts := tlsHosts(ingress, c.opts.ImportTLSSecrets)
+	if len(hosts) == 0 {
+		return certArns, nil
+	}
+	summaries, err := c.cloud.ListIssuedCertificates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates due to %v", err)
+	}
+
+	var unmatchedHosts []string
+	for _, host := range hosts {
+		certArn := matchCertificate(summaries, host)
+		if certArn == "" {
+			unmatchedHosts = append(unmatchedHosts, host)
+			continue
+		}
+		certArns = appendUnique(certArns, certArn)
+	}
+	if len(unmatchedHosts) == 0 || !c.requesting() {
+		return certArns, nil
+	}
+
+	requestedByHost, err := c.describeRequestedCertificates(ctx, ingress.Namespace, ingress.Name)
+	if err != nil {
+		return nil, err
+	}
+	for _, host := range unmatchedHosts {
+		if cert, ok := requestedByHost[host]; ok {
+			if aws.StringValue(cert.Status) == acm.CertificateStatusFailed {
+				return nil, fmt.Errorf("certificate %v for host %v failed to be issued due to %v",
+					aws.StringValue(cert.CertificateArn), host, aws.StringValue(cert.FailureReason))
+			}
+			if c.opts.PrivateCAArn == "" {
+				if err := c.reconcileValidationRecords(ctx, cert); err != nil {
+					return nil, err
+				}
+			}
+			albctx.RecordWait(ctx, aws.StringValue(cert.CertificateArn), albctx.WaitStateProvisioning)
+			continue
+		}
+		certArn, err := c.requestCertificate(ctx, ingress, host)
+		if err != nil {
+			return nil, err
+		}
+		albctx.RecordWait(ctx, certArn, albctx.WaitStateProvisioning)
+	}
+	return nil, fmt.Errorf("waiting for certificates of hosts %v to be issued by %v", strings.Join(unmatchedHosts, ","), c.issuer())
+}
+
+func (c *defaultController) GC(ctx context.Context, ingressKey types.NamespacedName, inUse []string) error {
+	certArns, err := c.ownedCertificates(ingressKey.Namespace, ingressKey.Name)
+	if err != nil {
+		return err
+	}
+	unused := sets.NewString(certArns...).Difference(sets.NewString(inUse...))
+	for _, certArn := range unused.List() {
+		albctx.GetLogger(ctx).Infof("deleting certificate %v", certArn)
+		if _, err := c.cloud.DeleteCertificateWithContext(ctx, &acm.DeleteCertificateInput{
+			CertificateArn: aws.String(certArn),
+		}); err != nil {
+			return fmt.Errorf("failed to delete certificate %v due to %v", certArn, err)
+		}
+	}
+	return nil
+}
+
+func (c *defaultController) Delete(ctx context.Context, ingressKey types.NamespacedName) error {
+	return c.GC(ctx, ingressKey, nil)
+}
+
+// requesting returns whether certificates are requested for hosts not matched by any issued certificate.
+func (c *defaultController) requesting() bool {
+	return c.opts.PrivateCAArn != "" || c.opts.ValidationZoneID != ""
+}
+
+// issuer describes who issues requested certificates.
+func (c *defaultController) issuer() string {
+	if c.opts.PrivateCAArn != "" {
+		return c.opts.PrivateCAArn
+	}
+	return "ACM after DNS validation in " + c.opts.ValidationZoneID
+}
+
+func (c *defaultController) requestCertificate(ctx context.Context, ingress *extensions.Ingress, host string) (string, error) {
+	albctx.GetLogger(ctx).Infof("requesting certificate for host %v from %v", host, c.issuer())
+	input := &acm.RequestCertificateInput{
+		DomainName:       aws.String(host),
+		IdempotencyToken: aws.String(idempotencyToken(ingress.Namespace, ingress.Name, host)),
+	}
+	if c.opts.PrivateCAArn != "" {
+		input.CertificateAuthorityArn = aws.String(c.opts.PrivateCAArn)
+	} else {
+		input.ValidationMethod = aws.String(acm.ValidationMethodDns)
+	}
+	resp, err := c.cloud.RequestCertificateWithContext(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("failed to request certificate for host %v due to %v", host, err)
+	}
+	certArn := aws.StringValue(resp.CertificateArn)
+
+	if err := c.tagCertificate(ctx, certArn, c.tagGen.TagCertificate(ingress.Namespace, ingress.Name)); err != nil {
+		return "", err
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "CREATE", "certificate %v requested for host %v", certArn, host)
+	return certArn, nil
+}
+
+// reconcileValidationRecords creates the DNS records validating public certificate in the validation hosted zone.
+// ACM only reports the records shortly after the certificate is requested, they're created on a later reconcile.
+// Records are kept after the certificate is deleted, since certificates for the same domain share them.
+func (c *defaultController) reconcileValidationRecords(ctx context.Context, cert *acm.CertificateDetail) error {
+	var changes []*route53.Change
+	for _, option := range cert.DomainValidationOptions {
+		if aws.StringValue(option.ValidationStatus) != acm.DomainStatusPendingValidation || option.ResourceRecord == nil {
+			continue
+		}
+		changes = append(changes, &route53.Change{
+			Action: aws.String(route53.ChangeActionUpsert),
+			ResourceRecordSet: &route53.ResourceRecordSet{
+				Name: option.ResourceRecord.Name,
+				Type: option.ResourceRecord.Type,
+				TTL:  aws.Int64(validationRecordTTL),
+				ResourceRecords: []*route53.ResourceRecord{
+					{Value: option.ResourceRecord.Value},
+				},
+			},
+		})
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	albctx.GetLogger(ctx).Infof("creating DNS validation records for certificate %v", aws.StringValue(cert.CertificateArn))
+	if _, err := c.cloud.ChangeResourceRecordSetsWithContext(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(c.opts.ValidationZoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Comment: aws.String("validation of " + aws.StringValue(cert.CertificateArn)),
+			Changes: changes,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to create DNS validation records for certificate %v due to %v", aws.StringValue(cert.CertificateArn), err)
+	}
+	return nil
+}
+
+func (c *defaultController) tagCertificate(ctx context.Context, certArn string, tags map[string]string) error {
+	var acmTags []*acm.Tag
+	for k, v := range tags {
+		acmTags = append(acmTags, &acm.Tag{Key: aws.String(k), Value: aws.String(v)})
+	}
+	sort.Slice(acmTags, func(i, j int) bool {
+		return aws.StringValue(acmTags[i].Key) < aws.StringValue(acmTags[j].Key)
+	})
+	if _, err := c.cloud.AddTagsToCertificateWithContext(ctx, &acm.AddTagsToCertificateInput{
+		CertificateArn: aws.String(certArn),
+		Tags:           acmTags,
+	}); err != nil {
+		return fmt.Errorf("failed to tag certificate %v due to %v", certArn, err)
+	}
+	return nil
+}
+
+// describeRequestedCertificates returns the certificates requested for ingress by their domain name, imported certificates are excluded.
+func (c *defaultController) describeRequestedCertificates(ctx context.Context, namespace string, ingressName string) (map[string]*acm.CertificateDetail, error) {
+	certArns, err := c.ownedCertificates(namespace, ingressName)
+	if err != nil {
+		return nil, err
+	}
+	certByHost := make(map[string]*acm.CertificateDetail)
+	for _, certArn := range certArns {
+		resp, err := c.cloud.DescribeCertificateWithContext(ctx, &acm.DescribeCertificateInput{
+			CertificateArn: aws.String(certArn),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe certificate %v due to %v", certArn, err)
+		}
+		if aws.StringValue(resp.Certificate.Type) == acm.CertificateTypeImported {
+			continue
+		}
+		certByHost[aws.StringValue(resp.Certificate.DomainName)] = resp.Certificate
+	}
+	return certByHost, nil
+}
+
+// ownedCertificates returns the ARNs of certificates imported or requested for ingress, found by their tags.
+func (c *defaultController) ownedCertificates(namespace string, ingressName string) ([]string, error) {
+	tagFilters := make(map[string][]string)
+	for k, v := range c.tagGen.TagCertificate(namespace, ingressName) {
+		tagFilters[k] = []string{v}
+	}
+	certArns, err := c.cloud.GetResourcesByFilters(tagFilters, aws.ResourceTypeEnumACMCertificate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certificates due to %v", err)
+	}
+	return certArns, nil
+}
+
+// tlsHosts returns the distinct TLS hosts of ingress, in order of appearance.
+// Hosts of TLS entries with secretName are skipped if skipSecrets, as their certificates are imported.
+func tlsHosts(ingress *extensions.Ingress, skipSecrets bool) []string {
+	seen := sets.NewString()
+	var hosts []string
+	for _, tls := range ingress.Spec.TLS {
+		if skipSecrets && tls.SecretName != "" {
+			continue
+		}
+		for _, host := range tls.Hosts {
+			host = strings.ToLower(host)
+			if host == "" || seen.Has(host) {
+				continue
+			}
+			seen.Insert(host)
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// appendUnique appends certArn to certArns unless it's already in.
+func appendUnique(certArns []string, certArn string) []string {
+	for _, arn := range certArns {
+		if arn == certArn {
+			return certArns
+		}
+	}
+	return append(certArns, certArn)
+}
+
+// matchCertificate returns the ARN of the certificate in summaries matching host, preferring exact matches over wildcards.
+// It returns empty string if none matches.
+func matchCertificate(summaries []*acm.CertificateSummary, host string) string {
+	wildcardDomain := ""
+	if i := strings.Index(host, "."); i > 0 {
+		wildcardDomain = "*" + host[i:]
+	}
+	wildcardMatch := ""
+	for _, summary := range summaries {
+		domainName := strings.ToLower(aws.StringValue(summary.DomainName))
+		if domainName == host {
+			return aws.StringValue(summary.CertificateArn)
+		}
+		if wildcardMatch == "" && wildcardDomain != "" && domainName == wildcardDomain {
+			wildcardMatch = aws.StringValue(summary.CertificateArn)
+		}
+	}
+	return wildcardMatch
+}
+
+// idempotencyToken returns the token identifying the certificate request for host of ingress,
+// so that retried requests don't issue duplicate certificates.
+func idempotencyToken(namespace string, ingressName string, host string) string {
+	hash := sha256.Sum256([]byte(namespace + "/" + ingressName + "/" + host))
+	return hex.EncodeToString(hash[:])[:idempotencyTokenLength]
+}
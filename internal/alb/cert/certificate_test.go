@@ -0,0 +1,236 @@
+package cert
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type mockTagGenerator struct{}
+
+func (mockTagGenerator) TagCertificate(namespace string, ingressName string) map[string]string {
+	return map[string]string{
+		"kubernetes.io/namespace":    namespace,
+		"kubernetes.io/ingress-name": ingressName,
+	}
+}
+
+var ownedTagFilters = map[string][]string{
+	"kubernetes.io/namespace":    {"namespace"},
+	"kubernetes.io/ingress-name": {"ingress"},
+}
+
+func Test_matchCertificate(t *testing.T) {
+	summaries := []*acm.CertificateSummary{
+		{CertificateArn: aws.String("wildcard"), DomainName: aws.String("*.example.com")},
+		{CertificateArn: aws.String("exact"), DomainName: aws.String("www.example.com")},
+	}
+	for _, tc := range []struct {
+		host     string
+		expected string
+	}{
+		{host: "www.example.com", expected: "exact"},
+		{host: "api.example.com", expected: "wildcard"},
+		{host: "a.api.example.com", expected: ""},
+		{host: "example.com", expected: ""},
+	} {
+		t.Run(tc.host, func(t *testing.T) {
+			assert.Equal(t, tc.expected, matchCertificate(summaries, tc.host))
+		})
+	}
+}
+
+func Test_tlsHosts(t *testing.T) {
+	ingress := &extensions.Ingress{
+		Spec: extensions.IngressSpec{
+			TLS: []extensions.IngressTLS{
+				{Hosts: []string{"b.example.com", "A.example.com"}},
+				{Hosts: []string{"a.example.com", ""}},
+				{Hosts: []string{"c.example.com"}, SecretName: "secret"},
+			},
+		},
+	}
+	assert.Equal(t, []string{"b.example.com", "a.example.com", "c.example.com"}, tlsHosts(ingress, false))
+	assert.Equal(t, []string{"b.example.com", "a.example.com"}, tlsHosts(ingress, true))
+}
+
+func TestDefaultController_Reconcile(t *testing.T) {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"},
+		Spec: extensions.IngressSpec{
+			TLS: []extensions.IngressTLS{
+				{Hosts: []string{"a.example.com", "b.example.com"}},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		name                    string
+		summaries               []*acm.CertificateSummary
+		ownedArns               []string
+		ownedCertificates       []*acm.CertificateDetail
+		expectRequest           bool
+		expectedCertificateArns []string
+		expectedErr             error
+	}{
+		{
+			name: "all hosts matched by issued certificates",
+			summaries: []*acm.CertificateSummary{
+				{CertificateArn: aws.String("cert-a"), DomainName: aws.String("a.example.com")},
+				{CertificateArn: aws.String("cert-wildcard"), DomainName: aws.String("*.example.com")},
+			},
+			expectedCertificateArns: []string{"cert-a", "cert-wildcard"},
+		},
+		{
+			name: "certificate requested for unmatched host",
+			summaries: []*acm.CertificateSummary{
+				{CertificateArn: aws.String("cert-a"), DomainName: aws.String("a.example.com")},
+			},
+			expectRequest: true,
+			expectedErr:   errors.New("waiting for certificates of hosts b.example.com to be issued by ca"),
+		},
+		{
+			name: "certificate pending for unmatched host",
+			summaries: []*acm.CertificateSummary{
+				{CertificateArn: aws.String("cert-a"), DomainName: aws.String("a.example.com")},
+			},
+			ownedArns: []string{"cert-b"},
+			ownedCertificates: []*acm.CertificateDetail{
+				{CertificateArn: aws.String("cert-b"), DomainName: aws.String("b.example.com"), Status: aws.String(acm.CertificateStatusPendingValidation)},
+			},
+			expectedErr: errors.New("waiting for certificates of hosts b.example.com to be issued by ca"),
+		},
+		{
+			name: "certificate failed for unmatched host",
+			summaries: []*acm.CertificateSummary{
+				{CertificateArn: aws.String("cert-a"), DomainName: aws.String("a.example.com")},
+			},
+			ownedArns: []string{"cert-b"},
+			ownedCertificates: []*acm.CertificateDetail{
+				{CertificateArn: aws.String("cert-b"), DomainName: aws.String("b.example.com"), Status: aws.String(acm.CertificateStatusFailed), FailureReason: aws.String("PCA_INVALID_STATE")},
+			},
+			expectedErr: errors.New("certificate cert-b for host b.example.com failed to be issued due to PCA_INVALID_STATE"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("ListIssuedCertificates", ctx).Return(tc.summaries, nil)
+			if tc.expectedErr != nil {
+				cloud.On("GetResourcesByFilters", ownedTagFilters, aws.ResourceTypeEnumACMCertificate).Return(tc.ownedArns, nil)
+			}
+			for _, detail := range tc.ownedCertificates {
+				cloud.On("DescribeCertificateWithContext", ctx, &acm.DescribeCertificateInput{CertificateArn: detail.CertificateArn}).
+					Return(&acm.DescribeCertificateOutput{Certificate: detail}, nil)
+			}
+			if tc.expectRequest {
+				cloud.On("RequestCertificateWithContext", ctx, &acm.RequestCertificateInput{
+					DomainName:              aws.String("b.example.com"),
+					CertificateAuthorityArn: aws.String("ca"),
+					IdempotencyToken:        aws.String(idempotencyToken("namespace", "ingress", "b.example.com")),
+				}).Return(&acm.RequestCertificateOutput{CertificateArn: aws.String("cert-b")}, nil)
+				cloud.On("AddTagsToCertificateWithContext", ctx, &acm.AddTagsToCertificateInput{
+					CertificateArn: aws.String("cert-b"),
+					Tags: []*acm.Tag{
+						{Key: aws.String("kubernetes.io/ingress-name"), Value: aws.String("ingress")},
+						{Key: aws.String("kubernetes.io/namespace"), Value: aws.String("namespace")},
+					},
+				}).Return(&acm.AddTagsToCertificateOutput{}, nil)
+			}
+
+			controller := NewController(cloud, nil, mockTagGenerator{}, Options{PrivateCAArn: "ca"})
+			certificateArns, err := controller.Reconcile(ctx, ingress)
+			assert.Equal(t, tc.expectedCertificateArns, certificateArns)
+			assert.Equal(t, tc.expectedErr, err)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultController_Reconcile_DNSValidation(t *testing.T) {
+	ctx := context.Background()
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"},
+		Spec: extensions.IngressSpec{
+			TLS: []extensions.IngressTLS{
+				{Hosts: []string{"a.example.com", "b.example.com"}},
+			},
+		},
+	}
+	cloud := &mocks.CloudAPI{}
+	cloud.On("ListIssuedCertificates", ctx).Return(nil, nil)
+	cloud.On("GetResourcesByFilters", ownedTagFilters, aws.ResourceTypeEnumACMCertificate).Return([]string{"cert-a"}, nil)
+	cloud.On("DescribeCertificateWithContext", ctx, &acm.DescribeCertificateInput{CertificateArn: aws.String("cert-a")}).
+		Return(&acm.DescribeCertificateOutput{Certificate: &acm.CertificateDetail{
+			CertificateArn: aws.String("cert-a"),
+			DomainName:     aws.String("a.example.com"),
+			Status:         aws.String(acm.CertificateStatusPendingValidation),
+			DomainValidationOptions: []*acm.DomainValidation{
+				{
+					DomainName:       aws.String("a.example.com"),
+					ValidationStatus: aws.String(acm.DomainStatusPendingValidation),
+					ResourceRecord: &acm.ResourceRecord{
+						Name:  aws.String("_x1.a.example.com."),
+						Type:  aws.String("CNAME"),
+						Value: aws.String("_x2.acm-validations.aws."),
+					},
+				},
+			},
+		}}, nil)
+	cloud.On("ChangeResourceRecordSetsWithContext", ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String("zone"),
+		ChangeBatch: &route53.ChangeBatch{
+			Comment: aws.String("validation of cert-a"),
+			Changes: []*route53.Change{
+				{
+					Action: aws.String(route53.ChangeActionUpsert),
+					ResourceRecordSet: &route53.ResourceRecordSet{
+						Name:            aws.String("_x1.a.example.com."),
+						Type:            aws.String("CNAME"),
+						TTL:             aws.Int64(validationRecordTTL),
+						ResourceRecords: []*route53.ResourceRecord{{Value: aws.String("_x2.acm-validations.aws.")}},
+					},
+				},
+			},
+		},
+	}).Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+	cloud.On("RequestCertificateWithContext", ctx, &acm.RequestCertificateInput{
+		DomainName:       aws.String("b.example.com"),
+		ValidationMethod: aws.String(acm.ValidationMethodDns),
+		IdempotencyToken: aws.String(idempotencyToken("namespace", "ingress", "b.example.com")),
+	}).Return(&acm.RequestCertificateOutput{CertificateArn: aws.String("cert-b")}, nil)
+	cloud.On("AddTagsToCertificateWithContext", ctx, &acm.AddTagsToCertificateInput{
+		CertificateArn: aws.String("cert-b"),
+		Tags: []*acm.Tag{
+			{Key: aws.String("kubernetes.io/ingress-name"), Value: aws.String("ingress")},
+			{Key: aws.String("kubernetes.io/namespace"), Value: aws.String("namespace")},
+		},
+	}).Return(&acm.AddTagsToCertificateOutput{}, nil)
+
+	controller := NewController(cloud, nil, mockTagGenerator{}, Options{ValidationZoneID: "zone"})
+	certificateArns, err := controller.Reconcile(ctx, ingress)
+	assert.Nil(t, certificateArns)
+	assert.Equal(t, errors.New("waiting for certificates of hosts a.example.com,b.example.com to be issued by ACM after DNS validation in zone"), err)
+	cloud.AssertExpectations(t)
+}
+
+func TestDefaultController_GC(t *testing.T) {
+	ctx := context.Background()
+	cloud := &mocks.CloudAPI{}
+	cloud.On("GetResourcesByFilters", ownedTagFilters, aws.ResourceTypeEnumACMCertificate).Return([]string{"cert-a", "cert-b"}, nil)
+	cloud.On("DeleteCertificateWithContext", ctx, &acm.DeleteCertificateInput{CertificateArn: aws.String("cert-b")}).
+		Return(&acm.DeleteCertificateOutput{}, nil)
+
+	controller := NewController(cloud, nil, mockTagGenerator{}, Options{PrivateCAArn: "ca"})
+	err := controller.GC(ctx, types.NamespacedName{Namespace: "namespace", Name: "ingress"}, []string{"cert-a"})
+	assert.NoError(t, err)
+	cloud.AssertExpectations(t)
+}
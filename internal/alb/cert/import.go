@@ -0,0 +1,128 @@
+package cert
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// tagKeyTLSSecret is the tag of imported certificates naming the TLS secret they're imported from.
+	tagKeyTLSSecret = "alb.ingress.kubernetes.io/tls-secret"
+	// tagKeyTLSCertificateHash is the tag of imported certificates holding the hash of the imported tls.crt,
+	// so that certificates are only re-imported when the TLS secret is renewed.
+	tagKeyTLSCertificateHash = "alb.ingress.kubernetes.io/tls-certificate-hash"
+)
+
+// importTLSSecrets imports the TLS secrets referenced by ingress into ACM, and returns the ARNs of imported certificates.
+func (c *defaultController) importTLSSecrets(ctx context.Context, ingress *extensions.Ingress) ([]string, error) {
+	seen := sets.NewString()
+	var certArns []string
+	for _, tls := range ingress.Spec.TLS {
+		if tls.SecretName == "" || seen.Has(tls.SecretName) {
+			continue
+		}
+		seen.Insert(tls.SecretName)
+		certArn, err := c.importTLSSecret(ctx, ingress, tls.SecretName)
+		if err != nil {
+			return nil, err
+		}
+		certArns = append(certArns, certArn)
+	}
+	return certArns, nil
+}
+
+// importTLSSecret imports TLS secret secretName of ingress, unless the certificate imported from it is up to date.
+// Renewed secrets are re-imported into the same certificate, so listeners keep using its ARN.
+func (c *defaultController) importTLSSecret(ctx context.Context, ingress *extensions.Ingress, secretName string) (string, error) {
+	secret := &corev1.Secret{}
+	if err := c.client.Get(ctx, types.NamespacedName{Namespace: ingress.Namespace, Name: secretName}, secret); err != nil {
+		return "", fmt.Errorf("failed to get TLS secret %v due to %v", secretName, err)
+	}
+	certPEM, chainPEM, err := splitCertificateChain(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %v of TLS secret %v due to %v", corev1.TLSCertKey, secretName, err)
+	}
+	hash := certificateHash(secret.Data[corev1.TLSCertKey])
+
+	tags := c.tagGen.TagCertificate(ingress.Namespace, ingress.Name)
+	tags[tagKeyTLSSecret] = secretName
+	tagFilters := make(map[string][]string)
+	for k, v := range tags {
+		tagFilters[k] = []string{v}
+	}
+	certArns, err := c.cloud.GetResourcesByFilters(tagFilters, aws.ResourceTypeEnumACMCertificate)
+	if err != nil {
+		return "", fmt.Errorf("failed to get certificates due to %v", err)
+	}
+
+	input := &acm.ImportCertificateInput{
+		Certificate:      certPEM,
+		CertificateChain: chainPEM,
+		PrivateKey:       secret.Data[corev1.TLSPrivateKeyKey],
+	}
+	if len(certArns) != 0 {
+		certArn := certArns[0]
+		resp, err := c.cloud.ListTagsForCertificateWithContext(ctx, &acm.ListTagsForCertificateInput{
+			CertificateArn: aws.String(certArn),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to get tags of certificate %v due to %v", certArn, err)
+		}
+		for _, tag := range resp.Tags {
+			if aws.StringValue(tag.Key) == tagKeyTLSCertificateHash && aws.StringValue(tag.Value) == hash {
+				return certArn, nil
+			}
+		}
+		input.CertificateArn = aws.String(certArn)
+	}
+
+	albctx.GetLogger(ctx).Infof("importing certificate from TLS secret %v", secretName)
+	resp, err := c.cloud.ImportCertificateWithContext(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("failed to import certificate from TLS secret %v due to %v", secretName, err)
+	}
+	certArn := aws.StringValue(resp.CertificateArn)
+
+	tags[tagKeyTLSCertificateHash] = hash
+	if err := c.tagCertificate(ctx, certArn, tags); err != nil {
+		return "", err
+	}
+	if input.CertificateArn != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "certificate %v re-imported from TLS secret %v", certArn, secretName)
+	} else {
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "CREATE", "certificate %v imported from TLS secret %v", certArn, secretName)
+	}
+	return certArn, nil
+}
+
+// splitCertificateChain splits the PEM encoded tls.crt of TLS secrets into the leaf certificate and its chain, which may be empty.
+func splitCertificateChain(data []byte) ([]byte, []byte, error) {
+	block, rest := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, nil, errors.New("no PEM encoded certificate found")
+	}
+	var chain []byte
+	if rest = bytes.TrimSpace(rest); len(rest) != 0 {
+		chain = rest
+	}
+	return pem.EncodeToMemory(block), chain, nil
+}
+
+// certificateHash returns the hash of the PEM encoded tls.crt of TLS secrets.
+func certificateHash(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,142 @@
+package cert
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	leafPEM  = "-----BEGIN CERTIFICATE-----\nbGVhZg==\n-----END CERTIFICATE-----\n"
+	chainPEM = "-----BEGIN CERTIFICATE-----\nY2hhaW4=\n-----END CERTIFICATE-----\n"
+)
+
+func Test_splitCertificateChain(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		data          string
+		expectedCert  []byte
+		expectedChain []byte
+		expectErr     bool
+	}{
+		{
+			name:         "leaf certificate only",
+			data:         leafPEM,
+			expectedCert: []byte(leafPEM),
+		},
+		{
+			name:          "leaf certificate with chain",
+			data:          leafPEM + chainPEM,
+			expectedCert:  []byte(leafPEM),
+			expectedChain: []byte(chainPEM[:len(chainPEM)-1]),
+		},
+		{
+			name:      "no certificate",
+			data:      "not a certificate",
+			expectErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cert, chain, err := splitCertificateChain([]byte(tc.data))
+			assert.Equal(t, tc.expectedCert, cert)
+			assert.Equal(t, tc.expectedChain, chain)
+			assert.Equal(t, tc.expectErr, err != nil)
+		})
+	}
+}
+
+func TestDefaultController_Reconcile_ImportTLSSecrets(t *testing.T) {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"},
+		Spec: extensions.IngressSpec{
+			TLS: []extensions.IngressTLS{
+				{Hosts: []string{"a.example.com"}, SecretName: "secret"},
+				{Hosts: []string{"b.example.com"}, SecretName: "secret"},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "secret"},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       []byte(leafPEM + chainPEM),
+			corev1.TLSPrivateKeyKey: []byte("key"),
+		},
+	}
+	secretTagFilters := map[string][]string{
+		"kubernetes.io/namespace":    {"namespace"},
+		"kubernetes.io/ingress-name": {"ingress"},
+		tagKeyTLSSecret:              {"secret"},
+	}
+	hash := certificateHash(secret.Data[corev1.TLSCertKey])
+
+	for _, tc := range []struct {
+		name         string
+		ownedArns    []string
+		ownedHash    string
+		expectImport *acm.ImportCertificateInput
+	}{
+		{
+			name: "secret imported",
+			expectImport: &acm.ImportCertificateInput{
+				Certificate:      []byte(leafPEM),
+				CertificateChain: []byte(chainPEM[:len(chainPEM)-1]),
+				PrivateKey:       []byte("key"),
+			},
+		},
+		{
+			name:      "secret already imported",
+			ownedArns: []string{"cert"},
+			ownedHash: hash,
+		},
+		{
+			name:      "secret renewed",
+			ownedArns: []string{"cert"},
+			ownedHash: "outdated",
+			expectImport: &acm.ImportCertificateInput{
+				CertificateArn:   aws.String("cert"),
+				Certificate:      []byte(leafPEM),
+				CertificateChain: []byte(chainPEM[:len(chainPEM)-1]),
+				PrivateKey:       []byte("key"),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetResourcesByFilters", secretTagFilters, aws.ResourceTypeEnumACMCertificate).Return(tc.ownedArns, nil)
+			if len(tc.ownedArns) != 0 {
+				cloud.On("ListTagsForCertificateWithContext", ctx, &acm.ListTagsForCertificateInput{CertificateArn: aws.String("cert")}).
+					Return(&acm.ListTagsForCertificateOutput{Tags: []*acm.Tag{
+						{Key: aws.String(tagKeyTLSCertificateHash), Value: aws.String(tc.ownedHash)},
+					}}, nil)
+			}
+			if tc.expectImport != nil {
+				cloud.On("ImportCertificateWithContext", ctx, tc.expectImport).
+					Return(&acm.ImportCertificateOutput{CertificateArn: aws.String("cert")}, nil)
+				cloud.On("AddTagsToCertificateWithContext", ctx, &acm.AddTagsToCertificateInput{
+					CertificateArn: aws.String("cert"),
+					Tags: []*acm.Tag{
+						{Key: aws.String(tagKeyTLSCertificateHash), Value: aws.String(hash)},
+						{Key: aws.String(tagKeyTLSSecret), Value: aws.String("secret")},
+						{Key: aws.String("kubernetes.io/ingress-name"), Value: aws.String("ingress")},
+						{Key: aws.String("kubernetes.io/namespace"), Value: aws.String("namespace")},
+					},
+				}).Return(&acm.AddTagsToCertificateOutput{}, nil)
+			}
+
+			controller := NewController(cloud, fake.NewFakeClient(secret), mockTagGenerator{}, Options{ImportTLSSecrets: true})
+			certificateArns, err := controller.Reconcile(ctx, ingress)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"cert"}, certificateArns)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
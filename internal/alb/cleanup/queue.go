@@ -0,0 +1,406 @@
+package cleanup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Kinds of AWS resources whose deletion can be deferred.
+const (
+	KindSecurityGroup = "securityGroup"
+	KindTargetGroup   = "targetGroup"
+)
+
+var kinds = []string{KindSecurityGroup, KindTargetGroup}
+
+const (
+	// retryInterval is the interval to check for deferred deletions due for retry.
+	retryInterval = 15 * time.Second
+
+	// initialBackoff and maxBackoff bound the exponential delay between retries of a deferred deletion.
+	initialBackoff = 30 * time.Second
+	maxBackoff     = 10 * time.Minute
+
+	// entriesDataKey is the key inside configMap data that holds the serialized deferred deletions.
+	entriesDataKey = "entries.json"
+)
+
+// Entry is an AWS resource whose deletion is deferred.
+type Entry struct {
+	Kind string
+	ID   string
+
+	// Since is the time of the first failed deletion attempt.
+	Since time.Time
+	// Attempts is the number of failed deletion attempts.
+	Attempts int
+	// NextAttempt is the time deletion is retried next.
+	NextAttempt time.Time
+}
+
+// Queue deletes AWS resources, deferring deletions blocked by dependencies so that they don't fail the reconcile.
+// The network interfaces of an ALB are detached asynchronously after it's deleted, and targetGroups are in use for a while
+// after the rules referencing them are deleted, so such resources can't be deleted until minutes later.
+// Deferred deletions are retried with exponential backoff, and persisted in a configMap so that they survive controller restarts.
+type Queue interface {
+	// Delete deletes resource, or defers its deletion if it's still in use within the retry window.
+	Delete(ctx context.Context, kind string, id string) error
+
+	// Defer defers the deletion of resource which failed as it's still in use.
+	Defer(ctx context.Context, kind string, id string)
+
+	// Cancel cancels the deferred deletion of resource, for resources which are in use again.
+	Cancel(ctx context.Context, kind string, id string)
+
+	// Start retries deferred deletions until stop is closed, implements manager.Runnable
+	Start(stop <-chan struct{}) error
+}
+
+// NewQueue constructs new Queue, which retries deletions blocked by dependencies for retryWindow.
+// Deferred deletions are persisted in configMap, they're only kept in memory if its name is empty.
+// client should read from the API server directly, so configMap is neither cached nor updated from stale reads.
+func NewQueue(cloud aws.CloudAPI, client client.Client, configMapKey types.NamespacedName, mc metric.Collector, retryWindow time.Duration) Queue {
+	return &queue{
+		cloud:        cloud,
+		client:       client,
+		configMapKey: configMapKey,
+		mc:           mc,
+		retryWindow:  retryWindow,
+		entries:      make(map[string]*Entry),
+	}
+}
+
+type queue struct {
+	cloud        aws.CloudAPI
+	client       client.Client
+	configMapKey types.NamespacedName
+	mc           metric.Collector
+	retryWindow  time.Duration
+
+	mutex sync.Mutex
+	// entries contains the deferred deletions, by kind and ID.
+	entries map[string]*Entry
+	// loaded is whether the deferred deletions persisted in configMap are loaded, configMap isn't updated before that.
+	loaded bool
+	// version counts the changes of entries to persist.
+	version int
+
+	// saveMutex serializes the updates of configMap, which are made without holding mutex.
+	saveMutex sync.Mutex
+	// savedVersion is the version of entries last saved in configMap, so older versions saved late are skipped.
+	savedVersion int
+}
+
+// pendingSave is a version of the deferred deletions to save in configMap.
+type pendingSave struct {
+	version int
+	payload string
+}
+
+func (q *queue) Delete(ctx context.Context, kind string, id string) error {
+	err := q.deleteResource(ctx, kind, id)
+	deferred, save := q.recordAttempt(ctx, kind, id, err)
+	q.persist(ctx, save)
+	if deferred || isNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// recordAttempt updates the deferred deletion of resource after a deletion attempt which failed with err if not nil,
+// and returns whether its deletion is deferred.
+func (q *queue) recordAttempt(ctx context.Context, kind string, id string, err error) (bool, *pendingSave) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	key := entryKey(kind, id)
+	entry, ok := q.entries[key]
+	now := time.Now()
+	if !ok {
+		entry = &Entry{Kind: kind, ID: id, Since: now}
+	}
+	if err == nil || !isInUse(err) || now.Sub(entry.Since) >= q.retryWindow {
+		if !ok {
+			return false, nil
+		}
+		delete(q.entries, key)
+		return false, q.changed(ctx)
+	}
+	albctx.GetLogger(ctx).Infof("deferring deletion of %v %v which is still in use", kind, id)
+	entry.Attempts++
+	entry.NextAttempt = now.Add(backoff(entry.Attempts))
+	q.entries[key] = entry
+	return true, q.changed(ctx)
+}
+
+func (q *queue) Defer(ctx context.Context, kind string, id string) {
+	if q.retryWindow <= 0 {
+		return
+	}
+	q.mutex.Lock()
+	key := entryKey(kind, id)
+	if _, ok := q.entries[key]; ok {
+		q.mutex.Unlock()
+		return
+	}
+	now := time.Now()
+	q.entries[key] = &Entry{Kind: kind, ID: id, Since: now, Attempts: 1, NextAttempt: now.Add(backoff(1))}
+	save := q.changed(ctx)
+	q.mutex.Unlock()
+	q.persist(ctx, save)
+}
+
+func (q *queue) Cancel(ctx context.Context, kind string, id string) {
+	q.mutex.Lock()
+	key := entryKey(kind, id)
+	if _, ok := q.entries[key]; !ok {
+		q.mutex.Unlock()
+		return
+	}
+	delete(q.entries, key)
+	save := q.changed(ctx)
+	q.mutex.Unlock()
+	q.persist(ctx, save)
+}
+
+func (q *queue) Start(stop <-chan struct{}) error {
+	ctx := albctx.SetLogger(context.Background(), log.New("deletion-queue"))
+	if err := q.load(ctx); err != nil {
+		albctx.GetLogger(ctx).Errorf("failed to load deferred deletions due to %v", err)
+	}
+	wait.Until(q.retry, retryInterval, stop)
+	return nil
+}
+
+// retry retries the deferred deletions which are due, giving up on resources still in use after retry window.
+func (q *queue) retry() {
+	ctx := albctx.SetLogger(context.Background(), log.New("deletion-queue"))
+	now := time.Now()
+	q.mutex.Lock()
+	var due []Entry
+	for _, entry := range q.entries {
+		if !entry.NextAttempt.After(now) {
+			due = append(due, *entry)
+		}
+	}
+	q.mutex.Unlock()
+	if len(due) == 0 {
+		return
+	}
+
+	for _, entry := range due {
+		key := entryKey(entry.Kind, entry.ID)
+		if !q.isPending(key) {
+			continue
+		}
+		err := q.deleteResource(ctx, entry.Kind, entry.ID)
+
+		q.mutex.Lock()
+		if current, ok := q.entries[key]; ok {
+			switch {
+			case err != nil && isInUse(err) && time.Since(current.Since) < q.retryWindow:
+				current.Attempts++
+				current.NextAttempt = time.Now().Add(backoff(current.Attempts))
+			case err != nil && !isNotFound(err):
+				albctx.GetLogger(ctx).Errorf("failed to delete %v %v after %v attempts due to %v", entry.Kind, entry.ID, current.Attempts+1, err)
+				delete(q.entries, key)
+			default:
+				albctx.GetLogger(ctx).Infof("deleted %v %v after %v attempts", entry.Kind, entry.ID, current.Attempts+1)
+				delete(q.entries, key)
+			}
+		}
+		q.mutex.Unlock()
+	}
+
+	q.mutex.Lock()
+	save := q.changed(ctx)
+	q.mutex.Unlock()
+	q.persist(ctx, save)
+}
+
+func (q *queue) isPending(key string) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	_, ok := q.entries[key]
+	return ok
+}
+
+func (q *queue) deleteResource(ctx context.Context, kind string, id string) error {
+	switch kind {
+	case KindSecurityGroup:
+		return q.cloud.DeleteSecurityGroupByID(id)
+	case KindTargetGroup:
+		return q.cloud.DeleteTargetGroupByArn(ctx, id)
+	}
+	return fmt.Errorf("unknown resource kind %v", kind)
+}
+
+// load restores the deferred deletions persisted in configMap, in addition to those deferred since controller started.
+func (q *queue) load(ctx context.Context) error {
+	var entries []*Entry
+	if q.configMapKey.Name != "" {
+		configMap := &corev1.ConfigMap{}
+		if err := q.client.Get(ctx, q.configMapKey, configMap); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get configMap %v due to %v", q.configMapKey, err)
+		}
+		if payload, ok := configMap.Data[entriesDataKey]; ok {
+			if err := json.Unmarshal([]byte(payload), &entries); err != nil {
+				return fmt.Errorf("failed to deserialize deferred deletions in configMap %v due to %v", q.configMapKey, err)
+			}
+		}
+	}
+
+	q.mutex.Lock()
+	q.loaded = true
+	for _, entry := range entries {
+		key := entryKey(entry.Kind, entry.ID)
+		if _, ok := q.entries[key]; !ok {
+			q.entries[key] = entry
+		}
+	}
+	save := q.changed(ctx)
+	q.mutex.Unlock()
+	q.persist(ctx, save)
+	return nil
+}
+
+// changed updates metrics after entries changed, and returns the new version of entries to persist, if they're persisted.
+// It must be called with mutex held.
+func (q *queue) changed(ctx context.Context) *pendingSave {
+	now := time.Now()
+	for _, kind := range kinds {
+		depth := 0
+		oldestAge := 0.0
+		for _, entry := range q.entries {
+			if entry.Kind != kind {
+				continue
+			}
+			depth++
+			if age := now.Sub(entry.Since).Seconds(); age > oldestAge {
+				oldestAge = age
+			}
+		}
+		q.mc.SetDeletionQueue(kind, depth, oldestAge)
+	}
+
+	if q.configMapKey.Name == "" || !q.loaded {
+		return nil
+	}
+	entries := make([]*Entry, 0, len(q.entries))
+	for _, entry := range q.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entryKey(entries[i].Kind, entries[i].ID) < entryKey(entries[j].Kind, entries[j].ID)
+	})
+	payload, err := json.Marshal(entries)
+	if err != nil {
+		albctx.GetLogger(ctx).Errorf("failed to serialize deferred deletions due to %v", err)
+		return nil
+	}
+	q.version++
+	return &pendingSave{version: q.version, payload: string(payload)}
+}
+
+// persist saves the deferred deletions of save in configMap, unless a later version was saved already.
+// It must be called without mutex held. Failures are only logged, since the deletions are still retried as long as controller keeps running.
+func (q *queue) persist(ctx context.Context, save *pendingSave) {
+	if save == nil {
+		return
+	}
+	q.saveMutex.Lock()
+	defer q.saveMutex.Unlock()
+	if save.version <= q.savedVersion {
+		return
+	}
+	if err := q.save(ctx, save.payload); err != nil {
+		albctx.GetLogger(ctx).Errorf("failed to persist deferred deletions due to %v", err)
+		return
+	}
+	q.savedVersion = save.version
+}
+
+func (q *queue) save(ctx context.Context, payload string) error {
+	configMap := &corev1.ConfigMap{}
+	if err := q.client.Get(ctx, q.configMapKey, configMap); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get configMap %v due to %v", q.configMapKey, err)
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: q.configMapKey.Namespace,
+				Name:      q.configMapKey.Name,
+			},
+			Data: map[string]string{
+				entriesDataKey: payload,
+			},
+		}
+		if err := q.client.Create(ctx, configMap); err != nil {
+			return fmt.Errorf("failed to create configMap %v due to %v", q.configMapKey, err)
+		}
+		return nil
+	}
+
+	if configMap.Data[entriesDataKey] == payload {
+		return nil
+	}
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+	configMap.Data[entriesDataKey] = payload
+	if err := q.client.Update(ctx, configMap); err != nil {
+		return fmt.Errorf("failed to update configMap %v due to %v", q.configMapKey, err)
+	}
+	return nil
+}
+
+// backoff returns the delay before retrying a deletion that failed attempts times, which doubles per attempt up to maxBackoff.
+func backoff(attempts int) time.Duration {
+	delay := initialBackoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		return maxBackoff
+	}
+	return delay
+}
+
+func entryKey(kind string, id string) string {
+	return kind + "/" + id
+}
+
+// isInUse returns true if err is caused by deleting a resource which is still in use.
+func isInUse(err error) bool {
+	if aws.IsDependencyViolation(err) {
+		return true
+	}
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code() == elbv2.ErrCodeResourceInUseException
+	}
+	return false
+}
+
+// isNotFound returns true if err is caused by deleting a resource which is already deleted.
+func isNotFound(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code() == "InvalidGroup.NotFound" || awsErr.Code() == elbv2.ErrCodeTargetGroupNotFoundException
+	}
+	return false
+}
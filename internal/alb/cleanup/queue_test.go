@@ -0,0 +1,175 @@
+package cleanup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var configMapKey = types.NamespacedName{Namespace: "kube-system", Name: "deletion-queue"}
+
+func persistedEntries(t *testing.T, q *queue) []*Entry {
+	configMap := &corev1.ConfigMap{}
+	assert.NoError(t, q.client.Get(context.Background(), configMapKey, configMap))
+	var entries []*Entry
+	assert.NoError(t, json.Unmarshal([]byte(configMap.Data[entriesDataKey]), &entries))
+	return entries
+}
+
+func TestQueue_Delete(t *testing.T) {
+	dependencyViolation := awserr.New("DependencyViolation", "resource sg-1 has a dependent object", nil)
+	for _, tc := range []struct {
+		name             string
+		retryWindow      time.Duration
+		pendingSince     time.Time
+		deleteErr        error
+		expectedErr      error
+		expectedAttempts int
+	}{
+		{
+			name:        "deletes securityGroup",
+			retryWindow: time.Minute,
+		},
+		{
+			name:        "ignores securityGroup already deleted",
+			retryWindow: time.Minute,
+			deleteErr:   awserr.New("InvalidGroup.NotFound", "not found", nil),
+		},
+		{
+			name:             "defers deletion of securityGroup in use",
+			retryWindow:      time.Minute,
+			deleteErr:        dependencyViolation,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "defers deletion of securityGroup in use again",
+			retryWindow:      time.Minute,
+			pendingSince:     time.Now().Add(-30 * time.Second),
+			deleteErr:        dependencyViolation,
+			expectedAttempts: 2,
+		},
+		{
+			name:         "fails deletion of securityGroup in use after retry window",
+			retryWindow:  time.Minute,
+			pendingSince: time.Now().Add(-2 * time.Minute),
+			deleteErr:    dependencyViolation,
+			expectedErr:  dependencyViolation,
+		},
+		{
+			name:        "fails deletion of securityGroup in use without retry window",
+			deleteErr:   dependencyViolation,
+			expectedErr: dependencyViolation,
+		},
+		{
+			name:        "fails deletion on other errors",
+			retryWindow: time.Minute,
+			deleteErr:   errors.New("some error"),
+			expectedErr: errors.New("some error"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cloud := &mocks.CloudAPI{}
+			cloud.On("DeleteSecurityGroupByID", "sg-1").Return(tc.deleteErr)
+			q := NewQueue(cloud, fake.NewFakeClient(), configMapKey, metric.DummyCollector{}, tc.retryWindow).(*queue)
+			assert.NoError(t, q.load(context.Background()))
+			if !tc.pendingSince.IsZero() {
+				q.entries["securityGroup/sg-1"] = &Entry{Kind: KindSecurityGroup, ID: "sg-1", Since: tc.pendingSince, Attempts: 1}
+			}
+
+			err := q.Delete(context.Background(), KindSecurityGroup, "sg-1")
+			assert.Equal(t, tc.expectedErr, err)
+			if tc.expectedAttempts == 0 {
+				assert.Empty(t, q.entries)
+			} else {
+				entries := persistedEntries(t, q)
+				assert.Len(t, entries, 1)
+				assert.Equal(t, tc.expectedAttempts, entries[0].Attempts)
+			}
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+func TestQueue_Retry(t *testing.T) {
+	inUse := awserr.New(elbv2.ErrCodeResourceInUseException, "in use", nil)
+	cloud := &mocks.CloudAPI{}
+	cloud.On("DeleteSecurityGroupByID", "sg-deleted").Return(nil)
+	cloud.On("DeleteTargetGroupByArn", mock.Anything, "tg-in-use").Return(inUse)
+	cloud.On("DeleteTargetGroupByArn", mock.Anything, "tg-expired").Return(inUse)
+	q := NewQueue(cloud, fake.NewFakeClient(), configMapKey, metric.DummyCollector{}, time.Hour).(*queue)
+	assert.NoError(t, q.load(context.Background()))
+	now := time.Now()
+	for _, entry := range []*Entry{
+		{Kind: KindSecurityGroup, ID: "sg-deleted", Since: now, Attempts: 1, NextAttempt: now},
+		{Kind: KindSecurityGroup, ID: "sg-not-due", Since: now, Attempts: 1, NextAttempt: now.Add(time.Minute)},
+		{Kind: KindTargetGroup, ID: "tg-in-use", Since: now, Attempts: 3, NextAttempt: now},
+		{Kind: KindTargetGroup, ID: "tg-expired", Since: now.Add(-2 * time.Hour), Attempts: 10, NextAttempt: now},
+	} {
+		q.entries[entryKey(entry.Kind, entry.ID)] = entry
+	}
+
+	q.retry()
+	entries := persistedEntries(t, q)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "sg-not-due", entries[0].ID)
+	assert.Equal(t, "tg-in-use", entries[1].ID)
+	assert.Equal(t, 4, entries[1].Attempts)
+	assert.True(t, entries[1].NextAttempt.After(now.Add(3*time.Minute)))
+	cloud.AssertExpectations(t)
+}
+
+func TestQueue_Cancel(t *testing.T) {
+	ctx := context.Background()
+	q := NewQueue(&mocks.CloudAPI{}, fake.NewFakeClient(), configMapKey, metric.DummyCollector{}, time.Hour).(*queue)
+	assert.NoError(t, q.load(ctx))
+	q.Defer(ctx, KindSecurityGroup, "sg-1")
+	q.Defer(ctx, KindTargetGroup, "tgArn")
+
+	q.Cancel(ctx, KindSecurityGroup, "sg-1")
+	entries := persistedEntries(t, q)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "tgArn", entries[0].ID)
+}
+
+func TestQueue_Load(t *testing.T) {
+	since := time.Now().Add(-time.Minute).UTC().Round(time.Second)
+	payload, _ := json.Marshal([]*Entry{{Kind: KindTargetGroup, ID: "tgArn", Since: since, Attempts: 2, NextAttempt: since}})
+	client := fake.NewFakeClient(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: configMapKey.Namespace, Name: configMapKey.Name},
+		Data:       map[string]string{entriesDataKey: string(payload)},
+	})
+	q := NewQueue(&mocks.CloudAPI{}, client, configMapKey, metric.DummyCollector{}, time.Hour).(*queue)
+	q.Defer(context.Background(), KindSecurityGroup, "sg-1")
+	assert.Equal(t, "tgArn", persistedEntries(t, q)[0].ID)
+
+	assert.NoError(t, q.load(context.Background()))
+	assert.Equal(t, 2, q.entries["targetGroup/tgArn"].Attempts)
+	assert.True(t, since.Equal(q.entries["targetGroup/tgArn"].Since))
+	assert.Len(t, persistedEntries(t, q), 2)
+}
+
+func Test_backoff(t *testing.T) {
+	for attempts, expected := range map[int]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		3:  2 * time.Minute,
+		5:  8 * time.Minute,
+		6:  10 * time.Minute,
+		50: 10 * time.Minute,
+	} {
+		assert.Equal(t, expected, backoff(attempts))
+	}
+}
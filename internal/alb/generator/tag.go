@@ -1,7 +1,9 @@
 package generator
 
 import (
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/cert"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/ls"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/sg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
 )
@@ -18,6 +20,8 @@ const (
 var _ tg.TagGenerator = (*TagGenerator)(nil)
 var _ lb.TagGenerator = (*TagGenerator)(nil)
 var _ sg.TagGenerator = (*TagGenerator)(nil)
+var _ ls.TagGenerator = (*TagGenerator)(nil)
+var _ cert.TagGenerator = (*TagGenerator)(nil)
 
 type TagGenerator struct {
 	ClusterName string
@@ -32,6 +36,14 @@ func (gen *TagGenerator) TagTGGroup(namespace string, ingressName string) map[st
 	return gen.tagIngressResources(namespace, ingressName)
 }
 
+func (gen *TagGenerator) TagListener(namespace string, ingressName string) map[string]string {
+	return gen.tagIngressResources(namespace, ingressName)
+}
+
+func (gen *TagGenerator) TagCertificate(namespace string, ingressName string) map[string]string {
+	return gen.tagIngressResources(namespace, ingressName)
+}
+
 func (gen *TagGenerator) TagTG(serviceName string, servicePort string) map[string]string {
 	return map[string]string{
 		TagKeyServiceName: serviceName,
@@ -21,6 +21,8 @@ func Test_TagIngress(t *testing.T) {
 	}
 	assert.Equal(t, gen.TagLB("namespace", "ingress"), expected)
 	assert.Equal(t, gen.TagTGGroup("namespace", "ingress"), expected)
+	assert.Equal(t, gen.TagListener("namespace", "ingress"), expected)
+	assert.Equal(t, gen.TagCertificate("namespace", "ingress"), expected)
 }
 
 func Test_TagTG(t *testing.T) {
@@ -0,0 +1,268 @@
+package lb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// accessLogsPolicySidPrefix is the prefix of Sid of the bucket policy statements that allow ELB to deliver access logs.
+	accessLogsPolicySidPrefix = "ALBIngressAccessLogs"
+
+	// accessLogsLifecycleRuleIDPrefix is the prefix of ID of the lifecycle rules that expire access logs.
+	accessLogsLifecycleRuleIDPrefix = "alb-ingress-access-logs/"
+
+	// S3 error codes that are not modeled by the SDK.
+	s3ErrCodeNotFound                     = "NotFound"
+	s3ErrCodeNoSuchBucketPolicy           = "NoSuchBucketPolicy"
+	s3ErrCodeNoSuchLifecycleConfiguration = "NoSuchLifecycleConfiguration"
+	s3ErrCodeNoEncryptionConfiguration    = "ServerSideEncryptionConfigurationNotFoundError"
+)
+
+var nonAlphanumeric = regexp.MustCompile("[^a-zA-Z0-9]")
+
+// AccessLogsBucketController manages the S3 buckets that access logs of LoadBalancers are delivered to.
+type AccessLogsBucketController interface {
+	// Reconcile ensures bucket exists with default encryption, a bucket policy allowing ELB to deliver access logs under prefix,
+	// and a lifecycle rule expiring them after expirationDays if it's non-zero.
+	Reconcile(ctx context.Context, bucket string, prefix string, expirationDays int64) error
+}
+
+// NewAccessLogsBucketController constructs a new access logs bucket controller
+func NewAccessLogsBucketController(cloud aws.CloudAPI) AccessLogsBucketController {
+	return &accessLogsBucketController{
+		cloud: cloud,
+	}
+}
+
+type accessLogsBucketController struct {
+	cloud aws.CloudAPI
+}
+
+func (c *accessLogsBucketController) Reconcile(ctx context.Context, bucket string, prefix string, expirationDays int64) error {
+	if err := c.ensureBucket(ctx, bucket); err != nil {
+		return err
+	}
+	if err := c.reconcileEncryption(ctx, bucket); err != nil {
+		return err
+	}
+	if err := c.reconcilePolicy(ctx, bucket, prefix); err != nil {
+		return err
+	}
+	if expirationDays != 0 {
+		if err := c.reconcileLifecycle(ctx, bucket, prefix, expirationDays); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *accessLogsBucketController) ensureBucket(ctx context.Context, bucket string) error {
+	_, err := c.cloud.HeadBucketWithContext(ctx, &s3.HeadBucketInput{Bucket: aws.String(bucket)})
+	if err == nil {
+		return nil
+	}
+	if !isAWSErrorCode(err, s3ErrCodeNotFound, s3.ErrCodeNoSuchBucket) {
+		return fmt.Errorf("failed to access bucket %v due to %v", bucket, err)
+	}
+	albctx.GetLogger(ctx).Infof("creating access logs bucket %v", bucket)
+	if err := c.cloud.CreateBucket(ctx, bucket); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to create access logs bucket %v due to %v", bucket, err)
+		return err
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "CREATE", "access logs bucket %v created", bucket)
+	return nil
+}
+
+// reconcileEncryption ensures bucket is encrypted by default. ELB only supports delivering access logs to buckets encrypted with SSE-S3.
+func (c *accessLogsBucketController) reconcileEncryption(ctx context.Context, bucket string) error {
+	resp, err := c.cloud.GetBucketEncryptionWithContext(ctx, &s3.GetBucketEncryptionInput{Bucket: aws.String(bucket)})
+	if err != nil && !isAWSErrorCode(err, s3ErrCodeNoEncryptionConfiguration) {
+		return fmt.Errorf("failed to get encryption of bucket %v due to %v", bucket, err)
+	}
+	if err == nil && resp.ServerSideEncryptionConfiguration != nil && len(resp.ServerSideEncryptionConfiguration.Rules) != 0 {
+		for _, rule := range resp.ServerSideEncryptionConfiguration.Rules {
+			if rule.ApplyServerSideEncryptionByDefault == nil {
+				continue
+			}
+			if algorithm := aws.StringValue(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm); algorithm != s3.ServerSideEncryptionAes256 {
+				return fmt.Errorf("access logs bucket %v must be encrypted with %v rather than %v", bucket, s3.ServerSideEncryptionAes256, algorithm)
+			}
+		}
+		return nil
+	}
+
+	albctx.GetLogger(ctx).Infof("enabling default encryption of access logs bucket %v", bucket)
+	if _, err := c.cloud.PutBucketEncryptionWithContext(ctx, &s3.PutBucketEncryptionInput{
+		Bucket: aws.String(bucket),
+		ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+			Rules: []*s3.ServerSideEncryptionRule{
+				{
+					ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{
+						SSEAlgorithm: aws.String(s3.ServerSideEncryptionAes256),
+					},
+				},
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to put encryption of bucket %v due to %v", bucket, err)
+	}
+	return nil
+}
+
+// bucketPolicy is the document of an S3 bucket policy, statements are kept as is so that other statements are preserved.
+type bucketPolicy struct {
+	Version   string                   `json:"Version"`
+	ID        string                   `json:"Id,omitempty"`
+	Statement []map[string]interface{} `json:"Statement"`
+}
+
+// reconcilePolicy ensures the policy of bucket allows ELB to deliver access logs under prefix, other statements are kept unchanged.
+func (c *accessLogsBucketController) reconcilePolicy(ctx context.Context, bucket string, prefix string) error {
+	policy := bucketPolicy{Version: "2012-10-17"}
+	resp, err := c.cloud.GetBucketPolicyWithContext(ctx, &s3.GetBucketPolicyInput{Bucket: aws.String(bucket)})
+	if err != nil && !isAWSErrorCode(err, s3ErrCodeNoSuchBucketPolicy) {
+		return fmt.Errorf("failed to get policy of bucket %v due to %v", bucket, err)
+	}
+	if err == nil {
+		if err := json.Unmarshal([]byte(aws.StringValue(resp.Policy)), &policy); err != nil {
+			return fmt.Errorf("failed to parse policy of bucket %v due to %v", bucket, err)
+		}
+	}
+
+	desired, err := c.buildPolicyStatement(bucket, prefix)
+	if err != nil {
+		return err
+	}
+	sid := desired["Sid"]
+	found := false
+	for i, statement := range policy.Statement {
+		if statement["Sid"] != sid {
+			continue
+		}
+		if reflect.DeepEqual(statement, desired) {
+			return nil
+		}
+		policy.Statement[i] = desired
+		found = true
+	}
+	if !found {
+		policy.Statement = append(policy.Statement, desired)
+	}
+
+	payload, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	albctx.GetLogger(ctx).Infof("allowing access logs delivery to bucket %v", bucket)
+	if _, err := c.cloud.PutBucketPolicyWithContext(ctx, &s3.PutBucketPolicyInput{
+		Bucket: aws.String(bucket),
+		Policy: aws.String(string(payload)),
+	}); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to put policy of access logs bucket %v due to %v", bucket, err)
+		return fmt.Errorf("failed to put policy of bucket %v due to %v", bucket, err)
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "access logs bucket %v policy modified", bucket)
+	return nil
+}
+
+// buildPolicyStatement builds the bucket policy statement that allows ELB to deliver access logs under prefix.
+// It's round-tripped through JSON so that it can be compared with statements of existing policy.
+func (c *accessLogsBucketController) buildPolicyStatement(bucket string, prefix string) (map[string]interface{}, error) {
+	payload, err := json.Marshal(map[string]interface{}{
+		"Sid":       accessLogsPolicySidPrefix + nonAlphanumeric.ReplaceAllString(prefix, ""),
+		"Effect":    "Allow",
+		"Principal": c.cloud.AccessLogsDeliveryPrincipal(),
+		"Action":    "s3:PutObject",
+		"Resource":  c.cloud.S3ObjectArn(bucket, accessLogsKeyPrefix(prefix)+"*"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var statement map[string]interface{}
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return nil, err
+	}
+	return statement, nil
+}
+
+// reconcileLifecycle ensures bucket has a lifecycle rule expiring access logs under prefix after expirationDays, other rules are kept unchanged.
+func (c *accessLogsBucketController) reconcileLifecycle(ctx context.Context, bucket string, prefix string, expirationDays int64) error {
+	var rules []*s3.LifecycleRule
+	resp, err := c.cloud.GetBucketLifecycleConfigurationWithContext(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: aws.String(bucket)})
+	if err != nil && !isAWSErrorCode(err, s3ErrCodeNoSuchLifecycleConfiguration) {
+		return fmt.Errorf("failed to get lifecycle configuration of bucket %v due to %v", bucket, err)
+	}
+	if err == nil {
+		rules = resp.Rules
+	}
+
+	keyPrefix := accessLogsKeyPrefix(prefix)
+	desired := &s3.LifecycleRule{
+		ID:         aws.String(accessLogsLifecycleRuleIDPrefix + keyPrefix),
+		Status:     aws.String(s3.ExpirationStatusEnabled),
+		Filter:     &s3.LifecycleRuleFilter{Prefix: aws.String(keyPrefix)},
+		Expiration: &s3.LifecycleExpiration{Days: aws.Int64(expirationDays)},
+	}
+	found := false
+	for i, rule := range rules {
+		if aws.StringValue(rule.ID) != aws.StringValue(desired.ID) {
+			continue
+		}
+		if lifecycleRuleUpToDate(rule, desired) {
+			return nil
+		}
+		rules[i] = desired
+		found = true
+	}
+	if !found {
+		rules = append(rules, desired)
+	}
+
+	albctx.GetLogger(ctx).Infof("expiring access logs in bucket %v after %v days", bucket, expirationDays)
+	if _, err := c.cloud.PutBucketLifecycleConfigurationWithContext(ctx, &s3.PutBucketLifecycleConfigurationInput{
+		Bucket:                 aws.String(bucket),
+		LifecycleConfiguration: &s3.BucketLifecycleConfiguration{Rules: rules},
+	}); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to put lifecycle configuration of access logs bucket %v due to %v", bucket, err)
+		return fmt.Errorf("failed to put lifecycle configuration of bucket %v due to %v", bucket, err)
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "access logs bucket %v expires logs after %v days", bucket, expirationDays)
+	return nil
+}
+
+func lifecycleRuleUpToDate(existing *s3.LifecycleRule, desired *s3.LifecycleRule) bool {
+	return aws.StringValue(existing.Status) == aws.StringValue(desired.Status) &&
+		existing.Filter != nil && aws.StringValue(existing.Filter.Prefix) == aws.StringValue(desired.Filter.Prefix) &&
+		existing.Expiration != nil && aws.Int64Value(existing.Expiration.Days) == aws.Int64Value(desired.Expiration.Days)
+}
+
+// accessLogsKeyPrefix returns the prefix of keys ELB delivers access logs to with the access_logs.s3.prefix attribute.
+func accessLogsKeyPrefix(prefix string) string {
+	if prefix == "" {
+		return "AWSLogs/"
+	}
+	return prefix + "/AWSLogs/"
+}
+
+func isAWSErrorCode(err error, codes ...string) bool {
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+	for _, code := range codes {
+		if awsErr.Code() == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package lb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const accessLogsPolicy = `{"Version":"2012-10-17","Statement":[{"Action":"s3:PutObject","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::797873946194:root"},"Resource":"arn:aws:s3:::logs/team/AWSLogs/*","Sid":"ALBIngressAccessLogsteam"}]}`
+
+func Test_accessLogsBucketController_Reconcile(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		headErr        error
+		encryption     *s3.GetBucketEncryptionOutput
+		encryptionErr  error
+		policy         *s3.GetBucketPolicyOutput
+		policyErr      error
+		lifecycle      *s3.GetBucketLifecycleConfigurationOutput
+		lifecycleErr   error
+		expectCreate   bool
+		expectPuts     bool
+		expectedPolicy string
+		expectedErr    string
+	}{
+		{
+			name:           "creates and configures missing bucket",
+			headErr:        awserr.New("NotFound", "Not Found", nil),
+			encryptionErr:  awserr.New("ServerSideEncryptionConfigurationNotFoundError", "", nil),
+			policyErr:      awserr.New("NoSuchBucketPolicy", "", nil),
+			lifecycleErr:   awserr.New("NoSuchLifecycleConfiguration", "", nil),
+			expectCreate:   true,
+			expectPuts:     true,
+			expectedPolicy: accessLogsPolicy,
+		},
+		{
+			name: "keeps other policy statements of existing bucket",
+			encryption: &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+				Rules: []*s3.ServerSideEncryptionRule{{ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{SSEAlgorithm: aws.String("AES256")}}},
+			}},
+			policy: &s3.GetBucketPolicyOutput{Policy: aws.String(`{"Version":"2012-10-17","Statement":[{"Sid":"Other","Effect":"Deny","Principal":"*","Action":"s3:DeleteObject","Resource":"arn:aws:s3:::logs/*"}]}`)},
+			lifecycle: &s3.GetBucketLifecycleConfigurationOutput{Rules: []*s3.LifecycleRule{
+				{
+					ID:         aws.String("alb-ingress-access-logs/team/AWSLogs/"),
+					Status:     aws.String("Enabled"),
+					Filter:     &s3.LifecycleRuleFilter{Prefix: aws.String("team/AWSLogs/")},
+					Expiration: &s3.LifecycleExpiration{Days: aws.Int64(90)},
+				},
+			}},
+			expectedPolicy: `{"Version":"2012-10-17","Statement":[{"Action":"s3:DeleteObject","Effect":"Deny","Principal":"*","Resource":"arn:aws:s3:::logs/*","Sid":"Other"},` +
+				`{"Action":"s3:PutObject","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::797873946194:root"},"Resource":"arn:aws:s3:::logs/team/AWSLogs/*","Sid":"ALBIngressAccessLogsteam"}]}`,
+		},
+		{
+			name: "rejects bucket encrypted with KMS",
+			encryption: &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+				Rules: []*s3.ServerSideEncryptionRule{{ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{SSEAlgorithm: aws.String("aws:kms")}}},
+			}},
+			expectedErr: "access logs bucket logs must be encrypted with AES256 rather than aws:kms",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cloud := &mocks.CloudAPI{}
+			cloud.On("HeadBucketWithContext", mock.Anything, &s3.HeadBucketInput{Bucket: aws.String("logs")}).Return(&s3.HeadBucketOutput{}, tc.headErr)
+			if tc.expectCreate {
+				cloud.On("CreateBucket", mock.Anything, "logs").Return(nil)
+			}
+			cloud.On("GetBucketEncryptionWithContext", mock.Anything, &s3.GetBucketEncryptionInput{Bucket: aws.String("logs")}).Return(tc.encryption, tc.encryptionErr)
+			cloud.On("GetBucketPolicyWithContext", mock.Anything, &s3.GetBucketPolicyInput{Bucket: aws.String("logs")}).Return(tc.policy, tc.policyErr)
+			cloud.On("GetBucketLifecycleConfigurationWithContext", mock.Anything, &s3.GetBucketLifecycleConfigurationInput{Bucket: aws.String("logs")}).Return(tc.lifecycle, tc.lifecycleErr)
+			cloud.On("AccessLogsDeliveryPrincipal").Return(map[string]string{"AWS": "arn:aws:iam::797873946194:root"})
+			cloud.On("S3ObjectArn", "logs", "team/AWSLogs/*").Return("arn:aws:s3:::logs/team/AWSLogs/*")
+			if tc.expectPuts {
+				cloud.On("PutBucketEncryptionWithContext", mock.Anything, mock.Anything).Return(&s3.PutBucketEncryptionOutput{}, nil)
+				cloud.On("PutBucketLifecycleConfigurationWithContext", mock.Anything, mock.MatchedBy(func(input *s3.PutBucketLifecycleConfigurationInput) bool {
+					return len(input.LifecycleConfiguration.Rules) == 1 && aws.Int64Value(input.LifecycleConfiguration.Rules[0].Expiration.Days) == 90
+				})).Return(&s3.PutBucketLifecycleConfigurationOutput{}, nil)
+			}
+			if tc.expectedPolicy != "" {
+				cloud.On("PutBucketPolicyWithContext", mock.Anything, &s3.PutBucketPolicyInput{Bucket: aws.String("logs"), Policy: aws.String(tc.expectedPolicy)}).
+					Return(&s3.PutBucketPolicyOutput{}, nil)
+			}
+
+			controller := NewAccessLogsBucketController(cloud)
+			err := controller.Reconcile(context.Background(), "logs", "team", 90)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
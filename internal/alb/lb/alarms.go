@@ -0,0 +1,232 @@
+package lb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// alarmPeriod is the period in seconds of metrics evaluated by alarms.
+	alarmPeriod = 60
+
+	// alarmEvaluationPeriods is the number of consecutive periods breaching the threshold before alarms fire.
+	alarmEvaluationPeriods = 5
+
+	alarmMetricNamespace = "AWS/ApplicationELB"
+)
+
+// AlarmsController manages the CloudWatch alarms of LoadBalancers.
+// Alarms are named after the LoadBalancer, so they can be found and cleaned up without extra state.
+type AlarmsController interface {
+	// Reconcile ensures the CloudWatch alarms of LoadBalancer match thresholds, alarms of other kinds or of other targetGroups are deleted.
+	Reconcile(ctx context.Context, lbName string, lbArn string, thresholds map[string]float64, topicArn *string, tgGroup tg.TargetGroupGroup) error
+
+	// Delete deletes all CloudWatch alarms of LoadBalancer.
+	Delete(ctx context.Context, lbName string) error
+}
+
+// NewAlarmsController constructs a new alarms controller
+func NewAlarmsController(cloud aws.CloudAPI) AlarmsController {
+	return &alarmsController{
+		cloud: cloud,
+	}
+}
+
+type alarmsController struct {
+	cloud aws.CloudAPI
+}
+
+func (c *alarmsController) Reconcile(ctx context.Context, lbName string, lbArn string, thresholds map[string]float64, topicArn *string, tgGroup tg.TargetGroupGroup) error {
+	desired, err := buildAlarms(lbName, lbArn, thresholds, topicArn, tgGroup)
+	if err != nil {
+		return err
+	}
+	current, err := c.cloud.GetAlarmsByPrefix(ctx, alarmNamePrefix(lbName))
+	if err != nil {
+		return fmt.Errorf("failed to get alarms due to %v", err)
+	}
+	currentByName := make(map[string]*cloudwatch.MetricAlarm, len(current))
+	for _, alarm := range current {
+		currentByName[aws.StringValue(alarm.AlarmName)] = alarm
+	}
+
+	for _, alarm := range desired {
+		name := aws.StringValue(alarm.AlarmName)
+		if existing, ok := currentByName[name]; ok {
+			delete(currentByName, name)
+			if alarmUpToDate(existing, alarm) {
+				continue
+			}
+		}
+		albctx.GetLogger(ctx).Infof("putting alarm %v", name)
+		if _, err := c.cloud.PutMetricAlarmWithContext(ctx, alarm); err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to put alarm %v due to %v", name, err)
+			return fmt.Errorf("failed to put alarm %v due to %v", name, err)
+		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "alarm %v put with threshold %v", name, aws.Float64Value(alarm.Threshold))
+	}
+
+	var unused []string
+	for name := range currentByName {
+		unused = append(unused, name)
+	}
+	sort.Strings(unused)
+	return c.deleteAlarms(ctx, unused)
+}
+
+func (c *alarmsController) Delete(ctx context.Context, lbName string) error {
+	current, err := c.cloud.GetAlarmsByPrefix(ctx, alarmNamePrefix(lbName))
+	if err != nil {
+		return fmt.Errorf("failed to get alarms due to %v", err)
+	}
+	names := make([]string, 0, len(current))
+	for _, alarm := range current {
+		names = append(names, aws.StringValue(alarm.AlarmName))
+	}
+	return c.deleteAlarms(ctx, names)
+}
+
+func (c *alarmsController) deleteAlarms(ctx context.Context, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	albctx.GetLogger(ctx).Infof("deleting alarms %v", strings.Join(names, ", "))
+	if _, err := c.cloud.DeleteAlarmsWithContext(ctx, &cloudwatch.DeleteAlarmsInput{AlarmNames: aws.StringSlice(names)}); err != nil {
+		return fmt.Errorf("failed to delete alarms due to %v", err)
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "DELETE", "alarms %v deleted", strings.Join(names, ", "))
+	return nil
+}
+
+// alarmUpToDate checks whether the existing alarm has the threshold and actions of desired alarm.
+// The metrics of alarms are determined by their names, so they're not compared.
+func alarmUpToDate(existing *cloudwatch.MetricAlarm, desired *cloudwatch.PutMetricAlarmInput) bool {
+	return aws.Float64Value(existing.Threshold) == aws.Float64Value(desired.Threshold) &&
+		stringSliceEqual(aws.StringValueSlice(existing.AlarmActions), aws.StringValueSlice(desired.AlarmActions)) &&
+		stringSliceEqual(aws.StringValueSlice(existing.OKActions), aws.StringValueSlice(desired.OKActions))
+}
+
+func stringSliceEqual(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func alarmNamePrefix(lbName string) string {
+	return lbName + "/"
+}
+
+// buildAlarms builds the alarms of LoadBalancer for thresholds, alarms of AlarmKindUnhealthyHostCount are built per targetGroup.
+func buildAlarms(lbName string, lbArn string, thresholds map[string]float64, topicArn *string, tgGroup tg.TargetGroupGroup) ([]*cloudwatch.PutMetricAlarmInput, error) {
+	if len(thresholds) == 0 {
+		return nil, nil
+	}
+	lbDimension, err := aws.LoadBalancerMetricDimension(lbArn)
+	if err != nil {
+		return nil, err
+	}
+	lbDimensions := []*cloudwatch.Dimension{{Name: aws.String("LoadBalancer"), Value: aws.String(lbDimension)}}
+	newAlarm := func(name string, description string, threshold float64) *cloudwatch.PutMetricAlarmInput {
+		return &cloudwatch.PutMetricAlarmInput{
+			AlarmName:          aws.String(alarmNamePrefix(lbName) + name),
+			AlarmDescription:   aws.String(description),
+			AlarmActions:       []*string{topicArn},
+			OKActions:          []*string{topicArn},
+			Threshold:          aws.Float64(threshold),
+			EvaluationPeriods:  aws.Int64(alarmEvaluationPeriods),
+			ComparisonOperator: aws.String(cloudwatch.ComparisonOperatorGreaterThanThreshold),
+			TreatMissingData:   aws.String("notBreaching"),
+		}
+	}
+
+	var alarms []*cloudwatch.PutMetricAlarmInput
+	if threshold, ok := thresholds[loadbalancer.AlarmKind5xxRate]; ok {
+		alarm := newAlarm(loadbalancer.AlarmKind5xxRate, fmt.Sprintf("Percentage of 5XX responses from targets of LoadBalancer %v", lbName), threshold)
+		alarm.Metrics = []*cloudwatch.MetricDataQuery{
+			alarmMetricQuery("errors", "HTTPCode_Target_5XX_Count", cloudwatch.StatisticSum, lbDimensions),
+			alarmMetricQuery("requests", "RequestCount", cloudwatch.StatisticSum, lbDimensions),
+			{
+				Id:         aws.String("rate"),
+				Expression: aws.String("100 * errors / requests"),
+				Label:      aws.String("5XX rate"),
+				ReturnData: aws.Bool(true),
+			},
+		}
+		alarms = append(alarms, alarm)
+	}
+	if threshold, ok := thresholds[loadbalancer.AlarmKindTargetResponseTime]; ok {
+		alarm := newAlarm(loadbalancer.AlarmKindTargetResponseTime, fmt.Sprintf("Average target response time of LoadBalancer %v", lbName), threshold)
+		alarm.Namespace = aws.String(alarmMetricNamespace)
+		alarm.MetricName = aws.String("TargetResponseTime")
+		alarm.Dimensions = lbDimensions
+		alarm.Statistic = aws.String(cloudwatch.StatisticAverage)
+		alarm.Period = aws.Int64(alarmPeriod)
+		alarms = append(alarms, alarm)
+	}
+	if threshold, ok := thresholds[loadbalancer.AlarmKindUnhealthyHostCount]; ok {
+		tgArns := make(map[string]bool)
+		for _, targetGroup := range sortedTargetGroups(tgGroup) {
+			if tgArns[targetGroup.Arn] {
+				continue
+			}
+			tgArns[targetGroup.Arn] = true
+			tgDimension, err := aws.TargetGroupMetricDimension(targetGroup.Arn)
+			if err != nil {
+				return nil, err
+			}
+			// tgDimension is like "targetgroup/my-targets/73e2d6bc24d8a067".
+			tgName := strings.Split(tgDimension, "/")[1]
+			alarm := newAlarm(loadbalancer.AlarmKindUnhealthyHostCount+"/"+tgName,
+				fmt.Sprintf("Unhealthy targets in targetGroup %v of LoadBalancer %v", tgName, lbName), threshold)
+			alarm.Namespace = aws.String(alarmMetricNamespace)
+			alarm.MetricName = aws.String("UnHealthyHostCount")
+			alarm.Dimensions = append([]*cloudwatch.Dimension{{Name: aws.String("TargetGroup"), Value: aws.String(tgDimension)}}, lbDimensions...)
+			alarm.Statistic = aws.String(cloudwatch.StatisticMaximum)
+			alarm.Period = aws.Int64(alarmPeriod)
+			alarm.ComparisonOperator = aws.String(cloudwatch.ComparisonOperatorGreaterThanOrEqualToThreshold)
+			alarms = append(alarms, alarm)
+		}
+	}
+	return alarms, nil
+}
+
+func alarmMetricQuery(id string, metricName string, stat string, dimensions []*cloudwatch.Dimension) *cloudwatch.MetricDataQuery {
+	return &cloudwatch.MetricDataQuery{
+		Id: aws.String(id),
+		MetricStat: &cloudwatch.MetricStat{
+			Metric: &cloudwatch.Metric{
+				Namespace:  aws.String(alarmMetricNamespace),
+				MetricName: aws.String(metricName),
+				Dimensions: dimensions,
+			},
+			Period: aws.Int64(alarmPeriod),
+			Stat:   aws.String(stat),
+		},
+		ReturnData: aws.Bool(false),
+	}
+}
+
+// sortedTargetGroups returns the targetGroups of tgGroup in the order of their backends, so alarms are put in stable order.
+func sortedTargetGroups(tgGroup tg.TargetGroupGroup) []tg.TargetGroup {
+	backends := sortedBackends(tgGroup)
+	targetGroups := make([]tg.TargetGroup, 0, len(backends))
+	for _, backend := range backends {
+		targetGroups = append(targetGroups, tgGroup.TGByBackend[backend])
+	}
+	return targetGroups
+}
@@ -0,0 +1,110 @@
+package lb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	alarmLBArn = "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/lb/50dc6c495c0c9188"
+	alarmTGArn = "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg1/73e2d6bc24d8a067"
+	alarmTopic = "arn:aws:sns:us-west-2:123456789012:alerts"
+)
+
+func Test_buildAlarms(t *testing.T) {
+	tgGroup := tg.TargetGroupGroup{
+		TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{
+			{ServiceName: "service", ServicePort: intstr.FromInt(80)}:        {Arn: alarmTGArn},
+			{ServiceName: "service", ServicePort: intstr.FromString("http")}: {Arn: alarmTGArn},
+		},
+	}
+	alarms, err := buildAlarms("lb", alarmLBArn, map[string]float64{
+		loadbalancer.AlarmKind5xxRate:            5,
+		loadbalancer.AlarmKindTargetResponseTime: 0.5,
+		loadbalancer.AlarmKindUnhealthyHostCount: 1,
+	}, aws.String(alarmTopic), tgGroup)
+	assert.NoError(t, err)
+
+	var names []string
+	for _, alarm := range alarms {
+		names = append(names, aws.StringValue(alarm.AlarmName))
+		assert.Equal(t, []string{alarmTopic}, aws.StringValueSlice(alarm.AlarmActions))
+	}
+	assert.Equal(t, []string{"lb/5xx-rate", "lb/target-response-time", "lb/unhealthy-host-count/tg1"}, names)
+	assert.Equal(t, "100 * errors / requests", aws.StringValue(alarms[0].Metrics[2].Expression))
+	assert.Equal(t, []*cloudwatch.Dimension{
+		{Name: aws.String("TargetGroup"), Value: aws.String("targetgroup/tg1/73e2d6bc24d8a067")},
+		{Name: aws.String("LoadBalancer"), Value: aws.String("app/lb/50dc6c495c0c9188")},
+	}, alarms[2].Dimensions)
+}
+
+func Test_alarmsController_Reconcile(t *testing.T) {
+	tgGroup := tg.TargetGroupGroup{
+		TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{
+			{ServiceName: "service", ServicePort: intstr.FromInt(80)}: {Arn: alarmTGArn},
+		},
+	}
+	for _, tc := range []struct {
+		name            string
+		thresholds      map[string]float64
+		existing        []*cloudwatch.MetricAlarm
+		expectedPuts    []string
+		expectedDeletes []string
+	}{
+		{
+			name:       "creates missing alarms",
+			thresholds: map[string]float64{loadbalancer.AlarmKind5xxRate: 5, loadbalancer.AlarmKindUnhealthyHostCount: 1},
+			existing: []*cloudwatch.MetricAlarm{
+				{AlarmName: aws.String("lb/5xx-rate"), Threshold: aws.Float64(5), AlarmActions: aws.StringSlice([]string{alarmTopic}), OKActions: aws.StringSlice([]string{alarmTopic})},
+			},
+			expectedPuts: []string{"lb/unhealthy-host-count/tg1"},
+		},
+		{
+			name:       "updates changed thresholds and deletes unused alarms",
+			thresholds: map[string]float64{loadbalancer.AlarmKind5xxRate: 10},
+			existing: []*cloudwatch.MetricAlarm{
+				{AlarmName: aws.String("lb/5xx-rate"), Threshold: aws.Float64(5), AlarmActions: aws.StringSlice([]string{alarmTopic}), OKActions: aws.StringSlice([]string{alarmTopic})},
+				{AlarmName: aws.String("lb/unhealthy-host-count/tg0"), Threshold: aws.Float64(1)},
+			},
+			expectedPuts:    []string{"lb/5xx-rate"},
+			expectedDeletes: []string{"lb/unhealthy-host-count/tg0"},
+		},
+		{
+			name: "deletes all alarms without thresholds",
+			existing: []*cloudwatch.MetricAlarm{
+				{AlarmName: aws.String("lb/5xx-rate"), Threshold: aws.Float64(5)},
+			},
+			expectedDeletes: []string{"lb/5xx-rate"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetAlarmsByPrefix", mock.Anything, "lb/").Return(tc.existing, nil)
+			for _, name := range tc.expectedPuts {
+				name := name
+				cloud.On("PutMetricAlarmWithContext", mock.Anything, mock.MatchedBy(func(input *cloudwatch.PutMetricAlarmInput) bool {
+					return aws.StringValue(input.AlarmName) == name
+				})).Return(&cloudwatch.PutMetricAlarmOutput{}, nil).Once()
+			}
+			if len(tc.expectedDeletes) != 0 {
+				cloud.On("DeleteAlarmsWithContext", mock.Anything, &cloudwatch.DeleteAlarmsInput{AlarmNames: aws.StringSlice(tc.expectedDeletes)}).
+					Return(&cloudwatch.DeleteAlarmsOutput{}, nil)
+			}
+
+			controller := NewAlarmsController(cloud)
+			err := controller.Reconcile(context.Background(), "lb", alarmLBArn, tc.thresholds, aws.String(alarmTopic), tgGroup)
+			assert.NoError(t, err)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"strconv"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 const (
@@ -17,15 +19,66 @@ const (
 	AccessLogsS3EnabledKey       = "access_logs.s3.enabled"
 	AccessLogsS3BucketKey        = "access_logs.s3.bucket"
 	AccessLogsS3PrefixKey        = "access_logs.s3.prefix"
+	ConnectionLogsS3EnabledKey   = "connection_logs.s3.enabled"
+	ConnectionLogsS3BucketKey    = "connection_logs.s3.bucket"
+	ConnectionLogsS3PrefixKey    = "connection_logs.s3.prefix"
 	IdleTimeoutTimeoutSecondsKey = "idle_timeout.timeout_seconds"
 	RoutingHTTP2EnabledKey       = "routing.http2.enabled"
+	WAFFailOpenEnabledKey        = "waf.fail_open.enabled"
+	ZonalShiftConfigEnabledKey   = "zonal_shift.config.enabled"
+
+	RoutingHTTPXFFClientPortEnabledKey      = "routing.http.xff_client_port.enabled"
+	RoutingHTTPXFFHeaderProcessingModeKey   = "routing.http.xff_header_processing.mode"
+	RoutingHTTPPreserveHostHeaderEnabledKey = "routing.http.preserve_host_header.enabled"
+
+	RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabledKey = "routing.http.x_amzn_tls_version_and_cipher_suite.enabled"
+	RoutingHTTPDropInvalidHeaderFieldsEnabledKey       = "routing.http.drop_invalid_header_fields.enabled"
 
 	DeletionProtectionEnabled = false
 	AccessLogsS3Enabled       = false
 	AccessLogsS3Bucket        = ""
 	AccessLogsS3Prefix        = ""
+	ConnectionLogsS3Enabled   = false
+	ConnectionLogsS3Bucket    = ""
+	ConnectionLogsS3Prefix    = ""
 	IdleTimeoutTimeoutSeconds = 60
 	RoutingHTTP2Enabled       = true
+	WAFFailOpenEnabled        = false
+	ZonalShiftConfigEnabled   = false
+
+	RoutingHTTPXFFClientPortEnabled      = false
+	RoutingHTTPXFFHeaderProcessingMode   = XFFHeaderProcessingModeAppend
+	RoutingHTTPPreserveHostHeaderEnabled = false
+
+	RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabled = false
+	RoutingHTTPDropInvalidHeaderFieldsEnabled       = false
+)
+
+// Modes of processing the X-Forwarded-For header before forwarding requests to targets.
+const (
+	XFFHeaderProcessingModeAppend   = "append"
+	XFFHeaderProcessingModePreserve = "preserve"
+	XFFHeaderProcessingModeRemove   = "remove"
+)
+
+// attributeKeys contains the keys of the attributes managed by the controller.
+var attributeKeys = sets.NewString(
+	DeletionProtectionEnabledKey,
+	AccessLogsS3EnabledKey,
+	AccessLogsS3BucketKey,
+	AccessLogsS3PrefixKey,
+	ConnectionLogsS3EnabledKey,
+	ConnectionLogsS3BucketKey,
+	ConnectionLogsS3PrefixKey,
+	IdleTimeoutTimeoutSecondsKey,
+	RoutingHTTP2EnabledKey,
+	WAFFailOpenEnabledKey,
+	ZonalShiftConfigEnabledKey,
+	RoutingHTTPXFFClientPortEnabledKey,
+	RoutingHTTPXFFHeaderProcessingModeKey,
+	RoutingHTTPPreserveHostHeaderEnabledKey,
+	RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabledKey,
+	RoutingHTTPDropInvalidHeaderFieldsEnabledKey,
 )
 
 // Attributes represents the desired state of attributes for a load balancer.
@@ -48,6 +101,20 @@ type Attributes struct {
 	// for the access logs.
 	AccessLogsS3Prefix string
 
+	// ConnectionLogsS3Enabled: connection_logs.s3.enabled - Indicates whether connection logs are enabled.
+	// The value is true or false. The default is false.
+	ConnectionLogsS3Enabled bool
+
+	// ConnectionLogsS3Bucket: connection_logs.s3.bucket - The name of the S3 bucket for the connection logs.
+	// This attribute is required if connection logs are enabled. The bucket must
+	// exist in the same region as the load balancer and have a bucket policy
+	// that grants Elastic Load Balancing permissions to write to the bucket.
+	ConnectionLogsS3Bucket string
+
+	// ConnectionLogsS3Prefix: connection_logs.s3.prefix - The prefix for the location in the S3 bucket
+	// for the connection logs.
+	ConnectionLogsS3Prefix string
+
 	// IdleTimeoutTimeoutSeconds: idle_timeout.timeout_seconds - The idle timeout value, in seconds. The
 	// valid range is 1-4000 seconds. The default is 60 seconds.
 	IdleTimeoutTimeoutSeconds int64
@@ -55,6 +122,40 @@ type Attributes struct {
 	// RoutingHTTP2Enabled: routing.http2.enabled - Indicates whether HTTP/2 is enabled. The value
 	// is true or false. The default is true.
 	RoutingHTTP2Enabled bool
+
+	// WAFFailOpenEnabled: waf.fail_open.enabled - Indicates whether to allow a WAF-enabled load balancer
+	// to route requests to targets if it is unable to forward the request to AWS WAF. The value is true
+	// or false. The default is false.
+	WAFFailOpenEnabled bool
+
+	// ZonalShiftConfigEnabled: zonal_shift.config.enabled - Indicates whether zonal shift is enabled, which
+	// allows traffic to be shifted away from an impaired Availability Zone. The value is true or false.
+	// The default is false.
+	ZonalShiftConfigEnabled bool
+
+	// RoutingHTTPXFFClientPortEnabled: routing.http.xff_client_port.enabled - Indicates whether the X-Forwarded-For
+	// header should preserve the source port that the client used to connect to the load balancer. The value is
+	// true or false. The default is false.
+	RoutingHTTPXFFClientPortEnabled bool
+
+	// RoutingHTTPXFFHeaderProcessingMode: routing.http.xff_header_processing.mode - Enables you to modify, preserve,
+	// or remove the X-Forwarded-For header in the HTTP request before the load balancer sends the request to the target.
+	// The value is append, preserve or remove. The default is append.
+	RoutingHTTPXFFHeaderProcessingMode string
+
+	// RoutingHTTPPreserveHostHeaderEnabled: routing.http.preserve_host_header.enabled - Indicates whether the load balancer
+	// preserves the Host header in the HTTP request and sends it to the target without any change. The value is true or false.
+	// The default is false.
+	RoutingHTTPPreserveHostHeaderEnabled bool
+
+	// RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabled: routing.http.x_amzn_tls_version_and_cipher_suite.enabled - Indicates whether
+	// the x-amzn-tls-version and x-amzn-tls-cipher-suite headers, with the TLS version and cipher suite negotiated with the client,
+	// are added to the request before it's sent to the target. The value is true or false. The default is false.
+	RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabled bool
+
+	// RoutingHTTPDropInvalidHeaderFieldsEnabled: routing.http.drop_invalid_header_fields.enabled - Indicates whether HTTP headers
+	// with invalid header fields are removed by the load balancer (true) or routed to targets (false). The default is false.
+	RoutingHTTPDropInvalidHeaderFieldsEnabled bool
 }
 
 func NewAttributes(attrs []*elbv2.LoadBalancerAttribute) (a *Attributes, err error) {
@@ -63,8 +164,20 @@ func NewAttributes(attrs []*elbv2.LoadBalancerAttribute) (a *Attributes, err err
 		AccessLogsS3Enabled:       AccessLogsS3Enabled,
 		AccessLogsS3Bucket:        AccessLogsS3Bucket,
 		AccessLogsS3Prefix:        AccessLogsS3Prefix,
+		ConnectionLogsS3Enabled:   ConnectionLogsS3Enabled,
+		ConnectionLogsS3Bucket:    ConnectionLogsS3Bucket,
+		ConnectionLogsS3Prefix:    ConnectionLogsS3Prefix,
 		IdleTimeoutTimeoutSeconds: IdleTimeoutTimeoutSeconds,
 		RoutingHTTP2Enabled:       RoutingHTTP2Enabled,
+		WAFFailOpenEnabled:        WAFFailOpenEnabled,
+		ZonalShiftConfigEnabled:   ZonalShiftConfigEnabled,
+
+		RoutingHTTPXFFClientPortEnabled:      RoutingHTTPXFFClientPortEnabled,
+		RoutingHTTPXFFHeaderProcessingMode:   RoutingHTTPXFFHeaderProcessingMode,
+		RoutingHTTPPreserveHostHeaderEnabled: RoutingHTTPPreserveHostHeaderEnabled,
+
+		RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabled: RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabled,
+		RoutingHTTPDropInvalidHeaderFieldsEnabled:       RoutingHTTPDropInvalidHeaderFieldsEnabled,
 	}
 	var e error
 	for _, attr := range attrs {
@@ -84,6 +197,15 @@ func NewAttributes(attrs []*elbv2.LoadBalancerAttribute) (a *Attributes, err err
 			a.AccessLogsS3Bucket = attrValue
 		case AccessLogsS3PrefixKey:
 			a.AccessLogsS3Prefix = attrValue
+		case ConnectionLogsS3EnabledKey:
+			a.ConnectionLogsS3Enabled, err = strconv.ParseBool(attrValue)
+			if err != nil {
+				return a, fmt.Errorf("invalid load balancer attribute value %s=%s", attrKey, attrValue)
+			}
+		case ConnectionLogsS3BucketKey:
+			a.ConnectionLogsS3Bucket = attrValue
+		case ConnectionLogsS3PrefixKey:
+			a.ConnectionLogsS3Prefix = attrValue
 		case IdleTimeoutTimeoutSecondsKey:
 			a.IdleTimeoutTimeoutSeconds, err = strconv.ParseInt(attrValue, 10, 64)
 			if err != nil {
@@ -97,6 +219,43 @@ func NewAttributes(attrs []*elbv2.LoadBalancerAttribute) (a *Attributes, err err
 			if err != nil {
 				return a, fmt.Errorf("invalid load balancer attribute value %s=%s", attrKey, attrValue)
 			}
+		case WAFFailOpenEnabledKey:
+			a.WAFFailOpenEnabled, err = strconv.ParseBool(attrValue)
+			if err != nil {
+				return a, fmt.Errorf("invalid load balancer attribute value %s=%s", attrKey, attrValue)
+			}
+		case ZonalShiftConfigEnabledKey:
+			a.ZonalShiftConfigEnabled, err = strconv.ParseBool(attrValue)
+			if err != nil {
+				return a, fmt.Errorf("invalid load balancer attribute value %s=%s", attrKey, attrValue)
+			}
+		case RoutingHTTPXFFClientPortEnabledKey:
+			a.RoutingHTTPXFFClientPortEnabled, err = strconv.ParseBool(attrValue)
+			if err != nil {
+				return a, fmt.Errorf("invalid load balancer attribute value %s=%s", attrKey, attrValue)
+			}
+		case RoutingHTTPXFFHeaderProcessingModeKey:
+			switch attrValue {
+			case XFFHeaderProcessingModeAppend, XFFHeaderProcessingModePreserve, XFFHeaderProcessingModeRemove:
+				a.RoutingHTTPXFFHeaderProcessingMode = attrValue
+			default:
+				return a, fmt.Errorf("invalid load balancer attribute value %s=%s", attrKey, attrValue)
+			}
+		case RoutingHTTPPreserveHostHeaderEnabledKey:
+			a.RoutingHTTPPreserveHostHeaderEnabled, err = strconv.ParseBool(attrValue)
+			if err != nil {
+				return a, fmt.Errorf("invalid load balancer attribute value %s=%s", attrKey, attrValue)
+			}
+		case RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabledKey:
+			a.RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabled, err = strconv.ParseBool(attrValue)
+			if err != nil {
+				return a, fmt.Errorf("invalid load balancer attribute value %s=%s", attrKey, attrValue)
+			}
+		case RoutingHTTPDropInvalidHeaderFieldsEnabledKey:
+			a.RoutingHTTPDropInvalidHeaderFieldsEnabled, err = strconv.ParseBool(attrValue)
+			if err != nil {
+				return a, fmt.Errorf("invalid load balancer attribute value %s=%s", attrKey, attrValue)
+			}
 		default:
 			e = NewInvalidAttribute(attrKey)
 		}
@@ -110,15 +269,18 @@ type AttributesController interface {
 	Reconcile(ctx context.Context, lbArn string, attrs []*elbv2.LoadBalancerAttribute) error
 }
 
-// NewAttributesController constructs a new attributes controller
-func NewAttributesController(cloud aws.CloudAPI) AttributesController {
+// NewAttributesController constructs a new attributes controller.
+// Unless strict, attributes not specified are left unmanaged instead of being reset to their defaults.
+func NewAttributesController(cloud aws.CloudAPI, strict bool) AttributesController {
 	return &attributesController{
-		cloud: cloud,
+		cloud:  cloud,
+		strict: strict,
 	}
 }
 
 type attributesController struct {
-	cloud aws.CloudAPI
+	cloud  aws.CloudAPI
+	strict bool
 }
 
 func (c *attributesController) Reconcile(ctx context.Context, lbArn string, attrs []*elbv2.LoadBalancerAttribute) error {
@@ -138,23 +300,76 @@ func (c *attributesController) Reconcile(ctx context.Context, lbArn string, attr
 	if err != nil && !IsInvalidAttribute(err) {
 		return fmt.Errorf("failed parsing attributes: %v", err)
 	}
+	// unless strict, the keys of attributes applied before are recorded in a tag, so those removed since are reset to their defaults.
+	var applied, recorded tags.KeySet
+	if !c.strict {
+		if applied, err = tags.DescribeELBKeySet(ctx, c.cloud, lbArn, tags.AppliedAttributesKey); err != nil {
+			return fmt.Errorf("failed to retrieve tags from ELBV2 in AWS: %v", err)
+		}
+		if desired, err = NewAttributes(mergeAttributes(raw.Attributes, attrs, applied)); err != nil {
+			return fmt.Errorf("failed parsing attributes; %v", err)
+		}
+		// new keys are recorded before modifying attributes, so none goes unrecorded if reconciling stops halfway.
+		recorded = attributesKeySet(attrs)
+		if union := applied.Union(recorded); !union.Equal(applied) {
+			if err := tags.RecordELBKeySet(ctx, c.cloud, lbArn, tags.AppliedAttributesKey, union); err != nil {
+				return fmt.Errorf("failed to record applied attributes: %v", err)
+			}
+			applied = union
+		}
+	}
 
 	changeSet := attributesChangeSet(current, desired)
 	if len(changeSet) > 0 {
 		albctx.GetLogger(ctx).Infof("Modifying ELBV2 attributes to %v.", log.Prettify(changeSet))
-		_, err = c.cloud.ModifyLoadBalancerAttributesWithContext(ctx, &elbv2.ModifyLoadBalancerAttributesInput{
+		in := &elbv2.ModifyLoadBalancerAttributesInput{
 			LoadBalancerArn: aws.String(lbArn),
 			Attributes:      changeSet,
+		}
+		albctx.RecordAuditBefore(ctx, in, &elbv2.ModifyLoadBalancerAttributesInput{
+			LoadBalancerArn: aws.String(lbArn),
+			Attributes:      raw.Attributes,
 		})
+		_, err = c.cloud.ModifyLoadBalancerAttributesWithContext(ctx, in)
 		if err != nil {
 			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "%s attributes modification failed: %s", lbArn, err.Error())
 			return fmt.Errorf("failed modifying attributes: %s", err)
 		}
 
 	}
+	if !c.strict && !recorded.Equal(applied) {
+		if err := tags.RecordELBKeySet(ctx, c.cloud, lbArn, tags.AppliedAttributesKey, recorded); err != nil {
+			return fmt.Errorf("failed to record applied attributes: %v", err)
+		}
+	}
 	return nil
 }
 
+// mergeAttributes returns attrs with the attributes of current managed by the controller that aren't in attrs,
+// so they keep their current values. Attributes in applied were set by the controller before, they're left out to be reset to their defaults.
+func mergeAttributes(current []*elbv2.LoadBalancerAttribute, attrs []*elbv2.LoadBalancerAttribute, applied tags.KeySet) []*elbv2.LoadBalancerAttribute {
+	keys := sets.NewString()
+	for _, attr := range attrs {
+		keys.Insert(aws.StringValue(attr.Key))
+	}
+	merged := append([]*elbv2.LoadBalancerAttribute{}, attrs...)
+	for _, attr := range current {
+		if key := aws.StringValue(attr.Key); attributeKeys.Has(key) && !keys.Has(key) && !applied.Has(key) {
+			merged = append(merged, attr)
+		}
+	}
+	return merged
+}
+
+// attributesKeySet returns the KeySet recording the keys of attrs.
+func attributesKeySet(attrs []*elbv2.LoadBalancerAttribute) tags.KeySet {
+	keys := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		keys = append(keys, aws.StringValue(attr.Key))
+	}
+	return tags.NewKeySet(keys...)
+}
+
 // attributesChangeSet returns a list of elbv2.LoadBalancerAttribute required to change a into b
 func attributesChangeSet(current, desired *Attributes) (changeSet []*elbv2.LoadBalancerAttribute) {
 	if current.DeletionProtectionEnabled != desired.DeletionProtectionEnabled {
@@ -176,6 +391,21 @@ func attributesChangeSet(current, desired *Attributes) (changeSet []*elbv2.LoadB
 		}
 	}
 
+	if current.ConnectionLogsS3Enabled != desired.ConnectionLogsS3Enabled {
+		changeSet = append(changeSet, lbAttribute(ConnectionLogsS3EnabledKey, fmt.Sprintf("%v", desired.ConnectionLogsS3Enabled)))
+	}
+
+	// same as access logs, bucket of connection logs is kept unchanged if ConnectionLogsS3Enabled==false.
+	if desired.ConnectionLogsS3Enabled {
+		if current.ConnectionLogsS3Bucket != desired.ConnectionLogsS3Bucket {
+			changeSet = append(changeSet, lbAttribute(ConnectionLogsS3BucketKey, desired.ConnectionLogsS3Bucket))
+		}
+
+		if current.ConnectionLogsS3Prefix != desired.ConnectionLogsS3Prefix {
+			changeSet = append(changeSet, lbAttribute(ConnectionLogsS3PrefixKey, desired.ConnectionLogsS3Prefix))
+		}
+	}
+
 	if current.IdleTimeoutTimeoutSeconds != desired.IdleTimeoutTimeoutSeconds {
 		changeSet = append(changeSet, lbAttribute(IdleTimeoutTimeoutSecondsKey, fmt.Sprintf("%v", desired.IdleTimeoutTimeoutSeconds)))
 	}
@@ -184,6 +414,34 @@ func attributesChangeSet(current, desired *Attributes) (changeSet []*elbv2.LoadB
 		changeSet = append(changeSet, lbAttribute(RoutingHTTP2EnabledKey, fmt.Sprintf("%v", desired.RoutingHTTP2Enabled)))
 	}
 
+	if current.WAFFailOpenEnabled != desired.WAFFailOpenEnabled {
+		changeSet = append(changeSet, lbAttribute(WAFFailOpenEnabledKey, fmt.Sprintf("%v", desired.WAFFailOpenEnabled)))
+	}
+
+	if current.ZonalShiftConfigEnabled != desired.ZonalShiftConfigEnabled {
+		changeSet = append(changeSet, lbAttribute(ZonalShiftConfigEnabledKey, fmt.Sprintf("%v", desired.ZonalShiftConfigEnabled)))
+	}
+
+	if current.RoutingHTTPXFFClientPortEnabled != desired.RoutingHTTPXFFClientPortEnabled {
+		changeSet = append(changeSet, lbAttribute(RoutingHTTPXFFClientPortEnabledKey, fmt.Sprintf("%v", desired.RoutingHTTPXFFClientPortEnabled)))
+	}
+
+	if current.RoutingHTTPXFFHeaderProcessingMode != desired.RoutingHTTPXFFHeaderProcessingMode {
+		changeSet = append(changeSet, lbAttribute(RoutingHTTPXFFHeaderProcessingModeKey, desired.RoutingHTTPXFFHeaderProcessingMode))
+	}
+
+	if current.RoutingHTTPPreserveHostHeaderEnabled != desired.RoutingHTTPPreserveHostHeaderEnabled {
+		changeSet = append(changeSet, lbAttribute(RoutingHTTPPreserveHostHeaderEnabledKey, fmt.Sprintf("%v", desired.RoutingHTTPPreserveHostHeaderEnabled)))
+	}
+
+	if current.RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabled != desired.RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabled {
+		changeSet = append(changeSet, lbAttribute(RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabledKey, fmt.Sprintf("%v", desired.RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabled)))
+	}
+
+	if current.RoutingHTTPDropInvalidHeaderFieldsEnabled != desired.RoutingHTTPDropInvalidHeaderFieldsEnabled {
+		changeSet = append(changeSet, lbAttribute(RoutingHTTPDropInvalidHeaderFieldsEnabledKey, fmt.Sprintf("%v", desired.RoutingHTTPDropInvalidHeaderFieldsEnabled)))
+	}
+
 	return
 }
 
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -43,6 +44,11 @@ func Test_NewAttributes(t *testing.T) {
 			ok:         false,
 			attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(AccessLogsS3EnabledKey, "falfadssdfdsse")},
 		},
+		{
+			name:       fmt.Sprintf("%v is invalid", ConnectionLogsS3EnabledKey),
+			ok:         false,
+			attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(ConnectionLogsS3EnabledKey, "not a bool")},
+		},
 		{
 			name:       "one invalid attribute",
 			ok:         false,
@@ -63,6 +69,41 @@ func Test_NewAttributes(t *testing.T) {
 			ok:         false,
 			attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(RoutingHTTP2EnabledKey, "falfadssdfdsse")},
 		},
+		{
+			name:       fmt.Sprintf("%v is invalid", WAFFailOpenEnabledKey),
+			ok:         false,
+			attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(WAFFailOpenEnabledKey, "not a bool")},
+		},
+		{
+			name:       fmt.Sprintf("%v is invalid", ZonalShiftConfigEnabledKey),
+			ok:         false,
+			attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(ZonalShiftConfigEnabledKey, "not a bool")},
+		},
+		{
+			name:       fmt.Sprintf("%v is invalid", RoutingHTTPXFFClientPortEnabledKey),
+			ok:         false,
+			attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(RoutingHTTPXFFClientPortEnabledKey, "not a bool")},
+		},
+		{
+			name:       fmt.Sprintf("%v is invalid", RoutingHTTPXFFHeaderProcessingModeKey),
+			ok:         false,
+			attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(RoutingHTTPXFFHeaderProcessingModeKey, "replace")},
+		},
+		{
+			name:       fmt.Sprintf("%v is invalid", RoutingHTTPPreserveHostHeaderEnabledKey),
+			ok:         false,
+			attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(RoutingHTTPPreserveHostHeaderEnabledKey, "not a bool")},
+		},
+		{
+			name:       fmt.Sprintf("%v is invalid", RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabledKey),
+			ok:         false,
+			attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabledKey, "not a bool")},
+		},
+		{
+			name:       fmt.Sprintf("%v is invalid", RoutingHTTPDropInvalidHeaderFieldsEnabledKey),
+			ok:         false,
+			attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(RoutingHTTPDropInvalidHeaderFieldsEnabledKey, "not a bool")},
+		},
 		{
 			name:       fmt.Sprintf("undefined attribute"),
 			ok:         false,
@@ -76,16 +117,38 @@ func Test_NewAttributes(t *testing.T) {
 				lbAttribute(AccessLogsS3EnabledKey, "true"),
 				lbAttribute(AccessLogsS3BucketKey, "bucket name"),
 				lbAttribute(AccessLogsS3PrefixKey, "prefix"),
+				lbAttribute(ConnectionLogsS3EnabledKey, "true"),
+				lbAttribute(ConnectionLogsS3BucketKey, "connection bucket"),
+				lbAttribute(ConnectionLogsS3PrefixKey, "connection-prefix"),
 				lbAttribute(IdleTimeoutTimeoutSecondsKey, "45"),
 				lbAttribute(RoutingHTTP2EnabledKey, "false"),
+				lbAttribute(WAFFailOpenEnabledKey, "true"),
+				lbAttribute(ZonalShiftConfigEnabledKey, "true"),
+				lbAttribute(RoutingHTTPXFFClientPortEnabledKey, "true"),
+				lbAttribute(RoutingHTTPXFFHeaderProcessingModeKey, "preserve"),
+				lbAttribute(RoutingHTTPPreserveHostHeaderEnabledKey, "true"),
+				lbAttribute(RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabledKey, "true"),
+				lbAttribute(RoutingHTTPDropInvalidHeaderFieldsEnabledKey, "true"),
 			},
 			output: &Attributes{
 				DeletionProtectionEnabled: true,
 				AccessLogsS3Enabled:       true,
 				AccessLogsS3Bucket:        "bucket name",
 				AccessLogsS3Prefix:        "prefix",
+				ConnectionLogsS3Enabled:   true,
+				ConnectionLogsS3Bucket:    "connection bucket",
+				ConnectionLogsS3Prefix:    "connection-prefix",
 				IdleTimeoutTimeoutSeconds: 45,
 				RoutingHTTP2Enabled:       false,
+				WAFFailOpenEnabled:        true,
+				ZonalShiftConfigEnabled:   true,
+
+				RoutingHTTPXFFClientPortEnabled:      true,
+				RoutingHTTPXFFHeaderProcessingMode:   "preserve",
+				RoutingHTTPPreserveHostHeaderEnabled: true,
+
+				RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabled: true,
+				RoutingHTTPDropInvalidHeaderFieldsEnabled:       true,
 			},
 		},
 	} {
@@ -143,6 +206,18 @@ func Test_attributesChangeSet(t *testing.T) {
 			b:         MustNewAttributes([]*elbv2.LoadBalancerAttribute{lbAttribute(AccessLogsS3EnabledKey, "false"), lbAttribute(AccessLogsS3BucketKey, ""), lbAttribute(AccessLogsS3PrefixKey, "")}),
 			changeSet: []*elbv2.LoadBalancerAttribute{lbAttribute(AccessLogsS3EnabledKey, "false")},
 		},
+		{
+			name:      fmt.Sprintf("enable ConnectionLogS3"),
+			a:         MustNewAttributes(nil),
+			b:         MustNewAttributes([]*elbv2.LoadBalancerAttribute{lbAttribute(ConnectionLogsS3EnabledKey, "true"), lbAttribute(ConnectionLogsS3BucketKey, "bucket"), lbAttribute(ConnectionLogsS3PrefixKey, "prefix")}),
+			changeSet: []*elbv2.LoadBalancerAttribute{lbAttribute(ConnectionLogsS3EnabledKey, "true"), lbAttribute(ConnectionLogsS3BucketKey, "bucket"), lbAttribute(ConnectionLogsS3PrefixKey, "prefix")},
+		},
+		{
+			name:      fmt.Sprintf("disable ConnectionLogS3, don't change bucket/prefix"),
+			a:         MustNewAttributes([]*elbv2.LoadBalancerAttribute{lbAttribute(ConnectionLogsS3EnabledKey, "true"), lbAttribute(ConnectionLogsS3BucketKey, "bucket"), lbAttribute(ConnectionLogsS3PrefixKey, "prefix")}),
+			b:         MustNewAttributes([]*elbv2.LoadBalancerAttribute{lbAttribute(ConnectionLogsS3EnabledKey, "false")}),
+			changeSet: []*elbv2.LoadBalancerAttribute{lbAttribute(ConnectionLogsS3EnabledKey, "false")},
+		},
 		{
 			name:      fmt.Sprintf("a contains default, b contains non-default IdleTimeoutTimeoutSecondsKey, make a change"),
 			a:         MustNewAttributes(nil),
@@ -155,6 +230,44 @@ func Test_attributesChangeSet(t *testing.T) {
 			b:         MustNewAttributes([]*elbv2.LoadBalancerAttribute{lbAttribute(RoutingHTTP2EnabledKey, "false")}),
 			changeSet: []*elbv2.LoadBalancerAttribute{lbAttribute(RoutingHTTP2EnabledKey, "false")},
 		},
+		{
+			name:      fmt.Sprintf("a contains default, b contains non-default WAFFailOpenEnabledKey, make a change"),
+			a:         MustNewAttributes(nil),
+			b:         MustNewAttributes([]*elbv2.LoadBalancerAttribute{lbAttribute(WAFFailOpenEnabledKey, "true")}),
+			changeSet: []*elbv2.LoadBalancerAttribute{lbAttribute(WAFFailOpenEnabledKey, "true")},
+		},
+		{
+			name:      fmt.Sprintf("a contains default, b contains non-default ZonalShiftConfigEnabledKey, make a change"),
+			a:         MustNewAttributes(nil),
+			b:         MustNewAttributes([]*elbv2.LoadBalancerAttribute{lbAttribute(ZonalShiftConfigEnabledKey, "true")}),
+			changeSet: []*elbv2.LoadBalancerAttribute{lbAttribute(ZonalShiftConfigEnabledKey, "true")},
+		},
+		{
+			name: fmt.Sprintf("a contains default, b contains non-default client IP preservation attributes, make a change"),
+			a:    MustNewAttributes(nil),
+			b: MustNewAttributes([]*elbv2.LoadBalancerAttribute{
+				lbAttribute(RoutingHTTPXFFClientPortEnabledKey, "true"),
+				lbAttribute(RoutingHTTPXFFHeaderProcessingModeKey, "remove"),
+				lbAttribute(RoutingHTTPPreserveHostHeaderEnabledKey, "true"),
+			}),
+			changeSet: []*elbv2.LoadBalancerAttribute{
+				lbAttribute(RoutingHTTPXFFClientPortEnabledKey, "true"),
+				lbAttribute(RoutingHTTPXFFHeaderProcessingModeKey, "remove"),
+				lbAttribute(RoutingHTTPPreserveHostHeaderEnabledKey, "true"),
+			},
+		},
+		{
+			name: fmt.Sprintf("a contains default, b contains non-default request header attributes, make a change"),
+			a:    MustNewAttributes(nil),
+			b: MustNewAttributes([]*elbv2.LoadBalancerAttribute{
+				lbAttribute(RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabledKey, "true"),
+				lbAttribute(RoutingHTTPDropInvalidHeaderFieldsEnabledKey, "true"),
+			}),
+			changeSet: []*elbv2.LoadBalancerAttribute{
+				lbAttribute(RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabledKey, "true"),
+				lbAttribute(RoutingHTTPDropInvalidHeaderFieldsEnabledKey, "true"),
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			changeSet := attributesChangeSet(tc.a, tc.b)
@@ -186,8 +299,18 @@ func defaultAttributes() []*elbv2.LoadBalancerAttribute {
 		lbAttribute(AccessLogsS3EnabledKey, "false"),
 		lbAttribute(AccessLogsS3BucketKey, ""),
 		lbAttribute(AccessLogsS3PrefixKey, ""),
+		lbAttribute(ConnectionLogsS3EnabledKey, "false"),
+		lbAttribute(ConnectionLogsS3BucketKey, ""),
+		lbAttribute(ConnectionLogsS3PrefixKey, ""),
 		lbAttribute(IdleTimeoutTimeoutSecondsKey, "60"),
 		lbAttribute(RoutingHTTP2EnabledKey, "true"),
+		lbAttribute(WAFFailOpenEnabledKey, "false"),
+		lbAttribute(ZonalShiftConfigEnabledKey, "false"),
+		lbAttribute(RoutingHTTPXFFClientPortEnabledKey, "false"),
+		lbAttribute(RoutingHTTPXFFHeaderProcessingModeKey, "append"),
+		lbAttribute(RoutingHTTPPreserveHostHeaderEnabledKey, "false"),
+		lbAttribute(RoutingHTTPXAmznTLSVersionAndCipherSuiteEnabledKey, "false"),
+		lbAttribute(RoutingHTTPDropInvalidHeaderFieldsEnabledKey, "false"),
 	}
 }
 
@@ -195,9 +318,12 @@ func TestReconcile(t *testing.T) {
 	lbArn := "arn"
 	for _, tc := range []struct {
 		Name                               string
+		Strict                             bool
 		Attributes                         []*elbv2.LoadBalancerAttribute
+		AppliedAttributes                  []string
 		DescribeLoadBalancerAttributesCall *DescribeLoadBalancerAttributesCall
 		ModifyLoadBalancerAttributesCall   *ModifyLoadBalancerAttributesCall
+		RecordAppliedAttributesCalls       [][]string
 		ExpectedError                      error
 	}{
 		{
@@ -238,7 +364,65 @@ func TestReconcile(t *testing.T) {
 				},
 				Err: nil,
 			},
-			ExpectedError: nil,
+			RecordAppliedAttributesCalls: [][]string{{IdleTimeoutTimeoutSecondsKey}},
+			ExpectedError:                nil,
+		},
+		{
+			Name:       "attributes not specified are left unmanaged",
+			Attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(IdleTimeoutTimeoutSecondsKey, "120")},
+			DescribeLoadBalancerAttributesCall: &DescribeLoadBalancerAttributesCall{
+				LbArn: aws.String("arn"),
+				Output: &elbv2.DescribeLoadBalancerAttributesOutput{Attributes: append(defaultAttributes(),
+					lbAttribute(DeletionProtectionEnabledKey, "true"), lbAttribute("routing.http.drop_invalid_header_fields.enabled", "true"))},
+			},
+			ModifyLoadBalancerAttributesCall: &ModifyLoadBalancerAttributesCall{
+				Input: &elbv2.ModifyLoadBalancerAttributesInput{
+					LoadBalancerArn: aws.String("arn"),
+					Attributes: []*elbv2.LoadBalancerAttribute{
+						lbAttribute("idle_timeout.timeout_seconds", "120"),
+					},
+				},
+			},
+			RecordAppliedAttributesCalls: [][]string{{IdleTimeoutTimeoutSecondsKey}},
+		},
+		{
+			Name:              "attributes applied before are reset to their defaults once not specified",
+			Attributes:        []*elbv2.LoadBalancerAttribute{lbAttribute(IdleTimeoutTimeoutSecondsKey, "120")},
+			AppliedAttributes: []string{IdleTimeoutTimeoutSecondsKey, DeletionProtectionEnabledKey},
+			DescribeLoadBalancerAttributesCall: &DescribeLoadBalancerAttributesCall{
+				LbArn: aws.String("arn"),
+				Output: &elbv2.DescribeLoadBalancerAttributesOutput{Attributes: append(defaultAttributes(),
+					lbAttribute(DeletionProtectionEnabledKey, "true"), lbAttribute(RoutingHTTPDropInvalidHeaderFieldsEnabledKey, "true"))},
+			},
+			ModifyLoadBalancerAttributesCall: &ModifyLoadBalancerAttributesCall{
+				Input: &elbv2.ModifyLoadBalancerAttributesInput{
+					LoadBalancerArn: aws.String("arn"),
+					Attributes: []*elbv2.LoadBalancerAttribute{
+						lbAttribute("deletion_protection.enabled", "false"),
+						lbAttribute("idle_timeout.timeout_seconds", "120"),
+					},
+				},
+			},
+			RecordAppliedAttributesCalls: [][]string{{IdleTimeoutTimeoutSecondsKey}},
+		},
+		{
+			Name:       "attributes not specified are reset to their defaults when strict",
+			Strict:     true,
+			Attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(IdleTimeoutTimeoutSecondsKey, "120")},
+			DescribeLoadBalancerAttributesCall: &DescribeLoadBalancerAttributesCall{
+				LbArn: aws.String("arn"),
+				Output: &elbv2.DescribeLoadBalancerAttributesOutput{Attributes: append(defaultAttributes(),
+					lbAttribute(DeletionProtectionEnabledKey, "true"))},
+			},
+			ModifyLoadBalancerAttributesCall: &ModifyLoadBalancerAttributesCall{
+				Input: &elbv2.ModifyLoadBalancerAttributesInput{
+					LoadBalancerArn: aws.String("arn"),
+					Attributes: []*elbv2.LoadBalancerAttribute{
+						lbAttribute("deletion_protection.enabled", "false"),
+						lbAttribute("idle_timeout.timeout_seconds", "120"),
+					},
+				},
+			},
 		},
 		{
 			Name:       "start with default attribute set, API throws an error",
@@ -257,7 +441,8 @@ func TestReconcile(t *testing.T) {
 				},
 				Err: fmt.Errorf("Something unexpected happened"),
 			},
-			ExpectedError: errors.New("failed modifying attributes: Something unexpected happened"),
+			RecordAppliedAttributesCalls: [][]string{{IdleTimeoutTimeoutSecondsKey}},
+			ExpectedError:                errors.New("failed modifying attributes: Something unexpected happened"),
 		},
 	} {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -267,11 +452,27 @@ func TestReconcile(t *testing.T) {
 				cloud.On("DescribeLoadBalancerAttributesWithContext", ctx, &elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: tc.DescribeLoadBalancerAttributesCall.LbArn}).Return(tc.DescribeLoadBalancerAttributesCall.Output, tc.DescribeLoadBalancerAttributesCall.Err)
 			}
 
+			if !tc.Strict && tc.DescribeLoadBalancerAttributesCall != nil && tc.DescribeLoadBalancerAttributesCall.Err == nil {
+				tagDescription := &elbv2.TagDescription{ResourceArn: aws.String(lbArn)}
+				if tc.AppliedAttributes != nil {
+					tagDescription.Tags = []*elbv2.Tag{{Key: aws.String(tags.AppliedAttributesKey), Value: aws.String(tags.NewKeySet(tc.AppliedAttributes...).String())}}
+				}
+				cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(lbArn)}}).Return(&elbv2.DescribeTagsOutput{
+					TagDescriptions: []*elbv2.TagDescription{tagDescription},
+				}, nil)
+			}
+			for _, keys := range tc.RecordAppliedAttributesCalls {
+				cloud.On("AddELBV2TagsWithContext", ctx, &elbv2.AddTagsInput{
+					ResourceArns: []*string{aws.String(lbArn)},
+					Tags:         []*elbv2.Tag{{Key: aws.String(tags.AppliedAttributesKey), Value: aws.String(tags.NewKeySet(keys...).String())}},
+				}).Return(&elbv2.AddTagsOutput{}, nil).Once()
+			}
+
 			if tc.ModifyLoadBalancerAttributesCall != nil {
 				cloud.On("ModifyLoadBalancerAttributesWithContext", ctx, tc.ModifyLoadBalancerAttributesCall.Input).Return(tc.ModifyLoadBalancerAttributesCall.Output, tc.ModifyLoadBalancerAttributesCall.Err)
 			}
 
-			controller := NewAttributesController(cloud)
+			controller := NewAttributesController(cloud, tc.Strict)
 			err := controller.Reconcile(context.Background(), lbArn, tc.Attributes)
 
 			if tc.ExpectedError != nil {
@@ -0,0 +1,34 @@
+package lb
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+)
+
+// checkClientIPPreservation emits a warning for backend services that expect the client IP, while LoadBalancer attributes hide it from them.
+// ALB always connects to targets from its own IP addresses, so the client IP is only available to targets via the X-Forwarded-For header.
+func (controller *defaultController) checkClientIPPreservation(ctx context.Context, ingress *extensions.Ingress, attrs []*elbv2.LoadBalancerAttribute, tgGroup tg.TargetGroupGroup) {
+	attributes, err := NewAttributes(attrs)
+	if err != nil && !IsInvalidAttribute(err) {
+		return
+	}
+	if attributes.RoutingHTTPXFFHeaderProcessingMode != XFFHeaderProcessingModeRemove {
+		return
+	}
+
+	for _, backend := range sortedBackends(tgGroup) {
+		service, err := controller.store.GetService(ingress.Namespace + "/" + backend.ServiceName)
+		if err != nil || service.Spec.ExternalTrafficPolicy != corev1.ServiceExternalTrafficPolicyTypeLocal {
+			continue
+		}
+		albctx.GetLogger(ctx).Warnf("service %v sets externalTrafficPolicy to %v to preserve client IP, while %v=%v removes the X-Forwarded-For header that carries client IP",
+			backend.ServiceName, service.Spec.ExternalTrafficPolicy, RoutingHTTPXFFHeaderProcessingModeKey, attributes.RoutingHTTPXFFHeaderProcessingMode)
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "CLIENT_IP", "service %v sets externalTrafficPolicy to %v to preserve client IP, while %v=%v removes the X-Forwarded-For header that carries client IP",
+			backend.ServiceName, service.Spec.ExternalTrafficPolicy, RoutingHTTPXFFHeaderProcessingModeKey, attributes.RoutingHTTPXFFHeaderProcessingMode)
+	}
+}
@@ -0,0 +1,63 @@
+package lb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func Test_checkClientIPPreservation(t *testing.T) {
+	for _, tc := range []struct {
+		name                  string
+		attributes            []*elbv2.LoadBalancerAttribute
+		externalTrafficPolicy corev1.ServiceExternalTrafficPolicyType
+		expectedEvents        []string
+	}{
+		{
+			name:                  "X-Forwarded-For appended",
+			externalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
+		},
+		{
+			name:                  "X-Forwarded-For removed for service not expecting client IP",
+			attributes:            []*elbv2.LoadBalancerAttribute{lbAttribute(RoutingHTTPXFFHeaderProcessingModeKey, "remove")},
+			externalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeCluster,
+		},
+		{
+			name:                  "X-Forwarded-For removed for service expecting client IP",
+			attributes:            []*elbv2.LoadBalancerAttribute{lbAttribute(RoutingHTTPXFFHeaderProcessingModeKey, "remove")},
+			externalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
+			expectedEvents: []string{
+				"Warning CLIENT_IP service service sets externalTrafficPolicy to Local to preserve client IP, while routing.http.xff_header_processing.mode=remove removes the X-Forwarded-For header that carries client IP",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockStore := store.NewDummy()
+			mockStore.GetServiceFunc = func(string) (*corev1.Service, error) {
+				return &corev1.Service{Spec: corev1.ServiceSpec{ExternalTrafficPolicy: tc.externalTrafficPolicy}}, nil
+			}
+
+			var events []string
+			ctx := albctx.SetEventf(context.Background(), func(eventType, reason, format string, vals ...interface{}) {
+				events = append(events, fmt.Sprintf("%v %v %v", eventType, reason, fmt.Sprintf(format, vals...)))
+			})
+			controller := &defaultController{store: mockStore}
+			controller.checkClientIPPreservation(ctx, &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}, tc.attributes, tg.TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{
+					{ServiceName: "service", ServicePort: intstr.FromInt(80)}: {TargetType: elbv2.TargetTypeEnumInstance},
+				},
+			})
+			assert.Equal(t, tc.expectedEvents, events)
+		})
+	}
+}
@@ -8,8 +8,10 @@ import (
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/cert"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/ls"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/sg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
@@ -17,16 +19,29 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/policy"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/keylock"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+const (
+	// TagKeyPrivateLink is the tag key that marks internal LoadBalancers shared to other VPCs through PrivateLink,
+	// so that the automation which fronts them with a VPC Endpoint Service can discover them.
+	TagKeyPrivateLink = "kubernetes.io/privatelink"
+
+	// TagValuePrivateLinkShared is the value of TagKeyPrivateLink for LoadBalancers shared through PrivateLink.
+	TagValuePrivateLinkShared = "shared"
+)
+
 // LoadBalancerController manages loadBalancer for ingress objects
 type Controller interface {
 	// Reconcile will make sure an LoadBalancer exists for specified ingress.
@@ -34,6 +49,13 @@ type Controller interface {
 
 	// Deletes will ensure no LoadBalancer exists for specified ingressKey.
 	Delete(ctx context.Context, ingressKey types.NamespacedName) error
+
+	// Verify checks whether the AWS resources in a previously applied LoadBalancer model still exist as described.
+	Verify(ctx context.Context, model *LoadBalancer) error
+
+	// ReconcileRestored reconciles the targets, listeners and rules of ingress on the AWS resources of a verified model,
+	// skipping the LoadBalancer and targetGroup settings the model vouches for.
+	ReconcileRestored(ctx context.Context, ingress *extensions.Ingress, model *LoadBalancer) error
 }
 
 func NewController(
@@ -43,18 +65,26 @@ func NewController(
 	tgGroupController tg.GroupController,
 	lsGroupController ls.GroupController,
 	sgAssociationController sg.AssociationController,
-	tagsController tags.Controller) Controller {
-	attrsController := NewAttributesController(cloud)
+	tagsController tags.Controller,
+	certController cert.Controller,
+	policy *policy.Policy) Controller {
+	attrsController := NewAttributesController(cloud, store.GetConfig().StrictAttributes)
+	alarmsController := NewAlarmsController(cloud)
+	accessLogsBucketController := NewAccessLogsBucketController(cloud)
 
 	return &defaultController{
-		cloud:                   cloud,
-		store:                   store,
-		nameTagGen:              nameTagGen,
-		tgGroupController:       tgGroupController,
-		lsGroupController:       lsGroupController,
-		sgAssociationController: sgAssociationController,
-		tagsController:          tagsController,
-		attrsController:         attrsController,
+		cloud:                      cloud,
+		store:                      store,
+		nameTagGen:                 nameTagGen,
+		tgGroupController:          tgGroupController,
+		lsGroupController:          lsGroupController,
+		sgAssociationController:    sgAssociationController,
+		tagsController:             tagsController,
+		certController:             certController,
+		attrsController:            attrsController,
+		alarmsController:           alarmsController,
+		accessLogsBucketController: accessLogsBucketController,
+		policy:                     policy,
 	}
 }
 
@@ -66,18 +96,29 @@ type loadBalancerConfig struct {
 	Scheme        *string
 	IpAddressType *string
 	Subnets       []string
+
+	// DiscoveredSubnets are the subnets found by subnet auto discovery by availability zone, nil if subnets are specified by annotation.
+	DiscoveredSubnets map[string]string
 }
 
 type defaultController struct {
 	cloud aws.CloudAPI
 	store store.Storer
 
-	nameTagGen              NameTagGenerator
-	tgGroupController       tg.GroupController
-	lsGroupController       ls.GroupController
-	sgAssociationController sg.AssociationController
-	tagsController          tags.Controller
-	attrsController         AttributesController
+	nameTagGen                 NameTagGenerator
+	tgGroupController          tg.GroupController
+	lsGroupController          ls.GroupController
+	sgAssociationController    sg.AssociationController
+	tagsController             tags.Controller
+	attrsController            AttributesController
+	alarmsController           AlarmsController
+	accessLogsBucketController AccessLogsBucketController
+
+	// certController imports or issues certificates for TLS hosts of ingresses, nil if disabled.
+	certController cert.Controller
+
+	// policy is the org policy LoadBalancers must conform to, nil if there is none.
+	policy *policy.Policy
 }
 
 var _ Controller = (*defaultController)(nil)
@@ -94,40 +135,197 @@ func (controller *defaultController) Reconcile(ctx context.Context, ingress *ext
 	if err := controller.validateLBConfig(ctx, ingress, lbConfig); err != nil {
 		return nil, err
 	}
+	if err := controller.checkPolicy(ctx, ingressAnnos, lbConfig.Tags); err != nil {
+		return nil, err
+	}
+	if err := controller.checkPlaintext(ctx, ingressAnnos); err != nil {
+		return nil, err
+	}
 
-	instance, err := controller.ensureLBInstance(ctx, lbConfig)
+	instance, err := controller.ensureLBInstance(ctx, ingress, lbConfig)
 	if err != nil {
 		return nil, err
 	}
 	lbArn := aws.StringValue(instance.LoadBalancerArn)
+	provisioning := instance.State != nil && aws.StringValue(instance.State.Code) == elbv2.LoadBalancerStateEnumProvisioning
+	if err := controller.reconcileLogsBuckets(ctx, ingressAnnos); err != nil {
+		return nil, err
+	}
 	if err := controller.attrsController.Reconcile(ctx, lbArn, ingressAnnos.LoadBalancer.Attributes); err != nil {
 		return nil, fmt.Errorf("failed to reconcile attributes of %v due to %v", lbArn, err)
 	}
 
-	if controller.store.GetConfig().FeatureGate.Enabled(config.WAF) {
-		if err := controller.reconcileWAF(ctx, lbArn, ingressAnnos.LoadBalancer.WebACLId); err != nil {
-			return nil, err
-		}
-	}
-
 	tgGroup, err := controller.tgGroupController.Reconcile(ctx, ingress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to reconcile targetGroups due to %v", err)
 	}
-	if err := controller.lsGroupController.Reconcile(ctx, lbArn, ingress, tgGroup); err != nil {
+	certificateARNs, err := controller.reconcileCertificates(ctx, ingress, ingressAnnos)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile certificates due to %v", err)
+	}
+	if err := controller.lsGroupController.Reconcile(ctx, lbArn, ingress, tgGroup, certificateARNs); err != nil {
 		return nil, fmt.Errorf("failed to reconcile listeners due to %v", err)
 	}
 	if err := controller.tgGroupController.GC(ctx, tgGroup); err != nil {
 		return nil, fmt.Errorf("failed to GC targetGroups due to %v", err)
 	}
+	if controller.certController != nil {
+		ingressKey := types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}
+		if err := controller.certController.GC(ctx, ingressKey, certificateARNs); err != nil {
+			return nil, fmt.Errorf("failed to GC certificates due to %v", err)
+		}
+	}
+	if err := controller.alarmsController.Reconcile(ctx, lbConfig.Name, lbArn,
+		ingressAnnos.LoadBalancer.AlarmThresholds, ingressAnnos.LoadBalancer.AlarmTopicArn, tgGroup); err != nil {
+		return nil, fmt.Errorf("failed to reconcile alarms of %v due to %v", lbArn, err)
+	}
+
+	if provisioning && controller.store.GetConfig().LBActiveTimeout > 0 {
+		if err := controller.waitForActive(ctx, lbArn); err != nil {
+			return nil, err
+		}
+		provisioning = false
+	}
+	if provisioning {
+		albctx.RecordWait(ctx, lbArn, albctx.WaitStateProvisioning)
+	}
+	// webACLs cannot be associated with LoadBalancers until they are active, it's done on requeue.
+	if controller.store.GetConfig().FeatureGate.Enabled(config.WAF) && !provisioning {
+		if err := controller.reconcileWAF(ctx, lbArn, ingressAnnos.LoadBalancer.WebACLId); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := controller.sgAssociationController.Reconcile(ctx, ingress, instance, tgGroup); err != nil {
 		return nil, fmt.Errorf("failed to reconcile securityGroup associations due to %v", err)
 	}
+	controller.checkTargetZones(ctx, ingress, instance, tgGroup)
+	controller.checkClientIPPreservation(ctx, ingress, ingressAnnos.LoadBalancer.Attributes, tgGroup)
+	return buildLoadBalancer(instance, lbConfig, ingressAnnos, tgGroup), nil
+}
+
+// reconcileCertificates returns the certificates issued for TLS hosts of ingress,
+// which are only needed by https listeners without certificate-arn annotation.
+func (controller *defaultController) reconcileCertificates(ctx context.Context, ingress *extensions.Ingress, ingressAnnos *annotations.Ingress) ([]string, error) {
+	if controller.certController == nil {
+		return nil, nil
+	}
+	if _, ok := ingress.Annotations[parser.GetAnnotationWithPrefix(ls.AnnotationCertificateARN)]; ok {
+		return nil, nil
+	}
+	for _, port := range ingressAnnos.LoadBalancer.Ports {
+		if port.Scheme == elbv2.ProtocolEnumHttps {
+			return controller.certController.Reconcile(ctx, ingress)
+		}
+	}
+	return nil, nil
+}
+
+// checkPolicy emits events for violations of policy by the LoadBalancer of ingress and lbTags, the tags applied to its resources,
+// and returns an error if any violation has error severity.
+func (controller *defaultController) checkPolicy(ctx context.Context, ingressAnnos *annotations.Ingress, lbTags map[string]string) error {
+	var blocking []string
+	for _, violation := range controller.policy.Evaluate(ingressAnnos.LoadBalancer, lbTags) {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "POLICY_VIOLATION", "%v (severity %v)", violation, violation.Rule.Severity)
+		if violation.Rule.Severity == policy.SeverityError {
+			blocking = append(blocking, violation.String())
+		}
+	}
+	if len(blocking) != 0 {
+		return fmt.Errorf("LoadBalancer violates policy rules: %v", strings.Join(blocking, "; "))
+	}
+	return nil
+}
+
+// reconcileLogsBuckets prepares the buckets that access logs and connection logs of LoadBalancer are delivered to, if they're managed by controller.
+// It must happen before attributes are modified, since ELB verifies it can write to the buckets when logs are enabled.
+func (controller *defaultController) reconcileLogsBuckets(ctx context.Context, ingressAnnos *annotations.Ingress) error {
+	if !ingressAnnos.LoadBalancer.AccessLogsBucketManaged {
+		return nil
+	}
+	attrs, err := NewAttributes(ingressAnnos.LoadBalancer.Attributes)
+	if err != nil {
+		return fmt.Errorf("failed parsing attributes; %v", err)
+	}
+	type destination struct {
+		bucket string
+		prefix string
+	}
+	var destinations []destination
+	if attrs.AccessLogsS3Enabled && attrs.AccessLogsS3Bucket != "" {
+		destinations = append(destinations, destination{attrs.AccessLogsS3Bucket, attrs.AccessLogsS3Prefix})
+	}
+	if attrs.ConnectionLogsS3Enabled && attrs.ConnectionLogsS3Bucket != "" {
+		destinations = append(destinations, destination{attrs.ConnectionLogsS3Bucket, attrs.ConnectionLogsS3Prefix})
+	}
+	reconciled := make(map[destination]bool, len(destinations))
+	for _, d := range destinations {
+		if reconciled[d] {
+			continue
+		}
+		reconciled[d] = true
+		if err := controller.accessLogsBucketController.Reconcile(ctx, d.bucket, d.prefix,
+			ingressAnnos.LoadBalancer.AccessLogsExpirationDays); err != nil {
+			return fmt.Errorf("failed to reconcile logs bucket %v due to %v", d.bucket, err)
+		}
+	}
+	return nil
+}
+
+// waitForActive waits until LoadBalancer becomes active within LBActiveTimeout, so that ingress status only reports LoadBalancers that are serving.
+func (controller *defaultController) waitForActive(ctx context.Context, lbArn string) error {
+	timeout := controller.store.GetConfig().LBActiveTimeout
+	albctx.GetLogger(ctx).Infof("waiting up to %v for LoadBalancer %v to become active", timeout, lbArn)
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "WAITING", "waiting up to %v for LoadBalancer %v to become active", timeout, lbArn)
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := controller.cloud.WaitLoadBalancerActive(waitCtx, lbArn); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "LoadBalancer %v did not become active within %v", lbArn, timeout)
+		return fmt.Errorf("failed waiting for LoadBalancer %v to become active due to %v", lbArn, err)
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "ACTIVE", "LoadBalancer %v is active", lbArn)
+	return nil
+}
+
+// buildLoadBalancer builds the model of AWS resources that have been applied for an ingress.
+func buildLoadBalancer(instance *elbv2.LoadBalancer, lbConfig *loadBalancerConfig, ingressAnnos *annotations.Ingress, tgGroup tg.TargetGroupGroup) *LoadBalancer {
+	listeners := make([]Listener, 0, len(ingressAnnos.LoadBalancer.Ports))
+	for _, port := range ingressAnnos.LoadBalancer.Ports {
+		listeners = append(listeners, Listener{
+			Port:     port.Port,
+			Protocol: port.Scheme,
+		})
+	}
+	sort.Slice(listeners, func(i, j int) bool {
+		return listeners[i].Port < listeners[j].Port
+	})
+
+	targetGroups := make([]TargetGroup, 0, len(tgGroup.TGByBackend))
+	for backend, targetGroup := range tgGroup.TGByBackend {
+		targetGroups = append(targetGroups, TargetGroup{
+			ServiceName: backend.ServiceName,
+			ServicePort: backend.ServicePort.String(),
+			Arn:         targetGroup.Arn,
+			TargetType:  targetGroup.TargetType,
+		})
+	}
+	sort.Slice(targetGroups, func(i, j int) bool {
+		return targetGroups[i].Arn < targetGroups[j].Arn
+	})
+
+	subnets := append([]string(nil), lbConfig.Subnets...)
+	sort.Strings(subnets)
 	return &LoadBalancer{
-		Arn:     lbArn,
-		DNSName: aws.StringValue(instance.DNSName),
-	}, nil
+		Arn:           aws.StringValue(instance.LoadBalancerArn),
+		DNSName:       aws.StringValue(instance.DNSName),
+		Name:          lbConfig.Name,
+		Scheme:        aws.StringValue(lbConfig.Scheme),
+		IPAddressType: aws.StringValue(lbConfig.IpAddressType),
+		Subnets:       subnets,
+		Listeners:     listeners,
+		TargetGroups:  targetGroups,
+	}
 }
 
 func (controller *defaultController) Delete(ctx context.Context, ingressKey types.NamespacedName) error {
@@ -143,10 +341,22 @@ func (controller *defaultController) Delete(ctx context.Context, ingressKey type
 		if err = controller.lsGroupController.Delete(ctx, aws.StringValue(instance.LoadBalancerArn)); err != nil {
 			return fmt.Errorf("failed to delete listeners due to %v", err)
 		}
-		if err = controller.tgGroupController.Delete(ctx, ingressKey); err != nil {
-			return fmt.Errorf("failed to GC targetGroups due to %v", err)
+	}
+	// certificates can only be deleted once listeners no longer use them, they're deleted even if the LoadBalancer is already gone.
+	if controller.certController != nil {
+		if err = controller.certController.Delete(ctx, ingressKey); err != nil {
+			return fmt.Errorf("failed to delete certificates due to %v", err)
 		}
-
+	}
+	// alarms are named after the LoadBalancer, they're deleted even if the LoadBalancer is already gone.
+	if err = controller.alarmsController.Delete(ctx, lbName); err != nil {
+		return fmt.Errorf("failed to delete alarms due to %v", err)
+	}
+	// targetGroups still in use are left for a later Delete, which happens after the LoadBalancer is gone.
+	if err = controller.tgGroupController.Delete(ctx, ingressKey); err != nil {
+		return fmt.Errorf("failed to GC targetGroups due to %v", err)
+	}
+	if instance != nil {
 		albctx.GetLogger(ctx).Infof("deleting LoadBalancer %v", aws.StringValue(instance.LoadBalancerArn))
 		if err = controller.cloud.DeleteLoadBalancerByArn(ctx, aws.StringValue(instance.LoadBalancerArn)); err != nil {
 			return err
@@ -156,7 +366,59 @@ func (controller *defaultController) Delete(ctx context.Context, ingressKey type
 	return nil
 }
 
-func (controller *defaultController) ensureLBInstance(ctx context.Context, lbConfig *loadBalancerConfig) (*elbv2.LoadBalancer, error) {
+func (controller *defaultController) Verify(ctx context.Context, model *LoadBalancer) error {
+	instance, err := controller.cloud.GetLoadBalancerByArn(ctx, model.Arn)
+	if err != nil {
+		return fmt.Errorf("failed to find LoadBalancer %v due to %v", model.Arn, err)
+	}
+	if instance == nil {
+		return fmt.Errorf("LoadBalancer %v no longer exists", model.Arn)
+	}
+	if aws.StringValue(instance.DNSName) != model.DNSName || aws.StringValue(instance.Scheme) != model.Scheme {
+		return fmt.Errorf("LoadBalancer %v has been modified", model.Arn)
+	}
+	for _, targetGroup := range model.TargetGroups {
+		instance, err := controller.cloud.GetTargetGroupByArn(ctx, targetGroup.Arn)
+		if err != nil {
+			return fmt.Errorf("failed to find targetGroup %v due to %v", targetGroup.Arn, err)
+		}
+		if instance == nil {
+			return fmt.Errorf("targetGroup %v no longer exists", targetGroup.Arn)
+		}
+	}
+	return nil
+}
+
+func (controller *defaultController) ReconcileRestored(ctx context.Context, ingress *extensions.Ingress, model *LoadBalancer) error {
+	ingressAnnos, err := controller.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
+	if err != nil {
+		return err
+	}
+	existing := make(map[extensions.IngressBackend]tg.TargetGroup, len(model.TargetGroups))
+	for _, targetGroup := range model.TargetGroups {
+		backend := extensions.IngressBackend{ServiceName: targetGroup.ServiceName, ServicePort: intstr.Parse(targetGroup.ServicePort)}
+		existing[backend] = tg.TargetGroup{Arn: targetGroup.Arn, TargetType: targetGroup.TargetType}
+	}
+	tgGroup, err := controller.tgGroupController.ReconcileTargets(ctx, ingress, existing)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile targets due to %v", err)
+	}
+	certificateARNs, err := controller.reconcileCertificates(ctx, ingress, ingressAnnos)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile certificates due to %v", err)
+	}
+	if err := controller.lsGroupController.Reconcile(ctx, model.Arn, ingress, tgGroup, certificateARNs); err != nil {
+		return fmt.Errorf("failed to reconcile listeners due to %v", err)
+	}
+	return nil
+}
+
+// lbNameLock serializes the lookup and creation of LoadBalancers with the same name, so concurrent reconciles never race to create them.
+var lbNameLock = keylock.New()
+
+func (controller *defaultController) ensureLBInstance(ctx context.Context, ingress *extensions.Ingress, lbConfig *loadBalancerConfig) (*elbv2.LoadBalancer, error) {
+	lbNameLock.Lock(lbConfig.Name)
+	defer lbNameLock.Unlock(lbConfig.Name)
 	instance, err := controller.cloud.GetLoadBalancerByName(ctx, lbConfig.Name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find existing LoadBalancer due to %v", err)
@@ -175,13 +437,26 @@ func (controller *defaultController) ensureLBInstance(ctx context.Context, lbCon
 		}
 		return instance, nil
 	}
-	if err := controller.reconcileLBInstance(ctx, instance, lbConfig); err != nil {
+	if err := controller.reconcileLBInstance(ctx, ingress, instance, lbConfig); err != nil {
 		return nil, err
 	}
 	return instance, nil
 }
 
 func (controller *defaultController) newLBInstance(ctx context.Context, lbConfig *loadBalancerConfig) (*elbv2.LoadBalancer, error) {
+	subnets, err := controller.cloud.GetSubnetsByNameOrID(ctx, lbConfig.Subnets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch subnets due to %v", err)
+	}
+	if err := controller.validateSubnetZones(ctx, subnets); err != nil {
+		return nil, err
+	}
+	if err := controller.validateSubnetScheme(ctx, lbConfig.Name, aws.StringValue(lbConfig.Scheme), subnets); err != nil {
+		return nil, err
+	}
+	if err := controller.checkSubnetCapacity(ctx, lbConfig.Name, lbConfig.Subnets); err != nil {
+		return nil, err
+	}
 	albctx.GetLogger(ctx).Infof("creating LoadBalancer %v", lbConfig.Name)
 	resp, err := controller.cloud.CreateLoadBalancerWithContext(ctx, &elbv2.CreateLoadBalancerInput{
 		Name:          aws.String(lbConfig.Name),
@@ -191,6 +466,9 @@ func (controller *defaultController) newLBInstance(ctx context.Context, lbConfig
 		Subnets:       aws.StringSlice(lbConfig.Subnets),
 		Tags:          tags.ConvertToELBV2(lbConfig.Tags),
 	})
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == elbv2.ErrCodeDuplicateLoadBalancerNameException {
+		return controller.adoptLBInstance(ctx, lbConfig)
+	}
 	if err != nil {
 		albctx.GetLogger(ctx).Errorf("failed to create LoadBalancer %v due to %v", lbConfig.Name, err)
 		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to create LoadBalancer %v due to %v", lbConfig.Name, err)
@@ -203,6 +481,30 @@ func (controller *defaultController) newLBInstance(ctx context.Context, lbConfig
 	return instance, nil
 }
 
+// adoptLBInstance returns the LoadBalancer named by lbConfig that already exists when creating it, e.g. because a previous create
+// succeeded but its response was lost. The LoadBalancer is only adopted if it has the tags of lbConfig, so LoadBalancers
+// not created for the ingress are never taken over.
+func (controller *defaultController) adoptLBInstance(ctx context.Context, lbConfig *loadBalancerConfig) (*elbv2.LoadBalancer, error) {
+	instance, err := controller.cloud.GetLoadBalancerByName(ctx, lbConfig.Name)
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		return nil, fmt.Errorf("LoadBalancer %v already exists but can't be found", lbConfig.Name)
+	}
+	lbArn := aws.StringValue(instance.LoadBalancerArn)
+	lbTags, err := tags.DescribeELB(ctx, controller.cloud, lbArn)
+	if err != nil {
+		return nil, err
+	}
+	if !tags.Contains(lbTags, lbConfig.Tags) {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "LoadBalancer %v already exists and isn't tagged for this ingress, ARN: %v", lbConfig.Name, lbArn)
+		return nil, fmt.Errorf("LoadBalancer %v already exists and isn't tagged for this ingress, ARN: %v", lbConfig.Name, lbArn)
+	}
+	albctx.GetLogger(ctx).Infof("LoadBalancer %v already exists with the tags of this ingress, adopting it, ARN: %v", lbConfig.Name, lbArn)
+	return instance, nil
+}
+
 func (controller *defaultController) recreateLBInstance(ctx context.Context, existingInstance *elbv2.LoadBalancer, lbConfig *loadBalancerConfig) (*elbv2.LoadBalancer, error) {
 	existingLBArn := aws.StringValue(existingInstance.LoadBalancerArn)
 	albctx.GetLogger(ctx).Infof("deleting LoadBalancer %v for recreation", existingLBArn)
@@ -212,28 +514,43 @@ func (controller *defaultController) recreateLBInstance(ctx context.Context, exi
 	return controller.newLBInstance(ctx, lbConfig)
 }
 
-func (controller *defaultController) reconcileLBInstance(ctx context.Context, instance *elbv2.LoadBalancer, lbConfig *loadBalancerConfig) error {
+func (controller *defaultController) reconcileLBInstance(ctx context.Context, ingress *extensions.Ingress, instance *elbv2.LoadBalancer, lbConfig *loadBalancerConfig) error {
 	lbArn := aws.StringValue(instance.LoadBalancerArn)
 	if !util.DeepEqual(instance.IpAddressType, lbConfig.IpAddressType) {
 		albctx.GetLogger(ctx).Infof("modifying LoadBalancer %v due to IpAddressType change (%v => %v)", lbArn, aws.StringValue(instance.IpAddressType), aws.StringValue(lbConfig.IpAddressType))
-		if _, err := controller.cloud.SetIpAddressTypeWithContext(ctx, &elbv2.SetIpAddressTypeInput{
+		in := &elbv2.SetIpAddressTypeInput{
 			LoadBalancerArn: instance.LoadBalancerArn,
 			IpAddressType:   lbConfig.IpAddressType,
-		}); err != nil {
+		}
+		albctx.RecordAuditBefore(ctx, in, &elbv2.SetIpAddressTypeInput{
+			LoadBalancerArn: instance.LoadBalancerArn,
+			IpAddressType:   instance.IpAddressType,
+		})
+		if _, err := controller.cloud.SetIpAddressTypeWithContext(ctx, in); err != nil {
 			albctx.GetEventf(ctx)(corev1.EventTypeNormal, "ERROR", "failed to modify IpAddressType of %v due to %v", lbArn, err)
 			return fmt.Errorf("failed to modify IpAddressType of %v due to %v", lbArn, err)
 		}
 		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "IpAddressType of %v modified", lbArn)
 	}
 
+	lbConfig.Subnets = controller.expandableSubnets(ctx, instance, lbConfig)
 	desiredSubnets := sets.NewString(lbConfig.Subnets...)
 	currentSubnets := sets.NewString(aws.StringValueSlice(util.AvailabilityZones(instance.AvailabilityZones).AsSubnets())...)
 	if !currentSubnets.Equal(desiredSubnets) {
+		if err := controller.validateSubnetChange(ctx, ingress, instance, lbConfig.Subnets); err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to modify Subnets of %v due to %v", lbArn, err)
+			return fmt.Errorf("failed to modify Subnets of %v due to %v", lbArn, err)
+		}
 		albctx.GetLogger(ctx).Infof("modifying LoadBalancer %v due to Subnets change (%v => %v)", lbArn, currentSubnets.List(), desiredSubnets.List())
-		if _, err := controller.cloud.SetSubnetsWithContext(ctx, &elbv2.SetSubnetsInput{
+		in := &elbv2.SetSubnetsInput{
 			LoadBalancerArn: instance.LoadBalancerArn,
 			Subnets:         aws.StringSlice(lbConfig.Subnets),
-		}); err != nil {
+		}
+		albctx.RecordAuditBefore(ctx, in, &elbv2.SetSubnetsInput{
+			LoadBalancerArn: instance.LoadBalancerArn,
+			Subnets:         aws.StringSlice(currentSubnets.List()),
+		})
+		if _, err := controller.cloud.SetSubnetsWithContext(ctx, in); err != nil {
 			albctx.GetEventf(ctx)(corev1.EventTypeNormal, "ERROR", "failed to modify Subnets of %v due to %v", lbArn, err)
 			return fmt.Errorf("failed to modify Subnets of %v due to %v", lbArn, err)
 		}
@@ -301,7 +618,10 @@ func (controller *defaultController) buildLBConfig(ctx context.Context, ingress
 	for k, v := range ingressAnnos.Tags.LoadBalancer {
 		lbTags[k] = v
 	}
-	subnets, err := controller.resolveSubnets(ctx, aws.StringValue(ingressAnnos.LoadBalancer.Scheme), ingressAnnos.LoadBalancer.Subnets)
+	if ingressAnnos.LoadBalancer.PrivateLink {
+		lbTags[TagKeyPrivateLink] = TagValuePrivateLinkShared
+	}
+	subnets, discoveredSubnets, err := controller.resolveSubnets(ctx, aws.StringValue(ingressAnnos.LoadBalancer.Scheme), ingressAnnos.LoadBalancer.Subnets)
 	if err != nil {
 		return nil, err
 	}
@@ -313,6 +633,8 @@ func (controller *defaultController) buildLBConfig(ctx context.Context, ingress
 		Scheme:        ingressAnnos.LoadBalancer.Scheme,
 		IpAddressType: ingressAnnos.LoadBalancer.IPAddressType,
 		Subnets:       subnets,
+
+		DiscoveredSubnets: discoveredSubnets,
 	}, nil
 }
 
@@ -334,11 +656,20 @@ func (controller *defaultController) validateLBConfig(ctx context.Context, ingre
 	return nil
 }
 
-func (controller *defaultController) resolveSubnets(ctx context.Context, scheme string, in []string) ([]string, error) {
+// resolveSubnets resolves the IDs of subnets specified by name or ID, or discovers subnets if none is specified.
+// Discovered subnets are also returned by availability zone.
+func (controller *defaultController) resolveSubnets(ctx context.Context, scheme string, in []string) ([]string, map[string]string, error) {
 	if len(in) == 0 {
-		subnets, err := controller.clusterSubnets(ctx, scheme)
-		return subnets, err
-
+		subnetByZone, err := controller.clusterSubnets(ctx, scheme)
+		if err != nil {
+			return nil, nil, err
+		}
+		subnets := make([]string, 0, len(subnetByZone))
+		for _, subnet := range subnetByZone {
+			subnets = append(subnets, subnet)
+		}
+		sort.Strings(subnets)
+		return subnets, subnetByZone, nil
 	}
 
 	var names []string
@@ -355,7 +686,7 @@ func (controller *defaultController) resolveSubnets(ctx context.Context, scheme
 	if len(names) > 0 {
 		o, err := controller.cloud.GetSubnetsByNameOrID(ctx, names)
 		if err != nil {
-			return subnets, err
+			return subnets, nil, err
 		}
 
 		for _, subnet := range o {
@@ -365,17 +696,19 @@ func (controller *defaultController) resolveSubnets(ctx context.Context, scheme
 
 	sort.Strings(subnets)
 	if len(subnets) != len(in) {
-		return subnets, fmt.Errorf("not all subnets were resolvable, (%v != %v)", strings.Join(in, ","), strings.Join(subnets, ","))
+		return subnets, nil, fmt.Errorf("not all subnets were resolvable, (%v != %v)", strings.Join(in, ","), strings.Join(subnets, ","))
 	}
 
-	return subnets, nil
+	return subnets, nil, nil
 }
 
-func (controller *defaultController) clusterSubnets(ctx context.Context, scheme string) ([]string, error) {
+// clusterSubnets discovers the subnets tagged for LoadBalancers of scheme, one per availability zone.
+func (controller *defaultController) clusterSubnets(ctx context.Context, scheme string) (map[string]string, error) {
 	var subnetIds []string
 	var useableSubnets []*ec2.Subnet
 	var out []string
 	var key string
+	subnetByZone := make(map[string]string)
 
 	if scheme == elbv2.LoadBalancerSchemeEnumInternal {
 		key = aws.TagNameSubnetInternalELB
@@ -405,10 +738,11 @@ func (controller *defaultController) clusterSubnets(ctx context.Context, scheme
 		return nil, fmt.Errorf("unable to fetch subnets due to %v", err)
 	}
 
-	for _, subnet := range o {
+	for _, subnet := range controller.discoverableSubnets(ctx, o) {
 		if subnetIsUsable(subnet, useableSubnets) {
 			useableSubnets = append(useableSubnets, subnet)
 			out = append(out, aws.StringValue(subnet.SubnetId))
+			subnetByZone[aws.StringValue(subnet.AvailabilityZone)] = aws.StringValue(subnet.SubnetId)
 		}
 	}
 
@@ -422,8 +756,7 @@ func (controller *defaultController) clusterSubnets(ctx context.Context, scheme
 			log.Prettify(out))
 	}
 
-	sort.Strings(out)
-	return out, nil
+	return subnetByZone, nil
 }
 
 // subnetIsUsable determines if the subnet shares the same availability zone as a subnet in the
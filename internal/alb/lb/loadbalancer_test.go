@@ -1 +1,62 @@
 package lb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_waitForActive(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		waitErr        error
+		expectedErr    error
+		expectedEvents []string
+	}{
+		{
+			name: "LoadBalancer becomes active",
+			expectedEvents: []string{
+				"Normal WAITING waiting up to 5m0s for LoadBalancer lbArn to become active",
+				"Normal ACTIVE LoadBalancer lbArn is active",
+			},
+		},
+		{
+			name:        "LoadBalancer doesn't become active in time",
+			waitErr:     errors.New("RequestCanceled"),
+			expectedErr: errors.New("failed waiting for LoadBalancer lbArn to become active due to RequestCanceled"),
+			expectedEvents: []string{
+				"Normal WAITING waiting up to 5m0s for LoadBalancer lbArn to become active",
+				"Warning ERROR LoadBalancer lbArn did not become active within 5m0s",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockStore := store.NewDummy()
+			mockStore.SetConfig(&config.Configuration{LBActiveTimeout: 5 * time.Minute})
+			cloud := &mocks.CloudAPI{}
+			cloud.On("WaitLoadBalancerActive", mock.Anything, "lbArn").Return(tc.waitErr)
+
+			var events []string
+			ctx := albctx.SetEventf(context.Background(), func(eventType, reason, format string, vals ...interface{}) {
+				events = append(events, fmt.Sprintf("%v %v %v", eventType, reason, fmt.Sprintf(format, vals...)))
+			})
+			controller := &defaultController{cloud: cloud, store: mockStore}
+			err := controller.waitForActive(ctx, "lbArn")
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedEvents, events)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
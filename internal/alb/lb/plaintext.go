@@ -0,0 +1,40 @@
+package lb
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// checkPlaintext emits an event and returns an error if ingress has HTTP listen ports while controller only allows HTTPS listeners.
+// HTTP listen ports are allowed along with an HTTPS listen port if controller redirects them to HTTPS.
+func (controller *defaultController) checkPlaintext(ctx context.Context, ingressAnnos *annotations.Ingress) error {
+	cfg := controller.store.GetConfig()
+	if !cfg.HTTPSOnly {
+		return nil
+	}
+	var httpPorts []string
+	hasHTTPS := false
+	for _, port := range ingressAnnos.LoadBalancer.Ports {
+		if port.Scheme == elbv2.ProtocolEnumHttps {
+			hasHTTPS = true
+		} else {
+			httpPorts = append(httpPorts, strconv.FormatInt(port.Port, 10))
+		}
+	}
+	if len(httpPorts) == 0 || (cfg.HTTPSOnlyRedirect && hasHTTPS) {
+		return nil
+	}
+	reason := "only HTTPS listeners are allowed"
+	if cfg.HTTPSOnlyRedirect {
+		reason = "they're only allowed to redirect to an HTTPS listen port, but there is none"
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeWarning, "PLAINTEXT_DENIED", "HTTP listen ports %v are denied, %v", strings.Join(httpPorts, ","), reason)
+	return fmt.Errorf("HTTP listen ports %v are denied, %v", strings.Join(httpPorts, ","), reason)
+}
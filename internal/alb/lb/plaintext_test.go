@@ -0,0 +1,75 @@
+package lb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_checkPlaintext(t *testing.T) {
+	httpPort := loadbalancer.PortData{Port: 80, Scheme: elbv2.ProtocolEnumHttp}
+	httpsPort := loadbalancer.PortData{Port: 443, Scheme: elbv2.ProtocolEnumHttps}
+	for _, tc := range []struct {
+		name           string
+		cfg            config.Configuration
+		ports          []loadbalancer.PortData
+		expectedErr    error
+		expectedEvents []string
+	}{
+		{
+			name:  "HTTP allowed",
+			ports: []loadbalancer.PortData{httpPort},
+		},
+		{
+			name:  "HTTPS only",
+			cfg:   config.Configuration{HTTPSOnly: true},
+			ports: []loadbalancer.PortData{httpsPort},
+		},
+		{
+			name:        "HTTP denied",
+			cfg:         config.Configuration{HTTPSOnly: true},
+			ports:       []loadbalancer.PortData{httpPort, httpsPort},
+			expectedErr: errors.New("HTTP listen ports 80 are denied, only HTTPS listeners are allowed"),
+			expectedEvents: []string{
+				"Warning PLAINTEXT_DENIED HTTP listen ports 80 are denied, only HTTPS listeners are allowed",
+			},
+		},
+		{
+			name:  "HTTP redirected",
+			cfg:   config.Configuration{HTTPSOnly: true, HTTPSOnlyRedirect: true},
+			ports: []loadbalancer.PortData{httpPort, httpsPort},
+		},
+		{
+			name:        "HTTP denied without HTTPS to redirect to",
+			cfg:         config.Configuration{HTTPSOnly: true, HTTPSOnlyRedirect: true},
+			ports:       []loadbalancer.PortData{httpPort},
+			expectedErr: errors.New("HTTP listen ports 80 are denied, they're only allowed to redirect to an HTTPS listen port, but there is none"),
+			expectedEvents: []string{
+				"Warning PLAINTEXT_DENIED HTTP listen ports 80 are denied, they're only allowed to redirect to an HTTPS listen port, but there is none",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockStore := store.NewDummy()
+			mockStore.SetConfig(&tc.cfg)
+
+			var events []string
+			ctx := albctx.SetEventf(context.Background(), func(eventType, reason, format string, vals ...interface{}) {
+				events = append(events, fmt.Sprintf("%v %v %v", eventType, reason, fmt.Sprintf(format, vals...)))
+			})
+			controller := &defaultController{store: mockStore}
+			err := controller.checkPlaintext(ctx, &annotations.Ingress{LoadBalancer: &loadbalancer.Config{Ports: tc.ports}})
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedEvents, events)
+		})
+	}
+}
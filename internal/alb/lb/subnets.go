@@ -0,0 +1,243 @@
+package lb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// minSubnetFreeIPAddresses is the number of free IP addresses ALBs require in each of their subnets to scale.
+const minSubnetFreeIPAddresses = 8
+
+// checkSubnetCapacity ensures each subnet has enough free IP addresses for LoadBalancer, emitting an event naming the exhausted subnets otherwise.
+// AWS rejects such subnets with an error that doesn't tell which subnet is exhausted.
+func (controller *defaultController) checkSubnetCapacity(ctx context.Context, lbName string, subnetIDs []string) error {
+	if len(subnetIDs) == 0 {
+		return nil
+	}
+	subnets, err := controller.cloud.GetSubnetsByNameOrID(ctx, subnetIDs)
+	if err != nil {
+		return fmt.Errorf("failed to fetch subnets due to %v", err)
+	}
+	var exhausted []string
+	for _, subnet := range subnets {
+		freeIPAddresses := aws.Int64Value(subnet.AvailableIpAddressCount)
+		if freeIPAddresses >= minSubnetFreeIPAddresses {
+			continue
+		}
+		subnetID := aws.StringValue(subnet.SubnetId)
+		exhausted = append(exhausted, subnetID)
+		albctx.GetLogger(ctx).Warnf("subnet %v has %v free IP addresses, LoadBalancer %v requires at least %v", subnetID, freeIPAddresses, lbName, minSubnetFreeIPAddresses)
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "SUBNET_CAPACITY", "subnet %v has %v free IP addresses, LoadBalancer %v requires at least %v", subnetID, freeIPAddresses, lbName, minSubnetFreeIPAddresses)
+	}
+	if len(exhausted) != 0 {
+		return fmt.Errorf("subnets %v don't have %v free IP addresses required by LoadBalancer", exhausted, minSubnetFreeIPAddresses)
+	}
+	return nil
+}
+
+// validateSubnetZones ensures subnets are in at least 2 distinct availability zones.
+// Availability zones are compared by zone ID rather than name, since zone names of different accounts can map to the same zone,
+// e.g. for subnets shared by another account. Zones missing from the zone inventory are compared by name.
+// LoadBalancers don't support subnets in Wavelength Zones, nor mixing subnets in Local Zones with subnets in availability zones.
+func (controller *defaultController) validateSubnetZones(ctx context.Context, subnets []*ec2.Subnet) error {
+	var localZoneSubnets, zoneSubnets []string
+	for _, subnet := range subnets {
+		subnetID, zone := aws.StringValue(subnet.SubnetId), aws.StringValue(subnet.AvailabilityZone)
+		switch aws.ZoneType(zone) {
+		case aws.ZoneTypeWavelengthZone:
+			return fmt.Errorf("subnet %v is in Wavelength Zone %v, which LoadBalancers don't support", subnetID, zone)
+		case aws.ZoneTypeLocalZone:
+			localZoneSubnets = append(localZoneSubnets, subnetID)
+		default:
+			zoneSubnets = append(zoneSubnets, subnetID)
+		}
+	}
+	if len(localZoneSubnets) != 0 && len(zoneSubnets) != 0 {
+		return fmt.Errorf("subnets %v in Local Zones can't be mixed with subnets %v in availability zones", localZoneSubnets, zoneSubnets)
+	}
+
+	zoneIDByName, err := controller.cloud.GetZoneIDsByName(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch availability zones due to %v", err)
+	}
+	subnetByZoneID := make(map[string]string)
+	for _, subnet := range subnets {
+		zone := aws.StringValue(subnet.AvailabilityZone)
+		zoneID, ok := zoneIDByName[zone]
+		if !ok {
+			zoneID = zone
+		}
+		if other, ok := subnetByZoneID[zoneID]; ok {
+			return fmt.Errorf("subnets %v and %v are both in availability zone %v (%v)", other, aws.StringValue(subnet.SubnetId), zone, zoneID)
+		}
+		subnetByZoneID[zoneID] = aws.StringValue(subnet.SubnetId)
+	}
+	if len(subnetByZoneID) < 2 {
+		return fmt.Errorf("subnets must be in at least 2 availability zones")
+	}
+	return nil
+}
+
+// validateSubnetScheme ensures subnets agree with the scheme of LoadBalancer, emitting events naming the misconfigured subnets.
+// Internet-facing LoadBalancers require public subnets, i.e. subnets routing to an internet gateway, otherwise they're unreachable.
+// Internal LoadBalancers don't require public subnets, so using public subnets auto-assigning public IP addresses only raises a warning.
+func (controller *defaultController) validateSubnetScheme(ctx context.Context, lbName string, scheme string, subnets []*ec2.Subnet) error {
+	if scheme != elbv2.LoadBalancerSchemeEnumInternetFacing && scheme != elbv2.LoadBalancerSchemeEnumInternal {
+		return nil
+	}
+	subnetIDs := make([]string, 0, len(subnets))
+	for _, subnet := range subnets {
+		subnetIDs = append(subnetIDs, aws.StringValue(subnet.SubnetId))
+	}
+	routeTables, err := controller.cloud.GetRouteTablesBySubnetID(ctx, subnetIDs)
+	if err != nil {
+		return fmt.Errorf("failed to fetch route tables due to %v", err)
+	}
+
+	var misconfigured []string
+	for _, subnet := range subnets {
+		subnetID := aws.StringValue(subnet.SubnetId)
+		routeTable := routeTables[subnetID]
+		if scheme == elbv2.LoadBalancerSchemeEnumInternetFacing && !routesToInternetGateway(routeTable) {
+			misconfigured = append(misconfigured, subnetID)
+			if routeTable == nil {
+				albctx.GetEventf(ctx)(corev1.EventTypeWarning, "SUBNET_SCHEME", "subnet %v of internet-facing LoadBalancer %v has no route table", subnetID, lbName)
+				continue
+			}
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "SUBNET_SCHEME", "subnet %v of internet-facing LoadBalancer %v has no route to an internet gateway in route table %v",
+				subnetID, lbName, aws.StringValue(routeTable.RouteTableId))
+		}
+		if scheme == elbv2.LoadBalancerSchemeEnumInternal && routesToInternetGateway(routeTable) && aws.BoolValue(subnet.MapPublicIpOnLaunch) {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "SUBNET_SCHEME", "subnet %v of internal LoadBalancer %v is a public subnet, routing to an internet gateway and auto-assigning public IP addresses",
+				subnetID, lbName)
+		}
+	}
+	if len(misconfigured) != 0 {
+		return fmt.Errorf("subnets %v have no route to an internet gateway, required by internet-facing LoadBalancers", misconfigured)
+	}
+	return nil
+}
+
+// routesToInternetGateway returns whether routeTable has an active route to an internet gateway.
+func routesToInternetGateway(routeTable *ec2.RouteTable) bool {
+	if routeTable == nil {
+		return false
+	}
+	for _, route := range routeTable.Routes {
+		if strings.HasPrefix(aws.StringValue(route.GatewayId), "igw-") && aws.StringValue(route.State) != ec2.RouteStateBlackhole {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSubnetChange ensures LoadBalancer instance can be moved into subnets via SetSubnets.
+// Subnets must be in distinct availability zones and agree with the LoadBalancer scheme, added subnets need free IP addresses, and availability zones
+// can't be removed while pods registered as ip targets run in them, since the LoadBalancer stops routing to such targets.
+func (controller *defaultController) validateSubnetChange(ctx context.Context, ingress *extensions.Ingress, instance *elbv2.LoadBalancer, subnetIDs []string) error {
+	subnets, err := controller.cloud.GetSubnetsByNameOrID(ctx, subnetIDs)
+	if err != nil {
+		return fmt.Errorf("failed to fetch subnets due to %v", err)
+	}
+	if err := controller.validateSubnetZones(ctx, subnets); err != nil {
+		return err
+	}
+	if err := controller.validateSubnetScheme(ctx, aws.StringValue(instance.LoadBalancerName), aws.StringValue(instance.Scheme), subnets); err != nil {
+		return err
+	}
+	desiredZones := sets.NewString()
+	for _, subnet := range subnets {
+		desiredZones.Insert(aws.StringValue(subnet.AvailabilityZone))
+	}
+
+	currentSubnets := sets.NewString()
+	currentZones := sets.NewString()
+	for _, az := range instance.AvailabilityZones {
+		currentSubnets.Insert(aws.StringValue(az.SubnetId))
+		currentZones.Insert(aws.StringValue(az.ZoneName))
+	}
+	// subnets the LoadBalancer is already in hold its network interfaces, only added subnets need free IP addresses.
+	if err := controller.checkSubnetCapacity(ctx, aws.StringValue(instance.LoadBalancerName), sets.NewString(subnetIDs...).Difference(currentSubnets).List()); err != nil {
+		return err
+	}
+
+	removedZones := currentZones.Difference(desiredZones)
+	if removedZones.Len() == 0 {
+		return nil
+	}
+	if zones := removedZones.Intersection(controller.ipTargetZones(ingress)); zones.Len() != 0 {
+		return fmt.Errorf("availability zones %v can't be removed while ip targets run in them", zones.List())
+	}
+	return nil
+}
+
+// expandableSubnets returns the subnets existing LoadBalancer instance should be in.
+// Discovered subnets in availability zones the LoadBalancer doesn't span yet are only added if subnet auto expansion is enabled,
+// otherwise an event advises about them.
+func (controller *defaultController) expandableSubnets(ctx context.Context, instance *elbv2.LoadBalancer, lbConfig *loadBalancerConfig) []string {
+	if lbConfig.DiscoveredSubnets == nil {
+		return lbConfig.Subnets
+	}
+	currentZones := sets.NewString()
+	for _, az := range instance.AvailabilityZones {
+		currentZones.Insert(aws.StringValue(az.ZoneName))
+	}
+	var subnets []string
+	newZones := sets.NewString()
+	for zone, subnet := range lbConfig.DiscoveredSubnets {
+		if currentZones.Has(zone) {
+			subnets = append(subnets, subnet)
+		} else {
+			newZones.Insert(zone)
+		}
+	}
+	if newZones.Len() == 0 {
+		return lbConfig.Subnets
+	}
+
+	lbName := aws.StringValue(instance.LoadBalancerName)
+	if controller.store.GetConfig().SubnetAutoExpansion || len(subnets) < 2 {
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "AZ_EXPANSION", "adding availability zones %v to LoadBalancer %v", strings.Join(newZones.List(), ", "), lbName)
+		return lbConfig.Subnets
+	}
+	albctx.GetLogger(ctx).Infof("LoadBalancer %v spans %v of %v availability zones with discovered subnets, missing %v",
+		lbName, len(subnets), len(lbConfig.DiscoveredSubnets), strings.Join(newZones.List(), ", "))
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "AZ_EXPANSION", "LoadBalancer %v spans %v of %v availability zones with discovered subnets, enable --subnet-auto-expansion to add %v",
+		lbName, len(subnets), len(lbConfig.DiscoveredSubnets), strings.Join(newZones.List(), ", "))
+	sort.Strings(subnets)
+	return subnets
+}
+
+// discoverableSubnets returns the subnets subnet auto discovery can use. Subnets in Wavelength Zones are excluded, and subnets in
+// Local Zones are only used instead of subnets in availability zones if preferred and they span at least 2 Local Zones.
+func (controller *defaultController) discoverableSubnets(ctx context.Context, subnets []*ec2.Subnet) []*ec2.Subnet {
+	var zoneSubnets, localZoneSubnets []*ec2.Subnet
+	localZones := sets.NewString()
+	for _, subnet := range subnets {
+		zone := aws.StringValue(subnet.AvailabilityZone)
+		switch aws.ZoneType(zone) {
+		case aws.ZoneTypeAvailabilityZone:
+			zoneSubnets = append(zoneSubnets, subnet)
+		case aws.ZoneTypeLocalZone:
+			localZoneSubnets = append(localZoneSubnets, subnet)
+			localZones.Insert(zone)
+		default:
+			albctx.GetLogger(ctx).Infof("ignoring subnet %v in %v %v", aws.StringValue(subnet.SubnetId), aws.ZoneType(zone), zone)
+		}
+	}
+	if controller.store.GetConfig().LocalZoneSubnets == config.LocalZoneSubnetsPrefer && localZones.Len() >= 2 {
+		return localZoneSubnets
+	}
+	return zoneSubnets
+}
@@ -0,0 +1,373 @@
+package lb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func Test_checkSubnetCapacity(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		subnetIDs      []string
+		subnets        []*ec2.Subnet
+		subnetsErr     error
+		expectedErr    error
+		expectedEvents []string
+	}{
+		{
+			name:      "subnets have enough free IP addresses",
+			subnetIDs: []string{"subnet-1", "subnet-2"},
+			subnets: []*ec2.Subnet{
+				{SubnetId: aws.String("subnet-1"), AvailableIpAddressCount: aws.Int64(8)},
+				{SubnetId: aws.String("subnet-2"), AvailableIpAddressCount: aws.Int64(250)},
+			},
+		},
+		{
+			name:      "subnet is exhausted",
+			subnetIDs: []string{"subnet-1", "subnet-2"},
+			subnets: []*ec2.Subnet{
+				{SubnetId: aws.String("subnet-1"), AvailableIpAddressCount: aws.Int64(3)},
+				{SubnetId: aws.String("subnet-2"), AvailableIpAddressCount: aws.Int64(250)},
+			},
+			expectedErr: errors.New("subnets [subnet-1] don't have 8 free IP addresses required by LoadBalancer"),
+			expectedEvents: []string{
+				"Warning SUBNET_CAPACITY subnet subnet-1 has 3 free IP addresses, LoadBalancer lbName requires at least 8",
+			},
+		},
+		{
+			name:        "subnets can't be fetched",
+			subnetIDs:   []string{"subnet-1"},
+			subnetsErr:  errors.New("RequestLimitExceeded"),
+			expectedErr: errors.New("failed to fetch subnets due to RequestLimitExceeded"),
+		},
+		{
+			name: "no subnets to check",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cloud := &mocks.CloudAPI{}
+			if len(tc.subnetIDs) != 0 {
+				cloud.On("GetSubnetsByNameOrID", mock.Anything, tc.subnetIDs).Return(tc.subnets, tc.subnetsErr)
+			}
+
+			var events []string
+			ctx := albctx.SetEventf(context.Background(), func(eventType, reason, format string, vals ...interface{}) {
+				events = append(events, fmt.Sprintf("%v %v %v", eventType, reason, fmt.Sprintf(format, vals...)))
+			})
+			controller := &defaultController{cloud: cloud}
+			err := controller.checkSubnetCapacity(ctx, "lbName", tc.subnetIDs)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedEvents, events)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_validateSubnetChange(t *testing.T) {
+	subnets := map[string]*ec2.Subnet{
+		"subnet-a":  {SubnetId: aws.String("subnet-a"), AvailabilityZone: aws.String("us-west-2a"), AvailableIpAddressCount: aws.Int64(100)},
+		"subnet-a2": {SubnetId: aws.String("subnet-a2"), AvailabilityZone: aws.String("us-west-2a"), AvailableIpAddressCount: aws.Int64(100)},
+		"subnet-b":  {SubnetId: aws.String("subnet-b"), AvailabilityZone: aws.String("us-west-2b"), AvailableIpAddressCount: aws.Int64(100)},
+		"subnet-c":  {SubnetId: aws.String("subnet-c"), AvailabilityZone: aws.String("us-west-2c"), AvailableIpAddressCount: aws.Int64(100)},
+		"subnet-d":  {SubnetId: aws.String("subnet-d"), AvailabilityZone: aws.String("us-west-2d"), AvailableIpAddressCount: aws.Int64(100)},
+		"subnet-e":  {SubnetId: aws.String("subnet-e"), AvailabilityZone: aws.String("us-west-2e"), AvailableIpAddressCount: aws.Int64(100)},
+		"subnet-l":  {SubnetId: aws.String("subnet-l"), AvailabilityZone: aws.String("us-west-2-lax-1a"), AvailableIpAddressCount: aws.Int64(100)},
+		"subnet-w":  {SubnetId: aws.String("subnet-w"), AvailabilityZone: aws.String("us-west-2-wl1-las-wlz-1"), AvailableIpAddressCount: aws.Int64(100)},
+	}
+	// us-west-2d is named differently in the account sharing subnet-d, but is the same zone as us-west-2b.
+	zoneIDByName := map[string]string{
+		"us-west-2a": "usw2-az1",
+		"us-west-2b": "usw2-az2",
+		"us-west-2c": "usw2-az3",
+		"us-west-2d": "usw2-az2",
+	}
+	instance := &elbv2.LoadBalancer{
+		LoadBalancerName: aws.String("lbName"),
+		AvailabilityZones: []*elbv2.AvailabilityZone{
+			{SubnetId: aws.String("subnet-a"), ZoneName: aws.String("us-west-2a")},
+			{SubnetId: aws.String("subnet-b"), ZoneName: aws.String("us-west-2b")},
+		},
+	}
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ingress"},
+		Spec: extensions.IngressSpec{
+			Backend: &extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(80)},
+		},
+	}
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{nodeZoneLabel: "us-west-2a"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{nodeZoneLabel: "us-west-2b"}}},
+	}
+	endpoints := &corev1.Endpoints{
+		Subsets: []corev1.EndpointSubset{
+			{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1", NodeName: aws.String("node-a")}}},
+		},
+	}
+	for _, tc := range []struct {
+		name        string
+		subnetIDs   []string
+		targetType  string
+		expectedErr error
+	}{
+		{
+			name:       "adds availability zone",
+			subnetIDs:  []string{"subnet-a", "subnet-b", "subnet-c"},
+			targetType: elbv2.TargetTypeEnumIp,
+		},
+		{
+			name:       "removes availability zone without ip targets",
+			subnetIDs:  []string{"subnet-b", "subnet-c"},
+			targetType: elbv2.TargetTypeEnumInstance,
+		},
+		{
+			name:        "removes availability zone with ip targets",
+			subnetIDs:   []string{"subnet-b", "subnet-c"},
+			targetType:  elbv2.TargetTypeEnumIp,
+			expectedErr: errors.New("availability zones [us-west-2a] can't be removed while ip targets run in them"),
+		},
+		{
+			name:       "replaces subnet in availability zone with ip targets",
+			subnetIDs:  []string{"subnet-a2", "subnet-b"},
+			targetType: elbv2.TargetTypeEnumIp,
+		},
+		{
+			name:        "subnets in same availability zone",
+			subnetIDs:   []string{"subnet-a", "subnet-a2", "subnet-b"},
+			expectedErr: errors.New("subnets subnet-a and subnet-a2 are both in availability zone us-west-2a (usw2-az1)"),
+		},
+		{
+			name:        "subnets in same availability zone with different names",
+			subnetIDs:   []string{"subnet-a", "subnet-b", "subnet-d"},
+			expectedErr: errors.New("subnets subnet-b and subnet-d are both in availability zone us-west-2d (usw2-az2)"),
+		},
+		{
+			name:        "subnet in Wavelength Zone",
+			subnetIDs:   []string{"subnet-a", "subnet-b", "subnet-w"},
+			expectedErr: errors.New("subnet subnet-w is in Wavelength Zone us-west-2-wl1-las-wlz-1, which LoadBalancers don't support"),
+		},
+		{
+			name:        "subnets in Local Zones and availability zones",
+			subnetIDs:   []string{"subnet-a", "subnet-b", "subnet-l"},
+			expectedErr: errors.New("subnets [subnet-l] in Local Zones can't be mixed with subnets [subnet-a subnet-b] in availability zones"),
+		},
+		{
+			name:       "subnet in availability zone missing from inventory",
+			subnetIDs:  []string{"subnet-a", "subnet-b", "subnet-e"},
+			targetType: elbv2.TargetTypeEnumIp,
+		},
+		{
+			name:        "subnets in single availability zone",
+			subnetIDs:   []string{"subnet-a"},
+			expectedErr: errors.New("subnets must be in at least 2 availability zones"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetSubnetsByNameOrID", mock.Anything, mock.Anything).Return(func(_ context.Context, subnetIDs []string) []*ec2.Subnet {
+				var result []*ec2.Subnet
+				for _, subnetID := range subnetIDs {
+					result = append(result, subnets[subnetID])
+				}
+				return result
+			}, nil)
+			cloud.On("GetZoneIDsByName", mock.Anything).Return(zoneIDByName, nil)
+			mockStore := store.NewDummy()
+			mockStore.ListNodesFunc = func() []*corev1.Node { return nodes }
+			mockStore.GetServiceEndpointsFunc = func(string) (*corev1.Endpoints, error) { return endpoints, nil }
+			serviceAnnos := annotations.NewServiceDummy()
+			serviceAnnos.TargetGroup.TargetType = aws.String(tc.targetType)
+			mockStore.GetServiceAnnotationsResponse = serviceAnnos
+
+			controller := &defaultController{cloud: cloud, store: mockStore}
+			err := controller.validateSubnetChange(context.Background(), ingress, instance, tc.subnetIDs)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func Test_expandableSubnets(t *testing.T) {
+	instance := &elbv2.LoadBalancer{
+		LoadBalancerName: aws.String("lbName"),
+		AvailabilityZones: []*elbv2.AvailabilityZone{
+			{SubnetId: aws.String("subnet-a"), ZoneName: aws.String("us-west-2a")},
+			{SubnetId: aws.String("subnet-b"), ZoneName: aws.String("us-west-2b")},
+		},
+	}
+	for _, tc := range []struct {
+		name            string
+		autoExpansion   bool
+		lbConfig        *loadBalancerConfig
+		expectedSubnets []string
+		expectedEvents  []string
+	}{
+		{
+			name:            "subnets specified by annotation",
+			lbConfig:        &loadBalancerConfig{Subnets: []string{"subnet-a", "subnet-c"}},
+			expectedSubnets: []string{"subnet-a", "subnet-c"},
+		},
+		{
+			name: "no new availability zone",
+			lbConfig: &loadBalancerConfig{
+				Subnets:           []string{"subnet-a", "subnet-b"},
+				DiscoveredSubnets: map[string]string{"us-west-2a": "subnet-a", "us-west-2b": "subnet-b"},
+			},
+			expectedSubnets: []string{"subnet-a", "subnet-b"},
+		},
+		{
+			name:          "new availability zone with auto expansion",
+			autoExpansion: true,
+			lbConfig: &loadBalancerConfig{
+				Subnets:           []string{"subnet-a", "subnet-b", "subnet-c"},
+				DiscoveredSubnets: map[string]string{"us-west-2a": "subnet-a", "us-west-2b": "subnet-b", "us-west-2c": "subnet-c"},
+			},
+			expectedSubnets: []string{"subnet-a", "subnet-b", "subnet-c"},
+			expectedEvents:  []string{"Normal AZ_EXPANSION adding availability zones us-west-2c to LoadBalancer lbName"},
+		},
+		{
+			name: "new availability zone without auto expansion",
+			lbConfig: &loadBalancerConfig{
+				Subnets:           []string{"subnet-a", "subnet-b", "subnet-c"},
+				DiscoveredSubnets: map[string]string{"us-west-2a": "subnet-a", "us-west-2b": "subnet-b", "us-west-2c": "subnet-c"},
+			},
+			expectedSubnets: []string{"subnet-a", "subnet-b"},
+			expectedEvents:  []string{"Normal AZ_EXPANSION LoadBalancer lbName spans 2 of 3 availability zones with discovered subnets, enable --subnet-auto-expansion to add us-west-2c"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockStore := store.NewDummy()
+			mockStore.SetConfig(&config.Configuration{SubnetAutoExpansion: tc.autoExpansion})
+
+			var events []string
+			ctx := albctx.SetEventf(context.Background(), func(eventType, reason, format string, vals ...interface{}) {
+				events = append(events, fmt.Sprintf("%v %v %v", eventType, reason, fmt.Sprintf(format, vals...)))
+			})
+			controller := &defaultController{store: mockStore}
+			subnets := controller.expandableSubnets(ctx, instance, tc.lbConfig)
+			assert.Equal(t, tc.expectedSubnets, subnets)
+			assert.Equal(t, tc.expectedEvents, events)
+		})
+	}
+}
+
+func Test_discoverableSubnets(t *testing.T) {
+	subnetA := &ec2.Subnet{SubnetId: aws.String("subnet-a"), AvailabilityZone: aws.String("us-west-2a")}
+	subnetB := &ec2.Subnet{SubnetId: aws.String("subnet-b"), AvailabilityZone: aws.String("us-west-2b")}
+	subnetLax := &ec2.Subnet{SubnetId: aws.String("subnet-lax"), AvailabilityZone: aws.String("us-west-2-lax-1a")}
+	subnetLax2 := &ec2.Subnet{SubnetId: aws.String("subnet-lax2"), AvailabilityZone: aws.String("us-west-2-lax-1b")}
+	subnetWavelength := &ec2.Subnet{SubnetId: aws.String("subnet-w"), AvailabilityZone: aws.String("us-west-2-wl1-las-wlz-1")}
+	for _, tc := range []struct {
+		name             string
+		localZoneSubnets string
+		subnets          []*ec2.Subnet
+		expected         []*ec2.Subnet
+	}{
+		{
+			name:             "Local Zones excluded",
+			localZoneSubnets: config.LocalZoneSubnetsExclude,
+			subnets:          []*ec2.Subnet{subnetA, subnetLax, subnetB, subnetLax2, subnetWavelength},
+			expected:         []*ec2.Subnet{subnetA, subnetB},
+		},
+		{
+			name:             "Local Zones preferred",
+			localZoneSubnets: config.LocalZoneSubnetsPrefer,
+			subnets:          []*ec2.Subnet{subnetA, subnetLax, subnetB, subnetLax2, subnetWavelength},
+			expected:         []*ec2.Subnet{subnetLax, subnetLax2},
+		},
+		{
+			name:             "Local Zones preferred but in single Local Zone",
+			localZoneSubnets: config.LocalZoneSubnetsPrefer,
+			subnets:          []*ec2.Subnet{subnetA, subnetLax, subnetB},
+			expected:         []*ec2.Subnet{subnetA, subnetB},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockStore := store.NewDummy()
+			mockStore.SetConfig(&config.Configuration{LocalZoneSubnets: tc.localZoneSubnets})
+			controller := &defaultController{store: mockStore}
+			assert.Equal(t, tc.expected, controller.discoverableSubnets(context.Background(), tc.subnets))
+		})
+	}
+}
+
+func Test_validateSubnetScheme(t *testing.T) {
+	publicSubnet := &ec2.Subnet{SubnetId: aws.String("subnet-public"), MapPublicIpOnLaunch: aws.Bool(true)}
+	privateSubnet := &ec2.Subnet{SubnetId: aws.String("subnet-private"), MapPublicIpOnLaunch: aws.Bool(false)}
+	routeTables := map[string]*ec2.RouteTable{
+		"subnet-public": {
+			RouteTableId: aws.String("rtb-public"),
+			Routes: []*ec2.Route{
+				{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local"), State: aws.String(ec2.RouteStateActive)},
+				{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1"), State: aws.String(ec2.RouteStateActive)},
+			},
+		},
+		"subnet-private": {
+			RouteTableId: aws.String("rtb-private"),
+			Routes: []*ec2.Route{
+				{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local"), State: aws.String(ec2.RouteStateActive)},
+				{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1"), State: aws.String(ec2.RouteStateActive)},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		name           string
+		scheme         string
+		subnets        []*ec2.Subnet
+		expectedErr    error
+		expectedEvents []string
+	}{
+		{
+			name:    "internet-facing in public subnets",
+			scheme:  elbv2.LoadBalancerSchemeEnumInternetFacing,
+			subnets: []*ec2.Subnet{publicSubnet},
+		},
+		{
+			name:           "internet-facing in private subnets",
+			scheme:         elbv2.LoadBalancerSchemeEnumInternetFacing,
+			subnets:        []*ec2.Subnet{publicSubnet, privateSubnet},
+			expectedErr:    errors.New("subnets [subnet-private] have no route to an internet gateway, required by internet-facing LoadBalancers"),
+			expectedEvents: []string{"Warning SUBNET_SCHEME subnet subnet-private of internet-facing LoadBalancer lbName has no route to an internet gateway in route table rtb-private"},
+		},
+		{
+			name:    "internal in private subnets",
+			scheme:  elbv2.LoadBalancerSchemeEnumInternal,
+			subnets: []*ec2.Subnet{privateSubnet},
+		},
+		{
+			name:           "internal in public subnets",
+			scheme:         elbv2.LoadBalancerSchemeEnumInternal,
+			subnets:        []*ec2.Subnet{publicSubnet, privateSubnet},
+			expectedEvents: []string{"Warning SUBNET_SCHEME subnet subnet-public of internal LoadBalancer lbName is a public subnet, routing to an internet gateway and auto-assigning public IP addresses"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetRouteTablesBySubnetID", mock.Anything, mock.Anything).Return(routeTables, nil)
+
+			var events []string
+			ctx := albctx.SetEventf(context.Background(), func(eventType, reason, format string, vals ...interface{}) {
+				events = append(events, fmt.Sprintf("%v %v %v", eventType, reason, fmt.Sprintf(format, vals...)))
+			})
+			controller := &defaultController{cloud: cloud}
+			err := controller.validateSubnetScheme(ctx, "lbName", tc.scheme, tc.subnets)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedEvents, events)
+		})
+	}
+}
@@ -2,8 +2,30 @@ package lb
 
 // LoadBalancer contains information of LoadBalancer in AWS
 type LoadBalancer struct {
-	Arn     string
-	DNSName string
+	Arn     string `json:"arn"`
+	DNSName string `json:"dnsName"`
+
+	Name          string   `json:"name"`
+	Scheme        string   `json:"scheme"`
+	IPAddressType string   `json:"ipAddressType"`
+	Subnets       []string `json:"subnets"`
+
+	Listeners    []Listener    `json:"listeners"`
+	TargetGroups []TargetGroup `json:"targetGroups"`
+}
+
+// Listener contains information of a listener applied on LoadBalancer
+type Listener struct {
+	Port     int64  `json:"port"`
+	Protocol string `json:"protocol"`
+}
+
+// TargetGroup contains information of a targetGroup applied for an ingress backend
+type TargetGroup struct {
+	ServiceName string `json:"serviceName"`
+	ServicePort string `json:"servicePort"`
+	Arn         string `json:"arn"`
+	TargetType  string `json:"targetType"`
 }
 
 // NameGenerator generates name for loadBalancer resources
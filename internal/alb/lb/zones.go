@@ -0,0 +1,148 @@
+package lb
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// nodeZoneLabel is the well-known label for the availability zone of nodes.
+const nodeZoneLabel = "failure-domain.beta.kubernetes.io/zone"
+
+// checkTargetZones emits a warning for targetGroups whose targets are all in a single availability zone,
+// while the LoadBalancer spans multiple availability zones. Such targetGroups lose all capacity when that zone is impaired.
+func (controller *defaultController) checkTargetZones(ctx context.Context, ingress *extensions.Ingress, instance *elbv2.LoadBalancer, tgGroup tg.TargetGroupGroup) {
+	lbZones := sets.NewString()
+	for _, az := range instance.AvailabilityZones {
+		lbZones.Insert(aws.StringValue(az.ZoneName))
+	}
+	if lbZones.Len() < 2 {
+		return
+	}
+
+	zoneByNodeName, zoneByInstanceID := controller.nodeZones()
+	for _, backend := range sortedBackends(tgGroup) {
+		targetGroup := tgGroup.TGByBackend[backend]
+		if len(targetGroup.Targets) == 0 {
+			continue
+		}
+		var zoneByTargetID map[string]string
+		if targetGroup.TargetType == elbv2.TargetTypeEnumInstance {
+			zoneByTargetID = zoneByInstanceID
+		} else {
+			zoneByTargetID = controller.endpointZones(ingress.Namespace, backend.ServiceName, zoneByNodeName)
+		}
+
+		targetZones := sets.NewString()
+		for _, target := range targetGroup.Targets {
+			zone, ok := zoneByTargetID[aws.StringValue(target.Id)]
+			if !ok {
+				// zone is unknown for some target, don't draw conclusion from partial data.
+				targetZones = nil
+				break
+			}
+			targetZones.Insert(zone)
+		}
+		if targetZones.Len() == 1 {
+			albctx.GetLogger(ctx).Warnf("targets of backend %v:%v are all in availability zone %v, while LoadBalancer spans %v",
+				backend.ServiceName, backend.ServicePort.String(), targetZones.List()[0], strings.Join(lbZones.List(), ", "))
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ZONE_IMBALANCE", "targets of backend %v:%v are all in availability zone %v, while LoadBalancer spans %v",
+				backend.ServiceName, backend.ServicePort.String(), targetZones.List()[0], strings.Join(lbZones.List(), ", "))
+		}
+	}
+}
+
+// nodeZones returns the availability zone of nodes, by node name and by instance ID.
+func (controller *defaultController) nodeZones() (map[string]string, map[string]string) {
+	zoneByNodeName := make(map[string]string)
+	zoneByInstanceID := make(map[string]string)
+	for _, node := range controller.store.ListNodes() {
+		zone, ok := node.Labels[nodeZoneLabel]
+		if !ok {
+			continue
+		}
+		zoneByNodeName[node.Name] = zone
+		if instanceID, err := controller.store.GetNodeInstanceID(node); err == nil {
+			zoneByInstanceID[instanceID] = zone
+		}
+	}
+	return zoneByNodeName, zoneByInstanceID
+}
+
+// endpointZones returns the availability zone of endpoint addresses of service, by IP.
+func (controller *defaultController) endpointZones(namespace string, serviceName string, zoneByNodeName map[string]string) map[string]string {
+	zoneByIP := make(map[string]string)
+	eps, err := controller.store.GetServiceEndpoints(namespace + "/" + serviceName)
+	if err != nil || eps == nil {
+		return zoneByIP
+	}
+	for _, epSubset := range eps.Subsets {
+		for _, epAddr := range epSubset.Addresses {
+			if epAddr.NodeName == nil {
+				continue
+			}
+			if zone, ok := zoneByNodeName[*epAddr.NodeName]; ok {
+				zoneByIP[epAddr.IP] = zone
+			}
+		}
+	}
+	return zoneByIP
+}
+
+// ipTargetZones returns the availability zones of pods registered as ip targets for backends of ingress.
+func (controller *defaultController) ipTargetZones(ingress *extensions.Ingress) sets.String {
+	zones := sets.NewString()
+	ingressAnnos, err := controller.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
+	if err != nil {
+		return zones
+	}
+	zoneByNodeName, _ := controller.nodeZones()
+	for _, backend := range ingressBackends(ingress) {
+		serviceAnnos, err := controller.store.GetServiceAnnotations(ingress.Namespace+"/"+backend.ServiceName, ingressAnnos)
+		if err != nil || aws.StringValue(serviceAnnos.TargetGroup.TargetType) != elbv2.TargetTypeEnumIp {
+			continue
+		}
+		for _, zone := range controller.endpointZones(ingress.Namespace, backend.ServiceName, zoneByNodeName) {
+			zones.Insert(zone)
+		}
+	}
+	return zones
+}
+
+// ingressBackends returns the service backends referenced by rules of ingress.
+func ingressBackends(ingress *extensions.Ingress) []extensions.IngressBackend {
+	var backends []extensions.IngressBackend
+	if ingress.Spec.Backend != nil {
+		backends = append(backends, *ingress.Spec.Backend)
+	}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			backends = append(backends, path.Backend)
+		}
+	}
+	return backends
+}
+
+// sortedBackends returns the backends of tgGroup sorted by service name and port, so warnings are emitted in stable order.
+func sortedBackends(tgGroup tg.TargetGroupGroup) []extensions.IngressBackend {
+	backends := make([]extensions.IngressBackend, 0, len(tgGroup.TGByBackend))
+	for backend := range tgGroup.TGByBackend {
+		backends = append(backends, backend)
+	}
+	sort.Slice(backends, func(i, j int) bool {
+		return backends[i].ServiceName+":"+backends[i].ServicePort.String() < backends[j].ServiceName+":"+backends[j].ServicePort.String()
+	})
+	return backends
+}
@@ -0,0 +1,99 @@
+package lb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func Test_checkTargetZones(t *testing.T) {
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{nodeZoneLabel: "us-west-2a"}}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-west-2a/i-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{nodeZoneLabel: "us-west-2b"}}, Spec: corev1.NodeSpec{ProviderID: "aws:///us-west-2b/i-b"}},
+	}
+	instance := &elbv2.LoadBalancer{
+		AvailabilityZones: []*elbv2.AvailabilityZone{
+			{ZoneName: aws.String("us-west-2a")},
+			{ZoneName: aws.String("us-west-2b")},
+		},
+	}
+	for _, tc := range []struct {
+		name           string
+		targetGroup    tg.TargetGroup
+		endpoints      *corev1.Endpoints
+		expectedEvents []string
+	}{
+		{
+			name: "instance targets spread across zones",
+			targetGroup: tg.TargetGroup{
+				TargetType: elbv2.TargetTypeEnumInstance,
+				Targets:    []*elbv2.TargetDescription{{Id: aws.String("i-a")}, {Id: aws.String("i-b")}},
+			},
+		},
+		{
+			name: "instance targets concentrated in one zone",
+			targetGroup: tg.TargetGroup{
+				TargetType: elbv2.TargetTypeEnumInstance,
+				Targets:    []*elbv2.TargetDescription{{Id: aws.String("i-a")}},
+			},
+			expectedEvents: []string{"Warning ZONE_IMBALANCE targets of backend service:80 are all in availability zone us-west-2a, while LoadBalancer spans us-west-2a, us-west-2b"},
+		},
+		{
+			name: "ip targets concentrated in one zone",
+			targetGroup: tg.TargetGroup{
+				TargetType: elbv2.TargetTypeEnumIp,
+				Targets:    []*elbv2.TargetDescription{{Id: aws.String("10.0.0.1")}, {Id: aws.String("10.0.0.2")}},
+			},
+			endpoints: &corev1.Endpoints{
+				Subsets: []corev1.EndpointSubset{
+					{
+						Addresses: []corev1.EndpointAddress{
+							{IP: "10.0.0.1", NodeName: aws.String("node-b")},
+							{IP: "10.0.0.2", NodeName: aws.String("node-b")},
+						},
+					},
+				},
+			},
+			expectedEvents: []string{"Warning ZONE_IMBALANCE targets of backend service:80 are all in availability zone us-west-2b, while LoadBalancer spans us-west-2a, us-west-2b"},
+		},
+		{
+			name: "ip targets with unknown zone",
+			targetGroup: tg.TargetGroup{
+				TargetType: elbv2.TargetTypeEnumIp,
+				Targets:    []*elbv2.TargetDescription{{Id: aws.String("10.0.0.1")}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockStore := store.NewDummy()
+			mockStore.ListNodesFunc = func() []*corev1.Node { return nodes }
+			mockStore.GetNodeInstanceIDFunc = func(node *corev1.Node) (string, error) {
+				return "i-" + node.Name[len("node-"):], nil
+			}
+			mockStore.GetServiceEndpointsFunc = func(string) (*corev1.Endpoints, error) { return tc.endpoints, nil }
+
+			var events []string
+			ctx := albctx.SetEventf(context.Background(), func(eventType, reason, format string, vals ...interface{}) {
+				events = append(events, fmt.Sprintf("%v %v %v", eventType, reason, fmt.Sprintf(format, vals...)))
+			})
+			controller := &defaultController{store: mockStore}
+			controller.checkTargetZones(ctx, &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}, instance, tg.TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{
+					{ServiceName: "service", ServicePort: intstr.FromInt(80)}: tc.targetGroup,
+				},
+			})
+			assert.Equal(t, tc.expectedEvents, events)
+		})
+	}
+}
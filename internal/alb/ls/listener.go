@@ -3,6 +3,7 @@ package ls
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -13,6 +14,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/awsutil"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
@@ -39,6 +41,12 @@ type ReconcileOptions struct {
 	Port         loadbalancer.PortData
 	TGGroup      tg.TargetGroupGroup
 
+	// CertificateARNs are the certificates issued for TLS hosts of Ingress, used by https listeners when certificate-arn annotation isn't specified.
+	CertificateARNs []string
+
+	// HTTPSRedirectPort is the https listen port that http listeners redirect all requests to, without any rule, when non-zero.
+	HTTPSRedirectPort int64
+
 	// If instance is specified, reconcile will operate on this instance, otherwise new listener instance will be created.
 	Instance *elbv2.Listener
 }
@@ -48,11 +56,13 @@ type Controller interface {
 	Reconcile(ctx context.Context, options ReconcileOptions) error
 }
 
-func NewController(cloud aws.CloudAPI, authModule auth.Module) Controller {
-	rulesController := NewRulesController(cloud, authModule)
+func NewController(cloud aws.CloudAPI, authModule auth.Module, tagsController tags.Controller, tagGen TagGenerator) Controller {
+	rulesController := NewRulesController(cloud, authModule, tagsController, tagGen)
 	return &defaultController{
 		cloud:           cloud,
 		authModule:      authModule,
+		tagsController:  tagsController,
+		tagGen:          tagGen,
 		rulesController: rulesController,
 	}
 }
@@ -60,6 +70,8 @@ func NewController(cloud aws.CloudAPI, authModule auth.Module) Controller {
 type defaultController struct {
 	cloud           aws.CloudAPI
 	authModule      auth.Module
+	tagsController  tags.Controller
+	tagGen          TagGenerator
 	rulesController RulesController
 }
 
@@ -85,19 +97,42 @@ func (controller *defaultController) Reconcile(ctx context.Context, options Reco
 			return fmt.Errorf("failed to create listener due to %v", err)
 		}
 	} else {
+		if options.Port.Scheme == elbv2.ProtocolEnumHttps && defaultCertificateRotated(instance, config) {
+			lsArn := aws.StringValue(instance.ListenerArn)
+			if err := controller.stageCertificates(ctx, lsArn, config); err != nil {
+				return errors.Wrapf(err, "failed to stage certificates on listener %v", lsArn)
+			}
+		}
 		if instance, err = controller.reconcileLSInstance(ctx, instance, config); err != nil {
 			return fmt.Errorf("failed to reconcile listener due to %v", err)
 		}
 	}
 
+	if controller.tagGen != nil {
+		lsArn := aws.StringValue(instance.ListenerArn)
+		lsTags := buildTags(controller.tagGen, options.Ingress, options.IngressAnnos)
+		if err := controller.tagsController.ReconcileELB(ctx, lsArn, lsTags); err != nil {
+			return fmt.Errorf("failed to reconcile tags on listener %v due to %v", lsArn, err)
+		}
+	}
+
 	if options.Port.Scheme == elbv2.ProtocolEnumHttps {
 		lsArn := aws.StringValue(instance.ListenerArn)
-		if err := controller.reconcileExtraCertificates(ctx, lsArn, config.ExtraCertificateARNs); err != nil {
+		if err := controller.reconcileExtraCertificates(ctx, lsArn, config); err != nil {
 			return errors.Wrapf(err, "failed to reconcile extra certificates on listener %v", lsArn)
 		}
 	}
 
-	if err := controller.rulesController.Reconcile(ctx, instance, options.Ingress, options.IngressAnnos, options.TGGroup); err != nil {
+	ingress, ingressAnnos := options.Ingress, options.IngressAnnos
+	if options.HTTPSRedirectPort != 0 {
+		// listeners redirecting to https have no rules, so that no request is served in plaintext.
+		ingress = ingress.DeepCopy()
+		ingress.Spec.Rules = nil
+		redirectAnnos := *ingressAnnos
+		redirectAnnos.Action = nil
+		ingressAnnos = &redirectAnnos
+	}
+	if err := controller.rulesController.Reconcile(ctx, instance, ingress, ingressAnnos, options.TGGroup); err != nil {
 		return fmt.Errorf("failed to reconcile rules due to %v", err)
 	}
 	return nil
@@ -122,14 +157,16 @@ func (controller *defaultController) newLSInstance(ctx context.Context, lbArn st
 func (controller *defaultController) reconcileLSInstance(ctx context.Context, instance *elbv2.Listener, config listenerConfig) (*elbv2.Listener, error) {
 	if controller.LSInstanceNeedsModification(ctx, instance, config) {
 		albctx.GetLogger(ctx).Infof("modifying listener %v, arn: %v", aws.Int64Value(config.Port), aws.StringValue(instance.ListenerArn))
-		output, err := controller.cloud.ModifyListenerWithContext(ctx, &elbv2.ModifyListenerInput{
+		in := &elbv2.ModifyListenerInput{
 			ListenerArn:    instance.ListenerArn,
 			Port:           config.Port,
 			Protocol:       config.Protocol,
 			Certificates:   config.DefaultCertificate,
 			SslPolicy:      config.SslPolicy,
 			DefaultActions: config.DefaultActions,
-		})
+		}
+		albctx.RecordAuditBefore(ctx, in, instance)
+		output, err := controller.cloud.ModifyListenerWithContext(ctx, in)
 		if err != nil {
 			return instance, err
 		}
@@ -163,24 +200,35 @@ func (controller *defaultController) LSInstanceNeedsModification(ctx context.Con
 	return needModification
 }
 
-func (controller *defaultController) reconcileExtraCertificates(ctx context.Context, lsArn string, extraCertificateARNs []string) error {
-	certificates, err := controller.cloud.DescribeListenerCertificates(ctx, lsArn)
+// stageCertificates adds the default and extra certificates of config to listener as SNI certificates before its default certificate is swapped,
+// so that clients keep being served a matching certificate while certificates are rotated.
+func (controller *defaultController) stageCertificates(ctx context.Context, lsArn string, config listenerConfig) error {
+	actualExtraCertificateArns, err := controller.describeExtraCertificateARNs(ctx, lsArn)
 	if err != nil {
 		return err
 	}
-	actualExtraCertificateArns := sets.NewString()
-	for _, certificate := range certificates {
-		if !aws.BoolValue(certificate.IsDefault) {
-			actualExtraCertificateArns.Insert(aws.StringValue(certificate.CertificateArn))
-		}
+	stagedCertificateArns := sets.NewString(config.ExtraCertificateARNs...).Insert(aws.StringValue(config.DefaultCertificate[0].CertificateArn))
+	return controller.addCertificates(ctx, lsArn, stagedCertificateArns.Difference(actualExtraCertificateArns).List())
+}
+
+// reconcileExtraCertificates adds the missing extra certificates of config to listener, and removes the others.
+// The default certificate is never removed, as it may have been staged by stageCertificates.
+func (controller *defaultController) reconcileExtraCertificates(ctx context.Context, lsArn string, config listenerConfig) error {
+	actualExtraCertificateArns, err := controller.describeExtraCertificateARNs(ctx, lsArn)
+	if err != nil {
+		return err
 	}
-	desiredExtraCertificateArns := sets.NewString(extraCertificateARNs...)
+	desiredExtraCertificateArns := sets.NewString(config.ExtraCertificateARNs...)
 
 	certificatesToAdd := desiredExtraCertificateArns.Difference(actualExtraCertificateArns)
-	certificatesToRemove := actualExtraCertificateArns.Difference(desiredExtraCertificateArns)
-	for certARN := range certificatesToAdd {
-		albctx.GetLogger(ctx).Infof("adding certificate %v to listener %v", certARN, lsArn)
-		if _, err := controller.cloud.AddListenerCertificates(ctx, &elbv2.AddListenerCertificatesInput{
+	if err := controller.addCertificates(ctx, lsArn, certificatesToAdd.List()); err != nil {
+		return err
+	}
+	certificatesToRemove := actualExtraCertificateArns.Difference(desiredExtraCertificateArns).
+		Delete(aws.StringValue(config.DefaultCertificate[0].CertificateArn))
+	for _, certARN := range certificatesToRemove.List() {
+		albctx.GetLogger(ctx).Infof("removing certificate %v from listener %v", certARN, lsArn)
+		if _, err := controller.cloud.RemoveListenerCertificates(ctx, &elbv2.RemoveListenerCertificatesInput{
 			ListenerArn: aws.String(lsArn),
 			Certificates: []*elbv2.Certificate{
 				{
@@ -191,9 +239,28 @@ func (controller *defaultController) reconcileExtraCertificates(ctx context.Cont
 			return err
 		}
 	}
-	for certARN := range certificatesToRemove {
-		albctx.GetLogger(ctx).Infof("removing certificate %v from listener %v", certARN, lsArn)
-		if _, err := controller.cloud.RemoveListenerCertificates(ctx, &elbv2.RemoveListenerCertificatesInput{
+	return nil
+}
+
+// describeExtraCertificateARNs returns the ARNs of the SNI certificates of listener, which excludes its default certificate.
+func (controller *defaultController) describeExtraCertificateARNs(ctx context.Context, lsArn string) (sets.String, error) {
+	certificates, err := controller.cloud.DescribeListenerCertificates(ctx, lsArn)
+	if err != nil {
+		return nil, err
+	}
+	certificateArns := sets.NewString()
+	for _, certificate := range certificates {
+		if !aws.BoolValue(certificate.IsDefault) {
+			certificateArns.Insert(aws.StringValue(certificate.CertificateArn))
+		}
+	}
+	return certificateArns, nil
+}
+
+func (controller *defaultController) addCertificates(ctx context.Context, lsArn string, certARNs []string) error {
+	for _, certARN := range certARNs {
+		albctx.GetLogger(ctx).Infof("adding certificate %v to listener %v", certARN, lsArn)
+		if _, err := controller.cloud.AddListenerCertificates(ctx, &elbv2.AddListenerCertificatesInput{
 			ListenerArn: aws.String(lsArn),
 			Certificates: []*elbv2.Certificate{
 				{
@@ -207,6 +274,14 @@ func (controller *defaultController) reconcileExtraCertificates(ctx context.Cont
 	return nil
 }
 
+// defaultCertificateRotated returns whether the default certificate of https listener instance differs from the one of config.
+func defaultCertificateRotated(instance *elbv2.Listener, config listenerConfig) bool {
+	if aws.StringValue(instance.Protocol) != elbv2.ProtocolEnumHttps || len(instance.Certificates) == 0 || len(config.DefaultCertificate) == 0 {
+		return false
+	}
+	return aws.StringValue(instance.Certificates[0].CertificateArn) != aws.StringValue(config.DefaultCertificate[0].CertificateArn)
+}
+
 func (controller *defaultController) buildListenerConfig(ctx context.Context, options ReconcileOptions) (listenerConfig, error) {
 	config := listenerConfig{
 		Port:     aws.Int64(options.Port.Port),
@@ -219,6 +294,9 @@ func (controller *defaultController) buildListenerConfig(ctx context.Context, op
 
 		var certificateARNs []string
 		_ = annotations.LoadStringSliceAnnotation(AnnotationCertificateARN, &certificateARNs, options.Ingress.Annotations)
+		if len(certificateARNs) == 0 {
+			certificateARNs = options.CertificateARNs
+		}
 		if len(certificateARNs) == 0 {
 			return config, errors.Errorf("annotation %v must be specified for https listener", parser.GetAnnotationWithPrefix(AnnotationCertificateARN))
 		}
@@ -230,6 +308,10 @@ func (controller *defaultController) buildListenerConfig(ctx context.Context, op
 		config.ExtraCertificateARNs = certificateARNs[1:]
 	}
 
+	if options.HTTPSRedirectPort != 0 {
+		config.DefaultActions = buildHTTPSRedirectActions(options.HTTPSRedirectPort)
+		return config, nil
+	}
 	actions, err := controller.buildDefaultActions(ctx, options)
 	if err != nil {
 		return config, err
@@ -241,8 +323,8 @@ func (controller *defaultController) buildListenerConfig(ctx context.Context, op
 
 func (controller *defaultController) buildDefaultActions(ctx context.Context, options ReconcileOptions) ([]*elbv2.Action, error) {
 	backend := action.Default404Backend()
-	if options.Ingress.Spec.Backend != nil {
-		backend = *options.Ingress.Spec.Backend
+	if !options.IngressAnnos.Action.UseDefaultBackendRule() {
+		backend = defaultBackend(options.Ingress, options.IngressAnnos, options.Port.Port, options.Port.Scheme)
 	}
 	authCfg, err := controller.authModule.NewConfig(ctx, options.Ingress, backend, options.Port.Scheme)
 	if err != nil {
@@ -250,3 +332,33 @@ func (controller *defaultController) buildDefaultActions(ctx context.Context, op
 	}
 	return buildActions(ctx, authCfg, options.IngressAnnos, backend, options.TGGroup)
 }
+
+// buildHTTPSRedirectActions builds the default actions of http listeners permanently redirecting requests to https on port.
+func buildHTTPSRedirectActions(port int64) []*elbv2.Action {
+	return []*elbv2.Action{
+		{
+			Order: aws.Int64(1),
+			Type:  aws.String(elbv2.ActionTypeEnumRedirect),
+			RedirectConfig: &elbv2.RedirectActionConfig{
+				Host:       aws.String("#{host}"),
+				Path:       aws.String("/#{path}"),
+				Port:       aws.String(strconv.FormatInt(port, 10)),
+				Protocol:   aws.String(elbv2.ProtocolEnumHttps),
+				Query:      aws.String("#{query}"),
+				StatusCode: aws.String(elbv2.RedirectActionStatusCodeEnumHttp301),
+			},
+		},
+	}
+}
+
+// defaultBackend returns the backend for requests on listener with port and protocol that match no ingress rule.
+// The backend configured by annotation for the listener takes precedence over the ingress's default backend.
+func defaultBackend(ingress *extensions.Ingress, ingressAnnos *annotations.Ingress, port int64, protocol string) extensions.IngressBackend {
+	if backend, ok := ingressAnnos.Action.GetDefaultBackend(port, protocol); ok {
+		return backend
+	}
+	if ingress.Spec.Backend != nil {
+		return *ingress.Spec.Backend
+	}
+	return action.Default404Backend()
+}
@@ -8,8 +8,10 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 	extensions "k8s.io/api/extensions/v1beta1"
@@ -18,14 +20,15 @@ import (
 
 type GroupController interface {
 	// Reconcile ensures listeners exists in LB to satisfy ingress requirements.
-	Reconcile(ctx context.Context, lbArn string, ingress *extensions.Ingress, tgGroup tg.TargetGroupGroup) error
+	// certificateARNs are the certificates issued for TLS hosts of ingress, see ReconcileOptions.
+	Reconcile(ctx context.Context, lbArn string, ingress *extensions.Ingress, tgGroup tg.TargetGroupGroup, certificateARNs []string) error
 
 	// Delete ensures all listeners are deleted
 	Delete(ctx context.Context, lbArn string) error
 }
 
-func NewGroupController(store store.Storer, cloud aws.CloudAPI, authModule auth.Module) GroupController {
-	lsController := NewController(cloud, authModule)
+func NewGroupController(store store.Storer, cloud aws.CloudAPI, authModule auth.Module, tagsController tags.Controller, tagGen TagGenerator) GroupController {
+	lsController := NewController(cloud, authModule, tagsController, tagGen)
 	return &defaultGroupController{
 		cloud:        cloud,
 		store:        store,
@@ -40,7 +43,7 @@ type defaultGroupController struct {
 	lsController Controller
 }
 
-func (controller *defaultGroupController) Reconcile(ctx context.Context, lbArn string, ingress *extensions.Ingress, tgGroup tg.TargetGroupGroup) error {
+func (controller *defaultGroupController) Reconcile(ctx context.Context, lbArn string, ingress *extensions.Ingress, tgGroup tg.TargetGroupGroup, certificateARNs []string) error {
 	ingressAnnos, err := controller.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
 	if err != nil {
 		return err
@@ -50,18 +53,28 @@ func (controller *defaultGroupController) Reconcile(ctx context.Context, lbArn s
 		return err
 	}
 
+	var httpsRedirectPort int64
+	if cfg := controller.store.GetConfig(); cfg.HTTPSOnly && cfg.HTTPSOnlyRedirect {
+		httpsRedirectPort = firstHTTPSPort(ingressAnnos.LoadBalancer.Ports)
+	}
+
 	portsInUse := sets.NewInt64()
 	for _, port := range ingressAnnos.LoadBalancer.Ports {
 		portsInUse.Insert(port.Port)
 		instance := instancesByPort[port.Port]
-		if err := controller.lsController.Reconcile(ctx, ReconcileOptions{
-			LBArn:        lbArn,
-			Ingress:      ingress,
-			IngressAnnos: ingressAnnos,
-			Port:         port,
-			TGGroup:      tgGroup,
-			Instance:     instance,
-		}); err != nil {
+		options := ReconcileOptions{
+			LBArn:           lbArn,
+			Ingress:         ingress,
+			IngressAnnos:    ingressAnnos,
+			Port:            port,
+			TGGroup:         tgGroup,
+			CertificateARNs: certificateARNs,
+			Instance:        instance,
+		}
+		if port.Scheme == elbv2.ProtocolEnumHttp {
+			options.HTTPSRedirectPort = httpsRedirectPort
+		}
+		if err := controller.lsController.Reconcile(ctx, options); err != nil {
 			return err
 		}
 	}
@@ -101,3 +114,13 @@ func (controller *defaultGroupController) loadListenerInstances(ctx context.Cont
 	}
 	return instanceByPort, nil
 }
+
+// firstHTTPSPort returns the first https port in ports, or zero if there is none.
+func firstHTTPSPort(ports []loadbalancer.PortData) int64 {
+	for _, port := range ports {
+		if port.Scheme == elbv2.ProtocolEnumHttps {
+			return port.Port
+		}
+	}
+	return 0
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
 	"github.com/stretchr/testify/assert"
@@ -330,6 +331,9 @@ func TestDefaultGroupController_Reconcile(t *testing.T) {
 			if tc.GetIngressAnnotationsCall != nil {
 				mockStore.On("GetIngressAnnotations", tc.GetIngressAnnotationsCall.Key).Return(tc.GetIngressAnnotationsCall.IngressAnnos, tc.GetIngressAnnotationsCall.Err)
 			}
+			if tc.ListListenersByLoadBalancerCall != nil && tc.ListListenersByLoadBalancerCall.Err == nil {
+				mockStore.On("GetConfig").Return(&config.Configuration{})
+			}
 			mockLSController := &MockController{}
 			for _, call := range tc.LSControllerReconcileCalls {
 				mockLSController.On("Reconcile", mock.Anything, ReconcileOptions{
@@ -348,7 +352,7 @@ func TestDefaultGroupController_Reconcile(t *testing.T) {
 				lsController: mockLSController,
 			}
 
-			err := controller.Reconcile(context.Background(), lbArn, &ingress, targetGroup)
+			err := controller.Reconcile(context.Background(), lbArn, &ingress, targetGroup, nil)
 			assert.Equal(t, tc.ExpectedErr, err)
 			cloud.AssertExpectations(t)
 			mockStore.AssertExpectations(t)
@@ -902,3 +902,170 @@ func TestDefaultController_Reconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultController_Reconcile_CertificateRotation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	ingress := extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ingress",
+			Namespace: "namespace",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/ssl-policy":      "sslPolicy",
+				"alb.ingress.kubernetes.io/certificate-arn": "certificateArn2,certificateArn3",
+			},
+		},
+		Spec: extensions.IngressSpec{
+			Backend: &extensions.IngressBackend{
+				ServiceName: "service",
+				ServicePort: intstr.FromInt(8443),
+			},
+		},
+	}
+	ingressAnnos := annotations.Ingress{}
+	tgGroup := tg.TargetGroupGroup{
+		TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{
+			{
+				ServiceName: "service",
+				ServicePort: intstr.FromInt(8443),
+			}: {
+				Arn: "tgArn",
+			},
+		},
+	}
+	defaultActions := []*elbv2.Action{
+		{
+			Order:          aws.Int64(1),
+			Type:           aws.String(elbv2.ActionTypeEnumForward),
+			TargetGroupArn: aws.String("tgArn"),
+		},
+	}
+	instance := &elbv2.Listener{
+		ListenerArn:    aws.String("lsArn"),
+		Port:           aws.Int64(443),
+		Protocol:       aws.String(elbv2.ProtocolEnumHttps),
+		Certificates:   []*elbv2.Certificate{{CertificateArn: aws.String("certificateArn")}},
+		SslPolicy:      aws.String("sslPolicy"),
+		DefaultActions: defaultActions,
+	}
+	rotatedInstance := &elbv2.Listener{
+		ListenerArn:    aws.String("lsArn"),
+		Port:           aws.Int64(443),
+		Protocol:       aws.String(elbv2.ProtocolEnumHttps),
+		Certificates:   []*elbv2.Certificate{{CertificateArn: aws.String("certificateArn2")}},
+		SslPolicy:      aws.String("sslPolicy"),
+		DefaultActions: defaultActions,
+	}
+
+	var calls []string
+	cloud := &mocks.CloudAPI{}
+	cloud.On("DescribeListenerCertificates", ctx, "lsArn").Return([]*elbv2.Certificate{
+		{CertificateArn: aws.String("certificateArn"), IsDefault: aws.Bool(true)},
+		{CertificateArn: aws.String("certificateArn3"), IsDefault: aws.Bool(false)},
+	}, nil)
+	cloud.On("AddListenerCertificates", ctx, &elbv2.AddListenerCertificatesInput{
+		ListenerArn:  aws.String("lsArn"),
+		Certificates: []*elbv2.Certificate{{CertificateArn: aws.String("certificateArn2")}},
+	}).Run(func(mock.Arguments) { calls = append(calls, "AddListenerCertificates") }).Return(nil, nil)
+	cloud.On("ModifyListenerWithContext", ctx, &elbv2.ModifyListenerInput{
+		ListenerArn:    aws.String("lsArn"),
+		Port:           aws.Int64(443),
+		Protocol:       aws.String(elbv2.ProtocolEnumHttps),
+		Certificates:   []*elbv2.Certificate{{CertificateArn: aws.String("certificateArn2")}},
+		SslPolicy:      aws.String("sslPolicy"),
+		DefaultActions: defaultActions,
+	}).Run(func(mock.Arguments) { calls = append(calls, "ModifyListener") }).
+		Return(&elbv2.ModifyListenerOutput{Listeners: []*elbv2.Listener{rotatedInstance}}, nil)
+
+	mockAuthModule := mock_auth.NewMockModule(ctrl)
+	mockAuthModule.EXPECT().NewConfig(gomock.Any(), &ingress, gomock.Any(), gomock.Any()).Return(auth.Config{Type: auth.TypeNone}, nil)
+	mockRulesController := &MockRulesController{}
+	mockRulesController.On("Reconcile", mock.Anything, rotatedInstance, &ingress, &ingressAnnos, tgGroup).Return(nil)
+
+	controller := &defaultController{
+		cloud:           cloud,
+		authModule:      mockAuthModule,
+		rulesController: mockRulesController,
+	}
+	err := controller.Reconcile(ctx, ReconcileOptions{
+		LBArn:        "MyLBArn",
+		Ingress:      &ingress,
+		IngressAnnos: &ingressAnnos,
+		Port:         loadbalancer.PortData{Port: 443, Scheme: elbv2.ProtocolEnumHttps},
+		TGGroup:      tgGroup,
+		Instance:     instance,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "AddListenerCertificates", calls[0], "new default certificate must be added for SNI before it's swapped")
+	assert.Contains(t, calls, "ModifyListener")
+	cloud.AssertNotCalled(t, "RemoveListenerCertificates", mock.Anything, mock.Anything)
+	cloud.AssertExpectations(t)
+	mockRulesController.AssertExpectations(t)
+}
+
+func TestDefaultController_Reconcile_HTTPSRedirect(t *testing.T) {
+	ctx := context.Background()
+	ingress := extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ingress",
+			Namespace: "namespace",
+		},
+		Spec: extensions.IngressSpec{
+			Rules: []extensions.IngressRule{
+				{
+					Host: "example.com",
+					IngressRuleValue: extensions.IngressRuleValue{
+						HTTP: &extensions.HTTPIngressRuleValue{
+							Paths: []extensions.HTTPIngressPath{
+								{Path: "/", Backend: extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(80)}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	ingressAnnos := annotations.Ingress{Action: &action.Config{DefaultBackendRule: true}}
+	instance := &elbv2.Listener{ListenerArn: aws.String("lsArn")}
+
+	cloud := &mocks.CloudAPI{}
+	cloud.On("CreateListenerWithContext", ctx, &elbv2.CreateListenerInput{
+		LoadBalancerArn: aws.String("MyLBArn"),
+		Port:            aws.Int64(80),
+		Protocol:        aws.String(elbv2.ProtocolEnumHttp),
+		DefaultActions: []*elbv2.Action{
+			{
+				Order: aws.Int64(1),
+				Type:  aws.String(elbv2.ActionTypeEnumRedirect),
+				RedirectConfig: &elbv2.RedirectActionConfig{
+					Host:       aws.String("#{host}"),
+					Path:       aws.String("/#{path}"),
+					Port:       aws.String("443"),
+					Protocol:   aws.String(elbv2.ProtocolEnumHttps),
+					Query:      aws.String("#{query}"),
+					StatusCode: aws.String(elbv2.RedirectActionStatusCodeEnumHttp301),
+				},
+			},
+		},
+	}).Return(&elbv2.CreateListenerOutput{Listeners: []*elbv2.Listener{instance}}, nil)
+
+	mockRulesController := &MockRulesController{}
+	mockRulesController.On("Reconcile", mock.Anything, instance, &extensions.Ingress{ObjectMeta: ingress.ObjectMeta}, &annotations.Ingress{}, tg.TargetGroupGroup{}).Return(nil)
+
+	controller := &defaultController{
+		cloud:           cloud,
+		rulesController: mockRulesController,
+	}
+	err := controller.Reconcile(ctx, ReconcileOptions{
+		LBArn:             "MyLBArn",
+		Ingress:           &ingress,
+		IngressAnnos:      &ingressAnnos,
+		Port:              loadbalancer.PortData{Port: 80, Scheme: elbv2.ProtocolEnumHttp},
+		HTTPSRedirectPort: 443,
+	})
+	assert.NoError(t, err)
+	cloud.AssertExpectations(t)
+	mockRulesController.AssertExpectations(t)
+}
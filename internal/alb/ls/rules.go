@@ -6,8 +6,11 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws/awsutil"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
@@ -27,16 +30,20 @@ type RulesController interface {
 }
 
 // NewRulesController constructs RulesController
-func NewRulesController(cloud aws.CloudAPI, authModule auth.Module) RulesController {
+func NewRulesController(cloud aws.CloudAPI, authModule auth.Module, tagsController tags.Controller, tagGen TagGenerator) RulesController {
 	return &rulesController{
-		cloud:      cloud,
-		authModule: authModule,
+		cloud:          cloud,
+		authModule:     authModule,
+		tagsController: tagsController,
+		tagGen:         tagGen,
 	}
 }
 
 type rulesController struct {
-	cloud      aws.CloudAPI
-	authModule auth.Module
+	cloud          aws.CloudAPI
+	authModule     auth.Module
+	tagsController tags.Controller
+	tagGen         TagGenerator
 }
 
 // Reconcile modifies AWS resources to match the rules defined in the Ingress
@@ -50,32 +57,35 @@ func (c *rulesController) Reconcile(ctx context.Context, listener *elbv2.Listene
 	if err != nil {
 		return err
 	}
-	return c.reconcileRules(ctx, lsArn, current, desired)
-}
-
-func (c *rulesController) reconcileRules(ctx context.Context, lsArn string, current []elbv2.Rule, desired []elbv2.Rule) error {
-	additions, modifies, removals := rulesChangeSets(current, desired)
 
-	for _, rule := range additions {
-		albctx.GetLogger(ctx).Infof("creating rule %v on %v", aws.StringValue(rule.Priority), lsArn)
-		priority, _ := strconv.ParseInt(aws.StringValue(rule.Priority), 10, 64)
-		in := &elbv2.CreateRuleInput{
-			ListenerArn: aws.String(lsArn),
-			Actions:     rule.Actions,
-			Conditions:  rule.Conditions,
-			Priority:    aws.Int64(priority),
+	// Rules tagged for other owners are left untouched, so rules on a listener can be attributed to their ingress.
+	var ruleTags map[string]string
+	if c.tagGen != nil {
+		ruleTags = buildTags(c.tagGen, ingress, ingressAnnos)
+		if current, desired, err = c.claimRules(ctx, lsArn, current, desired, ruleTags); err != nil {
+			return err
 		}
+	}
+	return c.reconcileRules(ctx, lsArn, current, desired, ruleTags)
+}
 
-		if _, err := c.cloud.CreateRuleWithContext(ctx, in); err != nil {
-			msg := fmt.Sprintf("failed creating rule %v on %v due to %v", aws.StringValue(rule.Priority), lsArn, err)
-			albctx.GetLogger(ctx).Errorf(msg)
-			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", msg)
-			return fmt.Errorf(msg)
-		}
+// reconcileRules reconciles current rules on listener to desired, newly created rules are tagged with ruleTags if specified.
+func (c *rulesController) reconcileRules(ctx context.Context, lsArn string, current []elbv2.Rule, desired []elbv2.Rule, ruleTags map[string]string) error {
+	additions, modifies, reprioritizes, removals := rulesChangeSets(current, desired)
 
-		msg := fmt.Sprintf("rule %v created with conditions %v", aws.StringValue(rule.Priority), log.Prettify(rule.Conditions))
-		albctx.GetLogger(ctx).Infof(msg)
-		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "CREATE", msg)
+	currentByArn := make(map[string]elbv2.Rule, len(current))
+	for _, rule := range current {
+		currentByArn[aws.StringValue(rule.RuleArn)] = rule
+	}
+
+	// modifies changing the conditions of a rule replace its route as much as removals do.
+	replacements := conditionReplacements(currentByArn, modifies)
+	if albctx.GetRemovalGuard(ctx).Exceeded(len(current), len(removals)+len(replacements)) {
+		msg := fmt.Sprintf("skipping removal of %v and replacement of %v out of %v rules on %v, which exceeds the removal safety threshold", len(removals), len(replacements), len(current), lsArn)
+		albctx.GetLogger(ctx).Warnf(msg)
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ANOMALY", msg)
+		additions, modifies, reprioritizes = keepRules(currentByArn, append(removals, replacements...), additions, modifies, reprioritizes)
+		removals = nil
 	}
 
 	for _, rule := range modifies {
@@ -85,6 +95,13 @@ func (c *rulesController) reconcileRules(ctx context.Context, lsArn string, curr
 			Conditions: rule.Conditions,
 			RuleArn:    rule.RuleArn,
 		}
+		if before, ok := currentByArn[aws.StringValue(rule.RuleArn)]; ok {
+			albctx.RecordAuditBefore(ctx, in, &elbv2.ModifyRuleInput{
+				Actions:    before.Actions,
+				Conditions: before.Conditions,
+				RuleArn:    before.RuleArn,
+			})
+		}
 
 		if _, err := c.cloud.ModifyRuleWithContext(ctx, in); err != nil {
 			msg := fmt.Sprintf("failed modifying rule %v on %v due to %v", aws.StringValue(rule.Priority), lsArn, err)
@@ -113,18 +130,102 @@ func (c *rulesController) reconcileRules(ctx context.Context, lsArn string, curr
 		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "DELETE", msg)
 		albctx.GetLogger(ctx).Infof(msg)
 	}
+
+	// Rules that moved are reprioritized in a single call, so their priorities can be swapped without conflicts.
+	if len(reprioritizes) > 0 {
+		var priorities []*elbv2.RulePriorityPair
+		for _, rule := range reprioritizes {
+			priority, _ := strconv.ParseInt(aws.StringValue(rule.Priority), 10, 64)
+			priorities = append(priorities, &elbv2.RulePriorityPair{
+				RuleArn:  rule.RuleArn,
+				Priority: aws.Int64(priority),
+			})
+		}
+		albctx.GetLogger(ctx).Infof("setting rule priorities on %v to %v", lsArn, log.Prettify(priorities))
+		if _, err := c.cloud.SetRulePrioritiesWithContext(ctx, &elbv2.SetRulePrioritiesInput{
+			RulePriorities: priorities,
+		}); err != nil {
+			msg := fmt.Sprintf("failed setting rule priorities on %v due to %v", lsArn, err)
+			albctx.GetLogger(ctx).Errorf(msg)
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", msg)
+			return fmt.Errorf(msg)
+		}
+
+		for _, rule := range reprioritizes {
+			if rule.Actions == nil {
+				continue
+			}
+			albctx.GetLogger(ctx).Infof("modifying rule %v on %v", aws.StringValue(rule.Priority), lsArn)
+			in := &elbv2.ModifyRuleInput{
+				Actions: rule.Actions,
+				RuleArn: rule.RuleArn,
+			}
+			if before, ok := currentByArn[aws.StringValue(rule.RuleArn)]; ok {
+				albctx.RecordAuditBefore(ctx, in, &elbv2.ModifyRuleInput{
+					Actions: before.Actions,
+					RuleArn: before.RuleArn,
+				})
+			}
+			if _, err := c.cloud.ModifyRuleWithContext(ctx, in); err != nil {
+				msg := fmt.Sprintf("failed modifying rule %v on %v due to %v", aws.StringValue(rule.Priority), lsArn, err)
+				albctx.GetLogger(ctx).Errorf(msg)
+				albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", msg)
+				return fmt.Errorf(msg)
+			}
+		}
+
+		msg := fmt.Sprintf("rule priorities modified to %v", log.Prettify(priorities))
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", msg)
+		albctx.GetLogger(ctx).Infof(msg)
+	}
+
+	for _, rule := range additions {
+		albctx.GetLogger(ctx).Infof("creating rule %v on %v", aws.StringValue(rule.Priority), lsArn)
+		priority, _ := strconv.ParseInt(aws.StringValue(rule.Priority), 10, 64)
+		in := &elbv2.CreateRuleInput{
+			ListenerArn: aws.String(lsArn),
+			Actions:     rule.Actions,
+			Conditions:  rule.Conditions,
+			Priority:    aws.Int64(priority),
+		}
+
+		resp, err := c.cloud.CreateRuleWithContext(ctx, in)
+		if err != nil {
+			msg := fmt.Sprintf("failed creating rule %v on %v due to %v", aws.StringValue(rule.Priority), lsArn, err)
+			albctx.GetLogger(ctx).Errorf(msg)
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", msg)
+			return fmt.Errorf(msg)
+		}
+		if len(ruleTags) > 0 && resp != nil && len(resp.Rules) > 0 {
+			ruleArn := aws.StringValue(resp.Rules[0].RuleArn)
+			if _, err := c.cloud.AddELBV2TagsWithContext(ctx, &elbv2.AddTagsInput{
+				ResourceArns: []*string{aws.String(ruleArn)},
+				Tags:         tags.ConvertToELBV2(ruleTags),
+			}); err != nil {
+				return fmt.Errorf("failed to tag rule %v due to %v", ruleArn, err)
+			}
+		}
+
+		msg := fmt.Sprintf("rule %v created with conditions %v", aws.StringValue(rule.Priority), log.Prettify(rule.Conditions))
+		albctx.GetLogger(ctx).Infof(msg)
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "CREATE", msg)
+	}
 	return nil
 }
 
 func (c *rulesController) getDesiredRules(ctx context.Context, listener *elbv2.Listener, ingress *extensions.Ingress, ingressAnnos *annotations.Ingress, tgGroup tg.TargetGroupGroup) ([]elbv2.Rule, error) {
 	var output []elbv2.Rule
 
+	useDefaultBackendRule := ingressAnnos != nil && ingressAnnos.Action.UseDefaultBackendRule()
 	nextPriority := 1
 	for _, ingressRule := range ingress.Spec.Rules {
 		// Ingress spec allows empty HTTP, and we will 'route all traffic to the default backend'(which relies on default action of listeners)
 		if ingressRule.HTTP == nil {
 			continue
 		}
+		if !hostEnabled(ingressAnnos, ingressRule.Host) {
+			continue
+		}
 
 		for _, path := range ingressRule.HTTP.Paths {
 			authCfg, err := c.authModule.NewConfig(ctx, ingress, path.Backend, aws.StringValue(listener.Protocol))
@@ -149,9 +250,67 @@ func (c *rulesController) getDesiredRules(ctx context.Context, listener *elbv2.L
 			nextPriority++
 		}
 	}
+
+	if useDefaultBackendRule {
+		defaultRules, err := c.buildDefaultBackendRules(ctx, listener, ingress, ingressAnnos, tgGroup, nextPriority)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, defaultRules...)
+	}
+	return output, nil
+}
+
+// buildDefaultBackendRules builds the lowest-priority rules that route to the default backend of listener, starting from priority.
+// Hosts in ingress rules without HTTP paths are routed to the default backend by their host-header, and other requests are routed by a catch-all rule.
+func (c *rulesController) buildDefaultBackendRules(ctx context.Context, listener *elbv2.Listener, ingress *extensions.Ingress, ingressAnnos *annotations.Ingress, tgGroup tg.TargetGroupGroup, priority int) ([]elbv2.Rule, error) {
+	backend := defaultBackend(ingress, ingressAnnos, aws.Int64Value(listener.Port), aws.StringValue(listener.Protocol))
+	if backend == action.Default404Backend() {
+		// default action of listeners already responds 404.
+		return nil, nil
+	}
+	authCfg, err := c.authModule.NewConfig(ctx, ingress, backend, aws.StringValue(listener.Protocol))
+	if err != nil {
+		return nil, err
+	}
+
+	var conditionsList [][]*elbv2.RuleCondition
+	for _, ingressRule := range ingress.Spec.Rules {
+		if ingressRule.HTTP == nil && ingressRule.Host != "" && hostEnabled(ingressAnnos, ingressRule.Host) {
+			conditionsList = append(conditionsList, []*elbv2.RuleCondition{condition("host-header", ingressRule.Host)})
+		}
+	}
+	conditionsList = append(conditionsList, []*elbv2.RuleCondition{condition("path-pattern", "/*")})
+
+	var output []elbv2.Rule
+	for _, conditions := range conditionsList {
+		elbActions, err := buildActions(ctx, authCfg, ingressAnnos, backend, tgGroup)
+		if err != nil {
+			return nil, err
+		}
+		elbRule := elbv2.Rule{
+			IsDefault:  aws.Bool(false),
+			Priority:   aws.String(strconv.Itoa(priority)),
+			Actions:    elbActions,
+			Conditions: conditions,
+		}
+		if createsRedirectLoop(listener, elbRule) {
+			continue
+		}
+		output = append(output, elbRule)
+		priority++
+	}
 	return output, nil
 }
 
+// hostEnabled returns whether rules for host are currently enabled by the rule-schedules annotation.
+func hostEnabled(ingressAnnos *annotations.Ingress, host string) bool {
+	if ingressAnnos == nil {
+		return true
+	}
+	return ingressAnnos.Schedule.Enabled(host, time.Now())
+}
+
 func (c *rulesController) getCurrentRules(ctx context.Context, listenerArn string) ([]elbv2.Rule, error) {
 	rules, err := c.cloud.GetRules(ctx, listenerArn)
 	if err != nil {
@@ -202,6 +361,16 @@ func buildActions(ctx context.Context, authCfg auth.Config, ingressAnnos *annota
 		actions = append(actions, &backendAction)
 	}
 
+	// In maintenance mode, traffic otherwise forwarded to targetGroups is served by the maintenance action instead.
+	if maintenanceAction, ok := ingressAnnos.Action.GetMaintenanceAction(); ok {
+		for index, action := range actions {
+			if aws.StringValue(action.Type) == elbv2.ActionTypeEnumForward {
+				action := maintenanceAction
+				actions[index] = &action
+			}
+		}
+	}
+
 	for index, action := range actions {
 		action.Order = aws.Int64(int64(index) + 1)
 	}
@@ -265,39 +434,141 @@ func buildAuthAction(ctx context.Context, authCfg auth.Config) *elbv2.Action {
 	return nil
 }
 
-// rulesChangeSets compares desired to current, returning a list of rules to add, modify and remove from current to match desired
-func rulesChangeSets(current, desired []elbv2.Rule) (add []elbv2.Rule, modify []elbv2.Rule, remove []elbv2.Rule) {
-	currentMap := make(map[string]elbv2.Rule, len(current))
-	desiredMap := make(map[string]elbv2.Rule, len(desired))
-	for _, i := range current {
-		currentMap[aws.StringValue(i.Priority)] = i
+// rulesChangeSets compares desired to current, returning a list of rules to add, modify, reprioritize and remove from current to match desired.
+// Existing rules are preserved(along with their ARNs) whenever possible:
+//  1. a current rule with same conditions as a desired rule is kept, and moved to the desired priority if necessary.
+//     A reprioritized rule carries Actions only if its actions need modification as well.
+//  2. otherwise, a current rule with same priority as a desired rule is modified in place.
+//  3. remaining desired rules are added, and remaining current rules are removed.
+func rulesChangeSets(current, desired []elbv2.Rule) (add []elbv2.Rule, modify []elbv2.Rule, reprioritize []elbv2.Rule, remove []elbv2.Rule) {
+	for _, rule := range current {
+		sortConditions(rule.Conditions)
+		sortActions(rule.Actions)
 	}
-	for _, i := range desired {
-		desiredMap[aws.StringValue(i.Priority)] = i
+	for _, rule := range desired {
+		sortConditions(rule.Conditions)
+		sortActions(rule.Actions)
 	}
-	currentKeys := sets.StringKeySet(currentMap)
-	desiredKeys := sets.StringKeySet(desiredMap)
-	for key := range desiredKeys.Difference(currentKeys) {
-		add = append(add, desiredMap[key])
+
+	currentByConditions := make(map[string][]elbv2.Rule, len(current))
+	for _, rule := range current {
+		key := conditionsKey(rule.Conditions)
+		currentByConditions[key] = append(currentByConditions[key], rule)
 	}
-	for key := range currentKeys.Difference(desiredKeys) {
-		remove = append(remove, currentMap[key])
+
+	var unmatchedDesired []elbv2.Rule
+	matchedCurrent := sets.NewString()
+	for _, desiredRule := range desired {
+		key := conditionsKey(desiredRule.Conditions)
+		candidates := currentByConditions[key]
+		if len(candidates) == 0 {
+			unmatchedDesired = append(unmatchedDesired, desiredRule)
+			continue
+		}
+		// prefer the candidate already on desired priority.
+		index := 0
+		for i, candidate := range candidates {
+			if aws.StringValue(candidate.Priority) == aws.StringValue(desiredRule.Priority) {
+				index = i
+				break
+			}
+		}
+		currentRule := candidates[index]
+		currentByConditions[key] = append(candidates[:index:index], candidates[index+1:]...)
+		matchedCurrent.Insert(aws.StringValue(currentRule.Priority))
+
+		desiredRule.RuleArn = currentRule.RuleArn
+		actionsChanged := !reflect.DeepEqual(currentRule.Actions, desiredRule.Actions)
+		if aws.StringValue(currentRule.Priority) == aws.StringValue(desiredRule.Priority) {
+			if actionsChanged {
+				modify = append(modify, desiredRule)
+			}
+			continue
+		}
+		if !actionsChanged {
+			desiredRule.Actions = nil
+		}
+		reprioritize = append(reprioritize, desiredRule)
 	}
 
-	for key := range currentKeys.Intersection(desiredKeys) {
-		currentRule := currentMap[key]
-		desiredRule := desiredMap[key]
+	unmatchedCurrentByPriority := make(map[string]elbv2.Rule, len(current))
+	for _, rule := range current {
+		if !matchedCurrent.Has(aws.StringValue(rule.Priority)) {
+			unmatchedCurrentByPriority[aws.StringValue(rule.Priority)] = rule
+		}
+	}
+	for _, desiredRule := range unmatchedDesired {
+		currentRule, ok := unmatchedCurrentByPriority[aws.StringValue(desiredRule.Priority)]
+		if !ok {
+			add = append(add, desiredRule)
+			continue
+		}
+		delete(unmatchedCurrentByPriority, aws.StringValue(desiredRule.Priority))
 		desiredRule.RuleArn = currentRule.RuleArn
+		modify = append(modify, desiredRule)
+	}
+	for _, rule := range current {
+		if _, ok := unmatchedCurrentByPriority[aws.StringValue(rule.Priority)]; ok {
+			remove = append(remove, rule)
+		}
+	}
+	return add, modify, reprioritize, remove
+}
 
-		sortConditions(currentRule.Conditions)
-		sortConditions(desiredRule.Conditions)
-		sortActions(currentRule.Actions)
-		sortActions(desiredRule.Actions)
-		if !reflect.DeepEqual(currentRule, desiredRule) {
-			modify = append(modify, desiredRule)
+// conditionReplacements returns the rules of modifies whose conditions differ from those of the current rule they modify.
+func conditionReplacements(currentByArn map[string]elbv2.Rule, modifies []elbv2.Rule) []elbv2.Rule {
+	var replacements []elbv2.Rule
+	for _, rule := range modifies {
+		currentRule := currentByArn[aws.StringValue(rule.RuleArn)]
+		if conditionsKey(currentRule.Conditions) != conditionsKey(rule.Conditions) {
+			replacements = append(replacements, currentRule)
 		}
 	}
-	return add, modify, remove
+	return replacements
+}
+
+// keepRules returns the additions, modifies and reprioritizes that still apply when the current rules of kept stay in place.
+// Modifies of kept rules are dropped, and so are additions and reprioritizes onto priorities that stay occupied:
+// those of kept rules, and in turn those of rules whose reprioritize is dropped.
+func keepRules(currentByArn map[string]elbv2.Rule, kept []elbv2.Rule, additions, modifies, reprioritizes []elbv2.Rule) ([]elbv2.Rule, []elbv2.Rule, []elbv2.Rule) {
+	held := sets.NewString()
+	keptArns := sets.NewString()
+	for _, rule := range kept {
+		held.Insert(aws.StringValue(rule.Priority))
+		keptArns.Insert(aws.StringValue(rule.RuleArn))
+	}
+	var keptModifies []elbv2.Rule
+	for _, rule := range modifies {
+		if !keptArns.Has(aws.StringValue(rule.RuleArn)) {
+			keptModifies = append(keptModifies, rule)
+		}
+	}
+	for {
+		var keptReprioritizes []elbv2.Rule
+		for _, rule := range reprioritizes {
+			if held.Has(aws.StringValue(rule.Priority)) {
+				held.Insert(aws.StringValue(currentByArn[aws.StringValue(rule.RuleArn)].Priority))
+				continue
+			}
+			keptReprioritizes = append(keptReprioritizes, rule)
+		}
+		if len(keptReprioritizes) == len(reprioritizes) {
+			break
+		}
+		reprioritizes = keptReprioritizes
+	}
+	var keptAdditions []elbv2.Rule
+	for _, rule := range additions {
+		if !held.Has(aws.StringValue(rule.Priority)) {
+			keptAdditions = append(keptAdditions, rule)
+		}
+	}
+	return keptAdditions, keptModifies, reprioritizes
+}
+
+// conditionsKey returns an key that identifies a set of sorted conditions.
+func conditionsKey(conditions []*elbv2.RuleCondition) string {
+	return awsutil.Prettify(conditions)
 }
 
 func condition(field string, values ...string) *elbv2.RuleCondition {
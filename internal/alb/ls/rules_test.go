@@ -8,8 +8,11 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/golang/mock/gomock"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/schedule"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/auth"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
 	mock_auth "github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks/aws-alb-ingress-controller/ingress/auth"
@@ -610,6 +613,252 @@ func Test_getDesiredRules(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "default backend routed by catch-all rule",
+			ingress: extensions.Ingress{
+				Spec: extensions.IngressSpec{
+					Backend: &extensions.IngressBackend{
+						ServiceName: "default",
+						ServicePort: intstr.FromInt(80),
+					},
+					Rules: []extensions.IngressRule{
+						{
+							Host: "www.example.com",
+						},
+						{
+							Host: "api.example.com",
+							IngressRuleValue: extensions.IngressRuleValue{
+								HTTP: &extensions.HTTPIngressRuleValue{
+									Paths: []extensions.HTTPIngressPath{
+										{
+											Path: "/v1/*",
+											Backend: extensions.IngressBackend{
+												ServiceName: "service",
+												ServicePort: intstr.FromInt(80),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ingressAnnos: &annotations.Ingress{
+				Action: &action.Config{DefaultBackendRule: true},
+			},
+			targetGroups: tg.TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{
+					{ServiceName: "default", ServicePort: intstr.FromInt(80)}: {Arn: "defaultTgArn"},
+					{ServiceName: "service", ServicePort: intstr.FromInt(80)}: {Arn: "tgArn"},
+				},
+			},
+			authNewConfigCalls: []AuthNewConfigCall{
+				{
+					backend: extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(80)},
+					authCfg: auth.Config{Type: auth.TypeNone},
+				},
+				{
+					backend: extensions.IngressBackend{ServiceName: "default", ServicePort: intstr.FromInt(80)},
+					authCfg: auth.Config{Type: auth.TypeNone},
+				},
+			},
+			expected: []elbv2.Rule{
+				{
+					IsDefault:  aws.Bool(false),
+					Priority:   aws.String("1"),
+					Conditions: []*elbv2.RuleCondition{condition("host-header", "api.example.com"), condition("path-pattern", "/v1/*")},
+					Actions: []*elbv2.Action{
+						{
+							Order:          aws.Int64(1),
+							Type:           aws.String("forward"),
+							TargetGroupArn: aws.String("tgArn"),
+						},
+					},
+				},
+				{
+					IsDefault:  aws.Bool(false),
+					Priority:   aws.String("2"),
+					Conditions: []*elbv2.RuleCondition{condition("host-header", "www.example.com")},
+					Actions: []*elbv2.Action{
+						{
+							Order:          aws.Int64(1),
+							Type:           aws.String("forward"),
+							TargetGroupArn: aws.String("defaultTgArn"),
+						},
+					},
+				},
+				{
+					IsDefault:  aws.Bool(false),
+					Priority:   aws.String("3"),
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/*")},
+					Actions: []*elbv2.Action{
+						{
+							Order:          aws.Int64(1),
+							Type:           aws.String("forward"),
+							TargetGroupArn: aws.String("defaultTgArn"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "maintenance action replaces forward actions",
+			ingress: extensions.Ingress{
+				Spec: extensions.IngressSpec{
+					Rules: []extensions.IngressRule{
+						{
+							Host: "www.example.com",
+							IngressRuleValue: extensions.IngressRuleValue{
+								HTTP: &extensions.HTTPIngressRuleValue{
+									Paths: []extensions.HTTPIngressPath{
+										{
+											Path: "/*",
+											Backend: extensions.IngressBackend{
+												ServiceName: "service",
+												ServicePort: intstr.FromInt(80),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ingressAnnos: &annotations.Ingress{
+				Action: &action.Config{
+					Actions: map[string]*elbv2.Action{
+						"maintenance": {
+							Type: aws.String("fixed-response"),
+							FixedResponseConfig: &elbv2.FixedResponseActionConfig{
+								ContentType: aws.String("text/plain"),
+								StatusCode:  aws.String("503"),
+							},
+						},
+					},
+					MaintenanceAction: "maintenance",
+				},
+			},
+			targetGroups: tg.TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{
+					{ServiceName: "service", ServicePort: intstr.FromInt(80)}: {Arn: "tgArn"},
+				},
+			},
+			authNewConfigCalls: []AuthNewConfigCall{
+				{
+					backend: extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(80)},
+					authCfg: auth.Config{Type: auth.TypeNone},
+				},
+			},
+			expected: []elbv2.Rule{
+				{
+					IsDefault:  aws.Bool(false),
+					Priority:   aws.String("1"),
+					Conditions: []*elbv2.RuleCondition{condition("host-header", "www.example.com"), condition("path-pattern", "/*")},
+					Actions: []*elbv2.Action{
+						{
+							Order: aws.Int64(1),
+							Type:  aws.String("fixed-response"),
+							FixedResponseConfig: &elbv2.FixedResponseActionConfig{
+								ContentType: aws.String("text/plain"),
+								StatusCode:  aws.String("503"),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "rules of hosts disabled by schedule are skipped",
+			ingress: extensions.Ingress{
+				Spec: extensions.IngressSpec{
+					Rules: []extensions.IngressRule{
+						{
+							Host: "beta.example.com",
+							IngressRuleValue: extensions.IngressRuleValue{
+								HTTP: &extensions.HTTPIngressRuleValue{
+									Paths: []extensions.HTTPIngressPath{
+										{
+											Path: "/*",
+											Backend: extensions.IngressBackend{
+												ServiceName: "beta",
+												ServicePort: intstr.FromInt(80),
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Host: "www.example.com",
+							IngressRuleValue: extensions.IngressRuleValue{
+								HTTP: &extensions.HTTPIngressRuleValue{
+									Paths: []extensions.HTTPIngressPath{
+										{
+											Path: "/*",
+											Backend: extensions.IngressBackend{
+												ServiceName: "service",
+												ServicePort: intstr.FromInt(80),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ingressAnnos: &annotations.Ingress{
+				Action: action.Dummy(),
+				Schedule: &schedule.Config{
+					Schedules: []schedule.RuleSchedule{mustNewRuleSchedule("beta.example.com", "0 0 1 1 *", "* * * * *")},
+				},
+			},
+			targetGroups: tg.TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{
+					{ServiceName: "beta", ServicePort: intstr.FromInt(80)}:    {Arn: "betaTgArn"},
+					{ServiceName: "service", ServicePort: intstr.FromInt(80)}: {Arn: "tgArn"},
+				},
+			},
+			authNewConfigCalls: []AuthNewConfigCall{
+				{
+					backend: extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(80)},
+					authCfg: auth.Config{Type: auth.TypeNone},
+				},
+			},
+			expected: []elbv2.Rule{
+				{
+					IsDefault:  aws.Bool(false),
+					Priority:   aws.String("1"),
+					Conditions: []*elbv2.RuleCondition{condition("host-header", "www.example.com"), condition("path-pattern", "/*")},
+					Actions: []*elbv2.Action{
+						{
+							Order:          aws.Int64(1),
+							Type:           aws.String("forward"),
+							TargetGroupArn: aws.String("tgArn"),
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "catch-all rule is skipped without default backend",
+			ingress: extensions.Ingress{
+				Spec: extensions.IngressSpec{
+					Rules: []extensions.IngressRule{
+						{
+							Host: "www.example.com",
+						},
+					},
+				},
+			},
+			ingressAnnos: &annotations.Ingress{
+				Action: &action.Config{DefaultBackendRule: true},
+			},
+			expected:      nil,
+			expectedError: nil,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
@@ -634,6 +883,14 @@ func Test_getDesiredRules(t *testing.T) {
 	}
 }
 
+func mustNewRuleSchedule(host string, enable string, disable string) schedule.RuleSchedule {
+	sch, err := schedule.NewRuleSchedule(host, enable, disable)
+	if err != nil {
+		panic(err)
+	}
+	return sch
+}
+
 type GetRulesCall struct {
 	Output []*elbv2.Rule
 	Error  error
@@ -753,6 +1010,11 @@ type DeleteRuleCall struct {
 	Error error
 }
 
+type SetRulePrioritiesCall struct {
+	Input *elbv2.SetRulePrioritiesInput
+	Error error
+}
+
 func Test_reconcileRules(t *testing.T) {
 	listenerArn := aws.String("lsArn")
 	tgArn := aws.String("tgArn")
@@ -763,6 +1025,8 @@ func Test_reconcileRules(t *testing.T) {
 		createRuleCall *CreateRuleCall
 		modifyRuleCall *ModifyRuleCall
 		deleteRuleCall *DeleteRuleCall
+		setPrioCall    *SetRulePrioritiesCall
+		removalGuard   albctx.RemovalGuard
 		expectedError  error
 	}{
 		{
@@ -988,24 +1252,388 @@ func Test_reconcileRules(t *testing.T) {
 			},
 			expectedError: errors.New("failed modifying rule 1 on lsArn due to modify rule error"),
 		},
-	} {
-		t.Run(tc.name, func(t *testing.T) {
-			ctx := context.Background()
-			cloud := &mocks.CloudAPI{}
-			if tc.createRuleCall != nil {
-				cloud.On("CreateRuleWithContext", ctx, tc.createRuleCall.Input).Return(nil, tc.createRuleCall.Error)
-			}
-			if tc.modifyRuleCall != nil {
-				cloud.On("ModifyRuleWithContext", ctx, tc.modifyRuleCall.Input).Return(nil, tc.modifyRuleCall.Error)
-			}
-			if tc.deleteRuleCall != nil {
-				cloud.On("DeleteRuleWithContext", ctx, tc.deleteRuleCall.Input).Return(nil, tc.deleteRuleCall.Error)
+		{
+			name: "Insert one rule before existing rule",
+			current: []elbv2.Rule{
+				{
+					RuleArn:    aws.String("RuleArn1"),
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("1"),
+				},
+			},
+			desired: []elbv2.Rule{
+				{
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/path/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("1"),
+				},
+				{
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("2"),
+				},
+			},
+			setPrioCall: &SetRulePrioritiesCall{
+				Input: &elbv2.SetRulePrioritiesInput{
+					RulePriorities: []*elbv2.RulePriorityPair{
+						{RuleArn: aws.String("RuleArn1"), Priority: aws.Int64(2)},
+					},
+				},
+			},
+			createRuleCall: &CreateRuleCall{
+				Input: &elbv2.CreateRuleInput{
+					ListenerArn: listenerArn,
+					Priority:    aws.Int64(1),
+					Conditions:  []*elbv2.RuleCondition{condition("path-pattern", "/path/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+				},
+			},
+		},
+		{
+			name: "Swap two rules",
+			current: []elbv2.Rule{
+				{
+					RuleArn:    aws.String("RuleArn1"),
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/a/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("1"),
+				},
+				{
+					RuleArn:    aws.String("RuleArn2"),
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/b/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("2"),
+				},
+			},
+			desired: []elbv2.Rule{
+				{
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/b/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("1"),
+				},
+				{
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/a/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("2"),
+				},
+			},
+			setPrioCall: &SetRulePrioritiesCall{
+				Input: &elbv2.SetRulePrioritiesInput{
+					RulePriorities: []*elbv2.RulePriorityPair{
+						{RuleArn: aws.String("RuleArn2"), Priority: aws.Int64(1)},
+						{RuleArn: aws.String("RuleArn1"), Priority: aws.Int64(2)},
+					},
+				},
+			},
+		},
+		{
+			name: "SetRulePriorities error",
+			current: []elbv2.Rule{
+				{
+					RuleArn:    aws.String("RuleArn1"),
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("1"),
+				},
+			},
+			desired: []elbv2.Rule{
+				{
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("2"),
+				},
+			},
+			setPrioCall: &SetRulePrioritiesCall{
+				Input: &elbv2.SetRulePrioritiesInput{
+					RulePriorities: []*elbv2.RulePriorityPair{
+						{RuleArn: aws.String("RuleArn1"), Priority: aws.Int64(2)},
+					},
+				},
+				Error: errors.New("set priorities error"),
+			},
+			expectedError: errors.New("failed setting rule priorities on lsArn due to set priorities error"),
+		},
+		{
+			name: "Remove rules beyond removal safety threshold, no actions",
+			current: []elbv2.Rule{
+				{
+					RuleArn:    aws.String("RuleArn1"),
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("1"),
+				},
+				{
+					RuleArn:    aws.String("RuleArn2"),
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/path/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("2"),
+				},
+			},
+			removalGuard: albctx.RemovalGuard{ThresholdPercent: 50},
+		},
+		{
+			name: "Remove rules beyond removal safety threshold, still adds rules",
+			current: []elbv2.Rule{
+				{
+					RuleArn:    aws.String("RuleArn1"),
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("1"),
+				},
+				{
+					RuleArn:    aws.String("RuleArn2"),
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/path/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("2"),
+				},
+			},
+			desired: []elbv2.Rule{
+				{
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/new/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("3"),
+				},
+			},
+			createRuleCall: &CreateRuleCall{
+				Input: &elbv2.CreateRuleInput{
+					ListenerArn: listenerArn,
+					Conditions:  []*elbv2.RuleCondition{condition("path-pattern", "/new/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.Int64(3),
+				},
+			},
+			removalGuard: albctx.RemovalGuard{ThresholdPercent: 50},
+		},
+		{
+			name: "Replace rules beyond removal safety threshold, no actions",
+			current: []elbv2.Rule{
+				{
+					RuleArn:    aws.String("RuleArn1"),
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("1"),
+				},
+				{
+					RuleArn:    aws.String("RuleArn2"),
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/path/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("2"),
+				},
+			},
+			desired: []elbv2.Rule{
+				{
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/other/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("1"),
+				},
+				{
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/path/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("2"),
+				},
+			},
+			removalGuard: albctx.RemovalGuard{ThresholdPercent: 40},
+		},
+		{
+			name: "Remove rules beyond removal safety threshold, skips changes onto priorities kept",
+			current: []elbv2.Rule{
+				{
+					RuleArn:    aws.String("RuleArn1"),
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/a/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("1"),
+				},
+				{
+					RuleArn:    aws.String("RuleArn2"),
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/b/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("2"),
+				},
+				{
+					RuleArn:    aws.String("RuleArn3"),
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/c/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("3"),
+				},
+			},
+			desired: []elbv2.Rule{
+				{
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/b/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("1"),
+				},
+				{
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/new/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("2"),
+				},
+				{
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/d/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("4"),
+				},
+			},
+			createRuleCall: &CreateRuleCall{
+				Input: &elbv2.CreateRuleInput{
+					ListenerArn: listenerArn,
+					Conditions:  []*elbv2.RuleCondition{condition("path-pattern", "/d/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.Int64(4),
+				},
+			},
+			removalGuard: albctx.RemovalGuard{ThresholdPercent: 50},
+		},
+		{
+			name: "Remove rules beyond removal safety threshold with confirmation",
+			current: []elbv2.Rule{
+				{
+					RuleArn:    aws.String("RuleArn1"),
+					Conditions: []*elbv2.RuleCondition{condition("path-pattern", "/*")},
+					Actions: []*elbv2.Action{{
+						Order:          aws.Int64(1),
+						Type:           aws.String(elbv2.ActionTypeEnumForward),
+						TargetGroupArn: tgArn,
+					}},
+					Priority: aws.String("1"),
+				},
+			},
+			deleteRuleCall: &DeleteRuleCall{
+				Input: &elbv2.DeleteRuleInput{
+					RuleArn: aws.String("RuleArn1"),
+				},
+			},
+			removalGuard: albctx.RemovalGuard{ThresholdPercent: 50, Confirmed: true},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := albctx.SetRemovalGuard(context.Background(), tc.removalGuard)
+			cloud := &mocks.CloudAPI{}
+			if tc.createRuleCall != nil {
+				cloud.On("CreateRuleWithContext", ctx, tc.createRuleCall.Input).Return(nil, tc.createRuleCall.Error)
+			}
+			if tc.modifyRuleCall != nil {
+				cloud.On("ModifyRuleWithContext", ctx, tc.modifyRuleCall.Input).Return(nil, tc.modifyRuleCall.Error)
+			}
+			if tc.deleteRuleCall != nil {
+				cloud.On("DeleteRuleWithContext", ctx, tc.deleteRuleCall.Input).Return(nil, tc.deleteRuleCall.Error)
+			}
+			if tc.setPrioCall != nil {
+				cloud.On("SetRulePrioritiesWithContext", ctx, tc.setPrioCall.Input).Return(nil, tc.setPrioCall.Error)
 			}
 
 			controller := &rulesController{
 				cloud: cloud,
 			}
-			err := controller.reconcileRules(context.Background(), *listenerArn, tc.current, tc.desired)
+			err := controller.reconcileRules(ctx, *listenerArn, tc.current, tc.desired, nil)
 			assert.Equal(t, tc.expectedError, err)
 			cloud.AssertExpectations(t)
 		})
@@ -0,0 +1,116 @@
+package ls
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ownerTagPrefix is the prefix of tag keys that identify the ingress owning a listener or rule.
+const ownerTagPrefix = "kubernetes.io/"
+
+// describeTagsBatchSize is the maximum amount of resources ELBV2 DescribeTags accepts per call.
+const describeTagsBatchSize = 20
+
+// TagGenerator generates tags for listener resources
+type TagGenerator interface {
+	TagListener(namespace string, ingressName string) map[string]string
+}
+
+func buildTags(tagGen TagGenerator, ingress *extensions.Ingress, ingressAnnos *annotations.Ingress) map[string]string {
+	tags := make(map[string]string)
+	for k, v := range tagGen.TagListener(ingress.Namespace, ingress.Name) {
+		tags[k] = v
+	}
+	if ingressAnnos != nil && ingressAnnos.Tags != nil {
+		for k, v := range ingressAnnos.Tags.LoadBalancer {
+			tags[k] = v
+		}
+	}
+	return tags
+}
+
+// ownedBy returns whether resource tagged with curTags belongs to the owner of desiredTags.
+// Untagged resources are considered owned, so rules created before tagging was supported are adopted.
+func ownedBy(curTags map[string]string, desiredTags map[string]string) bool {
+	for k, v := range desiredTags {
+		if !strings.HasPrefix(k, ownerTagPrefix) {
+			continue
+		}
+		if curV, ok := curTags[k]; ok && curV != v {
+			return false
+		}
+	}
+	return true
+}
+
+// getRuleTags returns the tags of rules keyed by rule ARN.
+func (c *rulesController) getRuleTags(ctx context.Context, rules []elbv2.Rule) (map[string]map[string]string, error) {
+	tagsByArn := make(map[string]map[string]string)
+	for start := 0; start < len(rules); start += describeTagsBatchSize {
+		end := start + describeTagsBatchSize
+		if end > len(rules) {
+			end = len(rules)
+		}
+		var arns []*string
+		for _, rule := range rules[start:end] {
+			arns = append(arns, rule.RuleArn)
+		}
+		resp, err := c.cloud.DescribeELBV2TagsWithContext(ctx, &elbv2.DescribeTagsInput{ResourceArns: arns})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe tags of rules due to %v", err)
+		}
+		for _, tagDescription := range resp.TagDescriptions {
+			tags := make(map[string]string)
+			for _, tag := range tagDescription.Tags {
+				tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+			tagsByArn[aws.StringValue(tagDescription.ResourceArn)] = tags
+		}
+	}
+	return tagsByArn, nil
+}
+
+// claimRules splits current rules into rules owned by desiredTags and rules owned by others.
+// Owned rules get their tags reconciled, desired rules are renumbered to skip priorities held by others.
+func (c *rulesController) claimRules(ctx context.Context, lsArn string, current []elbv2.Rule, desired []elbv2.Rule, desiredTags map[string]string) ([]elbv2.Rule, []elbv2.Rule, error) {
+	tagsByArn, err := c.getRuleTags(ctx, current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var owned []elbv2.Rule
+	foreignPriorities := sets.NewInt64()
+	for _, rule := range current {
+		ruleArn := aws.StringValue(rule.RuleArn)
+		curTags := tagsByArn[ruleArn]
+		if !ownedBy(curTags, desiredTags) {
+			albctx.GetLogger(ctx).Infof("skipping rule %v on %v since it's owned by another ingress", aws.StringValue(rule.Priority), lsArn)
+			priority, _ := strconv.ParseInt(aws.StringValue(rule.Priority), 10, 64)
+			foreignPriorities.Insert(priority)
+			continue
+		}
+		if err := c.tagsController.ReconcileELBWithCurTags(ctx, ruleArn, desiredTags, curTags); err != nil {
+			return nil, nil, fmt.Errorf("failed to reconcile tags of rule %v due to %v", ruleArn, err)
+		}
+		owned = append(owned, rule)
+	}
+
+	var priority int64
+	for i := range desired {
+		priority++
+		for foreignPriorities.Has(priority) {
+			priority++
+		}
+		desired[i].Priority = aws.String(strconv.FormatInt(priority, 10))
+	}
+	return owned, desired, nil
+}
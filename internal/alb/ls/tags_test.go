@@ -0,0 +1,161 @@
+package ls
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ownedBy(t *testing.T) {
+	desiredTags := map[string]string{
+		"kubernetes.io/namespace":    "default",
+		"kubernetes.io/ingress-name": "ingress",
+		"team":                       "a",
+	}
+	for _, tc := range []struct {
+		name     string
+		curTags  map[string]string
+		expected bool
+	}{
+		{
+			name:     "untagged rule is owned",
+			curTags:  nil,
+			expected: true,
+		},
+		{
+			name: "rule tagged for same ingress is owned",
+			curTags: map[string]string{
+				"kubernetes.io/namespace":    "default",
+				"kubernetes.io/ingress-name": "ingress",
+				"team":                       "b",
+			},
+			expected: true,
+		},
+		{
+			name: "rule tagged for another ingress is not owned",
+			curTags: map[string]string{
+				"kubernetes.io/namespace":    "default",
+				"kubernetes.io/ingress-name": "other",
+			},
+			expected: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ownedBy(tc.curTags, desiredTags))
+		})
+	}
+}
+
+func Test_claimRules(t *testing.T) {
+	lsArn := "lsArn"
+	desiredTags := map[string]string{
+		"kubernetes.io/namespace":    "default",
+		"kubernetes.io/ingress-name": "ingress",
+	}
+	foreignTags := []*elbv2.Tag{
+		{Key: aws.String("kubernetes.io/namespace"), Value: aws.String("default")},
+		{Key: aws.String("kubernetes.io/ingress-name"), Value: aws.String("other")},
+	}
+	for _, tc := range []struct {
+		name            string
+		current         []elbv2.Rule
+		desired         []elbv2.Rule
+		tagDescriptions []*elbv2.TagDescription
+		describeTagsErr error
+		reconciledArns  []string
+		expectedOwned   []elbv2.Rule
+		expectedDesired []elbv2.Rule
+		expectedErr     error
+	}{
+		{
+			name: "untagged rules are adopted",
+			current: []elbv2.Rule{
+				{RuleArn: aws.String("rule1"), Priority: aws.String("1")},
+			},
+			desired: []elbv2.Rule{
+				{Priority: aws.String("1")},
+			},
+			tagDescriptions: []*elbv2.TagDescription{
+				{ResourceArn: aws.String("rule1")},
+			},
+			reconciledArns: []string{"rule1"},
+			expectedOwned: []elbv2.Rule{
+				{RuleArn: aws.String("rule1"), Priority: aws.String("1")},
+			},
+			expectedDesired: []elbv2.Rule{
+				{Priority: aws.String("1")},
+			},
+		},
+		{
+			name: "rules owned by others are preserved and their priorities skipped",
+			current: []elbv2.Rule{
+				{RuleArn: aws.String("rule1"), Priority: aws.String("1")},
+				{RuleArn: aws.String("rule2"), Priority: aws.String("2")},
+			},
+			desired: []elbv2.Rule{
+				{Priority: aws.String("1")},
+				{Priority: aws.String("2")},
+			},
+			tagDescriptions: []*elbv2.TagDescription{
+				{ResourceArn: aws.String("rule1"), Tags: foreignTags},
+				{ResourceArn: aws.String("rule2"), Tags: tags.ConvertToELBV2(desiredTags)},
+			},
+			reconciledArns: []string{"rule2"},
+			expectedOwned: []elbv2.Rule{
+				{RuleArn: aws.String("rule2"), Priority: aws.String("2")},
+			},
+			expectedDesired: []elbv2.Rule{
+				{Priority: aws.String("2")},
+				{Priority: aws.String("3")},
+			},
+		},
+		{
+			name: "describe tags failed",
+			current: []elbv2.Rule{
+				{RuleArn: aws.String("rule1"), Priority: aws.String("1")},
+			},
+			describeTagsErr: errors.New("DescribeTags"),
+			expectedErr:     errors.New("failed to describe tags of rules due to DescribeTags"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			var arns []*string
+			for _, rule := range tc.current {
+				arns = append(arns, rule.RuleArn)
+			}
+			cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{ResourceArns: arns}).Return(&elbv2.DescribeTagsOutput{TagDescriptions: tc.tagDescriptions}, tc.describeTagsErr)
+
+			tagsController := &tags.MockController{}
+			curTagsByArn := make(map[string]map[string]string)
+			for _, tagDescription := range tc.tagDescriptions {
+				curTags := make(map[string]string)
+				for _, tag := range tagDescription.Tags {
+					curTags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+				}
+				curTagsByArn[aws.StringValue(tagDescription.ResourceArn)] = curTags
+			}
+			for _, arn := range tc.reconciledArns {
+				tagsController.On("ReconcileELBWithCurTags", ctx, arn, desiredTags, curTagsByArn[arn]).Return(nil)
+			}
+
+			controller := &rulesController{
+				cloud:          cloud,
+				tagsController: tagsController,
+			}
+			owned, desired, err := controller.claimRules(ctx, lsArn, tc.current, tc.desired, desiredTags)
+			assert.Equal(t, tc.expectedErr, err)
+			assert.Equal(t, tc.expectedOwned, owned)
+			assert.Equal(t, tc.expectedDesired, desired)
+			cloud.AssertExpectations(t)
+			tagsController.AssertExpectations(t)
+		})
+	}
+}
@@ -3,14 +3,18 @@ package sg
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/cleanup"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 	"k8s.io/apimachinery/pkg/types"
 
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -29,7 +33,7 @@ type AssociationController interface {
 }
 
 // NewAssociationController constructs a new association controller
-func NewAssociationController(store store.Storer, cloud aws.CloudAPI, tagsController tags.Controller, nameTagGen NameTagGenerator) AssociationController {
+func NewAssociationController(store store.Storer, cloud aws.CloudAPI, tagsController tags.Controller, nameTagGen NameTagGenerator, deletionQueue cleanup.Queue) AssociationController {
 	lbAttachmentController := &lbAttachmentController{
 		cloud: cloud,
 	}
@@ -46,6 +50,7 @@ func NewAssociationController(store store.Storer, cloud aws.CloudAPI, tagsContro
 		instanceAttachmentController: instanceAttachmentController,
 		sgController:                 sgController,
 		nameTagGen:                   nameTagGen,
+		deletionQueue:                deletionQueue,
 		store:                        store,
 		cloud:                        cloud,
 	}
@@ -56,6 +61,7 @@ type associationController struct {
 	instanceAttachmentController InstanceAttachmentController
 	sgController                 SecurityGroupController
 	nameTagGen                   NameTagGenerator
+	deletionQueue                cleanup.Queue
 
 	store store.Storer
 	cloud aws.CloudAPI
@@ -64,8 +70,12 @@ type associationController struct {
 type associationConfig struct {
 	LbPorts        []int64
 	LbInboundCIDRs []string
+	LbExtraRules   []config.SecurityGroupRule
 	LbExternalSGs  []string
 	AdditionalTags map[string]string
+
+	LbRestrictEgress bool
+	LbEgressCIDRs    []string
 }
 
 func (c *associationController) Reconcile(ctx context.Context, ingress *extensions.Ingress, lbInstance *elbv2.LoadBalancer, tgGroup tg.TargetGroupGroup) error {
@@ -94,10 +104,11 @@ func (c *associationController) Delete(ctx context.Context, ingressKey types.Nam
 }
 
 func (c *associationController) reconcileWithManagedSGs(ctx context.Context, ingressKey types.NamespacedName, lbInstance *elbv2.LoadBalancer, cfg associationConfig, tgGroup tg.TargetGroupGroup) error {
-	lbSGID, err := c.reconcileLbSG(ctx, ingressKey, cfg)
+	lbSG, err := c.reconcileLbSG(ctx, ingressKey, cfg)
 	if err != nil {
 		return err
 	}
+	lbSGID := aws.StringValue(lbSG.GroupId)
 	if err := c.lbAttachmentController.Reconcile(ctx, lbInstance, []string{lbSGID}); err != nil {
 		return err
 	}
@@ -109,6 +120,11 @@ func (c *associationController) reconcileWithManagedSGs(ctx context.Context, ing
 	if err := c.instanceAttachmentController.Reconcile(ctx, instanceSGID, tgGroup); err != nil {
 		return err
 	}
+
+	outboundPermissions := lbOutboundPermissions(ingressKey, cfg, instanceSGID, tgGroup)
+	if err := c.sgController.ReconcileOutbound(ctx, lbSG, outboundPermissions); err != nil {
+		return fmt.Errorf("failed to reconcile managed LoadBalancer securityGroup egress due to %v", err)
+	}
 	return nil
 }
 
@@ -125,11 +141,11 @@ func (c *associationController) reconcileWithExternalSGs(ctx context.Context, in
 	return nil
 }
 
-func (c *associationController) reconcileLbSG(ctx context.Context, ingressKey types.NamespacedName, cfg associationConfig) (string, error) {
+func (c *associationController) reconcileLbSG(ctx context.Context, ingressKey types.NamespacedName, cfg associationConfig) (*ec2.SecurityGroup, error) {
 	sgName := c.nameTagGen.NameLBSG(ingressKey.Namespace, ingressKey.Name)
 	sgInstance, err := c.ensureSGInstance(ctx, sgName, "managed LoadBalancer securityGroup by ALB Ingress Controller")
 	if err != nil {
-		return "", fmt.Errorf("failed to reconcile managed LoadBalancer securityGroup due to %v", err)
+		return nil, fmt.Errorf("failed to reconcile managed LoadBalancer securityGroup due to %v", err)
 	}
 	sgTags := c.nameTagGen.TagLBSG(ingressKey.Namespace, ingressKey.Name)
 	for k, v := range cfg.AdditionalTags {
@@ -141,7 +157,7 @@ func (c *associationController) reconcileLbSG(ctx context.Context, ingressKey ty
 		for _, cidr := range cfg.LbInboundCIDRs {
 			ipRanges = append(ipRanges, &ec2.IpRange{
 				CidrIp:      aws.String(cidr),
-				Description: aws.String(fmt.Sprintf("Allow ingress on port %v from %v", port, cidr)),
+				Description: aws.String(fmt.Sprintf("Allow ingress on port %v from %v for %v", port, cidr, ingressKey)),
 			})
 		}
 		permission := &ec2.IpPermission{
@@ -152,10 +168,106 @@ func (c *associationController) reconcileLbSG(ctx context.Context, ingressKey ty
 		}
 		inboundPermissions = append(inboundPermissions, permission)
 	}
+	for _, rule := range cfg.LbExtraRules {
+		inboundPermissions = addIPPermission(inboundPermissions, extraRulePermission(ingressKey, rule))
+	}
 	if err := c.sgController.Reconcile(ctx, sgInstance, inboundPermissions, sgTags); err != nil {
-		return "", fmt.Errorf("failed to reconcile managed LoadBalancer securityGroup due to %v", err)
+		return nil, fmt.Errorf("failed to reconcile managed LoadBalancer securityGroup due to %v", err)
 	}
-	return aws.StringValue(sgInstance.GroupId), nil
+	return sgInstance, nil
+}
+
+// lbOutboundPermissions returns the outbound permissions of LoadBalancer securityGroup of ingress.
+// Unless egress is restricted, it's the allow-all permission that EC2 creates by default.
+// Otherwise egress is allowed to the instance securityGroup and egress CIDRs on the target and health check ports.
+func lbOutboundPermissions(ingressKey types.NamespacedName, cfg associationConfig, instanceSGID string, tgGroup tg.TargetGroupGroup) []*ec2.IpPermission {
+	if !cfg.LbRestrictEgress {
+		return []*ec2.IpPermission{defaultOutboundPermission()}
+	}
+
+	portSet := make(map[int64]bool)
+	for _, tgInstance := range tgGroup.TGByBackend {
+		for _, target := range tgInstance.Targets {
+			portSet[aws.Int64Value(target.Port)] = true
+		}
+		if port, err := strconv.ParseInt(tgInstance.HealthCheckPort, 10, 64); err == nil {
+			portSet[port] = true
+		}
+	}
+	ports := make([]int64, 0, len(portSet))
+	for port := range portSet {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+
+	permissions := make([]*ec2.IpPermission, 0, len(ports))
+	for _, port := range ports {
+		ipRanges := make([]*ec2.IpRange, 0, len(cfg.LbEgressCIDRs))
+		for _, cidr := range cfg.LbEgressCIDRs {
+			ipRanges = append(ipRanges, &ec2.IpRange{
+				CidrIp:      aws.String(cidr),
+				Description: aws.String(fmt.Sprintf("Allow egress on port %v to %v for %v", port, cidr, ingressKey)),
+			})
+		}
+		permissions = append(permissions, &ec2.IpPermission{
+			IpProtocol: aws.String("tcp"),
+			FromPort:   aws.Int64(port),
+			ToPort:     aws.Int64(port),
+			IpRanges:   ipRanges,
+			UserIdGroupPairs: []*ec2.UserIdGroupPair{
+				{
+					GroupId:     aws.String(instanceSGID),
+					Description: aws.String(fmt.Sprintf("Allow egress on port %v to instance securityGroup for %v", port, ingressKey)),
+				},
+			},
+		})
+	}
+	return permissions
+}
+
+// defaultOutboundPermission returns the allow-all outbound permission that EC2 creates for new securityGroups.
+func defaultOutboundPermission() *ec2.IpPermission {
+	return &ec2.IpPermission{
+		IpProtocol: aws.String("-1"),
+		IpRanges: []*ec2.IpRange{
+			{
+				CidrIp: aws.String("0.0.0.0/0"),
+			},
+		},
+	}
+}
+
+// extraRulePermission returns the inbound permission of extra rule for LoadBalancer securityGroup of ingress.
+func extraRulePermission(ingressKey types.NamespacedName, rule config.SecurityGroupRule) *ec2.IpPermission {
+	permission := &ec2.IpPermission{
+		IpProtocol: aws.String(rule.Protocol),
+		IpRanges: []*ec2.IpRange{
+			{
+				CidrIp:      aws.String(rule.CIDR),
+				Description: aws.String(fmt.Sprintf("Allow ingress by rule %v for %v", rule, ingressKey)),
+			},
+		},
+	}
+	switch rule.Protocol {
+	case "tcp", "udp", "icmp":
+		permission.FromPort = aws.Int64(rule.FromPort)
+		permission.ToPort = aws.Int64(rule.ToPort)
+	}
+	return permission
+}
+
+// addIPPermission adds permission to permissions, merging its IpRanges into the permission of same protocol and ports if any,
+// since EC2 reports them as a single permission.
+func addIPPermission(permissions []*ec2.IpPermission, permission *ec2.IpPermission) []*ec2.IpPermission {
+	for _, existing := range permissions {
+		if aws.StringValue(existing.IpProtocol) == aws.StringValue(permission.IpProtocol) &&
+			aws.Int64Value(existing.FromPort) == aws.Int64Value(permission.FromPort) &&
+			aws.Int64Value(existing.ToPort) == aws.Int64Value(permission.ToPort) {
+			existing.IpRanges = append(existing.IpRanges, diffIPRanges(permission.IpRanges, existing.IpRanges)...)
+			return permissions
+		}
+	}
+	return append(permissions, permission)
 }
 
 func (c *associationController) deleteLbSG(ctx context.Context, ingressKey types.NamespacedName) error {
@@ -187,7 +299,8 @@ func (c *associationController) reconcileInstanceSG(ctx context.Context, ingress
 			ToPort:     aws.Int64(65535),
 			UserIdGroupPairs: []*ec2.UserIdGroupPair{
 				{
-					GroupId: aws.String(lbSGID),
+					GroupId:     aws.String(lbSGID),
+					Description: aws.String(fmt.Sprintf("Allow ingress on all ports from LoadBalancer securityGroup for %v", ingressKey)),
 				},
 			},
 		},
@@ -219,6 +332,7 @@ func (c *associationController) ensureSGInstance(ctx context.Context, groupName
 		return nil, err
 	}
 	if sgInstance != nil {
+		c.deletionQueue.Cancel(ctx, cleanup.KindSecurityGroup, aws.StringValue(sgInstance.GroupId))
 		return sgInstance, nil
 	}
 	albctx.GetLogger(ctx).Infof("creating securityGroup %v:%v", groupName, description)
@@ -230,14 +344,15 @@ func (c *associationController) ensureSGInstance(ctx context.Context, groupName
 		return nil, err
 	}
 	return &ec2.SecurityGroup{
-		GroupId:   resp.GroupId,
-		GroupName: aws.String(groupName),
+		GroupId:             resp.GroupId,
+		GroupName:           aws.String(groupName),
+		IpPermissionsEgress: []*ec2.IpPermission{defaultOutboundPermission()},
 	}, nil
 }
 
 func (c *associationController) deleteSGInstance(ctx context.Context, instance *ec2.SecurityGroup) error {
 	albctx.GetLogger(ctx).Infof("deleting securityGroup %v:%v", aws.StringValue(instance.GroupName), aws.StringValue(instance.Description))
-	return c.cloud.DeleteSecurityGroupByID(aws.StringValue(instance.GroupId))
+	return c.deletionQueue.Delete(ctx, cleanup.KindSecurityGroup, aws.StringValue(instance.GroupId))
 }
 
 func (c *associationController) buildAssociationConfig(ctx context.Context, ingress *extensions.Ingress) (associationConfig, error) {
@@ -257,8 +372,12 @@ func (c *associationController) buildAssociationConfig(ctx context.Context, ingr
 	return associationConfig{
 		LbPorts:        lbPorts,
 		LbInboundCIDRs: ingressAnnos.LoadBalancer.InboundCidrs,
+		LbExtraRules:   c.store.GetConfig().LBSGExtraInboundRules,
 		LbExternalSGs:  lbExternalSGs,
 		AdditionalTags: ingressAnnos.Tags.LoadBalancer,
+
+		LbRestrictEgress: c.store.GetConfig().RestrictLBSGEgress,
+		LbEgressCIDRs:    c.store.GetConfig().LBSGEgressCIDRs,
 	}, nil
 }
 
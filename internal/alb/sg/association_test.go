@@ -7,9 +7,15 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
 	"github.com/magiconair/properties/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func Test_resolveSecurityGroupIDs(t *testing.T) {
@@ -92,3 +98,78 @@ func Test_resolveSecurityGroupIDs(t *testing.T) {
 		})
 	}
 }
+
+func Test_addIPPermission(t *testing.T) {
+	ingressKey := types.NamespacedName{Namespace: "namespace", Name: "ingress"}
+	permissions := []*ec2.IpPermission{
+		{
+			IpProtocol: aws.String("tcp"),
+			FromPort:   aws.Int64(443),
+			ToPort:     aws.Int64(443),
+			IpRanges: []*ec2.IpRange{
+				{CidrIp: aws.String("10.0.0.0/8"), Description: aws.String("Allow ingress on port 443 from 10.0.0.0/8 for namespace/ingress")},
+			},
+		},
+	}
+	permissions = addIPPermission(permissions, extraRulePermission(ingressKey, config.SecurityGroupRule{Protocol: "tcp", FromPort: 443, ToPort: 443, CIDR: "192.168.0.0/16"}))
+	permissions = addIPPermission(permissions, extraRulePermission(ingressKey, config.SecurityGroupRule{Protocol: "icmp", FromPort: 3, ToPort: 4, CIDR: "0.0.0.0/0"}))
+	permissions = addIPPermission(permissions, extraRulePermission(ingressKey, config.SecurityGroupRule{Protocol: "50", CIDR: "10.0.0.0/8"}))
+
+	assert.Equal(t, permissions, []*ec2.IpPermission{
+		{
+			IpProtocol: aws.String("tcp"),
+			FromPort:   aws.Int64(443),
+			ToPort:     aws.Int64(443),
+			IpRanges: []*ec2.IpRange{
+				{CidrIp: aws.String("10.0.0.0/8"), Description: aws.String("Allow ingress on port 443 from 10.0.0.0/8 for namespace/ingress")},
+				{CidrIp: aws.String("192.168.0.0/16"), Description: aws.String("Allow ingress by rule tcp:443:192.168.0.0/16 for namespace/ingress")},
+			},
+		},
+		{
+			IpProtocol: aws.String("icmp"),
+			FromPort:   aws.Int64(3),
+			ToPort:     aws.Int64(4),
+			IpRanges: []*ec2.IpRange{
+				{CidrIp: aws.String("0.0.0.0/0"), Description: aws.String("Allow ingress by rule icmp:3-4:0.0.0.0/0 for namespace/ingress")},
+			},
+		},
+		{
+			IpProtocol: aws.String("50"),
+			IpRanges: []*ec2.IpRange{
+				{CidrIp: aws.String("10.0.0.0/8"), Description: aws.String("Allow ingress by rule 50:0:10.0.0.0/8 for namespace/ingress")},
+			},
+		},
+	})
+}
+
+func Test_lbOutboundPermissions(t *testing.T) {
+	ingressKey := types.NamespacedName{Namespace: "namespace", Name: "ingress"}
+	tgGroup := tg.TargetGroupGroup{
+		TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{
+			{ServiceName: "service1", ServicePort: intstr.FromInt(80)}: {
+				Targets: []*elbv2.TargetDescription{
+					{Id: aws.String("i-1"), Port: aws.Int64(30080)},
+					{Id: aws.String("i-2"), Port: aws.Int64(30080)},
+				},
+				HealthCheckPort: "traffic-port",
+			},
+			{ServiceName: "service2", ServicePort: intstr.FromInt(80)}: {
+				Targets: []*elbv2.TargetDescription{
+					{Id: aws.String("i-1"), Port: aws.Int64(30081)},
+				},
+				HealthCheckPort: "30000",
+			},
+		},
+	}
+
+	assert.Equal(t, lbOutboundPermissions(ingressKey, associationConfig{}, "sg-instance", tgGroup), []*ec2.IpPermission{defaultOutboundPermission()})
+
+	cfg := associationConfig{LbRestrictEgress: true, LbEgressCIDRs: []string{"10.0.0.0/16"}}
+	var ports []int64
+	for _, permission := range lbOutboundPermissions(ingressKey, cfg, "sg-instance", tgGroup) {
+		ports = append(ports, aws.Int64Value(permission.FromPort))
+		assert.Equal(t, aws.StringValue(permission.UserIdGroupPairs[0].GroupId), "sg-instance")
+		assert.Equal(t, aws.StringValue(permission.IpRanges[0].CidrIp), "10.0.0.0/16")
+	}
+	assert.Equal(t, ports, []int64{30000, 30080, 30081})
+}
@@ -28,10 +28,15 @@ func (controller *lbAttachmentController) Reconcile(ctx context.Context, lbInsta
 	currentGroups := sets.NewString(aws.StringValueSlice(lbInstance.SecurityGroups)...)
 	if !desiredGroups.Equal(currentGroups) {
 		albctx.GetLogger(ctx).Infof("modify securityGroup on LoadBalancer %s to be %v", aws.StringValue(lbInstance.LoadBalancerArn), desiredGroups.List())
-		if _, err := controller.cloud.SetSecurityGroupsWithContext(ctx, &elbv2.SetSecurityGroupsInput{
+		in := &elbv2.SetSecurityGroupsInput{
 			LoadBalancerArn: lbInstance.LoadBalancerArn,
 			SecurityGroups:  aws.StringSlice(desiredGroups.List()),
-		}); err != nil {
+		}
+		albctx.RecordAuditBefore(ctx, in, &elbv2.SetSecurityGroupsInput{
+			LoadBalancerArn: lbInstance.LoadBalancerArn,
+			SecurityGroups:  aws.StringSlice(currentGroups.List()),
+		})
+		if _, err := controller.cloud.SetSecurityGroupsWithContext(ctx, in); err != nil {
 			return err
 		}
 	}
@@ -47,10 +52,15 @@ func (controller *lbAttachmentController) Delete(ctx context.Context, lbInstance
 	currentGroups := sets.NewString(aws.StringValueSlice(lbInstance.SecurityGroups)...)
 	if !desiredGroups.Equal(currentGroups) {
 		albctx.GetLogger(ctx).Infof("modify securityGroup on LoadBalancer %s to be %v", aws.StringValue(lbInstance.LoadBalancerArn), desiredGroups.List())
-		if _, err := controller.cloud.SetSecurityGroupsWithContext(ctx, &elbv2.SetSecurityGroupsInput{
+		in := &elbv2.SetSecurityGroupsInput{
 			LoadBalancerArn: lbInstance.LoadBalancerArn,
 			SecurityGroups:  aws.StringSlice(desiredGroups.List()),
-		}); err != nil {
+		}
+		albctx.RecordAuditBefore(ctx, in, &elbv2.SetSecurityGroupsInput{
+			LoadBalancerArn: lbInstance.LoadBalancerArn,
+			SecurityGroups:  aws.StringSlice(currentGroups.List()),
+		})
+		if _, err := controller.cloud.SetSecurityGroupsWithContext(ctx, in); err != nil {
 			return err
 		}
 	}
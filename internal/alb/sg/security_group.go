@@ -15,6 +15,9 @@ import (
 type SecurityGroupController interface {
 	// Reconcile ensures the securityGroup configuration matches specification.
 	Reconcile(ctx context.Context, instance *ec2.SecurityGroup, inboundPermissions []*ec2.IpPermission, tags map[string]string) error
+
+	// ReconcileOutbound ensures the outbound permissions of securityGroup matches specification.
+	ReconcileOutbound(ctx context.Context, instance *ec2.SecurityGroup, outboundPermissions []*ec2.IpPermission) error
 }
 
 type securityGroupController struct {
@@ -56,6 +59,44 @@ func (c *securityGroupController) reconcileInboundPermissions(ctx context.Contex
 		}
 	}
 
+	permissionsToDescribe := diffIPPermissionDescriptions(inboundPermissions, sgInstance.IpPermissions)
+	if len(permissionsToDescribe) != 0 {
+		albctx.GetLogger(ctx).Infof("updating inbound permission descriptions of securityGroup %s: %v", aws.StringValue(sgInstance.GroupId), log.Prettify(permissionsToDescribe))
+		if _, err := c.cloud.UpdateSecurityGroupRuleDescriptionsIngressWithContext(ctx, &ec2.UpdateSecurityGroupRuleDescriptionsIngressInput{
+			GroupId:       sgInstance.GroupId,
+			IpPermissions: permissionsToDescribe,
+		}); err != nil {
+			return fmt.Errorf("failed to update inbound permission descriptions due to %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *securityGroupController) ReconcileOutbound(ctx context.Context, sgInstance *ec2.SecurityGroup, outboundPermissions []*ec2.IpPermission) error {
+	permissionsToGrant := diffIPPermissions(outboundPermissions, sgInstance.IpPermissionsEgress)
+	if len(permissionsToGrant) != 0 {
+		albctx.GetLogger(ctx).Infof("granting outbound permissions to securityGroup %s: %v", aws.StringValue(sgInstance.GroupId), log.Prettify(permissionsToGrant))
+		if _, err := c.cloud.AuthorizeSecurityGroupEgressWithContext(ctx, &ec2.AuthorizeSecurityGroupEgressInput{
+			GroupId:       sgInstance.GroupId,
+			IpPermissions: permissionsToGrant,
+		}); err != nil {
+			return fmt.Errorf("failed to grant outbound permissions due to %v", err)
+		}
+	}
+
+	// outbound permissions are revoked after granting, so that traffic to targets isn't interrupted when egress becomes restricted.
+	permissionsToRevoke := diffIPPermissions(sgInstance.IpPermissionsEgress, outboundPermissions)
+	if len(permissionsToRevoke) != 0 {
+		albctx.GetLogger(ctx).Infof("revoking outbound permissions from securityGroup %s: %v", aws.StringValue(sgInstance.GroupId), log.Prettify(permissionsToRevoke))
+		if _, err := c.cloud.RevokeSecurityGroupEgressWithContext(ctx, &ec2.RevokeSecurityGroupEgressInput{
+			GroupId:       sgInstance.GroupId,
+			IpPermissions: permissionsToRevoke,
+		}); err != nil {
+			return fmt.Errorf("failed to revoke outbound permissions due to %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -88,6 +129,40 @@ func diffIPPermissions(source []*ec2.IpPermission, target []*ec2.IpPermission) (
 	return diffs
 }
 
+// diffIPPermissionDescriptions returns the permissions in source that exist in target with different descriptions
+func diffIPPermissionDescriptions(source []*ec2.IpPermission, target []*ec2.IpPermission) (diffs []*ec2.IpPermission) {
+	for _, sPermission := range source {
+		for _, tPermission := range target {
+			if ipPermissionEquals(sPermission, tPermission) {
+				if !ipPermissionDescriptionsEquals(sPermission, tPermission) {
+					diffs = append(diffs, sPermission)
+				}
+				break
+			}
+		}
+	}
+	return diffs
+}
+
+// ipPermissionDescriptionsEquals test whether the descriptions of IpRanges and UserIdGroupPairs of two equal IPPermission are equals
+func ipPermissionDescriptionsEquals(source *ec2.IpPermission, target *ec2.IpPermission) bool {
+	for _, sRange := range source.IpRanges {
+		for _, tRange := range target.IpRanges {
+			if ipRangeEquals(sRange, tRange) && aws.StringValue(sRange.Description) != aws.StringValue(tRange.Description) {
+				return false
+			}
+		}
+	}
+	for _, sPair := range source.UserIdGroupPairs {
+		for _, tPair := range target.UserIdGroupPairs {
+			if userIDGroupPairEquals(sPair, tPair) && aws.StringValue(sPair.Description) != aws.StringValue(tPair.Description) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // ipPermissionEquals test whether two IPPermission instance are equals
 func ipPermissionEquals(source *ec2.IpPermission, target *ec2.IpPermission) bool {
 	if aws.StringValue(source.IpProtocol) != aws.StringValue(target.IpProtocol) {
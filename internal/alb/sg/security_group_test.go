@@ -32,6 +32,11 @@ type AuthorizeSecurityGroupIngressCall struct {
 	Err   error
 }
 
+type UpdateSecurityGroupRuleDescriptionsIngressCall struct {
+	Input *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput
+	Err   error
+}
+
 func TestReconcile(t *testing.T) {
 	for _, tc := range []struct {
 		Name               string
@@ -39,10 +44,11 @@ func TestReconcile(t *testing.T) {
 		InboundPermissions []*ec2.IpPermission
 		Tags               map[string]string
 
-		ReconcileEC2WithCurTagsCall       *ReconcileEC2WithCurTagsCall
-		RevokeSecurityGroupIngressCall    *RevokeSecurityGroupIngressCall
-		AuthorizeSecurityGroupIngressCall *AuthorizeSecurityGroupIngressCall
-		ExpectedError                     error
+		ReconcileEC2WithCurTagsCall                    *ReconcileEC2WithCurTagsCall
+		RevokeSecurityGroupIngressCall                 *RevokeSecurityGroupIngressCall
+		AuthorizeSecurityGroupIngressCall              *AuthorizeSecurityGroupIngressCall
+		UpdateSecurityGroupRuleDescriptionsIngressCall *UpdateSecurityGroupRuleDescriptionsIngressCall
+		ExpectedError                                  error
 	}{
 		{
 			Name: "reconcile succeed without change anything",
@@ -236,6 +242,63 @@ func TestReconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "reconcile succeed by update permission descriptions",
+			Instance: ec2.SecurityGroup{
+				GroupId:   aws.String("groupID"),
+				GroupName: aws.String("groupName"),
+				IpPermissions: []*ec2.IpPermission{
+					{
+						IpProtocol: aws.String("tcp"),
+						FromPort:   aws.Int64(80),
+						ToPort:     aws.Int64(80),
+						IpRanges: []*ec2.IpRange{
+							{
+								CidrIp:      aws.String("0.0.0.0/0"),
+								Description: aws.String("Allow ingress on port 80 from 0.0.0.0/0"),
+							},
+						},
+					},
+				},
+			},
+			InboundPermissions: []*ec2.IpPermission{
+				{
+					IpProtocol: aws.String("tcp"),
+					FromPort:   aws.Int64(80),
+					ToPort:     aws.Int64(80),
+					IpRanges: []*ec2.IpRange{
+						{
+							CidrIp:      aws.String("0.0.0.0/0"),
+							Description: aws.String("Allow ingress on port 80 from 0.0.0.0/0 for namespace/ingress"),
+						},
+					},
+				},
+			},
+			Tags: map[string]string{},
+			ReconcileEC2WithCurTagsCall: &ReconcileEC2WithCurTagsCall{
+				GroupID: "groupID",
+				Tags:    map[string]string{},
+				CurTags: map[string]string{},
+			},
+			UpdateSecurityGroupRuleDescriptionsIngressCall: &UpdateSecurityGroupRuleDescriptionsIngressCall{
+				Input: &ec2.UpdateSecurityGroupRuleDescriptionsIngressInput{
+					GroupId: aws.String("groupID"),
+					IpPermissions: []*ec2.IpPermission{
+						{
+							IpProtocol: aws.String("tcp"),
+							FromPort:   aws.Int64(80),
+							ToPort:     aws.Int64(80),
+							IpRanges: []*ec2.IpRange{
+								{
+									CidrIp:      aws.String("0.0.0.0/0"),
+									Description: aws.String("Allow ingress on port 80 from 0.0.0.0/0 for namespace/ingress"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			Name: "reconcile failed when reconcile tags",
 			Instance: ec2.SecurityGroup{
@@ -359,6 +422,9 @@ func TestReconcile(t *testing.T) {
 			if tc.RevokeSecurityGroupIngressCall != nil {
 				cloud.On("RevokeSecurityGroupIngressWithContext", mock.Anything, tc.RevokeSecurityGroupIngressCall.Input).Return(nil, tc.RevokeSecurityGroupIngressCall.Err)
 			}
+			if tc.UpdateSecurityGroupRuleDescriptionsIngressCall != nil {
+				cloud.On("UpdateSecurityGroupRuleDescriptionsIngressWithContext", mock.Anything, tc.UpdateSecurityGroupRuleDescriptionsIngressCall.Input).Return(nil, tc.UpdateSecurityGroupRuleDescriptionsIngressCall.Err)
+			}
 
 			sgController := securityGroupController{
 				cloud:          cloud,
@@ -783,3 +849,114 @@ func TestDiffIPPermissions(t *testing.T) {
 		}
 	}
 }
+
+type AuthorizeSecurityGroupEgressCall struct {
+	Input *ec2.AuthorizeSecurityGroupEgressInput
+	Err   error
+}
+
+type RevokeSecurityGroupEgressCall struct {
+	Input *ec2.RevokeSecurityGroupEgressInput
+	Err   error
+}
+
+func TestReconcileOutbound(t *testing.T) {
+	allowAll := &ec2.IpPermission{
+		IpProtocol: aws.String("-1"),
+		IpRanges:   []*ec2.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+	}
+	toInstanceSG := &ec2.IpPermission{
+		IpProtocol:       aws.String("tcp"),
+		FromPort:         aws.Int64(8080),
+		ToPort:           aws.Int64(8080),
+		UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: aws.String("instanceSG")}},
+	}
+	for _, tc := range []struct {
+		Name                string
+		Instance            ec2.SecurityGroup
+		OutboundPermissions []*ec2.IpPermission
+
+		AuthorizeSecurityGroupEgressCall *AuthorizeSecurityGroupEgressCall
+		RevokeSecurityGroupEgressCall    *RevokeSecurityGroupEgressCall
+		ExpectedError                    error
+	}{
+		{
+			Name: "reconcile succeed without change anything",
+			Instance: ec2.SecurityGroup{
+				GroupId:             aws.String("groupID"),
+				IpPermissionsEgress: []*ec2.IpPermission{allowAll},
+			},
+			OutboundPermissions: []*ec2.IpPermission{allowAll},
+		},
+		{
+			Name: "reconcile succeed by restricting egress",
+			Instance: ec2.SecurityGroup{
+				GroupId:             aws.String("groupID"),
+				IpPermissionsEgress: []*ec2.IpPermission{allowAll},
+			},
+			OutboundPermissions: []*ec2.IpPermission{toInstanceSG},
+			AuthorizeSecurityGroupEgressCall: &AuthorizeSecurityGroupEgressCall{
+				Input: &ec2.AuthorizeSecurityGroupEgressInput{
+					GroupId:       aws.String("groupID"),
+					IpPermissions: []*ec2.IpPermission{toInstanceSG},
+				},
+			},
+			RevokeSecurityGroupEgressCall: &RevokeSecurityGroupEgressCall{
+				Input: &ec2.RevokeSecurityGroupEgressInput{
+					GroupId:       aws.String("groupID"),
+					IpPermissions: []*ec2.IpPermission{allowAll},
+				},
+			},
+		},
+		{
+			Name: "reconcile failed when granting egress",
+			Instance: ec2.SecurityGroup{
+				GroupId:             aws.String("groupID"),
+				IpPermissionsEgress: []*ec2.IpPermission{allowAll},
+			},
+			OutboundPermissions: []*ec2.IpPermission{toInstanceSG},
+			AuthorizeSecurityGroupEgressCall: &AuthorizeSecurityGroupEgressCall{
+				Input: &ec2.AuthorizeSecurityGroupEgressInput{
+					GroupId:       aws.String("groupID"),
+					IpPermissions: []*ec2.IpPermission{toInstanceSG},
+				},
+				Err: errors.New("AuthorizeSecurityGroupEgressCall"),
+			},
+			ExpectedError: errors.New("failed to grant outbound permissions due to AuthorizeSecurityGroupEgressCall"),
+		},
+		{
+			Name: "reconcile failed when revoking egress",
+			Instance: ec2.SecurityGroup{
+				GroupId:             aws.String("groupID"),
+				IpPermissionsEgress: []*ec2.IpPermission{allowAll, toInstanceSG},
+			},
+			OutboundPermissions: []*ec2.IpPermission{toInstanceSG},
+			RevokeSecurityGroupEgressCall: &RevokeSecurityGroupEgressCall{
+				Input: &ec2.RevokeSecurityGroupEgressInput{
+					GroupId:       aws.String("groupID"),
+					IpPermissions: []*ec2.IpPermission{allowAll},
+				},
+				Err: errors.New("RevokeSecurityGroupEgressCall"),
+			},
+			ExpectedError: errors.New("failed to revoke outbound permissions due to RevokeSecurityGroupEgressCall"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			cloud := &mocks.CloudAPI{}
+			if tc.AuthorizeSecurityGroupEgressCall != nil {
+				cloud.On("AuthorizeSecurityGroupEgressWithContext", mock.Anything, tc.AuthorizeSecurityGroupEgressCall.Input).Return(nil, tc.AuthorizeSecurityGroupEgressCall.Err)
+			}
+			if tc.RevokeSecurityGroupEgressCall != nil {
+				cloud.On("RevokeSecurityGroupEgressWithContext", mock.Anything, tc.RevokeSecurityGroupEgressCall.Input).Return(nil, tc.RevokeSecurityGroupEgressCall.Err)
+			}
+
+			sgController := securityGroupController{
+				cloud: cloud,
+			}
+
+			err := sgController.ReconcileOutbound(context.Background(), &tc.Instance, tc.OutboundPermissions)
+			assert.Equal(t, err, tc.ExpectedError)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package tags
+
+import (
+	"context"
+	"hash/fnv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// AppliedAttributesKey is the tag recording the keys of the attributes the controller applied to a resource,
+// so attributes removed from annotations are reset to their defaults while attributes never set by the controller are left alone.
+// It's reconciled by the attributes controllers, so it's never removed when reconciling the other tags.
+const AppliedAttributesKey = "alb.ingress.kubernetes.io/applied-attributes"
+
+// MaxKeySetSize is the number of keys a KeySet can record in a single tag value.
+// Tag values are limited to 256 characters, each key takes 5 characters plus a separator.
+const MaxKeySetSize = 42
+
+// KeySet records a set of keys in a tag value.
+// Tag values are too short to list arbitrary keys, so each key is recorded by a 30-bit FNV-1a hash of it, base64 encoded.
+type KeySet sets.String
+
+// NewKeySet returns a KeySet recording keys.
+func NewKeySet(keys ...string) KeySet {
+	s := sets.NewString()
+	for _, key := range keys {
+		s.Insert(keyDigest(key))
+	}
+	return KeySet(s)
+}
+
+// ParseKeySet returns the KeySet recorded in tag value.
+func ParseKeySet(value string) KeySet {
+	return KeySet(sets.NewString(strings.Fields(value)...))
+}
+
+// Has returns whether key is recorded in s.
+func (s KeySet) Has(key string) bool {
+	return sets.String(s).Has(keyDigest(key))
+}
+
+// Union returns a KeySet recording the keys of both s and other.
+func (s KeySet) Union(other KeySet) KeySet {
+	return KeySet(sets.String(s).Union(sets.String(other)))
+}
+
+// Equal returns whether s and other record the same keys.
+func (s KeySet) Equal(other KeySet) bool {
+	return sets.String(s).Equal(sets.String(other))
+}
+
+// String returns the tag value recording s.
+func (s KeySet) String() string {
+	return strings.Join(sets.String(s).List(), " ")
+}
+
+// digestAlphabet is the base64 alphabet, whose characters are all allowed in tag values.
+const digestAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+func keyDigest(key string) string {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	sum := h.Sum32()
+	digest := make([]byte, 5)
+	for i := range digest {
+		digest[i] = digestAlphabet[(sum>>uint(6*i))&63]
+	}
+	return string(digest)
+}
+
+// DescribeELBKeySet returns the KeySet recorded in tag key of ELB resource denoted by arn.
+func DescribeELBKeySet(ctx context.Context, cloud aws.CloudAPI, arn string, key string) (KeySet, error) {
+	curTags, err := DescribeELB(ctx, cloud, arn)
+	if err != nil {
+		return nil, err
+	}
+	return ParseKeySet(curTags[key]), nil
+}
+
+// RecordELBKeySet records keySet in tag key of ELB resource denoted by arn.
+func RecordELBKeySet(ctx context.Context, cloud aws.CloudAPI, arn string, key string, keySet KeySet) error {
+	_, err := cloud.AddELBV2TagsWithContext(ctx, &elbv2.AddTagsInput{
+		ResourceArns: []*string{aws.String(arn)},
+		Tags:         ConvertToELBV2(map[string]string{key: keySet.String()}),
+	})
+	return err
+}
@@ -37,3 +37,17 @@ func (_m *MockController) ReconcileELB(ctx context.Context, arn string, desiredT
 
 	return r0
 }
+
+// ReconcileELBWithCurTags provides a mock function with given fields: ctx, arn, desiredTags, curTags
+func (_m *MockController) ReconcileELBWithCurTags(ctx context.Context, arn string, desiredTags map[string]string, curTags map[string]string) error {
+	ret := _m.Called(ctx, arn, desiredTags, curTags)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string, map[string]string) error); ok {
+		r0 = rf(ctx, arn, desiredTags, curTags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
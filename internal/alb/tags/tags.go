@@ -2,6 +2,8 @@ package tags
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
 	api "k8s.io/api/core/v1"
@@ -13,11 +15,23 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 )
 
+// ownedKeyPrefixes are the prefixes of tag keys owned by the controller.
+// Tags with other keys may have been added by other systems, e.g. cost allocation or backup tooling, so they're only removed
+// if the controller applied them, as recorded by the ManagedTagsKey tag.
+var ownedKeyPrefixes = []string{"kubernetes.io/", "alb.ingress.kubernetes.io/"}
+
+// ManagedTagsKey is the tag recording the keys without owned prefixes of the tags applied by the controller, e.g. from the tags annotation,
+// so they're removed once no longer desired while tags added by other systems are left alone.
+const ManagedTagsKey = "alb.ingress.kubernetes.io/managed-tags"
+
 // Controller manages tags on a resource
 type Controller interface {
 	// ReconcileELB ensures the tag for ELB resources denoted by arn have specified tags.
 	ReconcileELB(ctx context.Context, arn string, desiredTags map[string]string) error
 
+	// ReconcileELBWithCurTags ensures the tag for ELB resources denoted by arn have specified tags by reconcile from curTags.
+	ReconcileELBWithCurTags(ctx context.Context, arn string, desiredTags map[string]string, curTags map[string]string) error
+
 	// ReconcileEC2WithCurTags ensures the tag for EC2 resources denoted by resourceID have specified tags by reconcile from curTags.
 	ReconcileEC2WithCurTags(ctx context.Context, resourceID string, desiredTags map[string]string, curTags map[string]string) error
 }
@@ -38,7 +52,14 @@ func (c *controller) ReconcileELB(ctx context.Context, arn string, desiredTags m
 	if err != nil {
 		return err
 	}
-	modify, remove := changeSets(curTags, desiredTags)
+	return c.ReconcileELBWithCurTags(ctx, arn, desiredTags, curTags)
+}
+
+func (c *controller) ReconcileELBWithCurTags(ctx context.Context, arn string, desiredTags map[string]string, curTags map[string]string) error {
+	modify, remove, err := changeSets(curTags, desiredTags)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile tags of %v due to %v", arn, err)
+	}
 	if len(modify) > 0 {
 		albctx.GetLogger(ctx).Infof("modifying tags %v on %v", log.Prettify(modify), arn)
 		if _, err := c.cloud.AddELBV2TagsWithContext(ctx, &elbv2.AddTagsInput{
@@ -64,7 +85,10 @@ func (c *controller) ReconcileELB(ctx context.Context, arn string, desiredTags m
 }
 
 func (c *controller) ReconcileEC2WithCurTags(ctx context.Context, resourceID string, desiredTags map[string]string, curTags map[string]string) error {
-	modify, remove := changeSets(curTags, desiredTags)
+	modify, remove, err := changeSets(curTags, desiredTags)
+	if err != nil {
+		return fmt.Errorf("failed to reconcile tags of %v due to %v", resourceID, err)
+	}
 	if len(modify) > 0 {
 		albctx.GetLogger(ctx).Infof("modifying tags %v on %v", log.Prettify(modify), resourceID)
 		if _, err := c.cloud.CreateEC2TagsWithContext(ctx, &ec2.CreateTagsInput{
@@ -89,7 +113,12 @@ func (c *controller) ReconcileEC2WithCurTags(ctx context.Context, resourceID str
 }
 
 func (c *controller) getCurrentELBTags(ctx context.Context, arn string) (map[string]string, error) {
-	resp, err := c.cloud.DescribeELBV2TagsWithContext(ctx, &elbv2.DescribeTagsInput{
+	return DescribeELB(ctx, c.cloud, arn)
+}
+
+// DescribeELB returns the tags of ELB resource denoted by arn.
+func DescribeELB(ctx context.Context, cloud aws.CloudAPI, arn string) (map[string]string, error) {
+	resp, err := cloud.DescribeELBV2TagsWithContext(ctx, &elbv2.DescribeTagsInput{
 		ResourceArns: []*string{aws.String(arn)},
 	})
 	if err != nil {
@@ -104,8 +133,24 @@ func (c *controller) getCurrentELBTags(ctx context.Context, arn string) (map[str
 	return tags, nil
 }
 
+// Contains returns whether tags contains all tags of subset with the same values.
+func Contains(tags map[string]string, subset map[string]string) bool {
+	for k, v := range subset {
+		if value, ok := tags[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
 // changeSets compares source with target, return the add/change and remove tags to reach target from source.
-func changeSets(source, target map[string]string) (map[string]string, map[string]string) {
+// Only tags with keys owned by the controller or recorded by the ManagedTagsKey tag of source are removed,
+// tags from other systems are kept unless target overwrites them. The AppliedAttributesKey tag is left to the attributes controllers.
+func changeSets(source, target map[string]string) (map[string]string, map[string]string, error) {
+	target, err := withManagedTags(target)
+	if err != nil {
+		return nil, nil, err
+	}
 	modify := make(map[string]string)
 	remove := make(map[string]string)
 
@@ -115,22 +160,58 @@ func changeSets(source, target map[string]string) (map[string]string, map[string
 			modify[key] = targetVal
 		}
 	}
+	managed := ParseKeySet(source[ManagedTagsKey])
 	for key, sourceVal := range source {
-		if _, ok := target[key]; !ok {
+		if _, ok := target[key]; ok || key == AppliedAttributesKey {
+			continue
+		}
+		if IsOwnedKey(key) || managed.Has(key) {
 			remove[key] = sourceVal
 		}
 	}
 
-	return modify, remove
+	return modify, remove, nil
+}
+
+// withManagedTags returns target with the ManagedTagsKey tag recording its keys without owned prefixes, if there are any.
+func withManagedTags(target map[string]string) (map[string]string, error) {
+	var managedKeys []string
+	for key := range target {
+		if !IsOwnedKey(key) {
+			managedKeys = append(managedKeys, key)
+		}
+	}
+	if len(managedKeys) == 0 {
+		return target, nil
+	}
+	if len(managedKeys) > MaxKeySetSize {
+		return nil, fmt.Errorf("at most %v tags without the prefixes %v can be managed, got %v", MaxKeySetSize, ownedKeyPrefixes, len(managedKeys))
+	}
+	result := make(map[string]string, len(target)+1)
+	for key, value := range target {
+		result[key] = value
+	}
+	result[ManagedTagsKey] = NewKeySet(managedKeys...).String()
+	return result, nil
+}
+
+// IsOwnedKey returns whether tags with key are owned by the controller, i.e. key has one of the owned prefixes.
+func IsOwnedKey(key string) bool {
+	for _, prefix := range ownedKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
 }
 
 // ConvertToELBV2 will convert tags to ELBV2 Tags
 func ConvertToELBV2(tags map[string]string) []*elbv2.Tag {
 	output := make([]*elbv2.Tag, 0, len(tags))
-	for k, v := range tags {
+	for _, k := range sets.StringKeySet(tags).List() {
 		output = append(output, &elbv2.Tag{
 			Key:   aws.String(k),
-			Value: aws.String(v),
+			Value: aws.String(tags[k]),
 		})
 	}
 	return output
@@ -139,10 +220,10 @@ func ConvertToELBV2(tags map[string]string) []*elbv2.Tag {
 // ConvertToEC2 will convert tags to EC2 tags
 func ConvertToEC2(tags map[string]string) []*ec2.Tag {
 	output := make([]*ec2.Tag, 0, len(tags))
-	for k, v := range tags {
+	for _, k := range sets.StringKeySet(tags).List() {
 		output = append(output, &ec2.Tag{
 			Key:   aws.String(k),
-			Value: aws.String(v),
+			Value: aws.String(tags[k]),
 		})
 	}
 	return output
@@ -34,32 +34,99 @@ func Test_tagsChangeSet(t *testing.T) {
 			name:      "empty a, b adds a key to a",
 			a:         nil,
 			b:         map[string]string{"k": "v"},
-			changeSet: map[string]string{"k": "v"},
+			changeSet: map[string]string{"k": "v", ManagedTagsKey: NewKeySet("k").String()},
 			removeSet: emptyChangeSet,
 		},
 		{
 			name:      "a, b changes a key in a",
-			a:         map[string]string{"k": "v"},
+			a:         map[string]string{"k": "v", ManagedTagsKey: NewKeySet("k").String()},
 			b:         map[string]string{"k": "v2"},
 			changeSet: map[string]string{"k": "v2"},
 			removeSet: emptyChangeSet,
 		},
 		{
-			name:      "a, b removes a key in a",
+			name:      "a, b removes an owned key in a",
+			a:         map[string]string{"kubernetes.io/k": "v", "alb.ingress.kubernetes.io/k": "v"},
+			b:         nil,
+			changeSet: emptyChangeSet,
+			removeSet: map[string]string{"kubernetes.io/k": "v", "alb.ingress.kubernetes.io/k": "v"},
+		},
+		{
+			name:      "a, b keeps a foreign key in a",
+			a:         map[string]string{"k": "v", "kubernetes.io/k": "v"},
+			b:         map[string]string{"kubernetes.io/k": "v"},
+			changeSet: emptyChangeSet,
+			removeSet: emptyChangeSet,
+		},
+		{
+			name:      "a, b keeps the applied attributes key in a",
+			a:         map[string]string{AppliedAttributesKey: "v", "kubernetes.io/k": "v"},
+			b:         nil,
+			changeSet: emptyChangeSet,
+			removeSet: map[string]string{"kubernetes.io/k": "v"},
+		},
+		{
+			name:      "a, b overwrites a foreign key in a",
 			a:         map[string]string{"k": "v"},
+			b:         map[string]string{"k": "v2"},
+			changeSet: map[string]string{"k": "v2", ManagedTagsKey: NewKeySet("k").String()},
+			removeSet: emptyChangeSet,
+		},
+		{
+			name:      "a, b removes a key applied before in a",
+			a:         map[string]string{"k": "v", "k2": "v", "foreign": "v", ManagedTagsKey: NewKeySet("k", "k2").String()},
+			b:         map[string]string{"k": "v"},
+			changeSet: map[string]string{ManagedTagsKey: NewKeySet("k").String()},
+			removeSet: map[string]string{"k2": "v"},
+		},
+		{
+			name:      "a, b removes all keys applied before in a",
+			a:         map[string]string{"k": "v", "foreign": "v", ManagedTagsKey: NewKeySet("k").String()},
 			b:         nil,
 			changeSet: emptyChangeSet,
-			removeSet: map[string]string{"k": "v"},
+			removeSet: map[string]string{"k": "v", ManagedTagsKey: NewKeySet("k").String()},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			changeSet, removeSet := changeSets(tc.a, tc.b)
+			changeSet, removeSet, err := changeSets(tc.a, tc.b)
+			assert.NoError(t, err)
 			assert.Equal(t, tc.changeSet, changeSet, "add/modify list not as expected")
 			assert.Equal(t, tc.removeSet, removeSet, "remove list not as expected")
 		})
 	}
 }
 
+func Test_tagsChangeSet_tooManyManagedKeys(t *testing.T) {
+	target := make(map[string]string)
+	for i := 0; i <= MaxKeySetSize; i++ {
+		target[fmt.Sprintf("k%v", i)] = "v"
+	}
+	_, _, err := changeSets(nil, target)
+	assert.Error(t, err)
+}
+
+func Test_IsOwnedKey(t *testing.T) {
+	assert.True(t, IsOwnedKey("kubernetes.io/cluster/cluster"))
+	assert.True(t, IsOwnedKey("alb.ingress.kubernetes.io/tls-secret"))
+	assert.False(t, IsOwnedKey("CostCenter"))
+	assert.False(t, IsOwnedKey("aws:cloudformation:stack-name"))
+}
+
+func Test_KeySet(t *testing.T) {
+	keySet := NewKeySet("stickiness.enabled", "slow_start.duration_seconds")
+	assert.True(t, keySet.Has("stickiness.enabled"))
+	assert.False(t, keySet.Has("stickiness.type"))
+
+	parsed := ParseKeySet(keySet.String())
+	assert.True(t, parsed.Equal(keySet))
+	assert.True(t, parsed.Has("slow_start.duration_seconds"))
+	assert.True(t, ParseKeySet("").Equal(NewKeySet()))
+
+	union := keySet.Union(NewKeySet("stickiness.type"))
+	assert.True(t, union.Has("stickiness.type"))
+	assert.True(t, union.Has("stickiness.enabled"))
+}
+
 func Test_ConvertToELBV2(t *testing.T) {
 	source := map[string]string{"key": "val"}
 	expected := []*elbv2.Tag{
@@ -68,6 +135,14 @@ func Test_ConvertToELBV2(t *testing.T) {
 	assert.Equal(t, ConvertToELBV2(source), expected)
 }
 
+func Test_Contains(t *testing.T) {
+	tags := map[string]string{"key1": "val1", "key2": "val2"}
+	assert.True(t, Contains(tags, nil))
+	assert.True(t, Contains(tags, map[string]string{"key1": "val1"}))
+	assert.False(t, Contains(tags, map[string]string{"key1": "val2"}))
+	assert.False(t, Contains(tags, map[string]string{"key3": "val3"}))
+}
+
 func Test_ConvertToEC2(t *testing.T) {
 	source := map[string]string{"key": "val"}
 	expected := []*ec2.Tag{
@@ -122,6 +197,7 @@ func Test_ReconcileELB(t *testing.T) {
 				Input: &elbv2.AddTagsInput{
 					ResourceArns: []*string{aws.String(arn)},
 					Tags: []*elbv2.Tag{
+						elbv2Tag(ManagedTagsKey, NewKeySet("k").String()),
 						elbv2Tag("k", "v"),
 					},
 				},
@@ -137,6 +213,7 @@ func Test_ReconcileELB(t *testing.T) {
 							ResourceArn: aws.String(arn),
 							Tags: []*elbv2.Tag{
 								elbv2Tag("k", "v"),
+								elbv2Tag(ManagedTagsKey, NewKeySet("k").String()),
 							},
 						},
 					},
@@ -160,7 +237,31 @@ func Test_ReconcileELB(t *testing.T) {
 						{
 							ResourceArn: aws.String(arn),
 							Tags: []*elbv2.Tag{
+								elbv2Tag("kubernetes.io/k", "v"),
+							},
+						},
+					},
+				},
+			},
+			RemoveELBV2TagsWithContextCall: &RemoveELBV2TagsWithContextCall{
+				Input: &elbv2.RemoveTagsInput{
+					ResourceArns: []*string{aws.String(arn)},
+					TagKeys:      []*string{aws.String("kubernetes.io/k")},
+				},
+			},
+		},
+		{
+			Name:        "remove a tag applied before",
+			DesiredTags: map[string]string{"kubernetes.io/k": "v"},
+			DescribeELBV2TagsWithContextCall: &DescribeELBV2TagsWithContextCall{
+				Output: &elbv2.DescribeTagsOutput{
+					TagDescriptions: []*elbv2.TagDescription{
+						{
+							ResourceArn: aws.String(arn),
+							Tags: []*elbv2.Tag{
+								elbv2Tag("kubernetes.io/k", "v"),
 								elbv2Tag("k", "v"),
+								elbv2Tag(ManagedTagsKey, NewKeySet("k").String()),
 							},
 						},
 					},
@@ -169,7 +270,23 @@ func Test_ReconcileELB(t *testing.T) {
 			RemoveELBV2TagsWithContextCall: &RemoveELBV2TagsWithContextCall{
 				Input: &elbv2.RemoveTagsInput{
 					ResourceArns: []*string{aws.String(arn)},
-					TagKeys:      []*string{aws.String("k")},
+					TagKeys:      []*string{aws.String(ManagedTagsKey), aws.String("k")},
+				},
+			},
+		},
+		{
+			Name:        "keep a foreign tag",
+			DesiredTags: nil,
+			DescribeELBV2TagsWithContextCall: &DescribeELBV2TagsWithContextCall{
+				Output: &elbv2.DescribeTagsOutput{
+					TagDescriptions: []*elbv2.TagDescription{
+						{
+							ResourceArn: aws.String(arn),
+							Tags: []*elbv2.Tag{
+								elbv2Tag("k", "v"),
+							},
+						},
+					},
 				},
 			},
 		},
@@ -191,6 +308,7 @@ func Test_ReconcileELB(t *testing.T) {
 				Input: &elbv2.AddTagsInput{
 					ResourceArns: []*string{aws.String(arn)},
 					Tags: []*elbv2.Tag{
+						elbv2Tag(ManagedTagsKey, NewKeySet("k").String()),
 						elbv2Tag("k", "v"),
 					},
 				},
@@ -207,7 +325,7 @@ func Test_ReconcileELB(t *testing.T) {
 						{
 							ResourceArn: aws.String(arn),
 							Tags: []*elbv2.Tag{
-								elbv2Tag("k", "v"),
+								elbv2Tag("kubernetes.io/k", "v"),
 							},
 						},
 					},
@@ -216,7 +334,7 @@ func Test_ReconcileELB(t *testing.T) {
 			RemoveELBV2TagsWithContextCall: &RemoveELBV2TagsWithContextCall{
 				Input: &elbv2.RemoveTagsInput{
 					ResourceArns: []*string{aws.String(arn)},
-					TagKeys:      []*string{aws.String("k")},
+					TagKeys:      []*string{aws.String("kubernetes.io/k")},
 				},
 				Err: fmt.Errorf("nope"),
 			},
@@ -290,6 +408,7 @@ func Test_ReconcileEC2WithCurTags(t *testing.T) {
 				Input: &ec2.CreateTagsInput{
 					Resources: []*string{aws.String(resourceID)},
 					Tags: []*ec2.Tag{
+						ec2Tag(ManagedTagsKey, NewKeySet("k").String()),
 						ec2Tag("k", "v"),
 					},
 				},
@@ -298,7 +417,7 @@ func Test_ReconcileEC2WithCurTags(t *testing.T) {
 		{
 			Name:        "modify an tag",
 			DesiredTags: map[string]string{"k": "new"},
-			CurrentTags: map[string]string{"k": "v"},
+			CurrentTags: map[string]string{"k": "v", ManagedTagsKey: NewKeySet("k").String()},
 			CreateEC2TagsWithContextCall: &CreateEC2TagsWithContextCall{
 				Input: &ec2.CreateTagsInput{
 					Resources: []*string{aws.String(resourceID)},
@@ -311,20 +430,25 @@ func Test_ReconcileEC2WithCurTags(t *testing.T) {
 		{
 			Name:        "remove an tag",
 			DesiredTags: nil,
-			CurrentTags: map[string]string{"k": "v"},
+			CurrentTags: map[string]string{"kubernetes.io/k": "v"},
 			DeleteEC2TagsWithContextCall: &DeleteEC2TagsWithContextCall{
 				Input: &ec2.DeleteTagsInput{
 					Resources: []*string{aws.String(resourceID)},
 					Tags: []*ec2.Tag{
-						ec2Tag("k", "v"),
+						ec2Tag("kubernetes.io/k", "v"),
 					},
 				},
 			},
 		},
+		{
+			Name:        "keep a foreign tag",
+			DesiredTags: nil,
+			CurrentTags: map[string]string{"k": "v"},
+		},
 		{
 			Name:        "error when modify an tag",
 			DesiredTags: map[string]string{"k": "new"},
-			CurrentTags: map[string]string{"k": "v"},
+			CurrentTags: map[string]string{"k": "v", ManagedTagsKey: NewKeySet("k").String()},
 			CreateEC2TagsWithContextCall: &CreateEC2TagsWithContextCall{
 				Input: &ec2.CreateTagsInput{
 					Resources: []*string{aws.String(resourceID)},
@@ -339,12 +463,12 @@ func Test_ReconcileEC2WithCurTags(t *testing.T) {
 		{
 			Name:        "error when remove an tag",
 			DesiredTags: nil,
-			CurrentTags: map[string]string{"k": "v"},
+			CurrentTags: map[string]string{"kubernetes.io/k": "v"},
 			DeleteEC2TagsWithContextCall: &DeleteEC2TagsWithContextCall{
 				Input: &ec2.DeleteTagsInput{
 					Resources: []*string{aws.String(resourceID)},
 					Tags: []*ec2.Tag{
-						ec2Tag("k", "v"),
+						ec2Tag("kubernetes.io/k", "v"),
 					},
 				},
 				Err: errors.New("DeleteEC2TagsWithContextCall"),
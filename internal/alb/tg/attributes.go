@@ -6,24 +6,59 @@ import (
 	"strconv"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 const (
-	DeregistrationDelayTimeoutSecondsKey = "deregistration_delay.timeout_seconds"
-	SlowStartDurationSecondsKey          = "slow_start.duration_seconds"
-	StickinessEnabledKey                 = "stickiness.enabled"
-	StickinessTypeKey                    = "stickiness.type"
-	StickinessLbCookieDurationSecondsKey = "stickiness.lb_cookie.duration_seconds"
-
-	DeregistrationDelayTimeoutSeconds = 300
-	SlowStartDurationSeconds          = 0
-	StickinessEnabled                 = false
-	StickinessType                    = "lb_cookie"
-	StickinessLbCookieDurationSeconds = 86400
+	DeregistrationDelayTimeoutSecondsKey       = "deregistration_delay.timeout_seconds"
+	SlowStartDurationSecondsKey                = "slow_start.duration_seconds"
+	StickinessEnabledKey                       = "stickiness.enabled"
+	StickinessTypeKey                          = "stickiness.type"
+	StickinessLbCookieDurationSecondsKey       = "stickiness.lb_cookie.duration_seconds"
+	StickinessAppCookieCookieNameKey           = "stickiness.app_cookie.cookie_name"
+	StickinessAppCookieDurationSecondsKey      = "stickiness.app_cookie.duration_seconds"
+	LoadBalancingCrossZoneEnabledKey           = "load_balancing.cross_zone.enabled"
+	LoadBalancingAlgorithmTypeKey              = "load_balancing.algorithm.type"
+	LoadBalancingAlgorithmAnomalyMitigationKey = "load_balancing.algorithm.anomaly_mitigation"
+
+	StickinessTypeLbCookie  = "lb_cookie"
+	StickinessTypeAppCookie = "app_cookie"
+
+	LoadBalancingCrossZoneUseLoadBalancerConfiguration = "use_load_balancer_configuration"
+
+	LoadBalancingAlgorithmRoundRobin               = "round_robin"
+	LoadBalancingAlgorithmLeastOutstandingRequests = "least_outstanding_requests"
+	LoadBalancingAlgorithmWeightedRandom           = "weighted_random"
+
+	DeregistrationDelayTimeoutSeconds       = 300
+	SlowStartDurationSeconds                = 0
+	StickinessEnabled                       = false
+	StickinessType                          = StickinessTypeLbCookie
+	StickinessLbCookieDurationSeconds       = 86400
+	StickinessAppCookieCookieName           = ""
+	StickinessAppCookieDurationSeconds      = 86400
+	LoadBalancingCrossZoneEnabled           = LoadBalancingCrossZoneUseLoadBalancerConfiguration
+	LoadBalancingAlgorithmType              = LoadBalancingAlgorithmRoundRobin
+	LoadBalancingAlgorithmAnomalyMitigation = "off"
+)
+
+// attributeKeys contains the keys of the attributes managed by the controller.
+var attributeKeys = sets.NewString(
+	DeregistrationDelayTimeoutSecondsKey,
+	SlowStartDurationSecondsKey,
+	StickinessEnabledKey,
+	StickinessTypeKey,
+	StickinessLbCookieDurationSecondsKey,
+	StickinessAppCookieCookieNameKey,
+	StickinessAppCookieDurationSecondsKey,
+	LoadBalancingCrossZoneEnabledKey,
+	LoadBalancingAlgorithmTypeKey,
+	LoadBalancingAlgorithmAnomalyMitigationKey,
 )
 
 // Attributes represents the desired state of attributes for a target group.
@@ -45,8 +80,8 @@ type Attributes struct {
 	// The value is true or false. The default is false.
 	StickinessEnabled bool
 
-	// StickinessType: stickiness.type - The type of sticky sessions. The possible value is
-	// lb_cookie.
+	// StickinessType: stickiness.type - The type of sticky sessions. The possible values are
+	// lb_cookie and app_cookie.
 	StickinessType string
 
 	// StickinessLbCookieDurationSeconds: stickiness.lb_cookie.duration_seconds - The time period, in seconds,
@@ -55,15 +90,46 @@ type Attributes struct {
 	// considered stale. The range is 1 second to 1 week (604800 seconds). The
 	// default value is 1 day (86400 seconds).
 	StickinessLbCookieDurationSeconds int64
+
+	// StickinessAppCookieCookieName: stickiness.app_cookie.cookie_name - The name of the application-based
+	// cookie. Names that start with the following prefixes are not allowed: AWSALB, AWSALBAPP, and AWSALBTG;
+	// they're reserved for use by the load balancer.
+	StickinessAppCookieCookieName string
+
+	// StickinessAppCookieDurationSeconds: stickiness.app_cookie.duration_seconds - The time period, in seconds,
+	// during which requests from a client should be routed to the same target. After this time period expires,
+	// the application-based cookie is considered stale. The range is 1 second to 1 week (604800 seconds). The
+	// default value is 1 day (86400 seconds).
+	StickinessAppCookieDurationSeconds int64
+
+	// LoadBalancingCrossZoneEnabled: load_balancing.cross_zone.enabled - Indicates whether cross zone load
+	// balancing is enabled for the target group. The value is true, false or use_load_balancer_configuration.
+	// The default is use_load_balancer_configuration.
+	LoadBalancingCrossZoneEnabled string
+
+	// LoadBalancingAlgorithmType: load_balancing.algorithm.type - The load balancing algorithm determines how
+	// the load balancer selects targets when routing requests. The value is round_robin, least_outstanding_requests
+	// or weighted_random. The default is round_robin.
+	LoadBalancingAlgorithmType string
+
+	// LoadBalancingAlgorithmAnomalyMitigation: load_balancing.algorithm.anomaly_mitigation - Indicates whether
+	// anomaly mitigation is enabled, only available with the weighted_random algorithm. The value is on or off.
+	// The default is off.
+	LoadBalancingAlgorithmAnomalyMitigation string
 }
 
 func NewAttributes(attrs []*elbv2.TargetGroupAttribute) (a *Attributes, err error) {
 	a = &Attributes{
-		DeregistrationDelayTimeoutSeconds: DeregistrationDelayTimeoutSeconds,
-		SlowStartDurationSeconds:          SlowStartDurationSeconds,
-		StickinessEnabled:                 StickinessEnabled,
-		StickinessType:                    StickinessType,
-		StickinessLbCookieDurationSeconds: StickinessLbCookieDurationSeconds,
+		DeregistrationDelayTimeoutSeconds:       DeregistrationDelayTimeoutSeconds,
+		SlowStartDurationSeconds:                SlowStartDurationSeconds,
+		StickinessEnabled:                       StickinessEnabled,
+		StickinessType:                          StickinessType,
+		StickinessLbCookieDurationSeconds:       StickinessLbCookieDurationSeconds,
+		StickinessAppCookieCookieName:           StickinessAppCookieCookieName,
+		StickinessAppCookieDurationSeconds:      StickinessAppCookieDurationSeconds,
+		LoadBalancingCrossZoneEnabled:           LoadBalancingCrossZoneEnabled,
+		LoadBalancingAlgorithmType:              LoadBalancingAlgorithmType,
+		LoadBalancingAlgorithmAnomalyMitigation: LoadBalancingAlgorithmAnomalyMitigation,
 	}
 	var e error
 	for _, attr := range attrs {
@@ -92,7 +158,7 @@ func NewAttributes(attrs []*elbv2.TargetGroupAttribute) (a *Attributes, err erro
 			}
 		case StickinessTypeKey:
 			a.StickinessType = attrValue
-			if attrValue != "lb_cookie" {
+			if attrValue != StickinessTypeLbCookie && attrValue != StickinessTypeAppCookie {
 				return a, fmt.Errorf("invalid target group attribute value %s=%s", attrKey, attrValue)
 			}
 		case StickinessLbCookieDurationSecondsKey:
@@ -103,10 +169,42 @@ func NewAttributes(attrs []*elbv2.TargetGroupAttribute) (a *Attributes, err erro
 			if a.StickinessLbCookieDurationSeconds < 1 || a.StickinessLbCookieDurationSeconds > 604800 {
 				return a, fmt.Errorf("%s must be within 1-604800 seconds, not %v", attrKey, attrValue)
 			}
+		case StickinessAppCookieCookieNameKey:
+			a.StickinessAppCookieCookieName = attrValue
+		case StickinessAppCookieDurationSecondsKey:
+			a.StickinessAppCookieDurationSeconds, err = strconv.ParseInt(attrValue, 10, 64)
+			if err != nil {
+				return a, fmt.Errorf("invalid target group attribute value %s=%s", attrKey, attrValue)
+			}
+			if a.StickinessAppCookieDurationSeconds < 1 || a.StickinessAppCookieDurationSeconds > 604800 {
+				return a, fmt.Errorf("%s must be within 1-604800 seconds, not %v", attrKey, attrValue)
+			}
+		case LoadBalancingCrossZoneEnabledKey:
+			a.LoadBalancingCrossZoneEnabled = attrValue
+			if attrValue != "true" && attrValue != "false" && attrValue != LoadBalancingCrossZoneUseLoadBalancerConfiguration {
+				return a, fmt.Errorf("invalid target group attribute value %s=%s", attrKey, attrValue)
+			}
+		case LoadBalancingAlgorithmTypeKey:
+			a.LoadBalancingAlgorithmType = attrValue
+			if attrValue != LoadBalancingAlgorithmRoundRobin && attrValue != LoadBalancingAlgorithmLeastOutstandingRequests && attrValue != LoadBalancingAlgorithmWeightedRandom {
+				return a, fmt.Errorf("invalid target group attribute value %s=%s", attrKey, attrValue)
+			}
+		case LoadBalancingAlgorithmAnomalyMitigationKey:
+			a.LoadBalancingAlgorithmAnomalyMitigation = attrValue
+			if attrValue != "on" && attrValue != "off" {
+				return a, fmt.Errorf("invalid target group attribute value %s=%s", attrKey, attrValue)
+			}
 		default:
 			e = NewInvalidAttribute(attrKey)
 		}
 	}
+	if a.LoadBalancingAlgorithmType != LoadBalancingAlgorithmWeightedRandom {
+		if a.LoadBalancingAlgorithmAnomalyMitigation == "on" {
+			return a, fmt.Errorf("%s requires %s=%s", LoadBalancingAlgorithmAnomalyMitigationKey, LoadBalancingAlgorithmTypeKey, LoadBalancingAlgorithmWeightedRandom)
+		}
+	} else if a.SlowStartDurationSeconds != 0 {
+		return a, fmt.Errorf("%s isn't supported with %s=%s", SlowStartDurationSecondsKey, LoadBalancingAlgorithmTypeKey, LoadBalancingAlgorithmWeightedRandom)
+	}
 	return a, e
 }
 
@@ -116,15 +214,18 @@ type AttributesController interface {
 	Reconcile(ctx context.Context, tgArn string, attributes []*elbv2.TargetGroupAttribute) error
 }
 
-// NewAttributesController constructs a new attributes controller
-func NewAttributesController(cloud aws.CloudAPI) AttributesController {
+// NewAttributesController constructs a new attributes controller.
+// Unless strict, attributes not specified are left unmanaged instead of being reset to their defaults.
+func NewAttributesController(cloud aws.CloudAPI, strict bool) AttributesController {
 	return &attributesController{
-		cloud: cloud,
+		cloud:  cloud,
+		strict: strict,
 	}
 }
 
 type attributesController struct {
-	cloud aws.CloudAPI
+	cloud  aws.CloudAPI
+	strict bool
 }
 
 func (c *attributesController) Reconcile(ctx context.Context, tgArn string, attributes []*elbv2.TargetGroupAttribute) error {
@@ -142,22 +243,75 @@ func (c *attributesController) Reconcile(ctx context.Context, tgArn string, attr
 	if err != nil && !IsInvalidAttribute(err) {
 		return fmt.Errorf("failed parsing attributes: %v", err)
 	}
+	// unless strict, the keys of attributes applied before are recorded in a tag, so those removed since are reset to their defaults.
+	var applied, recorded tags.KeySet
+	if !c.strict {
+		if applied, err = tags.DescribeELBKeySet(ctx, c.cloud, tgArn, tags.AppliedAttributesKey); err != nil {
+			return fmt.Errorf("failed to retrieve tags from TargetGroup in AWS: %v", err)
+		}
+		if desired, err = NewAttributes(mergeAttributes(raw.Attributes, attributes, applied)); err != nil {
+			return fmt.Errorf("invalid attributes due to %v", err)
+		}
+		// new keys are recorded before modifying attributes, so none goes unrecorded if reconciling stops halfway.
+		recorded = attributesKeySet(attributes)
+		if union := applied.Union(recorded); !union.Equal(applied) {
+			if err := tags.RecordELBKeySet(ctx, c.cloud, tgArn, tags.AppliedAttributesKey, union); err != nil {
+				return fmt.Errorf("failed to record applied attributes of TargetGroup due to %v", err)
+			}
+			applied = union
+		}
+	}
 
 	changeSet := attributesChangeSet(current, desired)
 	if len(changeSet) > 0 {
 		albctx.GetLogger(ctx).Infof("Modifying TargetGroup %v attributes to %v.", tgArn, log.Prettify(changeSet))
-		_, err = c.cloud.ModifyTargetGroupAttributesWithContext(ctx, &elbv2.ModifyTargetGroupAttributesInput{
+		in := &elbv2.ModifyTargetGroupAttributesInput{
 			TargetGroupArn: aws.String(tgArn),
 			Attributes:     changeSet,
+		}
+		albctx.RecordAuditBefore(ctx, in, &elbv2.ModifyTargetGroupAttributesInput{
+			TargetGroupArn: aws.String(tgArn),
+			Attributes:     raw.Attributes,
 		})
+		_, err = c.cloud.ModifyTargetGroupAttributesWithContext(ctx, in)
 		if err != nil {
 			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "%s attributes modification failed: %s", tgArn, err.Error())
 			return err
 		}
 	}
+	if !c.strict && !recorded.Equal(applied) {
+		if err := tags.RecordELBKeySet(ctx, c.cloud, tgArn, tags.AppliedAttributesKey, recorded); err != nil {
+			return fmt.Errorf("failed to record applied attributes of TargetGroup due to %v", err)
+		}
+	}
 	return nil
 }
 
+// mergeAttributes returns attributes with the attributes of current managed by the controller that aren't in attributes,
+// so they keep their current values. Attributes in applied were set by the controller before, they're left out to be reset to their defaults.
+func mergeAttributes(current []*elbv2.TargetGroupAttribute, attributes []*elbv2.TargetGroupAttribute, applied tags.KeySet) []*elbv2.TargetGroupAttribute {
+	keys := sets.NewString()
+	for _, attr := range attributes {
+		keys.Insert(aws.StringValue(attr.Key))
+	}
+	merged := append([]*elbv2.TargetGroupAttribute{}, attributes...)
+	for _, attr := range current {
+		if key := aws.StringValue(attr.Key); attributeKeys.Has(key) && !keys.Has(key) && !applied.Has(key) {
+			merged = append(merged, attr)
+		}
+	}
+	return merged
+}
+
+// attributesKeySet returns the KeySet recording the keys of attributes.
+func attributesKeySet(attributes []*elbv2.TargetGroupAttribute) tags.KeySet {
+	keys := make([]string, 0, len(attributes))
+	for _, attr := range attributes {
+		keys = append(keys, aws.StringValue(attr.Key))
+	}
+	return tags.NewKeySet(keys...)
+}
+
 // attributesChangeSet returns a list of elbv2.TargetGroupAttribute required to change a into b
 func attributesChangeSet(a, b *Attributes) (changeSet []*elbv2.TargetGroupAttribute) {
 	if a.DeregistrationDelayTimeoutSeconds != b.DeregistrationDelayTimeoutSeconds {
@@ -180,6 +334,32 @@ func attributesChangeSet(a, b *Attributes) (changeSet []*elbv2.TargetGroupAttrib
 		changeSet = append(changeSet, tgAttribute(StickinessLbCookieDurationSecondsKey, fmt.Sprintf("%v", b.StickinessLbCookieDurationSeconds)))
 	}
 
+	// app_cookie attributes are rejected by AWS unless the stickiness type is app_cookie.
+	if b.StickinessType == StickinessTypeAppCookie {
+		if a.StickinessAppCookieCookieName != b.StickinessAppCookieCookieName {
+			changeSet = append(changeSet, tgAttribute(StickinessAppCookieCookieNameKey, b.StickinessAppCookieCookieName))
+		}
+
+		if a.StickinessAppCookieDurationSeconds != b.StickinessAppCookieDurationSeconds {
+			changeSet = append(changeSet, tgAttribute(StickinessAppCookieDurationSecondsKey, fmt.Sprintf("%v", b.StickinessAppCookieDurationSeconds)))
+		}
+	}
+
+	if a.LoadBalancingCrossZoneEnabled != b.LoadBalancingCrossZoneEnabled {
+		changeSet = append(changeSet, tgAttribute(LoadBalancingCrossZoneEnabledKey, b.LoadBalancingCrossZoneEnabled))
+	}
+
+	if a.LoadBalancingAlgorithmType != b.LoadBalancingAlgorithmType {
+		changeSet = append(changeSet, tgAttribute(LoadBalancingAlgorithmTypeKey, b.LoadBalancingAlgorithmType))
+	}
+
+	// anomaly_mitigation is rejected by AWS unless the algorithm is weighted_random.
+	if b.LoadBalancingAlgorithmType == LoadBalancingAlgorithmWeightedRandom {
+		if a.LoadBalancingAlgorithmAnomalyMitigation != b.LoadBalancingAlgorithmAnomalyMitigation {
+			changeSet = append(changeSet, tgAttribute(LoadBalancingAlgorithmAnomalyMitigationKey, b.LoadBalancingAlgorithmAnomalyMitigation))
+		}
+	}
+
 	return
 }
 
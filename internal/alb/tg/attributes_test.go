@@ -8,6 +8,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
 	"github.com/stretchr/testify/assert"
 )
@@ -137,6 +138,87 @@ func Test_NewAttributes(t *testing.T) {
 			ok:         false,
 			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessLbCookieDurationSecondsKey, "error")},
 		},
+		{
+			name:       "StickinessTypeKey is app_cookie",
+			ok:         true,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessTypeKey, "app_cookie")},
+			output:     MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute(StickinessTypeKey, "app_cookie")}),
+		},
+
+		{
+			name:       "StickinessAppCookieCookieNameKey is set",
+			ok:         true,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieCookieNameKey, "SESSION")},
+			output:     MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieCookieNameKey, "SESSION")}),
+		},
+		{
+			name:       "StickinessAppCookieDurationSecondsKey is 45",
+			ok:         true,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieDurationSecondsKey, "45")},
+			output:     MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieDurationSecondsKey, "45")}),
+		},
+		{
+			name:       "StickinessAppCookieDurationSecondsKey is > 604800",
+			ok:         false,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieDurationSecondsKey, "604801")},
+		},
+		{
+			name:       "StickinessAppCookieDurationSecondsKey is not a number",
+			ok:         false,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieDurationSecondsKey, "error")},
+		},
+		{
+			name:       "LoadBalancingCrossZoneEnabledKey is false",
+			ok:         true,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(LoadBalancingCrossZoneEnabledKey, "false")},
+			output:     MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute(LoadBalancingCrossZoneEnabledKey, "false")}),
+		},
+		{
+			name:       "LoadBalancingCrossZoneEnabledKey is invalid",
+			ok:         false,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(LoadBalancingCrossZoneEnabledKey, "sometimes")},
+		},
+		{
+			name:       "LoadBalancingAlgorithmTypeKey is least_outstanding_requests",
+			ok:         true,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(LoadBalancingAlgorithmTypeKey, "least_outstanding_requests")},
+			output:     MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute(LoadBalancingAlgorithmTypeKey, "least_outstanding_requests")}),
+		},
+		{
+			name:       "LoadBalancingAlgorithmTypeKey is invalid",
+			ok:         false,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(LoadBalancingAlgorithmTypeKey, "random")},
+		},
+		{
+			name: "LoadBalancingAlgorithmAnomalyMitigationKey is on with weighted_random",
+			ok:   true,
+			attributes: []*elbv2.TargetGroupAttribute{
+				tgAttribute(LoadBalancingAlgorithmTypeKey, "weighted_random"),
+				tgAttribute(LoadBalancingAlgorithmAnomalyMitigationKey, "on"),
+			},
+			output: MustNewAttributes([]*elbv2.TargetGroupAttribute{
+				tgAttribute(LoadBalancingAlgorithmTypeKey, "weighted_random"),
+				tgAttribute(LoadBalancingAlgorithmAnomalyMitigationKey, "on"),
+			}),
+		},
+		{
+			name:       "LoadBalancingAlgorithmAnomalyMitigationKey is on without weighted_random",
+			ok:         false,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(LoadBalancingAlgorithmAnomalyMitigationKey, "on")},
+		},
+		{
+			name:       "LoadBalancingAlgorithmAnomalyMitigationKey is invalid",
+			ok:         false,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(LoadBalancingAlgorithmAnomalyMitigationKey, "maybe")},
+		},
+		{
+			name: "SlowStartDurationSecondsKey with weighted_random",
+			ok:   false,
+			attributes: []*elbv2.TargetGroupAttribute{
+				tgAttribute(LoadBalancingAlgorithmTypeKey, "weighted_random"),
+				tgAttribute(SlowStartDurationSecondsKey, "30"),
+			},
+		},
 
 		{
 			name:       "Invalid attribute",
@@ -290,6 +372,58 @@ func Test_attributesChangeSet(t *testing.T) {
 			b:         MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute(StickinessLbCookieDurationSecondsKey, "501")}),
 			changeSet: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessLbCookieDurationSecondsKey, "501")},
 		},
+		{
+			name: "StickinessAppCookie: b=lb_cookie",
+			a:    MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieCookieNameKey, "SESSION")}),
+			b:    MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieDurationSecondsKey, "500")}),
+		},
+		{
+			name: "StickinessAppCookie: a=lb_cookie b=app_cookie",
+			a:    MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute(StickinessTypeKey, "lb_cookie")}),
+			b: MustNewAttributes([]*elbv2.TargetGroupAttribute{
+				tgAttribute(StickinessTypeKey, "app_cookie"),
+				tgAttribute(StickinessAppCookieCookieNameKey, "SESSION"),
+				tgAttribute(StickinessAppCookieDurationSecondsKey, "500"),
+			}),
+			changeSet: []*elbv2.TargetGroupAttribute{
+				tgAttribute(StickinessTypeKey, "app_cookie"),
+				tgAttribute(StickinessAppCookieCookieNameKey, "SESSION"),
+				tgAttribute(StickinessAppCookieDurationSecondsKey, "500"),
+			},
+		},
+		{
+			name:      "LoadBalancingCrossZoneEnabled: a=default b=false",
+			a:         MustNewAttributes(nil),
+			b:         MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute(LoadBalancingCrossZoneEnabledKey, "false")}),
+			changeSet: []*elbv2.TargetGroupAttribute{tgAttribute(LoadBalancingCrossZoneEnabledKey, "false")},
+		},
+		{
+			name:      "LoadBalancingAlgorithmType: a=default b=least_outstanding_requests",
+			a:         MustNewAttributes(nil),
+			b:         MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute(LoadBalancingAlgorithmTypeKey, "least_outstanding_requests")}),
+			changeSet: []*elbv2.TargetGroupAttribute{tgAttribute(LoadBalancingAlgorithmTypeKey, "least_outstanding_requests")},
+		},
+		{
+			name: "LoadBalancingAlgorithmAnomalyMitigation: a=default b=weighted_random with anomaly mitigation",
+			a:    MustNewAttributes(nil),
+			b: MustNewAttributes([]*elbv2.TargetGroupAttribute{
+				tgAttribute(LoadBalancingAlgorithmTypeKey, "weighted_random"),
+				tgAttribute(LoadBalancingAlgorithmAnomalyMitigationKey, "on"),
+			}),
+			changeSet: []*elbv2.TargetGroupAttribute{
+				tgAttribute(LoadBalancingAlgorithmTypeKey, "weighted_random"),
+				tgAttribute(LoadBalancingAlgorithmAnomalyMitigationKey, "on"),
+			},
+		},
+		{
+			name: "LoadBalancingAlgorithmAnomalyMitigation: a=weighted_random with anomaly mitigation b=round_robin",
+			a: MustNewAttributes([]*elbv2.TargetGroupAttribute{
+				tgAttribute(LoadBalancingAlgorithmTypeKey, "weighted_random"),
+				tgAttribute(LoadBalancingAlgorithmAnomalyMitigationKey, "on"),
+			}),
+			b:         MustNewAttributes(nil),
+			changeSet: []*elbv2.TargetGroupAttribute{tgAttribute(LoadBalancingAlgorithmTypeKey, "round_robin")},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			changeSet := attributesChangeSet(tc.a, tc.b)
@@ -324,9 +458,12 @@ func defaultAttributes() []*elbv2.TargetGroupAttribute {
 func Test_AttributesReconcile(t *testing.T) {
 	for _, tc := range []struct {
 		Name                              string
+		Strict                            bool
 		Attributes                        []*elbv2.TargetGroupAttribute
+		AppliedAttributes                 []string
 		DescribeTargetGroupAttributesCall *DescribeTargetGroupAttributesCall
 		ModifyTargetGroupAttributesCall   *ModifyTargetGroupAttributesCall
+		RecordAppliedAttributesCalls      [][]string
 		ExpectedError                     error
 	}{
 		{
@@ -367,7 +504,70 @@ func Test_AttributesReconcile(t *testing.T) {
 				},
 				Err: nil,
 			},
-			ExpectedError: nil,
+			RecordAppliedAttributesCalls: [][]string{{SlowStartDurationSecondsKey}},
+			ExpectedError:                nil,
+		},
+		{
+			Name:       "attributes not specified are left unmanaged",
+			Attributes: []*elbv2.TargetGroupAttribute{tgAttribute(SlowStartDurationSecondsKey, "500")},
+			DescribeTargetGroupAttributesCall: &DescribeTargetGroupAttributesCall{
+				TgArn: aws.String("arn"),
+				Output: &elbv2.DescribeTargetGroupAttributesOutput{Attributes: append(defaultAttributes(),
+					tgAttribute(StickinessEnabledKey, "true"), tgAttribute(DeregistrationDelayTimeoutSecondsKey, "30"))},
+			},
+			ModifyTargetGroupAttributesCall: &ModifyTargetGroupAttributesCall{
+				Input: &elbv2.ModifyTargetGroupAttributesInput{
+					TargetGroupArn: aws.String("arn"),
+					Attributes: []*elbv2.TargetGroupAttribute{
+						tgAttribute("slow_start.duration_seconds", "500"),
+					},
+				},
+			},
+			RecordAppliedAttributesCalls: [][]string{{SlowStartDurationSecondsKey}},
+		},
+		{
+			Name:              "attributes applied before are reset to their defaults once not specified",
+			Attributes:        []*elbv2.TargetGroupAttribute{tgAttribute(DeregistrationDelayTimeoutSecondsKey, "30")},
+			AppliedAttributes: []string{SlowStartDurationSecondsKey, StickinessEnabledKey},
+			DescribeTargetGroupAttributesCall: &DescribeTargetGroupAttributesCall{
+				TgArn: aws.String("arn"),
+				Output: &elbv2.DescribeTargetGroupAttributesOutput{Attributes: append(defaultAttributes(),
+					tgAttribute(SlowStartDurationSecondsKey, "500"), tgAttribute(StickinessEnabledKey, "true"), tgAttribute(StickinessLbCookieDurationSecondsKey, "3600"))},
+			},
+			ModifyTargetGroupAttributesCall: &ModifyTargetGroupAttributesCall{
+				Input: &elbv2.ModifyTargetGroupAttributesInput{
+					TargetGroupArn: aws.String("arn"),
+					Attributes: []*elbv2.TargetGroupAttribute{
+						tgAttribute("deregistration_delay.timeout_seconds", "30"),
+						tgAttribute("slow_start.duration_seconds", "0"),
+						tgAttribute("stickiness.enabled", "false"),
+					},
+				},
+			},
+			RecordAppliedAttributesCalls: [][]string{
+				{DeregistrationDelayTimeoutSecondsKey, SlowStartDurationSecondsKey, StickinessEnabledKey},
+				{DeregistrationDelayTimeoutSecondsKey},
+			},
+		},
+		{
+			Name:       "attributes not specified are reset to their defaults when strict",
+			Strict:     true,
+			Attributes: []*elbv2.TargetGroupAttribute{tgAttribute(SlowStartDurationSecondsKey, "500")},
+			DescribeTargetGroupAttributesCall: &DescribeTargetGroupAttributesCall{
+				TgArn: aws.String("arn"),
+				Output: &elbv2.DescribeTargetGroupAttributesOutput{Attributes: append(defaultAttributes(),
+					tgAttribute(StickinessEnabledKey, "true"), tgAttribute(DeregistrationDelayTimeoutSecondsKey, "30"))},
+			},
+			ModifyTargetGroupAttributesCall: &ModifyTargetGroupAttributesCall{
+				Input: &elbv2.ModifyTargetGroupAttributesInput{
+					TargetGroupArn: aws.String("arn"),
+					Attributes: []*elbv2.TargetGroupAttribute{
+						tgAttribute("deregistration_delay.timeout_seconds", "300"),
+						tgAttribute("slow_start.duration_seconds", "500"),
+						tgAttribute("stickiness.enabled", "false"),
+					},
+				},
+			},
 		},
 		{
 			Name:       "start with default attribute set, API throws an error",
@@ -386,7 +586,8 @@ func Test_AttributesReconcile(t *testing.T) {
 				},
 				Err: fmt.Errorf("Something unexpected happened"),
 			},
-			ExpectedError: errors.New("Something unexpected happened"),
+			RecordAppliedAttributesCalls: [][]string{{SlowStartDurationSecondsKey}},
+			ExpectedError:                errors.New("Something unexpected happened"),
 		},
 	} {
 		t.Run(tc.Name, func(t *testing.T) {
@@ -396,11 +597,27 @@ func Test_AttributesReconcile(t *testing.T) {
 				cloud.On("DescribeTargetGroupAttributesWithContext", ctx, &elbv2.DescribeTargetGroupAttributesInput{TargetGroupArn: tc.DescribeTargetGroupAttributesCall.TgArn}).Return(tc.DescribeTargetGroupAttributesCall.Output, tc.DescribeTargetGroupAttributesCall.Err)
 			}
 
+			if !tc.Strict && tc.DescribeTargetGroupAttributesCall != nil && tc.DescribeTargetGroupAttributesCall.Err == nil {
+				tagDescription := &elbv2.TagDescription{ResourceArn: aws.String("arn")}
+				if tc.AppliedAttributes != nil {
+					tagDescription.Tags = []*elbv2.Tag{{Key: aws.String(tags.AppliedAttributesKey), Value: aws.String(tags.NewKeySet(tc.AppliedAttributes...).String())}}
+				}
+				cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String("arn")}}).Return(&elbv2.DescribeTagsOutput{
+					TagDescriptions: []*elbv2.TagDescription{tagDescription},
+				}, nil)
+			}
+			for _, keys := range tc.RecordAppliedAttributesCalls {
+				cloud.On("AddELBV2TagsWithContext", ctx, &elbv2.AddTagsInput{
+					ResourceArns: []*string{aws.String("arn")},
+					Tags:         []*elbv2.Tag{{Key: aws.String(tags.AppliedAttributesKey), Value: aws.String(tags.NewKeySet(keys...).String())}},
+				}).Return(&elbv2.AddTagsOutput{}, nil).Once()
+			}
+
 			if tc.ModifyTargetGroupAttributesCall != nil {
 				cloud.On("ModifyTargetGroupAttributesWithContext", ctx, tc.ModifyTargetGroupAttributesCall.Input).Return(tc.ModifyTargetGroupAttributesCall.Output, tc.ModifyTargetGroupAttributesCall.Err)
 			}
 
-			controller := NewAttributesController(cloud)
+			controller := NewAttributesController(cloud, tc.Strict)
 			err := controller.Reconcile(context.Background(), "arn", tc.Attributes)
 
 			if tc.ExpectedError != nil {
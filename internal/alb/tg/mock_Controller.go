@@ -11,6 +11,11 @@ type MockController struct {
 	mock.Mock
 }
 
+// Forget provides a mock function with given fields: tgArn
+func (_m *MockController) Forget(tgArn string) {
+	_m.Called(tgArn)
+}
+
 // Reconcile provides a mock function with given fields: ctx, ingress, backend
 func (_m *MockController) Reconcile(ctx context.Context, ingress *v1beta1.Ingress, backend v1beta1.IngressBackend) (TargetGroup, error) {
 	ret := _m.Called(ctx, ingress, backend)
@@ -31,3 +36,24 @@ func (_m *MockController) Reconcile(ctx context.Context, ingress *v1beta1.Ingres
 
 	return r0, r1
 }
+
+// ReconcileTargets provides a mock function with given fields: ctx, ingress, backend, existing
+func (_m *MockController) ReconcileTargets(ctx context.Context, ingress *v1beta1.Ingress, backend v1beta1.IngressBackend, existing TargetGroup) (TargetGroup, error) {
+	ret := _m.Called(ctx, ingress, backend, existing)
+
+	var r0 TargetGroup
+	if rf, ok := ret.Get(0).(func(context.Context, *v1beta1.Ingress, v1beta1.IngressBackend, TargetGroup) TargetGroup); ok {
+		r0 = rf(ctx, ingress, backend, existing)
+	} else {
+		r0 = ret.Get(0).(TargetGroup)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *v1beta1.Ingress, v1beta1.IngressBackend, TargetGroup) error); ok {
+		r1 = rf(ctx, ingress, backend, existing)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -10,6 +10,11 @@ type MockTargetsController struct {
 	mock.Mock
 }
 
+// Forget provides a mock function with given fields: tgArn
+func (_m *MockTargetsController) Forget(tgArn string) {
+	_m.Called(tgArn)
+}
+
 // Reconcile provides a mock function with given fields: _a0, _a1
 func (_m *MockTargetsController) Reconcile(_a0 context.Context, _a1 *Targets) error {
 	ret := _m.Called(_a0, _a1)
@@ -0,0 +1,48 @@
+package tg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// rolloutAttributes returns attributes with slow start enabled for slowStartSeconds if the service is rolling out,
+// so that new pods ramp up gradually during rollouts while routing stays evenly weighted afterwards.
+// Once the rollout finishes, slow start is disabled explicitly, as attributes not specified may be left unmanaged.
+func (controller *defaultController) rolloutAttributes(ctx context.Context, namespace string, serviceName string,
+	attributes []*elbv2.TargetGroupAttribute, slowStartSeconds *int64) []*elbv2.TargetGroupAttribute {
+	if slowStartSeconds == nil {
+		return attributes
+	}
+	duration := aws.Int64Value(slowStartSeconds)
+	if controller.serviceRollingOut(namespace, serviceName) {
+		albctx.GetLogger(ctx).Infof("service %v/%v is rolling out, enabling slow start for %v seconds", namespace, serviceName, duration)
+	} else {
+		duration = SlowStartDurationSeconds
+	}
+	result := make([]*elbv2.TargetGroupAttribute, 0, len(attributes)+1)
+	result = append(result, attributes...)
+	return append(result, tgAttribute(SlowStartDurationSecondsKey, fmt.Sprintf("%v", duration)))
+}
+
+// serviceRollingOut returns whether pods selected by service are managed by more than one active ReplicaSet,
+// which happens while a Deployment replaces its old ReplicaSet with a new one.
+func (controller *defaultController) serviceRollingOut(namespace string, serviceName string) bool {
+	service, err := controller.store.GetService(namespace + "/" + serviceName)
+	if err != nil || len(service.Spec.Selector) == 0 {
+		return false
+	}
+	selector := labels.SelectorFromSet(service.Spec.Selector)
+	activeReplicaSets := 0
+	for _, rs := range controller.store.ListReplicaSets(namespace) {
+		if rs.Status.Replicas == 0 || !selector.Matches(labels.Set(rs.Spec.Template.Labels)) {
+			continue
+		}
+		activeReplicaSets++
+	}
+	return activeReplicaSets > 1
+}
@@ -0,0 +1,81 @@
+package tg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newReplicaSet(name string, podLabels map[string]string, replicas int32) *appsv1.ReplicaSet {
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: name},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: podLabels}},
+		},
+		Status: appsv1.ReplicaSetStatus{Replicas: replicas},
+	}
+}
+
+func Test_rolloutAttributes(t *testing.T) {
+	attributes := []*elbv2.TargetGroupAttribute{tgAttribute(DeregistrationDelayTimeoutSecondsKey, "30")}
+	for _, tc := range []struct {
+		name             string
+		replicaSets      []*appsv1.ReplicaSet
+		slowStartSeconds *int64
+		expected         []*elbv2.TargetGroupAttribute
+	}{
+		{
+			name: "rollout slow start not configured",
+			replicaSets: []*appsv1.ReplicaSet{
+				newReplicaSet("app-1", map[string]string{"app": "app", "pod-template-hash": "1"}, 2),
+				newReplicaSet("app-2", map[string]string{"app": "app", "pod-template-hash": "2"}, 1),
+			},
+			expected: attributes,
+		},
+		{
+			name: "single active replicaSet",
+			replicaSets: []*appsv1.ReplicaSet{
+				newReplicaSet("app-1", map[string]string{"app": "app", "pod-template-hash": "1"}, 0),
+				newReplicaSet("app-2", map[string]string{"app": "app", "pod-template-hash": "2"}, 3),
+				newReplicaSet("other-1", map[string]string{"app": "other", "pod-template-hash": "1"}, 3),
+			},
+			slowStartSeconds: aws.Int64(60),
+			expected: []*elbv2.TargetGroupAttribute{
+				tgAttribute(DeregistrationDelayTimeoutSecondsKey, "30"),
+				tgAttribute(SlowStartDurationSecondsKey, "0"),
+			},
+		},
+		{
+			name: "multiple active replicaSets",
+			replicaSets: []*appsv1.ReplicaSet{
+				newReplicaSet("app-1", map[string]string{"app": "app", "pod-template-hash": "1"}, 2),
+				newReplicaSet("app-2", map[string]string{"app": "app", "pod-template-hash": "2"}, 1),
+			},
+			slowStartSeconds: aws.Int64(60),
+			expected: []*elbv2.TargetGroupAttribute{
+				tgAttribute(DeregistrationDelayTimeoutSecondsKey, "30"),
+				tgAttribute(SlowStartDurationSecondsKey, "60"),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := store.NewDummy()
+			s.GetServiceFunc = func(string) (*corev1.Service, error) {
+				return &corev1.Service{Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "app"}}}, nil
+			}
+			s.ListReplicaSetsFunc = func(string) []*appsv1.ReplicaSet { return tc.replicaSets }
+			controller := &defaultController{store: s}
+
+			actual := controller.rolloutAttributes(context.Background(), "namespace", "service", attributes, tc.slowStartSeconds)
+			assert.Equal(t, tc.expected, actual)
+			assert.Len(t, attributes, 1)
+		})
+	}
+}
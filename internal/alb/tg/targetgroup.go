@@ -5,20 +5,26 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/healthcheck"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/targetgroup"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // The port used when creating targetGroup serves as a default value for targets registered without port specified.
@@ -32,10 +38,16 @@ const targetGroupDefaultPort = 1
 type Controller interface {
 	// Reconcile ensures an targetGroup exists for specified backend of ingress.
 	Reconcile(ctx context.Context, ingress *extensions.Ingress, backend extensions.IngressBackend) (TargetGroup, error)
+
+	// ReconcileTargets reconciles the targets of existing targetGroup for specified backend of ingress, leaving its settings untouched.
+	ReconcileTargets(ctx context.Context, ingress *extensions.Ingress, backend extensions.IngressBackend, existing TargetGroup) (TargetGroup, error)
+
+	// Forget drops the state kept for targetGroup tgArn, once it's deleted.
+	Forget(tgArn string)
 }
 
 func NewController(cloud aws.CloudAPI, store store.Storer, nameTagGen NameTagGenerator, tagsController tags.Controller, endpointResolver backend.EndpointResolver) Controller {
-	attrsController := NewAttributesController(cloud)
+	attrsController := NewAttributesController(cloud, store.GetConfig().StrictAttributes)
 	targetsController := NewTargetsController(cloud, endpointResolver)
 	return &defaultController{
 		cloud:             cloud,
@@ -60,18 +72,16 @@ type defaultController struct {
 }
 
 func (controller *defaultController) Reconcile(ctx context.Context, ingress *extensions.Ingress, backend extensions.IngressBackend) (TargetGroup, error) {
-	ingressAnnos, err := controller.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
-	if err != nil {
-		return TargetGroup{}, fmt.Errorf("failed to load ingressAnnotation due to %v", err)
-	}
-	serviceKey := types.NamespacedName{Namespace: ingress.Namespace, Name: backend.ServiceName}
-	serviceAnnos, err := controller.store.GetServiceAnnotations(serviceKey.String(), ingressAnnos)
+	ingressAnnos, serviceAnnos, err := controller.loadAnnotations(ingress, backend)
 	if err != nil {
-		return TargetGroup{}, fmt.Errorf("failed to load serviceAnnotation due to %v", err)
+		return TargetGroup{}, err
 	}
 
 	protocol := aws.StringValue(serviceAnnos.TargetGroup.BackendProtocol)
 	targetType := aws.StringValue(serviceAnnos.TargetGroup.TargetType)
+	if cfg := controller.store.GetConfig(); cfg.RestrictTargetType && targetType != cfg.DefaultTargetType {
+		return TargetGroup{}, fmt.Errorf("target-type %v is not allowed, controller is restricted to target-type %v", targetType, cfg.DefaultTargetType)
+	}
 
 	healthCheckPort, err := controller.resolveServiceHealthCheckPort(ingress.Namespace, backend.ServiceName, intstr.Parse(*serviceAnnos.HealthCheck.Port), targetType)
 
@@ -80,41 +90,150 @@ func (controller *defaultController) Reconcile(ctx context.Context, ingress *ext
 	}
 
 	tgName := controller.nameTagGen.NameTG(ingress.Namespace, ingress.Name, backend.ServiceName, backend.ServicePort.String(), targetType, protocol)
-	tgInstance, err := controller.findExistingTGInstance(ctx, tgName)
+	tgInstance, curTags, err := controller.ensureTGInstance(ctx, ingress, tgName, serviceAnnos, healthCheckPort)
 	if err != nil {
-		return TargetGroup{}, fmt.Errorf("failed to find existing targetGroup due to %v", err)
-	}
-	if tgInstance == nil {
-		if tgInstance, err = controller.newTGInstance(ctx, tgName, serviceAnnos, healthCheckPort); err != nil {
-			return TargetGroup{}, fmt.Errorf("failed to create targetGroup due to %v", err)
-		}
-	} else {
-		if tgInstance, err = controller.reconcileTGInstance(ctx, tgInstance, serviceAnnos, healthCheckPort); err != nil {
-			return TargetGroup{}, fmt.Errorf("failed to modify targetGroup due to %v", err)
-		}
+		return TargetGroup{}, err
 	}
 
 	tgArn := aws.StringValue(tgInstance.TargetGroupArn)
 	tgTags := controller.buildTags(ingress, backend, ingressAnnos)
-	if err := controller.tagsController.ReconcileELB(ctx, tgArn, tgTags); err != nil {
+	importing := curTags != nil && importsSettings(curTags)
+	if importing {
+		tgTags[importedSettingsTagKey] = "true"
+	}
+	if curTags != nil {
+		err = controller.tagsController.ReconcileELBWithCurTags(ctx, tgArn, tgTags, curTags)
+	} else {
+		err = controller.tagsController.ReconcileELB(ctx, tgArn, tgTags)
+	}
+	if err != nil {
 		return TargetGroup{}, fmt.Errorf("failed to reconcile targetGroup tags due to %v", err)
 	}
-	if err := controller.attrsController.Reconcile(ctx, tgArn, serviceAnnos.TargetGroup.Attributes); err != nil {
+	tgAttributes := controller.rolloutAttributes(ctx, ingress.Namespace, backend.ServiceName, serviceAnnos.TargetGroup.Attributes, serviceAnnos.TargetGroup.RolloutSlowStartSeconds)
+	// unless attributes are strict, attributes not specified are left unmanaged and need no import.
+	if importing && controller.store.GetConfig().StrictAttributes {
+		if tgAttributes, err = controller.importAttributes(ctx, tgArn, tgAttributes); err != nil {
+			return TargetGroup{}, fmt.Errorf("failed to import targetGroup attributes due to %v", err)
+		}
+	}
+	if err := controller.attrsController.Reconcile(ctx, tgArn, tgAttributes); err != nil {
 		return TargetGroup{}, fmt.Errorf("failed to reconcile targetGroup attributes due to %v", err)
 	}
-	tgTargets := NewTargets(targetType, ingress, &backend)
-	tgTargets.TgArn = tgArn
-	if err = controller.targetsController.Reconcile(ctx, tgTargets); err != nil {
-		return TargetGroup{}, fmt.Errorf("failed to reconcile targetGroup targets due to %v", err)
+	targets, err := controller.reconcileTargets(ctx, ingress, backend, serviceAnnos, tgArn, targetType)
+	if err != nil {
+		return TargetGroup{}, err
+	}
+
+	return TargetGroup{
+		Arn:             tgArn,
+		TargetType:      targetType,
+		Targets:         targets,
+		HealthCheckPort: healthCheckPort,
+	}, nil
+}
+
+func (controller *defaultController) ReconcileTargets(ctx context.Context, ingress *extensions.Ingress, backend extensions.IngressBackend, existing TargetGroup) (TargetGroup, error) {
+	_, serviceAnnos, err := controller.loadAnnotations(ingress, backend)
+	if err != nil {
+		return TargetGroup{}, err
+	}
+	// targetType can't be modified, a changed one needs a new targetGroup.
+	if targetType := aws.StringValue(serviceAnnos.TargetGroup.TargetType); targetType != existing.TargetType {
+		return TargetGroup{}, fmt.Errorf("target-type of targetGroup %v changed from %v to %v", existing.Arn, existing.TargetType, targetType)
+	}
+	healthCheckPort, err := controller.resolveServiceHealthCheckPort(ingress.Namespace, backend.ServiceName, intstr.Parse(*serviceAnnos.HealthCheck.Port), existing.TargetType)
+	if err != nil {
+		return TargetGroup{}, fmt.Errorf("failed to resolve healthcheck port due to %v", err)
+	}
+	targets, err := controller.reconcileTargets(ctx, ingress, backend, serviceAnnos, existing.Arn, existing.TargetType)
+	if err != nil {
+		return TargetGroup{}, err
 	}
 
 	return TargetGroup{
-		Arn:        tgArn,
-		TargetType: targetType,
-		Targets:    tgTargets.Targets,
+		Arn:             existing.Arn,
+		TargetType:      existing.TargetType,
+		Targets:         targets,
+		HealthCheckPort: healthCheckPort,
 	}, nil
 }
 
+func (controller *defaultController) Forget(tgArn string) {
+	controller.targetsController.Forget(tgArn)
+}
+
+// loadAnnotations returns the annotations of ingress, and those of the service of backend with its health check overrides applied.
+func (controller *defaultController) loadAnnotations(ingress *extensions.Ingress, backend extensions.IngressBackend) (*annotations.Ingress, *annotations.Service, error) {
+	ingressAnnos, err := controller.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load ingressAnnotation due to %v", err)
+	}
+	serviceKey := types.NamespacedName{Namespace: ingress.Namespace, Name: backend.ServiceName}
+	serviceAnnos, err := controller.store.GetServiceAnnotations(serviceKey.String(), ingressAnnos)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load serviceAnnotation due to %v", err)
+	}
+	if serviceAnnos, err = applyHealthCheckOverride(serviceAnnos, backend); err != nil {
+		return nil, nil, err
+	}
+	return ingressAnnos, serviceAnnos, nil
+}
+
+// reconcileTargets registers the endpoints of backend as targets of targetGroup tgArn, and returns them.
+func (controller *defaultController) reconcileTargets(ctx context.Context, ingress *extensions.Ingress, backend extensions.IngressBackend,
+	serviceAnnos *annotations.Service, tgArn string, targetType string) ([]*elbv2.TargetDescription, error) {
+	tgTargets := NewTargets(targetType, ingress, &backend)
+	tgTargets.TgArn = tgArn
+	tgTargets.MinHealthyTargets = aws.Int64Value(serviceAnnos.TargetGroup.MinHealthyTargets)
+	tgTargets.GracePeriod = controller.store.GetConfig().TargetHealthGracePeriod
+	if err := controller.targetsController.Reconcile(ctx, tgTargets); err != nil {
+		return nil, fmt.Errorf("failed to reconcile targetGroup targets due to %v", err)
+	}
+	return tgTargets.Targets, nil
+}
+
+// importedSettingsTagKey is the tag of targetGroups created outside the controller, whose health check settings and attributes
+// left to their defaults keep the values they had when adopted, instead of being reset to the controller defaults.
+const importedSettingsTagKey = "alb.ingress.kubernetes.io/imported-settings"
+
+// ensureTGInstance ensures the targetGroup named tgName exists with the health check settings of serviceAnnos.
+// It returns the current tags of the targetGroup if it already existed, nil otherwise.
+func (controller *defaultController) ensureTGInstance(ctx context.Context, ingress *extensions.Ingress, tgName string, serviceAnnos *annotations.Service, healthCheckPort string) (*elbv2.TargetGroup, map[string]string, error) {
+	tgInstance, err := controller.findExistingTGInstance(ctx, tgName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find existing targetGroup due to %v", err)
+	}
+	var curTags map[string]string
+	if tgInstance == nil {
+		tgInstance, err = controller.newTGInstance(ctx, tgName, serviceAnnos, healthCheckPort)
+		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != elbv2.ErrCodeDuplicateTargetGroupNameException {
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create targetGroup due to %v", err)
+			}
+			return tgInstance, nil, nil
+		}
+		ownerTags := controller.nameTagGen.TagTGGroup(ingress.Namespace, ingress.Name)
+		if tgInstance, err = controller.adoptTGInstance(ctx, tgName, ownerTags); err != nil {
+			return nil, nil, fmt.Errorf("failed to create targetGroup due to %v", err)
+		}
+	} else {
+		tgArn := aws.StringValue(tgInstance.TargetGroupArn)
+		if curTags, err = tags.DescribeELB(ctx, controller.cloud, tgArn); err != nil {
+			return nil, nil, fmt.Errorf("failed to get targetGroup tags due to %v", err)
+		}
+		if importsSettings(curTags) {
+			if _, ok := curTags[importedSettingsTagKey]; !ok {
+				albctx.GetEventf(ctx)(corev1.EventTypeNormal, "IMPORT", "targetGroup %v wasn't created by the controller, importing its settings: %v", tgName, tgArn)
+			}
+			serviceAnnos = importHealthCheck(tgInstance, serviceAnnos, controller.store.GetConfig())
+		}
+	}
+	if tgInstance, err = controller.reconcileTGInstance(ctx, tgInstance, serviceAnnos, healthCheckPort); err != nil {
+		return nil, nil, fmt.Errorf("failed to modify targetGroup due to %v", err)
+	}
+	return tgInstance, curTags, nil
+}
+
 func (controller *defaultController) newTGInstance(ctx context.Context, name string, serviceAnnos *annotations.Service, healthCheckPort string) (*elbv2.TargetGroup, error) {
 	albctx.GetLogger(ctx).Infof("creating target group %v", name)
 	resp, err := controller.cloud.CreateTargetGroupWithContext(ctx, &elbv2.CreateTargetGroupInput{
@@ -139,11 +258,81 @@ func (controller *defaultController) newTGInstance(ctx context.Context, name str
 	return tgInstance, nil
 }
 
+// adoptTGInstance returns the targetGroup named tgName that already exists when creating it, e.g. because it was created
+// since it was looked up. It's only adopted if it has ownerTags, so targetGroups not created for the ingress are never taken over.
+// An untagged targetGroup left by a create whose response was lost is found by name on the next reconcile instead.
+func (controller *defaultController) adoptTGInstance(ctx context.Context, tgName string, ownerTags map[string]string) (*elbv2.TargetGroup, error) {
+	instance, err := controller.findExistingTGInstance(ctx, tgName)
+	if err != nil {
+		return nil, err
+	}
+	if instance == nil {
+		return nil, fmt.Errorf("targetGroup %v already exists but can't be found", tgName)
+	}
+	tgArn := aws.StringValue(instance.TargetGroupArn)
+	tgTags, err := tags.DescribeELB(ctx, controller.cloud, tgArn)
+	if err != nil {
+		return nil, err
+	}
+	if !tags.Contains(tgTags, ownerTags) {
+		return nil, fmt.Errorf("targetGroup %v already exists and isn't tagged for this ingress, ARN: %v", tgName, tgArn)
+	}
+	albctx.GetLogger(ctx).Infof("target group %v already exists, adopting it: %v", tgName, tgArn)
+	return instance, nil
+}
+
+// importsSettings returns whether the settings of a targetGroup with tgTags are imported, i.e. it's being adopted
+// because it has no tags owned by the controller, or it has been adopted that way before.
+func importsSettings(tgTags map[string]string) bool {
+	if tgTags[importedSettingsTagKey] == "true" {
+		return true
+	}
+	for key := range tgTags {
+		if tags.IsOwnedKey(key) {
+			return false
+		}
+	}
+	return true
+}
+
+// importHealthCheck returns a copy of serviceAnnos whose health check settings left to their defaults take the values of instance.
+// The health check port isn't imported, as it decides the security group rules managed for the targets.
+func importHealthCheck(instance *elbv2.TargetGroup, serviceAnnos *annotations.Service, cfg *config.Configuration) *annotations.Service {
+	healthCheck := *serviceAnnos.HealthCheck
+	targetGroup := *serviceAnnos.TargetGroup
+	healthCheck.Path = parser.MergeString(healthCheck.Path, instance.HealthCheckPath, healthcheck.DefaultPath)
+	healthCheck.Protocol = parser.MergeString(healthCheck.Protocol, instance.HealthCheckProtocol, cfg.DefaultBackendProtocol)
+	healthCheck.IntervalSeconds = parser.MergeInt64(healthCheck.IntervalSeconds, instance.HealthCheckIntervalSeconds, healthcheck.DefaultIntervalSeconds)
+	healthCheck.TimeoutSeconds = parser.MergeInt64(healthCheck.TimeoutSeconds, instance.HealthCheckTimeoutSeconds, healthcheck.DefaultTimeoutSeconds)
+	if instance.Matcher != nil {
+		targetGroup.SuccessCodes = parser.MergeString(targetGroup.SuccessCodes, instance.Matcher.HttpCode, targetgroup.DefaultSuccessCodes)
+	}
+	targetGroup.HealthyThresholdCount = parser.MergeInt64(targetGroup.HealthyThresholdCount, instance.HealthyThresholdCount, targetgroup.DefaultHealthyThresholdCount)
+	targetGroup.UnhealthyThresholdCount = parser.MergeInt64(targetGroup.UnhealthyThresholdCount, instance.UnhealthyThresholdCount, targetgroup.DefaultUnhealthyThresholdCount)
+
+	output := *serviceAnnos
+	output.HealthCheck = &healthCheck
+	output.TargetGroup = &targetGroup
+	return &output
+}
+
+// importAttributes returns attributes with the current attributes of targetGroup tgArn that aren't in attributes,
+// so they keep their values instead of being reset to their defaults.
+func (controller *defaultController) importAttributes(ctx context.Context, tgArn string, attributes []*elbv2.TargetGroupAttribute) ([]*elbv2.TargetGroupAttribute, error) {
+	resp, err := controller.cloud.DescribeTargetGroupAttributesWithContext(ctx, &elbv2.DescribeTargetGroupAttributesInput{
+		TargetGroupArn: aws.String(tgArn),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mergeAttributes(resp.Attributes, attributes, nil), nil
+}
+
 func (controller *defaultController) reconcileTGInstance(ctx context.Context, instance *elbv2.TargetGroup, serviceAnnos *annotations.Service, healthCheckPort string) (*elbv2.TargetGroup, error) {
-	if controller.TGInstanceNeedsModification(ctx, instance, serviceAnnos) {
+	if controller.TGInstanceNeedsModification(ctx, instance, serviceAnnos, healthCheckPort) {
 		albctx.GetLogger(ctx).Infof("modify target group %v", aws.StringValue(instance.TargetGroupArn))
 
-		output, err := controller.cloud.ModifyTargetGroupWithContext(ctx, &elbv2.ModifyTargetGroupInput{
+		in := &elbv2.ModifyTargetGroupInput{
 			TargetGroupArn:             instance.TargetGroupArn,
 			HealthCheckPath:            serviceAnnos.HealthCheck.Path,
 			HealthCheckIntervalSeconds: serviceAnnos.HealthCheck.IntervalSeconds,
@@ -153,7 +342,9 @@ func (controller *defaultController) reconcileTGInstance(ctx context.Context, in
 			Matcher:                    &elbv2.Matcher{HttpCode: serviceAnnos.TargetGroup.SuccessCodes},
 			HealthyThresholdCount:      serviceAnnos.TargetGroup.HealthyThresholdCount,
 			UnhealthyThresholdCount:    serviceAnnos.TargetGroup.UnhealthyThresholdCount,
-		})
+		}
+		albctx.RecordAuditBefore(ctx, in, instance)
+		output, err := controller.cloud.ModifyTargetGroupWithContext(ctx, in)
 		if err != nil {
 			return instance, err
 		}
@@ -164,6 +355,7 @@ func (controller *defaultController) reconcileTGInstance(ctx context.Context, in
 
 // resolveServiceHealthCheckPort checks if the service-port annotation is a string. If so, it tries to look up a port with the same name
 // on the service and use that port's NodePort as the health check port.
+// For target-type ip, the name can also refer to a named container port of pods backing the service.
 func (controller *defaultController) resolveServiceHealthCheckPort(namespace string, serviceName string, servicePortAnnotation intstr.IntOrString, targetType string) (string, error) {
 
 	if servicePortAnnotation.Type == intstr.Int {
@@ -187,6 +379,9 @@ func (controller *defaultController) resolveServiceHealthCheckPort(namespace str
 
 	resolvedServicePort, err := k8s.LookupServicePort(service, servicePortAnnotation)
 	if err != nil {
+		if targetType == elbv2.TargetTypeEnumIp {
+			return controller.resolveContainerHealthCheckPort(namespace, serviceName, servicePort)
+		}
 		return servicePort, errors.Wrap(err, "failed to resolve healthcheck port for service")
 	}
 	if targetType == elbv2.TargetTypeEnumInstance {
@@ -195,16 +390,93 @@ func (controller *defaultController) resolveServiceHealthCheckPort(namespace str
 		}
 		return strconv.Itoa(int(resolvedServicePort.NodePort)), nil
 	}
+	if resolvedServicePort.TargetPort.Type == intstr.String {
+		return controller.resolveContainerHealthCheckPort(namespace, serviceName, resolvedServicePort.TargetPort.StrVal)
+	}
 	return resolvedServicePort.TargetPort.String(), nil
 
 }
 
-func (controller *defaultController) TGInstanceNeedsModification(ctx context.Context, instance *elbv2.TargetGroup, serviceAnnos *annotations.Service) bool {
+// resolveContainerHealthCheckPort looks up the container port named portName in pods backing the service.
+// Pods must agree on the port number, since a targetGroup has a single health check port.
+func (controller *defaultController) resolveContainerHealthCheckPort(namespace string, serviceName string, portName string) (string, error) {
+	serviceKey := namespace + "/" + serviceName
+	eps, err := controller.store.GetServiceEndpoints(serviceKey)
+	if err != nil {
+		return portName, errors.Wrap(err, "failed to resolve healthcheck service endpoints")
+	}
+	ports := sets.NewInt()
+	for _, epSubset := range eps.Subsets {
+		for _, epAddr := range append(epSubset.Addresses, epSubset.NotReadyAddresses...) {
+			if epAddr.TargetRef == nil || epAddr.TargetRef.Kind != "Pod" {
+				continue
+			}
+			pod, err := controller.store.GetPod(namespace + "/" + epAddr.TargetRef.Name)
+			if err != nil {
+				continue
+			}
+			for _, container := range pod.Spec.Containers {
+				for _, containerPort := range container.Ports {
+					if containerPort.Name == portName {
+						ports.Insert(int(containerPort.ContainerPort))
+					}
+				}
+			}
+		}
+	}
+	switch ports.Len() {
+	case 0:
+		return portName, fmt.Errorf("failed to find port %s on service %s or its pods", portName, serviceKey)
+	case 1:
+		return strconv.Itoa(ports.List()[0]), nil
+	default:
+		return portName, fmt.Errorf("pods of service %s use different container ports %v for port %s", serviceKey, ports.List(), portName)
+	}
+}
+
+// applyHealthCheckOverride returns a copy of serviceAnnos with health check settings overridden for service port of backend.
+func applyHealthCheckOverride(serviceAnnos *annotations.Service, backend extensions.IngressBackend) (*annotations.Service, error) {
+	override := serviceAnnos.TargetGroup.GetHealthCheckOverride(backend.ServiceName, backend.ServicePort.String())
+	if override == nil {
+		return serviceAnnos, nil
+	}
+	healthCheck := *serviceAnnos.HealthCheck
+	targetGroup := *serviceAnnos.TargetGroup
+	if override.Port != nil {
+		healthCheck.Port = override.Port
+	}
+	if override.Protocol != nil {
+		healthCheck.Protocol = override.Protocol
+	}
+	if override.IntervalSeconds != nil {
+		healthCheck.IntervalSeconds = override.IntervalSeconds
+	}
+	if override.TimeoutSeconds != nil {
+		healthCheck.TimeoutSeconds = override.TimeoutSeconds
+	}
+	if override.HealthyThresholdCount != nil {
+		targetGroup.HealthyThresholdCount = override.HealthyThresholdCount
+	}
+	if override.UnhealthyThresholdCount != nil {
+		targetGroup.UnhealthyThresholdCount = override.UnhealthyThresholdCount
+	}
+	if aws.Int64Value(healthCheck.TimeoutSeconds) >= aws.Int64Value(healthCheck.IntervalSeconds) {
+		return nil, fmt.Errorf("healthcheck timeout must be less than healthcheck interval for backend %v:%v. Timeout was: %d. Interval was %d",
+			backend.ServiceName, backend.ServicePort.String(), aws.Int64Value(healthCheck.TimeoutSeconds), aws.Int64Value(healthCheck.IntervalSeconds))
+	}
+
+	output := *serviceAnnos
+	output.HealthCheck = &healthCheck
+	output.TargetGroup = &targetGroup
+	return &output, nil
+}
+
+func (controller *defaultController) TGInstanceNeedsModification(ctx context.Context, instance *elbv2.TargetGroup, serviceAnnos *annotations.Service, healthCheckPort string) bool {
 	needsChange := false
 	if !util.DeepEqual(instance.HealthCheckPath, serviceAnnos.HealthCheck.Path) {
 		needsChange = true
 	}
-	if !util.DeepEqual(instance.HealthCheckPort, serviceAnnos.HealthCheck.Port) {
+	if aws.StringValue(instance.HealthCheckPort) != healthCheckPort {
 		needsChange = true
 	}
 	if !util.DeepEqual(instance.HealthCheckProtocol, serviceAnnos.HealthCheck.Protocol) {
@@ -2,25 +2,38 @@ package tg
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/cleanup"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+// TagKeyUnusedSince is the tag on targetGroups no longer used by their ingress, holding the time they became unused.
+const TagKeyUnusedSince = "alb.ingress.kubernetes.io/unused-since"
+
 // GroupController manages all target groups for one ingress.
 type GroupController interface {
 	// Reconcile ensures AWS an targetGroup exists for each backend in ingress.
 	Reconcile(ctx context.Context, ingress *extensions.Ingress) (TargetGroupGroup, error)
 
+	// ReconcileTargets reconciles the targets of the existing targetGroups of ingress, by backend, leaving their settings untouched.
+	ReconcileTargets(ctx context.Context, ingress *extensions.Ingress, existing map[extensions.IngressBackend]TargetGroup) (TargetGroupGroup, error)
+
 	// GC will delete unused targetGroups matched by tag selector
 	GC(ctx context.Context, tgGroup TargetGroupGroup) error
 
@@ -34,37 +47,73 @@ func NewGroupController(
 	store store.Storer,
 	nameTagGen NameTagGenerator,
 	tagsController tags.Controller,
-	endpointResolver backend.EndpointResolver) GroupController {
+	endpointResolver backend.EndpointResolver,
+	deletionQueue cleanup.Queue) GroupController {
 	tgController := NewController(cloud, store, nameTagGen, tagsController, endpointResolver)
 	return &defaultGroupController{
-		cloud:        cloud,
-		nameTagGen:   nameTagGen,
-		tgController: tgController,
+		cloud:         cloud,
+		store:         store,
+		nameTagGen:    nameTagGen,
+		deletionQueue: deletionQueue,
+		retention:     store.GetConfig().TargetGroupRetention,
+		tgController:  tgController,
 	}
 }
 
 var _ GroupController = (*defaultGroupController)(nil)
 
 type defaultGroupController struct {
-	cloud      aws.CloudAPI
-	nameTagGen NameTagGenerator
+	cloud         aws.CloudAPI
+	store         store.Storer
+	nameTagGen    NameTagGenerator
+	deletionQueue cleanup.Queue
+	// retention is the duration unused targetGroups are kept before deletion.
+	retention time.Duration
 
 	tgController Controller
 }
 
 func (controller *defaultGroupController) Reconcile(ctx context.Context, ingress *extensions.Ingress) (TargetGroupGroup, error) {
+	return controller.reconcile(ingress, func(backend extensions.IngressBackend) (TargetGroup, error) {
+		return controller.tgController.Reconcile(ctx, ingress, backend)
+	})
+}
+
+func (controller *defaultGroupController) ReconcileTargets(ctx context.Context, ingress *extensions.Ingress, existing map[extensions.IngressBackend]TargetGroup) (TargetGroupGroup, error) {
+	return controller.reconcile(ingress, func(backend extensions.IngressBackend) (TargetGroup, error) {
+		targetGroup, ok := existing[backend]
+		if !ok {
+			return TargetGroup{}, fmt.Errorf("no targetGroup exists for backend %v:%v", backend.ServiceName, backend.ServicePort.String())
+		}
+		return controller.tgController.ReconcileTargets(ctx, ingress, backend, targetGroup)
+	})
+}
+
+// reconcile reconciles the targetGroup of each backend in ingress with reconcileTG.
+func (controller *defaultGroupController) reconcile(ingress *extensions.Ingress, reconcileTG func(backend extensions.IngressBackend) (TargetGroup, error)) (TargetGroupGroup, error) {
 	tgByBackend := make(map[extensions.IngressBackend]TargetGroup)
-	var err error
-	for _, backend := range controller.extractIngressBackends(ingress) {
+	backends, err := controller.extractIngressBackends(ingress)
+	if err != nil {
+		return TargetGroupGroup{}, err
+	}
+	// backends referencing the same service port with the same health check overrides share the targetGroup of the first of them.
+	backendByServicePort := make(map[string]extensions.IngressBackend)
+	for _, backend := range backends {
 		if action.Use(backend.ServicePort.String()) {
 			continue
 		}
 		if _, ok := tgByBackend[backend]; ok {
 			continue
 		}
-		if tgByBackend[backend], err = controller.tgController.Reconcile(ctx, ingress, backend); err != nil {
+		servicePortKey := controller.sharingKey(ingress, backend)
+		if sharedBackend, ok := backendByServicePort[servicePortKey]; ok {
+			tgByBackend[backend] = tgByBackend[sharedBackend]
+			continue
+		}
+		if tgByBackend[backend], err = reconcileTG(backend); err != nil {
 			return TargetGroupGroup{}, err
 		}
+		backendByServicePort[servicePortKey] = backend
 	}
 	selector := controller.nameTagGen.TagTGGroup(ingress.Namespace, ingress.Name)
 	return TargetGroupGroup{
@@ -74,6 +123,11 @@ func (controller *defaultGroupController) Reconcile(ctx context.Context, ingress
 }
 
 func (controller *defaultGroupController) GC(ctx context.Context, tgGroup TargetGroupGroup) error {
+	return controller.gc(ctx, tgGroup, controller.retention)
+}
+
+// gc deletes targetGroups matched by selector of tgGroup but not used by it, once they have been unused for retention.
+func (controller *defaultGroupController) gc(ctx context.Context, tgGroup TargetGroupGroup, retention time.Duration) error {
 	tagFilters := make(map[string][]string)
 	for k, v := range tgGroup.selector {
 		tagFilters[k] = []string{v}
@@ -90,10 +144,28 @@ func (controller *defaultGroupController) GC(ctx context.Context, tgGroup Target
 	currentTgArns := sets.NewString(arns...)
 	unusedTgArns := currentTgArns.Difference(usedTgArns)
 	for arn := range unusedTgArns {
+		if retention > 0 {
+			retained, err := controller.retainUnused(ctx, arn, retention)
+			if err != nil {
+				return err
+			}
+			if retained {
+				continue
+			}
+		}
 		albctx.GetLogger(ctx).Infof("deleting target group %v", arn)
 		if err := controller.cloud.DeleteTargetGroupByArn(ctx, arn); err != nil {
+			// targetGroups are still in use for a while after the rules referencing them are deleted, retry them on requeue.
+			// they're retried by deletion queue as well, in case the ingress is deleted or controller restarts meanwhile.
+			if awsError, ok := err.(awserr.Error); ok && awsError.Code() == elbv2.ErrCodeResourceInUseException {
+				albctx.GetLogger(ctx).Infof("target group %v is still in use, deletion deferred", arn)
+				albctx.RecordWait(ctx, arn, albctx.WaitStateInUse)
+				controller.deletionQueue.Defer(ctx, cleanup.KindTargetGroup, arn)
+				continue
+			}
 			return fmt.Errorf("failed to delete targetGroup due to %v", err)
 		}
+		controller.tgController.Forget(arn)
 	}
 	return nil
 }
@@ -103,15 +175,96 @@ func (controller *defaultGroupController) Delete(ctx context.Context, ingressKey
 	tgGroup := TargetGroupGroup{
 		selector: selector,
 	}
-	return controller.GC(ctx, tgGroup)
+	// targetGroups can't receive traffic once the LoadBalancer of ingress is deleted, so they aren't retained.
+	return controller.gc(ctx, tgGroup, 0)
+}
+
+// retainUnused returns whether unused targetGroup should be kept as it became unused less than retention ago.
+// The time targetGroup became unused is tagged on it, the tag is removed by tag reconciliation once it's used again.
+func (controller *defaultGroupController) retainUnused(ctx context.Context, arn string, retention time.Duration) (bool, error) {
+	resp, err := controller.cloud.DescribeELBV2TagsWithContext(ctx, &elbv2.DescribeTagsInput{
+		ResourceArns: []*string{aws.String(arn)},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to describe tags of targetGroup %v due to %v", arn, err)
+	}
+	var since time.Time
+	for _, tagDescription := range resp.TagDescriptions {
+		for _, tag := range tagDescription.Tags {
+			if aws.StringValue(tag.Key) == TagKeyUnusedSince {
+				since, _ = time.Parse(time.RFC3339, aws.StringValue(tag.Value))
+			}
+		}
+	}
+	if since.IsZero() {
+		since = time.Now()
+		albctx.GetLogger(ctx).Infof("retaining unused target group %v for %v", arn, retention)
+		if _, err := controller.cloud.AddELBV2TagsWithContext(ctx, &elbv2.AddTagsInput{
+			ResourceArns: []*string{aws.String(arn)},
+			Tags:         tags.ConvertToELBV2(map[string]string{TagKeyUnusedSince: since.UTC().Format(time.RFC3339)}),
+		}); err != nil {
+			return false, fmt.Errorf("failed to tag targetGroup %v due to %v", arn, err)
+		}
+	}
+	if time.Since(since) < retention {
+		albctx.RecordWait(ctx, arn, albctx.WaitStateRetained)
+		return true, nil
+	}
+	return false, nil
+}
+
+// sharingKey identifies the targetGroup of backend among those of ingress. Backends referencing the same service port, e.g. by name
+// and by number, share it, unless their health check overrides differ as overrides are keyed by the service port as referenced.
+func (controller *defaultGroupController) sharingKey(ingress *extensions.Ingress, backend extensions.IngressBackend) string {
+	servicePortKey := controller.servicePortKey(ingress.Namespace, backend)
+	// backends whose overrides can't be resolved aren't shared, the error is reported when reconciling their targetGroup.
+	unshared := servicePortKey + "/" + backend.ServicePort.String()
+	ingressAnnos, err := controller.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
+	if err != nil {
+		return unshared
+	}
+	serviceKey := types.NamespacedName{Namespace: ingress.Namespace, Name: backend.ServiceName}
+	serviceAnnos, err := controller.store.GetServiceAnnotations(serviceKey.String(), ingressAnnos)
+	if err != nil {
+		return unshared
+	}
+	override := serviceAnnos.TargetGroup.GetHealthCheckOverride(backend.ServiceName, backend.ServicePort.String())
+	if override == nil {
+		return servicePortKey
+	}
+	payload, err := json.Marshal(override)
+	if err != nil {
+		return unshared
+	}
+	return servicePortKey + "/" + string(payload)
+}
+
+// servicePortKey identifies the service port referenced by backend, resolving port names to numbers when the service is known.
+func (controller *defaultGroupController) servicePortKey(namespace string, backend extensions.IngressBackend) string {
+	service, err := controller.store.GetService(namespace + "/" + backend.ServiceName)
+	if err != nil || service == nil {
+		return backend.ServiceName + ":" + backend.ServicePort.String()
+	}
+	for _, port := range service.Spec.Ports {
+		if (backend.ServicePort.Type == intstr.String && port.Name == backend.ServicePort.StrVal) ||
+			(backend.ServicePort.Type == intstr.Int && port.Port == backend.ServicePort.IntVal) {
+			return fmt.Sprintf("%v:%v", backend.ServiceName, port.Port)
+		}
+	}
+	return backend.ServiceName + ":" + backend.ServicePort.String()
 }
 
 // TODO, should be k8s utils :D
-func (controller *defaultGroupController) extractIngressBackends(ingress *extensions.Ingress) []extensions.IngressBackend {
+func (controller *defaultGroupController) extractIngressBackends(ingress *extensions.Ingress) ([]extensions.IngressBackend, error) {
 	var output []extensions.IngressBackend
 	if ingress.Spec.Backend != nil {
 		output = append(output, *ingress.Spec.Backend)
 	}
+	defaultBackends, err := action.ParseDefaultBackends(ingress)
+	if err != nil {
+		return nil, err
+	}
+	output = append(output, defaultBackends.Backends()...)
 	for _, rule := range ingress.Spec.Rules {
 		if rule.HTTP == nil {
 			continue
@@ -120,5 +273,5 @@ func (controller *defaultGroupController) extractIngressBackends(ingress *extens
 			output = append(output, path.Backend)
 		}
 	}
-	return output
+	return output, nil
 }
@@ -4,11 +4,20 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/cleanup"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/targetgroup"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -36,12 +45,14 @@ type DeleteTargetGroupByArnCall struct {
 
 func TestDefaultGroupController_Reconcile(t *testing.T) {
 	for _, tc := range []struct {
-		Name             string
-		Ingress          extensions.Ingress
-		TGReconcileCalls []TGReconcileCall
-		TagTGGroupCall   *TagTGGroupCall
-		ExpectedTGGroup  TargetGroupGroup
-		ExpectedError    error
+		Name                 string
+		Ingress              extensions.Ingress
+		Service              *corev1.Service
+		HealthCheckOverrides map[string]*targetgroup.HealthCheckOverride
+		TGReconcileCalls     []TGReconcileCall
+		TagTGGroupCall       *TagTGGroupCall
+		ExpectedTGGroup      TargetGroupGroup
+		ExpectedError        error
 	}{
 		{
 			Name: "Reconcile succeeds with duplicated targetGroup",
@@ -153,6 +164,158 @@ func TestDefaultGroupController_Reconcile(t *testing.T) {
 				selector: map[string]string{"key1": "value1", "key2": "value2"},
 			},
 		},
+		{
+			Name: "Reconcile succeeds with backends referencing same service port by name and number",
+			Ingress: extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress",
+					Namespace: "namespace",
+				},
+				Spec: extensions.IngressSpec{
+					Rules: []extensions.IngressRule{
+						{
+							Host: "d1.example.com",
+							IngressRuleValue: extensions.IngressRuleValue{
+								HTTP: &extensions.HTTPIngressRuleValue{
+									Paths: []extensions.HTTPIngressPath{
+										{
+											Path: "/path1",
+											Backend: extensions.IngressBackend{
+												ServiceName: "service1",
+												ServicePort: intstr.FromString("http"),
+											},
+										},
+										{
+											Path: "/path2",
+											Backend: extensions.IngressBackend{
+												ServiceName: "service1",
+												ServicePort: intstr.FromInt(80),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Service: &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Name: "http", Port: 80}},
+				},
+			},
+			TGReconcileCalls: []TGReconcileCall{
+				{
+					Backend: extensions.IngressBackend{
+						ServiceName: "service1",
+						ServicePort: intstr.FromString("http"),
+					},
+					TargetGroup: TargetGroup{
+						Arn: "arn1",
+					},
+				},
+			},
+			TagTGGroupCall: &TagTGGroupCall{
+				Namespace:   "namespace",
+				IngressName: "ingress",
+				Tags:        map[string]string{"key1": "value1", "key2": "value2"},
+			},
+			ExpectedTGGroup: TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]TargetGroup{
+					{
+						ServiceName: "service1",
+						ServicePort: intstr.FromString("http"),
+					}: {Arn: "arn1"},
+					{
+						ServiceName: "service1",
+						ServicePort: intstr.FromInt(80),
+					}: {Arn: "arn1"},
+				},
+				selector: map[string]string{"key1": "value1", "key2": "value2"},
+			},
+		},
+		{
+			Name: "Reconcile succeeds with backends referencing same service port with different health check overrides",
+			Ingress: extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress",
+					Namespace: "namespace",
+				},
+				Spec: extensions.IngressSpec{
+					Rules: []extensions.IngressRule{
+						{
+							Host: "d1.example.com",
+							IngressRuleValue: extensions.IngressRuleValue{
+								HTTP: &extensions.HTTPIngressRuleValue{
+									Paths: []extensions.HTTPIngressPath{
+										{
+											Path: "/path1",
+											Backend: extensions.IngressBackend{
+												ServiceName: "service1",
+												ServicePort: intstr.FromString("http"),
+											},
+										},
+										{
+											Path: "/path2",
+											Backend: extensions.IngressBackend{
+												ServiceName: "service1",
+												ServicePort: intstr.FromInt(80),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Service: &corev1.Service{
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Name: "http", Port: 80}},
+				},
+			},
+			HealthCheckOverrides: map[string]*targetgroup.HealthCheckOverride{
+				"http": {IntervalSeconds: aws.Int64(30)},
+			},
+			TGReconcileCalls: []TGReconcileCall{
+				{
+					Backend: extensions.IngressBackend{
+						ServiceName: "service1",
+						ServicePort: intstr.FromString("http"),
+					},
+					TargetGroup: TargetGroup{
+						Arn: "arn1",
+					},
+				},
+				{
+					Backend: extensions.IngressBackend{
+						ServiceName: "service1",
+						ServicePort: intstr.FromInt(80),
+					},
+					TargetGroup: TargetGroup{
+						Arn: "arn2",
+					},
+				},
+			},
+			TagTGGroupCall: &TagTGGroupCall{
+				Namespace:   "namespace",
+				IngressName: "ingress",
+				Tags:        map[string]string{"key1": "value1", "key2": "value2"},
+			},
+			ExpectedTGGroup: TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]TargetGroup{
+					{
+						ServiceName: "service1",
+						ServicePort: intstr.FromString("http"),
+					}: {Arn: "arn1"},
+					{
+						ServiceName: "service1",
+						ServicePort: intstr.FromInt(80),
+					}: {Arn: "arn2"},
+				},
+				selector: map[string]string{"key1": "value1", "key2": "value2"},
+			},
+		},
 		{
 			Name: "Reconcile succeeds with empty HTTP rule",
 			Ingress: extensions.Ingress{
@@ -431,8 +594,21 @@ func TestDefaultGroupController_Reconcile(t *testing.T) {
 				mockTGController.On("Reconcile", mock.Anything, &tc.Ingress, call.Backend).Return(call.TargetGroup, call.Err)
 			}
 
+			mockStore := store.NewDummy()
+			if tc.Service != nil {
+				mockStore.GetServiceFunc = func(string) (*corev1.Service, error) {
+					return tc.Service, nil
+				}
+			}
+			if tc.HealthCheckOverrides != nil {
+				serviceAnnos := annotations.NewServiceDummy()
+				serviceAnnos.TargetGroup.HealthCheckOverrides = tc.HealthCheckOverrides
+				mockStore.GetServiceAnnotationsResponse = serviceAnnos
+			}
+
 			controller := &defaultGroupController{
 				cloud:        cloud,
+				store:        mockStore,
 				nameTagGen:   mockNameTagGen,
 				tgController: mockTGController,
 			}
@@ -447,12 +623,89 @@ func TestDefaultGroupController_Reconcile(t *testing.T) {
 	}
 }
 
+func TestDefaultGroupController_ReconcileTargets(t *testing.T) {
+	backend1 := extensions.IngressBackend{ServiceName: "service1", ServicePort: intstr.FromInt(80)}
+	backend2 := extensions.IngressBackend{ServiceName: "service2", ServicePort: intstr.FromString("https")}
+	targets := []*elbv2.TargetDescription{{Id: aws.String("i-1")}}
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"},
+		Spec: extensions.IngressSpec{
+			Rules: []extensions.IngressRule{
+				{
+					IngressRuleValue: extensions.IngressRuleValue{
+						HTTP: &extensions.HTTPIngressRuleValue{
+							Paths: []extensions.HTTPIngressPath{
+								{Path: "/path1", Backend: backend1},
+								{Path: "/path2", Backend: backend2},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		Name            string
+		Existing        map[extensions.IngressBackend]TargetGroup
+		ExpectedTGGroup TargetGroupGroup
+		ExpectedError   error
+	}{
+		{
+			Name: "reconciles targets of existing targetGroups",
+			Existing: map[extensions.IngressBackend]TargetGroup{
+				backend1: {Arn: "arn1", TargetType: elbv2.TargetTypeEnumInstance},
+				backend2: {Arn: "arn2", TargetType: elbv2.TargetTypeEnumIp},
+			},
+			ExpectedTGGroup: TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]TargetGroup{
+					backend1: {Arn: "arn1", TargetType: elbv2.TargetTypeEnumInstance, Targets: targets},
+					backend2: {Arn: "arn2", TargetType: elbv2.TargetTypeEnumIp, Targets: targets},
+				},
+				selector: map[string]string{"tag": "value"},
+			},
+		},
+		{
+			Name: "fails if the targetGroup of a backend doesn't exist",
+			Existing: map[extensions.IngressBackend]TargetGroup{
+				backend1: {Arn: "arn1", TargetType: elbv2.TargetTypeEnumInstance},
+			},
+			ExpectedError: errors.New("no targetGroup exists for backend service2:https"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			mockNameTagGen := &MockNameTagGenerator{}
+			mockNameTagGen.On("TagTGGroup", "namespace", "ingress").Return(map[string]string{"tag": "value"})
+			mockTGController := &MockController{}
+			for backend, targetGroup := range tc.Existing {
+				reconciled := targetGroup
+				reconciled.Targets = targets
+				mockTGController.On("ReconcileTargets", mock.Anything, ingress, backend, targetGroup).Return(reconciled, nil)
+			}
+
+			controller := &defaultGroupController{
+				store:        store.NewDummy(),
+				nameTagGen:   mockNameTagGen,
+				tgController: mockTGController,
+			}
+
+			tgGroup, err := controller.ReconcileTargets(context.Background(), ingress, tc.Existing)
+			assert.Equal(t, tc.ExpectedTGGroup, tgGroup)
+			assert.Equal(t, tc.ExpectedError, err)
+			mockTGController.AssertExpectations(t)
+		})
+	}
+}
+
 func TestDefaultGroupController_GC(t *testing.T) {
 	for _, tc := range []struct {
 		Name                        string
 		TGGroup                     TargetGroupGroup
 		GetResourcesByFiltersCall   *GetResourcesByFiltersCall
 		DeleteTargetGroupByArnCalls []DeleteTargetGroupByArnCall
+		Retention                   time.Duration
+		UnusedSinceByArn            map[string]string
+		ExpectedTaggedArns          []string
+		ExpectedWaits               []albctx.Wait
 		ExpectedError               error
 	}{
 		{
@@ -522,8 +775,65 @@ func TestDefaultGroupController_GC(t *testing.T) {
 			},
 			ExpectedError: errors.New("failed to delete targetGroup due to DeleteTargetGroupByArnCall"),
 		},
+		{
+			Name: "GC defers targetGroup still in use",
+			TGGroup: TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]TargetGroup{
+					{
+						ServiceName: "service1",
+						ServicePort: intstr.FromInt(80),
+					}: {Arn: "arn1"},
+				},
+				selector: map[string]string{"key1": "value1", "key2": "value2"},
+			},
+			GetResourcesByFiltersCall: &GetResourcesByFiltersCall{
+				TagFilters:   map[string][]string{"key1": {"value1"}, "key2": {"value2"}},
+				ResourceType: aws.ResourceTypeEnumELBTargetGroup,
+				Arns:         []string{"arn1", "arn2", "arn3"},
+			},
+			DeleteTargetGroupByArnCalls: []DeleteTargetGroupByArnCall{
+				{
+					Arn: "arn2",
+					Err: awserr.New(elbv2.ErrCodeResourceInUseException, "in use", nil),
+				},
+				{
+					Arn: "arn3",
+				},
+			},
+			ExpectedWaits: []albctx.Wait{{Resource: "arn2", State: albctx.WaitStateInUse}},
+		},
+		{
+			Name: "GC retains targetGroups unused for less than retention",
+			TGGroup: TargetGroupGroup{
+				TGByBackend: map[extensions.IngressBackend]TargetGroup{
+					{
+						ServiceName: "service1",
+						ServicePort: intstr.FromInt(80),
+					}: {Arn: "arn1"},
+				},
+				selector: map[string]string{"key1": "value1", "key2": "value2"},
+			},
+			GetResourcesByFiltersCall: &GetResourcesByFiltersCall{
+				TagFilters:   map[string][]string{"key1": {"value1"}, "key2": {"value2"}},
+				ResourceType: aws.ResourceTypeEnumELBTargetGroup,
+				Arns:         []string{"arn1", "arn2", "arn3"},
+			},
+			DeleteTargetGroupByArnCalls: []DeleteTargetGroupByArnCall{
+				{
+					Arn: "arn3",
+				},
+			},
+			Retention: time.Hour,
+			UnusedSinceByArn: map[string]string{
+				"arn2": "",
+				"arn3": time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339),
+			},
+			ExpectedTaggedArns: []string{"arn2"},
+			ExpectedWaits:      []albctx.Wait{{Resource: "arn2", State: albctx.WaitStateRetained}},
+		},
 	} {
-		ctx := context.Background()
+		recorder := &albctx.WaitRecorder{}
+		ctx := albctx.SetWaitRecorder(context.Background(), recorder)
 		cloud := &mocks.CloudAPI{}
 		if tc.GetResourcesByFiltersCall != nil {
 			cloud.On("GetResourcesByFilters", tc.GetResourcesByFiltersCall.TagFilters, tc.GetResourcesByFiltersCall.ResourceType).Return(tc.GetResourcesByFiltersCall.Arns, tc.GetResourcesByFiltersCall.Err)
@@ -531,20 +841,47 @@ func TestDefaultGroupController_GC(t *testing.T) {
 		for _, call := range tc.DeleteTargetGroupByArnCalls {
 			cloud.On("DeleteTargetGroupByArn", ctx, call.Arn).Return(call.Err)
 		}
+		for arn, unusedSince := range tc.UnusedSinceByArn {
+			tagDescription := &elbv2.TagDescription{ResourceArn: aws.String(arn)}
+			if unusedSince != "" {
+				tagDescription.Tags = []*elbv2.Tag{{Key: aws.String(TagKeyUnusedSince), Value: aws.String(unusedSince)}}
+			}
+			cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{ResourceArns: []*string{aws.String(arn)}}).Return(&elbv2.DescribeTagsOutput{
+				TagDescriptions: []*elbv2.TagDescription{tagDescription},
+			}, nil)
+		}
+		for _, arn := range tc.ExpectedTaggedArns {
+			cloud.On("AddELBV2TagsWithContext", ctx, mock.MatchedBy(func(input *elbv2.AddTagsInput) bool {
+				return aws.StringValue(input.ResourceArns[0]) == arn && aws.StringValue(input.Tags[0].Key) == TagKeyUnusedSince
+			})).Return(nil, nil)
+		}
 		mockNameTagGen := &MockNameTagGenerator{}
 		mockTGController := &MockController{}
+		for _, call := range tc.DeleteTargetGroupByArnCalls {
+			if call.Err == nil {
+				mockTGController.On("Forget", call.Arn).Return()
+			}
+		}
+		mockDeletionQueue := &cleanup.MockQueue{}
+		for _, wait := range tc.ExpectedWaits {
+			mockDeletionQueue.On("Defer", ctx, cleanup.KindTargetGroup, wait.Resource).Return()
+		}
 
 		controller := &defaultGroupController{
-			cloud:        cloud,
-			nameTagGen:   mockNameTagGen,
-			tgController: mockTGController,
+			cloud:         cloud,
+			nameTagGen:    mockNameTagGen,
+			deletionQueue: mockDeletionQueue,
+			retention:     tc.Retention,
+			tgController:  mockTGController,
 		}
 
-		err := controller.GC(context.Background(), tc.TGGroup)
+		err := controller.GC(ctx, tc.TGGroup)
+		assert.Equal(t, tc.ExpectedWaits, recorder.Waits())
 		assert.Equal(t, tc.ExpectedError, err)
 		cloud.AssertExpectations(t)
 		mockNameTagGen.AssertExpectations(t)
 		mockTGController.AssertExpectations(t)
+		mockDeletionQueue.AssertExpectations(t)
 	}
 }
 
@@ -641,6 +978,11 @@ func TestDefaultGroupController_Delete(t *testing.T) {
 			mockNameTagGen.On("TagTGGroup", tc.TagTGGroupCall.Namespace, tc.TagTGGroupCall.IngressName).Return(tc.TagTGGroupCall.Tags)
 		}
 		mockTGController := &MockController{}
+		for _, call := range tc.DeleteTargetGroupByArnCalls {
+			if call.Err == nil {
+				mockTGController.On("Forget", call.Arn).Return()
+			}
+		}
 
 		controller := &defaultGroupController{
 			cloud:        cloud,
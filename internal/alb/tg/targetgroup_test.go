@@ -12,6 +12,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/healthcheck"
 	annoTags "github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/targetgroup"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
 	"github.com/stretchr/testify/assert"
@@ -86,6 +87,12 @@ type TagsReconcileCall struct {
 	Err  error
 }
 
+type DescribeAttributesCall struct {
+	TGArn      string
+	Attributes []*elbv2.TargetGroupAttribute
+	Err        error
+}
+
 type AttributesReconcileCall struct {
 	TGArn      string
 	Attributes []*elbv2.TargetGroupAttribute
@@ -116,11 +123,14 @@ func TestDefaultController_Reconcile(t *testing.T) {
 		GetIngressAnnotationsCall *GetIngressAnnotationsCall
 		GetServiceAnnotationsCall *GetServiceAnnotationsCall
 		GetServiceCall            *GetServiceCall
+		Config                    *config.Configuration
 		NameTGCall                *NameTGCall
 		TagTGCall                 *TagTGCall
 		TagTGGroupCall            *TagTGGroupCall
 		GetTargetGroupByNameCall  *GetTargetGroupByNameCall
+		CurrentTags               map[string]string
 		ModifyTargetGroupCall     *ModifyTargetGroupCall
+		DescribeAttributesCall    *DescribeAttributesCall
 		CreateTargetGroupCall     *CreateTargetGroupCall
 		TagsReconcileCall         *TagsReconcileCall
 		AttributesReconcileCall   *AttributesReconcileCall
@@ -250,6 +260,7 @@ func TestDefaultController_Reconcile(t *testing.T) {
 						Port: aws.Int64(8888),
 					},
 				},
+				HealthCheckPort: "8080",
 			},
 		},
 		{
@@ -387,6 +398,7 @@ func TestDefaultController_Reconcile(t *testing.T) {
 						Port: aws.Int64(8888),
 					},
 				},
+				HealthCheckPort: "9090",
 			},
 		},
 		{
@@ -525,6 +537,7 @@ func TestDefaultController_Reconcile(t *testing.T) {
 						Port: aws.Int64(8888),
 					},
 				},
+				HealthCheckPort: "9091",
 			},
 		},
 		{
@@ -632,6 +645,142 @@ func TestDefaultController_Reconcile(t *testing.T) {
 						Port: aws.Int64(8888),
 					},
 				},
+				HealthCheckPort: "8080",
+			},
+		},
+		{
+			Name:    "Reconcile succeeds by importing settings of existing instance not created by controller",
+			Ingress: ingress,
+			Backend: ingressBackend,
+			Config:  &config.Configuration{StrictAttributes: true},
+			GetIngressAnnotationsCall: &GetIngressAnnotationsCall{
+				Key:          "namespace/ingress",
+				IngressAnnos: &annotations.Ingress{Tags: &annoTags.Config{}},
+			},
+			GetServiceAnnotationsCall: &GetServiceAnnotationsCall{
+				Key:          "namespace/service",
+				IngressAnnos: &annotations.Ingress{Tags: &annoTags.Config{}},
+				ServiceAnnos: &annotations.Service{
+					HealthCheck: &healthcheck.Config{
+						Path:            aws.String(healthcheck.DefaultPath),
+						Port:            aws.String("8080"),
+						Protocol:        aws.String("HTTP"),
+						IntervalSeconds: aws.Int64(healthcheck.DefaultIntervalSeconds),
+						TimeoutSeconds:  aws.Int64(healthcheck.DefaultTimeoutSeconds),
+					},
+					TargetGroup: &targetgroup.Config{
+						BackendProtocol:         aws.String("HTTP"),
+						TargetType:              aws.String("ip"),
+						SuccessCodes:            aws.String(targetgroup.DefaultSuccessCodes),
+						HealthyThresholdCount:   aws.Int64(targetgroup.DefaultHealthyThresholdCount),
+						UnhealthyThresholdCount: aws.Int64(5),
+						Attributes: []*elbv2.TargetGroupAttribute{
+							{
+								Key:   aws.String("stickiness.enabled"),
+								Value: aws.String("true"),
+							},
+						},
+					},
+				},
+			},
+			NameTGCall: &NameTGCall{
+				Namespace:   "namespace",
+				IngressName: "ingress",
+				ServiceName: "service",
+				ServicePort: "443",
+				TargetType:  "ip",
+				Protocol:    "HTTP",
+				TGName:      "k8s-tgName",
+			},
+			TagTGCall: &TagTGCall{
+				ServiceName: "service",
+				ServicePort: "443",
+				Tags:        map[string]string{"tg-tag": "tg-tag-value"},
+			},
+			TagTGGroupCall: &TagTGGroupCall{
+				Namespace:   "namespace",
+				IngressName: "ingress",
+				Tags:        map[string]string{"group-tag": "group-tag-value"},
+			},
+			GetTargetGroupByNameCall: &GetTargetGroupByNameCall{
+				TGName: "k8s-tgName",
+				Instance: &elbv2.TargetGroup{
+					TargetGroupArn:             aws.String("MyTargetGroupArn"),
+					HealthCheckPath:            aws.String("/healthz"),
+					HealthCheckPort:            aws.String("8080"),
+					HealthCheckProtocol:        aws.String("HTTP"),
+					HealthCheckIntervalSeconds: aws.Int64(30),
+					HealthCheckTimeoutSeconds:  aws.Int64(10),
+					Protocol:                   aws.String("HTTP"),
+					TargetType:                 aws.String("ip"),
+					Matcher:                    &elbv2.Matcher{HttpCode: aws.String("200-299")},
+					HealthyThresholdCount:      aws.Int64(3),
+					UnhealthyThresholdCount:    aws.Int64(4),
+				},
+			},
+			CurrentTags: map[string]string{"team": "a"},
+			ModifyTargetGroupCall: &ModifyTargetGroupCall{
+				Input: &elbv2.ModifyTargetGroupInput{
+					TargetGroupArn:             aws.String("MyTargetGroupArn"),
+					HealthCheckPath:            aws.String("/healthz"),
+					HealthCheckPort:            aws.String("8080"),
+					HealthCheckProtocol:        aws.String("HTTP"),
+					HealthCheckIntervalSeconds: aws.Int64(30),
+					HealthCheckTimeoutSeconds:  aws.Int64(10),
+					Matcher:                    &elbv2.Matcher{HttpCode: aws.String("200-299")},
+					HealthyThresholdCount:      aws.Int64(3),
+					UnhealthyThresholdCount:    aws.Int64(5),
+				},
+				Instance: &elbv2.TargetGroup{
+					TargetGroupArn: aws.String("MyTargetGroupArn"),
+				},
+			},
+			TagsReconcileCall: &TagsReconcileCall{
+				Arn:  "MyTargetGroupArn",
+				Tags: map[string]string{"tg-tag": "tg-tag-value", "group-tag": "group-tag-value", importedSettingsTagKey: "true"},
+			},
+			DescribeAttributesCall: &DescribeAttributesCall{
+				TGArn: "MyTargetGroupArn",
+				Attributes: []*elbv2.TargetGroupAttribute{
+					{
+						Key:   aws.String("stickiness.enabled"),
+						Value: aws.String("false"),
+					},
+					{
+						Key:   aws.String("deregistration_delay.timeout_seconds"),
+						Value: aws.String("30"),
+					},
+					{
+						Key:   aws.String("unknown.attribute"),
+						Value: aws.String("value"),
+					},
+				},
+			},
+			AttributesReconcileCall: &AttributesReconcileCall{
+				TGArn: "MyTargetGroupArn",
+				Attributes: []*elbv2.TargetGroupAttribute{
+					{
+						Key:   aws.String("stickiness.enabled"),
+						Value: aws.String("true"),
+					},
+					{
+						Key:   aws.String("deregistration_delay.timeout_seconds"),
+						Value: aws.String("30"),
+					},
+				},
+			},
+			TargetsReconcileCall: &TargetsReconcileCall{
+				Targets: &Targets{
+					TgArn:      "MyTargetGroupArn",
+					TargetType: "ip",
+					Ingress:    &ingress,
+					Backend:    &ingressBackend,
+				},
+			},
+			ExpectedTG: TargetGroup{
+				Arn:             "MyTargetGroupArn",
+				TargetType:      "ip",
+				HealthCheckPort: "8080",
 			},
 		},
 		{
@@ -765,6 +914,7 @@ func TestDefaultController_Reconcile(t *testing.T) {
 						Port: aws.Int64(8888),
 					},
 				},
+				HealthCheckPort: "8080",
 			},
 		},
 		{
@@ -1217,6 +1367,30 @@ func TestDefaultController_Reconcile(t *testing.T) {
 			},
 			ExpectedError: errors.New("failed to reconcile targetGroup targets due to TargetsReconcileCall"),
 		},
+		{
+			Name:    "Reconcile failed when target-type is restricted",
+			Ingress: ingress,
+			Backend: ingressBackend,
+			GetIngressAnnotationsCall: &GetIngressAnnotationsCall{
+				Key:          "namespace/ingress",
+				IngressAnnos: &annotations.Ingress{Tags: &annoTags.Config{}},
+			},
+			GetServiceAnnotationsCall: &GetServiceAnnotationsCall{
+				Key:          "namespace/service",
+				IngressAnnos: &annotations.Ingress{Tags: &annoTags.Config{}},
+				ServiceAnnos: &annotations.Service{
+					HealthCheck: &healthcheck.Config{
+						Port: aws.String("8080"),
+					},
+					TargetGroup: &targetgroup.Config{
+						BackendProtocol: aws.String("HTTP"),
+						TargetType:      aws.String("ip"),
+					},
+				},
+			},
+			Config:        &config.Configuration{DefaultTargetType: "instance", RestrictTargetType: true},
+			ExpectedError: errors.New("target-type ip is not allowed, controller is restricted to target-type instance"),
+		},
 		{
 			Name:    "GetIngressAnnotations returns error",
 			Ingress: ingress,
@@ -1246,9 +1420,24 @@ func TestDefaultController_Reconcile(t *testing.T) {
 		t.Run(tc.Name, func(t *testing.T) {
 			ctx := context.Background()
 			cloud := &mocks.CloudAPI{}
+			// existing targetGroups are created by the controller unless the test case says otherwise.
+			curTags := tc.CurrentTags
+			if curTags == nil {
+				curTags = map[string]string{"kubernetes.io/ingress-name": "ingress"}
+			}
+			existing := tc.GetTargetGroupByNameCall != nil && tc.GetTargetGroupByNameCall.Instance != nil
 			if tc.GetTargetGroupByNameCall != nil {
 				cloud.On("GetTargetGroupByName", ctx, tc.GetTargetGroupByNameCall.TGName).Return(tc.GetTargetGroupByNameCall.Instance, tc.GetTargetGroupByNameCall.Err)
 			}
+			if existing {
+				tgArn := tc.GetTargetGroupByNameCall.Instance.TargetGroupArn
+				cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{ResourceArns: []*string{tgArn}}).Return(
+					&elbv2.DescribeTagsOutput{TagDescriptions: []*elbv2.TagDescription{{ResourceArn: tgArn, Tags: tags.ConvertToELBV2(curTags)}}}, nil)
+			}
+			if tc.DescribeAttributesCall != nil {
+				cloud.On("DescribeTargetGroupAttributesWithContext", ctx, &elbv2.DescribeTargetGroupAttributesInput{TargetGroupArn: aws.String(tc.DescribeAttributesCall.TGArn)}).Return(
+					&elbv2.DescribeTargetGroupAttributesOutput{Attributes: tc.DescribeAttributesCall.Attributes}, tc.DescribeAttributesCall.Err)
+			}
 			if tc.ModifyTargetGroupCall != nil {
 				cloud.On("ModifyTargetGroupWithContext", ctx, tc.ModifyTargetGroupCall.Input).Return(&elbv2.ModifyTargetGroupOutput{
 					TargetGroups: []*elbv2.TargetGroup{tc.ModifyTargetGroupCall.Instance},
@@ -1271,6 +1460,13 @@ func TestDefaultController_Reconcile(t *testing.T) {
 			if tc.GetServiceCall != nil {
 				mockStore.On("GetService", tc.GetServiceCall.Key).Return(tc.GetServiceCall.service, tc.GetServiceCall.Err)
 			}
+			if tc.GetServiceAnnotationsCall != nil && tc.GetServiceAnnotationsCall.Err == nil {
+				cfg := tc.Config
+				if cfg == nil {
+					cfg = &config.Configuration{}
+				}
+				mockStore.On("GetConfig").Return(cfg)
+			}
 
 			mockNameTagGen := &MockNameTagGenerator{}
 			if tc.NameTGCall != nil {
@@ -1284,7 +1480,9 @@ func TestDefaultController_Reconcile(t *testing.T) {
 			}
 
 			mockTagsController := &tags.MockController{}
-			if tc.TagsReconcileCall != nil {
+			if tc.TagsReconcileCall != nil && existing {
+				mockTagsController.On("ReconcileELBWithCurTags", mock.Anything, tc.TagsReconcileCall.Arn, tc.TagsReconcileCall.Tags, curTags).Return(tc.TagsReconcileCall.Err)
+			} else if tc.TagsReconcileCall != nil {
 				mockTagsController.On("ReconcileELB", mock.Anything, tc.TagsReconcileCall.Arn, tc.TagsReconcileCall.Tags).Return(tc.TagsReconcileCall.Err)
 			}
 
@@ -1323,3 +1521,221 @@ func TestDefaultController_Reconcile(t *testing.T) {
 		})
 	}
 }
+
+func Test_adoptTGInstance(t *testing.T) {
+	ownerTags := map[string]string{"kubernetes.io/ingress-name": "ingress"}
+	instance := &elbv2.TargetGroup{TargetGroupArn: aws.String("tgArn")}
+	for _, tc := range []struct {
+		name             string
+		tags             []*elbv2.Tag
+		expectedInstance *elbv2.TargetGroup
+		expectedErr      error
+	}{
+		{
+			name:        "untagged targetGroup is rejected",
+			expectedErr: errors.New("targetGroup tgName already exists and isn't tagged for this ingress, ARN: tgArn"),
+		},
+		{
+			name:             "targetGroup tagged for ingress is adopted",
+			tags:             []*elbv2.Tag{{Key: aws.String("kubernetes.io/ingress-name"), Value: aws.String("ingress")}},
+			expectedInstance: instance,
+		},
+		{
+			name:        "targetGroup tagged for another ingress is rejected",
+			tags:        []*elbv2.Tag{{Key: aws.String("kubernetes.io/ingress-name"), Value: aws.String("other-ingress")}},
+			expectedErr: errors.New("targetGroup tgName already exists and isn't tagged for this ingress, ARN: tgArn"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetTargetGroupByName", ctx, "tgName").Return(instance, nil)
+			cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{ResourceArns: aws.StringSlice([]string{"tgArn"})}).Return(
+				&elbv2.DescribeTagsOutput{TagDescriptions: []*elbv2.TagDescription{{ResourceArn: aws.String("tgArn"), Tags: tc.tags}}}, nil)
+
+			controller := &defaultController{cloud: cloud}
+			tgInstance, err := controller.adoptTGInstance(ctx, "tgName", ownerTags)
+			assert.Equal(t, tc.expectedInstance, tgInstance)
+			assert.Equal(t, tc.expectedErr, err)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_importsSettings(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		tags     map[string]string
+		expected bool
+	}{
+		{
+			name:     "untagged targetGroup",
+			tags:     map[string]string{},
+			expected: true,
+		},
+		{
+			name:     "targetGroup without controller tags",
+			tags:     map[string]string{"team": "a"},
+			expected: true,
+		},
+		{
+			name:     "targetGroup created by controller",
+			tags:     map[string]string{"kubernetes.io/ingress-name": "ingress"},
+			expected: false,
+		},
+		{
+			name:     "targetGroup adopted with imported settings",
+			tags:     map[string]string{"kubernetes.io/ingress-name": "ingress", importedSettingsTagKey: "true"},
+			expected: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, importsSettings(tc.tags))
+		})
+	}
+}
+
+func Test_applyHealthCheckOverride(t *testing.T) {
+	serviceAnnos := &annotations.Service{
+		HealthCheck: &healthcheck.Config{
+			IntervalSeconds: aws.Int64(15),
+			TimeoutSeconds:  aws.Int64(5),
+		},
+		TargetGroup: &targetgroup.Config{
+			HealthyThresholdCount:   aws.Int64(2),
+			UnhealthyThresholdCount: aws.Int64(2),
+			HealthCheckOverrides: map[string]*targetgroup.HealthCheckOverride{
+				"legacy:8080": {
+					IntervalSeconds:       aws.Int64(60),
+					TimeoutSeconds:        aws.Int64(30),
+					HealthyThresholdCount: aws.Int64(5),
+				},
+				"http": {
+					TimeoutSeconds: aws.Int64(20),
+				},
+			},
+		},
+	}
+
+	output, err := applyHealthCheckOverride(serviceAnnos, extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(80)})
+	assert.NoError(t, err)
+	assert.Equal(t, serviceAnnos, output)
+
+	output, err = applyHealthCheckOverride(serviceAnnos, extensions.IngressBackend{ServiceName: "legacy", ServicePort: intstr.FromInt(8080)})
+	assert.NoError(t, err)
+	assert.Equal(t, aws.Int64(60), output.HealthCheck.IntervalSeconds)
+	assert.Equal(t, aws.Int64(30), output.HealthCheck.TimeoutSeconds)
+	assert.Equal(t, aws.Int64(5), output.TargetGroup.HealthyThresholdCount)
+	assert.Equal(t, aws.Int64(2), output.TargetGroup.UnhealthyThresholdCount)
+	assert.Equal(t, aws.Int64(15), serviceAnnos.HealthCheck.IntervalSeconds, "original annotations must not be modified")
+
+	_, err = applyHealthCheckOverride(serviceAnnos, extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromString("http")})
+	assert.Error(t, err)
+}
+
+func Test_resolveServiceHealthCheckPort(t *testing.T) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "service"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80, NodePort: 30080, TargetPort: intstr.FromString("web")},
+			},
+		},
+	}
+	newPod := func(name string, adminPort int32) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: name},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Ports: []corev1.ContainerPort{{Name: "web", ContainerPort: 8080}}},
+					{Ports: []corev1.ContainerPort{{Name: "admin", ContainerPort: adminPort}}},
+				},
+			},
+		}
+	}
+	for _, tc := range []struct {
+		name          string
+		port          string
+		targetType    string
+		pods          []*corev1.Pod
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:       "traffic-port",
+			port:       healthcheck.DefaultPort,
+			targetType: elbv2.TargetTypeEnumIp,
+			expected:   healthcheck.DefaultPort,
+		},
+		{
+			name:       "service port by name for target-type=instance",
+			port:       "http",
+			targetType: elbv2.TargetTypeEnumInstance,
+			expected:   "30080",
+		},
+		{
+			name:       "service port with named targetPort for target-type=ip",
+			port:       "http",
+			targetType: elbv2.TargetTypeEnumIp,
+			pods:       []*corev1.Pod{newPod("pod-1", 8443)},
+			expected:   "8080",
+		},
+		{
+			name:       "container port by name for target-type=ip",
+			port:       "admin",
+			targetType: elbv2.TargetTypeEnumIp,
+			pods:       []*corev1.Pod{newPod("pod-1", 8443), newPod("pod-2", 8443)},
+			expected:   "8443",
+		},
+		{
+			name:          "container port by name for target-type=instance",
+			port:          "admin",
+			targetType:    elbv2.TargetTypeEnumInstance,
+			pods:          []*corev1.Pod{newPod("pod-1", 8443)},
+			expectedError: true,
+		},
+		{
+			name:          "container port differs between pods",
+			port:          "admin",
+			targetType:    elbv2.TargetTypeEnumIp,
+			pods:          []*corev1.Pod{newPod("pod-1", 8443), newPod("pod-2", 9443)},
+			expectedError: true,
+		},
+		{
+			name:          "unknown port name",
+			port:          "metrics",
+			targetType:    elbv2.TargetTypeEnumIp,
+			pods:          []*corev1.Pod{newPod("pod-1", 8443)},
+			expectedError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			podByKey := make(map[string]*corev1.Pod)
+			eps := &corev1.Endpoints{Subsets: []corev1.EndpointSubset{{}}}
+			for _, pod := range tc.pods {
+				podByKey[pod.Namespace+"/"+pod.Name] = pod
+				eps.Subsets[0].Addresses = append(eps.Subsets[0].Addresses, corev1.EndpointAddress{
+					TargetRef: &corev1.ObjectReference{Kind: "Pod", Namespace: pod.Namespace, Name: pod.Name},
+				})
+			}
+			s := store.NewDummy()
+			s.GetServiceFunc = func(string) (*corev1.Service, error) { return service, nil }
+			s.GetServiceEndpointsFunc = func(string) (*corev1.Endpoints, error) { return eps, nil }
+			s.GetPodFunc = func(key string) (*corev1.Pod, error) {
+				if pod, ok := podByKey[key]; ok {
+					return pod, nil
+				}
+				return nil, errors.New("pod not found")
+			}
+			controller := &defaultController{store: s}
+
+			port, err := controller.resolveServiceHealthCheckPort("namespace", "service", intstr.Parse(tc.port), tc.targetType)
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, port)
+		})
+	}
+}
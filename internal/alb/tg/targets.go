@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
@@ -11,6 +13,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
 	api "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // Targets contains the targets for a target group.
@@ -29,6 +32,14 @@ type Targets struct {
 
 	// Backend is the ingress backend for the targets
 	Backend *extensions.IngressBackend
+
+	// MinHealthyTargets is the minimum number of healthy targets to keep registered, deregistration of healthy targets
+	// that would drop the target group below it is deferred. Zero disables the protection.
+	MinHealthyTargets int64
+
+	// GracePeriod is the duration after registration during which targets are not counted as healthy towards MinHealthyTargets,
+	// so that targets of slow starting apps which pass health checks early don't replace healthy ones prematurely.
+	GracePeriod time.Duration
 }
 
 // NewTargets returns a new Targets pointer
@@ -44,6 +55,9 @@ func NewTargets(targetType string, ingress *extensions.Ingress, backend *extensi
 type TargetsController interface {
 	// Reconcile ensures the target group targets in AWS matches the targets configured in the ingress backend.
 	Reconcile(context.Context, *Targets) error
+
+	// Forget drops the registration times kept for the targets of targetGroup tgArn, once it's deleted.
+	Forget(tgArn string)
 }
 
 // NewTargetsController constructs a new target group targets controller
@@ -51,12 +65,17 @@ func NewTargetsController(cloud aws.CloudAPI, endpointResolver backend.EndpointR
 	return &targetsController{
 		cloud:            cloud,
 		endpointResolver: endpointResolver,
+		registeredAt:     make(map[string]time.Time),
 	}
 }
 
 type targetsController struct {
 	cloud            aws.CloudAPI
 	endpointResolver backend.EndpointResolver
+
+	mutex sync.Mutex
+	// registeredAt contains the registration time of targets registered within their grace period, by targetGroup ARN and target.
+	registeredAt map[string]time.Time
 }
 
 func (c *targetsController) Reconcile(ctx context.Context, t *Targets) error {
@@ -64,11 +83,29 @@ func (c *targetsController) Reconcile(ctx context.Context, t *Targets) error {
 	if err != nil {
 		return err
 	}
-	current, err := c.getCurrentTargets(ctx, t.TgArn)
+	current, healthy, err := c.getCurrentTargets(ctx, t.TgArn)
 	if err != nil {
 		return err
 	}
 	additions, removals := targetChangeSets(current, desired)
+	removals, unconfirmed := c.confirmRemovals(ctx, t, removals)
+	healthy = c.healthyAfterGracePeriod(t.TgArn, healthy, t.GracePeriod)
+	removals, deferred := deferRemovals(removals, healthy, t.MinHealthyTargets)
+	if len(unconfirmed) > 0 {
+		albctx.GetLogger(ctx).Warnf("Skipping removal of targets from %v, which isn't confirmed by API server: %v", t.TgArn, tdsString(unconfirmed))
+		albctx.GetEventf(ctx)(api.EventTypeWarning, "STALE_CACHE", "Skipping removal of %v targets from target group %s, which isn't confirmed by API server", len(unconfirmed), t.TgArn)
+		deferred = append(deferred, unconfirmed...)
+	}
+	if len(deferred) > 0 {
+		albctx.GetLogger(ctx).Warnf("Deferring removal of targets from %v to keep %v healthy targets: %v", t.TgArn, t.MinHealthyTargets, tdsString(deferred))
+		albctx.GetEventf(ctx)(api.EventTypeWarning, "DEFER", "Deferring removal of %v targets from target group %s to keep %v healthy targets", len(deferred), t.TgArn, t.MinHealthyTargets)
+	}
+	if albctx.GetRemovalGuard(ctx).Exceeded(len(current), len(removals)) {
+		albctx.GetLogger(ctx).Warnf("Skipping removal of %v out of %v targets from %v, which exceeds the removal safety threshold: %v", len(removals), len(current), t.TgArn, tdsString(removals))
+		albctx.GetEventf(ctx)(api.EventTypeWarning, "ANOMALY", "Skipping removal of %v out of %v targets from target group %s, which exceeds the removal safety threshold", len(removals), len(current), t.TgArn)
+		deferred = append(deferred, removals...)
+		removals = nil
+	}
 	if len(additions) > 0 {
 		albctx.GetLogger(ctx).Infof("Adding targets to %v: %v", t.TgArn, tdsString(additions))
 		in := &elbv2.RegisterTargetsInput{
@@ -81,6 +118,9 @@ func (c *targetsController) Reconcile(ctx context.Context, t *Targets) error {
 			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error adding targets to target group %s: %s", t.TgArn, err.Error())
 			return err
 		}
+		if t.GracePeriod > 0 {
+			c.recordRegistration(t.TgArn, additions)
+		}
 		// TODO add Add events ?
 	}
 
@@ -96,27 +136,130 @@ func (c *targetsController) Reconcile(ctx context.Context, t *Targets) error {
 			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "Error removing targets from target group %s: %s", t.TgArn, err.Error())
 			return err
 		}
+		if t.GracePeriod > 0 {
+			c.forgetRegistration(t.TgArn, removals)
+		}
 		// TODO add Delete events ?
 	}
-	t.Targets = desired
+	// deferred targets are still registered
+	t.Targets = append(desired, deferred...)
 	return nil
 }
 
-func (c *targetsController) getCurrentTargets(ctx context.Context, TgArn string) ([]*elbv2.TargetDescription, error) {
+func (c *targetsController) Forget(tgArn string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for key := range c.registeredAt {
+		if strings.HasPrefix(key, tgArn+"/") {
+			delete(c.registeredAt, key)
+		}
+	}
+}
+
+// healthyAfterGracePeriod returns the healthy targets of targetGroup except those registered within gracePeriod.
+func (c *targetsController) healthyAfterGracePeriod(tgArn string, healthy sets.String, gracePeriod time.Duration) sets.String {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	// registrations past their grace period are pruned for all targetGroups, as targets may be deregistered without being forgotten.
+	for key, registeredAt := range c.registeredAt {
+		if time.Since(registeredAt) >= gracePeriod {
+			delete(c.registeredAt, key)
+		}
+	}
+	result := sets.NewString()
+	for _, target := range healthy.List() {
+		if _, ok := c.registeredAt[tgArn+"/"+target]; ok {
+			continue
+		}
+		result.Insert(target)
+	}
+	return result
+}
+
+func (c *targetsController) recordRegistration(tgArn string, targets []*elbv2.TargetDescription) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now := time.Now()
+	for _, td := range targets {
+		c.registeredAt[tgArn+"/"+tdString(td)] = now
+	}
+}
+
+func (c *targetsController) forgetRegistration(tgArn string, targets []*elbv2.TargetDescription) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, td := range targets {
+		delete(c.registeredAt, tgArn+"/"+tdString(td))
+	}
+}
+
+// getCurrentTargets returns targets registered to target group, along with the set of healthy ones.
+func (c *targetsController) getCurrentTargets(ctx context.Context, TgArn string) ([]*elbv2.TargetDescription, sets.String, error) {
 	opts := &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(TgArn)}
 	resp, err := c.cloud.DescribeTargetHealthWithContext(ctx, opts)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var current []*elbv2.TargetDescription
+	healthy := sets.NewString()
 	for _, thd := range resp.TargetHealthDescriptions {
 		if aws.StringValue(thd.TargetHealth.State) == elbv2.TargetHealthStateEnumDraining {
 			continue
 		}
+		if aws.StringValue(thd.TargetHealth.State) == elbv2.TargetHealthStateEnumHealthy {
+			healthy.Insert(tdString(thd.Target))
+		}
 		current = append(current, thd.Target)
 	}
-	return current, nil
+	return current, healthy, nil
+}
+
+// deferRemovals splits removals into targets to remove now and targets whose removal is deferred,
+// so that at least minHealthy of the currently healthy targets stay registered. Unhealthy targets are never deferred.
+func deferRemovals(removals []*elbv2.TargetDescription, healthy sets.String, minHealthy int64) (remove []*elbv2.TargetDescription, deferred []*elbv2.TargetDescription) {
+	if minHealthy <= 0 {
+		return removals, nil
+	}
+	removableHealthy := int64(healthy.Len()) - minHealthy
+	for _, td := range removals {
+		if !healthy.Has(tdString(td)) {
+			remove = append(remove, td)
+			continue
+		}
+		if removableHealthy > 0 {
+			remove = append(remove, td)
+			removableHealthy--
+			continue
+		}
+		deferred = append(deferred, td)
+	}
+	return remove, deferred
+}
+
+// confirmRemovals returns the removals confirmed by resolving the endpoints from the API server, and the ones still existing there,
+// since the informer cache can lag behind or miss events after watch errors. Removals are unconfirmed if the API server can't be read.
+func (c *targetsController) confirmRemovals(ctx context.Context, t *Targets, removals []*elbv2.TargetDescription) (confirmed []*elbv2.TargetDescription, unconfirmed []*elbv2.TargetDescription) {
+	if len(removals) == 0 {
+		return nil, nil
+	}
+	live, err := c.endpointResolver.ResolveLive(ctx, t.Ingress, t.Backend, t.TargetType)
+	if err != nil {
+		albctx.GetLogger(ctx).Warnf("Unable to confirm removal of targets from %v with API server: %v", t.TgArn, err.Error())
+		return nil, removals
+	}
+	liveTargets := sets.NewString()
+	for _, td := range live {
+		liveTargets.Insert(tdString(td))
+	}
+	for _, td := range removals {
+		if liveTargets.Has(tdString(td)) {
+			unconfirmed = append(unconfirmed, td)
+		} else {
+			confirmed = append(confirmed, td)
+		}
+	}
+	return confirmed, unconfirmed
 }
 
 // targetChangeSets compares b to a, returning a list of targets to add and remove from a to match b
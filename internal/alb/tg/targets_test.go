@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elbv2"
@@ -14,6 +15,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 func Test_NewTargets(t *testing.T) {
@@ -91,6 +93,7 @@ func Test_TargetsReconcile(t *testing.T) {
 		RegisterTargetsCall      *RegisterTargetsCall
 		DeregisterTargetsCall    *DeregisterTargetsCall
 		ResolveCall              *ResolveCall
+		ResolveLiveCall          *ResolveCall
 		ExpectedError            error
 	}{
 		{
@@ -135,6 +138,11 @@ func Test_TargetsReconcile(t *testing.T) {
 				InputBackend:    backend,
 				InputTargetType: elbv2.TargetTypeEnumInstance,
 			},
+			ResolveLiveCall: &ResolveCall{
+				InputIngress:    dummy.NewIngress(),
+				InputBackend:    backend,
+				InputTargetType: elbv2.TargetTypeEnumInstance,
+			},
 		},
 		{
 			Name:    "deregister a target with error",
@@ -154,6 +162,11 @@ func Test_TargetsReconcile(t *testing.T) {
 				InputBackend:    backend,
 				InputTargetType: elbv2.TargetTypeEnumInstance,
 			},
+			ResolveLiveCall: &ResolveCall{
+				InputIngress:    dummy.NewIngress(),
+				InputBackend:    backend,
+				InputTargetType: elbv2.TargetTypeEnumInstance,
+			},
 			ExpectedError: errors.New("ERROR STRING"),
 		},
 		{
@@ -215,6 +228,81 @@ func Test_TargetsReconcile(t *testing.T) {
 			},
 			ExpectedError: errors.New("ERROR STRING"),
 		},
+		{
+			Name:    "defer removing healthy targets below MinHealthyTargets",
+			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: backend, TargetType: elbv2.TargetTypeEnumInstance, MinHealthyTargets: 2},
+			DescribeTargetHealthCall: &DescribeTargetHealthCall{
+				TgArn: tgArn,
+				Output: &elbv2.DescribeTargetHealthOutput{TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+					{Target: newTd("id", 123), TargetHealth: newTh(elbv2.TargetHealthStateEnumHealthy)},
+					{Target: newTd("id2", 1234), TargetHealth: newTh(elbv2.TargetHealthStateEnumHealthy)},
+					{Target: newTd("id3", 1234), TargetHealth: newTh(elbv2.TargetHealthStateEnumUnhealthy)},
+				}},
+			},
+			DeregisterTargetsCall: &DeregisterTargetsCall{
+				Input: &elbv2.DeregisterTargetsInput{TargetGroupArn: aws.String(tgArn), Targets: []*elbv2.TargetDescription{newTd("id3", 1234)}},
+			},
+			ResolveCall: &ResolveCall{
+				InputIngress:    dummy.NewIngress(),
+				InputBackend:    backend,
+				InputTargetType: elbv2.TargetTypeEnumInstance,
+				Output:          []*elbv2.TargetDescription{newTd("id", 123)},
+			},
+			ResolveLiveCall: &ResolveCall{
+				InputIngress:    dummy.NewIngress(),
+				InputBackend:    backend,
+				InputTargetType: elbv2.TargetTypeEnumInstance,
+				Output:          []*elbv2.TargetDescription{newTd("id", 123)},
+			},
+		},
+		{
+			Name:    "skip removing targets still existing in API server",
+			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: backend, TargetType: elbv2.TargetTypeEnumInstance},
+			DescribeTargetHealthCall: &DescribeTargetHealthCall{
+				TgArn: tgArn,
+				Output: &elbv2.DescribeTargetHealthOutput{TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+					{Target: newTd("id", 123), TargetHealth: newTh(elbv2.TargetHealthStateEnumHealthy)},
+					{Target: newTd("id2", 123), TargetHealth: newTh(elbv2.TargetHealthStateEnumHealthy)},
+					{Target: newTd("id3", 123), TargetHealth: newTh(elbv2.TargetHealthStateEnumHealthy)},
+				}},
+			},
+			DeregisterTargetsCall: &DeregisterTargetsCall{
+				Input: &elbv2.DeregisterTargetsInput{TargetGroupArn: aws.String(tgArn), Targets: []*elbv2.TargetDescription{newTd("id3", 123)}},
+			},
+			ResolveCall: &ResolveCall{
+				InputIngress:    dummy.NewIngress(),
+				InputBackend:    backend,
+				InputTargetType: elbv2.TargetTypeEnumInstance,
+				Output:          []*elbv2.TargetDescription{newTd("id", 123)},
+			},
+			ResolveLiveCall: &ResolveCall{
+				InputIngress:    dummy.NewIngress(),
+				InputBackend:    backend,
+				InputTargetType: elbv2.TargetTypeEnumInstance,
+				Output:          []*elbv2.TargetDescription{newTd("id", 123), newTd("id2", 123)},
+			},
+		},
+		{
+			Name:    "skip removing targets if API server can't be read",
+			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: backend, TargetType: elbv2.TargetTypeEnumInstance},
+			DescribeTargetHealthCall: &DescribeTargetHealthCall{
+				TgArn: tgArn,
+				Output: &elbv2.DescribeTargetHealthOutput{TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+					{Target: newTd("id", 123), TargetHealth: newTh(elbv2.TargetHealthStateEnumHealthy)},
+				}},
+			},
+			ResolveCall: &ResolveCall{
+				InputIngress:    dummy.NewIngress(),
+				InputBackend:    backend,
+				InputTargetType: elbv2.TargetTypeEnumInstance,
+			},
+			ResolveLiveCall: &ResolveCall{
+				InputIngress:    dummy.NewIngress(),
+				InputBackend:    backend,
+				InputTargetType: elbv2.TargetTypeEnumInstance,
+				Err:             errors.New("ERROR STRING"),
+			},
+		},
 	} {
 		t.Run(tc.Name, func(t *testing.T) {
 			ctx := context.Background()
@@ -222,6 +310,9 @@ func Test_TargetsReconcile(t *testing.T) {
 			if tc.ResolveCall != nil {
 				endpointResolver.On("Resolve", tc.ResolveCall.InputIngress, tc.ResolveCall.InputBackend, tc.ResolveCall.InputTargetType).Return(tc.ResolveCall.Output, tc.ResolveCall.Err)
 			}
+			if tc.ResolveLiveCall != nil {
+				endpointResolver.On("ResolveLive", ctx, tc.ResolveLiveCall.InputIngress, tc.ResolveLiveCall.InputBackend, tc.ResolveLiveCall.InputTargetType).Return(tc.ResolveLiveCall.Output, tc.ResolveLiveCall.Err)
+			}
 
 			cloud := &mocks.CloudAPI{}
 			if tc.DescribeTargetHealthCall != nil {
@@ -248,6 +339,31 @@ func Test_TargetsReconcile(t *testing.T) {
 
 	}
 }
+func Test_healthyAfterGracePeriod(t *testing.T) {
+	controller := NewTargetsController(&mocks.CloudAPI{}, &mocks.EndpointResolver{}).(*targetsController)
+	controller.recordRegistration("tgArn", []*elbv2.TargetDescription{newTd("new", 80)})
+	controller.registeredAt["tgArn/old:80"] = time.Now().Add(-2 * time.Minute)
+	controller.registeredAt["deletedTgArn/old:80"] = time.Now().Add(-2 * time.Minute)
+	healthy := sets.NewString("new:80", "old:80", "unknown:80")
+
+	assert.Equal(t, sets.NewString("old:80", "unknown:80"), controller.healthyAfterGracePeriod("tgArn", healthy, time.Minute))
+	assert.Equal(t, sets.NewString("new:80", "old:80", "unknown:80"), controller.healthyAfterGracePeriod("otherTgArn", healthy, time.Minute))
+	assert.NotContains(t, controller.registeredAt, "tgArn/old:80")
+	assert.NotContains(t, controller.registeredAt, "deletedTgArn/old:80")
+
+	controller.forgetRegistration("tgArn", []*elbv2.TargetDescription{newTd("new", 80)})
+	assert.Empty(t, controller.registeredAt)
+}
+
+func Test_targetsForget(t *testing.T) {
+	controller := NewTargetsController(&mocks.CloudAPI{}, &mocks.EndpointResolver{}).(*targetsController)
+	controller.recordRegistration("tgArn", []*elbv2.TargetDescription{newTd("a", 80), newTd("b", 80)})
+	controller.recordRegistration("otherTgArn", []*elbv2.TargetDescription{newTd("a", 80)})
+
+	controller.Forget("tgArn")
+	assert.Equal(t, []string{"otherTgArn/a:80"}, sets.StringKeySet(controller.registeredAt).List())
+}
+
 func Test_targetChangeSets(t *testing.T) {
 	for _, tc := range []struct {
 		name      string
@@ -297,3 +413,51 @@ func Test_tdsString(t *testing.T) {
 
 	}
 }
+
+func Test_deferRemovals(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		removals   []*elbv2.TargetDescription
+		healthy    sets.String
+		minHealthy int64
+		remove     []*elbv2.TargetDescription
+		deferred   []*elbv2.TargetDescription
+	}{
+		{
+			name:       "protection disabled",
+			removals:   []*elbv2.TargetDescription{newTd("a", 80), newTd("b", 80)},
+			healthy:    sets.NewString("a:80", "b:80"),
+			minHealthy: 0,
+			remove:     []*elbv2.TargetDescription{newTd("a", 80), newTd("b", 80)},
+		},
+		{
+			name:       "enough healthy targets remain",
+			removals:   []*elbv2.TargetDescription{newTd("a", 80)},
+			healthy:    sets.NewString("a:80", "b:80", "c:80"),
+			minHealthy: 2,
+			remove:     []*elbv2.TargetDescription{newTd("a", 80)},
+		},
+		{
+			name:       "partially deferred",
+			removals:   []*elbv2.TargetDescription{newTd("a", 80), newTd("b", 80)},
+			healthy:    sets.NewString("a:80", "b:80", "c:80"),
+			minHealthy: 2,
+			remove:     []*elbv2.TargetDescription{newTd("a", 80)},
+			deferred:   []*elbv2.TargetDescription{newTd("b", 80)},
+		},
+		{
+			name:       "unhealthy targets are always removed",
+			removals:   []*elbv2.TargetDescription{newTd("a", 80), newTd("b", 80)},
+			healthy:    sets.NewString("a:80"),
+			minHealthy: 2,
+			remove:     []*elbv2.TargetDescription{newTd("b", 80)},
+			deferred:   []*elbv2.TargetDescription{newTd("a", 80)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			remove, deferred := deferRemovals(tc.removals, tc.healthy, tc.minHealthy)
+			assert.Equal(t, tc.remove, remove)
+			assert.Equal(t, tc.deferred, deferred)
+		})
+	}
+}
@@ -10,6 +10,9 @@ type TargetGroup struct {
 	Arn        string
 	TargetType string
 	Targets    []*elbv2.TargetDescription
+
+	// HealthCheckPort is the resolved health check port, or "traffic-port" if health checks use the target port.
+	HealthCheckPort string
 }
 
 // TargetGroupGroup represents an collection of targetGroups for a single ingress in AWS
@@ -0,0 +1,45 @@
+package albctx
+
+import (
+	"context"
+	"sync"
+)
+
+var contextKeyAuditBefores = contextKey("AuditBefores")
+
+// AuditBefores collects the states of AWS resources before the AWS calls modifying them during a reconcile,
+// so the audit log records them along with the new states. States are keyed by the inputs of the calls.
+type AuditBefores struct {
+	mu      sync.Mutex
+	befores map[interface{}]interface{}
+}
+
+func SetAuditBefores(ctx context.Context, befores *AuditBefores) context.Context {
+	return context.WithValue(ctx, contextKeyAuditBefores, befores)
+}
+
+// RecordAuditBefore records before as the current state of the AWS resource modified by the AWS call with input, which must be a pointer.
+// It's usually shaped like input, and it's a no-op if ctx has no AuditBefores.
+func RecordAuditBefore(ctx context.Context, input interface{}, before interface{}) {
+	if befores, ok := ctx.Value(contextKeyAuditBefores).(*AuditBefores); ok {
+		befores.mu.Lock()
+		defer befores.mu.Unlock()
+		if befores.befores == nil {
+			befores.befores = make(map[interface{}]interface{})
+		}
+		befores.befores[input] = before
+	}
+}
+
+// TakeAuditBefore returns and forgets the state recorded for the AWS call with input, or nil if unknown.
+func TakeAuditBefore(ctx context.Context, input interface{}) interface{} {
+	befores, ok := ctx.Value(contextKeyAuditBefores).(*AuditBefores)
+	if !ok {
+		return nil
+	}
+	befores.mu.Lock()
+	defer befores.mu.Unlock()
+	before := befores.befores[input]
+	delete(befores.befores, input)
+	return before
+}
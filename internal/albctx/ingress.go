@@ -0,0 +1,33 @@
+package albctx
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var contextKeyIngressKey = contextKey("IngressKey")
+
+// SetIngressKey sets the key of the ingress being reconciled, so AWS calls can be attributed to it.
+func SetIngressKey(ctx context.Context, ingressKey types.NamespacedName) context.Context {
+	return context.WithValue(ctx, contextKeyIngressKey, ingressKey)
+}
+
+// GetIngressKey returns the key of the ingress being reconciled, it returns false if ctx isn't reconciling an ingress.
+func GetIngressKey(ctx context.Context) (types.NamespacedName, bool) {
+	ingressKey, ok := ctx.Value(contextKeyIngressKey).(types.NamespacedName)
+	return ingressKey, ok
+}
+
+var contextKeyReconcileID = contextKey("ReconcileID")
+
+// SetReconcileID sets the ID of the reconcile in progress, so AWS calls can be correlated with it.
+func SetReconcileID(ctx context.Context, reconcileID string) context.Context {
+	return context.WithValue(ctx, contextKeyReconcileID, reconcileID)
+}
+
+// GetReconcileID returns the ID of the reconcile in progress, it returns false if ctx isn't reconciling an ingress.
+func GetReconcileID(ctx context.Context) (string, bool) {
+	reconcileID, ok := ctx.Value(contextKeyReconcileID).(string)
+	return reconcileID, ok
+}
@@ -0,0 +1,34 @@
+package albctx
+
+import "context"
+
+var contextKeyRemovalGuard = contextKey("RemovalGuard")
+
+// RemovalGuard protects against reconciles that would remove an anomalous portion of targets or rules,
+// e.g. when the informer cache temporarily misses objects.
+type RemovalGuard struct {
+	// ThresholdPercent is the maximum percentage of current members that can be removed in a single reconcile.
+	// Zero disables the protection.
+	ThresholdPercent int64
+
+	// Confirmed indicates removals beyond ThresholdPercent are explicitly confirmed.
+	Confirmed bool
+}
+
+// Exceeded returns whether removing removals out of current members should be blocked.
+func (g RemovalGuard) Exceeded(current int, removals int) bool {
+	if g.ThresholdPercent <= 0 || g.Confirmed || current == 0 {
+		return false
+	}
+	return int64(removals)*100 > g.ThresholdPercent*int64(current)
+}
+
+func SetRemovalGuard(ctx context.Context, guard RemovalGuard) context.Context {
+	return context.WithValue(ctx, contextKeyRemovalGuard, guard)
+}
+
+// GetRemovalGuard returns the RemovalGuard in ctx, which is disabled if absent.
+func GetRemovalGuard(ctx context.Context) RemovalGuard {
+	guard, _ := ctx.Value(contextKeyRemovalGuard).(RemovalGuard)
+	return guard
+}
@@ -0,0 +1,35 @@
+package albctx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemovalGuard_Exceeded(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		guard    RemovalGuard
+		current  int
+		removals int
+		expected bool
+	}{
+		{name: "disabled", guard: RemovalGuard{}, current: 4, removals: 4, expected: false},
+		{name: "within threshold", guard: RemovalGuard{ThresholdPercent: 50}, current: 4, removals: 2, expected: false},
+		{name: "beyond threshold", guard: RemovalGuard{ThresholdPercent: 50}, current: 4, removals: 3, expected: true},
+		{name: "beyond threshold with confirmation", guard: RemovalGuard{ThresholdPercent: 50, Confirmed: true}, current: 4, removals: 3, expected: false},
+		{name: "nothing to remove from", guard: RemovalGuard{ThresholdPercent: 50}, current: 0, removals: 0, expected: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.guard.Exceeded(tc.current, tc.removals))
+		})
+	}
+}
+
+func TestGetRemovalGuard(t *testing.T) {
+	assert.Equal(t, RemovalGuard{}, GetRemovalGuard(context.Background()))
+
+	guard := RemovalGuard{ThresholdPercent: 50}
+	assert.Equal(t, guard, GetRemovalGuard(SetRemovalGuard(context.Background(), guard)))
+}
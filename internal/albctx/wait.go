@@ -0,0 +1,46 @@
+package albctx
+
+import "context"
+
+var contextKeyWaitRecorder = contextKey("WaitRecorder")
+
+const (
+	// WaitStateProvisioning is the state of LoadBalancers that are not active yet.
+	WaitStateProvisioning = "provisioning"
+
+	// WaitStateInUse is the state of resources that cannot be deleted while still referenced, e.g. targetGroups right after their rules are deleted.
+	WaitStateInUse = "in-use"
+
+	// WaitStateRetained is the state of resources no longer used, which are kept for a retention period before deletion.
+	WaitStateRetained = "retained"
+)
+
+// Wait is an AWS resource in a transitional state, which reconcile needs to revisit once the resource settles.
+type Wait struct {
+	// Resource identifies the resource, e.g. the ARN of LoadBalancer.
+	Resource string
+
+	// State is the transitional state of resource.
+	State string
+}
+
+// WaitRecorder collects the Waits encountered during a reconcile.
+type WaitRecorder struct {
+	waits []Wait
+}
+
+// Waits returns the Waits recorded.
+func (r *WaitRecorder) Waits() []Wait {
+	return r.waits
+}
+
+func SetWaitRecorder(ctx context.Context, recorder *WaitRecorder) context.Context {
+	return context.WithValue(ctx, contextKeyWaitRecorder, recorder)
+}
+
+// RecordWait records resource is in a transitional state into the WaitRecorder in ctx, it's a no-op if ctx has no WaitRecorder.
+func RecordWait(ctx context.Context, resource string, state string) {
+	if recorder, ok := ctx.Value(contextKeyWaitRecorder).(*WaitRecorder); ok {
+		recorder.waits = append(recorder.waits, Wait{Resource: resource, State: state})
+	}
+}
@@ -17,6 +17,16 @@ type ACMAPI interface {
 
 	// ACMAvailable whether ACM service is available
 	ACMAvailable() bool
+
+	// ListIssuedCertificates returns the summaries of all issued certificates.
+	ListIssuedCertificates(ctx context.Context) ([]*acm.CertificateSummary, error)
+
+	RequestCertificateWithContext(context.Context, *acm.RequestCertificateInput) (*acm.RequestCertificateOutput, error)
+	DescribeCertificateWithContext(context.Context, *acm.DescribeCertificateInput) (*acm.DescribeCertificateOutput, error)
+	AddTagsToCertificateWithContext(context.Context, *acm.AddTagsToCertificateInput) (*acm.AddTagsToCertificateOutput, error)
+	DeleteCertificateWithContext(context.Context, *acm.DeleteCertificateInput) (*acm.DeleteCertificateOutput, error)
+	ImportCertificateWithContext(context.Context, *acm.ImportCertificateInput) (*acm.ImportCertificateOutput, error)
+	ListTagsForCertificateWithContext(context.Context, *acm.ListTagsForCertificateInput) (*acm.ListTagsForCertificateOutput, error)
 }
 
 // Status validates ACM connectivity
@@ -38,3 +48,44 @@ func (c *Cloud) ACMAvailable() bool {
 	_, err := resolver.EndpointFor(acm.EndpointsID, c.region)
 	return err == nil
 }
+
+func (c *Cloud) ListIssuedCertificates(ctx context.Context) ([]*acm.CertificateSummary, error) {
+	var summaries []*acm.CertificateSummary
+	err := c.acm.ListCertificatesPagesWithContext(ctx,
+		&acm.ListCertificatesInput{CertificateStatuses: aws.StringSlice([]string{acm.CertificateStatusIssued})},
+		func(p *acm.ListCertificatesOutput, lastPage bool) bool {
+			if p == nil {
+				return false
+			}
+			summaries = append(summaries, p.CertificateSummaryList...)
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+func (c *Cloud) RequestCertificateWithContext(ctx context.Context, i *acm.RequestCertificateInput) (*acm.RequestCertificateOutput, error) {
+	return c.acm.RequestCertificateWithContext(ctx, i)
+}
+
+func (c *Cloud) DescribeCertificateWithContext(ctx context.Context, i *acm.DescribeCertificateInput) (*acm.DescribeCertificateOutput, error) {
+	return c.acm.DescribeCertificateWithContext(ctx, i)
+}
+
+func (c *Cloud) AddTagsToCertificateWithContext(ctx context.Context, i *acm.AddTagsToCertificateInput) (*acm.AddTagsToCertificateOutput, error) {
+	return c.acm.AddTagsToCertificateWithContext(ctx, i)
+}
+
+func (c *Cloud) DeleteCertificateWithContext(ctx context.Context, i *acm.DeleteCertificateInput) (*acm.DeleteCertificateOutput, error) {
+	return c.acm.DeleteCertificateWithContext(ctx, i)
+}
+
+func (c *Cloud) ImportCertificateWithContext(ctx context.Context, i *acm.ImportCertificateInput) (*acm.ImportCertificateOutput, error) {
+	return c.acm.ImportCertificateWithContext(ctx, i)
+}
+
+func (c *Cloud) ListTagsForCertificateWithContext(ctx context.Context, i *acm.ListTagsForCertificateInput) (*acm.ListTagsForCertificateOutput, error) {
+	return c.acm.ListTagsForCertificateWithContext(ctx, i)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/acm"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestCloud_StatusACM(t *testing.T) {
@@ -42,3 +43,52 @@ func TestCloud_StatusACM(t *testing.T) {
 		})
 	}
 }
+
+func TestCloud_ListIssuedCertificates(t *testing.T) {
+	for _, tc := range []struct {
+		Name                   string
+		ListCertificatesOutput *acm.ListCertificatesOutput
+		ListCertificatesError  error
+		ExpectedSummaries      []*acm.CertificateSummary
+		ExpectedError          error
+	}{
+		{
+			Name: "No error from API call",
+			ListCertificatesOutput: &acm.ListCertificatesOutput{
+				CertificateSummaryList: []*acm.CertificateSummary{
+					{CertificateArn: aws.String("arn"), DomainName: aws.String("example.com")},
+				},
+			},
+			ExpectedSummaries: []*acm.CertificateSummary{
+				{CertificateArn: aws.String("arn"), DomainName: aws.String("example.com")},
+			},
+		},
+		{
+			Name:                  "Error from API call",
+			ListCertificatesError: errors.New("Some API error"),
+			ExpectedError:         errors.New("Some API error"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			acmsvc := &mocks.ACMAPI{}
+			acmsvc.On("ListCertificatesPagesWithContext",
+				ctx,
+				&acm.ListCertificatesInput{CertificateStatuses: aws.StringSlice([]string{acm.CertificateStatusIssued})},
+				mock.AnythingOfType("func(*acm.ListCertificatesOutput, bool) bool"),
+			).Return(tc.ListCertificatesError).Run(func(args mock.Arguments) {
+				arg := args.Get(2).(func(*acm.ListCertificatesOutput, bool) bool)
+				arg(tc.ListCertificatesOutput, false)
+			})
+
+			cloud := &Cloud{
+				acm: acmsvc,
+			}
+
+			summaries, err := cloud.ListIssuedCertificates(ctx)
+			assert.Equal(t, tc.ExpectedSummaries, summaries)
+			assert.Equal(t, tc.ExpectedError, err)
+			acmsvc.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// regionPattern matches region names, like "us-west-2", "cn-north-1" or "us-gov-west-1".
+var regionPattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d+$`)
+
+// ARNAPI validates ARNs against the partition of the region of controller.
+type ARNAPI interface {
+	// ValidateARN returns an error unless arn is a valid ARN in the partition of the region of controller.
+	ValidateARN(arn string) error
+}
+
+// ARN is an Amazon Resource Name, like "arn:aws-cn:elasticloadbalancing:cn-north-1:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188".
+type ARN struct {
+	Partition string
+	Service   string
+	Region    string
+	AccountID string
+	// Resource is the remainder of ARN, which may contain colons and slashes, like "loadbalancer/app/my-lb/50dc6c495c0c9188".
+	Resource string
+}
+
+// ParseARN parses arn of any partition.
+func ParseARN(arn string) (ARN, error) {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[1] == "" || parts[2] == "" || parts[5] == "" {
+		return ARN{}, fmt.Errorf("invalid ARN %v", arn)
+	}
+	return ARN{
+		Partition: parts[1],
+		Service:   parts[2],
+		Region:    parts[3],
+		AccountID: parts[4],
+		Resource:  parts[5],
+	}, nil
+}
+
+// ValidateARN returns an error unless arn is a valid ARN in the partition of the region of controller.
+func (c *Cloud) ValidateARN(arn string) error {
+	return validatePartition(arn, c.region)
+}
+
+// validatePartition returns an error unless arn is a valid ARN in the partition of region.
+func validatePartition(arn string, region string) error {
+	parsed, err := ParseARN(arn)
+	if err != nil {
+		return err
+	}
+	if expected := partition(region); parsed.Partition != expected {
+		return fmt.Errorf("ARN %v is in partition %v, but region %v is in partition %v", arn, parsed.Partition, region, expected)
+	}
+	return nil
+}
+
+// validateRegion returns an error unless region is a valid region name.
+func validateRegion(region string) error {
+	if !regionPattern.MatchString(region) {
+		return fmt.Errorf("invalid region %v, region must be like us-west-2, cn-north-1 or us-gov-west-1", region)
+	}
+	return nil
+}
+
+// partition returns the AWS partition of region.
+func partition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	case strings.HasPrefix(region, "us-iso-"):
+		return "aws-iso"
+	case strings.HasPrefix(region, "us-isob-"):
+		return "aws-iso-b"
+	default:
+		return "aws"
+	}
+}
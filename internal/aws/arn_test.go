@@ -0,0 +1,124 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseARN(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		arn         string
+		expected    ARN
+		expectedErr string
+	}{
+		{
+			name: "loadBalancer in GovCloud",
+			arn:  "arn:aws-us-gov:elasticloadbalancing:us-gov-west-1:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188",
+			expected: ARN{
+				Partition: "aws-us-gov",
+				Service:   "elasticloadbalancing",
+				Region:    "us-gov-west-1",
+				AccountID: "123456789012",
+				Resource:  "loadbalancer/app/my-lb/50dc6c495c0c9188",
+			},
+		},
+		{
+			name: "role without region",
+			arn:  "arn:aws-cn:iam::123456789012:role/alb",
+			expected: ARN{
+				Partition: "aws-cn",
+				Service:   "iam",
+				AccountID: "123456789012",
+				Resource:  "role/alb",
+			},
+		},
+		{
+			name: "resource with colons",
+			arn:  "arn:aws:sns:us-west-2:123456789012:topic:subscription",
+			expected: ARN{
+				Partition: "aws",
+				Service:   "sns",
+				Region:    "us-west-2",
+				AccountID: "123456789012",
+				Resource:  "topic:subscription",
+			},
+		},
+		{
+			name:        "not an ARN",
+			arn:         "subnet-1234",
+			expectedErr: "invalid ARN subnet-1234",
+		},
+		{
+			name:        "missing resource",
+			arn:         "arn:aws:iam::123456789012:",
+			expectedErr: "invalid ARN arn:aws:iam::123456789012:",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			arn, err := ParseARN(tc.arn)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, arn)
+			}
+		})
+	}
+}
+
+func Test_validatePartition(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		arn         string
+		region      string
+		expectedErr string
+	}{
+		{
+			name:   "commercial",
+			arn:    "arn:aws:acm-pca:us-west-2:123456789012:certificate-authority/ca",
+			region: "us-west-2",
+		},
+		{
+			name:   "China",
+			arn:    "arn:aws-cn:iam::123456789012:role/alb",
+			region: "cn-northwest-1",
+		},
+		{
+			name:   "GovCloud",
+			arn:    "arn:aws-us-gov:iam::123456789012:role/alb",
+			region: "us-gov-east-1",
+		},
+		{
+			name:        "commercial ARN in China",
+			arn:         "arn:aws:iam::123456789012:role/alb",
+			region:      "cn-north-1",
+			expectedErr: "ARN arn:aws:iam::123456789012:role/alb is in partition aws, but region cn-north-1 is in partition aws-cn",
+		},
+		{
+			name:        "invalid ARN",
+			arn:         "alb",
+			region:      "us-west-2",
+			expectedErr: "invalid ARN alb",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePartition(tc.arn, tc.region)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_validateRegion(t *testing.T) {
+	for _, region := range []string{"us-west-2", "cn-north-1", "us-gov-west-1", "ap-southeast-3", "us-isob-east-1"} {
+		assert.NoError(t, validateRegion(region), region)
+	}
+	for _, region := range []string{"", "us-west", "US-WEST-2", "uswest2", "us-west-2a"} {
+		assert.EqualError(t, validateRegion(region), "invalid region "+region+", region must be like us-west-2, cn-north-1 or us-gov-west-1", region)
+	}
+}
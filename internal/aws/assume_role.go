@@ -0,0 +1,98 @@
+package aws
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// roleSessionNamePrefix is the prefix of role session names, identifying the controller in CloudTrail.
+	roleSessionNamePrefix = "alb-ingress"
+	// roleSessionNameMaxLength is the maximum length of role session names accepted by AssumeRole.
+	roleSessionNameMaxLength = 64
+	// roleSessionNameHashLength is the length of the hash appended to truncated role session names.
+	roleSessionNameHashLength = 8
+)
+
+// invalidRoleSessionNameChars matches the characters not allowed in role session names.
+var invalidRoleSessionNameChars = regexp.MustCompile(`[^\w+=,.@-]`)
+
+// roleCredentials provides the credentials of the role assumed by controller.
+// Requests made while reconciling an ingress can use a role session per ingress, so CloudTrail attributes them to the ingress.
+type roleCredentials struct {
+	provider    client.ConfigProvider
+	roleArn     string
+	externalID  string
+	clusterName string
+
+	// defaultCredentials are the credentials of the role session for the cluster, used by requests not made for an ingress.
+	defaultCredentials *credentials.Credentials
+
+	mutex     sync.Mutex
+	byIngress map[types.NamespacedName]*credentials.Credentials
+}
+
+// newRoleCredentials creates roleCredentials assuming roleArn with the base credentials of provider.
+func newRoleCredentials(provider client.ConfigProvider, roleArn string, externalID string, clusterName string) *roleCredentials {
+	c := &roleCredentials{
+		provider:    provider,
+		roleArn:     roleArn,
+		externalID:  externalID,
+		clusterName: clusterName,
+		byIngress:   make(map[types.NamespacedName]*credentials.Credentials),
+	}
+	c.defaultCredentials = c.newCredentials(roleSessionName(clusterName))
+	return c
+}
+
+func (c *roleCredentials) newCredentials(sessionName string) *credentials.Credentials {
+	return stscreds.NewCredentials(c.provider, c.roleArn, func(p *stscreds.AssumeRoleProvider) {
+		p.RoleSessionName = sessionName
+		if c.externalID != "" {
+			p.ExternalID = aws.String(c.externalID)
+		}
+	})
+}
+
+// forIngress returns the credentials of the role session for ingress, the role is assumed on first use.
+func (c *roleCredentials) forIngress(ingressKey types.NamespacedName) *credentials.Credentials {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if creds, ok := c.byIngress[ingressKey]; ok {
+		return creds
+	}
+	creds := c.newCredentials(roleSessionName(c.clusterName, ingressKey.Namespace, ingressKey.Name))
+	c.byIngress[ingressKey] = creds
+	return creds
+}
+
+// signWithIngressCredentials is a request handler making requests for an ingress use the credentials of its role session.
+// It must run before requests are signed.
+func (c *roleCredentials) signWithIngressCredentials(r *request.Request) {
+	if ingressKey, ok := albctx.GetIngressKey(r.Context()); ok {
+		r.Config.Credentials = c.forIngress(ingressKey)
+	}
+}
+
+// roleSessionName joins parts into a valid role session name prefixed with roleSessionNamePrefix.
+// Names too long are truncated, with a hash of the full name appended to keep them distinct.
+func roleSessionName(parts ...string) string {
+	name := strings.Join(append([]string{roleSessionNamePrefix}, parts...), ".")
+	name = invalidRoleSessionNameChars.ReplaceAllString(name, "-")
+	if len(name) <= roleSessionNameMaxLength {
+		return name
+	}
+	hash := sha256.Sum256([]byte(name))
+	return name[:roleSessionNameMaxLength-roleSessionNameHashLength-1] + "." + hex.EncodeToString(hash[:])[:roleSessionNameHashLength]
+}
@@ -0,0 +1,68 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_roleSessionName(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		parts    []string
+		expected string
+	}{
+		{
+			name:     "cluster",
+			parts:    []string{"cluster"},
+			expected: "alb-ingress.cluster",
+		},
+		{
+			name:     "ingress",
+			parts:    []string{"cluster", "namespace", "ingress"},
+			expected: "alb-ingress.cluster.namespace.ingress",
+		},
+		{
+			name:     "invalid characters replaced",
+			parts:    []string{"my cluster/1"},
+			expected: "alb-ingress.my-cluster-1",
+		},
+		{
+			name:     "truncated with hash",
+			parts:    []string{"cluster", "a-very-long-namespace-name", "a-very-long-ingress-name"},
+			expected: "alb-ingress.cluster.a-very-long-namespace-name.a-very-l.4142fc5c",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, roleSessionName(tc.parts...))
+		})
+	}
+}
+
+func TestRoleCredentials_signWithIngressCredentials(t *testing.T) {
+	sess := session.Must(session.NewSession(&aws.Config{Region: aws.String("us-west-2")}))
+	c := newRoleCredentials(sess, "arn:aws:iam::123456789012:role/alb", "", "cluster")
+	ingressKey := types.NamespacedName{Namespace: "namespace", Name: "ingress"}
+
+	newRequest := func(ctx context.Context) *request.Request {
+		r := &request.Request{Config: aws.Config{Credentials: c.defaultCredentials}, HTTPRequest: &http.Request{}}
+		r.SetContext(ctx)
+		return r
+	}
+
+	r := newRequest(context.Background())
+	c.signWithIngressCredentials(r)
+	assert.Equal(t, c.defaultCredentials, r.Config.Credentials)
+
+	r = newRequest(albctx.SetIngressKey(context.Background(), ingressKey))
+	c.signWithIngressCredentials(r)
+	assert.Equal(t, c.forIngress(ingressKey), r.Config.Credentials)
+	assert.NotEqual(t, c.defaultCredentials, r.Config.Credentials)
+}
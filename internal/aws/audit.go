@@ -0,0 +1,293 @@
+package aws
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+const (
+	// auditFlushInterval is the interval at which audit records are sent to remote sinks.
+	auditFlushInterval = 10 * time.Second
+	// auditMaxPending is the maximum number of audit records kept for remote sinks, the oldest records are dropped beyond it.
+	auditMaxPending = 10000
+	// redactedValue replaces the values of sensitive fields in audit records.
+	redactedValue = "REDACTED"
+)
+
+// readOnlyOperationPrefixes are the prefixes of AWS operations that don't modify resources, which aren't audited.
+var readOnlyOperationPrefixes = []string{"Describe", "Get", "List", "Head"}
+
+// sensitiveFields are the names of fields redacted from audit records, in addition to those tagged as sensitive by the SDK.
+var sensitiveFields = map[string]bool{
+	"ClientSecret": true,
+	"PrivateKey":   true,
+}
+
+// AuditRecord records an AWS call modifying resources.
+type AuditRecord struct {
+	Time        time.Time `json:"time"`
+	Cluster     string    `json:"cluster"`
+	Ingress     string    `json:"ingress,omitempty"`
+	ReconcileID string    `json:"reconcileID,omitempty"`
+	Service     string    `json:"service"`
+	Operation   string    `json:"operation"`
+	RequestID   string    `json:"requestID,omitempty"`
+	// Before is the state of the resource before the call, if known.
+	Before interface{} `json:"before,omitempty"`
+	// After is the input of the call, i.e. the requested state of the resource.
+	After interface{} `json:"after"`
+	// Response is the output of the call, e.g. with the ARN of created resources.
+	Response interface{} `json:"response,omitempty"`
+	Error    string      `json:"error,omitempty"`
+
+	// seq orders the records of an AuditLog.
+	seq uint64
+}
+
+// AuditSink receives audit records in batches, e.g. to store them remotely.
+type AuditSink interface {
+	// Name identifies the sink in logs.
+	Name() string
+	// Write stores records in order, and returns the number of records stored, even if it fails.
+	Write(records []AuditRecord) (int, error)
+}
+
+// AuditLog writes an append-only trail of the AWS calls modifying resources as JSON lines, and sends them to sinks.
+// Records are written synchronously, while sinks receive them in batches every auditFlushInterval and on Flush.
+type AuditLog struct {
+	clusterName string
+	out         io.Writer
+	clock       clock.Clock
+
+	mu      sync.Mutex
+	sinks   []AuditSink
+	pending []AuditRecord
+	seq     uint64
+	// flushMu serializes flushes, so that sinks receive records in order.
+	flushMu sync.Mutex
+}
+
+// NewAuditLog constructs an AuditLog writing records of clusterName to out.
+func NewAuditLog(out io.Writer, clusterName string) *AuditLog {
+	return &AuditLog{
+		clusterName: clusterName,
+		out:         out,
+		clock:       clock.RealClock{},
+	}
+}
+
+// AddSink adds a sink receiving all records written after it's added.
+func (l *AuditLog) AddSink(sink AuditSink) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sinks = append(l.sinks, sink)
+}
+
+// addHandlers records the AWS calls modifying resources made with sess into l.
+func (l *AuditLog) addHandlers(sess *session.Session) {
+	sess.Handlers.Complete.PushBack(l.recordRequest)
+}
+
+// recordRequest is a request handler recording r if it modifies resources.
+func (l *AuditLog) recordRequest(r *request.Request) {
+	if !isMutation(r.Operation.Name) {
+		return
+	}
+	record := AuditRecord{
+		Time:      l.clock.Now(),
+		Cluster:   l.clusterName,
+		Service:   r.ClientInfo.ServiceName,
+		Operation: r.Operation.Name,
+		RequestID: r.RequestID,
+		Before:    redact(albctx.TakeAuditBefore(r.Context(), r.Params)),
+		After:     redact(r.Params),
+	}
+	if ingressKey, ok := albctx.GetIngressKey(r.Context()); ok {
+		record.Ingress = ingressKey.String()
+	}
+	if reconcileID, ok := albctx.GetReconcileID(r.Context()); ok {
+		record.ReconcileID = reconcileID
+	}
+	if r.Error != nil {
+		record.Error = r.Error.Error()
+	} else {
+		record.Response = redact(r.Data)
+	}
+	l.record(record)
+}
+
+// record writes record, and keeps it for sinks if there are any.
+func (l *AuditLog) record(record AuditRecord) {
+	line, err := json.Marshal(record)
+	if err != nil {
+		glog.Errorf("failed to encode audit record of %v/%v due to %v", record.Service, record.Operation, err)
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.seq++
+	record.seq = l.seq
+	if _, err := l.out.Write(append(line, '\n')); err != nil {
+		glog.Errorf("failed to write audit record of %v/%v due to %v", record.Service, record.Operation, err)
+	}
+	if len(l.sinks) == 0 {
+		return
+	}
+	l.pending = append(l.pending, record)
+	if dropped := len(l.pending) - auditMaxPending; dropped > 0 {
+		glog.Errorf("dropping %v audit records not sent to sinks, more than %v are pending", dropped, auditMaxPending)
+		l.pending = l.pending[dropped:]
+	}
+}
+
+// Start sends pending records to sinks every auditFlushInterval until stop is closed.
+func (l *AuditLog) Start(stop <-chan struct{}) error {
+	ticker := time.NewTicker(auditFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			l.Flush()
+			return nil
+		case <-ticker.C:
+			l.Flush()
+		}
+	}
+}
+
+// Flush sends pending records to sinks. Records not stored by all sinks are kept pending for the next flush,
+// so sinks that stored them already may receive them again.
+func (l *AuditLog) Flush() {
+	l.flushMu.Lock()
+	defer l.flushMu.Unlock()
+	l.mu.Lock()
+	records := l.pending
+	sinks := l.sinks
+	l.mu.Unlock()
+	if len(records) == 0 {
+		return
+	}
+
+	stored := len(records)
+	for _, sink := range sinks {
+		n, err := sink.Write(records)
+		if err != nil {
+			glog.Errorf("failed to send audit records to %v due to %v", sink.Name(), err)
+		}
+		if n < stored {
+			stored = n
+		}
+	}
+	if stored == 0 {
+		return
+	}
+
+	// records may have been recorded or dropped during the flush, so stored records are found by their seq.
+	lastSeq := records[stored-1].seq
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := 0
+	for n < len(l.pending) && l.pending[n].seq <= lastSeq {
+		n++
+	}
+	l.pending = l.pending[n:]
+}
+
+// isMutation returns whether AWS operation can modify resources.
+func isMutation(operation string) bool {
+	for _, prefix := range readOnlyOperationPrefixes {
+		if strings.HasPrefix(operation, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// redact returns a copy of v without the values of sensitive fields, e.g. private keys of imported certificates.
+func redact(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return redactValue(reflect.ValueOf(v)).Interface()
+}
+
+func redactValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(redactValue(v.Elem()))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(redactValue(v.Elem()))
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			if field.Tag.Get("sensitive") == "true" || sensitiveFields[field.Name] {
+				out.Field(i).Set(redactedField(v.Field(i)))
+				continue
+			}
+			out.Field(i).Set(redactValue(v.Field(i)))
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8 {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(redactValue(v.Index(i)))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMap(v.Type())
+		for _, key := range v.MapKeys() {
+			out.SetMapIndex(key, redactValue(v.MapIndex(key)))
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// redactedField returns the value replacing sensitive field v, which is redactedValue for strings, or zero for other types.
+func redactedField(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return v
+	}
+	switch {
+	case v.Kind() == reflect.String:
+		return reflect.ValueOf(redactedValue).Convert(v.Type())
+	case v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.String:
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(reflect.ValueOf(redactedValue).Convert(v.Type().Elem()))
+		return out
+	default:
+		return reflect.Zero(v.Type())
+	}
+}
@@ -0,0 +1,187 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+// putLogEventsMaxEvents is the maximum number of log events accepted by a PutLogEvents call.
+const putLogEventsMaxEvents = 1000
+
+// cloudWatchLogsAuditSink stores audit records as the log events of a log stream per controller instance.
+type cloudWatchLogsAuditSink struct {
+	logs      cloudwatchlogsiface.CloudWatchLogsAPI
+	logGroup  string
+	logStream string
+
+	streamReady   bool
+	sequenceToken *string
+}
+
+// newCloudWatchLogsAuditSink creates an AuditSink storing the audit records of clusterName into logGroup, which must exist.
+func newCloudWatchLogsAuditSink(provider client.ConfigProvider, cfg *aws.Config, logGroup string, clusterName string) AuditSink {
+	return &cloudWatchLogsAuditSink{
+		logs:      cloudwatchlogs.New(provider, cfg),
+		logGroup:  logGroup,
+		logStream: path.Join(clusterName, auditInstanceName()),
+	}
+}
+
+func (s *cloudWatchLogsAuditSink) Name() string {
+	return fmt.Sprintf("log group %v", s.logGroup)
+}
+
+func (s *cloudWatchLogsAuditSink) Write(records []AuditRecord) (int, error) {
+	if !s.streamReady {
+		if err := s.prepareStream(); err != nil {
+			return 0, err
+		}
+	}
+
+	stored := 0
+	for stored < len(records) {
+		chunk := records[stored:]
+		if len(chunk) > putLogEventsMaxEvents {
+			chunk = chunk[:putLogEventsMaxEvents]
+		}
+		events := make([]*cloudwatchlogs.InputLogEvent, 0, len(chunk))
+		for _, record := range chunk {
+			line, err := json.Marshal(record)
+			if err != nil {
+				return stored, err
+			}
+			events = append(events, &cloudwatchlogs.InputLogEvent{
+				Message:   aws.String(string(line)),
+				Timestamp: aws.Int64(record.Time.UnixNano() / 1e6),
+			})
+		}
+		resp, err := s.logs.PutLogEvents(&cloudwatchlogs.PutLogEventsInput{
+			LogGroupName:  aws.String(s.logGroup),
+			LogStreamName: aws.String(s.logStream),
+			LogEvents:     events,
+			SequenceToken: s.sequenceToken,
+		})
+		if err != nil {
+			if aerr, ok := err.(awserr.Error); ok {
+				switch aerr.Code() {
+				case cloudwatchlogs.ErrCodeInvalidSequenceTokenException, cloudwatchlogs.ErrCodeDataAlreadyAcceptedException:
+					// the sequence token is fetched again by the next write.
+					s.streamReady = false
+				}
+			}
+			return stored, fmt.Errorf("failed to put log events into %v due to %v", s.logStream, err)
+		}
+		s.sequenceToken = resp.NextSequenceToken
+		stored += len(chunk)
+	}
+	return stored, nil
+}
+
+// prepareStream creates the log stream if it doesn't exist, and fetches its sequence token.
+func (s *cloudWatchLogsAuditSink) prepareStream() error {
+	_, err := s.logs.CreateLogStream(&cloudwatchlogs.CreateLogStreamInput{
+		LogGroupName:  aws.String(s.logGroup),
+		LogStreamName: aws.String(s.logStream),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != cloudwatchlogs.ErrCodeResourceAlreadyExistsException {
+			return fmt.Errorf("failed to create log stream %v due to %v", s.logStream, err)
+		}
+	}
+
+	resp, err := s.logs.DescribeLogStreams(&cloudwatchlogs.DescribeLogStreamsInput{
+		LogGroupName:        aws.String(s.logGroup),
+		LogStreamNamePrefix: aws.String(s.logStream),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe log stream %v due to %v", s.logStream, err)
+	}
+	s.sequenceToken = nil
+	for _, stream := range resp.LogStreams {
+		if aws.StringValue(stream.LogStreamName) == s.logStream {
+			s.sequenceToken = stream.UploadSequenceToken
+		}
+	}
+	s.streamReady = true
+	return nil
+}
+
+// s3AuditSink stores each batch of audit records as an object of JSON lines.
+type s3AuditSink struct {
+	s3     s3iface.S3API
+	bucket string
+	prefix string
+}
+
+// newS3AuditSink creates an AuditSink storing the audit records of clusterName under uri, formatted as s3://bucket/prefix.
+func newS3AuditSink(provider client.ConfigProvider, cfg *aws.Config, uri string, clusterName string) (AuditSink, error) {
+	bucket, prefix, err := parseS3URI(uri)
+	if err != nil {
+		return nil, err
+	}
+	return &s3AuditSink{
+		s3:     s3.New(provider, cfg),
+		bucket: bucket,
+		prefix: path.Join(prefix, clusterName, auditInstanceName()),
+	}, nil
+}
+
+func (s *s3AuditSink) Name() string {
+	return fmt.Sprintf("bucket %v", s.bucket)
+}
+
+func (s *s3AuditSink) Write(records []AuditRecord) (int, error) {
+	var body bytes.Buffer
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return 0, err
+		}
+		body.Write(line)
+		body.WriteByte('\n')
+	}
+
+	first := records[0].Time.UTC()
+	key := path.Join(s.prefix, first.Format("2006/01/02"), first.Format("20060102T150405.000000000Z")+".jsonl")
+	_, err := s.s3.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(body.Bytes()),
+		ContentType: aws.String("application/x-ndjson"),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to put object %v due to %v", key, err)
+	}
+	return len(records), nil
+}
+
+// parseS3URI returns the bucket and key prefix of uri, formatted as s3://bucket/prefix.
+func parseS3URI(uri string) (string, string, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "s3" || u.Host == "" {
+		return "", "", fmt.Errorf("invalid S3 URI %v, it must be formatted as s3://bucket/prefix", uri)
+	}
+	return u.Host, strings.Trim(u.Path, "/"), nil
+}
+
+// auditInstanceName identifies the controller instance writing audit records, so replicas don't write to the same log stream.
+func auditInstanceName() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return "unknown"
+	}
+	return hostname
+}
@@ -0,0 +1,159 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+type fakeAuditSink struct {
+	stored []AuditRecord
+	max    int
+}
+
+func (s *fakeAuditSink) Name() string {
+	return "fake"
+}
+
+func (s *fakeAuditSink) Write(records []AuditRecord) (int, error) {
+	if len(records) > s.max {
+		s.stored = append(s.stored, records[:s.max]...)
+		return s.max, errors.New("full")
+	}
+	s.stored = append(s.stored, records...)
+	return len(records), nil
+}
+
+func Test_isMutation(t *testing.T) {
+	for _, tc := range []struct {
+		operation string
+		expected  bool
+	}{
+		{operation: "DescribeLoadBalancers", expected: false},
+		{operation: "GetResources", expected: false},
+		{operation: "ListCertificates", expected: false},
+		{operation: "HeadBucket", expected: false},
+		{operation: "CreateLoadBalancer", expected: true},
+		{operation: "ModifyRule", expected: true},
+		{operation: "SetSubnets", expected: true},
+		{operation: "DeleteTargetGroup", expected: true},
+	} {
+		t.Run(tc.operation, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isMutation(tc.operation))
+		})
+	}
+}
+
+func Test_redact(t *testing.T) {
+	input := &acm.ImportCertificateInput{
+		Certificate: []byte("certificate"),
+		PrivateKey:  []byte("key"),
+	}
+	redacted := redact(input).(*acm.ImportCertificateInput)
+	assert.Equal(t, []byte("certificate"), redacted.Certificate)
+	assert.Nil(t, redacted.PrivateKey)
+	assert.Equal(t, []byte("key"), input.PrivateKey)
+
+	action := &elbv2.CreateRuleInput{
+		Actions: []*elbv2.Action{
+			{
+				Type: aws.String(elbv2.ActionTypeEnumAuthenticateOidc),
+				AuthenticateOidcConfig: &elbv2.AuthenticateOidcActionConfig{
+					ClientId:     aws.String("client"),
+					ClientSecret: aws.String("secret"),
+				},
+			},
+		},
+	}
+	redactedAction := redact(action).(*elbv2.CreateRuleInput)
+	assert.Equal(t, "client", aws.StringValue(redactedAction.Actions[0].AuthenticateOidcConfig.ClientId))
+	assert.Equal(t, redactedValue, aws.StringValue(redactedAction.Actions[0].AuthenticateOidcConfig.ClientSecret))
+	assert.Equal(t, "secret", aws.StringValue(action.Actions[0].AuthenticateOidcConfig.ClientSecret))
+
+	assert.Nil(t, redact(nil))
+}
+
+func TestAuditLog_recordRequest(t *testing.T) {
+	var out bytes.Buffer
+	now := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	l := NewAuditLog(&out, "cluster")
+	l.clock = clock.NewFakeClock(now)
+
+	ctx := albctx.SetIngressKey(context.Background(), types.NamespacedName{Namespace: "namespace", Name: "ingress"})
+	ctx = albctx.SetReconcileID(ctx, "abcd1234")
+	ctx = albctx.SetAuditBefores(ctx, &albctx.AuditBefores{})
+	in := &elbv2.SetSubnetsInput{LoadBalancerArn: aws.String("lbArn"), Subnets: aws.StringSlice([]string{"subnet-b"})}
+	albctx.RecordAuditBefore(ctx, in, &elbv2.SetSubnetsInput{LoadBalancerArn: aws.String("lbArn"), Subnets: aws.StringSlice([]string{"subnet-a"})})
+
+	for _, op := range []string{"DescribeLoadBalancers", "SetSubnets"} {
+		r := &request.Request{
+			ClientInfo: metadata.ClientInfo{ServiceName: "elasticloadbalancing"},
+			Operation:  &request.Operation{Name: op},
+			Params:     in,
+			Data:       &elbv2.SetSubnetsOutput{},
+			RequestID:  "requestID",
+		}
+		r.SetContext(ctx)
+		l.recordRequest(r)
+	}
+
+	var record AuditRecord
+	assert.Equal(t, 1, bytes.Count(out.Bytes(), []byte("\n")))
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	assert.True(t, now.Equal(record.Time))
+	assert.Equal(t, "cluster", record.Cluster)
+	assert.Equal(t, "namespace/ingress", record.Ingress)
+	assert.Equal(t, "abcd1234", record.ReconcileID)
+	assert.Equal(t, "elasticloadbalancing", record.Service)
+	assert.Equal(t, "SetSubnets", record.Operation)
+	assert.Equal(t, "requestID", record.RequestID)
+	assert.Empty(t, record.Error)
+	before, after := &elbv2.SetSubnetsInput{}, &elbv2.SetSubnetsInput{}
+	remarshal(t, record.Before, before)
+	remarshal(t, record.After, after)
+	assert.Equal(t, []string{"subnet-a"}, aws.StringValueSlice(before.Subnets))
+	assert.Equal(t, []string{"subnet-b"}, aws.StringValueSlice(after.Subnets))
+	assert.Nil(t, albctx.TakeAuditBefore(ctx, in))
+}
+
+func remarshal(t *testing.T, in interface{}, out interface{}) {
+	b, err := json.Marshal(in)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(b, out))
+}
+
+func TestAuditLog_Flush(t *testing.T) {
+	var out bytes.Buffer
+	l := NewAuditLog(&out, "cluster")
+	sink := &fakeAuditSink{max: 2}
+	l.AddSink(sink)
+	for _, op := range []string{"CreateTargetGroup", "CreateLoadBalancer", "CreateListener"} {
+		l.record(AuditRecord{Operation: op})
+	}
+
+	l.Flush()
+	assert.Len(t, sink.stored, 2)
+	assert.Len(t, l.pending, 1)
+	assert.Equal(t, "CreateListener", l.pending[0].Operation)
+
+	sink.max = 10
+	l.record(AuditRecord{Operation: "CreateRule"})
+	l.Flush()
+	assert.Len(t, sink.stored, 4)
+	assert.Equal(t, "CreateRule", sink.stored[3].Operation)
+	assert.Empty(t, l.pending)
+	assert.Equal(t, 4, bytes.Count(out.Bytes(), []byte("\n")))
+}
@@ -2,11 +2,15 @@ package aws
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/acm"
 	"github.com/aws/aws-sdk-go/service/acm/acmiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/elbv2"
@@ -15,18 +19,30 @@ import (
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/wafregional"
 	"github.com/aws/aws-sdk-go/service/wafregional/wafregionaliface"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/diagnostics"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
 	"github.com/ticketmaster/aws-sdk-go-cache/cache"
+	"k8s.io/apimachinery/pkg/util/clock"
 )
 
 type CloudAPI interface {
 	ACMAPI
+	ARNAPI
+	CacheAPI
+	CloudWatchAPI
 	EC2API
 	ELBV2API
 	IAMAPI
+	InventoryAPI
 	ResourceGroupsTaggingAPIAPI
+	Route53API
+	S3API
 	WAFRegionalAPI
 }
 
@@ -36,19 +52,64 @@ type Cloud struct {
 	clusterName string
 
 	acm         acmiface.ACMAPI
+	cloudwatch  cloudwatchiface.CloudWatchAPI
 	ec2         ec2iface.EC2API
 	elbv2       elbv2iface.ELBV2API
 	iam         iamiface.IAMAPI
 	rgt         resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+	route53     route53iface.Route53API
+	s3          s3iface.S3API
 	wafregional wafregionaliface.WAFRegionalAPI
+
+	cache *cache.Config
+	zones *zoneInventory
+
+	// targetGroups, listeners, rules and securityGroups cache the resources described through Cloud.
+	targetGroups   *describeCache
+	listeners      *describeCache
+	rules          *describeCache
+	securityGroups *describeCache
+	// loadBalancers indexes all LoadBalancers of the region, so they're not described for each ingress.
+	loadBalancers *loadBalancerInventory
+	// inventory is the state of RefreshInventory.
+	inventory clusterInventory
 }
 
 // Initialize the global AWS clients.
 // But due to huge number of aws clients, it's best to have one container AWS client that embed these aws clients.
 // TODO: remove clusterName dependency
 // TODO: remove mc dependency like https://github.com/kubernetes/kubernetes/blob/master/pkg/cloudprovider/providers/aws/aws_metrics.go
-func New(cfg CloudConfig, clusterName string, mc metric.Collector, cc *cache.Config) (CloudAPI, error) {
-	awsSession := NewSession(&aws.Config{MaxRetries: aws.Int(cfg.APIMaxRetries)}, cfg.APIDebug, mc, cc)
+// The AWS calls modifying resources are recorded into auditLog, unless it's nil.
+func New(cfg CloudConfig, clusterName string, mc metric.Collector, cc *cache.Config, diag *diagnostics.Diagnostics, auditLog *AuditLog) (CloudAPI, error) {
+	sessionOpts := session.Options{
+		Config: aws.Config{MaxRetries: aws.Int(cfg.APIMaxRetries)},
+	}
+	if cfg.CABundle != "" {
+		caBundle, err := os.Open(cfg.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open CA bundle due to %v", err)
+		}
+		defer caBundle.Close()
+		sessionOpts.CustomCABundle = caBundle
+	}
+	awsSession, err := NewSession(sessionOpts, cfg.APIDebug, mc, cc, diag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session due to %v", err)
+	}
+	addUserAgentHandlers(awsSession, clusterName)
+	if cfg.AssumeRoleArn != "" {
+		// roles are assumed with the base credentials, from a copy of the session made before they're replaced.
+		roleCreds := newRoleCredentials(awsSession.Copy(), cfg.AssumeRoleArn, cfg.AssumeRoleExternalID, clusterName)
+		awsSession.Config.Credentials = roleCreds.defaultCredentials
+		if cfg.AssumeRolePerIngress {
+			awsSession.Handlers.Sign.PushFront(roleCreds.signWithIngressCredentials)
+		}
+	} else if cfg.AssumeRoleExternalID != "" || cfg.AssumeRolePerIngress {
+		return nil, fmt.Errorf("--aws-assume-role-external-id and --aws-assume-role-per-ingress require --aws-assume-role-arn")
+	}
+	if auditLog == nil && (cfg.AuditLogGroup != "" || cfg.AuditLogS3URI != "") {
+		return nil, fmt.Errorf("--aws-audit-log-group and --aws-audit-log-s3-uri require --aws-audit-log")
+	}
 	metadata := ec2metadata.New(awsSession)
 
 	if len(cfg.VpcID) == 0 {
@@ -65,17 +126,55 @@ func New(cfg CloudConfig, clusterName string, mc metric.Collector, cc *cache.Con
 		}
 		cfg.Region = region
 	}
+	if err := validateRegion(cfg.Region); err != nil {
+		return nil, err
+	}
+	if cfg.AssumeRoleArn != "" {
+		if err := validatePartition(cfg.AssumeRoleArn, cfg.Region); err != nil {
+			return nil, fmt.Errorf("invalid --aws-assume-role-arn due to %v", err)
+		}
+	}
 
 	regionCfg := &aws.Config{Region: aws.String(cfg.Region)}
+	if cfg.UseFIPSEndpoints {
+		regionCfg.EndpointResolver = newFIPSResolver()
+	}
+	if auditLog != nil {
+		// sinks use a copy of the session made before audit handlers are added, so their own calls aren't audited.
+		if cfg.AuditLogGroup != "" {
+			auditLog.AddSink(newCloudWatchLogsAuditSink(awsSession.Copy(), regionCfg, cfg.AuditLogGroup, clusterName))
+		}
+		if cfg.AuditLogS3URI != "" {
+			sink, err := newS3AuditSink(awsSession.Copy(), regionCfg, cfg.AuditLogS3URI, clusterName)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --aws-audit-log-s3-uri due to %v", err)
+			}
+			auditLog.AddSink(sink)
+		}
+		auditLog.addHandlers(awsSession)
+	}
+	ec2Client := ec2.New(awsSession, regionCfg)
+	elbv2Client := elbv2.New(awsSession, regionCfg)
 	return &Cloud{
 		cfg.VpcID,
 		cfg.Region,
 		clusterName,
 		acm.New(awsSession, regionCfg),
-		ec2.New(awsSession, regionCfg),
-		elbv2.New(awsSession, regionCfg),
+		cloudwatch.New(awsSession, regionCfg),
+		ec2Client,
+		elbv2Client,
 		iam.New(awsSession, regionCfg),
 		resourcegroupstaggingapi.New(awsSession, regionCfg),
+		route53.New(awsSession, regionCfg),
+		s3.New(awsSession, regionCfg),
 		wafregional.New(awsSession, regionCfg),
+		cc,
+		newZoneInventory(ec2Client, clock.RealClock{}),
+		newDescribeCache(targetGroupCacheTTL, clock.RealClock{}),
+		newDescribeCache(listenerCacheTTL, clock.RealClock{}),
+		newDescribeCache(ruleCacheTTL, clock.RealClock{}),
+		newDescribeCache(securityGroupCacheTTL, clock.RealClock{}),
+		newLoadBalancerInventory(elbv2Client, clock.RealClock{}),
+		clusterInventory{},
 	}, nil
 }
@@ -0,0 +1,164 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+)
+
+// CloudWatchAPI is our wrapper CloudWatch API interface
+type CloudWatchAPI interface {
+	// GetAverageConsumedLCUs returns the average number of LCUs consumed by LoadBalancer lbArn over the duration until now.
+	// It returns zero if no LCUs are reported in the duration.
+	GetAverageConsumedLCUs(ctx context.Context, lbArn string, duration time.Duration) (float64, error)
+
+	// GetLatestTargetGroupStatistic returns the latest one minute statistic of metricName reported for targetGroup tgArn of LoadBalancer lbArn.
+	// It returns false if no datapoint is reported in the last few minutes.
+	GetLatestTargetGroupStatistic(ctx context.Context, lbArn string, tgArn string, metricName string, statistic string) (float64, bool, error)
+
+	// GetAlarmsByPrefix returns the metric alarms whose names start with prefix.
+	GetAlarmsByPrefix(ctx context.Context, prefix string) ([]*cloudwatch.MetricAlarm, error)
+
+	PutMetricAlarmWithContext(context.Context, *cloudwatch.PutMetricAlarmInput) (*cloudwatch.PutMetricAlarmOutput, error)
+	DeleteAlarmsWithContext(context.Context, *cloudwatch.DeleteAlarmsInput) (*cloudwatch.DeleteAlarmsOutput, error)
+}
+
+func (c *Cloud) PutMetricAlarmWithContext(ctx context.Context, i *cloudwatch.PutMetricAlarmInput) (*cloudwatch.PutMetricAlarmOutput, error) {
+	return c.cloudwatch.PutMetricAlarmWithContext(ctx, i)
+}
+func (c *Cloud) DeleteAlarmsWithContext(ctx context.Context, i *cloudwatch.DeleteAlarmsInput) (*cloudwatch.DeleteAlarmsOutput, error) {
+	return c.cloudwatch.DeleteAlarmsWithContext(ctx, i)
+}
+
+// GetAlarmsByPrefix returns the metric alarms whose names start with prefix.
+func (c *Cloud) GetAlarmsByPrefix(ctx context.Context, prefix string) ([]*cloudwatch.MetricAlarm, error) {
+	var alarms []*cloudwatch.MetricAlarm
+	err := c.cloudwatch.DescribeAlarmsPagesWithContext(ctx, &cloudwatch.DescribeAlarmsInput{
+		AlarmNamePrefix: aws.String(prefix),
+	}, func(output *cloudwatch.DescribeAlarmsOutput, _ bool) bool {
+		alarms = append(alarms, output.MetricAlarms...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return alarms, nil
+}
+
+// GetAverageConsumedLCUs returns the average number of LCUs consumed by LoadBalancer lbArn over the duration until now.
+func (c *Cloud) GetAverageConsumedLCUs(ctx context.Context, lbArn string, duration time.Duration) (float64, error) {
+	dimension, err := LoadBalancerMetricDimension(lbArn)
+	if err != nil {
+		return 0, err
+	}
+	period := duration.Truncate(time.Hour)
+	if period < time.Hour {
+		period = time.Hour
+	}
+	endTime := time.Now()
+	resp, err := c.cloudwatch.GetMetricStatisticsWithContext(ctx, &cloudwatch.GetMetricStatisticsInput{
+		Namespace:  aws.String("AWS/ApplicationELB"),
+		MetricName: aws.String("ConsumedLCUs"),
+		Dimensions: []*cloudwatch.Dimension{
+			{
+				Name:  aws.String("LoadBalancer"),
+				Value: aws.String(dimension),
+			},
+		},
+		StartTime:  aws.Time(endTime.Add(-period)),
+		EndTime:    aws.Time(endTime),
+		Period:     aws.Int64(int64(time.Hour.Seconds())),
+		Statistics: []*string{aws.String(cloudwatch.StatisticAverage)},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.Datapoints) == 0 {
+		return 0, nil
+	}
+	var sum float64
+	for _, datapoint := range resp.Datapoints {
+		sum += aws.Float64Value(datapoint.Average)
+	}
+	return sum / float64(len(resp.Datapoints)), nil
+}
+
+// GetLatestTargetGroupStatistic returns the latest one minute statistic of metricName reported for targetGroup tgArn of LoadBalancer lbArn.
+func (c *Cloud) GetLatestTargetGroupStatistic(ctx context.Context, lbArn string, tgArn string, metricName string, statistic string) (float64, bool, error) {
+	lbDimension, err := LoadBalancerMetricDimension(lbArn)
+	if err != nil {
+		return 0, false, err
+	}
+	tgDimension, err := TargetGroupMetricDimension(tgArn)
+	if err != nil {
+		return 0, false, err
+	}
+	endTime := time.Now()
+	resp, err := c.cloudwatch.GetMetricStatisticsWithContext(ctx, &cloudwatch.GetMetricStatisticsInput{
+		Namespace:  aws.String("AWS/ApplicationELB"),
+		MetricName: aws.String(metricName),
+		Dimensions: []*cloudwatch.Dimension{
+			{
+				Name:  aws.String("LoadBalancer"),
+				Value: aws.String(lbDimension),
+			},
+			{
+				Name:  aws.String("TargetGroup"),
+				Value: aws.String(tgDimension),
+			},
+		},
+		// ALB metrics are reported with a delay of a few minutes.
+		StartTime:  aws.Time(endTime.Add(-5 * time.Minute)),
+		EndTime:    aws.Time(endTime),
+		Period:     aws.Int64(int64(time.Minute.Seconds())),
+		Statistics: []*string{aws.String(statistic)},
+	})
+	if err != nil {
+		return 0, false, err
+	}
+	var latest *cloudwatch.Datapoint
+	for _, datapoint := range resp.Datapoints {
+		if latest == nil || aws.TimeValue(datapoint.Timestamp).After(aws.TimeValue(latest.Timestamp)) {
+			latest = datapoint
+		}
+	}
+	if latest == nil {
+		return 0, false, nil
+	}
+	switch statistic {
+	case cloudwatch.StatisticSum:
+		return aws.Float64Value(latest.Sum), true, nil
+	case cloudwatch.StatisticMaximum:
+		return aws.Float64Value(latest.Maximum), true, nil
+	case cloudwatch.StatisticMinimum:
+		return aws.Float64Value(latest.Minimum), true, nil
+	case cloudwatch.StatisticSampleCount:
+		return aws.Float64Value(latest.SampleCount), true, nil
+	default:
+		return aws.Float64Value(latest.Average), true, nil
+	}
+}
+
+// LoadBalancerMetricDimension returns the value of LoadBalancer dimension in CloudWatch metrics for LoadBalancer lbArn,
+// which is the final portion of ARN like "app/my-load-balancer/50dc6c495c0c9188".
+func LoadBalancerMetricDimension(lbArn string) (string, error) {
+	parsed, err := ParseARN(lbArn)
+	if err != nil || !strings.HasPrefix(parsed.Resource, "loadbalancer/") || parsed.Resource == "loadbalancer/" {
+		return "", fmt.Errorf("invalid LoadBalancer ARN %v", lbArn)
+	}
+	return strings.TrimPrefix(parsed.Resource, "loadbalancer/"), nil
+}
+
+// TargetGroupMetricDimension returns the value of TargetGroup dimension in CloudWatch metrics for targetGroup tgArn,
+// which is the final portion of ARN like "targetgroup/my-targets/73e2d6bc24d8a067".
+func TargetGroupMetricDimension(tgArn string) (string, error) {
+	parsed, err := ParseARN(tgArn)
+	if err != nil || !strings.HasPrefix(parsed.Resource, "targetgroup/") || parsed.Resource == "targetgroup/" {
+		return "", fmt.Errorf("invalid targetGroup ARN %v", tgArn)
+	}
+	return parsed.Resource, nil
+}
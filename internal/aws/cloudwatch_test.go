@@ -0,0 +1,51 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadBalancerMetricDimension(t *testing.T) {
+	for _, tc := range []struct {
+		Name              string
+		LBArn             string
+		ExpectedDimension string
+		ExpectedError     string
+	}{
+		{
+			Name:              "valid ARN",
+			LBArn:             "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-load-balancer/50dc6c495c0c9188",
+			ExpectedDimension: "app/my-load-balancer/50dc6c495c0c9188",
+		},
+		{
+			Name:              "valid ARN in China",
+			LBArn:             "arn:aws-cn:elasticloadbalancing:cn-north-1:123456789012:loadbalancer/app/my-load-balancer/50dc6c495c0c9188",
+			ExpectedDimension: "app/my-load-balancer/50dc6c495c0c9188",
+		},
+		{
+			Name:          "invalid ARN",
+			LBArn:         "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-targets/73e2d6bc24d8a067",
+			ExpectedError: "invalid LoadBalancer ARN arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-targets/73e2d6bc24d8a067",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			dimension, err := LoadBalancerMetricDimension(tc.LBArn)
+			if tc.ExpectedError != "" {
+				assert.EqualError(t, err, tc.ExpectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.ExpectedDimension, dimension)
+			}
+		})
+	}
+}
+
+func TestTargetGroupMetricDimension(t *testing.T) {
+	dimension, err := TargetGroupMetricDimension("arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-targets/73e2d6bc24d8a067")
+	assert.NoError(t, err)
+	assert.Equal(t, "targetgroup/my-targets/73e2d6bc24d8a067", dimension)
+
+	_, err = TargetGroupMetricDimension("arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-load-balancer/50dc6c495c0c9188")
+	assert.Error(t, err)
+}
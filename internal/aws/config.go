@@ -23,6 +23,25 @@ type CloudConfig struct {
 
 	APIMaxRetries int
 	APIDebug      bool
+
+	// UseFIPSEndpoints is whether FIPS endpoints are used for AWS services that have them.
+	UseFIPSEndpoints bool
+	// CABundle is the path of PEM file with the CA certificates trusted for connections to AWS APIs instead of system ones, if specified.
+	CABundle string
+
+	// AssumeRoleArn is the ARN of the role assumed for AWS calls, the base credentials are used if empty.
+	AssumeRoleArn string
+	// AssumeRoleExternalID is the external ID required by the trust policy of the assumed role, if any.
+	AssumeRoleExternalID string
+	// AssumeRolePerIngress is whether AWS calls made for an ingress use a role session named after it, so CloudTrail attributes them to it.
+	AssumeRolePerIngress bool
+
+	// AuditLog is whether the AWS calls modifying resources are written to stdout as JSON lines.
+	AuditLog bool
+	// AuditLogGroup is the CloudWatch Logs log group the audit log is sent to too, if specified.
+	AuditLogGroup string
+	// AuditLogS3URI is the S3 location, formatted as s3://bucket/prefix, the audit log is sent to too, if specified.
+	AuditLogS3URI string
 }
 
 func (cfg *CloudConfig) BindFlags(fs *pflag.FlagSet) {
@@ -34,6 +53,22 @@ func (cfg *CloudConfig) BindFlags(fs *pflag.FlagSet) {
 		`Maximum number of times to retry the AWS API.`)
 	fs.BoolVar(&cfg.APIDebug, "aws-api-debug", defaultAPIDebug,
 		`Enable debug logging of AWS API`)
+	fs.BoolVar(&cfg.UseFIPSEndpoints, "aws-use-fips-endpoints", false,
+		`Use FIPS endpoints of AWS APIs where available, for ACM, CloudWatch, EC2, ELBV2 and WAF Regional`)
+	fs.StringVar(&cfg.CABundle, "aws-ca-bundle", "",
+		`Path of PEM file with CA certificates to trust for connections to AWS APIs instead of system ones, e.g. behind a TLS-intercepting proxy`)
+	fs.StringVar(&cfg.AssumeRoleArn, "aws-assume-role-arn", "",
+		`ARN of an IAM role to assume for AWS calls, with role session names identifying the cluster`)
+	fs.StringVar(&cfg.AssumeRoleExternalID, "aws-assume-role-external-id", "",
+		`External ID to assume the role of aws-assume-role-arn with`)
+	fs.BoolVar(&cfg.AssumeRolePerIngress, "aws-assume-role-per-ingress", false,
+		`Assume the role of aws-assume-role-arn with a role session per Ingress, so CloudTrail attributes AWS calls to the Ingress they're made for`)
+	fs.BoolVar(&cfg.AuditLog, "aws-audit-log", false,
+		`Write an audit log of the AWS calls modifying resources to stdout as JSON lines, with their Ingress and the values before and after`)
+	fs.StringVar(&cfg.AuditLogGroup, "aws-audit-log-group", "",
+		`CloudWatch Logs log group to send the audit log to as well, requires aws-audit-log`)
+	fs.StringVar(&cfg.AuditLogS3URI, "aws-audit-log-s3-uri", "",
+		`S3 location formatted as s3://bucket/prefix to send the audit log to as well, requires aws-audit-log`)
 }
 
 func (cfg *CloudConfig) BindEnv() error {
@@ -23,6 +23,12 @@ const (
 type EC2API interface {
 	GetSubnetsByNameOrID(context.Context, []string) ([]*ec2.Subnet, error)
 
+	// GetRouteTablesBySubnetID returns the route tables of subnets by subnetID, subnets without explicit association use the main route table of vpc
+	GetRouteTablesBySubnetID(context.Context, []string) (map[string]*ec2.RouteTable, error)
+
+	// GetZoneIDsByName returns the IDs of the availability zones of the region by name
+	GetZoneIDsByName(context.Context) (map[string]string, error)
+
 	// StatusEC2 validates EC2 connectivity
 	StatusEC2() func() error
 
@@ -32,6 +38,9 @@ type EC2API interface {
 	// GetInstancesByIDs retrieves ec2 instances by slice of instanceID
 	GetInstancesByIDs([]string) ([]*ec2.Instance, error)
 
+	// GetInstanceIDByPrivateDNSName retrieves the ID of the ec2 instance with privateDNSName in vpc, empty if there's none
+	GetInstanceIDByPrivateDNSName(string) (string, error)
+
 	// GetSecurityGroupByID retrieves securityGroup by securityGroupID
 	GetSecurityGroupByID(string) (*ec2.SecurityGroup, error)
 
@@ -48,6 +57,9 @@ type EC2API interface {
 	CreateSecurityGroupWithContext(context.Context, *ec2.CreateSecurityGroupInput) (*ec2.CreateSecurityGroupOutput, error)
 	AuthorizeSecurityGroupIngressWithContext(context.Context, *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error)
 	RevokeSecurityGroupIngressWithContext(context.Context, *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error)
+	UpdateSecurityGroupRuleDescriptionsIngressWithContext(context.Context, *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput) (*ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput, error)
+	AuthorizeSecurityGroupEgressWithContext(context.Context, *ec2.AuthorizeSecurityGroupEgressInput) (*ec2.AuthorizeSecurityGroupEgressOutput, error)
+	RevokeSecurityGroupEgressWithContext(context.Context, *ec2.RevokeSecurityGroupEgressInput) (*ec2.RevokeSecurityGroupEgressOutput, error)
 	CreateEC2TagsWithContext(context.Context, *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error)
 	DeleteEC2TagsWithContext(context.Context, *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error)
 }
@@ -63,18 +75,44 @@ func (c *Cloud) CreateSecurityGroupWithContext(ctx context.Context, i *ec2.Creat
 }
 
 func (c *Cloud) AuthorizeSecurityGroupIngressWithContext(ctx context.Context, i *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	defer c.securityGroups.delete(aws.StringValue(i.GroupId))
 	return c.ec2.AuthorizeSecurityGroupIngressWithContext(ctx, i)
 }
 
 func (c *Cloud) RevokeSecurityGroupIngressWithContext(ctx context.Context, i *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	defer c.securityGroups.delete(aws.StringValue(i.GroupId))
 	return c.ec2.RevokeSecurityGroupIngressWithContext(ctx, i)
 }
+func (c *Cloud) UpdateSecurityGroupRuleDescriptionsIngressWithContext(ctx context.Context, i *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput) (*ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput, error) {
+	defer c.securityGroups.delete(aws.StringValue(i.GroupId))
+	return c.ec2.UpdateSecurityGroupRuleDescriptionsIngressWithContext(ctx, i)
+}
+
+func (c *Cloud) AuthorizeSecurityGroupEgressWithContext(ctx context.Context, i *ec2.AuthorizeSecurityGroupEgressInput) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
+	defer c.securityGroups.delete(aws.StringValue(i.GroupId))
+	return c.ec2.AuthorizeSecurityGroupEgressWithContext(ctx, i)
+}
+
+func (c *Cloud) RevokeSecurityGroupEgressWithContext(ctx context.Context, i *ec2.RevokeSecurityGroupEgressInput) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	defer c.securityGroups.delete(aws.StringValue(i.GroupId))
+	return c.ec2.RevokeSecurityGroupEgressWithContext(ctx, i)
+}
 
 func (c *Cloud) CreateEC2TagsWithContext(ctx context.Context, i *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+	defer func() {
+		for _, resource := range i.Resources {
+			c.securityGroups.delete(aws.StringValue(resource))
+		}
+	}()
 	return c.ec2.CreateTagsWithContext(ctx, i)
 }
 
 func (c *Cloud) DeleteEC2TagsWithContext(ctx context.Context, i *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error) {
+	defer func() {
+		for _, resource := range i.Resources {
+			c.securityGroups.delete(aws.StringValue(resource))
+		}
+	}()
 	return c.ec2.DeleteTagsWithContext(ctx, i)
 }
 
@@ -128,6 +166,40 @@ func (c *Cloud) GetSubnetsByNameOrID(ctx context.Context, nameOrIDs []string) (s
 	return
 }
 
+func (c *Cloud) GetRouteTablesBySubnetID(ctx context.Context, subnetIDs []string) (map[string]*ec2.RouteTable, error) {
+	resp, err := c.ec2.DescribeRouteTablesWithContext(ctx, &ec2.DescribeRouteTablesInput{Filters: []*ec2.Filter{
+		{
+			Name:   aws.String("vpc-id"),
+			Values: []*string{aws.String(c.vpcID)},
+		},
+	}})
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch route tables due to %v", err)
+	}
+
+	var mainRouteTable *ec2.RouteTable
+	associated := make(map[string]*ec2.RouteTable)
+	for _, routeTable := range resp.RouteTables {
+		for _, association := range routeTable.Associations {
+			if aws.BoolValue(association.Main) {
+				mainRouteTable = routeTable
+			} else if association.SubnetId != nil {
+				associated[aws.StringValue(association.SubnetId)] = routeTable
+			}
+		}
+	}
+
+	routeTables := make(map[string]*ec2.RouteTable, len(subnetIDs))
+	for _, subnetID := range subnetIDs {
+		if routeTable, ok := associated[subnetID]; ok {
+			routeTables[subnetID] = routeTable
+		} else if mainRouteTable != nil {
+			routeTables[subnetID] = mainRouteTable
+		}
+	}
+	return routeTables, nil
+}
+
 func (c *Cloud) GetSecurityGroupsByName(ctx context.Context, names []string) (groups []*ec2.SecurityGroup, err error) {
 	in := &ec2.DescribeSecurityGroupsInput{Filters: []*ec2.Filter{
 		{
@@ -166,7 +238,38 @@ func (c *Cloud) GetInstancesByIDs(instanceIDs []string) ([]*ec2.Instance, error)
 	return result, nil
 }
 
+func (c *Cloud) GetInstanceIDByPrivateDNSName(privateDNSName string) (string, error) {
+	reservations, err := c.describeInstancesHelper(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []*string{aws.String(c.vpcID)},
+			},
+			{
+				Name:   aws.String("private-dns-name"),
+				Values: []*string{aws.String(privateDNSName)},
+			},
+			{
+				Name:   aws.String("instance-state-name"),
+				Values: aws.StringSlice([]string{ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning, ec2.InstanceStateNameStopping, ec2.InstanceStateNameStopped}),
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, reservation := range reservations {
+		for _, instance := range reservation.Instances {
+			return aws.StringValue(instance.InstanceId), nil
+		}
+	}
+	return "", nil
+}
+
 func (c *Cloud) GetSecurityGroupByID(groupID string) (*ec2.SecurityGroup, error) {
+	if sg, ok := c.securityGroups.securityGroupByID(groupID); ok {
+		return sg, nil
+	}
 	securityGroups, err := c.describeSecurityGroupsHelper(&ec2.DescribeSecurityGroupsInput{
 		GroupIds: []*string{aws.String(groupID)},
 	})
@@ -176,10 +279,14 @@ func (c *Cloud) GetSecurityGroupByID(groupID string) (*ec2.SecurityGroup, error)
 	if len(securityGroups) == 0 {
 		return nil, nil
 	}
+	c.securityGroups.cacheSecurityGroup(securityGroups[0])
 	return securityGroups[0], nil
 }
 
 func (c *Cloud) GetSecurityGroupByName(groupName string) (*ec2.SecurityGroup, error) {
+	if sg, ok := c.securityGroups.securityGroupByName(groupName); ok {
+		return sg, nil
+	}
 	securityGroups, err := c.describeSecurityGroupsHelper(&ec2.DescribeSecurityGroupsInput{
 		Filters: []*ec2.Filter{
 			{
@@ -198,16 +305,18 @@ func (c *Cloud) GetSecurityGroupByName(groupName string) (*ec2.SecurityGroup, er
 	if len(securityGroups) == 0 {
 		return nil, nil
 	}
+	c.securityGroups.cacheSecurityGroup(securityGroups[0])
 	return securityGroups[0], nil
 }
 
 func (c *Cloud) DeleteSecurityGroupByID(groupID string) error {
+	defer c.securityGroups.delete(groupID)
 	input := &ec2.DeleteSecurityGroupInput{
 		GroupId: aws.String(groupID),
 	}
 
 	retryOption := func(req *request.Request) {
-		req.Retryer = &deleteSecurityGroupRetryer{
+		req.Retryer = &dependencyViolationRetryer{
 			req.Retryer,
 		}
 	}
@@ -278,20 +387,28 @@ func (c *Cloud) IsNodeHealthy(instanceid string) (bool, error) {
 	return false, nil
 }
 
-type deleteSecurityGroupRetryer struct {
+// IsDependencyViolation returns true if err is caused by deleting a resource which is still in use,
+// e.g. a securityGroup still attached to the network interfaces of a deleted ALB, which are detached asynchronously.
+func IsDependencyViolation(err error) bool {
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code() == "DependencyViolation"
+	}
+	return false
+}
+
+// dependencyViolationRetryer retries deletions failed with DependencyViolation, in addition to the default retry rules.
+type dependencyViolationRetryer struct {
 	request.Retryer
 }
 
-func (r *deleteSecurityGroupRetryer) ShouldRetry(req *request.Request) bool {
-	if awsErr, ok := req.Error.(awserr.Error); ok {
-		if awsErr.Code() == "DependencyViolation" {
-			return true
-		}
+func (r *dependencyViolationRetryer) ShouldRetry(req *request.Request) bool {
+	if IsDependencyViolation(req.Error) {
+		return true
 	}
 	// Fallback to built in retry rules
 	return r.Retryer.ShouldRetry(req)
 }
 
-func (r *deleteSecurityGroupRetryer) MaxRetries() int {
+func (r *dependencyViolationRetryer) MaxRetries() int {
 	return 20
 }
@@ -0,0 +1,40 @@
+package aws
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// securityGroupCacheTTL is how long securityGroups described by ID or name are cached.
+const securityGroupCacheTTL = 30 * time.Second
+
+// securityGroupByID returns a copy of the securityGroup cached by ID.
+func (c *describeCache) securityGroupByID(groupID string) (*ec2.SecurityGroup, bool) {
+	sg, ok := c.get(groupID)
+	if !ok {
+		return nil, false
+	}
+	return awsutil.CopyOf(sg).(*ec2.SecurityGroup), true
+}
+
+// securityGroupByName returns a copy of the securityGroup cached by name.
+func (c *describeCache) securityGroupByName(groupName string) (*ec2.SecurityGroup, bool) {
+	groupID, ok := c.get(nameKey(groupName))
+	if !ok {
+		return nil, false
+	}
+	return c.securityGroupByID(groupID.(string))
+}
+
+// cacheSecurityGroup caches a copy of sg by its ID and name.
+func (c *describeCache) cacheSecurityGroup(sg *ec2.SecurityGroup) {
+	if sg == nil || sg.GroupId == nil {
+		return
+	}
+	c.set(*sg.GroupId, awsutil.CopyOf(sg))
+	if sg.GroupName != nil {
+		c.set(nameKey(*sg.GroupName), *sg.GroupId)
+	}
+}
@@ -0,0 +1,44 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+func TestCloud_securityGroupCache(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewFakeClock(time.Now())
+	sg := &ec2.SecurityGroup{GroupId: aws.String("sg-1"), GroupName: aws.String("sgName")}
+	svc := &mocks.EC2API{}
+	svc.On("DescribeSecurityGroupsRequest",
+		&ec2.DescribeSecurityGroupsInput{GroupIds: []*string{aws.String("sg-1")}},
+	).Return(newReq(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: []*ec2.SecurityGroup{sg}}, nil), nil).Twice()
+	svc.On("AuthorizeSecurityGroupIngressWithContext", ctx, &ec2.AuthorizeSecurityGroupIngressInput{GroupId: aws.String("sg-1")}).
+		Return(&ec2.AuthorizeSecurityGroupIngressOutput{}, nil)
+	cloud := &Cloud{ec2: svc, securityGroups: newDescribeCache(securityGroupCacheTTL, clk)}
+
+	// securityGroups are described once, and cached by ID and name.
+	for i := 0; i < 2; i++ {
+		got, err := cloud.GetSecurityGroupByID("sg-1")
+		assert.NoError(t, err)
+		assert.Equal(t, sg, got)
+	}
+	got, err := cloud.GetSecurityGroupByName("sgName")
+	assert.NoError(t, err)
+	assert.Equal(t, sg, got)
+
+	// changes invalidate the cache, and securityGroups are described again.
+	_, err = cloud.AuthorizeSecurityGroupIngressWithContext(ctx, &ec2.AuthorizeSecurityGroupIngressInput{GroupId: aws.String("sg-1")})
+	assert.NoError(t, err)
+	got, err = cloud.GetSecurityGroupByID("sg-1")
+	assert.NoError(t, err)
+	assert.Equal(t, sg, got)
+	svc.AssertExpectations(t)
+}
@@ -147,3 +147,28 @@ func TestCloud_RevokeSecurityGroupIngressWithContext(t *testing.T) {
 		svc.AssertExpectations(t)
 	})
 }
+
+func TestCloud_GetRouteTablesBySubnetID(t *testing.T) {
+	ctx := context.Background()
+	mainRouteTable := &ec2.RouteTable{
+		RouteTableId: aws.String("rtb-main"),
+		Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}},
+	}
+	subnetRouteTable := &ec2.RouteTable{
+		RouteTableId: aws.String("rtb-subnet"),
+		Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(false), SubnetId: aws.String("subnet-a")}},
+	}
+	svc := &mocks.EC2API{}
+	svc.On("DescribeRouteTablesWithContext", ctx, &ec2.DescribeRouteTablesInput{Filters: []*ec2.Filter{
+		{Name: aws.String("vpc-id"), Values: []*string{aws.String("vpc-id")}},
+	}}).Return(&ec2.DescribeRouteTablesOutput{RouteTables: []*ec2.RouteTable{mainRouteTable, subnetRouteTable}}, nil)
+	cloud := &Cloud{
+		vpcID: "vpc-id",
+		ec2:   svc,
+	}
+
+	routeTables, err := cloud.GetRouteTablesBySubnetID(ctx, []string{"subnet-a", "subnet-b"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*ec2.RouteTable{"subnet-a": subnetRouteTable, "subnet-b": mainRouteTable}, routeTables)
+	svc.AssertExpectations(t)
+}
@@ -30,6 +30,9 @@ type ELBV2API interface {
 	// DeleteLoadBalancerByArn deletes LoadBalancer instance by arn
 	DeleteLoadBalancerByArn(context.Context, string) error
 
+	// WaitLoadBalancerActive waits until LoadBalancer instance by arn becomes active
+	WaitLoadBalancerActive(context.Context, string) error
+
 	// GetTargetGroupByArn retrieve TargetGroup instance by arn
 	GetTargetGroupByArn(context.Context, string) (*elbv2.TargetGroup, error)
 
@@ -49,6 +52,7 @@ type ELBV2API interface {
 	CreateRuleWithContext(context.Context, *elbv2.CreateRuleInput) (*elbv2.CreateRuleOutput, error)
 	ModifyRuleWithContext(context.Context, *elbv2.ModifyRuleInput) (*elbv2.ModifyRuleOutput, error)
 	DeleteRuleWithContext(context.Context, *elbv2.DeleteRuleInput) (*elbv2.DeleteRuleOutput, error)
+	SetRulePrioritiesWithContext(context.Context, *elbv2.SetRulePrioritiesInput) (*elbv2.SetRulePrioritiesOutput, error)
 	SetSecurityGroupsWithContext(context.Context, *elbv2.SetSecurityGroupsInput) (*elbv2.SetSecurityGroupsOutput, error)
 	CreateListenerWithContext(context.Context, *elbv2.CreateListenerInput) (*elbv2.CreateListenerOutput, error)
 	ModifyListenerWithContext(context.Context, *elbv2.ModifyListenerInput) (*elbv2.ModifyListenerOutput, error)
@@ -78,11 +82,25 @@ func (c *Cloud) CreateTargetGroupWithContext(ctx context.Context, i *elbv2.Creat
 	if i.VpcId == nil {
 		i.VpcId = aws.String(c.vpcID)
 	}
-	return c.elbv2.CreateTargetGroupWithContext(ctx, i)
+	o, err := c.elbv2.CreateTargetGroupWithContext(ctx, i)
+	if err == nil {
+		for _, tg := range o.TargetGroups {
+			c.targetGroups.cacheTargetGroup(tg)
+		}
+	}
+	return o, err
 }
 
 func (c *Cloud) ModifyTargetGroupWithContext(ctx context.Context, i *elbv2.ModifyTargetGroupInput) (*elbv2.ModifyTargetGroupOutput, error) {
-	return c.elbv2.ModifyTargetGroupWithContext(ctx, i)
+	o, err := c.elbv2.ModifyTargetGroupWithContext(ctx, i)
+	if err != nil {
+		c.targetGroups.delete(aws.StringValue(i.TargetGroupArn))
+		return o, err
+	}
+	for _, tg := range o.TargetGroups {
+		c.targetGroups.cacheTargetGroup(tg)
+	}
+	return o, err
 }
 
 func (c *Cloud) RegisterTargetsWithContext(ctx context.Context, i *elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error) {
@@ -95,21 +113,48 @@ func (c *Cloud) DescribeTargetHealthWithContext(ctx context.Context, i *elbv2.De
 	return c.elbv2.DescribeTargetHealthWithContext(ctx, i)
 }
 func (c *Cloud) CreateRuleWithContext(ctx context.Context, i *elbv2.CreateRuleInput) (*elbv2.CreateRuleOutput, error) {
-	return c.elbv2.CreateRuleWithContext(ctx, i)
+	o, err := c.elbv2.CreateRuleWithContext(ctx, i)
+	if err != nil {
+		c.rules.delete(aws.StringValue(i.ListenerArn))
+		return o, err
+	}
+	c.rules.putRules(o.Rules)
+	return o, err
 }
 func (c *Cloud) ModifyRuleWithContext(ctx context.Context, i *elbv2.ModifyRuleInput) (*elbv2.ModifyRuleOutput, error) {
-	return c.elbv2.ModifyRuleWithContext(ctx, i)
+	o, err := c.elbv2.ModifyRuleWithContext(ctx, i)
+	if err != nil {
+		c.invalidateRule(aws.StringValue(i.RuleArn))
+		return o, err
+	}
+	c.rules.putRules(o.Rules)
+	return o, err
 }
 func (c *Cloud) DeleteRuleWithContext(ctx context.Context, i *elbv2.DeleteRuleInput) (*elbv2.DeleteRuleOutput, error) {
+	defer c.rules.removeRule(aws.StringValue(i.RuleArn))
 	return c.elbv2.DeleteRuleWithContext(ctx, i)
 }
+func (c *Cloud) SetRulePrioritiesWithContext(ctx context.Context, i *elbv2.SetRulePrioritiesInput) (*elbv2.SetRulePrioritiesOutput, error) {
+	o, err := c.elbv2.SetRulePrioritiesWithContext(ctx, i)
+	if err != nil {
+		for _, p := range i.RulePriorities {
+			c.invalidateRule(aws.StringValue(p.RuleArn))
+		}
+		return o, err
+	}
+	c.rules.putRules(o.Rules)
+	return o, err
+}
 func (c *Cloud) SetSecurityGroupsWithContext(ctx context.Context, i *elbv2.SetSecurityGroupsInput) (*elbv2.SetSecurityGroupsOutput, error) {
+	defer c.loadBalancers.invalidate(aws.StringValue(i.LoadBalancerArn))
 	return c.elbv2.SetSecurityGroupsWithContext(ctx, i)
 }
 func (c *Cloud) CreateListenerWithContext(ctx context.Context, i *elbv2.CreateListenerInput) (*elbv2.CreateListenerOutput, error) {
+	defer c.listeners.delete(aws.StringValue(i.LoadBalancerArn))
 	return c.elbv2.CreateListenerWithContext(ctx, i)
 }
 func (c *Cloud) ModifyListenerWithContext(ctx context.Context, i *elbv2.ModifyListenerInput) (*elbv2.ModifyListenerOutput, error) {
+	defer c.invalidateListener(aws.StringValue(i.ListenerArn))
 	return c.elbv2.ModifyListenerWithContext(ctx, i)
 }
 func (c *Cloud) DescribeLoadBalancerAttributesWithContext(ctx context.Context, i *elbv2.DescribeLoadBalancerAttributesInput) (*elbv2.DescribeLoadBalancerAttributesOutput, error) {
@@ -119,12 +164,20 @@ func (c *Cloud) ModifyLoadBalancerAttributesWithContext(ctx context.Context, i *
 	return c.elbv2.ModifyLoadBalancerAttributesWithContext(ctx, i)
 }
 func (c *Cloud) CreateLoadBalancerWithContext(ctx context.Context, i *elbv2.CreateLoadBalancerInput) (*elbv2.CreateLoadBalancerOutput, error) {
-	return c.elbv2.CreateLoadBalancerWithContext(ctx, i)
+	o, err := c.elbv2.CreateLoadBalancerWithContext(ctx, i)
+	if err == nil {
+		for _, lb := range o.LoadBalancers {
+			c.loadBalancers.put(lb)
+		}
+	}
+	return o, err
 }
 func (c *Cloud) SetIpAddressTypeWithContext(ctx context.Context, i *elbv2.SetIpAddressTypeInput) (*elbv2.SetIpAddressTypeOutput, error) {
+	defer c.loadBalancers.invalidate(aws.StringValue(i.LoadBalancerArn))
 	return c.elbv2.SetIpAddressTypeWithContext(ctx, i)
 }
 func (c *Cloud) SetSubnetsWithContext(ctx context.Context, i *elbv2.SetSubnetsInput) (*elbv2.SetSubnetsOutput, error) {
+	defer c.loadBalancers.invalidate(aws.StringValue(i.LoadBalancerArn))
 	return c.elbv2.SetSubnetsWithContext(ctx, i)
 }
 func (c *Cloud) DescribeELBV2TagsWithContext(ctx context.Context, i *elbv2.DescribeTagsInput) (*elbv2.DescribeTagsOutput, error) {
@@ -167,6 +220,9 @@ func (c *Cloud) RemoveListenerCertificates(ctx context.Context, i *elbv2.RemoveL
 }
 
 func (c *Cloud) GetRules(ctx context.Context, listenerArn string) ([]*elbv2.Rule, error) {
+	if rules, ok := c.rules.rules(listenerArn); ok {
+		return rules, nil
+	}
 	var rules []*elbv2.Rule
 
 	p := request.Pagination{
@@ -181,7 +237,11 @@ func (c *Cloud) GetRules(ctx context.Context, listenerArn string) ([]*elbv2.Rule
 		page := p.Page().(*elbv2.DescribeRulesOutput)
 		rules = append(rules, page.Rules...)
 	}
-	return rules, p.Err()
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	c.rules.cacheRules(listenerArn, rules)
+	return rules, nil
 }
 
 // StatusELBV2 validates ELBV2 connectivity
@@ -197,6 +257,9 @@ func (c *Cloud) StatusELBV2() func() error {
 }
 
 func (c *Cloud) ListListenersByLoadBalancer(ctx context.Context, lbArn string) ([]*elbv2.Listener, error) {
+	if listeners, ok := c.listeners.listeners(lbArn); ok {
+		return listeners, nil
+	}
 	var listeners []*elbv2.Listener
 	err := c.elbv2.DescribeListenersPagesWithContext(ctx,
 		&elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(lbArn)},
@@ -211,10 +274,31 @@ func (c *Cloud) ListListenersByLoadBalancer(ctx context.Context, lbArn string) (
 		return nil, err
 	}
 
+	c.listeners.cacheListeners(lbArn, listeners)
 	return listeners, nil
 }
 
+// invalidateListener invalidates the listeners cached for the LoadBalancer of listener lsArn.
+func (c *Cloud) invalidateListener(lsArn string) {
+	if lbArn, ok := loadBalancerArnOfListener(lsArn); ok {
+		c.listeners.delete(lbArn)
+	} else {
+		c.listeners.flush()
+	}
+}
+
+// invalidateRule invalidates the rules cached for the listener of rule ruleArn.
+func (c *Cloud) invalidateRule(ruleArn string) {
+	if lsArn, ok := listenerArnOfRule(ruleArn); ok {
+		c.rules.delete(lsArn)
+	} else {
+		c.rules.flush()
+	}
+}
+
 func (c *Cloud) DeleteListenersByArn(ctx context.Context, lsArn string) error {
+	defer c.rules.delete(lsArn)
+	defer c.invalidateListener(lsArn)
 	_, err := c.elbv2.DeleteListenerWithContext(ctx, &elbv2.DeleteListenerInput{
 		ListenerArn: aws.String(lsArn),
 	})
@@ -222,6 +306,9 @@ func (c *Cloud) DeleteListenersByArn(ctx context.Context, lsArn string) error {
 }
 
 func (c *Cloud) GetLoadBalancerByArn(ctx context.Context, arn string) (*elbv2.LoadBalancer, error) {
+	if lb, ok := c.loadBalancers.getByArn(ctx, arn); ok {
+		return lb, nil
+	}
 	loadBalancers, err := c.describeLoadBalancersHelper(&elbv2.DescribeLoadBalancersInput{
 		LoadBalancerArns: []*string{aws.String(arn)},
 	})
@@ -231,10 +318,14 @@ func (c *Cloud) GetLoadBalancerByArn(ctx context.Context, arn string) (*elbv2.Lo
 	if len(loadBalancers) == 0 {
 		return nil, nil
 	}
+	c.loadBalancers.put(loadBalancers[0])
 	return loadBalancers[0], nil
 }
 
 func (c *Cloud) GetLoadBalancerByName(ctx context.Context, name string) (*elbv2.LoadBalancer, error) {
+	if lb, ok := c.loadBalancers.getByName(ctx, name); ok {
+		return lb, nil
+	}
 	loadBalancers, err := c.describeLoadBalancersHelper(&elbv2.DescribeLoadBalancersInput{
 		Names: []*string{aws.String(name)},
 	})
@@ -249,17 +340,30 @@ func (c *Cloud) GetLoadBalancerByName(ctx context.Context, name string) (*elbv2.
 	if len(loadBalancers) == 0 {
 		return nil, nil
 	}
+	c.loadBalancers.put(loadBalancers[0])
 	return loadBalancers[0], nil
 }
 
 func (c *Cloud) DeleteLoadBalancerByArn(ctx context.Context, arn string) error {
+	defer c.listeners.delete(arn)
+	defer c.loadBalancers.invalidate(arn)
 	_, err := c.elbv2.DeleteLoadBalancerWithContext(ctx, &elbv2.DeleteLoadBalancerInput{
 		LoadBalancerArn: aws.String(arn),
 	})
 	return err
 }
 
+// WaitLoadBalancerActive waits until LoadBalancer instance by arn becomes active, or ctx is done.
+func (c *Cloud) WaitLoadBalancerActive(ctx context.Context, arn string) error {
+	return c.elbv2.WaitUntilLoadBalancerAvailableWithContext(ctx, &elbv2.DescribeLoadBalancersInput{
+		LoadBalancerArns: []*string{aws.String(arn)},
+	})
+}
+
 func (c *Cloud) GetTargetGroupByArn(ctx context.Context, arn string) (*elbv2.TargetGroup, error) {
+	if tg, ok := c.targetGroups.targetGroupByArn(arn); ok {
+		return tg, nil
+	}
 	targetGroups, err := c.describeTargetGroupsHelper(&elbv2.DescribeTargetGroupsInput{
 		TargetGroupArns: []*string{aws.String(arn)},
 	})
@@ -269,11 +373,15 @@ func (c *Cloud) GetTargetGroupByArn(ctx context.Context, arn string) (*elbv2.Tar
 	if len(targetGroups) == 0 {
 		return nil, nil
 	}
+	c.targetGroups.cacheTargetGroup(targetGroups[0])
 	return targetGroups[0], nil
 }
 
 // GetTargetGroupByName retrieve TargetGroup instance by name
 func (c *Cloud) GetTargetGroupByName(ctx context.Context, name string) (*elbv2.TargetGroup, error) {
+	if tg, ok := c.targetGroups.targetGroupByName(name); ok {
+		return tg, nil
+	}
 	targetGroups, err := c.describeTargetGroupsHelper(&elbv2.DescribeTargetGroupsInput{
 		Names: []*string{aws.String(name)},
 	})
@@ -288,11 +396,13 @@ func (c *Cloud) GetTargetGroupByName(ctx context.Context, name string) (*elbv2.T
 	if len(targetGroups) == 0 {
 		return nil, nil
 	}
+	c.targetGroups.cacheTargetGroup(targetGroups[0])
 	return targetGroups[0], nil
 }
 
 // DeleteTargetGroupByArn deletes TargetGroup instance by arn
 func (c *Cloud) DeleteTargetGroupByArn(ctx context.Context, arn string) error {
+	defer c.targetGroups.delete(arn)
 	_, err := c.elbv2.DeleteTargetGroupWithContext(ctx, &elbv2.DeleteTargetGroupInput{
 		TargetGroupArn: aws.String(arn),
 	})
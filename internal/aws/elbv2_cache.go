@@ -0,0 +1,266 @@
+package aws
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+const (
+	// targetGroupCacheTTL is how long targetGroups described by ARN or name are cached.
+	targetGroupCacheTTL = 30 * time.Second
+	// listenerCacheTTL is how long the listeners of LoadBalancers are cached.
+	listenerCacheTTL = 30 * time.Second
+	// ruleCacheTTL is how long the rules of listeners are cached.
+	ruleCacheTTL = 30 * time.Second
+)
+
+// describeCache caches described ELBV2 resources for a short TTL, so that reconciles of many ingresses sharing them
+// don't describe them again and again. Entries are updated or invalidated by the mutations made through Cloud, while
+// changes made outside of controller are seen once entries expire. A nil describeCache caches nothing.
+type describeCache struct {
+	ttl   time.Duration
+	clock clock.Clock
+
+	mutex   sync.Mutex
+	entries map[string]describeCacheEntry
+}
+
+type describeCacheEntry struct {
+	value  interface{}
+	expiry time.Time
+}
+
+func newDescribeCache(ttl time.Duration, clk clock.Clock) *describeCache {
+	return &describeCache{
+		ttl:     ttl,
+		clock:   clk,
+		entries: make(map[string]describeCacheEntry),
+	}
+}
+
+// get returns the value cached for key, if it's not expired.
+func (c *describeCache) get(key string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.clock.Now().Before(entry.expiry) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// set caches value for key, which mustn't be modified afterwards.
+func (c *describeCache) set(key string, value interface{}) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries[key] = describeCacheEntry{value: value, expiry: c.clock.Now().Add(c.ttl)}
+}
+
+// delete invalidates the values cached for key.
+func (c *describeCache) delete(key string) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.entries, key)
+}
+
+// update replaces the value cached for key with the result of fn, keeping its expiry. Nothing is cached if key isn't.
+func (c *describeCache) update(key string, fn func(value interface{}) interface{}) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if entry, ok := c.entries[key]; ok {
+		c.entries[key] = describeCacheEntry{value: fn(entry.value), expiry: entry.expiry}
+	}
+}
+
+// flush invalidates all cached values.
+func (c *describeCache) flush() {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries = make(map[string]describeCacheEntry)
+}
+
+// nameKey is the key of the ARN or ID of resources cached by name, resources themselves are cached by ARN or ID.
+func nameKey(name string) string {
+	return "name/" + name
+}
+
+// targetGroupByArn returns a copy of the targetGroup cached by ARN.
+func (c *describeCache) targetGroupByArn(arn string) (*elbv2.TargetGroup, bool) {
+	tg, ok := c.get(arn)
+	if !ok {
+		return nil, false
+	}
+	return awsutil.CopyOf(tg).(*elbv2.TargetGroup), true
+}
+
+// targetGroupByName returns a copy of the targetGroup cached by name.
+func (c *describeCache) targetGroupByName(name string) (*elbv2.TargetGroup, bool) {
+	arn, ok := c.get(nameKey(name))
+	if !ok {
+		return nil, false
+	}
+	return c.targetGroupByArn(arn.(string))
+}
+
+// cacheTargetGroup caches a copy of tg by its ARN and name.
+func (c *describeCache) cacheTargetGroup(tg *elbv2.TargetGroup) {
+	if tg == nil || tg.TargetGroupArn == nil {
+		return
+	}
+	c.set(*tg.TargetGroupArn, awsutil.CopyOf(tg))
+	if tg.TargetGroupName != nil {
+		c.set(nameKey(*tg.TargetGroupName), *tg.TargetGroupArn)
+	}
+}
+
+// listeners returns a copy of the listeners of LoadBalancer lbArn cached.
+func (c *describeCache) listeners(lbArn string) ([]*elbv2.Listener, bool) {
+	listeners, ok := c.get(lbArn)
+	if !ok {
+		return nil, false
+	}
+	return copyListeners(listeners.([]*elbv2.Listener)), true
+}
+
+// cacheListeners caches a copy of the listeners of LoadBalancer lbArn.
+func (c *describeCache) cacheListeners(lbArn string, listeners []*elbv2.Listener) {
+	c.set(lbArn, copyListeners(listeners))
+}
+
+func copyListeners(listeners []*elbv2.Listener) []*elbv2.Listener {
+	if listeners == nil {
+		return nil
+	}
+	out := make([]*elbv2.Listener, 0, len(listeners))
+	for _, ls := range listeners {
+		out = append(out, awsutil.CopyOf(ls).(*elbv2.Listener))
+	}
+	return out
+}
+
+// rules returns a copy of the rules of listener lsArn cached.
+func (c *describeCache) rules(lsArn string) ([]*elbv2.Rule, bool) {
+	rules, ok := c.get(lsArn)
+	if !ok {
+		return nil, false
+	}
+	return copyRules(rules.([]*elbv2.Rule)), true
+}
+
+// cacheRules caches a copy of the rules of listener lsArn.
+func (c *describeCache) cacheRules(lsArn string, rules []*elbv2.Rule) {
+	c.set(lsArn, copyRules(rules))
+}
+
+// putRules adds or replaces a copy of rules into the rules cached for their listeners, so that the changes made through Cloud
+// are seen before the cached rules expire and are described again.
+func (c *describeCache) putRules(rules []*elbv2.Rule) {
+	for _, rule := range rules {
+		rule := awsutil.CopyOf(rule).(*elbv2.Rule)
+		ruleArn := aws.StringValue(rule.RuleArn)
+		lsArn, ok := listenerArnOfRule(ruleArn)
+		if !ok {
+			c.flush()
+			return
+		}
+		c.update(lsArn, func(value interface{}) interface{} {
+			rules := append(withoutRule(value.([]*elbv2.Rule), ruleArn), rule)
+			sort.Slice(rules, func(i, j int) bool {
+				return rulePriority(rules[i]) < rulePriority(rules[j])
+			})
+			return rules
+		})
+	}
+}
+
+// removeRule removes rule ruleArn from the rules cached for its listener.
+func (c *describeCache) removeRule(ruleArn string) {
+	lsArn, ok := listenerArnOfRule(ruleArn)
+	if !ok {
+		c.flush()
+		return
+	}
+	c.update(lsArn, func(value interface{}) interface{} {
+		return withoutRule(value.([]*elbv2.Rule), ruleArn)
+	})
+}
+
+func withoutRule(rules []*elbv2.Rule, ruleArn string) []*elbv2.Rule {
+	out := make([]*elbv2.Rule, 0, len(rules))
+	for _, rule := range rules {
+		if aws.StringValue(rule.RuleArn) != ruleArn {
+			out = append(out, rule)
+		}
+	}
+	return out
+}
+
+// rulePriority orders rules as DescribeRules does, the default rule last.
+func rulePriority(rule *elbv2.Rule) int64 {
+	if aws.BoolValue(rule.IsDefault) {
+		return math.MaxInt64
+	}
+	priority, _ := strconv.ParseInt(aws.StringValue(rule.Priority), 10, 64)
+	return priority
+}
+
+func copyRules(rules []*elbv2.Rule) []*elbv2.Rule {
+	if rules == nil {
+		return nil
+	}
+	out := make([]*elbv2.Rule, 0, len(rules))
+	for _, rule := range rules {
+		out = append(out, awsutil.CopyOf(rule).(*elbv2.Rule))
+	}
+	return out
+}
+
+// listenerArnOfRule returns the ARN of the listener of rule ruleArn, e.g.
+// arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2 for
+// arn:aws:elasticloadbalancing:us-west-2:123456789012:listener-rule/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2/9683b2d02a6cabee.
+func listenerArnOfRule(ruleArn string) (string, bool) {
+	i := strings.LastIndex(ruleArn, "/")
+	if i < 0 || !strings.Contains(ruleArn, ":listener-rule/") {
+		return "", false
+	}
+	return strings.Replace(ruleArn[:i], ":listener-rule/", ":listener/", 1), true
+}
+
+// loadBalancerArnOfListener returns the ARN of the LoadBalancer of listener lsArn, e.g.
+// arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188 for
+// arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2.
+func loadBalancerArnOfListener(lsArn string) (string, bool) {
+	i := strings.LastIndex(lsArn, "/")
+	if i < 0 || !strings.Contains(lsArn, ":listener/") {
+		return "", false
+	}
+	return strings.Replace(lsArn[:i], ":listener/", ":loadbalancer/", 1), true
+}
@@ -0,0 +1,194 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+func TestCloud_targetGroupCache(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewFakeClock(time.Now())
+	tg := &elbv2.TargetGroup{TargetGroupArn: aws.String("tgArn"), TargetGroupName: aws.String("tgName"), HealthCheckPath: aws.String("/")}
+	svc := &mocks.ELBV2API{}
+	svc.On("DescribeTargetGroupsPages",
+		&elbv2.DescribeTargetGroupsInput{TargetGroupArns: []*string{aws.String("tgArn")}},
+		mock.AnythingOfType("func(*elbv2.DescribeTargetGroupsOutput, bool) bool"),
+	).Return(nil).Run(func(args mock.Arguments) {
+		arg := args.Get(1).(func(output *elbv2.DescribeTargetGroupsOutput, _ bool) bool)
+		arg(&elbv2.DescribeTargetGroupsOutput{TargetGroups: []*elbv2.TargetGroup{tg}}, false)
+	}).Twice()
+	modified := &elbv2.TargetGroup{TargetGroupArn: aws.String("tgArn"), TargetGroupName: aws.String("tgName"), HealthCheckPath: aws.String("/healthz")}
+	svc.On("ModifyTargetGroupWithContext", ctx, &elbv2.ModifyTargetGroupInput{
+		TargetGroupArn:  aws.String("tgArn"),
+		HealthCheckPath: aws.String("/healthz"),
+	}).Return(&elbv2.ModifyTargetGroupOutput{TargetGroups: []*elbv2.TargetGroup{modified}}, nil)
+	svc.On("DeleteTargetGroupWithContext", ctx, &elbv2.DeleteTargetGroupInput{
+		TargetGroupArn: aws.String("tgArn"),
+	}).Return(&elbv2.DeleteTargetGroupOutput{}, nil)
+	cloud := &Cloud{elbv2: svc, targetGroups: newDescribeCache(targetGroupCacheTTL, clk)}
+
+	// targetGroups are described once, and cached by ARN and name.
+	for i := 0; i < 2; i++ {
+		got, err := cloud.GetTargetGroupByArn(ctx, "tgArn")
+		assert.NoError(t, err)
+		assert.Equal(t, tg, got)
+	}
+	got, err := cloud.GetTargetGroupByName(ctx, "tgName")
+	assert.NoError(t, err)
+	assert.Equal(t, tg, got)
+
+	// cached targetGroups are copies.
+	got.HealthCheckPath = aws.String("/changed")
+	got, _ = cloud.GetTargetGroupByArn(ctx, "tgArn")
+	assert.Equal(t, "/", aws.StringValue(got.HealthCheckPath))
+
+	// modifications are written through.
+	_, err = cloud.ModifyTargetGroupWithContext(ctx, &elbv2.ModifyTargetGroupInput{
+		TargetGroupArn:  aws.String("tgArn"),
+		HealthCheckPath: aws.String("/healthz"),
+	})
+	assert.NoError(t, err)
+	got, _ = cloud.GetTargetGroupByArn(ctx, "tgArn")
+	assert.Equal(t, modified, got)
+
+	// deletions invalidate the cache, and targetGroups are described again.
+	assert.NoError(t, cloud.DeleteTargetGroupByArn(ctx, "tgArn"))
+	got, _ = cloud.GetTargetGroupByArn(ctx, "tgArn")
+	assert.Equal(t, tg, got)
+
+	// cached targetGroups expire.
+	clk.Step(targetGroupCacheTTL)
+	_, ok := cloud.targetGroups.targetGroupByArn("tgArn")
+	assert.False(t, ok)
+	svc.AssertExpectations(t)
+}
+
+func TestCloud_listenerCache(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewFakeClock(time.Now())
+	lbArn := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188"
+	lsArn := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2"
+	listeners := []*elbv2.Listener{{ListenerArn: aws.String(lsArn), Port: aws.Int64(80)}}
+	svc := &mocks.ELBV2API{}
+	svc.On("DescribeListenersPagesWithContext",
+		ctx,
+		&elbv2.DescribeListenersInput{LoadBalancerArn: aws.String(lbArn)},
+		mock.AnythingOfType("func(*elbv2.DescribeListenersOutput, bool) bool"),
+	).Return(nil).Run(func(args mock.Arguments) {
+		arg := args.Get(2).(func(*elbv2.DescribeListenersOutput, bool) bool)
+		arg(&elbv2.DescribeListenersOutput{Listeners: listeners}, false)
+	}).Twice()
+	svc.On("ModifyListenerWithContext", ctx, &elbv2.ModifyListenerInput{
+		ListenerArn: aws.String(lsArn),
+		Port:        aws.Int64(8080),
+	}).Return(&elbv2.ModifyListenerOutput{}, nil)
+	cloud := &Cloud{elbv2: svc, listeners: newDescribeCache(listenerCacheTTL, clk)}
+
+	for i := 0; i < 2; i++ {
+		got, err := cloud.ListListenersByLoadBalancer(ctx, lbArn)
+		assert.NoError(t, err)
+		assert.Equal(t, listeners, got)
+	}
+
+	_, err := cloud.ModifyListenerWithContext(ctx, &elbv2.ModifyListenerInput{
+		ListenerArn: aws.String(lsArn),
+		Port:        aws.Int64(8080),
+	})
+	assert.NoError(t, err)
+	got, err := cloud.ListListenersByLoadBalancer(ctx, lbArn)
+	assert.NoError(t, err)
+	assert.Equal(t, listeners, got)
+	svc.AssertExpectations(t)
+}
+
+func Test_loadBalancerArnOfListener(t *testing.T) {
+	for _, tc := range []struct {
+		lsArn    string
+		expected string
+		ok       bool
+	}{
+		{
+			lsArn:    "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2",
+			expected: "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188",
+			ok:       true,
+		},
+		{
+			lsArn: "listenerArn",
+		},
+	} {
+		t.Run(tc.lsArn, func(t *testing.T) {
+			lbArn, ok := loadBalancerArnOfListener(tc.lsArn)
+			assert.Equal(t, tc.expected, lbArn)
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
+
+func TestCloud_ruleCache(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewFakeClock(time.Now())
+	lsArn := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2"
+	ruleArn1 := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener-rule/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2/9683b2d02a6cabee"
+	ruleArn2 := "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener-rule/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2/1f8a1d0ce8a3f2b4"
+	defaultRule := &elbv2.Rule{RuleArn: aws.String("defaultRuleArn"), Priority: aws.String("default"), IsDefault: aws.Bool(true)}
+	rule1 := &elbv2.Rule{RuleArn: aws.String(ruleArn1), Priority: aws.String("2")}
+	rule2 := &elbv2.Rule{RuleArn: aws.String(ruleArn2), Priority: aws.String("1")}
+	svc := &mocks.ELBV2API{}
+	svc.On("DescribeRulesRequest",
+		&elbv2.DescribeRulesInput{ListenerArn: aws.String(lsArn)},
+	).Return(newReq(&elbv2.DescribeRulesOutput{Rules: []*elbv2.Rule{rule1, defaultRule}}, nil), nil).Once()
+	svc.On("CreateRuleWithContext", ctx, &elbv2.CreateRuleInput{ListenerArn: aws.String(lsArn)}).
+		Return(&elbv2.CreateRuleOutput{Rules: []*elbv2.Rule{rule2}}, nil)
+	svc.On("DeleteRuleWithContext", ctx, &elbv2.DeleteRuleInput{RuleArn: aws.String(ruleArn1)}).
+		Return(&elbv2.DeleteRuleOutput{}, nil)
+	cloud := &Cloud{elbv2: svc, rules: newDescribeCache(ruleCacheTTL, clk)}
+
+	got, err := cloud.GetRules(ctx, lsArn)
+	assert.NoError(t, err)
+	assert.Equal(t, []*elbv2.Rule{rule1, defaultRule}, got)
+
+	// changes are written through to the cached rules, in the order of their priorities.
+	_, err = cloud.CreateRuleWithContext(ctx, &elbv2.CreateRuleInput{ListenerArn: aws.String(lsArn)})
+	assert.NoError(t, err)
+	got, err = cloud.GetRules(ctx, lsArn)
+	assert.NoError(t, err)
+	assert.Equal(t, []*elbv2.Rule{rule2, rule1, defaultRule}, got)
+
+	_, err = cloud.DeleteRuleWithContext(ctx, &elbv2.DeleteRuleInput{RuleArn: aws.String(ruleArn1)})
+	assert.NoError(t, err)
+	got, err = cloud.GetRules(ctx, lsArn)
+	assert.NoError(t, err)
+	assert.Equal(t, []*elbv2.Rule{rule2, defaultRule}, got)
+	svc.AssertExpectations(t)
+}
+
+func Test_listenerArnOfRule(t *testing.T) {
+	for _, tc := range []struct {
+		ruleArn  string
+		expected string
+		ok       bool
+	}{
+		{
+			ruleArn:  "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener-rule/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2/9683b2d02a6cabee",
+			expected: "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2",
+			ok:       true,
+		},
+		{
+			ruleArn: "ruleArn",
+		},
+	} {
+		t.Run(tc.ruleArn, func(t *testing.T) {
+			lsArn, ok := listenerArnOfRule(tc.ruleArn)
+			assert.Equal(t, tc.expected, lsArn)
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
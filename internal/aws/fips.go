@@ -0,0 +1,51 @@
+package aws
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/wafregional"
+)
+
+// fipsServices are the IDs of regional services with FIPS endpoints, whose hostname is the one of the standard endpoint
+// with "-fips" appended to the service label, e.g. elasticloadbalancing-fips.us-west-2.amazonaws.com.
+var fipsServices = map[string]bool{
+	acm.EndpointsID:         true,
+	cloudwatch.EndpointsID:  true,
+	ec2.EndpointsID:         true,
+	elbv2.EndpointsID:       true,
+	wafregional.EndpointsID: true,
+}
+
+// fipsResolver resolves FIPS endpoints of services in fipsServices, and standard endpoints of other services.
+type fipsResolver struct {
+	resolver endpoints.Resolver
+}
+
+// newFIPSResolver returns a resolver of FIPS endpoints where available, falling back to the default resolver.
+func newFIPSResolver() endpoints.Resolver {
+	return &fipsResolver{resolver: endpoints.DefaultResolver()}
+}
+
+func (r *fipsResolver) EndpointFor(service, region string, opts ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+	resolved, err := r.resolver.EndpointFor(service, region, opts...)
+	if err != nil || !fipsServices[service] {
+		return resolved, err
+	}
+	u, err := url.Parse(resolved.URL)
+	if err != nil {
+		return resolved, err
+	}
+	labels := strings.SplitN(u.Host, ".", 2)
+	if len(labels) != 2 || strings.HasSuffix(labels[0], "-fips") {
+		return resolved, nil
+	}
+	u.Host = labels[0] + "-fips." + labels[1]
+	resolved.URL = u.String()
+	return resolved, nil
+}
@@ -0,0 +1,39 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFIPSResolver_EndpointFor(t *testing.T) {
+	for _, tc := range []struct {
+		service  string
+		region   string
+		expected string
+	}{
+		{
+			service:  elbv2.EndpointsID,
+			region:   "us-west-2",
+			expected: "https://elasticloadbalancing-fips.us-west-2.amazonaws.com",
+		},
+		{
+			service:  elbv2.EndpointsID,
+			region:   "us-gov-west-1",
+			expected: "https://elasticloadbalancing-fips.us-gov-west-1.amazonaws.com",
+		},
+		{
+			service:  resourcegroupstaggingapi.EndpointsID,
+			region:   "us-west-2",
+			expected: "https://tagging.us-west-2.amazonaws.com",
+		},
+	} {
+		t.Run(tc.service+"/"+tc.region, func(t *testing.T) {
+			resolved, err := newFIPSResolver().EndpointFor(tc.service, tc.region)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, resolved.URL)
+		})
+	}
+}
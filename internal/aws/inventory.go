@@ -0,0 +1,119 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+)
+
+const (
+	// inventoryDescribeBatchSize is the number of new targetGroups described per call when refreshing the inventory.
+	inventoryDescribeBatchSize = 20
+
+	// tagKeySGClusterName is the tag identifying the cluster of securityGroups, which aren't tagged with the cluster tag.
+	tagKeySGClusterName = "kubernetes.io/cluster-name"
+)
+
+// InventoryAPI keeps the in-memory inventory of the AWS resources of cluster in sync with AWS.
+// Reconciles read LoadBalancers, listeners, rules, targetGroups and securityGroups from the inventory, and the changes they make
+// are written through to it, so they don't describe resources while diffing them. The inventory follows the changes made outside
+// of controller as its entries expire, or sooner with RefreshInventory.
+type InventoryAPI interface {
+	// RefreshInventory lists the LoadBalancers, targetGroups and securityGroups tagged for the cluster, describes the new
+	// targetGroups into the inventory and forgets the resources that no longer exist, so that only the resources that
+	// changed since the last refresh are described. LoadBalancers are indexed by the sweeps of all LoadBalancers of the region.
+	RefreshInventory(ctx context.Context) error
+}
+
+// clusterInventory is the state of RefreshInventory.
+type clusterInventory struct {
+	mutex sync.Mutex
+	// arns are the ARNs of the resources tagged for the cluster at the last refresh, nil before the first one.
+	arns map[string]bool
+}
+
+func (c *Cloud) RefreshInventory(ctx context.Context) error {
+	current, err := c.listClusterResources()
+	if err != nil {
+		return fmt.Errorf("failed to list resources of cluster due to %v", err)
+	}
+
+	c.inventory.mutex.Lock()
+	defer c.inventory.mutex.Unlock()
+	var newTGs []string
+	for arn := range current {
+		if !c.inventory.arns[arn] && strings.Contains(arn, ":targetgroup/") {
+			newTGs = append(newTGs, arn)
+		}
+	}
+	for arn := range c.inventory.arns {
+		if !current[arn] {
+			albctx.GetLogger(ctx).Infof("%v no longer exists, removing it from inventory", arn)
+			c.forget(arn)
+		}
+	}
+
+	for _, batch := range inventoryBatches(newTGs) {
+		targetGroups, err := c.describeTargetGroupsHelper(&elbv2.DescribeTargetGroupsInput{TargetGroupArns: aws.StringSlice(batch)})
+		if err != nil {
+			return fmt.Errorf("failed to describe new targetGroups due to %v", err)
+		}
+		for _, tg := range targetGroups {
+			c.targetGroups.cacheTargetGroup(tg)
+		}
+	}
+	c.inventory.arns = current
+	return nil
+}
+
+// listClusterResources returns the ARNs of the LoadBalancers, targetGroups and securityGroups tagged for the cluster.
+func (c *Cloud) listClusterResources() (map[string]bool, error) {
+	arns := make(map[string]bool)
+	elbv2Arns, err := c.GetResourcesByFilters(map[string][]string{
+		TagNameCluster + "/" + c.clusterName: {"owned", "shared"},
+	}, ResourceTypeEnumELBLoadBalancer, ResourceTypeEnumELBTargetGroup)
+	if err != nil {
+		return nil, err
+	}
+	sgArns, err := c.GetResourcesByFilters(map[string][]string{
+		tagKeySGClusterName: {c.clusterName},
+	}, ResourceTypeEnumEC2SecurityGroup)
+	if err != nil {
+		return nil, err
+	}
+	for _, arn := range append(elbv2Arns, sgArns...) {
+		arns[arn] = true
+	}
+	return arns, nil
+}
+
+// forget removes the resource of arn from the inventory.
+func (c *Cloud) forget(arn string) {
+	switch {
+	case strings.Contains(arn, ":loadbalancer/app/"):
+		c.loadBalancers.invalidate(arn)
+		c.listeners.delete(arn)
+	case strings.Contains(arn, ":targetgroup/"):
+		c.targetGroups.delete(arn)
+	case strings.Contains(arn, ":security-group/"):
+		c.securityGroups.delete(arn[strings.LastIndex(arn, "/")+1:])
+	}
+}
+
+// inventoryBatches splits arns into batches of inventoryDescribeBatchSize.
+func inventoryBatches(arns []string) [][]string {
+	var batches [][]string
+	for len(arns) > inventoryDescribeBatchSize {
+		batches = append(batches, arns[:inventoryDescribeBatchSize])
+		arns = arns[inventoryDescribeBatchSize:]
+	}
+	if len(arns) > 0 {
+		batches = append(batches, arns)
+	}
+	return batches
+}
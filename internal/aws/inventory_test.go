@@ -0,0 +1,115 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+func TestCloud_RefreshInventory(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewFakeClock(time.Now())
+	lbArn := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188"
+	tgArn1 := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg1/73e2d6bc24d8a067"
+	tgArn2 := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg2/2453ed029918f21f"
+	sgArn := "arn:aws:ec2:us-west-2:123456789012:security-group/sg-0123456789abcdef0"
+	tg1 := &elbv2.TargetGroup{TargetGroupArn: aws.String(tgArn1), TargetGroupName: aws.String("tg1")}
+	tg2 := &elbv2.TargetGroup{TargetGroupArn: aws.String(tgArn2), TargetGroupName: aws.String("tg2")}
+
+	rgtsvc := &mocks.ResourceGroupsTaggingAPIAPI{}
+	elbv2svc := &mocks.ELBV2API{}
+	cloud := &Cloud{
+		clusterName:    "cluster",
+		rgt:            rgtsvc,
+		elbv2:          elbv2svc,
+		targetGroups:   newDescribeCache(targetGroupCacheTTL, clk),
+		listeners:      newDescribeCache(listenerCacheTTL, clk),
+		securityGroups: newDescribeCache(securityGroupCacheTTL, clk),
+	}
+	refresh := func(elbv2Arns []string, sgArns []string) {
+		rgtsvc.On("GetResourcesPages",
+			&resourcegroupstaggingapi.GetResourcesInput{
+				ResourceTypeFilters: aws.StringSlice([]string{ResourceTypeEnumELBLoadBalancer, ResourceTypeEnumELBTargetGroup}),
+				TagFilters: []*resourcegroupstaggingapi.TagFilter{
+					{Key: aws.String("kubernetes.io/cluster/cluster"), Values: aws.StringSlice([]string{"owned", "shared"})},
+				},
+			},
+			mock.AnythingOfType("func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool"),
+		).Return(nil).Run(func(args mock.Arguments) {
+			arg := args.Get(1).(func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool)
+			arg(resourcesOutput(elbv2Arns), false)
+		}).Once()
+		rgtsvc.On("GetResourcesPages",
+			&resourcegroupstaggingapi.GetResourcesInput{
+				ResourceTypeFilters: aws.StringSlice([]string{ResourceTypeEnumEC2SecurityGroup}),
+				TagFilters: []*resourcegroupstaggingapi.TagFilter{
+					{Key: aws.String("kubernetes.io/cluster-name"), Values: aws.StringSlice([]string{"cluster"})},
+				},
+			},
+			mock.AnythingOfType("func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool"),
+		).Return(nil).Run(func(args mock.Arguments) {
+			arg := args.Get(1).(func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool)
+			arg(resourcesOutput(sgArns), false)
+		}).Once()
+		assert.NoError(t, cloud.RefreshInventory(ctx))
+	}
+	for _, tg := range []*elbv2.TargetGroup{tg1, tg2} {
+		tg := tg
+		elbv2svc.On("DescribeTargetGroupsPages",
+			&elbv2.DescribeTargetGroupsInput{TargetGroupArns: []*string{tg.TargetGroupArn}},
+			mock.AnythingOfType("func(*elbv2.DescribeTargetGroupsOutput, bool) bool"),
+		).Return(nil).Run(func(args mock.Arguments) {
+			arg := args.Get(1).(func(*elbv2.DescribeTargetGroupsOutput, bool) bool)
+			arg(&elbv2.DescribeTargetGroupsOutput{TargetGroups: []*elbv2.TargetGroup{tg}}, false)
+		}).Once()
+	}
+
+	// new targetGroups are described into the inventory.
+	refresh([]string{lbArn, tgArn1}, []string{sgArn})
+	got, err := cloud.GetTargetGroupByName(ctx, "tg1")
+	assert.NoError(t, err)
+	assert.Equal(t, tg1, got)
+
+	// only targetGroups new since the last refresh are described, and deleted resources are forgotten.
+	cloud.listeners.cacheListeners(lbArn, []*elbv2.Listener{})
+	cloud.securityGroups.set("sg-0123456789abcdef0", nil)
+	refresh([]string{tgArn1, tgArn2}, nil)
+	_, ok := cloud.targetGroups.targetGroupByArn(tgArn2)
+	assert.True(t, ok)
+	_, ok = cloud.listeners.listeners(lbArn)
+	assert.False(t, ok)
+	_, ok = cloud.securityGroups.get("sg-0123456789abcdef0")
+	assert.False(t, ok)
+
+	refresh([]string{tgArn2}, nil)
+	_, ok = cloud.targetGroups.targetGroupByArn(tgArn1)
+	assert.False(t, ok)
+	rgtsvc.AssertExpectations(t)
+	elbv2svc.AssertExpectations(t)
+}
+
+func Test_inventoryBatches(t *testing.T) {
+	arns := make([]string, 45)
+	batches := inventoryBatches(arns)
+	assert.Len(t, batches, 3)
+	assert.Len(t, batches[0], 20)
+	assert.Len(t, batches[1], 20)
+	assert.Len(t, batches[2], 5)
+	assert.Empty(t, inventoryBatches(nil))
+}
+
+func resourcesOutput(arns []string) *resourcegroupstaggingapi.GetResourcesOutput {
+	output := &resourcegroupstaggingapi.GetResourcesOutput{}
+	for _, arn := range arns {
+		output.ResourceTagMappingList = append(output.ResourceTagMappingList, &resourcegroupstaggingapi.ResourceTagMapping{ResourceARN: aws.String(arn)})
+	}
+	return output
+}
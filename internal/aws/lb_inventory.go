@@ -0,0 +1,139 @@
+package aws
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+// loadBalancerInventoryTTL is how often all LoadBalancers of the region are described.
+const loadBalancerInventoryTTL = time.Minute
+
+// loadBalancerInventory indexes all LoadBalancers of the region by name and ARN, from a single sweep of DescribeLoadBalancers
+// per loadBalancerInventoryTTL, so that looking up the LoadBalancer of each ingress doesn't take an AWS call.
+// It's updated by the changes made through Cloud. LoadBalancers missing from it, or not active, are looked up directly, so
+// LoadBalancers created outside of controller since the last sweep and transitions of their state are seen immediately.
+// A nil loadBalancerInventory knows no LoadBalancer.
+type loadBalancerInventory struct {
+	elbv2 elbv2iface.ELBV2API
+	ttl   time.Duration
+	clock clock.Clock
+
+	mutex  sync.Mutex
+	byName map[string]*elbv2.LoadBalancer
+	byArn  map[string]*elbv2.LoadBalancer
+	expiry time.Time
+}
+
+func newLoadBalancerInventory(elbv2Client elbv2iface.ELBV2API, clk clock.Clock) *loadBalancerInventory {
+	return &loadBalancerInventory{
+		elbv2: elbv2Client,
+		ttl:   loadBalancerInventoryTTL,
+		clock: clk,
+	}
+}
+
+// getByName returns a copy of the active LoadBalancer named name, and whether it's known.
+func (inv *loadBalancerInventory) getByName(ctx context.Context, name string) (*elbv2.LoadBalancer, bool) {
+	if inv == nil {
+		return nil, false
+	}
+	inv.mutex.Lock()
+	defer inv.mutex.Unlock()
+	inv.refresh(ctx)
+	return activeCopy(inv.byName[name])
+}
+
+// getByArn returns a copy of the active LoadBalancer of arn, and whether it's known.
+func (inv *loadBalancerInventory) getByArn(ctx context.Context, arn string) (*elbv2.LoadBalancer, bool) {
+	if inv == nil {
+		return nil, false
+	}
+	inv.mutex.Lock()
+	defer inv.mutex.Unlock()
+	inv.refresh(ctx)
+	return activeCopy(inv.byArn[arn])
+}
+
+// put adds or updates a copy of lb.
+func (inv *loadBalancerInventory) put(lb *elbv2.LoadBalancer) {
+	if inv == nil || lb == nil {
+		return
+	}
+	inv.mutex.Lock()
+	defer inv.mutex.Unlock()
+	if inv.byArn == nil {
+		return
+	}
+	inv.remove(aws.StringValue(lb.LoadBalancerArn))
+	lb = awsutil.CopyOf(lb).(*elbv2.LoadBalancer)
+	inv.byName[aws.StringValue(lb.LoadBalancerName)] = lb
+	inv.byArn[aws.StringValue(lb.LoadBalancerArn)] = lb
+}
+
+// invalidate forgets the LoadBalancer of arn, e.g. once it's deleted or modified, so it's looked up directly next time.
+func (inv *loadBalancerInventory) invalidate(arn string) {
+	if inv == nil {
+		return
+	}
+	inv.mutex.Lock()
+	defer inv.mutex.Unlock()
+	inv.remove(arn)
+}
+
+// flush forgets all LoadBalancers, so they're described again by the next lookup.
+func (inv *loadBalancerInventory) flush() {
+	if inv == nil {
+		return
+	}
+	inv.mutex.Lock()
+	defer inv.mutex.Unlock()
+	inv.byName, inv.byArn, inv.expiry = nil, nil, time.Time{}
+}
+
+func (inv *loadBalancerInventory) remove(arn string) {
+	if lb, ok := inv.byArn[arn]; ok {
+		delete(inv.byName, aws.StringValue(lb.LoadBalancerName))
+		delete(inv.byArn, arn)
+	}
+}
+
+// refresh describes all LoadBalancers once expired. If they can't be described, no LoadBalancer is known until the next refresh.
+func (inv *loadBalancerInventory) refresh(ctx context.Context) {
+	if inv.clock.Now().Before(inv.expiry) {
+		return
+	}
+	inv.byName = make(map[string]*elbv2.LoadBalancer)
+	inv.byArn = make(map[string]*elbv2.LoadBalancer)
+	inv.expiry = inv.clock.Now().Add(inv.ttl)
+	err := inv.elbv2.DescribeLoadBalancersPagesWithContext(ctx, &elbv2.DescribeLoadBalancersInput{}, func(output *elbv2.DescribeLoadBalancersOutput, _ bool) bool {
+		if output == nil {
+			return false
+		}
+		for _, lb := range output.LoadBalancers {
+			inv.byName[aws.StringValue(lb.LoadBalancerName)] = lb
+			inv.byArn[aws.StringValue(lb.LoadBalancerArn)] = lb
+		}
+		return true
+	})
+	if err != nil {
+		albctx.GetLogger(ctx).Warnf("failed to describe LoadBalancers due to %v, looking them up individually until next refresh", err)
+		inv.byName = make(map[string]*elbv2.LoadBalancer)
+		inv.byArn = make(map[string]*elbv2.LoadBalancer)
+	}
+}
+
+// activeCopy returns a copy of lb if it's active, LoadBalancers in other states are expected to change soon.
+func activeCopy(lb *elbv2.LoadBalancer) (*elbv2.LoadBalancer, bool) {
+	if lb == nil || lb.State == nil || aws.StringValue(lb.State.Code) != elbv2.LoadBalancerStateEnumActive {
+		return nil, false
+	}
+	return awsutil.CopyOf(lb).(*elbv2.LoadBalancer), true
+}
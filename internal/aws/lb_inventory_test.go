@@ -0,0 +1,93 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+func TestCloud_loadBalancerInventory(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewFakeClock(time.Now())
+	active := &elbv2.LoadBalancerState{Code: aws.String(elbv2.LoadBalancerStateEnumActive)}
+	provisioning := &elbv2.LoadBalancerState{Code: aws.String(elbv2.LoadBalancerStateEnumProvisioning)}
+	lb1 := &elbv2.LoadBalancer{LoadBalancerName: aws.String("lb1"), LoadBalancerArn: aws.String("lbArn1"), State: active}
+	lb2 := &elbv2.LoadBalancer{LoadBalancerName: aws.String("lb2"), LoadBalancerArn: aws.String("lbArn2"), State: active}
+	lb3 := &elbv2.LoadBalancer{LoadBalancerName: aws.String("lb3"), LoadBalancerArn: aws.String("lbArn3"), State: provisioning}
+
+	svc := &mocks.ELBV2API{}
+	svc.On("DescribeLoadBalancersPagesWithContext",
+		ctx,
+		&elbv2.DescribeLoadBalancersInput{},
+		mock.AnythingOfType("func(*elbv2.DescribeLoadBalancersOutput, bool) bool"),
+	).Return(nil).Run(func(args mock.Arguments) {
+		arg := args.Get(2).(func(*elbv2.DescribeLoadBalancersOutput, bool) bool)
+		arg(&elbv2.DescribeLoadBalancersOutput{LoadBalancers: []*elbv2.LoadBalancer{lb1, lb2, lb3}}, false)
+	}).Twice()
+	// LoadBalancers that aren't active or unknown are looked up directly.
+	for _, name := range []string{"lb3", "lb4"} {
+		name := name
+		svc.On("DescribeLoadBalancersPages",
+			&elbv2.DescribeLoadBalancersInput{Names: []*string{aws.String(name)}},
+			mock.AnythingOfType("func(*elbv2.DescribeLoadBalancersOutput, bool) bool"),
+		).Return(nil).Run(func(args mock.Arguments) {
+			arg := args.Get(1).(func(*elbv2.DescribeLoadBalancersOutput, bool) bool)
+			if name == "lb3" {
+				arg(&elbv2.DescribeLoadBalancersOutput{LoadBalancers: []*elbv2.LoadBalancer{lb3}}, false)
+			} else {
+				arg(&elbv2.DescribeLoadBalancersOutput{}, false)
+			}
+		}).Once()
+	}
+	svc.On("DescribeLoadBalancersPages",
+		&elbv2.DescribeLoadBalancersInput{LoadBalancerArns: []*string{aws.String("lbArn1")}},
+		mock.AnythingOfType("func(*elbv2.DescribeLoadBalancersOutput, bool) bool"),
+	).Return(nil).Run(func(args mock.Arguments) {
+		arg := args.Get(1).(func(*elbv2.DescribeLoadBalancersOutput, bool) bool)
+		arg(&elbv2.DescribeLoadBalancersOutput{LoadBalancers: []*elbv2.LoadBalancer{lb1}}, false)
+	}).Once()
+	svc.On("SetSubnetsWithContext", ctx, &elbv2.SetSubnetsInput{LoadBalancerArn: aws.String("lbArn1")}).Return(&elbv2.SetSubnetsOutput{}, nil)
+	cloud := &Cloud{elbv2: svc, loadBalancers: newLoadBalancerInventory(svc, clk)}
+
+	// active LoadBalancers are found by name and ARN from a single sweep.
+	got, err := cloud.GetLoadBalancerByName(ctx, "lb1")
+	assert.NoError(t, err)
+	assert.Equal(t, lb1, got)
+	got, err = cloud.GetLoadBalancerByName(ctx, "lb2")
+	assert.NoError(t, err)
+	assert.Equal(t, lb2, got)
+	got, err = cloud.GetLoadBalancerByArn(ctx, "lbArn2")
+	assert.NoError(t, err)
+	assert.Equal(t, lb2, got)
+
+	got, err = cloud.GetLoadBalancerByName(ctx, "lb3")
+	assert.NoError(t, err)
+	assert.Equal(t, lb3, got)
+	got, err = cloud.GetLoadBalancerByName(ctx, "lb4")
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	// modified LoadBalancers are looked up directly.
+	_, err = cloud.SetSubnetsWithContext(ctx, &elbv2.SetSubnetsInput{LoadBalancerArn: aws.String("lbArn1")})
+	assert.NoError(t, err)
+	got, err = cloud.GetLoadBalancerByArn(ctx, "lbArn1")
+	assert.NoError(t, err)
+	assert.Equal(t, lb1, got)
+	got, err = cloud.GetLoadBalancerByName(ctx, "lb1")
+	assert.NoError(t, err)
+	assert.Equal(t, lb1, got)
+
+	// LoadBalancers are described again once the inventory expires.
+	clk.Step(loadBalancerInventoryTTL)
+	got, err = cloud.GetLoadBalancerByName(ctx, "lb2")
+	assert.NoError(t, err)
+	assert.Equal(t, lb2, got)
+	svc.AssertExpectations(t)
+}
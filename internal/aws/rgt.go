@@ -14,7 +14,10 @@ import (
 )
 
 const (
-	ResourceTypeEnumELBTargetGroup = "elasticloadbalancing:targetgroup"
+	ResourceTypeEnumELBLoadBalancer  = "elasticloadbalancing:loadbalancer"
+	ResourceTypeEnumELBTargetGroup   = "elasticloadbalancing:targetgroup"
+	ResourceTypeEnumACMCertificate   = "acm:certificate"
+	ResourceTypeEnumEC2SecurityGroup = "ec2:security-group"
 )
 
 type ResourceGroupsTaggingAPIAPI interface {
@@ -79,8 +82,7 @@ func (c *Cloud) GetClusterSubnets() (map[string]util.EC2Tags, error) {
 				return false
 			}
 			for _, rtm := range page.ResourceTagMappingList {
-				switch {
-				case strings.Contains(*rtm.ResourceARN, ":subnet/"):
+				if arn, err := ParseARN(aws.StringValue(rtm.ResourceARN)); err == nil && strings.HasPrefix(arn.Resource, "subnet/") {
 					subnets[*rtm.ResourceARN] = rgtTagAsEC2Tag(rtm.Tags)
 				}
 			}
@@ -0,0 +1,15 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/service/route53"
+)
+
+type Route53API interface {
+	ChangeResourceRecordSetsWithContext(context.Context, *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error)
+}
+
+func (c *Cloud) ChangeResourceRecordSetsWithContext(ctx context.Context, i *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	return c.route53.ChangeResourceRecordSetsWithContext(ctx, i)
+}
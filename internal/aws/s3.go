@@ -0,0 +1,107 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// elbAccountIDs are the accounts ELB delivers access logs from, by region.
+// see https://docs.aws.amazon.com/elasticloadbalancing/latest/application/load-balancer-access-logs.html#access-logging-bucket-permissions
+var elbAccountIDs = map[string]string{
+	"us-east-1":      "127311923021",
+	"us-east-2":      "033677994240",
+	"us-west-1":      "027434742980",
+	"us-west-2":      "797873946194",
+	"ca-central-1":   "985666609251",
+	"eu-central-1":   "054676820928",
+	"eu-west-1":      "156460612806",
+	"eu-west-2":      "652711504416",
+	"eu-west-3":      "009996457667",
+	"eu-north-1":     "897822967062",
+	"ap-northeast-1": "582318560864",
+	"ap-northeast-2": "600734575887",
+	"ap-northeast-3": "383597477331",
+	"ap-southeast-1": "114774131450",
+	"ap-southeast-2": "783225319266",
+	"ap-south-1":     "718504428378",
+	"sa-east-1":      "507241528517",
+	"us-gov-west-1":  "048591011584",
+	"us-gov-east-1":  "190560391635",
+	"cn-north-1":     "638102146993",
+	"cn-northwest-1": "037604701340",
+}
+
+// elbLogDeliveryService is the service principal that delivers access logs in regions without an ELB account.
+const elbLogDeliveryService = "logdelivery.elasticloadbalancing.amazonaws.com"
+
+// S3API is our wrapper S3 API interface
+type S3API interface {
+	// CreateBucket creates bucket in the region of controller.
+	CreateBucket(ctx context.Context, bucket string) error
+
+	// AccessLogsDeliveryPrincipal returns the bucket policy principal that ELB delivers access logs as in the region of controller.
+	AccessLogsDeliveryPrincipal() map[string]string
+
+	// S3ObjectArn returns the ARN of objects matching key in bucket, key may contain wildcards.
+	S3ObjectArn(bucket string, key string) string
+
+	HeadBucketWithContext(context.Context, *s3.HeadBucketInput) (*s3.HeadBucketOutput, error)
+	GetBucketEncryptionWithContext(context.Context, *s3.GetBucketEncryptionInput) (*s3.GetBucketEncryptionOutput, error)
+	PutBucketEncryptionWithContext(context.Context, *s3.PutBucketEncryptionInput) (*s3.PutBucketEncryptionOutput, error)
+	GetBucketLifecycleConfigurationWithContext(context.Context, *s3.GetBucketLifecycleConfigurationInput) (*s3.GetBucketLifecycleConfigurationOutput, error)
+	PutBucketLifecycleConfigurationWithContext(context.Context, *s3.PutBucketLifecycleConfigurationInput) (*s3.PutBucketLifecycleConfigurationOutput, error)
+	GetBucketPolicyWithContext(context.Context, *s3.GetBucketPolicyInput) (*s3.GetBucketPolicyOutput, error)
+	PutBucketPolicyWithContext(context.Context, *s3.PutBucketPolicyInput) (*s3.PutBucketPolicyOutput, error)
+}
+
+func (c *Cloud) HeadBucketWithContext(ctx context.Context, i *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
+	return c.s3.HeadBucketWithContext(ctx, i)
+}
+func (c *Cloud) GetBucketEncryptionWithContext(ctx context.Context, i *s3.GetBucketEncryptionInput) (*s3.GetBucketEncryptionOutput, error) {
+	return c.s3.GetBucketEncryptionWithContext(ctx, i)
+}
+func (c *Cloud) PutBucketEncryptionWithContext(ctx context.Context, i *s3.PutBucketEncryptionInput) (*s3.PutBucketEncryptionOutput, error) {
+	return c.s3.PutBucketEncryptionWithContext(ctx, i)
+}
+func (c *Cloud) GetBucketLifecycleConfigurationWithContext(ctx context.Context, i *s3.GetBucketLifecycleConfigurationInput) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+	return c.s3.GetBucketLifecycleConfigurationWithContext(ctx, i)
+}
+func (c *Cloud) PutBucketLifecycleConfigurationWithContext(ctx context.Context, i *s3.PutBucketLifecycleConfigurationInput) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+	return c.s3.PutBucketLifecycleConfigurationWithContext(ctx, i)
+}
+func (c *Cloud) GetBucketPolicyWithContext(ctx context.Context, i *s3.GetBucketPolicyInput) (*s3.GetBucketPolicyOutput, error) {
+	return c.s3.GetBucketPolicyWithContext(ctx, i)
+}
+func (c *Cloud) PutBucketPolicyWithContext(ctx context.Context, i *s3.PutBucketPolicyInput) (*s3.PutBucketPolicyOutput, error) {
+	return c.s3.PutBucketPolicyWithContext(ctx, i)
+}
+
+// CreateBucket creates bucket in the region of controller.
+func (c *Cloud) CreateBucket(ctx context.Context, bucket string) error {
+	input := &s3.CreateBucketInput{Bucket: aws.String(bucket)}
+	// us-east-1 is the default location, and is rejected as a location constraint.
+	if c.region != "us-east-1" {
+		input.CreateBucketConfiguration = &s3.CreateBucketConfiguration{LocationConstraint: aws.String(c.region)}
+	}
+	if _, err := c.s3.CreateBucketWithContext(ctx, input); err != nil {
+		return fmt.Errorf("failed to create bucket %v due to %v", bucket, err)
+	}
+	return nil
+}
+
+// AccessLogsDeliveryPrincipal returns the bucket policy principal that ELB delivers access logs as in the region of controller.
+func (c *Cloud) AccessLogsDeliveryPrincipal() map[string]string {
+	accountID, ok := elbAccountIDs[c.region]
+	if !ok {
+		return map[string]string{"Service": elbLogDeliveryService}
+	}
+	return map[string]string{"AWS": fmt.Sprintf("arn:%v:iam::%v:root", partition(c.region), accountID)}
+}
+
+// S3ObjectArn returns the ARN of objects matching key in bucket, key may contain wildcards.
+func (c *Cloud) S3ObjectArn(bucket string, key string) string {
+	return fmt.Sprintf("arn:%v:s3:::%v/%v", partition(c.region), bucket, key)
+}
@@ -0,0 +1,35 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloud_AccessLogsDeliveryPrincipal(t *testing.T) {
+	for _, tc := range []struct {
+		region            string
+		expectedPrincipal map[string]string
+		expectedArn       string
+	}{
+		{
+			region:            "us-west-2",
+			expectedPrincipal: map[string]string{"AWS": "arn:aws:iam::797873946194:root"},
+			expectedArn:       "arn:aws:s3:::bucket/prefix/AWSLogs/*",
+		},
+		{
+			region:            "cn-north-1",
+			expectedPrincipal: map[string]string{"AWS": "arn:aws-cn:iam::638102146993:root"},
+			expectedArn:       "arn:aws-cn:s3:::bucket/prefix/AWSLogs/*",
+		},
+		{
+			region:            "ap-southeast-4",
+			expectedPrincipal: map[string]string{"Service": "logdelivery.elasticloadbalancing.amazonaws.com"},
+			expectedArn:       "arn:aws:s3:::bucket/prefix/AWSLogs/*",
+		},
+	} {
+		cloud := &Cloud{region: tc.region}
+		assert.Equal(t, tc.expectedPrincipal, cloud.AccessLogsDeliveryPrincipal())
+		assert.Equal(t, tc.expectedArn, cloud.S3ObjectArn("bucket", "prefix/AWSLogs/*"))
+	}
+}
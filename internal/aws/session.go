@@ -4,25 +4,61 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/wafregional"
 	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/diagnostics"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/ticketmaster/aws-sdk-go-cache/cache"
 )
 
-// NewSession returns an AWS session based off of the provided AWS config
-func NewSession(awsconfig *aws.Config, AWSDebug bool, mc metric.Collector, cc *cache.Config) *session.Session {
-	session, err := session.NewSession(awsconfig)
+// CacheAPI controls the cache of AWS responses.
+type CacheAPI interface {
+	// FlushCache drops all cached AWS responses, so that subsequent calls see changes made outside of controller.
+	FlushCache()
+}
+
+// FlushCache drops the cached responses of all services used by controller.
+func (c *Cloud) FlushCache() {
+	if c.cache != nil {
+		for _, serviceName := range []string{
+			acm.ServiceName,
+			cloudwatch.ServiceName,
+			ec2.ServiceName,
+			elbv2.ServiceName,
+			iam.ServiceName,
+			resourcegroupstaggingapi.ServiceName,
+			route53.ServiceName,
+			s3.ServiceName,
+			wafregional.ServiceName,
+		} {
+			c.cache.FlushCache(serviceName)
+		}
+	}
+	c.targetGroups.flush()
+	c.listeners.flush()
+	c.rules.flush()
+	c.securityGroups.flush()
+	c.loadBalancers.flush()
+}
+
+// NewSession returns an AWS session based off of the provided session options
+func NewSession(opts session.Options, AWSDebug bool, mc metric.Collector, cc *cache.Config, diag *diagnostics.Diagnostics) (*session.Session, error) {
+	session, err := session.NewSessionWithOptions(opts)
 	if err != nil {
 		mc.IncAPIErrorCount(prometheus.Labels{"service": "AWS", "request": "NewSession"})
-		glog.ErrorDepth(4, fmt.Sprintf("Failed to create AWS session: %s", err.Error()))
-		return nil
+		return nil, err
 	}
 
 	// Adds caching to session
@@ -30,11 +66,19 @@ func NewSession(awsconfig *aws.Config, AWSDebug bool, mc metric.Collector, cc *c
 	cc.SetCacheTTL(resourcegroupstaggingapi.ServiceName, "GetResources", time.Hour)
 	cc.SetCacheTTL(ec2.ServiceName, "DescribeInstanceStatus", time.Minute)
 
+	session.Handlers.Build.PushFront(func(r *request.Request) {
+		diag.AWSRequestStarted()
+	})
+
 	session.Handlers.Retry.PushFront(func(r *request.Request) {
 		mc.IncAPIRetryCount(prometheus.Labels{"service": r.ClientInfo.ServiceName, "operation": r.Operation.Name})
+		if request.IsErrorThrottle(r.Error) {
+			diag.AWSAttemptThrottled()
+		}
 	})
 
 	session.Handlers.Send.PushFront(func(r *request.Request) {
+		diag.AWSAttemptSent()
 		mc.IncAPIRequestCount(prometheus.Labels{"service": r.ClientInfo.ServiceName, "operation": r.Operation.Name})
 		if AWSDebug {
 			glog.InfoDepth(4, fmt.Sprintf("Request: %s/%s, Payload: %s", r.ClientInfo.ServiceName, r.Operation.Name, log.Prettify(r.Params)))
@@ -42,6 +86,7 @@ func NewSession(awsconfig *aws.Config, AWSDebug bool, mc metric.Collector, cc *c
 	})
 
 	session.Handlers.Complete.PushFront(func(r *request.Request) {
+		diag.AWSRequestCompleted()
 		if r.Error != nil {
 			mc.IncAPIErrorCount(prometheus.Labels{"service": r.ClientInfo.ServiceName, "operation": r.Operation.Name})
 			if AWSDebug {
@@ -53,5 +98,5 @@ func NewSession(awsconfig *aws.Config, AWSDebug bool, mc metric.Collector, cc *c
 			}
 		}
 	})
-	return session
+	return session, nil
 }
@@ -0,0 +1,28 @@
+package aws
+
+import (
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/version"
+)
+
+// userAgentName identifies the controller in the user agent of AWS calls.
+const userAgentName = "aws-alb-ingress-controller"
+
+// addUserAgentHandlers adds the controller release and clusterName to the user agent of AWS calls made with sess,
+// which CloudTrail records, along with the ingress and reconcile ID of calls made while reconciling an ingress.
+func addUserAgentHandlers(sess *session.Session, clusterName string) {
+	sess.Handlers.Build.PushBack(request.MakeAddToUserAgentHandler(userAgentName, version.RELEASE, "cluster/"+clusterName))
+	sess.Handlers.Build.PushBack(addReconcileToUserAgent)
+}
+
+// addReconcileToUserAgent is a request handler adding the ingress and reconcile ID of request to its user agent.
+func addReconcileToUserAgent(r *request.Request) {
+	if ingressKey, ok := albctx.GetIngressKey(r.Context()); ok {
+		request.AddToUserAgent(r, "ingress/"+ingressKey.String())
+	}
+	if reconcileID, ok := albctx.GetReconcileID(r.Context()); ok {
+		request.AddToUserAgent(r, "reconcile/"+reconcileID)
+	}
+}
@@ -0,0 +1,40 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func Test_addReconcileToUserAgent(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		ctx      context.Context
+		expected string
+	}{
+		{
+			name:     "not reconciling",
+			ctx:      context.Background(),
+			expected: "aws-sdk-go",
+		},
+		{
+			name: "reconciling ingress",
+			ctx: albctx.SetReconcileID(
+				albctx.SetIngressKey(context.Background(), types.NamespacedName{Namespace: "namespace", Name: "ingress"}), "abcd1234"),
+			expected: "aws-sdk-go ingress/namespace/ingress reconcile/abcd1234",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &request.Request{HTTPRequest: &http.Request{Header: http.Header{}}}
+			r.HTTPRequest.Header.Set("User-Agent", "aws-sdk-go")
+			r.SetContext(tc.ctx)
+			addReconcileToUserAgent(r)
+			assert.Equal(t, tc.expected, r.HTTPRequest.Header.Get("User-Agent"))
+		})
+	}
+}
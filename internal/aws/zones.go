@@ -0,0 +1,93 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+// zoneInventoryTTL is how long the availability zones of the region are cached.
+const zoneInventoryTTL = time.Hour
+
+// Zone types, as reported by DescribeAvailabilityZones.
+const (
+	ZoneTypeAvailabilityZone = "availability-zone"
+	ZoneTypeLocalZone        = "local-zone"
+	ZoneTypeWavelengthZone   = "wavelength-zone"
+)
+
+// availabilityZonePattern matches the names of availability zones, e.g. us-west-2a.
+// Local Zones and Wavelength Zones are named after their region and location instead, e.g. us-west-2-lax-1a and us-east-1-wl1-bos-wlz-1.
+var availabilityZonePattern = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-\d+[a-z]$`)
+
+// ZoneType returns the type of the zone named zoneName.
+func ZoneType(zoneName string) string {
+	switch {
+	case availabilityZonePattern.MatchString(zoneName):
+		return ZoneTypeAvailabilityZone
+	case strings.Contains(zoneName, "-wlz-"):
+		return ZoneTypeWavelengthZone
+	default:
+		return ZoneTypeLocalZone
+	}
+}
+
+// zoneInventory caches the IDs of the availability zones of the region by name.
+// Zone names are mapped to physical zones independently for each account, while zone IDs identify the same zone in all accounts.
+// If availability zones can't be described, the last known zones are used.
+type zoneInventory struct {
+	ec2   ec2iface.EC2API
+	ttl   time.Duration
+	clock clock.Clock
+
+	mutex        sync.Mutex
+	zoneIDByName map[string]string
+	expiry       time.Time
+}
+
+func newZoneInventory(ec2Client ec2iface.EC2API, clk clock.Clock) *zoneInventory {
+	return &zoneInventory{
+		ec2:   ec2Client,
+		ttl:   zoneInventoryTTL,
+		clock: clk,
+	}
+}
+
+// zoneIDsByName returns the IDs of the availability zones of the region by name, refreshing them once expired.
+func (z *zoneInventory) zoneIDsByName(ctx context.Context) (map[string]string, error) {
+	z.mutex.Lock()
+	defer z.mutex.Unlock()
+	if z.zoneIDByName != nil && z.clock.Now().Before(z.expiry) {
+		return z.zoneIDByName, nil
+	}
+
+	resp, err := z.ec2.DescribeAvailabilityZonesWithContext(ctx, &ec2.DescribeAvailabilityZonesInput{})
+	if err != nil {
+		if z.zoneIDByName == nil {
+			return nil, fmt.Errorf("failed to describe availability zones due to %v", err)
+		}
+		albctx.GetLogger(ctx).Warnf("failed to describe availability zones due to %v, using last known availability zones", err)
+		return z.zoneIDByName, nil
+	}
+	zoneIDByName := make(map[string]string, len(resp.AvailabilityZones))
+	for _, zone := range resp.AvailabilityZones {
+		zoneIDByName[aws.StringValue(zone.ZoneName)] = aws.StringValue(zone.ZoneId)
+	}
+	z.zoneIDByName = zoneIDByName
+	z.expiry = z.clock.Now().Add(z.ttl)
+	return zoneIDByName, nil
+}
+
+// GetZoneIDsByName returns the IDs of the availability zones of the region by name.
+func (c *Cloud) GetZoneIDsByName(ctx context.Context) (map[string]string, error) {
+	return c.zones.zoneIDsByName(ctx)
+}
@@ -0,0 +1,53 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+func TestZoneInventory_zoneIDsByName(t *testing.T) {
+	ctx := context.Background()
+	input := &ec2.DescribeAvailabilityZonesInput{}
+	output := &ec2.DescribeAvailabilityZonesOutput{
+		AvailabilityZones: []*ec2.AvailabilityZone{
+			{ZoneName: aws.String("us-west-2a"), ZoneId: aws.String("usw2-az2")},
+			{ZoneName: aws.String("us-west-2b"), ZoneId: aws.String("usw2-az1")},
+		},
+	}
+	expected := map[string]string{"us-west-2a": "usw2-az2", "us-west-2b": "usw2-az1"}
+
+	ec2svc := &mocks.EC2API{}
+	fakeClock := clock.NewFakeClock(time.Now())
+	zones := newZoneInventory(ec2svc, fakeClock)
+
+	ec2svc.On("DescribeAvailabilityZonesWithContext", ctx, input).Return(nil, errors.New("some API error")).Once()
+	_, err := zones.zoneIDsByName(ctx)
+	assert.Equal(t, errors.New("failed to describe availability zones due to some API error"), err)
+
+	ec2svc.On("DescribeAvailabilityZonesWithContext", ctx, input).Return(output, nil).Once()
+	zoneIDByName, err := zones.zoneIDsByName(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, zoneIDByName)
+
+	// cached until expired.
+	zoneIDByName, err = zones.zoneIDsByName(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, zoneIDByName)
+
+	// last known zones are used if they can't be refreshed.
+	fakeClock.Step(zoneInventoryTTL)
+	ec2svc.On("DescribeAvailabilityZonesWithContext", ctx, input).Return(nil, errors.New("some API error")).Once()
+	zoneIDByName, err = zones.zoneIDsByName(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, zoneIDByName)
+
+	ec2svc.AssertExpectations(t)
+}
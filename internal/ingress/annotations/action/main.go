@@ -3,6 +3,9 @@ package action
 import (
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 
@@ -11,6 +14,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -18,8 +22,94 @@ import (
 const UseActionAnnotation = "use-annotation"
 const default404ServiceName = "Default 404"
 
+// DefaultActionsAnnotation configures the backend for default action of listeners per port or protocol.
+const DefaultActionsAnnotation = "default-actions"
+
+// DefaultBackendRuleAnnotation configures whether the default backend is routed by a lowest-priority catch-all rule instead of the default action of listeners.
+const DefaultBackendRuleAnnotation = "default-backend-rule"
+
+// MaintenanceActionAnnotation names an action that replaces all forward actions of the ingress while set.
+const MaintenanceActionAnnotation = "maintenance-action"
+
 type Config struct {
 	Actions map[string]*elbv2.Action
+
+	// DefaultBackends are backends for default action of listeners.
+	DefaultBackends DefaultBackends
+
+	// DefaultBackendRule is whether the default backend is routed by a catch-all rule.
+	DefaultBackendRule bool
+
+	// MaintenanceAction is the name of action that replaces all forward actions, or empty if maintenance mode is off.
+	MaintenanceAction string
+
+	// Default404 is the response for requests routed to the default 404 backend, or nil to respond with an empty body.
+	Default404 *elbv2.FixedResponseActionConfig
+}
+
+// DefaultBackends are backends for default action of listeners, configured per listener port or protocol.
+// A backend configured for a port takes precedence over the one configured for its protocol.
+type DefaultBackends struct {
+	ByPort     map[int64]extensions.IngressBackend
+	ByProtocol map[string]extensions.IngressBackend
+}
+
+// Get returns the backend configured for listener with port and protocol.
+func (d DefaultBackends) Get(port int64, protocol string) (extensions.IngressBackend, bool) {
+	if backend, ok := d.ByPort[port]; ok {
+		return backend, true
+	}
+	backend, ok := d.ByProtocol[protocol]
+	return backend, ok
+}
+
+// Backends returns all configured backends in a stable order.
+func (d DefaultBackends) Backends() []extensions.IngressBackend {
+	var output []extensions.IngressBackend
+	ports := make([]int64, 0, len(d.ByPort))
+	for port := range d.ByPort {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	for _, port := range ports {
+		output = append(output, d.ByPort[port])
+	}
+	protocols := make([]string, 0, len(d.ByProtocol))
+	for protocol := range d.ByProtocol {
+		protocols = append(protocols, protocol)
+	}
+	sort.Strings(protocols)
+	for _, protocol := range protocols {
+		output = append(output, d.ByProtocol[protocol])
+	}
+	return output
+}
+
+// listenerDefaultBackend is the backend for default action of the listener on Port, or of listeners with Protocol.
+type listenerDefaultBackend struct {
+	Port     int64  `json:"port"`
+	Protocol string `json:"protocol"`
+	extensions.IngressBackend
+}
+
+const (
+	// QueryModeKeep keeps the query string of original request.
+	QueryModeKeep = "keep"
+	// QueryModeDrop drops the query string of original request.
+	QueryModeDrop = "drop"
+	// QueryModeOverride replaces the query string with the Query of RedirectConfig, which can reference original query by #{query}.
+	QueryModeOverride = "override"
+)
+
+// redirectKeywords are the placeholders AWS substitutes with URI components of original request in RedirectConfig.
+var redirectKeywords = []string{"#{protocol}", "#{host}", "#{port}", "#{path}", "#{query}"}
+
+var redirectKeywordPattern = regexp.MustCompile(`#\{[^}]*\}`)
+
+// redirectOptions are controller specific options for redirect actions, specified along with the action.
+type redirectOptions struct {
+	// QueryMode is one of keep, drop or override. When empty, Query of RedirectConfig is used as is.
+	QueryMode string `json:"QueryMode"`
 }
 
 type action struct {
@@ -35,10 +125,7 @@ func NewParser(r resolver.Resolver) parser.IngressAnnotation {
 func (a action) Parse(ing parser.AnnotationInterface) (interface{}, error) {
 	actions := make(map[string]*elbv2.Action)
 	annos, err := parser.GetStringAnnotations("actions", ing)
-	if err != nil {
-		if errors.IsMissingAnnotations(err) {
-			return &Config{}, nil
-		}
+	if err != nil && !errors.IsMissingAnnotations(err) {
 		return nil, err
 	}
 
@@ -61,6 +148,13 @@ func (a action) Parse(ing parser.AnnotationInterface) (interface{}, error) {
 			if data.RedirectConfig == nil {
 				return nil, fmt.Errorf("%v is type redirect but did not include a valid RedirectConfig configuration", serviceName)
 			}
+			var opts redirectOptions
+			if err := json.Unmarshal([]byte(raw), &opts); err != nil {
+				return nil, err
+			}
+			if err := applyQueryMode(data.RedirectConfig, opts.QueryMode); err != nil {
+				return nil, fmt.Errorf("%v has invalid redirect configuration: %v", serviceName, err)
+			}
 		case "forward":
 			if data.TargetGroupArn == nil {
 				return nil, fmt.Errorf("%v is type forward but did not include a valid TargetGroupArn configuration", serviceName)
@@ -69,17 +163,137 @@ func (a action) Parse(ing parser.AnnotationInterface) (interface{}, error) {
 			return nil, fmt.Errorf("an invalid action type %v was configured in %v", *data.Type, serviceName)
 		}
 		setDefaults(data)
+		if data.RedirectConfig != nil {
+			if err := validateRedirectConfig(data.RedirectConfig); err != nil {
+				return nil, fmt.Errorf("%v has invalid redirect configuration: %v", serviceName, err)
+			}
+		}
 		actions[serviceName] = data
 	}
 
+	defaultBackends, err := ParseDefaultBackends(ing)
+	if err != nil {
+		return nil, err
+	}
+	for _, backend := range defaultBackends.Backends() {
+		if !Use(backend.ServicePort.String()) {
+			continue
+		}
+		if _, ok := actions[backend.ServiceName]; !ok && backend.ServiceName != default404ServiceName {
+			return nil, fmt.Errorf("%v annotation uses action %v, but an action annotation for %v is not set", DefaultActionsAnnotation, backend.ServiceName, backend.ServiceName)
+		}
+	}
+
+	defaultBackendRule := false
+	if v, err := parser.GetBoolAnnotation(DefaultBackendRuleAnnotation, ing); err == nil {
+		defaultBackendRule = *v
+	} else if !errors.IsMissingAnnotations(err) {
+		return nil, err
+	}
+
+	maintenanceAction := ""
+	if v, err := parser.GetStringAnnotation(MaintenanceActionAnnotation, ing); err == nil {
+		maintenanceAction = *v
+		data, ok := actions[maintenanceAction]
+		if !ok {
+			return nil, fmt.Errorf("%v annotation uses action %v, but an action annotation for %v is not set", MaintenanceActionAnnotation, maintenanceAction, maintenanceAction)
+		}
+		if t := aws.StringValue(data.Type); t != elbv2.ActionTypeEnumFixedResponse && t != elbv2.ActionTypeEnumRedirect {
+			return nil, fmt.Errorf("%v annotation uses action %v of type %v, only fixed-response and redirect actions are allowed", MaintenanceActionAnnotation, maintenanceAction, t)
+		}
+	}
+
+	default404 := default404Response(a.r.GetConfig())
+
+	if len(actions) == 0 && len(defaultBackends.Backends()) == 0 && !defaultBackendRule && default404 == nil {
+		return &Config{}, nil
+	}
 	return &Config{
-		Actions: actions,
+		Actions:            actions,
+		DefaultBackends:    defaultBackends,
+		DefaultBackendRule: defaultBackendRule,
+		MaintenanceAction:  maintenanceAction,
+		Default404:         default404,
 	}, nil
 }
 
+// ParseDefaultBackends parses the backends for default action of listeners from DefaultActionsAnnotation.
+// The annotation is a JSON list like [{"port": 80, "serviceName": "redirect", "servicePort": "use-annotation"}, {"protocol": "HTTPS", "serviceName": "landing", "servicePort": 80}].
+func ParseDefaultBackends(ing parser.AnnotationInterface) (DefaultBackends, error) {
+	defaultBackends := DefaultBackends{
+		ByPort:     make(map[int64]extensions.IngressBackend),
+		ByProtocol: make(map[string]extensions.IngressBackend),
+	}
+	raw, err := parser.GetStringAnnotation(DefaultActionsAnnotation, ing)
+	if err != nil {
+		if errors.IsMissingAnnotations(err) {
+			return defaultBackends, nil
+		}
+		return defaultBackends, err
+	}
+
+	var entries []listenerDefaultBackend
+	if err := json.Unmarshal([]byte(*raw), &entries); err != nil {
+		return defaultBackends, fmt.Errorf("failed to parse %v annotation due to %v", DefaultActionsAnnotation, err)
+	}
+	for _, entry := range entries {
+		if entry.ServiceName == "" {
+			return defaultBackends, fmt.Errorf("serviceName must be specified for each entry in %v annotation", DefaultActionsAnnotation)
+		}
+		switch {
+		case entry.Port != 0 && entry.Protocol != "":
+			return defaultBackends, fmt.Errorf("only one of port and protocol can be specified for an entry in %v annotation", DefaultActionsAnnotation)
+		case entry.Protocol != "":
+			if entry.Protocol != elbv2.ProtocolEnumHttp && entry.Protocol != elbv2.ProtocolEnumHttps {
+				return defaultBackends, fmt.Errorf("invalid protocol %v in %v annotation, must be HTTP or HTTPS", entry.Protocol, DefaultActionsAnnotation)
+			}
+			if _, ok := defaultBackends.ByProtocol[entry.Protocol]; ok {
+				return defaultBackends, fmt.Errorf("duplicate protocol %v in %v annotation", entry.Protocol, DefaultActionsAnnotation)
+			}
+			defaultBackends.ByProtocol[entry.Protocol] = entry.IngressBackend
+		default:
+			if entry.Port < 1 || entry.Port > 65535 {
+				return defaultBackends, fmt.Errorf("invalid port %v in %v annotation", entry.Port, DefaultActionsAnnotation)
+			}
+			if _, ok := defaultBackends.ByPort[entry.Port]; ok {
+				return defaultBackends, fmt.Errorf("duplicate port %v in %v annotation", entry.Port, DefaultActionsAnnotation)
+			}
+			defaultBackends.ByPort[entry.Port] = entry.IngressBackend
+		}
+	}
+	return defaultBackends, nil
+}
+
+// GetDefaultBackend returns the backend configured for default action of listener with port and protocol.
+func (c *Config) GetDefaultBackend(port int64, protocol string) (extensions.IngressBackend, bool) {
+	if c == nil {
+		return extensions.IngressBackend{}, false
+	}
+	return c.DefaultBackends.Get(port, protocol)
+}
+
+// UseDefaultBackendRule returns whether the default backend should be routed by a catch-all rule.
+func (c *Config) UseDefaultBackendRule() bool {
+	return c != nil && c.DefaultBackendRule
+}
+
+// GetMaintenanceAction returns the action that replaces forward actions while maintenance mode is on.
+func (c *Config) GetMaintenanceAction() (elbv2.Action, bool) {
+	if c == nil || c.MaintenanceAction == "" {
+		return elbv2.Action{}, false
+	}
+	return *c.Actions[c.MaintenanceAction], true
+}
+
 // GetAction returns the action named serviceName configured by an annotation
 func (c *Config) GetAction(serviceName string) (elbv2.Action, error) {
 	if serviceName == default404ServiceName {
+		if c != nil && c.Default404 != nil {
+			return elbv2.Action{
+				Type:                aws.String(elbv2.ActionTypeEnumFixedResponse),
+				FixedResponseConfig: c.Default404,
+			}, nil
+		}
 		return default404Action(), nil
 	}
 
@@ -108,6 +322,22 @@ func default404Action() elbv2.Action {
 	}
 }
 
+// default404Response returns the response configured for the default 404 backend, or nil if no message body is configured.
+func default404Response(cfg *config.Configuration) *elbv2.FixedResponseActionConfig {
+	if cfg == nil || cfg.Default404MessageBody == "" {
+		return nil
+	}
+	contentType := cfg.Default404ContentType
+	if contentType == "" {
+		contentType = "text/plain"
+	}
+	return &elbv2.FixedResponseActionConfig{
+		ContentType: aws.String(contentType),
+		MessageBody: aws.String(cfg.Default404MessageBody),
+		StatusCode:  aws.String("404"),
+	}
+}
+
 // Default404Backend turns an IngressBackend that will return 404s
 func Default404Backend() extensions.IngressBackend {
 	return extensions.IngressBackend{
@@ -137,6 +367,53 @@ func setDefaults(d *elbv2.Action) *elbv2.Action {
 	return d
 }
 
+// applyQueryMode sets the Query of redirect config according to mode.
+func applyQueryMode(rc *elbv2.RedirectActionConfig, mode string) error {
+	switch mode {
+	case "":
+		return nil
+	case QueryModeKeep:
+		if rc.Query != nil && aws.StringValue(rc.Query) != "#{query}" {
+			return fmt.Errorf("specifying Query conflicts with QueryMode %v", mode)
+		}
+		rc.Query = aws.String("#{query}")
+	case QueryModeDrop:
+		if rc.Query != nil && aws.StringValue(rc.Query) != "" {
+			return fmt.Errorf("specifying Query conflicts with QueryMode %v", mode)
+		}
+		rc.Query = aws.String("")
+	case QueryModeOverride:
+		if rc.Query == nil {
+			return fmt.Errorf("missing Query for QueryMode %v", mode)
+		}
+	default:
+		return fmt.Errorf("invalid QueryMode, must be one of %v, %v, %v, not %v", QueryModeKeep, QueryModeDrop, QueryModeOverride, mode)
+	}
+	return nil
+}
+
+// validateRedirectConfig validates the templates in redirect config only reference known URI components.
+func validateRedirectConfig(rc *elbv2.RedirectActionConfig) error {
+	if !strings.HasPrefix(aws.StringValue(rc.Path), "/") {
+		return fmt.Errorf("redirect path must start with /, got %v", aws.StringValue(rc.Path))
+	}
+	for field, value := range map[string]*string{"Host": rc.Host, "Path": rc.Path, "Query": rc.Query} {
+		for _, keyword := range redirectKeywordPattern.FindAllString(aws.StringValue(value), -1) {
+			known := false
+			for _, redirectKeyword := range redirectKeywords {
+				if keyword == redirectKeyword {
+					known = true
+					break
+				}
+			}
+			if !known {
+				return fmt.Errorf("unknown placeholder %v in %v", keyword, field)
+			}
+		}
+	}
+	return nil
+}
+
 func Dummy() *Config {
 	return &Config{
 		Actions: map[string]*elbv2.Action{
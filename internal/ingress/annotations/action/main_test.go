@@ -3,10 +3,12 @@ package action
 import (
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
 )
 
@@ -57,3 +59,217 @@ func TestInvalidIngressActions(t *testing.T) {
 		t.Errorf("invalid annotation configuration was provided but an error was not returned: %v", err)
 	}
 }
+
+func TestIngressDefaultActions(t *testing.T) {
+	ing := dummy.NewIngress()
+
+	data := map[string]string{}
+	data[parser.GetAnnotationWithPrefix("actions.ssl-redirect")] = `{"Type": "redirect", "RedirectConfig": {"Protocol": "HTTPS", "Port": "443", "StatusCode": "HTTP_301"}}`
+	data[parser.GetAnnotationWithPrefix(DefaultActionsAnnotation)] = `[{"port": 80, "serviceName": "ssl-redirect", "servicePort": "use-annotation"}, {"port": 443, "serviceName": "landing", "servicePort": 8080}, {"protocol": "HTTPS", "serviceName": "secure", "servicePort": 80}]`
+	ing.SetAnnotations(data)
+
+	ai, err := NewParser(mockBackend{}).Parse(ing)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	a := ai.(*Config)
+
+	backend, ok := a.GetDefaultBackend(80, "HTTP")
+	if !ok || backend.ServiceName != "ssl-redirect" || backend.ServicePort.String() != UseActionAnnotation {
+		t.Errorf("expected default backend for port 80 to be ssl-redirect action, but returned %v", backend)
+	}
+	backend, ok = a.GetDefaultBackend(443, "HTTPS")
+	if !ok || backend.ServiceName != "landing" || backend.ServicePort.IntValue() != 8080 {
+		t.Errorf("expected default backend for port 443 to be landing:8080, but returned %v", backend)
+	}
+	backend, ok = a.GetDefaultBackend(8443, "HTTPS")
+	if !ok || backend.ServiceName != "secure" {
+		t.Errorf("expected default backend for HTTPS port 8443 to be secure:80, but returned %v", backend)
+	}
+	if _, ok := a.GetDefaultBackend(8080, "HTTP"); ok {
+		t.Errorf("expected no default backend for HTTP port 8080")
+	}
+	if a.UseDefaultBackendRule() {
+		t.Errorf("expected default backend rule to be disabled by default")
+	}
+}
+
+func TestInvalidIngressDefaultActions(t *testing.T) {
+	for _, annotation := range []string{
+		`{"port": 80, "serviceName": "landing", "servicePort": 80}`,
+		`[{"port": 0, "serviceName": "landing", "servicePort": 80}]`,
+		`[{"port": 80, "servicePort": 80}]`,
+		`[{"port": 80, "serviceName": "landing", "servicePort": 80}, {"port": 80, "serviceName": "other", "servicePort": 80}]`,
+		`[{"port": 80, "serviceName": "missing-action", "servicePort": "use-annotation"}]`,
+		`[{"port": 80, "protocol": "HTTP", "serviceName": "landing", "servicePort": 80}]`,
+		`[{"protocol": "TCP", "serviceName": "landing", "servicePort": 80}]`,
+	} {
+		ing := dummy.NewIngress()
+		ing.SetAnnotations(map[string]string{
+			parser.GetAnnotationWithPrefix(DefaultActionsAnnotation): annotation,
+		})
+
+		_, err := NewParser(mockBackend{}).Parse(ing)
+		if err == nil {
+			t.Errorf("invalid annotation configuration %v was provided but an error was not returned", annotation)
+		}
+	}
+}
+
+func TestRedirectActionQueryMode(t *testing.T) {
+	for _, tc := range []struct {
+		annotation    string
+		expectedQuery string
+		expectedPath  string
+		expectError   bool
+	}{
+		{
+			annotation:    `{"Type": "redirect", "RedirectConfig": {"Protocol": "HTTPS", "StatusCode": "HTTP_301"}}`,
+			expectedQuery: "#{query}",
+			expectedPath:  "/#{path}",
+		},
+		{
+			annotation:    `{"Type": "redirect", "QueryMode": "keep", "RedirectConfig": {"Protocol": "HTTPS", "StatusCode": "HTTP_301"}}`,
+			expectedQuery: "#{query}",
+			expectedPath:  "/#{path}",
+		},
+		{
+			annotation:    `{"Type": "redirect", "QueryMode": "drop", "RedirectConfig": {"Path": "/app/#{path}", "StatusCode": "HTTP_302"}}`,
+			expectedQuery: "",
+			expectedPath:  "/app/#{path}",
+		},
+		{
+			annotation:    `{"Type": "redirect", "QueryMode": "override", "RedirectConfig": {"Query": "source=redirect&#{query}", "StatusCode": "HTTP_301"}}`,
+			expectedQuery: "source=redirect&#{query}",
+			expectedPath:  "/#{path}",
+		},
+		{
+			annotation:  `{"Type": "redirect", "QueryMode": "override", "RedirectConfig": {"StatusCode": "HTTP_301"}}`,
+			expectError: true,
+		},
+		{
+			annotation:  `{"Type": "redirect", "QueryMode": "drop", "RedirectConfig": {"Query": "a=b", "StatusCode": "HTTP_301"}}`,
+			expectError: true,
+		},
+		{
+			annotation:  `{"Type": "redirect", "QueryMode": "append", "RedirectConfig": {"StatusCode": "HTTP_301"}}`,
+			expectError: true,
+		},
+		{
+			annotation:  `{"Type": "redirect", "RedirectConfig": {"Path": "#{path}", "StatusCode": "HTTP_301"}}`,
+			expectError: true,
+		},
+		{
+			annotation:  `{"Type": "redirect", "RedirectConfig": {"Path": "/#{uri}", "StatusCode": "HTTP_301"}}`,
+			expectError: true,
+		},
+	} {
+		ing := dummy.NewIngress()
+		ing.SetAnnotations(map[string]string{
+			parser.GetAnnotationWithPrefix("actions.redirect"): tc.annotation,
+		})
+
+		ai, err := NewParser(mockBackend{}).Parse(ing)
+		if tc.expectError {
+			if err == nil {
+				t.Errorf("invalid annotation configuration %v was provided but an error was not returned", tc.annotation)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		rc := ai.(*Config).Actions["redirect"].RedirectConfig
+		if *rc.Query != tc.expectedQuery {
+			t.Errorf("expected Query to be %v, but returned %v", tc.expectedQuery, *rc.Query)
+		}
+		if *rc.Path != tc.expectedPath {
+			t.Errorf("expected Path to be %v, but returned %v", tc.expectedPath, *rc.Path)
+		}
+	}
+}
+
+func TestIngressMaintenanceAction(t *testing.T) {
+	for _, tc := range []struct {
+		maintenanceAction string
+		expectError       bool
+	}{
+		{maintenanceAction: "maintenance-page"},
+		{maintenanceAction: "redirect-action"},
+		{maintenanceAction: "forward", expectError: true},
+		{maintenanceAction: "missing-action", expectError: true},
+	} {
+		ing := dummy.NewIngress()
+		ing.SetAnnotations(map[string]string{
+			parser.GetAnnotationWithPrefix("actions.maintenance-page"):  `{"Type": "fixed-response", "FixedResponseConfig": {"ContentType": "text/plain", "StatusCode": "503", "MessageBody": "down for maintenance"}}`,
+			parser.GetAnnotationWithPrefix("actions.redirect-action"):   `{"Type": "redirect", "RedirectConfig": {"Host": "status.example.com", "StatusCode": "HTTP_302"}}`,
+			parser.GetAnnotationWithPrefix("actions.forward"):           `{"Type": "forward", "TargetGroupArn": "legacy-tg-arn"}`,
+			parser.GetAnnotationWithPrefix(MaintenanceActionAnnotation): tc.maintenanceAction,
+		})
+
+		ai, err := NewParser(mockBackend{}).Parse(ing)
+		if tc.expectError {
+			if err == nil {
+				t.Errorf("invalid maintenance action %v was provided but an error was not returned", tc.maintenanceAction)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		action, ok := ai.(*Config).GetMaintenanceAction()
+		if !ok || action.Type == nil {
+			t.Errorf("expected maintenance action %v to be returned", tc.maintenanceAction)
+		}
+	}
+
+	var nilConfig *Config
+	if _, ok := nilConfig.GetMaintenanceAction(); ok {
+		t.Errorf("expected no maintenance action for nil config")
+	}
+}
+
+type mockDefault404Backend struct {
+	resolver.Mock
+}
+
+func (m mockDefault404Backend) GetConfig() *config.Configuration {
+	return &config.Configuration{
+		Default404MessageBody: "<h1>Not Found</h1>",
+		Default404ContentType: "text/html",
+	}
+}
+
+func TestDefault404Action(t *testing.T) {
+	for _, tc := range []struct {
+		r                   resolver.Resolver
+		expectedContentType string
+		expectedMessageBody *string
+	}{
+		{r: mockBackend{}, expectedContentType: "text/plain", expectedMessageBody: nil},
+		{r: mockDefault404Backend{}, expectedContentType: "text/html", expectedMessageBody: aws.String("<h1>Not Found</h1>")},
+	} {
+		ai, err := NewParser(tc.r).Parse(dummy.NewIngress())
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		action, err := ai.(*Config).GetAction(Default404Backend().ServiceName)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if ct := aws.StringValue(action.FixedResponseConfig.ContentType); ct != tc.expectedContentType {
+			t.Errorf("expected default 404 ContentType to be %v, but returned %v", tc.expectedContentType, ct)
+		}
+		if body := action.FixedResponseConfig.MessageBody; aws.StringValue(body) != aws.StringValue(tc.expectedMessageBody) {
+			t.Errorf("expected default 404 MessageBody to be %v, but returned %v", aws.StringValue(tc.expectedMessageBody), aws.StringValue(body))
+		}
+		if code := aws.StringValue(action.FixedResponseConfig.StatusCode); code != "404" {
+			t.Errorf("expected default 404 StatusCode to be 404, but returned %v", code)
+		}
+	}
+}
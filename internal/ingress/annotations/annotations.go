@@ -34,6 +34,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/healthcheck"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/schedule"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/targetgroup"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
@@ -49,6 +50,7 @@ type Ingress struct {
 	HealthCheck  *healthcheck.Config
 	TargetGroup  *targetgroup.Config
 	LoadBalancer *loadbalancer.Config
+	Schedule     *schedule.Config
 	Tags         *tags.Config
 	Error        error
 }
@@ -59,6 +61,7 @@ func NewIngressDummy() *Ingress {
 		HealthCheck:  &healthcheck.Config{},
 		TargetGroup:  targetgroup.Dummy(),
 		LoadBalancer: loadbalancer.Dummy(),
+		Schedule:     &schedule.Config{},
 		Tags:         &tags.Config{},
 	}
 }
@@ -100,6 +103,7 @@ func NewIngressAnnotationExtractor(cfg resolver.Resolver) Extractor {
 			"HealthCheck":  healthcheck.NewParser(cfg),
 			"TargetGroup":  targetgroup.NewParser(cfg),
 			"LoadBalancer": loadbalancer.NewParser(cfg),
+			"Schedule":     schedule.NewParser(cfg),
 			"Tags":         tags.NewParser(cfg),
 		},
 	}
@@ -137,10 +141,12 @@ func (e Extractor) ExtractService(svc *corev1.Service) *Service {
 	return s.(*Service)
 }
 
-// Extract extracts the annotations from metadata
+// Extract extracts the annotations from metadata.
+// The errors of all annotation parsers are returned together, so that all invalid annotations are reported at once.
 // TODO put kind in log message
 func (e Extractor) extract(dst interface{}, o metav1.Object) (interface{}, error) {
 	data := make(map[string]interface{})
+	var errs errors.InvalidAnnotations
 	for name, annotationParser := range e.annotations {
 		val, err := annotationParser.Parse(o)
 		glog.V(6).Infof("annotation %v in %v %v/%v: %v", name, "o.GetKind()", o.GetNamespace(), o.GetName(), val)
@@ -150,12 +156,16 @@ func (e Extractor) extract(dst interface{}, o metav1.Object) (interface{}, error
 			}
 
 			glog.V(5).Infof("error reading %v annotation in %v %v/%v: %v", name, "o.GetKind()", o.GetNamespace(), o.GetName(), err)
-			return dst, err
+			errs = errs.Append(err)
+			continue
 		}
 		if val != nil {
 			data[name] = val
 		}
 	}
+	if err := errs.ErrOrNil(); err != nil {
+		return dst, err
+	}
 	err := mergo.MapWithOverwrite(dst, data)
 	if err != nil {
 		glog.Errorf("unexpected error merging extracted annotations: %v", err)
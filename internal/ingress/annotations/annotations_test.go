@@ -26,6 +26,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/healthcheck"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	apiv1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -131,6 +134,32 @@ func TestHealthCheck(t *testing.T) {
 	}
 }
 
+func TestExtractIngress_invalidAnnotations(t *testing.T) {
+	cfg := mockCfg{}
+	ec := Extractor{
+		map[string]parser.IngressAnnotation{
+			"HealthCheck":  healthcheck.NewParser(cfg),
+			"LoadBalancer": loadbalancer.NewParser(cfg),
+			"Tags":         tags.NewParser(cfg),
+		},
+	}
+	ing := buildIngress()
+	ing.SetAnnotations(map[string]string{
+		annotationHealthcheckIntervalSeconds:                   "often",
+		parser.GetAnnotationWithPrefix("healthcheck-protocol"): "TCP",
+		annotationScheme:                       "public",
+		parser.GetAnnotationWithPrefix("tags"): "team",
+	})
+
+	r := ec.ExtractIngress(ing)
+	assert.Equal(t, errors.InvalidAnnotations{
+		errors.InvalidAnnotation{Name: annotationHealthcheckIntervalSeconds, Value: "often", Reason: "must be an integer"},
+		errors.InvalidAnnotation{Name: parser.GetAnnotationWithPrefix("healthcheck-protocol"), Value: "TCP", Reason: "must be HTTP or HTTPS"},
+		errors.InvalidAnnotation{Name: annotationScheme, Value: "public", Reason: "ALB scheme must be either `internal` or `internet-facing`"},
+		errors.InvalidAnnotation{Name: parser.GetAnnotationWithPrefix("tags"), Value: "team", Reason: "unable to parse `team` into Key=Value pair(s)"},
+	}, r.Error)
+}
+
 func TestMerge(t *testing.T) {
 	for _, tc := range []struct {
 		Source         *Service
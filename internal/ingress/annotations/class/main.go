@@ -30,6 +30,14 @@ const (
 	defaultIngressClass = "alb"
 )
 
+// Name returns the name of the ingress class watched for ingressClass, which is the default class if empty.
+func Name(ingressClass string) string {
+	if ingressClass == "" {
+		return defaultIngressClass
+	}
+	return ingressClass
+}
+
 // If watchIngressClass is empty, then both ingress without class annotation or with class annotation specified as `alb` will be matched.
 // If watchIngressClass is not empty, then only ingress with class annotation specified as watchIngressClass will be matched
 func IsValidIngress(ingressClass string, ingress *extensions.Ingress) bool {
@@ -22,8 +22,8 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
-	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
 )
 
@@ -56,41 +56,40 @@ func NewParser(r resolver.Resolver) parser.IngressAnnotation {
 // Parse the annotations contained in the resource
 func (hc healthCheck) Parse(ing parser.AnnotationInterface) (interface{}, error) {
 	cfg := hc.r.GetConfig()
+	annos := parser.NewAnnotations(ing)
 
-	seconds, err := parser.GetInt64Annotation("healthcheck-interval-seconds", ing)
-	if err != nil {
-		if err != errors.ErrMissingAnnotations {
-			return nil, err
-		}
+	seconds := annos.Int64("healthcheck-interval-seconds")
+	if seconds == nil {
 		seconds = aws.Int64(DefaultIntervalSeconds)
 	}
 
-	path, err := parser.GetStringAnnotation("healthcheck-path", ing)
-	if err != nil {
+	path := annos.String("healthcheck-path")
+	if path == nil {
 		path = aws.String(DefaultPath)
 	}
 
-	port, err := parser.GetStringAnnotation("healthcheck-port", ing)
-	if err != nil {
+	port := annos.String("healthcheck-port")
+	if port == nil {
 		port = aws.String(DefaultPort)
 	}
 
-	protocol, err := parser.GetStringAnnotation("healthcheck-protocol", ing)
-	if err != nil {
+	protocol := annos.String("healthcheck-protocol")
+	if protocol == nil {
 		protocol = aws.String(cfg.DefaultBackendProtocol)
+	} else if *protocol != elbv2.ProtocolEnumHttp && *protocol != elbv2.ProtocolEnumHttps {
+		annos.Invalid("healthcheck-protocol", fmt.Sprintf("must be %v or %v", elbv2.ProtocolEnumHttp, elbv2.ProtocolEnumHttps))
 	}
 
-	timeoutSeconds, err := parser.GetInt64Annotation("healthcheck-timeout-seconds", ing)
-	if err != nil {
-		if err != errors.ErrMissingAnnotations {
-			return nil, err
-		}
+	timeoutSeconds := annos.Int64("healthcheck-timeout-seconds")
+	if timeoutSeconds == nil {
 		timeoutSeconds = aws.Int64(DefaultTimeoutSeconds)
 	}
 
 	if *timeoutSeconds >= *seconds {
-		return nil, fmt.Errorf("healthcheck timeout must be less than healthcheck interval. Timeout was: %d. Interval was %d",
-			*timeoutSeconds, *seconds)
+		annos.Invalid("healthcheck-timeout-seconds", fmt.Sprintf("must be less than healthcheck interval of %d seconds", *seconds))
+	}
+	if err := annos.Err(); err != nil {
+		return nil, err
 	}
 
 	return &Config{
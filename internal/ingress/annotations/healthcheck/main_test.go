@@ -92,6 +92,25 @@ func TestIngressHealthCheck(t *testing.T) {
 	}
 }
 
+func TestIngressHealthCheckProtocol(t *testing.T) {
+	ing := buildIngress()
+
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("healthcheck-port"):     "admin",
+		parser.GetAnnotationWithPrefix("healthcheck-protocol"): "HTTPS",
+	})
+	hzi, err := NewParser(mockBackend{}).Parse(ing)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", aws.StringValue(hzi.(*Config).Port))
+	assert.Equal(t, "HTTPS", aws.StringValue(hzi.(*Config).Protocol))
+
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("healthcheck-protocol"): "TCP",
+	})
+	_, err = NewParser(mockBackend{}).Parse(ing)
+	assert.Error(t, err)
+}
+
 func TestMerge(t *testing.T) {
 	for _, tc := range []struct {
 		Source         *Config
@@ -20,6 +20,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/golang/glog"
@@ -46,6 +48,22 @@ type Config struct {
 	SecurityGroups []string
 	Subnets        []string
 	Attributes     []*elbv2.LoadBalancerAttribute
+
+	// PrivateLink marks internal LoadBalancers to be shared to other VPCs through PrivateLink.
+	PrivateLink bool
+
+	// AlarmThresholds are the thresholds of CloudWatch alarms created for LoadBalancer, by alarm kind.
+	AlarmThresholds map[string]float64
+	// AlarmTopicArn is the SNS topic notified by CloudWatch alarms of LoadBalancer.
+	AlarmTopicArn *string
+
+	// HTTPVersion is the highest HTTP version LoadBalancer negotiates with clients, one of HTTPVersion1 or HTTPVersion2.
+	HTTPVersion string
+
+	// AccessLogsBucketManaged makes the controller create and configure the S3 bucket that access logs are delivered to.
+	AccessLogsBucketManaged bool
+	// AccessLogsExpirationDays is the number of days after which access logs expire in a managed bucket, zero keeps them forever.
+	AccessLogsExpirationDays int64
 }
 
 type loadBalancer struct {
@@ -57,53 +75,109 @@ const (
 	DefaultScheme        = elbv2.LoadBalancerSchemeEnumInternal
 )
 
+// HTTP versions LoadBalancers can negotiate with clients.
+const (
+	// HTTPVersion1 limits clients to HTTP/1.1.
+	HTTPVersion1 = "http1"
+	// HTTPVersion2 allows clients to negotiate HTTP/2 on HTTPS listeners, requests are forwarded to targets with HTTP/1.1.
+	HTTPVersion2 = "http2"
+	// HTTPVersionGRPC forwards gRPC end to end, it requires targetGroups with the GRPC protocol version.
+	HTTPVersionGRPC = "grpc"
+)
+
+// Kinds of CloudWatch alarms that can be created for LoadBalancers.
+const (
+	// AlarmKind5xxRate alarms when the percentage of requests with 5XX responses from targets exceeds the threshold.
+	AlarmKind5xxRate = "5xx-rate"
+	// AlarmKindTargetResponseTime alarms when the average target response time in seconds exceeds the threshold.
+	AlarmKindTargetResponseTime = "target-response-time"
+	// AlarmKindUnhealthyHostCount alarms when the number of unhealthy targets in any targetGroup reaches the threshold.
+	AlarmKindUnhealthyHostCount = "unhealthy-host-count"
+)
+
 // NewParser creates a new target group annotation parser
 func NewParser(r resolver.Resolver) parser.IngressAnnotation {
 	return loadBalancer{r}
 }
 
-// Parse parses the annotations contained in the resource
+// Parse parses the annotations contained in the resource, and reports the errors of all invalid annotations together
 func (lb loadBalancer) Parse(ing parser.AnnotationInterface) (interface{}, error) {
+	annos := parser.NewAnnotations(ing)
+	var errs errors.InvalidAnnotations
+
 	// support legacy waf-acl-id annotation
-	webACLId, _ := parser.GetStringAnnotation("waf-acl-id", ing)
-	w, err := parser.GetStringAnnotation("web-acl-id", ing)
-	if err == nil {
+	webACLId := annos.String("waf-acl-id")
+	if w := annos.String("web-acl-id"); w != nil {
 		webACLId = w
 	}
 
-	ipAddressType, err := parser.GetStringAnnotation("ip-address-type", ing)
-	if err != nil {
+	ipAddressType := annos.String("ip-address-type")
+	if ipAddressType == nil {
 		ipAddressType = aws.String(DefaultIPAddressType)
+	} else if *ipAddressType != elbv2.IpAddressTypeIpv4 && *ipAddressType != elbv2.IpAddressTypeDualstack {
+		annos.Invalid("ip-address-type", fmt.Sprintf("IP address type must be either `%v` or `%v`", elbv2.IpAddressTypeIpv4, elbv2.IpAddressTypeDualstack))
 	}
 
-	if *ipAddressType != elbv2.IpAddressTypeIpv4 && *ipAddressType != elbv2.IpAddressTypeDualstack {
-		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("IP address type must be either `%v` or `%v`", elbv2.IpAddressTypeIpv4, elbv2.IpAddressTypeDualstack))
-	}
-
-	scheme, err := parser.GetStringAnnotation("scheme", ing)
-	if err != nil {
+	scheme := annos.String("scheme")
+	if scheme == nil {
 		scheme = aws.String(DefaultScheme)
+	} else if *scheme != elbv2.LoadBalancerSchemeEnumInternal && *scheme != elbv2.LoadBalancerSchemeEnumInternetFacing {
+		annos.Invalid("scheme", fmt.Sprintf("ALB scheme must be either `%v` or `%v`", elbv2.LoadBalancerSchemeEnumInternal, elbv2.LoadBalancerSchemeEnumInternetFacing))
 	}
 
-	if *scheme != elbv2.LoadBalancerSchemeEnumInternal && *scheme != elbv2.LoadBalancerSchemeEnumInternetFacing {
-		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("ALB scheme must be either `%v` or `%v`", elbv2.LoadBalancerSchemeEnumInternal, elbv2.LoadBalancerSchemeEnumInternetFacing))
+	privateLink := annos.Bool("privatelink")
+	if aws.BoolValue(privateLink) && *scheme != elbv2.LoadBalancerSchemeEnumInternal {
+		annos.Invalid("privatelink", fmt.Sprintf("PrivateLink requires ALB scheme `%v`", elbv2.LoadBalancerSchemeEnumInternal))
 	}
 
 	ports, err := parsePorts(ing)
 	if err != nil {
-		return nil, err
+		errs = errs.Append(err)
 	}
 
 	attributes, err := parseAttributes(ing)
 	if err != nil {
-		return nil, err
+		errs = errs.Append(err)
+	}
+	if aws.BoolValue(annos.Bool("connection-logs-enabled")) {
+		if attributes, err = withConnectionLogs(attributes); err != nil {
+			annos.Invalid("connection-logs-enabled", err.Error())
+		}
+	}
+	if err := validateLogsDestinations(attributes); err != nil {
+		errs = errs.Append(errors.NewInvalidAnnotationContentReason(err.Error()))
+	}
+	httpVersion, attributes, err := parseHTTPVersion(ing, attributes)
+	if err != nil {
+		annos.Invalid("http-version", err.Error())
 	}
 
-	securityGroups := parser.GetStringSliceAnnotation("security-groups", ing)
-	subnets := parser.GetStringSliceAnnotation("subnets", ing)
+	securityGroups := annos.StringSlice("security-groups")
+	subnets := annos.StringSlice("subnets")
 
 	cidrs, err := parseCidrs(ing)
 	if err != nil {
+		errs = errs.Append(err)
+	}
+
+	alarmThresholds, err := parseAlarmThresholds(ing)
+	if err != nil {
+		errs = errs.Append(err)
+	}
+	alarmTopicArn := annos.String("alarm-sns-topic-arn")
+	if len(alarmThresholds) != 0 && alarmTopicArn == nil {
+		annos.Invalid("alarm-thresholds", "requires alarm-sns-topic-arn")
+	}
+
+	accessLogsBucketManaged := annos.Bool("access-logs-bucket-managed")
+	accessLogsExpirationDays := annos.Int64("access-logs-expiration-days")
+	if aws.Int64Value(accessLogsExpirationDays) < 0 {
+		annos.Invalid("access-logs-expiration-days", "must be non-negative")
+	} else if aws.Int64Value(accessLogsExpirationDays) != 0 && !aws.BoolValue(accessLogsBucketManaged) {
+		annos.Invalid("access-logs-expiration-days", "requires access-logs-bucket-managed")
+	}
+
+	if err := errs.Append(annos.Err()).ErrOrNil(); err != nil {
 		return nil, err
 	}
 
@@ -118,9 +192,46 @@ func (lb loadBalancer) Parse(ing parser.AnnotationInterface) (interface{}, error
 
 		Subnets:        subnets,
 		SecurityGroups: securityGroups,
+
+		PrivateLink: aws.BoolValue(privateLink),
+		HTTPVersion: httpVersion,
+
+		AlarmThresholds: alarmThresholds,
+		AlarmTopicArn:   alarmTopicArn,
+
+		AccessLogsBucketManaged:  aws.BoolValue(accessLogsBucketManaged),
+		AccessLogsExpirationDays: aws.Int64Value(accessLogsExpirationDays),
 	}, nil
 }
 
+func parseAlarmThresholds(ing parser.AnnotationInterface) (map[string]float64, error) {
+	thresholds := parser.GetStringSliceAnnotation("alarm-thresholds", ing)
+	if len(thresholds) == 0 {
+		return nil, nil
+	}
+
+	out := make(map[string]float64, len(thresholds))
+	for _, threshold := range thresholds {
+		parts := strings.Split(threshold, "=")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("unable to parse `%s` into Kind=Threshold pair", threshold)
+		}
+		kind := strings.TrimSpace(parts[0])
+		switch kind {
+		case AlarmKind5xxRate, AlarmKindTargetResponseTime, AlarmKindUnhealthyHostCount:
+		default:
+			return nil, fmt.Errorf("unknown alarm kind `%s`, must be one of `%v`, `%v` or `%v`", kind,
+				AlarmKind5xxRate, AlarmKindTargetResponseTime, AlarmKindUnhealthyHostCount)
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil || value < 0 {
+			return nil, fmt.Errorf("invalid threshold `%s` for alarm kind `%s`", parts[1], kind)
+		}
+		out[kind] = value
+	}
+	return out, nil
+}
+
 func parseAttributes(ing parser.AnnotationInterface) ([]*elbv2.LoadBalancerAttribute, error) {
 	var badAttrs []string
 	var lbattrs []*elbv2.LoadBalancerAttribute
@@ -156,6 +267,98 @@ func parseAttributes(ing parser.AnnotationInterface) ([]*elbv2.LoadBalancerAttri
 	return lbattrs, nil
 }
 
+// parseHTTPVersion parses the http-version annotation into the routing.http2.enabled attribute.
+// Without the annotation, the version follows routing.http2.enabled, which defaults to HTTP/2.
+func parseHTTPVersion(ing parser.AnnotationInterface, attributes []*elbv2.LoadBalancerAttribute) (string, []*elbv2.LoadBalancerAttribute, error) {
+	var http2Attr *string
+	for _, attr := range attributes {
+		if aws.StringValue(attr.Key) == "routing.http2.enabled" {
+			http2Attr = attr.Value
+		}
+	}
+	version, err := parser.GetStringAnnotation("http-version", ing)
+	if err != nil {
+		if enabled, err := strconv.ParseBool(aws.StringValue(http2Attr)); err == nil && !enabled {
+			return HTTPVersion1, attributes, nil
+		}
+		return HTTPVersion2, attributes, nil
+	}
+
+	var http2Enabled bool
+	switch aws.StringValue(version) {
+	case HTTPVersion1:
+		http2Enabled = false
+	case HTTPVersion2:
+		http2Enabled = true
+	case HTTPVersionGRPC:
+		// the pinned ELBV2 API doesn't support the protocol version of targetGroups yet, so gRPC can't be forwarded to targets.
+		return "", nil, fmt.Errorf("http-version %v requires targetGroups with the GRPC protocol version, which is not supported yet", HTTPVersionGRPC)
+	default:
+		return "", nil, fmt.Errorf("http-version must be either `%v` or `%v`", HTTPVersion1, HTTPVersion2)
+	}
+	if http2Attr != nil {
+		if enabled, err := strconv.ParseBool(aws.StringValue(http2Attr)); err != nil || enabled != http2Enabled {
+			return "", nil, fmt.Errorf("http-version %v conflicts with load balancer attribute routing.http2.enabled=%v", aws.StringValue(version), aws.StringValue(http2Attr))
+		}
+		return aws.StringValue(version), attributes, nil
+	}
+	return aws.StringValue(version), append(attributes, &elbv2.LoadBalancerAttribute{
+		Key:   aws.String("routing.http2.enabled"),
+		Value: aws.String(strconv.FormatBool(http2Enabled)),
+	}), nil
+}
+
+// withConnectionLogs enables connection logs with the same bucket and prefix as access logs in attributes.
+func withConnectionLogs(attributes []*elbv2.LoadBalancerAttribute) ([]*elbv2.LoadBalancerAttribute, error) {
+	values := make(map[string]string, len(attributes))
+	for _, attr := range attributes {
+		values[aws.StringValue(attr.Key)] = aws.StringValue(attr.Value)
+	}
+	for _, key := range []string{"connection_logs.s3.enabled", "connection_logs.s3.bucket", "connection_logs.s3.prefix"} {
+		if _, ok := values[key]; ok {
+			return nil, fmt.Errorf("connection-logs-enabled conflicts with load balancer attribute %v", key)
+		}
+	}
+	if enabled, _ := strconv.ParseBool(values["access_logs.s3.enabled"]); !enabled {
+		return nil, fmt.Errorf("connection-logs-enabled requires access logs enabled with load balancer attribute access_logs.s3.enabled=true")
+	}
+	return append(attributes,
+		&elbv2.LoadBalancerAttribute{Key: aws.String("connection_logs.s3.enabled"), Value: aws.String("true")},
+		&elbv2.LoadBalancerAttribute{Key: aws.String("connection_logs.s3.bucket"), Value: aws.String(values["access_logs.s3.bucket"])},
+		&elbv2.LoadBalancerAttribute{Key: aws.String("connection_logs.s3.prefix"), Value: aws.String(values["access_logs.s3.prefix"])},
+	), nil
+}
+
+// bucketNamePattern matches valid names of S3 buckets that ELB can deliver logs to.
+var bucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// validateLogsDestinations validates the S3 buckets and prefixes that access logs and connection logs are delivered to,
+// so that misconfigurations are reported on the ingress rather than by ELB when modifying attributes.
+func validateLogsDestinations(attributes []*elbv2.LoadBalancerAttribute) error {
+	values := make(map[string]string, len(attributes))
+	for _, attr := range attributes {
+		values[aws.StringValue(attr.Key)] = aws.StringValue(attr.Value)
+	}
+	for _, logs := range []string{"access_logs", "connection_logs"} {
+		enabled, _ := strconv.ParseBool(values[logs+".s3.enabled"])
+		bucket := values[logs+".s3.bucket"]
+		prefix := values[logs+".s3.prefix"]
+		if enabled && bucket == "" {
+			return fmt.Errorf("%v.s3.bucket is required when %v.s3.enabled is true", logs, logs)
+		}
+		if bucket != "" && (!bucketNamePattern.MatchString(bucket) || strings.Contains(bucket, "..") || net.ParseIP(bucket) != nil) {
+			return fmt.Errorf("%v.s3.bucket %v is not a valid bucket name", logs, bucket)
+		}
+		if strings.HasPrefix(prefix, "/") || strings.HasSuffix(prefix, "/") {
+			return fmt.Errorf("%v.s3.prefix %v must not start or end with /", logs, prefix)
+		}
+		if strings.Contains(prefix, "AWSLogs") {
+			return fmt.Errorf("%v.s3.prefix %v must not contain AWSLogs", logs, prefix)
+		}
+	}
+	return nil
+}
+
 // parsePorts takes a JSON array describing what ports and protocols should be used. When the JSON
 // is empty, implying the annotation was not present, desired ports are set to the default. The
 // default port value is 80 when a certArn is not present and 443 when it is.
@@ -182,6 +385,7 @@ func parsePorts(ing parser.AnnotationInterface) ([]PortData, error) {
 
 	// Iterate over listeners in list. Validate port and protcol are correct, then inject them into
 	// the list of ListenerPorts.
+	protocolByPort := make(map[int64]string)
 	for _, l := range c {
 		for k, v := range l {
 			// Verify port value is valid for ALB.
@@ -189,6 +393,11 @@ func parsePorts(ing parser.AnnotationInterface) ([]PortData, error) {
 			if v < 1 || v > 65535 {
 				return nil, fmt.Errorf("Invalid port provided. Must be between 1 and 65535. It was %d", v)
 			}
+			// ALBs can only have a single listener per port, regardless of its protocol.
+			if protocol, ok := protocolByPort[v]; ok {
+				return nil, fmt.Errorf("listen-ports has port %d more than once, as %v and %v, each port can only have a single listener", v, protocol, k)
+			}
+			protocolByPort[v] = k
 			switch {
 			case k == elbv2.ProtocolEnumHttp:
 				lps = append(lps, PortData{v, k})
@@ -1 +1,341 @@
 package loadbalancer
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockBackend struct {
+	resolver.Mock
+}
+
+func TestIngressPrivateLink(t *testing.T) {
+	for _, tc := range []struct {
+		annotations         map[string]string
+		expectedPrivateLink bool
+		expectError         bool
+	}{
+		{
+			annotations:         map[string]string{},
+			expectedPrivateLink: false,
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("privatelink"): "true",
+			},
+			expectedPrivateLink: true,
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("privatelink"): "true",
+				parser.GetAnnotationWithPrefix("scheme"):      "internet-facing",
+			},
+			expectError: true,
+		},
+	} {
+		ing := dummy.NewIngress()
+		ing.SetAnnotations(tc.annotations)
+		i, err := NewParser(mockBackend{}).Parse(ing)
+		if tc.expectError {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expectedPrivateLink, i.(*Config).PrivateLink)
+	}
+}
+
+func TestIngressAlarms(t *testing.T) {
+	for _, tc := range []struct {
+		annotations             map[string]string
+		expectedAlarmThresholds map[string]float64
+		expectError             bool
+	}{
+		{
+			annotations: map[string]string{},
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("alarm-thresholds"):    "5xx-rate=5, target-response-time=0.5",
+				parser.GetAnnotationWithPrefix("alarm-sns-topic-arn"): "arn:aws:sns:us-west-2:123456789012:alerts",
+			},
+			expectedAlarmThresholds: map[string]float64{
+				AlarmKind5xxRate:            5,
+				AlarmKindTargetResponseTime: 0.5,
+			},
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("alarm-thresholds"): "5xx-rate=5",
+			},
+			expectError: true,
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("alarm-thresholds"):    "latency=5",
+				parser.GetAnnotationWithPrefix("alarm-sns-topic-arn"): "arn:aws:sns:us-west-2:123456789012:alerts",
+			},
+			expectError: true,
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("alarm-thresholds"):    "unhealthy-host-count=many",
+				parser.GetAnnotationWithPrefix("alarm-sns-topic-arn"): "arn:aws:sns:us-west-2:123456789012:alerts",
+			},
+			expectError: true,
+		},
+	} {
+		ing := dummy.NewIngress()
+		ing.SetAnnotations(tc.annotations)
+		i, err := NewParser(mockBackend{}).Parse(ing)
+		if tc.expectError {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expectedAlarmThresholds, i.(*Config).AlarmThresholds)
+	}
+}
+
+func TestIngressAccessLogsBucket(t *testing.T) {
+	for _, tc := range []struct {
+		annotations                      map[string]string
+		expectedAccessLogsBucketManaged  bool
+		expectedAccessLogsExpirationDays int64
+		expectError                      bool
+	}{
+		{
+			annotations: map[string]string{},
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("access-logs-bucket-managed"):  "true",
+				parser.GetAnnotationWithPrefix("access-logs-expiration-days"): "90",
+			},
+			expectedAccessLogsBucketManaged:  true,
+			expectedAccessLogsExpirationDays: 90,
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("access-logs-expiration-days"): "90",
+			},
+			expectError: true,
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("access-logs-bucket-managed"):  "true",
+				parser.GetAnnotationWithPrefix("access-logs-expiration-days"): "-1",
+			},
+			expectError: true,
+		},
+	} {
+		ing := dummy.NewIngress()
+		ing.SetAnnotations(tc.annotations)
+		i, err := NewParser(mockBackend{}).Parse(ing)
+		if tc.expectError {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expectedAccessLogsBucketManaged, i.(*Config).AccessLogsBucketManaged)
+		assert.Equal(t, tc.expectedAccessLogsExpirationDays, i.(*Config).AccessLogsExpirationDays)
+	}
+}
+
+func TestIngressConnectionLogs(t *testing.T) {
+	for _, tc := range []struct {
+		annotations        map[string]string
+		expectedAttributes []*elbv2.LoadBalancerAttribute
+		expectError        bool
+	}{
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("load-balancer-attributes"): "access_logs.s3.enabled=true,access_logs.s3.bucket=logs,access_logs.s3.prefix=team",
+				parser.GetAnnotationWithPrefix("connection-logs-enabled"):  "true",
+			},
+			expectedAttributes: []*elbv2.LoadBalancerAttribute{
+				{Key: aws.String("access_logs.s3.enabled"), Value: aws.String("true")},
+				{Key: aws.String("access_logs.s3.bucket"), Value: aws.String("logs")},
+				{Key: aws.String("access_logs.s3.prefix"), Value: aws.String("team")},
+				{Key: aws.String("connection_logs.s3.enabled"), Value: aws.String("true")},
+				{Key: aws.String("connection_logs.s3.bucket"), Value: aws.String("logs")},
+				{Key: aws.String("connection_logs.s3.prefix"), Value: aws.String("team")},
+			},
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("load-balancer-attributes"): "connection_logs.s3.enabled=true,connection_logs.s3.bucket=connection-logs",
+			},
+			expectedAttributes: []*elbv2.LoadBalancerAttribute{
+				{Key: aws.String("connection_logs.s3.enabled"), Value: aws.String("true")},
+				{Key: aws.String("connection_logs.s3.bucket"), Value: aws.String("connection-logs")},
+			},
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("connection-logs-enabled"): "true",
+			},
+			expectError: true,
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("load-balancer-attributes"): "access_logs.s3.enabled=true,access_logs.s3.bucket=logs,connection_logs.s3.enabled=false",
+				parser.GetAnnotationWithPrefix("connection-logs-enabled"):  "true",
+			},
+			expectError: true,
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("load-balancer-attributes"): "connection_logs.s3.enabled=true",
+			},
+			expectError: true,
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("load-balancer-attributes"): "access_logs.s3.enabled=true,access_logs.s3.bucket=My_Logs",
+			},
+			expectError: true,
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("load-balancer-attributes"): "access_logs.s3.enabled=true,access_logs.s3.bucket=logs,access_logs.s3.prefix=/team",
+			},
+			expectError: true,
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("load-balancer-attributes"): "access_logs.s3.enabled=true,access_logs.s3.bucket=logs,access_logs.s3.prefix=AWSLogs",
+			},
+			expectError: true,
+		},
+	} {
+		ing := dummy.NewIngress()
+		ing.SetAnnotations(tc.annotations)
+		i, err := NewParser(mockBackend{}).Parse(ing)
+		if tc.expectError {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expectedAttributes, i.(*Config).Attributes)
+	}
+}
+
+func TestIngressHTTPVersion(t *testing.T) {
+	for _, tc := range []struct {
+		annotations         map[string]string
+		expectedHTTPVersion string
+		expectedAttributes  []*elbv2.LoadBalancerAttribute
+		expectError         bool
+	}{
+		{
+			annotations:         map[string]string{},
+			expectedHTTPVersion: HTTPVersion2,
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("load-balancer-attributes"): "routing.http2.enabled=false",
+			},
+			expectedHTTPVersion: HTTPVersion1,
+			expectedAttributes: []*elbv2.LoadBalancerAttribute{
+				{Key: aws.String("routing.http2.enabled"), Value: aws.String("false")},
+			},
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("http-version"): "http1",
+			},
+			expectedHTTPVersion: HTTPVersion1,
+			expectedAttributes: []*elbv2.LoadBalancerAttribute{
+				{Key: aws.String("routing.http2.enabled"), Value: aws.String("false")},
+			},
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("http-version"):             "http2",
+				parser.GetAnnotationWithPrefix("load-balancer-attributes"): "routing.http2.enabled=true",
+			},
+			expectedHTTPVersion: HTTPVersion2,
+			expectedAttributes: []*elbv2.LoadBalancerAttribute{
+				{Key: aws.String("routing.http2.enabled"), Value: aws.String("true")},
+			},
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("http-version"):             "http1",
+				parser.GetAnnotationWithPrefix("load-balancer-attributes"): "routing.http2.enabled=true",
+			},
+			expectError: true,
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("http-version"): "grpc",
+			},
+			expectError: true,
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("http-version"): "http3",
+			},
+			expectError: true,
+		},
+	} {
+		ing := dummy.NewIngress()
+		ing.SetAnnotations(tc.annotations)
+		i, err := NewParser(mockBackend{}).Parse(ing)
+		if tc.expectError {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expectedHTTPVersion, i.(*Config).HTTPVersion)
+		assert.Equal(t, tc.expectedAttributes, i.(*Config).Attributes)
+	}
+}
+
+func TestIngressListenPorts(t *testing.T) {
+	for _, tc := range []struct {
+		annotations   map[string]string
+		expectedPorts []PortData
+		expectError   bool
+	}{
+		{
+			annotations:   map[string]string{},
+			expectedPorts: []PortData{{Port: 80, Scheme: elbv2.ProtocolEnumHttp}},
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("listen-ports"): `[{"HTTP": 80}, {"HTTPS": 443}]`,
+			},
+			expectedPorts: []PortData{{Port: 80, Scheme: elbv2.ProtocolEnumHttp}, {Port: 443, Scheme: elbv2.ProtocolEnumHttps}},
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("listen-ports"): `[{"HTTP": 8080}, {"HTTPS": 8080}]`,
+			},
+			expectError: true,
+		},
+		{
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("listen-ports"): `[{"HTTP": 80}, {"HTTP": 80}]`,
+			},
+			expectError: true,
+		},
+	} {
+		ing := dummy.NewIngress()
+		ing.SetAnnotations(tc.annotations)
+		i, err := NewParser(mockBackend{}).Parse(ing)
+		if tc.expectError {
+			assert.Error(t, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expectedPorts, i.(*Config).Ports)
+	}
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
+)
+
+// Annotations reads typed values from the annotations of a resource. Instead of failing on the first invalid annotation,
+// it collects the errors of all of them, so that they can be reported together.
+type Annotations struct {
+	annotations map[string]string
+	errs        errors.InvalidAnnotations
+}
+
+// NewAnnotations constructs Annotations reading the annotations of ing
+func NewAnnotations(ing AnnotationInterface) *Annotations {
+	a := &Annotations{}
+	if ing != nil {
+		a.annotations = ing.GetAnnotations()
+	}
+	return a
+}
+
+// Has returns whether annotation name is present
+func (a *Annotations) Has(name string) bool {
+	_, ok := a.annotations[GetAnnotationWithPrefix(name)]
+	return ok
+}
+
+// String returns the value of annotation name, or nil if it's missing
+func (a *Annotations) String(name string) *string {
+	val, ok := a.annotations[GetAnnotationWithPrefix(name)]
+	if !ok {
+		return nil
+	}
+	return &val
+}
+
+// StringSlice returns the comma separated values of annotation name, or nil if it's missing
+func (a *Annotations) StringSlice(name string) []string {
+	val, ok := a.annotations[GetAnnotationWithPrefix(name)]
+	if !ok {
+		return nil
+	}
+	var out []string
+	for _, part := range strings.Split(val, ",") {
+		if p := strings.TrimSpace(part); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Bool returns the value of annotation name, or nil if it's missing or invalid
+func (a *Annotations) Bool(name string) *bool {
+	val, ok := a.annotations[GetAnnotationWithPrefix(name)]
+	if !ok {
+		return nil
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		a.Invalid(name, "must be true or false")
+		return nil
+	}
+	return &b
+}
+
+// Int64 returns the value of annotation name, or nil if it's missing or invalid
+func (a *Annotations) Int64(name string) *int64 {
+	val, ok := a.annotations[GetAnnotationWithPrefix(name)]
+	if !ok {
+		return nil
+	}
+	i, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		a.Invalid(name, "must be an integer")
+		return nil
+	}
+	return &i
+}
+
+// JSON unmarshals the value of annotation name into v, and returns whether it's present and valid
+func (a *Annotations) JSON(name string, v interface{}) bool {
+	val, ok := a.annotations[GetAnnotationWithPrefix(name)]
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal([]byte(val), v); err != nil {
+		a.Invalid(name, "must be valid JSON: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// Invalid records the value of annotation name as invalid for reason
+func (a *Annotations) Invalid(name string, reason string) {
+	key := GetAnnotationWithPrefix(name)
+	a.errs = append(a.errs, errors.InvalidAnnotation{Name: key, Value: a.annotations[key], Reason: reason})
+}
+
+// Err returns the errors of all invalid annotations read so far, or nil if none
+func (a *Annotations) Err() error {
+	return a.errs.ErrOrNil()
+}
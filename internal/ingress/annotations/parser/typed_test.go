@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnotations(t *testing.T) {
+	ing := buildIngress()
+	ing.SetAnnotations(map[string]string{
+		GetAnnotationWithPrefix("string"):       "value",
+		GetAnnotationWithPrefix("slice"):        "a, b,,c",
+		GetAnnotationWithPrefix("bool"):         "true",
+		GetAnnotationWithPrefix("int"):          "42",
+		GetAnnotationWithPrefix("json"):         `{"key":"value"}`,
+		GetAnnotationWithPrefix("invalid-int"):  "forty-two",
+		GetAnnotationWithPrefix("invalid-bool"): "yes please",
+	})
+	annos := NewAnnotations(ing)
+
+	assert.True(t, annos.Has("string"))
+	assert.False(t, annos.Has("missing"))
+	assert.Equal(t, aws.String("value"), annos.String("string"))
+	assert.Nil(t, annos.String("missing"))
+	assert.Equal(t, []string{"a", "b", "c"}, annos.StringSlice("slice"))
+	assert.Nil(t, annos.StringSlice("missing"))
+	assert.Equal(t, aws.Bool(true), annos.Bool("bool"))
+	assert.Nil(t, annos.Bool("missing"))
+	assert.Equal(t, aws.Int64(42), annos.Int64("int"))
+	assert.Nil(t, annos.Int64("missing"))
+	var v map[string]string
+	assert.True(t, annos.JSON("json", &v))
+	assert.Equal(t, map[string]string{"key": "value"}, v)
+	assert.NoError(t, annos.Err())
+
+	assert.Nil(t, annos.Int64("invalid-int"))
+	assert.Nil(t, annos.Bool("invalid-bool"))
+	annos.Invalid("string", "must be another value")
+	assert.Equal(t, errors.InvalidAnnotations{
+		errors.InvalidAnnotation{Name: GetAnnotationWithPrefix("invalid-bool"), Value: "yes please", Reason: "must be true or false"},
+		errors.InvalidAnnotation{Name: GetAnnotationWithPrefix("invalid-int"), Value: "forty-two", Reason: "must be an integer"},
+		errors.InvalidAnnotation{Name: GetAnnotationWithPrefix("string"), Value: "value", Reason: "must be another value"},
+	}, annos.Err())
+}
+
+func TestAnnotations_nil(t *testing.T) {
+	annos := NewAnnotations(nil)
+	assert.Nil(t, annos.String("string"))
+	assert.NoError(t, annos.Err())
+}
@@ -0,0 +1,160 @@
+package restriction
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+)
+
+const (
+	// ActionReject blocks reconcile of ingresses setting denied annotations.
+	ActionReject = "reject"
+
+	// ActionStrip ignores denied annotations, and reconciles ingresses as if they were not set.
+	ActionStrip = "strip"
+)
+
+// Rule restricts the annotations ingresses may set in namespaces.
+type Rule struct {
+	// Namespaces are the namespaces rule applies to, as shell patterns like "team-*".
+	Namespaces []string `json:"namespaces"`
+
+	// DeniedAnnotations are the annotations denied regardless of value, without annotation prefix.
+	DeniedAnnotations []string `json:"deniedAnnotations,omitempty"`
+
+	// DeniedValues are the values denied per annotation, without annotation prefix.
+	DeniedValues map[string][]string `json:"deniedValues,omitempty"`
+
+	// Action is the action taken on denied annotations, ActionReject or ActionStrip.
+	Action string `json:"action"`
+}
+
+// Restrictions is a set of rules.
+type Restrictions struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Violation is a denied annotation set by an ingress.
+type Violation struct {
+	// Annotation is the name of annotation, without annotation prefix.
+	Annotation string
+	Value      string
+	Action     string
+
+	// ValueDenied is whether the value of annotation is denied rather than annotation itself.
+	ValueDenied bool
+}
+
+func (v Violation) String() string {
+	if v.ValueDenied {
+		return fmt.Sprintf("value %v of annotation %v is not allowed", v.Value, v.Annotation)
+	}
+	return fmt.Sprintf("annotation %v is not allowed", v.Annotation)
+}
+
+// Load loads the restrictions from YAML or JSON file at path.
+func Load(path string) (*Restrictions, error) {
+	payload, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read annotation restrictions file due to %v", err)
+	}
+	restrictions := &Restrictions{}
+	if err := yaml.Unmarshal(payload, restrictions); err != nil {
+		return nil, fmt.Errorf("failed to parse annotation restrictions file due to %v", err)
+	}
+	if err := restrictions.Validate(); err != nil {
+		return nil, err
+	}
+	return restrictions, nil
+}
+
+// Validate checks the rules of restrictions are well-formed.
+func (r *Restrictions) Validate() error {
+	for i, rule := range r.Rules {
+		if len(rule.Namespaces) == 0 {
+			return fmt.Errorf("annotation restriction rule %v must specify namespaces", i)
+		}
+		for _, pattern := range rule.Namespaces {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("annotation restriction rule %v has invalid namespace pattern %v", i, pattern)
+			}
+		}
+		if len(rule.DeniedAnnotations) == 0 && len(rule.DeniedValues) == 0 {
+			return fmt.Errorf("annotation restriction rule %v must specify deniedAnnotations or deniedValues", i)
+		}
+		if rule.Action != ActionReject && rule.Action != ActionStrip {
+			return fmt.Errorf("annotation restriction rule %v must have action %v or %v", i, ActionReject, ActionStrip)
+		}
+	}
+	return nil
+}
+
+// Apply returns annotations with the annotations denied in namespace removed, and the violations found.
+// annotations is returned as is if there are no violations. Nil restrictions deny nothing.
+func (r *Restrictions) Apply(namespace string, annotations map[string]string) (map[string]string, []Violation) {
+	if r == nil {
+		return annotations, nil
+	}
+	var violations []Violation
+	denied := make(map[string]bool)
+	for _, rule := range r.Rules {
+		if !matchNamespace(rule.Namespaces, namespace) {
+			continue
+		}
+		for _, name := range rule.DeniedAnnotations {
+			value, ok := annotations[parser.GetAnnotationWithPrefix(name)]
+			if !ok || denied[name] {
+				continue
+			}
+			denied[name] = true
+			violations = append(violations, Violation{Annotation: name, Value: value, Action: rule.Action})
+		}
+		for _, name := range sortedKeys(rule.DeniedValues) {
+			value, ok := annotations[parser.GetAnnotationWithPrefix(name)]
+			if !ok || denied[name] {
+				continue
+			}
+			for _, deniedValue := range rule.DeniedValues[name] {
+				if value == deniedValue {
+					denied[name] = true
+					violations = append(violations, Violation{Annotation: name, Value: value, Action: rule.Action, ValueDenied: true})
+					break
+				}
+			}
+		}
+	}
+	if len(violations) == 0 {
+		return annotations, nil
+	}
+
+	allowed := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		allowed[key] = value
+	}
+	for name := range denied {
+		delete(allowed, parser.GetAnnotationWithPrefix(name))
+	}
+	return allowed, violations
+}
+
+func matchNamespace(patterns []string, namespace string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, namespace); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,173 @@
+package restriction
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "restriction")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "restrictions.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`
+rules:
+- namespaces: ["team-*"]
+  deniedAnnotations: ["security-groups"]
+  deniedValues:
+    scheme: ["internet-facing"]
+  action: reject
+`), 0644))
+	restrictions, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, &Restrictions{
+		Rules: []Rule{
+			{
+				Namespaces:        []string{"team-*"},
+				DeniedAnnotations: []string{"security-groups"},
+				DeniedValues:      map[string][]string{"scheme": {"internet-facing"}},
+				Action:            ActionReject,
+			},
+		},
+	}, restrictions)
+}
+
+func TestRestrictions_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		rules         []Rule
+		expectedError string
+	}{
+		{
+			name:  "valid",
+			rules: []Rule{{Namespaces: []string{"team-*"}, DeniedAnnotations: []string{"scheme"}, Action: ActionStrip}},
+		},
+		{
+			name:          "no namespaces",
+			rules:         []Rule{{DeniedAnnotations: []string{"scheme"}, Action: ActionStrip}},
+			expectedError: "annotation restriction rule 0 must specify namespaces",
+		},
+		{
+			name:          "invalid namespace pattern",
+			rules:         []Rule{{Namespaces: []string{"team-["}, DeniedAnnotations: []string{"scheme"}, Action: ActionStrip}},
+			expectedError: "annotation restriction rule 0 has invalid namespace pattern team-[",
+		},
+		{
+			name:          "nothing denied",
+			rules:         []Rule{{Namespaces: []string{"team-*"}, Action: ActionStrip}},
+			expectedError: "annotation restriction rule 0 must specify deniedAnnotations or deniedValues",
+		},
+		{
+			name:          "unknown action",
+			rules:         []Rule{{Namespaces: []string{"team-*"}, DeniedAnnotations: []string{"scheme"}, Action: "drop"}},
+			expectedError: "annotation restriction rule 0 must have action reject or strip",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&Restrictions{Rules: tc.rules}).Validate()
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRestrictions_Apply(t *testing.T) {
+	restrictions := &Restrictions{
+		Rules: []Rule{
+			{
+				Namespaces:        []string{"team-*"},
+				DeniedAnnotations: []string{"security-groups"},
+				Action:            ActionStrip,
+			},
+			{
+				Namespaces:   []string{"team-*", "sandbox"},
+				DeniedValues: map[string][]string{"scheme": {"internet-facing"}},
+				Action:       ActionReject,
+			},
+		},
+	}
+	for _, tc := range []struct {
+		name                string
+		restrictions        *Restrictions
+		namespace           string
+		annotations         map[string]string
+		expectedAnnotations map[string]string
+		expectedViolations  []Violation
+	}{
+		{
+			name:         "nil restrictions",
+			restrictions: nil,
+			namespace:    "team-a",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/security-groups": "sg-1",
+			},
+			expectedAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/security-groups": "sg-1",
+			},
+		},
+		{
+			name:         "namespace not restricted",
+			restrictions: restrictions,
+			namespace:    "kube-system",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/security-groups": "sg-1",
+				"alb.ingress.kubernetes.io/scheme":          "internet-facing",
+			},
+			expectedAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/security-groups": "sg-1",
+				"alb.ingress.kubernetes.io/scheme":          "internet-facing",
+			},
+		},
+		{
+			name:         "allowed value",
+			restrictions: restrictions,
+			namespace:    "sandbox",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/security-groups": "sg-1",
+				"alb.ingress.kubernetes.io/scheme":          "internal",
+			},
+			expectedAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/security-groups": "sg-1",
+				"alb.ingress.kubernetes.io/scheme":          "internal",
+			},
+		},
+		{
+			name:         "denied annotation and value",
+			restrictions: restrictions,
+			namespace:    "team-a",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/security-groups": "sg-1",
+				"alb.ingress.kubernetes.io/scheme":          "internet-facing",
+				"alb.ingress.kubernetes.io/subnets":         "subnet-1",
+			},
+			expectedAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/subnets": "subnet-1",
+			},
+			expectedViolations: []Violation{
+				{Annotation: "security-groups", Value: "sg-1", Action: ActionStrip},
+				{Annotation: "scheme", Value: "internet-facing", Action: ActionReject, ValueDenied: true},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			annotations, violations := tc.restrictions.Apply(tc.namespace, tc.annotations)
+			assert.Equal(t, tc.expectedAnnotations, annotations)
+			assert.Equal(t, tc.expectedViolations, violations)
+		})
+	}
+}
+
+func TestViolation_String(t *testing.T) {
+	assert.Equal(t, "annotation security-groups is not allowed",
+		Violation{Annotation: "security-groups", Value: "sg-1"}.String())
+	assert.Equal(t, "value internet-facing of annotation scheme is not allowed",
+		Violation{Annotation: "scheme", Value: "internet-facing", ValueDenied: true}.String())
+}
@@ -0,0 +1,157 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchLimit bounds how far Next and Prev search for a matching time.
+const cronSearchLimit = 366 * 24 * time.Hour
+
+// Cron is a cron expression of five fields: minute, hour, day of month, month and day of week, evaluated in UTC.
+// Each field accepts `*`, values, ranges(`1-5`), lists(`1,3,5`) and steps(`*/15`, `0-30/10`).
+type Cron struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// domRestricted and dowRestricted follow cron semantics: when both day fields are restricted, either one matches.
+	domRestricted bool
+	dowRestricted bool
+}
+
+// ParseCron parses a cron expression.
+func ParseCron(expr string) (*Cron, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields", expr)
+	}
+	c := &Cron{}
+	var err error
+	if c.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("invalid minute in cron expression %q: %v", expr, err)
+	}
+	if c.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("invalid hour in cron expression %q: %v", expr, err)
+	}
+	if c.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("invalid day of month in cron expression %q: %v", expr, err)
+	}
+	if c.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("invalid month in cron expression %q: %v", expr, err)
+	}
+	if c.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("invalid day of week in cron expression %q: %v", expr, err)
+	}
+	// both 0 and 7 stand for Sunday.
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domRestricted = fields[2] != "*"
+	c.dowRestricted = fields[4] != "*"
+	return c, nil
+}
+
+// parseCronField parses a single field of cron expression into a bitset of values within [min, max].
+func parseCronField(field string, min int, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+			rangePart, step = part[:i], s
+		}
+
+		lo, hi := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+			if hi, err = strconv.Atoi(bounds[1]); err != nil {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+		default:
+			v, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			lo, hi = v, v
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q is out of range %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (c *Cron) dayMatches(t time.Time) bool {
+	domMatches := c.dom&(1<<uint(t.Day())) != 0
+	dowMatches := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	if c.domRestricted && c.dowRestricted {
+		return domMatches || dowMatches
+	}
+	return domMatches && dowMatches
+}
+
+func (c *Cron) hourMatches(t time.Time) bool {
+	return c.hour&(1<<uint(t.Hour())) != 0
+}
+
+func (c *Cron) minuteMatches(t time.Time) bool {
+	return c.minute&(1<<uint(t.Minute())) != 0
+}
+
+// Next returns the first time after t when cron fires, or false if it doesn't fire within a year.
+func (c *Cron) Next(t time.Time) (time.Time, bool) {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronSearchLimit)
+	for t.Before(limit) {
+		switch {
+		case !c.dayMatches(t):
+			t = t.Truncate(24 * time.Hour).Add(24 * time.Hour)
+		case !c.hourMatches(t):
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case !c.minuteMatches(t):
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Prev returns the last time at or before t when cron fired, or false if it didn't fire within a year.
+func (c *Cron) Prev(t time.Time) (time.Time, bool) {
+	t = t.UTC().Truncate(time.Minute)
+	limit := t.Add(-cronSearchLimit)
+	for t.After(limit) {
+		switch {
+		case !c.dayMatches(t):
+			t = t.Truncate(24 * time.Hour).Add(-time.Minute)
+		case !c.hourMatches(t):
+			t = t.Truncate(time.Hour).Add(-time.Minute)
+		case !c.minuteMatches(t):
+			t = t.Add(-time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
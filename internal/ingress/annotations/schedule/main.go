@@ -0,0 +1,145 @@
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// RuleSchedulesAnnotation declares schedules that enable and disable the rules of ingress hosts.
+const RuleSchedulesAnnotation = "rule-schedules"
+
+// RuleSchedule enables and disables the rules for Host on cron schedules.
+// The rules are enabled if Enable fired more recently than Disable.
+type RuleSchedule struct {
+	Host    string `json:"host"`
+	Enable  string `json:"enable"`
+	Disable string `json:"disable"`
+
+	enable  *Cron
+	disable *Cron
+}
+
+// NewRuleSchedule constructs a RuleSchedule that enables and disables the rules for host on cron expressions.
+func NewRuleSchedule(host string, enable string, disable string) (RuleSchedule, error) {
+	enableCron, err := ParseCron(enable)
+	if err != nil {
+		return RuleSchedule{}, fmt.Errorf("invalid enable schedule for host %v: %v", host, err)
+	}
+	disableCron, err := ParseCron(disable)
+	if err != nil {
+		return RuleSchedule{}, fmt.Errorf("invalid disable schedule for host %v: %v", host, err)
+	}
+	return RuleSchedule{
+		Host:    host,
+		Enable:  enable,
+		Disable: disable,
+		enable:  enableCron,
+		disable: disableCron,
+	}, nil
+}
+
+// Enabled returns whether the rules are enabled at now.
+// The rules are enabled if neither schedule fired within the last year.
+func (s *RuleSchedule) Enabled(now time.Time) bool {
+	lastEnable, enableFired := s.enable.Prev(now)
+	lastDisable, disableFired := s.disable.Prev(now)
+	if !disableFired {
+		return true
+	}
+	if !enableFired {
+		return false
+	}
+	return lastEnable.After(lastDisable)
+}
+
+// NextChange returns the next time after now when the rules become enabled or disabled.
+func (s *RuleSchedule) NextChange(now time.Time) (time.Time, bool) {
+	if s.Enabled(now) {
+		return s.disable.Next(now)
+	}
+	return s.enable.Next(now)
+}
+
+type Config struct {
+	Schedules []RuleSchedule
+}
+
+// GetSchedules returns the rule schedules, or nil if there is none.
+func (c *Config) GetSchedules() []RuleSchedule {
+	if c == nil {
+		return nil
+	}
+	return c.Schedules
+}
+
+// Enabled returns whether the rules for host are enabled at now. Hosts without schedule are always enabled.
+func (c *Config) Enabled(host string, now time.Time) bool {
+	if c == nil {
+		return true
+	}
+	for i := range c.Schedules {
+		if c.Schedules[i].Host == host {
+			return c.Schedules[i].Enabled(now)
+		}
+	}
+	return true
+}
+
+// NextChange returns the schedule that changes soonest after now, along with the time of the change.
+func (c *Config) NextChange(now time.Time) (*RuleSchedule, time.Time, bool) {
+	if c == nil {
+		return nil, time.Time{}, false
+	}
+	var next *RuleSchedule
+	var nextTime time.Time
+	for i := range c.Schedules {
+		t, ok := c.Schedules[i].NextChange(now)
+		if ok && (next == nil || t.Before(nextTime)) {
+			next, nextTime = &c.Schedules[i], t
+		}
+	}
+	return next, nextTime, next != nil
+}
+
+type schedule struct {
+	r resolver.Resolver
+}
+
+// NewParser creates a new rule schedule annotation parser
+func NewParser(r resolver.Resolver) parser.IngressAnnotation {
+	return schedule{r}
+}
+
+// Parse parses the annotations contained in the resource
+func (s schedule) Parse(ing parser.AnnotationInterface) (interface{}, error) {
+	raw, err := parser.GetStringAnnotation(RuleSchedulesAnnotation, ing)
+	if err != nil {
+		return nil, err
+	}
+
+	var schedules []RuleSchedule
+	if err := json.Unmarshal([]byte(*raw), &schedules); err != nil {
+		return nil, fmt.Errorf("failed to parse %v annotation due to %v", RuleSchedulesAnnotation, err)
+	}
+	hosts := sets.NewString()
+	for i, sch := range schedules {
+		if sch.Host == "" {
+			return nil, fmt.Errorf("%v annotation has a schedule without host", RuleSchedulesAnnotation)
+		}
+		if hosts.Has(sch.Host) {
+			return nil, fmt.Errorf("%v annotation has multiple schedules for host %v", RuleSchedulesAnnotation, sch.Host)
+		}
+		hosts.Insert(sch.Host)
+		if schedules[i], err = NewRuleSchedule(sch.Host, sch.Enable, sch.Disable); err != nil {
+			return nil, fmt.Errorf("%v annotation has %v", RuleSchedulesAnnotation, err)
+		}
+	}
+	return &Config{
+		Schedules: schedules,
+	}, nil
+}
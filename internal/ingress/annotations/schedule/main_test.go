@@ -0,0 +1,128 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockBackend struct {
+	resolver.Mock
+}
+
+func mustParseTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestCron(t *testing.T) {
+	for _, tc := range []struct {
+		expr         string
+		now          string
+		expectedPrev string
+		expectedNext string
+		expectError  bool
+	}{
+		{
+			expr:         "0 9 * * 1-5",
+			now:          "2019-01-05T12:00:00Z", // Saturday
+			expectedPrev: "2019-01-04T09:00:00Z",
+			expectedNext: "2019-01-07T09:00:00Z",
+		},
+		{
+			expr:         "*/15 * * * *",
+			now:          "2019-01-05T12:07:30Z",
+			expectedPrev: "2019-01-05T12:00:00Z",
+			expectedNext: "2019-01-05T12:15:00Z",
+		},
+		{
+			expr:         "30 18 1,15 * *",
+			now:          "2019-01-15T18:30:00Z",
+			expectedPrev: "2019-01-15T18:30:00Z",
+			expectedNext: "2019-02-01T18:30:00Z",
+		},
+		{
+			expr:         "0 0 * * 7",
+			now:          "2019-01-05T12:00:00Z",
+			expectedPrev: "2018-12-30T00:00:00Z",
+			expectedNext: "2019-01-06T00:00:00Z",
+		},
+		{expr: "0 9 * *", expectError: true},
+		{expr: "60 9 * * *", expectError: true},
+		{expr: "0 9-5 * * *", expectError: true},
+		{expr: "*/0 * * * *", expectError: true},
+		{expr: "0 9 * jan *", expectError: true},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			c, err := ParseCron(tc.expr)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			now := mustParseTime(tc.now)
+			prev, ok := c.Prev(now)
+			assert.True(t, ok)
+			assert.Equal(t, mustParseTime(tc.expectedPrev), prev)
+			next, ok := c.Next(now)
+			assert.True(t, ok)
+			assert.Equal(t, mustParseTime(tc.expectedNext), next)
+		})
+	}
+}
+
+func TestIngressRuleSchedules(t *testing.T) {
+	ing := dummy.NewIngress()
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix(RuleSchedulesAnnotation): `[{"host": "beta.example.com", "enable": "0 9 * * 1-5", "disable": "0 18 * * 1-5"}]`,
+	})
+	i, err := NewParser(mockBackend{}).Parse(ing)
+	assert.NoError(t, err)
+	cfg := i.(*Config)
+
+	for _, tc := range []struct {
+		now             string
+		expectedEnabled bool
+		expectedChange  string
+	}{
+		{now: "2019-01-07T10:00:00Z", expectedEnabled: true, expectedChange: "2019-01-07T18:00:00Z"},
+		{now: "2019-01-07T18:00:00Z", expectedEnabled: false, expectedChange: "2019-01-08T09:00:00Z"},
+		{now: "2019-01-05T10:00:00Z", expectedEnabled: false, expectedChange: "2019-01-07T09:00:00Z"},
+	} {
+		now := mustParseTime(tc.now)
+		assert.Equal(t, tc.expectedEnabled, cfg.Enabled("beta.example.com", now))
+		assert.True(t, cfg.Enabled("www.example.com", now))
+		sch, changeTime, ok := cfg.NextChange(now)
+		assert.True(t, ok)
+		assert.Equal(t, "beta.example.com", sch.Host)
+		assert.Equal(t, mustParseTime(tc.expectedChange), changeTime)
+	}
+
+	var nilConfig *Config
+	assert.True(t, nilConfig.Enabled("beta.example.com", time.Now()))
+	_, _, ok := nilConfig.NextChange(time.Now())
+	assert.False(t, ok)
+}
+
+func TestInvalidIngressRuleSchedules(t *testing.T) {
+	for _, annotation := range []string{
+		`{"host": "beta.example.com", "enable": "0 9 * * *", "disable": "0 18 * * *"}`,
+		`[{"enable": "0 9 * * *", "disable": "0 18 * * *"}]`,
+		`[{"host": "beta.example.com", "enable": "0 9 * * *"}]`,
+		`[{"host": "beta.example.com", "enable": "0 9 * * *", "disable": "0 18 * * *"}, {"host": "beta.example.com", "enable": "0 9 * * *", "disable": "0 18 * * *"}]`,
+	} {
+		ing := dummy.NewIngress()
+		ing.SetAnnotations(map[string]string{
+			parser.GetAnnotationWithPrefix(RuleSchedulesAnnotation): annotation,
+		})
+		_, err := NewParser(mockBackend{}).Parse(ing)
+		assert.Error(t, err, annotation)
+	}
+}
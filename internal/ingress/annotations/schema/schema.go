@@ -0,0 +1,178 @@
+package schema
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+)
+
+// Types of annotation values, annotation values are always strings encoded as described in the annotations guide.
+const (
+	TypeBoolean    = "boolean"
+	TypeInteger    = "integer"
+	TypeString     = "string"
+	TypeStringList = "stringList"
+	TypeStringMap  = "stringMap"
+	TypeJSON       = "json"
+)
+
+// Objects annotations can be applied to.
+const (
+	LocationIngress = "ingress"
+	LocationService = "service"
+)
+
+// Annotation describes an annotation supported by the controller.
+type Annotation struct {
+	// Name is the annotation key without prefix, a trailing ${placeholder} stands for any name.
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Values are the allowed values, any value of Type is allowed if empty.
+	Values []string `json:"values,omitempty"`
+	// Default is the value used when annotation is absent, empty if there is no default.
+	Default   string   `json:"default,omitempty"`
+	Locations []string `json:"locations"`
+	// Deprecated is whether the annotation is superseded by another one.
+	Deprecated bool `json:"deprecated,omitempty"`
+}
+
+var ingressOnly = []string{LocationIngress}
+var ingressAndService = []string{LocationIngress, LocationService}
+
+// Annotations are the annotations supported by the controller, sorted by name.
+var Annotations = []Annotation{
+	{Name: "access-logs-bucket-managed", Type: TypeBoolean, Default: "false", Locations: ingressOnly},
+	{Name: "access-logs-expiration-days", Type: TypeInteger, Locations: ingressOnly},
+	{Name: "actions.${action-name}", Type: TypeJSON, Locations: ingressOnly},
+	{Name: "affinity", Type: TypeJSON, Locations: ingressAndService},
+	{Name: "alarm-sns-topic-arn", Type: TypeString, Locations: ingressOnly},
+	{Name: "alarm-thresholds", Type: TypeStringMap, Locations: ingressOnly},
+	{Name: "attributes", Type: TypeStringMap, Locations: ingressOnly, Deprecated: true},
+	{Name: "auth-idp-cognito", Type: TypeJSON, Locations: ingressAndService},
+	{Name: "auth-idp-oidc", Type: TypeJSON, Locations: ingressAndService},
+	{Name: "auth-on-unauthenticated-request", Type: TypeString, Values: []string{"authenticate", "allow", "deny"}, Default: "authenticate", Locations: ingressAndService},
+	{Name: "auth-scope", Type: TypeString, Default: "openid", Locations: ingressAndService},
+	{Name: "auth-session-cookie", Type: TypeString, Default: "AWSELBAuthSessionCookie", Locations: ingressAndService},
+	{Name: "auth-session-timeout", Type: TypeInteger, Default: "604800", Locations: ingressAndService},
+	{Name: "auth-type", Type: TypeString, Values: []string{"none", "oidc", "cognito"}, Default: "none", Locations: ingressAndService},
+	{Name: "backend-protocol", Type: TypeString, Values: []string{"HTTP", "HTTPS"}, Default: "HTTP", Locations: ingressAndService},
+	{Name: "certificate-arn", Type: TypeStringList, Locations: ingressOnly},
+	{Name: "confirm-removals", Type: TypeBoolean, Default: "false", Locations: ingressOnly},
+	{Name: "connection-logs-enabled", Type: TypeBoolean, Default: "false", Locations: ingressOnly},
+	{Name: "default-actions", Type: TypeJSON, Locations: ingressOnly},
+	{Name: "default-backend-rule", Type: TypeBoolean, Default: "false", Locations: ingressOnly},
+	{Name: "healthcheck-interval-seconds", Type: TypeInteger, Default: "15", Locations: ingressAndService},
+	{Name: "healthcheck-overrides", Type: TypeJSON, Locations: ingressAndService},
+	{Name: "healthcheck-path", Type: TypeString, Default: "/", Locations: ingressAndService},
+	{Name: "healthcheck-port", Type: TypeString, Default: "traffic-port", Locations: ingressAndService},
+	{Name: "healthcheck-protocol", Type: TypeString, Values: []string{"HTTP", "HTTPS"}, Default: "HTTP", Locations: ingressAndService},
+	{Name: "healthcheck-timeout-seconds", Type: TypeInteger, Default: "5", Locations: ingressAndService},
+	{Name: "healthy-threshold-count", Type: TypeInteger, Default: "2", Locations: ingressAndService},
+	{Name: "http-version", Type: TypeString, Values: []string{"http1", "http2"}, Default: "http2", Locations: ingressOnly},
+	{Name: "inbound-cidrs", Type: TypeStringList, Default: "0.0.0.0/0", Locations: ingressOnly},
+	{Name: "ip-address-type", Type: TypeString, Values: []string{"ipv4", "dualstack"}, Default: "ipv4", Locations: ingressOnly},
+	{Name: "listen-ports", Type: TypeJSON, Locations: ingressOnly},
+	{Name: "load-balancer-attributes", Type: TypeStringMap, Locations: ingressOnly},
+	{Name: "maintenance-action", Type: TypeString, Locations: ingressOnly},
+	{Name: "min-healthy-targets", Type: TypeInteger, Default: "0", Locations: ingressAndService},
+	{Name: "mutual-authentication", Type: TypeJSON, Locations: ingressOnly},
+	{Name: "privatelink", Type: TypeBoolean, Default: "false", Locations: ingressOnly},
+	{Name: "rollout-slow-start-seconds", Type: TypeInteger, Locations: ingressAndService},
+	{Name: "rule-schedules", Type: TypeJSON, Locations: ingressOnly},
+	{Name: "scheme", Type: TypeString, Values: []string{"internal", "internet-facing"}, Default: "internal", Locations: ingressOnly},
+	{Name: "security-group-inbound-cidrs", Type: TypeStringList, Locations: ingressOnly, Deprecated: true},
+	{Name: "security-groups", Type: TypeStringList, Locations: ingressOnly},
+	{Name: "smoke-test", Type: TypeBoolean, Default: "false", Locations: ingressOnly},
+	{Name: "ssl-policy", Type: TypeString, Default: "ELBSecurityPolicy-2016-08", Locations: ingressOnly},
+	{Name: "subnets", Type: TypeStringList, Locations: ingressOnly},
+	{Name: "success-codes", Type: TypeString, Default: "200", Locations: ingressAndService},
+	{Name: "tags", Type: TypeStringMap, Locations: ingressOnly},
+	{Name: "target-group-attributes", Type: TypeStringMap, Locations: ingressAndService},
+	{Name: "target-type", Type: TypeString, Values: []string{"instance", "ip"}, Default: "instance", Locations: ingressAndService},
+	{Name: "unhealthy-threshold-count", Type: TypeInteger, Default: "2", Locations: ingressAndService},
+	{Name: "waf-acl-id", Type: TypeString, Locations: ingressOnly, Deprecated: true},
+	{Name: "web-acl-id", Type: TypeString, Locations: ingressOnly},
+}
+
+var placeholderPattern = regexp.MustCompile(`\$\{[^}]*\}$`)
+
+// patternByType are the patterns values of each type must match, types without pattern accept any string.
+var patternByType = map[string]string{
+	TypeInteger:    `^-?[0-9]+$`,
+	TypeStringMap:  `^[^=,]+=[^,]*(,[^=,]+=[^,]*)*$`,
+	TypeStringList: `^[^,]+(,[^,]+)*$`,
+}
+
+// JSONSchema returns the JSON schema of annotations on objects at location, with annotation keys prefixed by prefix.
+// Annotations which aren't prefixed are allowed, since they're used by other controllers.
+func JSONSchema(prefix string, location string) map[string]interface{} {
+	properties := make(map[string]interface{})
+	patternProperties := make(map[string]interface{})
+	for _, annotation := range Annotations {
+		if !contains(annotation.Locations, location) {
+			continue
+		}
+		property := map[string]interface{}{
+			"type":        "string",
+			"description": annotation.Type,
+		}
+		switch {
+		case len(annotation.Values) > 0:
+			property["enum"] = annotation.Values
+		case annotation.Type == TypeBoolean:
+			property["enum"] = []string{"true", "false"}
+		case patternByType[annotation.Type] != "":
+			property["pattern"] = patternByType[annotation.Type]
+		}
+		if annotation.Default != "" {
+			property["default"] = annotation.Default
+		}
+		if annotation.Deprecated {
+			property["deprecated"] = true
+		}
+
+		key := prefix + "/" + annotation.Name
+		if placeholderPattern.MatchString(annotation.Name) {
+			name := placeholderPattern.ReplaceAllString(annotation.Name, "")
+			patternProperties["^"+regexp.QuoteMeta(prefix+"/"+name)+"[^/]+$"] = property
+			continue
+		}
+		properties[key] = property
+	}
+	return map[string]interface{}{
+		"$schema":           "http://json-schema.org/draft-07/schema#",
+		"title":             strings.Title(location) + " annotations",
+		"type":              "object",
+		"properties":        properties,
+		"patternProperties": patternProperties,
+	}
+}
+
+// Handler serves the JSON schemas of ingress and service annotations, keyed by location.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		schemas := map[string]interface{}{
+			LocationIngress: JSONSchema(parser.AnnotationsPrefix, LocationIngress),
+			LocationService: JSONSchema(parser.AnnotationsPrefix, LocationService),
+		}
+		b, err := json.MarshalIndent(schemas, "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(b)
+	})
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package schema
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnotations_SortedAndUnique(t *testing.T) {
+	var names []string
+	for _, annotation := range Annotations {
+		names = append(names, annotation.Name)
+	}
+	assert.True(t, sort.StringsAreSorted(names), "annotations must be sorted by name")
+	for i := 1; i < len(names); i++ {
+		assert.NotEqual(t, names[i-1], names[i], "annotations must be unique")
+	}
+}
+
+func TestAnnotations_Documented(t *testing.T) {
+	doc, err := ioutil.ReadFile("../../../../docs/guide/ingress/annotation.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	known := make(map[string]bool)
+	for _, annotation := range Annotations {
+		known[annotation.Name] = true
+	}
+	pattern := regexp.MustCompile("(?m)^\\|\\[alb\\.ingress\\.kubernetes\\.io/([^\\]]+)\\]")
+	matches := pattern.FindAllStringSubmatch(string(doc), -1)
+	assert.NotEmpty(t, matches)
+	for _, match := range matches {
+		assert.True(t, known[match[1]], "annotation %v is documented but missing in schema", match[1])
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		location         string
+		expectedKeys     []string
+		unexpectedKeys   []string
+		expectedPatterns []string
+	}{
+		{
+			name:             "ingress",
+			location:         LocationIngress,
+			expectedKeys:     []string{"example.com/scheme", "example.com/target-type"},
+			expectedPatterns: []string{`^example\.com/actions\.[^/]+$`},
+		},
+		{
+			name:           "service",
+			location:       LocationService,
+			expectedKeys:   []string{"example.com/target-type"},
+			unexpectedKeys: []string{"example.com/scheme"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			schema := JSONSchema("example.com", tc.location)
+			properties := schema["properties"].(map[string]interface{})
+			patternProperties := schema["patternProperties"].(map[string]interface{})
+			for _, key := range tc.expectedKeys {
+				assert.Contains(t, properties, key)
+			}
+			for _, key := range tc.unexpectedKeys {
+				assert.NotContains(t, properties, key)
+			}
+			for _, pattern := range tc.expectedPatterns {
+				assert.Contains(t, patternProperties, pattern)
+			}
+		})
+	}
+
+	scheme := JSONSchema("example.com", LocationIngress)["properties"].(map[string]interface{})["example.com/scheme"].(map[string]interface{})
+	assert.Equal(t, []string{"internal", "internet-facing"}, scheme["enum"])
+	assert.Equal(t, "internal", scheme["default"])
+}
+
+func TestHandler(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/annotations/schema", nil))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	var schemas map[string]map[string]interface{}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &schemas))
+	assert.Contains(t, schemas, LocationIngress)
+	assert.Contains(t, schemas, LocationService)
+}
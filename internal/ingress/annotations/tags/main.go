@@ -39,16 +39,13 @@ func NewParser(r resolver.Resolver) parser.IngressAnnotation {
 
 // Parse parses the annotations contained in the resource
 func (tg targetGroup) Parse(ing parser.AnnotationInterface) (interface{}, error) {
+	annos := parser.NewAnnotations(ing)
 	lbtags := make(map[string]string)
 	var badTags []string
 
-	tags := parser.GetStringSliceAnnotation("tags", ing)
-	for _, tag := range tags {
+	for _, tag := range annos.StringSlice("tags") {
 		parts := strings.Split(tag, "=")
-		switch {
-		case tag == "":
-			continue
-		case len(parts) < 2:
+		if len(parts) < 2 {
 			badTags = append(badTags, tag)
 			continue
 		}
@@ -56,7 +53,8 @@ func (tg targetGroup) Parse(ing parser.AnnotationInterface) (interface{}, error)
 	}
 
 	if len(badTags) > 0 {
-		return nil, fmt.Errorf("Unable to parse `%s` into Key=Value pair(s)", strings.Join(badTags, ", "))
+		annos.Invalid("tags", fmt.Sprintf("unable to parse `%s` into Key=Value pair(s)", strings.Join(badTags, ", ")))
+		return nil, annos.Err()
 	}
 
 	return &Config{
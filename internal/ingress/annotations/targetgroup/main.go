@@ -17,7 +17,9 @@ limitations under the License.
 package targetgroup
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
@@ -36,6 +38,25 @@ type Config struct {
 	SuccessCodes            *string
 	TargetType              *string
 	UnhealthyThresholdCount *int64
+
+	// MinHealthyTargets is the minimum number of healthy targets to keep registered when deregistering targets.
+	MinHealthyTargets *int64
+
+	// RolloutSlowStartSeconds is the slow start duration applied only while the backing deployment of service is rolling out.
+	RolloutSlowStartSeconds *int64
+
+	// HealthCheckOverrides are health check settings for specific service ports, keyed by `servicePort` or `serviceName:servicePort`.
+	HealthCheckOverrides map[string]*HealthCheckOverride
+}
+
+// HealthCheckOverride overrides health check settings for target group of a specific service port.
+type HealthCheckOverride struct {
+	Port                    *string `json:"port,omitempty"`
+	Protocol                *string `json:"protocol,omitempty"`
+	IntervalSeconds         *int64  `json:"intervalSeconds,omitempty"`
+	TimeoutSeconds          *int64  `json:"timeoutSeconds,omitempty"`
+	HealthyThresholdCount   *int64  `json:"healthyThresholdCount,omitempty"`
+	UnhealthyThresholdCount *int64  `json:"unhealthyThresholdCount,omitempty"`
 }
 
 type targetGroup struct {
@@ -47,6 +68,14 @@ const (
 	DefaultHealthyThresholdCount   = 2
 	DefaultUnhealthyThresholdCount = 2
 	DefaultSuccessCodes            = "200"
+
+	// HTTP codes accepted by ELBv2 matcher.
+	minSuccessCode = 200
+	maxSuccessCode = 499
+
+	// slow start durations accepted by ELBv2.
+	minSlowStartSeconds = 30
+	maxSlowStartSeconds = 900
 )
 
 // NewParser creates a new target group annotation parser
@@ -93,11 +122,48 @@ func (tg targetGroup) Parse(ing parser.AnnotationInterface) (interface{}, error)
 		successCodes = s
 	}
 
+	if successCodes, err = normalizeSuccessCodes(*successCodes); err != nil {
+		return nil, err
+	}
+
 	attributes, err := parseAttributes(ing)
 	if err != nil {
 		return nil, err
 	}
 
+	affinityAttributes, err := parseAffinity(ing)
+	if err != nil {
+		return nil, err
+	}
+	if len(affinityAttributes) > 0 {
+		for _, attribute := range attributes {
+			if strings.HasPrefix(aws.StringValue(attribute.Key), "stickiness.") {
+				return nil, fmt.Errorf("target-group-attributes cannot specify %v together with affinity annotation", aws.StringValue(attribute.Key))
+			}
+		}
+		attributes = append(attributes, affinityAttributes...)
+	}
+
+	healthCheckOverrides, err := parseHealthCheckOverrides(ing)
+	if err != nil {
+		return nil, err
+	}
+
+	minHealthyTargets, err := parser.GetInt64Annotation("min-healthy-targets", ing)
+	if err != nil {
+		if err != errors.ErrMissingAnnotations {
+			return nil, err
+		}
+	}
+	if aws.Int64Value(minHealthyTargets) < 0 {
+		return nil, errors.NewInvalidAnnotationContent("min-healthy-targets", *minHealthyTargets)
+	}
+
+	rolloutSlowStartSeconds, err := parseRolloutSlowStartSeconds(ing, attributes)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		TargetType:              targetType,
 		BackendProtocol:         backendProtocol,
@@ -105,15 +171,63 @@ func (tg targetGroup) Parse(ing parser.AnnotationInterface) (interface{}, error)
 		UnhealthyThresholdCount: unhealthyThresholdCount,
 		SuccessCodes:            successCodes,
 		Attributes:              attributes,
+		MinHealthyTargets:       minHealthyTargets,
+		RolloutSlowStartSeconds: rolloutSlowStartSeconds,
+		HealthCheckOverrides:    healthCheckOverrides,
 	}, nil
 }
 
+// normalizeSuccessCodes validates successCodes is a comma separated list of HTTP codes or code ranges(e.g. 200-299,301)
+// that ELBv2 accepts for matcher, and returns it without whitespaces.
+func normalizeSuccessCodes(successCodes string) (*string, error) {
+	var parts []string
+	for _, part := range strings.Split(successCodes, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, fmt.Errorf("invalid success-codes %q, empty code", successCodes)
+		}
+		bounds := strings.Split(part, "-")
+		if len(bounds) > 2 {
+			return nil, fmt.Errorf("invalid success-codes %q, malformed range %v", successCodes, part)
+		}
+		var codes []int64
+		for _, bound := range bounds {
+			code, err := strconv.ParseInt(strings.TrimSpace(bound), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid success-codes %q, %v is not a number", successCodes, bound)
+			}
+			if code < minSuccessCode || code > maxSuccessCode {
+				return nil, fmt.Errorf("invalid success-codes %q, %v must be within %v-%v", successCodes, code, minSuccessCode, maxSuccessCode)
+			}
+			codes = append(codes, code)
+		}
+		if len(codes) == 2 {
+			if codes[0] >= codes[1] {
+				return nil, fmt.Errorf("invalid success-codes %q, range %v must be ascending", successCodes, part)
+			}
+			part = fmt.Sprintf("%v-%v", codes[0], codes[1])
+		} else {
+			part = fmt.Sprintf("%v", codes[0])
+		}
+		parts = append(parts, part)
+	}
+	return aws.String(strings.Join(parts, ",")), nil
+}
+
 // Merge merge two config according to defaults in cfg
 func (a *Config) Merge(b *Config, cfg *config.Configuration) *Config {
 	attributes := a.Attributes
 	if attributes == nil {
 		attributes = b.Attributes
 	}
+	minHealthyTargets := a.MinHealthyTargets
+	if minHealthyTargets == nil {
+		minHealthyTargets = b.MinHealthyTargets
+	}
+	rolloutSlowStartSeconds := a.RolloutSlowStartSeconds
+	if rolloutSlowStartSeconds == nil {
+		rolloutSlowStartSeconds = b.RolloutSlowStartSeconds
+	}
 
 	return &Config{
 		Attributes:              attributes,
@@ -122,7 +236,76 @@ func (a *Config) Merge(b *Config, cfg *config.Configuration) *Config {
 		SuccessCodes:            parser.MergeString(a.SuccessCodes, b.SuccessCodes, DefaultSuccessCodes),
 		HealthyThresholdCount:   parser.MergeInt64(a.HealthyThresholdCount, b.HealthyThresholdCount, DefaultHealthyThresholdCount),
 		UnhealthyThresholdCount: parser.MergeInt64(a.UnhealthyThresholdCount, b.UnhealthyThresholdCount, DefaultUnhealthyThresholdCount),
+		MinHealthyTargets:       minHealthyTargets,
+		RolloutSlowStartSeconds: rolloutSlowStartSeconds,
+		HealthCheckOverrides:    mergeHealthCheckOverrides(a.HealthCheckOverrides, b.HealthCheckOverrides),
+	}
+}
+
+// GetHealthCheckOverride returns the health check override for servicePort of serviceName, or nil if there is none.
+// An override keyed by `serviceName:servicePort` takes precedence over the one keyed by `servicePort`.
+func (a *Config) GetHealthCheckOverride(serviceName string, servicePort string) *HealthCheckOverride {
+	if override, ok := a.HealthCheckOverrides[serviceName+":"+servicePort]; ok {
+		return override
+	}
+	return a.HealthCheckOverrides[servicePort]
+}
+
+// mergeHealthCheckOverrides merges overrides of a into b, overrides in a takes precedence.
+func mergeHealthCheckOverrides(a, b map[string]*HealthCheckOverride) map[string]*HealthCheckOverride {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+	output := make(map[string]*HealthCheckOverride, len(a)+len(b))
+	for k, v := range b {
+		output[k] = v
+	}
+	for k, v := range a {
+		output[k] = v
+	}
+	return output
+}
+
+func parseHealthCheckOverrides(ing parser.AnnotationInterface) (map[string]*HealthCheckOverride, error) {
+	raw, err := parser.GetStringAnnotation("healthcheck-overrides", ing)
+	if err != nil {
+		return nil, nil
+	}
+	var overrides map[string]*HealthCheckOverride
+	if err := json.Unmarshal([]byte(*raw), &overrides); err != nil {
+		return nil, fmt.Errorf("failed to parse healthcheck-overrides annotation due to %v", err)
+	}
+	for key, override := range overrides {
+		if override == nil {
+			return nil, fmt.Errorf("healthcheck-overrides for %v must not be empty", key)
+		}
+		if override.Protocol != nil && *override.Protocol != elbv2.ProtocolEnumHttp && *override.Protocol != elbv2.ProtocolEnumHttps {
+			return nil, fmt.Errorf("healthcheck-overrides protocol for %v must be HTTP or HTTPS, not %v", key, *override.Protocol)
+		}
+		if override.Port != nil && len(*override.Port) == 0 {
+			return nil, fmt.Errorf("healthcheck-overrides port for %v must not be empty", key)
+		}
+		if err := validateRange(override.IntervalSeconds, 5, 300); err != nil {
+			return nil, fmt.Errorf("healthcheck-overrides intervalSeconds for %v %v", key, err)
+		}
+		if err := validateRange(override.TimeoutSeconds, 2, 120); err != nil {
+			return nil, fmt.Errorf("healthcheck-overrides timeoutSeconds for %v %v", key, err)
+		}
+		if err := validateRange(override.HealthyThresholdCount, 2, 10); err != nil {
+			return nil, fmt.Errorf("healthcheck-overrides healthyThresholdCount for %v %v", key, err)
+		}
+		if err := validateRange(override.UnhealthyThresholdCount, 2, 10); err != nil {
+			return nil, fmt.Errorf("healthcheck-overrides unhealthyThresholdCount for %v %v", key, err)
+		}
+	}
+	return overrides, nil
+}
+
+func validateRange(value *int64, min int64, max int64) error {
+	if value != nil && (*value < min || *value > max) {
+		return fmt.Errorf("must be within %v-%v, not %v", min, max, *value)
 	}
+	return nil
 }
 
 func parseAttributes(ing parser.AnnotationInterface) ([]*elbv2.TargetGroupAttribute, error) {
@@ -151,6 +334,106 @@ func parseAttributes(ing parser.AnnotationInterface) ([]*elbv2.TargetGroupAttrib
 	return output, nil
 }
 
+// Affinity is the session affinity configuration, which translates into stickiness attributes of target group.
+type Affinity struct {
+	// Mode is one of none, lb_cookie or app_cookie.
+	Mode string `json:"mode"`
+
+	// CookieName is the name of application cookie, required for app_cookie mode.
+	CookieName string `json:"cookieName,omitempty"`
+
+	// DurationSeconds is the time period during which requests from a client are routed to the same target.
+	DurationSeconds int64 `json:"durationSeconds,omitempty"`
+}
+
+const (
+	AffinityModeNone      = "none"
+	AffinityModeLbCookie  = "lb_cookie"
+	AffinityModeAppCookie = "app_cookie"
+)
+
+// reservedCookieNamePrefixes are reserved by ALB for its own cookies.
+var reservedCookieNamePrefixes = []string{"AWSALB", "AWSALBAPP", "AWSALBTG"}
+
+func parseAffinity(ing parser.AnnotationInterface) ([]*elbv2.TargetGroupAttribute, error) {
+	raw, err := parser.GetStringAnnotation("affinity", ing)
+	if err != nil {
+		return nil, nil
+	}
+	affinity := Affinity{}
+	if err := json.Unmarshal([]byte(*raw), &affinity); err != nil {
+		return nil, fmt.Errorf("failed to parse affinity annotation due to %v", err)
+	}
+	if affinity.DurationSeconds != 0 && (affinity.DurationSeconds < 1 || affinity.DurationSeconds > 604800) {
+		return nil, fmt.Errorf("affinity durationSeconds must be within 1-604800 seconds, not %v", affinity.DurationSeconds)
+	}
+
+	switch affinity.Mode {
+	case AffinityModeNone:
+		if affinity.CookieName != "" || affinity.DurationSeconds != 0 {
+			return nil, fmt.Errorf("affinity mode %v doesn't accept cookieName or durationSeconds", affinity.Mode)
+		}
+		return []*elbv2.TargetGroupAttribute{
+			tgAttribute("stickiness.enabled", "false"),
+		}, nil
+	case AffinityModeLbCookie:
+		if affinity.CookieName != "" {
+			return nil, fmt.Errorf("affinity mode %v doesn't accept cookieName", affinity.Mode)
+		}
+		output := []*elbv2.TargetGroupAttribute{
+			tgAttribute("stickiness.enabled", "true"),
+			tgAttribute("stickiness.type", AffinityModeLbCookie),
+		}
+		if affinity.DurationSeconds != 0 {
+			output = append(output, tgAttribute("stickiness.lb_cookie.duration_seconds", fmt.Sprintf("%v", affinity.DurationSeconds)))
+		}
+		return output, nil
+	case AffinityModeAppCookie:
+		if affinity.CookieName == "" {
+			return nil, fmt.Errorf("affinity mode %v requires cookieName", affinity.Mode)
+		}
+		for _, prefix := range reservedCookieNamePrefixes {
+			if strings.HasPrefix(affinity.CookieName, prefix) {
+				return nil, fmt.Errorf("affinity cookieName %v cannot start with reserved prefix %v", affinity.CookieName, prefix)
+			}
+		}
+		output := []*elbv2.TargetGroupAttribute{
+			tgAttribute("stickiness.enabled", "true"),
+			tgAttribute("stickiness.type", AffinityModeAppCookie),
+			tgAttribute("stickiness.app_cookie.cookie_name", affinity.CookieName),
+		}
+		if affinity.DurationSeconds != 0 {
+			output = append(output, tgAttribute("stickiness.app_cookie.duration_seconds", fmt.Sprintf("%v", affinity.DurationSeconds)))
+		}
+		return output, nil
+	}
+	return nil, errors.NewInvalidAnnotationContent("affinity", affinity.Mode)
+}
+
+// parseRolloutSlowStartSeconds parses the slow start duration applied during rollouts, which cannot be combined with a static slow start duration in attributes.
+func parseRolloutSlowStartSeconds(ing parser.AnnotationInterface, attributes []*elbv2.TargetGroupAttribute) (*int64, error) {
+	rolloutSlowStartSeconds, err := parser.GetInt64Annotation("rollout-slow-start-seconds", ing)
+	if err != nil {
+		if err == errors.ErrMissingAnnotations {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := validateRange(rolloutSlowStartSeconds, minSlowStartSeconds, maxSlowStartSeconds); err != nil {
+		return nil, fmt.Errorf("invalid rollout-slow-start-seconds: %v", err)
+	}
+	for _, attribute := range attributes {
+		if aws.StringValue(attribute.Key) == "slow_start.duration_seconds" {
+			return nil, fmt.Errorf("target-group-attributes cannot specify slow_start.duration_seconds together with rollout-slow-start-seconds annotation")
+		}
+	}
+	return rolloutSlowStartSeconds, nil
+}
+
+func tgAttribute(k, v string) *elbv2.TargetGroupAttribute {
+	return &elbv2.TargetGroupAttribute{Key: aws.String(k), Value: aws.String(v)}
+}
+
 func Dummy() *Config {
 	return &Config{
 		BackendProtocol:         aws.String(elbv2.ProtocolEnumHttp),
@@ -5,7 +5,9 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -104,3 +106,217 @@ func TestMerge(t *testing.T) {
 		assert.Equal(t, tc.ExpectedResult, actualResult)
 	}
 }
+
+func Test_parseAffinity(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotation  string
+		expected    []*elbv2.TargetGroupAttribute
+		expectError bool
+	}{
+		{
+			name:       "none",
+			annotation: `{"mode": "none"}`,
+			expected:   []*elbv2.TargetGroupAttribute{tgAttribute("stickiness.enabled", "false")},
+		},
+		{
+			name:       "lb_cookie with duration",
+			annotation: `{"mode": "lb_cookie", "durationSeconds": 3600}`,
+			expected: []*elbv2.TargetGroupAttribute{
+				tgAttribute("stickiness.enabled", "true"),
+				tgAttribute("stickiness.type", "lb_cookie"),
+				tgAttribute("stickiness.lb_cookie.duration_seconds", "3600"),
+			},
+		},
+		{
+			name:       "app_cookie",
+			annotation: `{"mode": "app_cookie", "cookieName": "SESSION"}`,
+			expected: []*elbv2.TargetGroupAttribute{
+				tgAttribute("stickiness.enabled", "true"),
+				tgAttribute("stickiness.type", "app_cookie"),
+				tgAttribute("stickiness.app_cookie.cookie_name", "SESSION"),
+			},
+		},
+		{
+			name:        "app_cookie without cookieName",
+			annotation:  `{"mode": "app_cookie"}`,
+			expectError: true,
+		},
+		{
+			name:        "app_cookie with reserved cookieName",
+			annotation:  `{"mode": "app_cookie", "cookieName": "AWSALBSESSION"}`,
+			expectError: true,
+		},
+		{
+			name:        "lb_cookie with cookieName",
+			annotation:  `{"mode": "lb_cookie", "cookieName": "SESSION"}`,
+			expectError: true,
+		},
+		{
+			name:        "duration out of range",
+			annotation:  `{"mode": "lb_cookie", "durationSeconds": 604801}`,
+			expectError: true,
+		},
+		{
+			name:        "unknown mode",
+			annotation:  `{"mode": "source_ip"}`,
+			expectError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := dummy.NewIngress()
+			ing.SetAnnotations(map[string]string{parser.GetAnnotationWithPrefix("affinity"): tc.annotation})
+
+			attributes, err := parseAffinity(ing)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, attributes)
+		})
+	}
+}
+
+func Test_parseHealthCheckOverrides(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotation  string
+		expected    map[string]*HealthCheckOverride
+		expectError bool
+	}{
+		{
+			name:       "per service port",
+			annotation: `{"legacy:8080": {"intervalSeconds": 60, "timeoutSeconds": 30, "unhealthyThresholdCount": 5}, "http": {"healthyThresholdCount": 3}}`,
+			expected: map[string]*HealthCheckOverride{
+				"legacy:8080": {
+					IntervalSeconds:         aws.Int64(60),
+					TimeoutSeconds:          aws.Int64(30),
+					UnhealthyThresholdCount: aws.Int64(5),
+				},
+				"http": {
+					HealthyThresholdCount: aws.Int64(3),
+				},
+			},
+		},
+		{
+			name:       "health check on https admin port",
+			annotation: `{"http": {"port": "admin", "protocol": "HTTPS"}}`,
+			expected: map[string]*HealthCheckOverride{
+				"http": {
+					Port:     aws.String("admin"),
+					Protocol: aws.String("HTTPS"),
+				},
+			},
+		},
+		{
+			name:        "invalid protocol",
+			annotation:  `{"http": {"protocol": "TCP"}}`,
+			expectError: true,
+		},
+		{
+			name:        "interval out of range",
+			annotation:  `{"http": {"intervalSeconds": 301}}`,
+			expectError: true,
+		},
+		{
+			name:        "threshold out of range",
+			annotation:  `{"http": {"healthyThresholdCount": 1}}`,
+			expectError: true,
+		},
+		{
+			name:        "invalid json",
+			annotation:  `{"http": 5}`,
+			expectError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := dummy.NewIngress()
+			ing.SetAnnotations(map[string]string{parser.GetAnnotationWithPrefix("healthcheck-overrides"): tc.annotation})
+
+			overrides, err := parseHealthCheckOverrides(ing)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, overrides)
+		})
+	}
+}
+
+func Test_normalizeSuccessCodes(t *testing.T) {
+	for _, tc := range []struct {
+		successCodes string
+		expected     string
+		expectError  bool
+	}{
+		{successCodes: "200", expected: "200"},
+		{successCodes: "200,202", expected: "200,202"},
+		{successCodes: "200-299", expected: "200-299"},
+		{successCodes: "200-299, 301", expected: "200-299,301"},
+		{successCodes: " 200 - 204 ,404", expected: "200-204,404"},
+		{successCodes: "", expectError: true},
+		{successCodes: "200,", expectError: true},
+		{successCodes: "ok", expectError: true},
+		{successCodes: "100", expectError: true},
+		{successCodes: "200-500", expectError: true},
+		{successCodes: "299-200", expectError: true},
+		{successCodes: "200-250-299", expectError: true},
+	} {
+		t.Run(tc.successCodes, func(t *testing.T) {
+			output, err := normalizeSuccessCodes(tc.successCodes)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, aws.StringValue(output))
+		})
+	}
+}
+
+func Test_parseRolloutSlowStartSeconds(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		attributes  []*elbv2.TargetGroupAttribute
+		expected    *int64
+		expectError bool
+	}{
+		{
+			name:        "absent",
+			annotations: map[string]string{},
+		},
+		{
+			name:        "valid",
+			annotations: map[string]string{parser.GetAnnotationWithPrefix("rollout-slow-start-seconds"): "60"},
+			attributes:  []*elbv2.TargetGroupAttribute{tgAttribute("deregistration_delay.timeout_seconds", "30")},
+			expected:    aws.Int64(60),
+		},
+		{
+			name:        "out of range",
+			annotations: map[string]string{parser.GetAnnotationWithPrefix("rollout-slow-start-seconds"): "10"},
+			expectError: true,
+		},
+		{
+			name:        "conflicts with slow start attribute",
+			annotations: map[string]string{parser.GetAnnotationWithPrefix("rollout-slow-start-seconds"): "60"},
+			attributes:  []*elbv2.TargetGroupAttribute{tgAttribute("slow_start.duration_seconds", "30")},
+			expectError: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := dummy.NewIngress()
+			ing.SetAnnotations(tc.annotations)
+
+			actual, err := parseRolloutSlowStartSeconds(ing, tc.attributes)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -17,55 +17,104 @@ limitations under the License.
 package backend
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // EndpointResolver resolves the endpoints for specific ingress backend
 type EndpointResolver interface {
 	Resolve(*extensions.Ingress, *extensions.IngressBackend, string) ([]*elbv2.TargetDescription, error)
+
+	// ResolveLive resolves the endpoints like Resolve, but reads services, endpoints and nodes from the API server instead of
+	// the informer cache, so that targets are only removed once their removal is confirmed.
+	ResolveLive(context.Context, *extensions.Ingress, *extensions.IngressBackend, string) ([]*elbv2.TargetDescription, error)
 }
 
-// NewEndpointResolver constructs a new EndpointResolver
-func NewEndpointResolver(store store.Storer, cloud aws.CloudAPI) EndpointResolver {
+// NewEndpointResolver constructs a new EndpointResolver, apiReader reads from the API server directly.
+func NewEndpointResolver(store store.Storer, cloud aws.CloudAPI, apiReader client.Reader) EndpointResolver {
 	return &endpointResolver{
-		cloud: cloud,
-		store: store,
+		cloud:     cloud,
+		store:     store,
+		apiReader: apiReader,
 	}
 }
 
 type endpointResolver struct {
-	cloud aws.CloudAPI
-	store store.Storer
+	cloud     aws.CloudAPI
+	store     store.Storer
+	apiReader client.Reader
 }
 
 func (resolver *endpointResolver) Resolve(ingress *extensions.Ingress, backend *extensions.IngressBackend, targetType string) ([]*elbv2.TargetDescription, error) {
+	service, servicePort, err := findServiceAndPort(resolver.store, ingress.Namespace, backend.ServiceName, backend.ServicePort)
+	if err != nil {
+		return nil, err
+	}
 	if targetType == elbv2.TargetTypeEnumInstance {
-		return resolver.resolveInstance(ingress, backend)
+		return resolver.resolveInstance(service, servicePort, resolver.store.ListNodes())
+	}
+	serviceKey := ingress.Namespace + "/" + service.Name
+	eps, err := resolver.store.GetServiceEndpoints(serviceKey)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to find service endpoints for %s: %v", serviceKey, err.Error())
 	}
-	return resolver.resolveIP(ingress, backend)
+	return resolveIP(servicePort, eps), nil
 }
 
-func (resolver *endpointResolver) resolveInstance(ingress *extensions.Ingress, backend *extensions.IngressBackend) ([]*elbv2.TargetDescription, error) {
-	service, servicePort, err := findServiceAndPort(resolver.store, ingress.Namespace, backend.ServiceName, backend.ServicePort)
+func (resolver *endpointResolver) ResolveLive(ctx context.Context, ingress *extensions.Ingress, backend *extensions.IngressBackend, targetType string) ([]*elbv2.TargetDescription, error) {
+	serviceKey := types.NamespacedName{Namespace: ingress.Namespace, Name: backend.ServiceName}
+	service := &corev1.Service{}
+	if err := resolver.apiReader.Get(ctx, serviceKey, service); err != nil {
+		return nil, fmt.Errorf("failed to get service %v due to %v", serviceKey, err)
+	}
+	servicePort, err := k8s.LookupServicePort(service, backend.ServicePort)
 	if err != nil {
 		return nil, err
 	}
+	if targetType == elbv2.TargetTypeEnumInstance {
+		nodeList := &corev1.NodeList{}
+		if err := resolver.apiReader.List(ctx, &client.ListOptions{}, nodeList); err != nil {
+			return nil, fmt.Errorf("failed to list nodes due to %v", err)
+		}
+		var nodes []*corev1.Node
+		for i := range nodeList.Items {
+			if class.IsValidNode(&nodeList.Items[i]) {
+				nodes = append(nodes, &nodeList.Items[i])
+			}
+		}
+		return resolver.resolveInstance(service, servicePort, nodes)
+	}
+	eps := &corev1.Endpoints{}
+	if err := resolver.apiReader.Get(ctx, serviceKey, eps); err != nil {
+		return nil, fmt.Errorf("failed to get service endpoints %v due to %v", serviceKey, err)
+	}
+	return resolveIP(servicePort, eps), nil
+}
+
+func (resolver *endpointResolver) resolveInstance(service *corev1.Service, servicePort *corev1.ServicePort, nodes []*corev1.Node) ([]*elbv2.TargetDescription, error) {
 	if service.Spec.Type != corev1.ServiceTypeNodePort && service.Spec.Type != corev1.ServiceTypeLoadBalancer {
 		return nil, fmt.Errorf("%v service is not of type NodePort or LoadBalancer and target-type is instance", service.Name)
 	}
 	nodePort := servicePort.NodePort
+	if nodePort == 0 {
+		return nil, fmt.Errorf("%v service port %v has no NodePort allocated and target-type is instance, allocate one or use target-type ip", service.Name, servicePortName(servicePort))
+	}
 
 	var result []*elbv2.TargetDescription
-	for _, node := range resolver.store.ListNodes() {
+	for _, node := range nodes {
 		instanceID, err := resolver.store.GetNodeInstanceID(node)
 		if err != nil {
 			return nil, err
@@ -82,17 +131,15 @@ func (resolver *endpointResolver) resolveInstance(ingress *extensions.Ingress, b
 	return result, nil
 }
 
-func (resolver *endpointResolver) resolveIP(ingress *extensions.Ingress, backend *extensions.IngressBackend) ([]*elbv2.TargetDescription, error) {
-	service, servicePort, err := findServiceAndPort(resolver.store, ingress.Namespace, backend.ServiceName, backend.ServicePort)
-	if err != nil {
-		return nil, err
-	}
-	serviceKey := ingress.Namespace + "/" + service.Name
-	eps, err := resolver.store.GetServiceEndpoints(serviceKey)
-	if err != nil {
-		return nil, fmt.Errorf("Unable to find service endpoints for %s: %v", serviceKey, err.Error())
+// servicePortName returns the name of servicePort, or its port number if it's unnamed.
+func servicePortName(servicePort *corev1.ServicePort) string {
+	if servicePort.Name != "" {
+		return servicePort.Name
 	}
+	return strconv.Itoa(int(servicePort.Port))
+}
 
+func resolveIP(servicePort *corev1.ServicePort, eps *corev1.Endpoints) []*elbv2.TargetDescription {
 	var result []*elbv2.TargetDescription
 	for _, epSubset := range eps.Subsets {
 		for _, epPort := range epSubset.Ports {
@@ -108,8 +155,7 @@ func (resolver *endpointResolver) resolveIP(ingress *extensions.Ingress, backend
 			}
 		}
 	}
-
-	return result, nil
+	return result
 }
 
 // findServiceAndPort returns the service & servicePort by name
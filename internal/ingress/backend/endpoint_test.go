@@ -17,6 +17,7 @@ limitations under the License.
 package backend
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
@@ -25,11 +26,13 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
 
 	api_v1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestResolveWithModeInstance(t *testing.T) {
@@ -252,6 +255,38 @@ func TestResolveWithModeInstance(t *testing.T) {
 			expectedTargets: nil,
 			expectedError:   true,
 		},
+		{
+			name: "failure scenario by NodePort not allocated",
+			ingress: &extensions.Ingress{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "ingress",
+					Namespace: api_v1.NamespaceDefault,
+				},
+				Spec: extensions.IngressSpec{
+					Backend: &extensions.IngressBackend{
+						ServiceName: "service",
+						ServicePort: intstr.FromInt(8080),
+					},
+				},
+			},
+			service: &api_v1.Service{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "service",
+					Namespace: api_v1.NamespaceDefault,
+				},
+				Spec: api_v1.ServiceSpec{
+					Type: api_v1.ServiceTypeLoadBalancer,
+					Ports: []api_v1.ServicePort{
+						{
+							Port: 8080,
+						},
+					},
+				},
+			},
+			nodes:           []*api_v1.Node{},
+			expectedTargets: nil,
+			expectedError:   true,
+		},
 		{
 			name: "failure scenario by failed nodeHealthCheck",
 			ingress: &extensions.Ingress{
@@ -315,7 +350,7 @@ func TestResolveWithModeInstance(t *testing.T) {
 
 			//  tc.nodeHealthProbe
 
-			resolver := NewEndpointResolver(store, cloud)
+			resolver := NewEndpointResolver(store, cloud, nil)
 			targets, err := resolver.Resolve(tc.ingress, tc.ingress.Spec.Backend, elbv2.TargetTypeEnumInstance)
 			if !reflect.DeepEqual(tc.expectedTargets, targets) {
 				t.Errorf("expected targets: %#v, actual targets:%#v", tc.expectedTargets, targets)
@@ -578,7 +613,7 @@ func TestResolveWithModeIP(t *testing.T) {
 				return nil, fmt.Errorf("No such endpoints")
 			}
 
-			resolver := NewEndpointResolver(store, cloud)
+			resolver := NewEndpointResolver(store, cloud, nil)
 			targets, err := resolver.Resolve(tc.ingress, tc.ingress.Spec.Backend, elbv2.TargetTypeEnumIp)
 			if !reflect.DeepEqual(tc.expectedTargets, targets) {
 				t.Errorf("expected targets: %#v, actual targets:%#v", tc.expectedTargets, targets)
@@ -589,3 +624,58 @@ func TestResolveWithModeIP(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveLive(t *testing.T) {
+	ingress := &extensions.Ingress{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "ingress", Namespace: api_v1.NamespaceDefault},
+		Spec: extensions.IngressSpec{
+			Backend: &extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromString("http")},
+		},
+	}
+	service := &api_v1.Service{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "service", Namespace: api_v1.NamespaceDefault},
+		Spec: api_v1.ServiceSpec{
+			Type:  api_v1.ServiceTypeNodePort,
+			Ports: []api_v1.ServicePort{{Name: "http", Port: 80, NodePort: 8888}},
+		},
+	}
+	endpoints := &api_v1.Endpoints{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "service", Namespace: api_v1.NamespaceDefault},
+		Subsets: []api_v1.EndpointSubset{
+			{
+				Addresses: []api_v1.EndpointAddress{{IP: "192.168.0.1"}},
+				Ports:     []api_v1.EndpointPort{{Name: "http", Port: 8080}},
+			},
+		},
+	}
+	nodes := []*api_v1.Node{
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "node1"},
+			Spec:       api_v1.NodeSpec{ProviderID: "i-1"},
+		},
+		{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "master", Labels: map[string]string{"node-role.kubernetes.io/master": ""}},
+			Spec:       api_v1.NodeSpec{ProviderID: "i-2"},
+		},
+	}
+
+	cloud := &mocks.CloudAPI{}
+	cloud.On("IsNodeHealthy", "i-1").Return(true, nil)
+	store := store.NewDummy()
+	store.GetNodeInstanceIDFunc = func(node *api_v1.Node) (string, error) {
+		return node.Spec.ProviderID, nil
+	}
+	resolver := NewEndpointResolver(store, cloud, fake.NewFakeClient(service, endpoints, nodes[0], nodes[1]))
+
+	targets, err := resolver.ResolveLive(context.Background(), ingress, ingress.Spec.Backend, elbv2.TargetTypeEnumInstance)
+	assert.NoError(t, err)
+	assert.Equal(t, []*elbv2.TargetDescription{{Id: aws.String("i-1"), Port: aws.Int64(8888)}}, targets)
+
+	targets, err = resolver.ResolveLive(context.Background(), ingress, ingress.Spec.Backend, elbv2.TargetTypeEnumIp)
+	assert.NoError(t, err)
+	assert.Equal(t, []*elbv2.TargetDescription{{Id: aws.String("192.168.0.1"), Port: aws.Int64(8080)}}, targets)
+
+	resolver = NewEndpointResolver(store, cloud, fake.NewFakeClient())
+	_, err = resolver.ResolveLive(context.Background(), ingress, ingress.Spec.Backend, elbv2.TargetTypeEnumIp)
+	assert.Error(t, err)
+}
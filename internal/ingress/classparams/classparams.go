@@ -0,0 +1,65 @@
+package classparams
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	extensions "k8s.io/api/extensions/v1beta1"
+)
+
+// Defaults returns the annotations defaulted by p, keyed like the annotations of ingresses.
+func (p *IngressClassParams) Defaults() map[string]string {
+	defaults := make(map[string]string)
+	if p.Spec.Scheme != nil {
+		defaults[parser.GetAnnotationWithPrefix("scheme")] = *p.Spec.Scheme
+	}
+	if len(p.Spec.Subnets) != 0 {
+		defaults[parser.GetAnnotationWithPrefix("subnets")] = strings.Join(p.Spec.Subnets, ",")
+	}
+	if len(p.Spec.Tags) != 0 {
+		var tags []string
+		for k, v := range p.Spec.Tags {
+			tags = append(tags, fmt.Sprintf("%v=%v", k, v))
+		}
+		sort.Strings(tags)
+		defaults[parser.GetAnnotationWithPrefix("tags")] = strings.Join(tags, ",")
+	}
+	if p.Spec.SSLPolicy != nil {
+		defaults[parser.GetAnnotationWithPrefix("ssl-policy")] = *p.Spec.SSLPolicy
+	}
+	if p.Spec.WebACLID != nil {
+		defaults[parser.GetAnnotationWithPrefix("web-acl-id")] = *p.Spec.WebACLID
+	}
+	return defaults
+}
+
+// Apply returns a copy of ingress with defaults added for its missing annotations.
+// The original ingress is returned if no default is added.
+func Apply(ingress *extensions.Ingress, defaults map[string]string) *extensions.Ingress {
+	annotations := ingress.GetAnnotations()
+	missing := make(map[string]string)
+	for k, v := range defaults {
+		if _, ok := annotations[k]; ok {
+			continue
+		}
+		// the legacy waf-acl-id annotation of ingress overrides the default web-acl-id too.
+		if _, ok := annotations[parser.GetAnnotationWithPrefix("waf-acl-id")]; ok && k == parser.GetAnnotationWithPrefix("web-acl-id") {
+			continue
+		}
+		missing[k] = v
+	}
+	if len(missing) == 0 {
+		return ingress
+	}
+
+	ingress = ingress.DeepCopy()
+	if ingress.Annotations == nil {
+		ingress.Annotations = make(map[string]string, len(missing))
+	}
+	for k, v := range missing {
+		ingress.Annotations[k] = v
+	}
+	return ingress
+}
@@ -0,0 +1,103 @@
+package classparams
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIngressClassParams_Defaults(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		spec   IngressClassParamsSpec
+		expect map[string]string
+	}{
+		{
+			name:   "empty spec",
+			spec:   IngressClassParamsSpec{},
+			expect: map[string]string{},
+		},
+		{
+			name: "all fields",
+			spec: IngressClassParamsSpec{
+				Scheme:    aws.String("internal"),
+				Subnets:   []string{"subnet-1", "subnet-2"},
+				Tags:      map[string]string{"Team": "platform", "Environment": "dev"},
+				SSLPolicy: aws.String("ELBSecurityPolicy-TLS-1-2-2017-01"),
+				WebACLID:  aws.String("web-acl"),
+			},
+			expect: map[string]string{
+				"alb.ingress.kubernetes.io/scheme":     "internal",
+				"alb.ingress.kubernetes.io/subnets":    "subnet-1,subnet-2",
+				"alb.ingress.kubernetes.io/tags":       "Environment=dev,Team=platform",
+				"alb.ingress.kubernetes.io/ssl-policy": "ELBSecurityPolicy-TLS-1-2-2017-01",
+				"alb.ingress.kubernetes.io/web-acl-id": "web-acl",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &IngressClassParams{Spec: tc.spec}
+			assert.Equal(t, tc.expect, p.Defaults())
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		defaults    map[string]string
+		expect      map[string]string
+	}{
+		{
+			name:        "no defaults",
+			annotations: map[string]string{"alb.ingress.kubernetes.io/scheme": "internet-facing"},
+			defaults:    nil,
+			expect:      map[string]string{"alb.ingress.kubernetes.io/scheme": "internet-facing"},
+		},
+		{
+			name:        "defaults added to ingress without annotations",
+			annotations: nil,
+			defaults:    map[string]string{"alb.ingress.kubernetes.io/scheme": "internal"},
+			expect:      map[string]string{"alb.ingress.kubernetes.io/scheme": "internal"},
+		},
+		{
+			name: "annotations override defaults",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/scheme": "internet-facing",
+			},
+			defaults: map[string]string{
+				"alb.ingress.kubernetes.io/scheme": "internal",
+				"alb.ingress.kubernetes.io/tags":   "Team=platform",
+			},
+			expect: map[string]string{
+				"alb.ingress.kubernetes.io/scheme": "internet-facing",
+				"alb.ingress.kubernetes.io/tags":   "Team=platform",
+			},
+		},
+		{
+			name: "legacy waf-acl-id overrides default web-acl-id",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/waf-acl-id": "legacy-acl",
+			},
+			defaults: map[string]string{
+				"alb.ingress.kubernetes.io/web-acl-id": "web-acl",
+			},
+			expect: map[string]string{
+				"alb.ingress.kubernetes.io/waf-acl-id": "legacy-acl",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "ingress", Annotations: tc.annotations}}
+			original := ingress.DeepCopy()
+
+			got := Apply(ingress, tc.defaults)
+			assert.Equal(t, tc.expect, got.Annotations)
+			assert.Equal(t, original, ingress)
+		})
+	}
+}
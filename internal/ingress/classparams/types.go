@@ -0,0 +1,139 @@
+package classparams
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// SchemeGroupVersion is the group version of IngressClassParams.
+	SchemeGroupVersion = schema.GroupVersion{Group: "alb.ingress.kubernetes.io", Version: "v1alpha1"}
+
+	// SchemeBuilder registers IngressClassParams into schemes.
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+
+	// AddToScheme adds IngressClassParams to scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion, &IngressClassParams{}, &IngressClassParamsList{})
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	return nil
+}
+
+// IngressClassParams holds the defaults of the ingresses of an ingress class, it's named after the ingress class.
+// Controller flags are overridden by IngressClassParams, which are overridden by annotations of each ingress.
+type IngressClassParams struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IngressClassParamsSpec `json:"spec,omitempty"`
+}
+
+// IngressClassParamsSpec defines the defaults of ingresses, each one defaults the annotation of the same name.
+type IngressClassParamsSpec struct {
+	// Scheme defaults the scheme annotation.
+	Scheme *string `json:"scheme,omitempty"`
+	// Subnets defaults the subnets annotation.
+	Subnets []string `json:"subnets,omitempty"`
+	// Tags defaults the tags annotation.
+	Tags map[string]string `json:"tags,omitempty"`
+	// SSLPolicy defaults the ssl-policy annotation.
+	SSLPolicy *string `json:"sslPolicy,omitempty"`
+	// WebACLID defaults the web-acl-id annotation.
+	WebACLID *string `json:"webACLId,omitempty"`
+}
+
+// IngressClassParamsList is a list of IngressClassParams.
+type IngressClassParamsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []IngressClassParams `json:"items"`
+}
+
+// DeepCopyInto copies p into out.
+func (p *IngressClassParams) DeepCopyInto(out *IngressClassParams) {
+	*out = *p
+	out.TypeMeta = p.TypeMeta
+	p.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	p.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy returns a deep copy of p.
+func (p *IngressClassParams) DeepCopy() *IngressClassParams {
+	if p == nil {
+		return nil
+	}
+	out := new(IngressClassParams)
+	p.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (p *IngressClassParams) DeepCopyObject() runtime.Object {
+	if c := p.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies s into out.
+func (s *IngressClassParamsSpec) DeepCopyInto(out *IngressClassParamsSpec) {
+	*out = *s
+	if s.Scheme != nil {
+		out.Scheme = new(string)
+		*out.Scheme = *s.Scheme
+	}
+	if s.Subnets != nil {
+		out.Subnets = make([]string, len(s.Subnets))
+		copy(out.Subnets, s.Subnets)
+	}
+	if s.Tags != nil {
+		out.Tags = make(map[string]string, len(s.Tags))
+		for k, v := range s.Tags {
+			out.Tags[k] = v
+		}
+	}
+	if s.SSLPolicy != nil {
+		out.SSLPolicy = new(string)
+		*out.SSLPolicy = *s.SSLPolicy
+	}
+	if s.WebACLID != nil {
+		out.WebACLID = new(string)
+		*out.WebACLID = *s.WebACLID
+	}
+}
+
+// DeepCopyInto copies l into out.
+func (l *IngressClassParamsList) DeepCopyInto(out *IngressClassParamsList) {
+	*out = *l
+	out.TypeMeta = l.TypeMeta
+	l.ListMeta.DeepCopyInto(&out.ListMeta)
+	if l.Items != nil {
+		out.Items = make([]IngressClassParams, len(l.Items))
+		for i := range l.Items {
+			l.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of l.
+func (l *IngressClassParamsList) DeepCopy() *IngressClassParamsList {
+	if l == nil {
+		return nil
+	}
+	out := new(IngressClassParamsList)
+	l.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (l *IngressClassParamsList) DeepCopyObject() runtime.Object {
+	if c := l.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -4,14 +4,21 @@ import (
 	"encoding/hex"
 	"fmt"
 	"hash/crc32"
+	"net"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/golang/glog"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/restriction"
 	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
 )
 
 const (
@@ -23,6 +30,30 @@ const (
 	defaultRestrictScheme          = false
 	defaultRestrictSchemeNamespace = corev1.NamespaceDefault
 	defaultSyncRateLimit           = 0.3
+	defaultEventDedupWindow        = 5 * time.Minute
+	defaultEventBurst              = 10
+	defaultRemovalSafetyThreshold  = 0
+	defaultSmokeTestTimeout        = 5 * time.Second
+	defaultLBHourlyPrice           = 0.0225
+	defaultLCUHourlyPrice          = 0.008
+	defaultDeletionRetryWindow     = 1 * time.Hour
+	defaultDeletionQueueConfigMap  = "kube-system/alb-ingress-controller-deletion-queue"
+	defaultLocalZoneSubnets        = LocalZoneSubnetsExclude
+	defaultHostConflicts           = HostConflictsIgnore
+)
+
+const (
+	// LocalZoneSubnetsExclude makes subnet auto discovery ignore subnets in Local Zones.
+	LocalZoneSubnetsExclude = "exclude"
+	// LocalZoneSubnetsPrefer makes subnet auto discovery use subnets in Local Zones if they span at least 2 Local Zones.
+	LocalZoneSubnetsPrefer = "prefer"
+
+	// HostConflictsIgnore doesn't check whether hosts of ingresses are claimed by other ingresses.
+	HostConflictsIgnore = "ignore"
+	// HostConflictsWarn emits warning events on ingresses claiming hosts already claimed by other ingresses.
+	HostConflictsWarn = "warn"
+	// HostConflictsReject rejects ingresses without LoadBalancer claiming hosts already claimed by other ingresses.
+	HostConflictsReject = "reject"
 )
 
 var (
@@ -42,11 +73,129 @@ type Configuration struct {
 	DefaultTargetType      string
 	DefaultBackendProtocol string
 
+	// Default404MessageBody is the body of responses to requests matching no rule, instead of an empty body.
+	Default404MessageBody string
+	// Default404ContentType is the content type of Default404MessageBody.
+	Default404ContentType string
+
+	// LBSGExtraInboundRules are inbound rules added to managed LoadBalancer securityGroups in addition to listen ports, e.g. ICMP for path MTU discovery.
+	LBSGExtraInboundRules SecurityGroupRules
+
+	// RestrictLBSGEgress replaces the default allow-all egress of managed LoadBalancer securityGroups with egress to the
+	// managed instance securityGroup and LBSGEgressCIDRs on the target ports.
+	RestrictLBSGEgress bool
+	// LBSGEgressCIDRs are the CIDRs of targets not covered by the managed instance securityGroup, e.g. pod CIDRs.
+	LBSGEgressCIDRs []string
+
+	// SubnetAutoExpansion adds subnets found by subnet auto discovery in new availability zones to existing ALBs.
+	// Otherwise existing ALBs keep their availability zones, and events advise about the new ones.
+	SubnetAutoExpansion bool
+
+	// LocalZoneSubnets is whether subnet auto discovery excludes subnets in Local Zones, or prefers them over subnets in availability zones.
+	// LoadBalancers can't mix both, and subnets in Wavelength Zones are always excluded since LoadBalancers don't support them.
+	LocalZoneSubnets string
+
+	// RestrictTargetType restricts targetGroups to DefaultTargetType, so that informers only needed by other target types are disabled.
+	RestrictTargetType bool
+
+	// StrictAttributes resets LoadBalancer and targetGroup attributes not specified by annotations to their defaults.
+	// Otherwise they're left unmanaged, so attributes set by other tools are kept.
+	StrictAttributes bool
+
+	// PodLabelSelector and EndpointsLabelSelector restrict the Pods and Endpoints watched by controller, empty selectors watch everything.
+	PodLabelSelector       string
+	EndpointsLabelSelector string
+
 	SyncRateLimit float32
 
+	// EventDedupWindow is the period during which identical events for an Ingress are emitted only once
+	EventDedupWindow time.Duration
+	// EventBurst is the maximum number of distinct events emitted for an Ingress within EventDedupWindow
+	EventBurst int
+
+	// RemovalSafetyThreshold is the maximum percentage of targets in a target group or rules on a listener that can be removed in a single reconcile.
+	// Larger removals are only alerted unless confirmed by annotation. Zero disables the protection.
+	RemovalSafetyThreshold int64
+
+	// LBActiveTimeout is the maximum duration to wait for new LoadBalancers to become active before updating Ingress status.
+	// Zero disables the wait, Ingress status is then updated while LoadBalancers are still provisioning.
+	LBActiveTimeout time.Duration
+
+	// DeletionRetryWindow is the maximum duration to retry deleting resources still in use, e.g. securityGroups used by network interfaces of deleted ALBs.
+	// Deletions are retried in background rather than failing reconcile. Zero disables the retries.
+	DeletionRetryWindow time.Duration
+	// DeletionQueueConfigMap is the namespace/name of configMap that persists deletions retried in background, they're only kept in memory if empty.
+	DeletionQueueConfigMap string
+
+	// TargetGroupRetention is the duration targetGroups no longer used by an ingress are kept before deletion,
+	// so external systems shifting traffic between targetGroups can finish first. Zero deletes them immediately.
+	TargetGroupRetention time.Duration
+
+	// ACMPrivateCAArn is the ARN of the ACM Private CA issuing certificates for TLS hosts of ingresses not matched by any issued certificate.
+	// Certificates are only issued for ingresses without certificate-arn annotation, and are deleted along with them. Empty disables it.
+	ACMPrivateCAArn string
+	// ACMValidationZoneID is the ID of the Route53 hosted zone to create DNS validation records in, for public certificates requested
+	// for TLS hosts of ingresses not matched by any issued certificate. Empty disables it.
+	ACMValidationZoneID string
+	// ACMImportTLSSecrets is whether the TLS secrets referenced by spec.tls of ingresses without certificate-arn annotation are imported into ACM,
+	// they're re-imported when the secrets change, and deleted along with ingresses.
+	ACMImportTLSSecrets bool
+
+	// TargetHealthInterval is the period to propagate the health of targets reported by ALB into metrics and events. Zero disables it.
+	TargetHealthInterval time.Duration
+	// TargetHealthGracePeriod is the duration after registration during which targets don't count towards minimum healthy targets,
+	// and their health changes don't emit warning events.
+	TargetHealthGracePeriod time.Duration
+
+	// CostEstimationInterval is the period to estimate the monthly cost of LoadBalancers into metrics. Zero disables it.
+	CostEstimationInterval time.Duration
+	// LBHourlyPrice is the price of a LoadBalancer per hour, used for cost estimation.
+	LBHourlyPrice float64
+	// LCUHourlyPrice is the price of a LCU per hour, used for cost estimation.
+	LCUHourlyPrice float64
+
+	// TargetGroupTrafficInterval is the period to propagate the request rate and response time of targetGroups reported by CloudWatch into metrics. Zero disables it.
+	TargetGroupTrafficInterval time.Duration
+	// MetricsPushgatewayURL is the URL of Pushgateway that targetGroup traffic metrics are pushed to, they're not pushed if empty.
+	MetricsPushgatewayURL string
+
+	// InventoryRefreshInterval is the period to refresh the inventory of AWS resources tagged for the cluster. Zero disables it,
+	// and the inventory follows changes made outside of controller only as its entries expire.
+	InventoryRefreshInterval time.Duration
+
+	// MaxLoadBalancers is the maximum number of LoadBalancers in the cluster, ingresses beyond it are rejected. Zero is unlimited.
+	MaxLoadBalancers int
+	// MaxLoadBalancersPerNamespace is the maximum number of LoadBalancers per namespace, ingresses beyond it are rejected.
+	// It applies to namespaces not in NamespaceLoadBalancerQuotas. Zero is unlimited.
+	MaxLoadBalancersPerNamespace int
+	// NamespaceLoadBalancerQuotas are the maximum numbers of LoadBalancers by namespace, overriding MaxLoadBalancersPerNamespace.
+	NamespaceLoadBalancerQuotas map[string]int
+
+	// HostConflicts is whether ingresses claiming hosts already claimed by older ingresses of the cluster, and thus served by
+	// another LoadBalancer, are ignored, warned on or rejected.
+	HostConflicts string
+
+	// PolicyFile is the path of file with org policy rules that LoadBalancers must conform to, no policy is enforced if empty.
+	PolicyFile string
+
+	// AnnotationRestrictionsFile is the path of file with rules that restrict the annotations ingresses may set per namespace.
+	AnnotationRestrictionsFile string
+	// AnnotationRestrictions are the restrictions loaded from AnnotationRestrictionsFile, nothing is restricted if nil.
+	AnnotationRestrictions *restriction.Restrictions
+
+	// SmokeTestProberURL is the URL of an external prober that performs smoke tests of ingresses, they're performed by controller itself if empty.
+	SmokeTestProberURL string
+	// SmokeTestTimeout is the timeout of each request of smoke tests.
+	SmokeTestTimeout time.Duration
+
 	RestrictScheme          bool
 	RestrictSchemeNamespace string
 
+	// HTTPSOnly is whether ingresses with HTTP listen ports are rejected, so only HTTPS listeners are created.
+	HTTPSOnly bool
+	// HTTPSOnlyRedirect is whether HTTP listen ports are allowed with HTTPSOnly, as listeners only redirecting to the first HTTPS listen port.
+	HTTPSOnlyRedirect bool
+
 	// InternetFacingIngresses is an dynamic setting that can be updated by configMaps
 	InternetFacingIngresses map[string][]string
 
@@ -78,12 +227,90 @@ func (cfg *Configuration) BindFlags(fs *pflag.FlagSet) {
 		`Default target type to use for target groups, must be "instance" or "ip"`)
 	fs.StringVar(&cfg.DefaultBackendProtocol, "backend-protocol", defaultBackendProtocol,
 		`Default protocol to use for target groups, must be "HTTP" or "HTTPS"`)
+	fs.StringVar(&cfg.Default404MessageBody, "default-404-message-body", "",
+		`Body of the 404 fixed response for requests matching no rule when ingresses have no default backend, e.g. a branded error page`)
+	fs.StringVar(&cfg.Default404ContentType, "default-404-content-type", "text/plain",
+		`Content type of --default-404-message-body, must be one of text/plain, text/css, text/html, application/javascript or application/json`)
+	fs.Var(&cfg.LBSGExtraInboundRules, "lb-sg-extra-inbound-rules",
+		`Comma separated protocol:ports:cidr inbound rules added to managed ALB securityGroups in addition to listen ports, e.g. icmp:3-4:0.0.0.0/0`)
+	fs.BoolVar(&cfg.RestrictLBSGEgress, "restrict-lb-sg-egress", false,
+		`Restrict egress of managed ALB securityGroups to the target ports of the managed instance securityGroup and --lb-sg-egress-cidrs, instead of allowing all egress`)
+	fs.StringSliceVar(&cfg.LBSGEgressCIDRs, "lb-sg-egress-cidrs", []string{},
+		`CIDRs of targets that managed ALB securityGroups are allowed egress to when --restrict-lb-sg-egress is set, e.g. pod CIDRs`)
+	fs.BoolVar(&cfg.SubnetAutoExpansion, "subnet-auto-expansion", true,
+		`Add subnets found by subnet auto discovery in new availability zones to existing ALBs. If disabled, events advise about ALBs spanning fewer availability zones than available`)
+	fs.StringVar(&cfg.LocalZoneSubnets, "local-zone-subnets", defaultLocalZoneSubnets,
+		`Whether subnet auto discovery excludes subnets in Local Zones, or prefers them over subnets in availability zones when they span at least 2 Local Zones, must be "exclude" or "prefer"`)
+	fs.BoolVar(&cfg.RestrictTargetType, "restrict-target-type", false,
+		`Restrict target groups to the default target-type. With "instance", the Pod and Endpoints informers are disabled to reduce memory usage`)
+	fs.BoolVar(&cfg.StrictAttributes, "strict-attributes", false,
+		`Reset ALB and target group attributes not specified by annotations to their defaults, instead of leaving them unmanaged`)
+	fs.StringVar(&cfg.PodLabelSelector, "pod-label-selector", "",
+		`Label selector restricting the Pods watched by controller, Pods are used to resolve named container ports of "ip" targets`)
+	fs.StringVar(&cfg.EndpointsLabelSelector, "endpoints-label-selector", "",
+		`Label selector restricting the Endpoints watched by controller, Endpoints of services referenced by "ip" targets must match`)
 	fs.Float32Var(&cfg.SyncRateLimit, "sync-rate-limit", defaultSyncRateLimit,
 		`Define the sync frequency upper limit`)
+	fs.DurationVar(&cfg.EventDedupWindow, "event-dedup-window", defaultEventDedupWindow,
+		`Period during which identical Kubernetes events for an Ingress are collapsed into one`)
+	fs.IntVar(&cfg.EventBurst, "event-burst", defaultEventBurst,
+		`Maximum number of distinct non-error Kubernetes events emitted for an Ingress within the event-dedup-window`)
+	fs.Int64Var(&cfg.RemovalSafetyThreshold, "removal-safety-threshold", defaultRemovalSafetyThreshold,
+		`Maximum percentage of targets in a target group or rules on a listener removed in a single reconcile without confirmation, larger removals are only alerted. 0 disables the protection`)
+	fs.DurationVar(&cfg.LBActiveTimeout, "lb-active-timeout", 0,
+		`Maximum duration to wait for new ALBs to become active before updating Ingress status. 0 disables the wait`)
+	fs.DurationVar(&cfg.DeletionRetryWindow, "deletion-retry-window", defaultDeletionRetryWindow,
+		`Maximum duration to retry deleting securityGroups and targetGroups still in use in background. 0 disables the retries`)
+	fs.StringVar(&cfg.DeletionQueueConfigMap, "deletion-queue-configmap", defaultDeletionQueueConfigMap,
+		`Namespace/name of ConfigMap that persists deletions retried in background across controller restarts, they're only kept in memory if empty`)
+	fs.DurationVar(&cfg.TargetGroupRetention, "target-group-retention", 0,
+		`Duration to keep targetGroups no longer used by an ingress before deleting them, e.g. for CodeDeploy or Argo Rollouts to finish shifting traffic. 0 deletes them immediately`)
+	fs.StringVar(&cfg.ACMPrivateCAArn, "acm-private-ca-arn", "",
+		`ARN of an ACM Private CA to request certificates from for TLS hosts of ingresses without certificate-arn annotation and no matching issued certificate`)
+	fs.StringVar(&cfg.ACMValidationZoneID, "acm-validation-zone-id", "",
+		`ID of a Route53 hosted zone to create DNS validation records in for public ACM certificates requested for TLS hosts of ingresses without certificate-arn annotation and no matching issued certificate`)
+	fs.BoolVar(&cfg.ACMImportTLSSecrets, "acm-import-tls-secrets", false,
+		`Import the TLS secrets referenced by spec.tls of ingresses without certificate-arn annotation into ACM, re-importing them when renewed`)
+	fs.DurationVar(&cfg.TargetHealthInterval, "target-health-interval", 0,
+		`Period to propagate the health of targets reported by ALB into metrics and Ingress events. 0 disables it`)
+	fs.DurationVar(&cfg.TargetHealthGracePeriod, "target-health-grace-period", 0,
+		`Duration after registration during which targets don't count towards min-healthy-targets and don't emit unhealthy events`)
+	fs.DurationVar(&cfg.CostEstimationInterval, "cost-estimation-interval", 0,
+		`Period to estimate the monthly cost of ALBs from hourly prices and consumed LCUs into metrics. 0 disables it`)
+	fs.Float64Var(&cfg.LBHourlyPrice, "lb-hourly-price", defaultLBHourlyPrice,
+		`Price of an ALB per hour used for cost estimation, defaults to the price in us-east-1`)
+	fs.Float64Var(&cfg.LCUHourlyPrice, "lcu-hourly-price", defaultLCUHourlyPrice,
+		`Price of a LCU per hour used for cost estimation, defaults to the price in us-east-1`)
+	fs.DurationVar(&cfg.TargetGroupTrafficInterval, "target-group-traffic-interval", 0,
+		`Period to propagate the request rate and response time of targetGroups reported by CloudWatch into metrics. 0 disables it`)
+	fs.StringVar(&cfg.MetricsPushgatewayURL, "metrics-pushgateway-url", "",
+		`URL of Prometheus Pushgateway to push targetGroup traffic metrics to`)
+	fs.DurationVar(&cfg.InventoryRefreshInterval, "inventory-refresh-interval", 0,
+		`Period to list the ALBs, targetGroups and securityGroups tagged for the cluster, describing new ones into the in-memory inventory and forgetting deleted ones. 0 disables it`)
+	fs.IntVar(&cfg.MaxLoadBalancers, "max-load-balancers", 0,
+		`Maximum number of ALBs in the cluster, Ingresses beyond it are rejected. 0 is unlimited`)
+	fs.IntVar(&cfg.MaxLoadBalancersPerNamespace, "max-load-balancers-per-namespace", 0,
+		`Maximum number of ALBs per namespace, Ingresses beyond it are rejected. 0 is unlimited`)
+	fs.StringToIntVar(&cfg.NamespaceLoadBalancerQuotas, "namespace-load-balancer-quotas", nil,
+		`Maximum numbers of ALBs of namespaces, overriding max-load-balancers-per-namespace, e.g. team-a=5,team-b=0. 0 is unlimited`)
+	fs.StringVar(&cfg.HostConflicts, "host-conflicts", defaultHostConflicts,
+		`Whether Ingresses claiming hosts already claimed by older Ingresses, and thus served by another ALB, are ignored, warned on with events, or rejected unless they already have an ALB, must be "ignore", "warn" or "reject"`)
+	fs.StringVar(&cfg.PolicyFile, "policy-file", "",
+		`Path of YAML file with policy rules that ALBs must conform to, violations block reconcile or emit warning events per rule severity`)
+	fs.StringVar(&cfg.AnnotationRestrictionsFile, "annotation-restrictions-file", "",
+		`Path of YAML file with rules that deny annotations or annotation values for Ingresses per namespace`)
+	fs.StringVar(&cfg.SmokeTestProberURL, "smoke-test-prober-url", "",
+		`URL of an external prober that performs smoke tests of Ingresses, smoke tests are performed from the controller if empty`)
+	fs.DurationVar(&cfg.SmokeTestTimeout, "smoke-test-timeout", defaultSmokeTestTimeout,
+		`Timeout of each request of smoke tests of Ingresses`)
 	fs.BoolVar(&cfg.RestrictScheme, "restrict-scheme", defaultRestrictScheme,
 		`Restrict the scheme to internal except for whitelisted namespaces`)
 	fs.StringVar(&cfg.RestrictSchemeNamespace, "restrict-scheme-namespace", defaultRestrictSchemeNamespace,
 		`The namespace with the ConfigMap containing the allowed ingresses. Only respected when restrict-scheme is true.`)
+	fs.BoolVar(&cfg.HTTPSOnly, "https-only", false,
+		`Reject Ingresses with HTTP listen ports, so ALBs only have HTTPS listeners`)
+	fs.BoolVar(&cfg.HTTPSOnlyRedirect, "https-only-redirect", false,
+		`Allow HTTP listen ports of Ingresses with an HTTPS listen port when https-only is true, their listeners only redirect to HTTPS`)
 
 	cfg.FeatureGate.BindFlags(fs)
 }
@@ -123,6 +350,93 @@ func (cfg *Configuration) Validate() error {
 	if len(cfg.ALBNamePrefix) > 12 {
 		return fmt.Errorf("ALBNamePrefix must be 12 characters or less")
 	}
+	if len(cfg.Default404MessageBody) > 1024 {
+		return fmt.Errorf("Default404MessageBody must be 1024 characters or less")
+	}
+	switch cfg.Default404ContentType {
+	case "text/plain", "text/css", "text/html", "application/javascript", "application/json":
+	default:
+		return fmt.Errorf("Default404ContentType must be one of text/plain, text/css, text/html, application/javascript or application/json")
+	}
+	if cfg.LocalZoneSubnets != LocalZoneSubnetsExclude && cfg.LocalZoneSubnets != LocalZoneSubnetsPrefer {
+		return fmt.Errorf("LocalZoneSubnets must be exclude or prefer")
+	}
+	if cfg.HostConflicts != HostConflictsIgnore && cfg.HostConflicts != HostConflictsWarn && cfg.HostConflicts != HostConflictsReject {
+		return fmt.Errorf("HostConflicts must be ignore, warn or reject")
+	}
+	if cfg.LBActiveTimeout < 0 {
+		return fmt.Errorf("LBActiveTimeout must be non-negative")
+	}
+	if cfg.DeletionRetryWindow < 0 {
+		return fmt.Errorf("DeletionRetryWindow must be non-negative")
+	}
+	if cfg.TargetGroupRetention < 0 {
+		return fmt.Errorf("TargetGroupRetention must be non-negative")
+	}
+	if cfg.ACMPrivateCAArn != "" && !strings.Contains(cfg.ACMPrivateCAArn, ":acm-pca:") {
+		return fmt.Errorf("ACMPrivateCAArn must be the ARN of an ACM Private CA")
+	}
+	if cfg.ACMPrivateCAArn != "" && cfg.ACMValidationZoneID != "" {
+		return fmt.Errorf("ACMPrivateCAArn and ACMValidationZoneID must not be both specified")
+	}
+	if cfg.HTTPSOnlyRedirect && !cfg.HTTPSOnly {
+		return fmt.Errorf("HTTPSOnlyRedirect must only be specified along with HTTPSOnly")
+	}
+	if cfg.DeletionQueueConfigMap != "" {
+		if namespace, name, err := cache.SplitMetaNamespaceKey(cfg.DeletionQueueConfigMap); err != nil || namespace == "" || name == "" {
+			return fmt.Errorf("DeletionQueueConfigMap must be in the form namespace/name")
+		}
+	}
+	for _, cidr := range cfg.LBSGEgressCIDRs {
+		if ip, _, err := net.ParseCIDR(cidr); err != nil || ip.To4() == nil {
+			return fmt.Errorf("LBSGEgressCIDRs must be IPv4 CIDRs, %v is invalid", cidr)
+		}
+	}
+	if cfg.TargetHealthInterval < 0 {
+		return fmt.Errorf("TargetHealthInterval must be non-negative")
+	}
+	if cfg.TargetHealthGracePeriod < 0 {
+		return fmt.Errorf("TargetHealthGracePeriod must be non-negative")
+	}
+	if cfg.CostEstimationInterval < 0 {
+		return fmt.Errorf("CostEstimationInterval must be non-negative")
+	}
+	if cfg.LBHourlyPrice < 0 || cfg.LCUHourlyPrice < 0 {
+		return fmt.Errorf("LBHourlyPrice and LCUHourlyPrice must be non-negative")
+	}
+	if cfg.TargetGroupTrafficInterval < 0 {
+		return fmt.Errorf("TargetGroupTrafficInterval must be non-negative")
+	}
+	if cfg.InventoryRefreshInterval < 0 {
+		return fmt.Errorf("InventoryRefreshInterval must be non-negative")
+	}
+	if cfg.MaxLoadBalancers < 0 || cfg.MaxLoadBalancersPerNamespace < 0 {
+		return fmt.Errorf("MaxLoadBalancers and MaxLoadBalancersPerNamespace must be non-negative")
+	}
+	for namespace, quota := range cfg.NamespaceLoadBalancerQuotas {
+		if quota < 0 {
+			return fmt.Errorf("NamespaceLoadBalancerQuotas must be non-negative, %v is %v", namespace, quota)
+		}
+	}
+	if cfg.MetricsPushgatewayURL != "" {
+		if _, err := url.ParseRequestURI(cfg.MetricsPushgatewayURL); err != nil {
+			return fmt.Errorf("MetricsPushgatewayURL is invalid due to %v", err)
+		}
+	}
+	if cfg.SmokeTestProberURL != "" {
+		if _, err := url.ParseRequestURI(cfg.SmokeTestProberURL); err != nil {
+			return fmt.Errorf("SmokeTestProberURL is invalid due to %v", err)
+		}
+	}
+	if cfg.RemovalSafetyThreshold < 0 || cfg.RemovalSafetyThreshold > 100 {
+		return fmt.Errorf("RemovalSafetyThreshold must be within 0-100")
+	}
+	if _, err := labels.Parse(cfg.PodLabelSelector); err != nil {
+		return fmt.Errorf("PodLabelSelector is invalid due to %v", err)
+	}
+	if _, err := labels.Parse(cfg.EndpointsLabelSelector); err != nil {
+		return fmt.Errorf("EndpointsLabelSelector is invalid due to %v", err)
+	}
 	if len(cfg.ALBNamePrefix) == 0 {
 		cfg.ALBNamePrefix = generateALBNamePrefix(cfg.ClusterName)
 	}
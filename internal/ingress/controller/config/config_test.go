@@ -1 +1,84 @@
 package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSecurityGroupRule(t *testing.T) {
+	for _, tc := range []struct {
+		value        string
+		expectedRule SecurityGroupRule
+		expectedErr  error
+	}{
+		{
+			value:        "tcp:443:10.0.0.0/8",
+			expectedRule: SecurityGroupRule{Protocol: "tcp", FromPort: 443, ToPort: 443, CIDR: "10.0.0.0/8"},
+		},
+		{
+			value:        "UDP:8000-8100:0.0.0.0/0",
+			expectedRule: SecurityGroupRule{Protocol: "udp", FromPort: 8000, ToPort: 8100, CIDR: "0.0.0.0/0"},
+		},
+		{
+			value:        "icmp:3-4:0.0.0.0/0",
+			expectedRule: SecurityGroupRule{Protocol: "icmp", FromPort: 3, ToPort: 4, CIDR: "0.0.0.0/0"},
+		},
+		{
+			value:        "icmp:3:0.0.0.0/0",
+			expectedRule: SecurityGroupRule{Protocol: "icmp", FromPort: 3, ToPort: -1, CIDR: "0.0.0.0/0"},
+		},
+		{
+			value:        "1:-1:0.0.0.0/0",
+			expectedRule: SecurityGroupRule{Protocol: "icmp", FromPort: -1, ToPort: -1, CIDR: "0.0.0.0/0"},
+		},
+		{
+			value:        "50:0:10.0.0.0/8",
+			expectedRule: SecurityGroupRule{Protocol: "50", CIDR: "10.0.0.0/8"},
+		},
+		{
+			value:        "all:0:10.0.0.0/8",
+			expectedRule: SecurityGroupRule{Protocol: "-1", CIDR: "10.0.0.0/8"},
+		},
+		{
+			value:       "tcp:443",
+			expectedErr: errors.New("invalid securityGroup rule tcp:443, must be in the form protocol:ports:cidr"),
+		},
+		{
+			value:       "sctp:443:10.0.0.0/8",
+			expectedErr: errors.New("invalid protocol sctp of securityGroup rule sctp:443:10.0.0.0/8"),
+		},
+		{
+			value:       "tcp:100-80:10.0.0.0/8",
+			expectedErr: errors.New("invalid ports 100-80 of securityGroup rule tcp:100-80:10.0.0.0/8"),
+		},
+		{
+			value:       "icmp:256:10.0.0.0/8",
+			expectedErr: errors.New("invalid ICMP type-code 256 of securityGroup rule icmp:256:10.0.0.0/8"),
+		},
+		{
+			value:       "tcp:443:::/0",
+			expectedErr: errors.New("invalid securityGroup rule tcp:443:::/0, must be in the form protocol:ports:cidr"),
+		},
+		{
+			value:       "tcp:443:10.0.0.0",
+			expectedErr: errors.New("invalid CIDR 10.0.0.0 of securityGroup rule tcp:443:10.0.0.0, must be an IPv4 CIDR"),
+		},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			rule, err := ParseSecurityGroupRule(tc.value)
+			assert.Equal(t, tc.expectedRule, rule)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestSecurityGroupRules_Set(t *testing.T) {
+	var rules SecurityGroupRules
+	assert.NoError(t, rules.Set("icmp:3-4:0.0.0.0/0, udp:53:10.0.0.0/8"))
+	assert.NoError(t, rules.Set("tcp:8000-8100:10.0.0.0/8"))
+	assert.Equal(t, "icmp:3-4:0.0.0.0/0,udp:53:10.0.0.0/8,tcp:8000-8100:10.0.0.0/8", rules.String())
+	assert.Error(t, rules.Set("icmp:3-4:0.0.0.0/0,tcp"))
+	assert.Len(t, rules, 3)
+}
@@ -27,7 +27,10 @@ import (
 type Feature string
 
 const (
-	WAF Feature = "waf"
+	WAF                Feature = "waf"
+	ModelSnapshot      Feature = "model-snapshot"
+	IngressClassParams Feature = "ingress-class-params"
+	LoadBalancerCRD    Feature = "loadbalancer-crd"
 )
 
 type FeatureGate interface {
@@ -55,7 +58,10 @@ type defaultFeatureGate struct {
 func NewFeatureGate() FeatureGate {
 	return &defaultFeatureGate{
 		featureState: map[Feature]bool{
-			WAF: true,
+			WAF:                true,
+			ModelSnapshot:      false,
+			IngressClassParams: false,
+			LoadBalancerCRD:    false,
 		},
 	}
 }
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// SecurityGroupRule is an inbound rule added to managed LoadBalancer securityGroups in addition to listen ports.
+type SecurityGroupRule struct {
+	// Protocol is the IP protocol name (tcp, udp, icmp) or number, -1 means all protocols.
+	Protocol string
+	// FromPort and ToPort are the range of ports for tcp and udp, or the ICMP type and code for icmp. They're zero for other protocols.
+	FromPort int64
+	ToPort   int64
+	// CIDR is the IPv4 CIDR traffic is allowed from.
+	CIDR string
+}
+
+// String returns rule in the form of protocol:ports:cidr
+func (r SecurityGroupRule) String() string {
+	switch {
+	case r.Protocol == "icmp" && r.ToPort == -1, r.FromPort == r.ToPort:
+		return fmt.Sprintf("%v:%v:%v", r.Protocol, r.FromPort, r.CIDR)
+	}
+	return fmt.Sprintf("%v:%v-%v:%v", r.Protocol, r.FromPort, r.ToPort, r.CIDR)
+}
+
+// protocolNames are the names EC2 reports for protocols specified by number.
+var protocolNames = map[string]string{
+	"all": "-1",
+	"1":   "icmp",
+	"6":   "tcp",
+	"17":  "udp",
+}
+
+// ParseSecurityGroupRule parses rule in the form of protocol:ports:cidr, e.g. udp:53:10.0.0.0/8 or icmp:3-4:0.0.0.0/0.
+// ports is a port or range of ports for tcp and udp, or the ICMP type-code for icmp where -1 means all. It's ignored for other protocols.
+func ParseSecurityGroupRule(value string) (SecurityGroupRule, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return SecurityGroupRule{}, fmt.Errorf("invalid securityGroup rule %v, must be in the form protocol:ports:cidr", value)
+	}
+	rule := SecurityGroupRule{Protocol: strings.ToLower(parts[0]), CIDR: parts[2]}
+	if name, ok := protocolNames[rule.Protocol]; ok {
+		rule.Protocol = name
+	}
+	switch rule.Protocol {
+	case "tcp", "udp", "icmp", "-1":
+	default:
+		if number, err := strconv.Atoi(rule.Protocol); err != nil || number < 0 || number > 255 {
+			return SecurityGroupRule{}, fmt.Errorf("invalid protocol %v of securityGroup rule %v", parts[0], value)
+		}
+	}
+
+	switch rule.Protocol {
+	case "tcp", "udp":
+		from, to, ok := parsePortRange(parts[1])
+		if !ok || from < 0 || from > to || to > 65535 {
+			return SecurityGroupRule{}, fmt.Errorf("invalid ports %v of securityGroup rule %v", parts[1], value)
+		}
+		rule.FromPort, rule.ToPort = from, to
+	case "icmp":
+		if parts[1] == "-1" {
+			rule.FromPort, rule.ToPort = -1, -1
+			break
+		}
+		icmpType, icmpCode, ok := parsePortRange(parts[1])
+		if !ok || icmpType < 0 || icmpType > 255 || icmpCode < -1 || icmpCode > 255 {
+			return SecurityGroupRule{}, fmt.Errorf("invalid ICMP type-code %v of securityGroup rule %v", parts[1], value)
+		}
+		if !strings.Contains(parts[1], "-") {
+			icmpCode = -1
+		}
+		rule.FromPort, rule.ToPort = icmpType, icmpCode
+	}
+
+	ip, _, err := net.ParseCIDR(rule.CIDR)
+	if err != nil || ip.To4() == nil {
+		return SecurityGroupRule{}, fmt.Errorf("invalid CIDR %v of securityGroup rule %v, must be an IPv4 CIDR", rule.CIDR, value)
+	}
+	return rule, nil
+}
+
+// parsePortRange parses a port or range of ports in the form of from-to.
+func parsePortRange(value string) (from int64, to int64, ok bool) {
+	ports := strings.SplitN(value, "-", 2)
+	from, err := strconv.ParseInt(ports[0], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if len(ports) == 1 {
+		return from, from, true
+	}
+	if to, err = strconv.ParseInt(ports[1], 10, 64); err != nil {
+		return 0, 0, false
+	}
+	return from, to, true
+}
+
+// SecurityGroupRules is a list of securityGroup rules, which can be bound to a flag.
+type SecurityGroupRules []SecurityGroupRule
+
+var _ pflag.Value = (*SecurityGroupRules)(nil)
+
+func (r *SecurityGroupRules) String() string {
+	values := make([]string, 0, len(*r))
+	for _, rule := range *r {
+		values = append(values, rule.String())
+	}
+	return strings.Join(values, ",")
+}
+
+func (r *SecurityGroupRules) Set(value string) error {
+	var rules SecurityGroupRules
+	for _, item := range strings.Split(value, ",") {
+		rule, err := ParseSecurityGroupRule(strings.TrimSpace(item))
+		if err != nil {
+			return err
+		}
+		rules = append(rules, rule)
+	}
+	*r = append(*r, rules...)
+	return nil
+}
+
+func (r *SecurityGroupRules) Type() string {
+	return "securityGroupRules"
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/auth"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/cert"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/cleanup"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/ls"
@@ -13,23 +15,39 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/restriction"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/handlers"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/snapshot"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/diagnostics"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/events"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/policy"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/smoketest"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/sets"
+	toolscache "k8s.io/client-go/tools/cache"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
-func Initialize(config *config.Configuration, mgr manager.Manager, mc metric.Collector, cloud aws.CloudAPI) error {
+func Initialize(config *config.Configuration, mgr manager.Manager, mc metric.Collector, cloud aws.CloudAPI, diag *diagnostics.Diagnostics, forceSync *ForceSync) error {
 	authModule := auth.NewModule(mgr.GetCache())
-	reconciler, err := newReconciler(config, mgr, mc, cloud, authModule)
+	informers, err := store.NewInformers(mgr, config)
+	if err != nil {
+		return err
+	}
+	registerInformers(diag, informers)
+	reconciler, err := newReconciler(config, mgr, informers, mc, cloud, authModule, diag, forceSync)
 	if err != nil {
 		return err
 	}
@@ -46,38 +64,201 @@ func Initialize(config *config.Configuration, mgr manager.Manager, mc metric.Col
 	if err := authModule.Init(c, ingressChan, serviceChan); err != nil {
 		return fmt.Errorf("failed to init auth module due to %v", err)
 	}
-	if err := watchClusterEvents(c, mgr.GetCache(), ingressChan, serviceChan, config.IngressClass); err != nil {
+	forceSync.bind(mgr.GetCache(), ingressChan)
+	if err := watchClusterEvents(c, mgr.GetCache(), informers, ingressChan, serviceChan, config.IngressClass, config.ACMImportTLSSecrets); err != nil {
 		return fmt.Errorf("failed to watch cluster events due to %v", err)
 	}
+	if informers.LoadBalancer != nil {
+		if err := initLoadBalancerController(mgr, informers, reconciler, config.IngressClass); err != nil {
+			return fmt.Errorf("failed to init LoadBalancer controller due to %v", err)
+		}
+	}
+
+	if config.TargetHealthInterval > 0 {
+		recorder := events.NewRecorder(mgr.GetRecorder("alb-ingress-controller"), events.Options{
+			DedupWindow: config.EventDedupWindow,
+			Burst:       config.EventBurst,
+		})
+		monitor := newTargetHealthMonitor(mgr.GetCache(), cloud, generator.NewNameTagGenerator(*config), recorder,
+			mc, config.IngressClass, config.TargetHealthInterval, config.TargetHealthGracePeriod)
+		if err := mgr.Add(monitor); err != nil {
+			return fmt.Errorf("failed to add target health monitor due to %v", err)
+		}
+	}
+	if config.CostEstimationInterval > 0 {
+		estimator := newCostEstimator(mgr.GetCache(), cloud, generator.NewNameTagGenerator(*config), mc,
+			config.IngressClass, config.CostEstimationInterval, config.LBHourlyPrice, config.LCUHourlyPrice)
+		if err := mgr.Add(estimator); err != nil {
+			return fmt.Errorf("failed to add cost estimator due to %v", err)
+		}
+	}
+	if config.TargetGroupTrafficInterval > 0 {
+		nameTagGen := generator.NewNameTagGenerator(*config)
+		monitor := newTargetGroupTrafficMonitor(mgr.GetCache(), cloud, nameTagGen, nameTagGen, mc,
+			config.IngressClass, config.TargetGroupTrafficInterval, config.MetricsPushgatewayURL)
+		if err := mgr.Add(monitor); err != nil {
+			return fmt.Errorf("failed to add targetGroup traffic monitor due to %v", err)
+		}
+	}
+	if config.InventoryRefreshInterval > 0 {
+		if err := mgr.Add(newInventoryRefresher(cloud, config.InventoryRefreshInterval)); err != nil {
+			return fmt.Errorf("failed to add inventory refresher due to %v", err)
+		}
+	}
 
 	return nil
 }
 
-func newReconciler(config *config.Configuration, mgr manager.Manager, mc metric.Collector, cloud aws.CloudAPI, authModule auth.Module) (reconcile.Reconciler, error) {
-	store, err := store.New(mgr, config)
+// apiClient reads from the API server directly, so the queue reads its own configMap only instead of caching all configMaps.
+func newDeletionQueue(config *config.Configuration, mgr manager.Manager, mc metric.Collector, cloud aws.CloudAPI, apiClient client.Client) (cleanup.Queue, error) {
+	var configMapKey types.NamespacedName
+	if config.DeletionQueueConfigMap != "" {
+		namespace, name, err := toolscache.SplitMetaNamespaceKey(config.DeletionQueueConfigMap)
+		if err != nil {
+			return nil, err
+		}
+		configMapKey = types.NamespacedName{Namespace: namespace, Name: name}
+	}
+	deletionQueue := cleanup.NewQueue(cloud, apiClient, configMapKey, mc, config.DeletionRetryWindow)
+	if err := mgr.Add(deletionQueue); err != nil {
+		return nil, fmt.Errorf("failed to add deletion queue due to %v", err)
+	}
+	return deletionQueue, nil
+}
+
+func newReconciler(config *config.Configuration, mgr manager.Manager, informers *store.Informer, mc metric.Collector, cloud aws.CloudAPI, authModule auth.Module, diag *diagnostics.Diagnostics, forceSync *ForceSync) (*Reconciler, error) {
+	if config.AnnotationRestrictionsFile != "" {
+		restrictions, err := restriction.Load(config.AnnotationRestrictionsFile)
+		if err != nil {
+			return nil, err
+		}
+		config.AnnotationRestrictions = restrictions
+	}
+	store, err := store.New(informers, config, cloud)
+	if err != nil {
+		return nil, err
+	}
+	// controller-runtime clients read from cache, while destructive actions are confirmed against the API server.
+	apiClient, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()})
 	if err != nil {
 		return nil, err
 	}
 	nameTagGenerator := generator.NewNameTagGenerator(*config)
 	tagsController := tags.NewController(cloud)
-	endpointResolver := backend.NewEndpointResolver(store, cloud)
-	tgGroupController := tg.NewGroupController(cloud, store, nameTagGenerator, tagsController, endpointResolver)
-	lsGroupController := ls.NewGroupController(store, cloud, authModule)
-	sgAssociationController := sg.NewAssociationController(store, cloud, tagsController, nameTagGenerator)
+	endpointResolver := backend.NewEndpointResolver(store, cloud, apiClient)
+	deletionQueue, err := newDeletionQueue(config, mgr, mc, cloud, apiClient)
+	if err != nil {
+		return nil, err
+	}
+	tgGroupController := tg.NewGroupController(cloud, store, nameTagGenerator, tagsController, endpointResolver, deletionQueue)
+	lsGroupController := ls.NewGroupController(store, cloud, authModule, tagsController, nameTagGenerator)
+	sgAssociationController := sg.NewAssociationController(store, cloud, tagsController, nameTagGenerator, deletionQueue)
+	var lbPolicy *policy.Policy
+	if config.PolicyFile != "" {
+		if lbPolicy, err = policy.Load(config.PolicyFile); err != nil {
+			return nil, err
+		}
+	}
+	if config.ACMPrivateCAArn != "" {
+		if err := cloud.ValidateARN(config.ACMPrivateCAArn); err != nil {
+			return nil, fmt.Errorf("invalid --acm-private-ca-arn due to %v", err)
+		}
+	}
+	var certController cert.Controller
+	if config.ACMPrivateCAArn != "" || config.ACMValidationZoneID != "" || config.ACMImportTLSSecrets {
+		certController = cert.NewController(cloud, mgr.GetClient(), nameTagGenerator, cert.Options{
+			PrivateCAArn:     config.ACMPrivateCAArn,
+			ValidationZoneID: config.ACMValidationZoneID,
+			ImportTLSSecrets: config.ACMImportTLSSecrets,
+		})
+	}
 	lbController := lb.NewController(cloud, store,
-		nameTagGenerator, tgGroupController, lsGroupController, sgAssociationController, tagsController)
+		nameTagGenerator, tgGroupController, lsGroupController, sgAssociationController, tagsController, certController, lbPolicy)
+	recorder := events.NewRecorder(mgr.GetRecorder("alb-ingress-controller"), events.Options{
+		DedupWindow: config.EventDedupWindow,
+		Burst:       config.EventBurst,
+	})
+	snapshotStore := snapshot.NewStore(mgr.GetClient())
 
 	return &Reconciler{
-		client:          mgr.GetClient(),
-		cache:           mgr.GetCache(),
-		recorder:        mgr.GetRecorder("alb-ingress-controller"),
-		store:           store,
-		lbController:    lbController,
-		metricCollector: mc,
+		client:            mgr.GetClient(),
+		cache:             mgr.GetCache(),
+		recorder:          recorder,
+		apiReader:         apiClient,
+		store:             store,
+		informersSynced:   informers.HasSynced,
+		lbController:      lbController,
+		snapshotStore:     snapshotStore,
+		restoredIngresses: sets.NewString(),
+		metricCollector:   mc,
+		diagnostics:       diag,
+		waitTracker:       newWaitTracker(clock.RealClock{}),
+		smokeTester:       smoketest.NewTester(newSmokeTestProber(config)),
+		quotaTracker:      newQuotaTracker(mgr.GetCache(), mc, config),
+		hostConflicts:     newHostConflictDetector(mgr.GetCache(), config.IngressClass),
+		forceSync:         forceSync,
 	}, nil
 }
 
-func watchClusterEvents(c controller.Controller, cache cache.Cache, ingressChan <-chan event.GenericEvent, serviceChan <-chan event.GenericEvent, ingressClass string) error {
+// initLoadBalancerController runs a controller for standalone LoadBalancers, which shares the AWS controllers of reconciler.
+// LoadBalancers are reconciled again on changes to the services and endpoints they use as backends.
+func initLoadBalancerController(mgr manager.Manager, informers *store.Informer, reconciler *Reconciler, ingressClass string) error {
+	c, err := controller.New("alb-loadbalancer-controller", mgr, controller.Options{
+		Reconciler: &LoadBalancerReconciler{reconciler: reconciler, ingressClass: ingressClass},
+	})
+	if err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Informer{Informer: informers.LoadBalancer}, &handlers.EnqueueRequestsForLoadBalancerEvent{
+		IngressClass: ingressClass,
+	}); err != nil {
+		return err
+	}
+	backendHandler := &handlers.EnqueueRequestsForLoadBalancerBackendEvent{
+		IngressClass: ingressClass,
+		Cache:        mgr.GetCache(),
+	}
+	if err := c.Watch(&source.Kind{Type: &corev1.Service{}}, backendHandler); err != nil {
+		return err
+	}
+	if informers.Endpoint != nil {
+		if err := c.Watch(&source.Informer{Informer: informers.Endpoint}, backendHandler); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newSmokeTestProber constructs the Prober for smoke tests, which delegates to the prober at SmokeTestProberURL if configured.
+func newSmokeTestProber(config *config.Configuration) smoketest.Prober {
+	if config.SmokeTestProberURL != "" {
+		return smoketest.NewRemoteProber(config.SmokeTestProberURL, config.SmokeTestTimeout)
+	}
+	return smoketest.NewHTTPProber(config.SmokeTestTimeout)
+}
+
+// registerInformers registers the informers of store for cache size reporting, disabled informers are skipped.
+func registerInformers(diag *diagnostics.Diagnostics, informers *store.Informer) {
+	for name, informer := range map[string]toolscache.SharedIndexInformer{
+		"ingress":      informers.Ingress,
+		"service":      informers.Service,
+		"endpoints":    informers.Endpoint,
+		"node":         informers.Node,
+		"pod":          informers.Pod,
+		"replicaset":   informers.ReplicaSet,
+		"classparams":  informers.ClassParams,
+		"loadbalancer": informers.LoadBalancer,
+	} {
+		if informer != nil {
+			diag.AddInformer(name, informer)
+		}
+	}
+}
+
+func watchClusterEvents(c controller.Controller, cache cache.Cache, informers *store.Informer, ingressChan <-chan event.GenericEvent, serviceChan <-chan event.GenericEvent, ingressClass string, watchTLSSecrets bool) error {
+	if err := cache.IndexField(&extensions.Ingress{}, handlers.FieldBackendService, handlers.IndexBackendServices); err != nil {
+		return err
+	}
 	if err := c.Watch(&source.Kind{Type: &extensions.Ingress{}}, &handlers.EnqueueRequestsForIngressEvent{
 		IngressClass: ingressClass,
 	}); err != nil {
@@ -102,18 +283,50 @@ func watchClusterEvents(c controller.Controller, cache cache.Cache, ingressChan
 		return err
 	}
 
-	if err := c.Watch(&source.Kind{Type: &corev1.Endpoints{}}, &handlers.EnqueueRequestsForEndpointsEvent{
+	// Endpoints informer may be restricted by label selector or disabled, see store.NewInformers.
+	if informers.Endpoint != nil {
+		if err := c.Watch(&source.Informer{Informer: informers.Endpoint}, &handlers.EnqueueRequestsForEndpointsEvent{
+			IngressClass: ingressClass,
+			Cache:        cache,
+		}); err != nil {
+			return err
+		}
+	}
+	if err := c.Watch(&source.Kind{Type: &corev1.Node{}}, &handlers.EnqueueRequestsForNodeEvent{
 		IngressClass: ingressClass,
 		Cache:        cache,
 	}); err != nil {
 		return err
 	}
-	if err := c.Watch(&source.Kind{Type: &corev1.Node{}}, &handlers.EnqueueRequestsForNodeEvent{
+	if err := c.Watch(&source.Kind{Type: &appsv1.ReplicaSet{}}, &handlers.EnqueueRequestsForReplicaSetEvent{
 		IngressClass: ingressClass,
 		Cache:        cache,
 	}); err != nil {
 		return err
 	}
 
+	// IngressClassParams informer is only started if its feature gate is enabled, see store.NewInformers.
+	if informers.ClassParams != nil {
+		if err := c.Watch(&source.Informer{Informer: informers.ClassParams}, &handlers.EnqueueRequestsForClassParamsEvent{
+			IngressClass: ingressClass,
+			Cache:        cache,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// TLS secrets are only watched when imported into ACM, so their renewals are re-imported.
+	if watchTLSSecrets {
+		if err := cache.IndexField(&extensions.Ingress{}, handlers.FieldTLSSecret, handlers.IndexTLSSecrets); err != nil {
+			return err
+		}
+		if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handlers.EnqueueRequestsForTLSSecretEvent{
+			IngressClass: ingressClass,
+			Cache:        cache,
+		}); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// hoursPerMonth is the number of hours per month AWS uses for pricing.
+	hoursPerMonth = 730
+
+	// lcuWindow is the duration over which consumed LCUs are averaged.
+	lcuWindow = 24 * time.Hour
+)
+
+// costEstimator periodically estimates the monthly cost of LoadBalancers of ingresses, and exposes it as metrics.
+// The estimate is the hourly price of LoadBalancer plus the hourly price of LCUs it consumed on average over the last day.
+type costEstimator struct {
+	reader         client.Reader
+	cloud          aws.CloudAPI
+	nameGen        lb.NameGenerator
+	mc             metric.Collector
+	ingressClass   string
+	interval       time.Duration
+	lbHourlyPrice  float64
+	lcuHourlyPrice float64
+
+	// estimated contains the ingresses with cost metrics set.
+	estimated map[types.NamespacedName]bool
+}
+
+func newCostEstimator(reader client.Reader, cloud aws.CloudAPI, nameGen lb.NameGenerator, mc metric.Collector,
+	ingressClass string, interval time.Duration, lbHourlyPrice float64, lcuHourlyPrice float64) *costEstimator {
+	return &costEstimator{
+		reader:         reader,
+		cloud:          cloud,
+		nameGen:        nameGen,
+		mc:             mc,
+		ingressClass:   ingressClass,
+		interval:       interval,
+		lbHourlyPrice:  lbHourlyPrice,
+		lcuHourlyPrice: lcuHourlyPrice,
+		estimated:      make(map[types.NamespacedName]bool),
+	}
+}
+
+// Start implements manager.Runnable
+func (e *costEstimator) Start(stop <-chan struct{}) error {
+	wait.Until(e.estimate, e.interval, stop)
+	return nil
+}
+
+func (e *costEstimator) estimate() {
+	ctx := context.Background()
+	ingressList := &extensions.IngressList{}
+	if err := e.reader.List(ctx, &client.ListOptions{}, ingressList); err != nil {
+		glog.Errorf("failed to list ingresses for cost estimation due to %v", err)
+		return
+	}
+
+	estimated := make(map[types.NamespacedName]bool)
+	for i := range ingressList.Items {
+		ingress := &ingressList.Items[i]
+		if !class.IsValidIngress(e.ingressClass, ingress) {
+			continue
+		}
+		ingressKey := types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}
+		ok, err := e.estimateIngress(ctx, ingressKey)
+		if err != nil {
+			glog.Errorf("failed to estimate cost of ingress %v due to %v", ingressKey, err)
+			// keep the last estimate on transient failures.
+			if e.estimated[ingressKey] {
+				estimated[ingressKey] = true
+			}
+			continue
+		}
+		if ok {
+			estimated[ingressKey] = true
+		}
+	}
+	for ingressKey := range e.estimated {
+		if !estimated[ingressKey] {
+			e.mc.RemoveLoadBalancerCost(ingressKey.Namespace, ingressKey.Name)
+		}
+	}
+	e.estimated = estimated
+}
+
+// estimateIngress sets the cost metrics for the LoadBalancer of ingress, and returns whether the LoadBalancer exists.
+func (e *costEstimator) estimateIngress(ctx context.Context, ingressKey types.NamespacedName) (bool, error) {
+	lbName := e.nameGen.NameLB(ingressKey.Namespace, ingressKey.Name)
+	instance, err := e.cloud.GetLoadBalancerByName(ctx, lbName)
+	if err != nil {
+		return false, fmt.Errorf("failed to get LoadBalancer %v due to %v", lbName, err)
+	}
+	if instance == nil {
+		return false, nil
+	}
+	lcus, err := e.cloud.GetAverageConsumedLCUs(ctx, aws.StringValue(instance.LoadBalancerArn), lcuWindow)
+	if err != nil {
+		return false, fmt.Errorf("failed to get consumed LCUs of LoadBalancer %v due to %v", lbName, err)
+	}
+	monthlyCost := (e.lbHourlyPrice + lcus*e.lcuHourlyPrice) * hoursPerMonth
+	e.mc.SetLoadBalancerCost(ingressKey.Namespace, ingressKey.Name, lcus, monthlyCost)
+	return true, nil
+}
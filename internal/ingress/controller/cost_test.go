@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type lbNameGenerator struct{}
+
+func (lbNameGenerator) NameLB(namespace string, ingressName string) string {
+	return namespace + "-" + ingressName
+}
+
+type costCollector struct {
+	metric.DummyCollector
+	costs map[string]float64
+}
+
+func (c *costCollector) SetLoadBalancerCost(namespace string, ingress string, consumedLCUs float64, monthlyCost float64) {
+	c.costs[namespace+"/"+ingress] = monthlyCost
+}
+
+func (c *costCollector) RemoveLoadBalancerCost(namespace string, ingress string) {
+	delete(c.costs, namespace+"/"+ingress)
+}
+
+func TestCostEstimator_Estimate(t *testing.T) {
+	ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"}}
+	pending := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "pending"}}
+	client := fake.NewFakeClient(ingress, pending)
+	cloud := &mocks.CloudAPI{}
+	cloud.On("GetLoadBalancerByName", mock.Anything, "namespace-ingress").Return(&elbv2.LoadBalancer{LoadBalancerArn: aws.String("lbArn")}, nil)
+	cloud.On("GetLoadBalancerByName", mock.Anything, "namespace-pending").Return(nil, nil)
+	mc := &costCollector{costs: make(map[string]float64)}
+	estimator := newCostEstimator(client, cloud, lbNameGenerator{}, mc, "", 0, 0.0225, 0.008)
+
+	cloud.On("GetAverageConsumedLCUs", mock.Anything, "lbArn", lcuWindow).Return(2.5, nil).Once()
+	estimator.estimate()
+	assert.InDelta(t, (0.0225+2.5*0.008)*730, mc.costs["namespace/ingress"], 1e-9)
+	assert.NotContains(t, mc.costs, "namespace/pending")
+
+	// the last estimate is kept on failures.
+	cloud.On("GetAverageConsumedLCUs", mock.Anything, "lbArn", lcuWindow).Return(0.0, errors.New("throttled")).Once()
+	estimator.estimate()
+	assert.InDelta(t, (0.0225+2.5*0.008)*730, mc.costs["namespace/ingress"], 1e-9)
+
+	assert.NoError(t, client.Delete(context.Background(), ingress))
+	estimator.estimate()
+	assert.Empty(t, mc.costs)
+	cloud.AssertExpectations(t)
+}
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// forceSyncAnnotation forces a resync of an ingress bypassing cached AWS responses each time its value changes, e.g. to a timestamp.
+const forceSyncAnnotation = "force-sync"
+
+// ForceSync forces resyncs of ingresses bypassing cached AWS responses, so that changes made to AWS resources outside of
+// controller are reconciled without restarting it. Resyncs are requested with the force-sync annotation, or with an HTTP POST
+// to the handler, e.g. /sync?namespace=<namespace>&name=<name>.
+type ForceSync struct {
+	cloud aws.CloudAPI
+
+	mutex       sync.Mutex
+	reader      client.Reader
+	ingressChan chan<- event.GenericEvent
+	// requested contains the ingresses whose resync was requested via HTTP and hasn't started yet.
+	requested map[types.NamespacedName]bool
+	// tokens contains the last value of the force-sync annotation resynced by ingress.
+	tokens map[types.NamespacedName]string
+}
+
+// NewForceSync constructs ForceSync flushing the cached AWS responses of cloud, it serves requests once the controller is initialized.
+func NewForceSync(cloud aws.CloudAPI) *ForceSync {
+	return &ForceSync{
+		cloud:     cloud,
+		requested: make(map[types.NamespacedName]bool),
+		tokens:    make(map[types.NamespacedName]string),
+	}
+}
+
+// bind makes s read ingresses from reader, and enqueue the resyncs requested via HTTP into ingressChan.
+func (s *ForceSync) bind(reader client.Reader, ingressChan chan<- event.GenericEvent) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.reader = reader
+	s.ingressChan = ingressChan
+}
+
+// ServeHTTP requests a resync of the ingress identified by the namespace and name query parameters.
+func (s *ForceSync) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	ingressKey := types.NamespacedName{Namespace: r.URL.Query().Get("namespace"), Name: r.URL.Query().Get("name")}
+	if ingressKey.Namespace == "" || ingressKey.Name == "" {
+		http.Error(w, "namespace and name query parameters are required", http.StatusBadRequest)
+		return
+	}
+
+	s.mutex.Lock()
+	reader, ingressChan := s.reader, s.ingressChan
+	s.mutex.Unlock()
+	if ingressChan == nil {
+		http.Error(w, "controller is not initialized yet", http.StatusServiceUnavailable)
+		return
+	}
+	ingress := &extensions.Ingress{}
+	if err := reader.Get(r.Context(), ingressKey, ingress); err != nil {
+		if errors.IsNotFound(err) {
+			http.Error(w, fmt.Sprintf("ingress %v not found", ingressKey), http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("failed to get ingress %v due to %v", ingressKey, err), http.StatusInternalServerError)
+		return
+	}
+
+	s.mutex.Lock()
+	s.requested[ingressKey] = true
+	s.mutex.Unlock()
+	select {
+	case ingressChan <- event.GenericEvent{Meta: ingress, Object: ingress}:
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, "resync of ingress %v requested\n", ingressKey)
+	case <-r.Context().Done():
+	}
+}
+
+// prepare flushes the cached AWS responses if a resync of ingress was requested since its last reconcile, either via HTTP
+// or by changing its force-sync annotation. It returns whether cached AWS responses were flushed.
+func (s *ForceSync) prepare(ingressKey types.NamespacedName, ingress *extensions.Ingress) bool {
+	token := ""
+	if ingress != nil {
+		if v, err := parser.GetStringAnnotation(forceSyncAnnotation, ingress); err == nil {
+			token = aws.StringValue(v)
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	lastToken, synced := s.tokens[ingressKey]
+	forced := s.requested[ingressKey] || (token != "" && synced && token != lastToken)
+	delete(s.requested, ingressKey)
+	if ingress == nil {
+		delete(s.tokens, ingressKey)
+	} else {
+		s.tokens[ingressKey] = token
+	}
+	if forced {
+		s.cloud.FlushCache()
+	}
+	return forced
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func newForceSyncTestIngress(token string) *extensions.Ingress {
+	ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"}}
+	if token != "" {
+		ingress.Annotations = map[string]string{"alb.ingress.kubernetes.io/force-sync": token}
+	}
+	return ingress
+}
+
+func TestForceSync_prepare(t *testing.T) {
+	ingressKey := types.NamespacedName{Namespace: "namespace", Name: "ingress"}
+	cloud := &mocks.CloudAPI{}
+	cloud.On("FlushCache").Return()
+	s := NewForceSync(cloud)
+
+	// annotations seen first, e.g. after controller restarts, don't force resyncs.
+	assert.False(t, s.prepare(ingressKey, newForceSyncTestIngress("1")))
+	assert.False(t, s.prepare(ingressKey, newForceSyncTestIngress("1")))
+	assert.True(t, s.prepare(ingressKey, newForceSyncTestIngress("2")))
+	assert.False(t, s.prepare(ingressKey, newForceSyncTestIngress("2")))
+	assert.False(t, s.prepare(ingressKey, newForceSyncTestIngress("")))
+	assert.True(t, s.prepare(ingressKey, newForceSyncTestIngress("3")))
+
+	s.requested[ingressKey] = true
+	assert.True(t, s.prepare(ingressKey, newForceSyncTestIngress("3")))
+	assert.False(t, s.prepare(ingressKey, newForceSyncTestIngress("3")))
+
+	assert.False(t, s.prepare(ingressKey, nil))
+	assert.Empty(t, s.tokens)
+	cloud.AssertNumberOfCalls(t, "FlushCache", 3)
+}
+
+func TestForceSync_ServeHTTP(t *testing.T) {
+	ingress := newForceSyncTestIngress("")
+	s := NewForceSync(&mocks.CloudAPI{})
+
+	recorder := httptest.NewRecorder()
+	s.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/sync?namespace=namespace&name=ingress", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	ingressChan := make(chan event.GenericEvent, 1)
+	s.bind(fake.NewFakeClient(ingress), ingressChan)
+	for _, tc := range []struct {
+		name         string
+		method       string
+		target       string
+		expectedCode int
+	}{
+		{
+			name:         "GET",
+			method:       http.MethodGet,
+			target:       "/sync?namespace=namespace&name=ingress",
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:         "missing name",
+			method:       http.MethodPost,
+			target:       "/sync?namespace=namespace",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "unknown ingress",
+			method:       http.MethodPost,
+			target:       "/sync?namespace=namespace&name=unknown",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "ingress",
+			method:       http.MethodPost,
+			target:       "/sync?namespace=namespace&name=ingress",
+			expectedCode: http.StatusAccepted,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			s.ServeHTTP(recorder, httptest.NewRequest(tc.method, tc.target, nil))
+			assert.Equal(t, tc.expectedCode, recorder.Code)
+		})
+	}
+	enqueued := <-ingressChan
+	assert.Equal(t, "ingress", enqueued.Meta.GetName())
+	assert.Equal(t, map[types.NamespacedName]bool{{Namespace: "namespace", Name: "ingress"}: true}, s.requested)
+}
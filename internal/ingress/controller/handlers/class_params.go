@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ handler.EventHandler = (*EnqueueRequestsForClassParamsEvent)(nil)
+
+// EnqueueRequestsForClassParamsEvent enqueues the ingresses of IngressClass once the IngressClassParams named after it change.
+type EnqueueRequestsForClassParamsEvent struct {
+	IngressClass string
+
+	Cache cache.Cache
+}
+
+// Create is called in response to an create event - e.g. Pod Creation.
+func (h *EnqueueRequestsForClassParamsEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.Meta, queue)
+}
+
+// Update is called in response to an update event -  e.g. Pod Updated.
+func (h *EnqueueRequestsForClassParamsEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.MetaNew, queue)
+}
+
+// Delete is called in response to a delete event - e.g. Pod Deleted.
+func (h *EnqueueRequestsForClassParamsEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.Meta, queue)
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile Autoscaling, or a Webhook.
+func (h *EnqueueRequestsForClassParamsEvent) Generic(event.GenericEvent, workqueue.RateLimitingInterface) {
+}
+
+func (h *EnqueueRequestsForClassParamsEvent) enqueueImpactedIngresses(params v1.Object, queue workqueue.RateLimitingInterface) {
+	if params == nil || params.GetName() != class.Name(h.IngressClass) {
+		return
+	}
+
+	ingressList := &extensions.IngressList{}
+	if err := h.Cache.List(context.Background(), nil, ingressList); err != nil {
+		glog.Errorf("failed to fetch impacted ingresses by IngressClassParams due to %v", err)
+		return
+	}
+
+	for _, ingress := range ingressList.Items {
+		if !class.IsValidIngress(h.IngressClass, &ingress) {
+			continue
+		}
+		queue.Add(reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: ingress.Namespace,
+				Name:      ingress.Name,
+			},
+		})
+	}
+}
@@ -48,10 +48,10 @@ func (h *EnqueueRequestsForEndpointsEvent) Delete(e event.DeleteEvent, queue wor
 func (h *EnqueueRequestsForEndpointsEvent) Generic(event.GenericEvent, workqueue.RateLimitingInterface) {
 }
 
-//TODO: this can be further optimized to only included ingresses referenced this endpoints(service) :D
+// enqueueImpactedIngresses enqueues ingresses using the service of endpoints as backend.
 func (h *EnqueueRequestsForEndpointsEvent) enqueueImpactedIngresses(endpoints *corev1.Endpoints, queue workqueue.RateLimitingInterface) {
 	ingressList := &extensions.IngressList{}
-	if err := h.Cache.List(context.Background(), client.InNamespace(endpoints.Namespace), ingressList); err != nil {
+	if err := h.Cache.List(context.Background(), client.MatchingField(FieldBackendService, types.NamespacedName{Namespace: endpoints.Namespace, Name: endpoints.Name}.String()), ingressList); err != nil {
 		glog.Errorf("failed to fetch impacted ingresses by endpoints due to %v", err)
 		return
 	}
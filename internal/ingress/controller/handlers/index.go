@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// FieldBackendService indexes ingresses by the "namespace/name" keys of the services they use as backends.
+const FieldBackendService = "backendService"
+
+// IndexBackendServices returns the keys of services used as backends by ingress obj, for the FieldBackendService index.
+func IndexBackendServices(obj runtime.Object) []string {
+	ingress := obj.(*extensions.Ingress)
+	var backends []extensions.IngressBackend
+	if ingress.Spec.Backend != nil {
+		backends = append(backends, *ingress.Spec.Backend)
+	}
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			backends = append(backends, path.Backend)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var keys []string
+	for _, backend := range backends {
+		key := types.NamespacedName{Namespace: ingress.Namespace, Name: backend.ServiceName}.String()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// FieldTLSSecret indexes ingresses by the "namespace/name" keys of the TLS secrets referenced by their spec.tls.
+const FieldTLSSecret = "tlsSecret"
+
+// IndexTLSSecrets returns the keys of TLS secrets referenced by ingress obj, for the FieldTLSSecret index.
+func IndexTLSSecrets(obj runtime.Object) []string {
+	ingress := obj.(*extensions.Ingress)
+	seen := make(map[string]bool)
+	var keys []string
+	for _, tls := range ingress.Spec.TLS {
+		if tls.SecretName == "" {
+			continue
+		}
+		key := types.NamespacedName{Namespace: ingress.Namespace, Name: tls.SecretName}.String()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	return keys
+}
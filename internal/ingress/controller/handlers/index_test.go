@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestIndexBackendServices(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		spec     extensions.IngressSpec
+		expected []string
+	}{
+		{
+			name:     "no backends",
+			spec:     extensions.IngressSpec{},
+			expected: nil,
+		},
+		{
+			name: "default backend and rules",
+			spec: extensions.IngressSpec{
+				Backend: &extensions.IngressBackend{ServiceName: "default", ServicePort: intstr.FromInt(80)},
+				Rules: []extensions.IngressRule{
+					{
+						IngressRuleValue: extensions.IngressRuleValue{
+							HTTP: &extensions.HTTPIngressRuleValue{
+								Paths: []extensions.HTTPIngressPath{
+									{Path: "/a", Backend: extensions.IngressBackend{ServiceName: "svc-a", ServicePort: intstr.FromInt(80)}},
+									{Path: "/b", Backend: extensions.IngressBackend{ServiceName: "svc-a", ServicePort: intstr.FromInt(443)}},
+								},
+							},
+						},
+					},
+					{
+						Host: "example.com",
+					},
+				},
+			},
+			expected: []string{"ns/default", "ns/svc-a"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ingress := &extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ingress"},
+				Spec:       tc.spec,
+			}
+			assert.Equal(t, tc.expected, IndexBackendServices(ingress))
+		})
+	}
+}
+
+func TestIndexTLSSecrets(t *testing.T) {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ingress"},
+		Spec: extensions.IngressSpec{
+			TLS: []extensions.IngressTLS{
+				{Hosts: []string{"a.example.com"}, SecretName: "secret-a"},
+				{Hosts: []string{"b.example.com"}},
+				{Hosts: []string{"c.example.com"}, SecretName: "secret-a"},
+				{Hosts: []string{"d.example.com"}, SecretName: "secret-d"},
+			},
+		},
+	}
+	assert.Equal(t, []string{"ns/secret-a", "ns/secret-d"}, IndexTLSSecrets(ingress))
+}
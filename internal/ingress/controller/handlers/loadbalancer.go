@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/standalone"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ handler.EventHandler = (*EnqueueRequestsForLoadBalancerEvent)(nil)
+
+// EnqueueRequestsForLoadBalancerEvent enqueues LoadBalancers of IngressClass.
+type EnqueueRequestsForLoadBalancerEvent struct {
+	IngressClass string
+}
+
+// Create is called in response to an create event - e.g. Pod Creation.
+func (h *EnqueueRequestsForLoadBalancerEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueIfIngressClassMatched(e.Object.(*standalone.LoadBalancer), queue)
+}
+
+// Update is called in response to an update event -  e.g. Pod Updated.
+func (h *EnqueueRequestsForLoadBalancerEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueIfIngressClassMatched(e.ObjectOld.(*standalone.LoadBalancer), queue)
+	h.enqueueIfIngressClassMatched(e.ObjectNew.(*standalone.LoadBalancer), queue)
+}
+
+// Delete is called in response to a delete event - e.g. Pod Deleted.
+func (h *EnqueueRequestsForLoadBalancerEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueIfIngressClassMatched(e.Object.(*standalone.LoadBalancer), queue)
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile Autoscaling, or a Webhook.
+func (h *EnqueueRequestsForLoadBalancerEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueIfIngressClassMatched(e.Object.(*standalone.LoadBalancer), queue)
+}
+
+func (h *EnqueueRequestsForLoadBalancerEvent) enqueueIfIngressClassMatched(lb *standalone.LoadBalancer, queue workqueue.RateLimitingInterface) {
+	if !class.IsValidIngress(h.IngressClass, standalone.Ingress(lb)) {
+		return
+	}
+	queue.Add(reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: lb.Namespace,
+			Name:      lb.Name,
+		},
+	})
+}
+
+var _ handler.EventHandler = (*EnqueueRequestsForLoadBalancerBackendEvent)(nil)
+
+// EnqueueRequestsForLoadBalancerBackendEvent enqueues LoadBalancers of IngressClass using a service as backend,
+// on events of the service or its endpoints.
+type EnqueueRequestsForLoadBalancerBackendEvent struct {
+	IngressClass string
+
+	Cache cache.Cache
+}
+
+// Create is called in response to an create event - e.g. Pod Creation.
+func (h *EnqueueRequestsForLoadBalancerBackendEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedLoadBalancers(e.Meta, queue)
+}
+
+// Update is called in response to an update event -  e.g. Pod Updated.
+func (h *EnqueueRequestsForLoadBalancerBackendEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedLoadBalancers(e.MetaNew, queue)
+}
+
+// Delete is called in response to a delete event - e.g. Pod Deleted.
+func (h *EnqueueRequestsForLoadBalancerBackendEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedLoadBalancers(e.Meta, queue)
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile Autoscaling, or a Webhook.
+func (h *EnqueueRequestsForLoadBalancerBackendEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedLoadBalancers(e.Meta, queue)
+}
+
+// enqueueImpactedLoadBalancers enqueues LoadBalancers using the service named after obj as backend.
+func (h *EnqueueRequestsForLoadBalancerBackendEvent) enqueueImpactedLoadBalancers(obj v1.Object, queue workqueue.RateLimitingInterface) {
+	lbList := &standalone.LoadBalancerList{}
+	if err := h.Cache.List(context.Background(), client.InNamespace(obj.GetNamespace()), lbList); err != nil {
+		glog.Errorf("failed to fetch impacted LoadBalancers by service due to %v", err)
+		return
+	}
+	serviceKey := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}.String()
+	for _, lb := range lbList.Items {
+		ingress := standalone.Ingress(&lb)
+		if !class.IsValidIngress(h.IngressClass, ingress) {
+			continue
+		}
+		for _, key := range IndexBackendServices(ingress) {
+			if key != serviceKey {
+				continue
+			}
+			queue.Add(reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: lb.Namespace,
+					Name:      lb.Name,
+				},
+			})
+			break
+		}
+	}
+}
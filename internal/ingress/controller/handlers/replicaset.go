@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ handler.EventHandler = (*EnqueueRequestsForReplicaSetEvent)(nil)
+
+// EnqueueRequestsForReplicaSetEvent enqueues ingresses using services that select the pods of a ReplicaSet,
+// so rollout slow start is enabled when a rollout starts and disabled when it finishes.
+type EnqueueRequestsForReplicaSetEvent struct {
+	IngressClass string
+
+	Cache cache.Cache
+}
+
+// Create is called in response to an create event - e.g. Pod Creation.
+func (h *EnqueueRequestsForReplicaSetEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.Object.(*appsv1.ReplicaSet), queue)
+}
+
+// Update is called in response to an update event -  e.g. Pod Updated.
+// Ingresses are only enqueued when the ReplicaSet becomes active or inactive, i.e. its replicas change from or to zero.
+func (h *EnqueueRequestsForReplicaSetEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	rsOld := e.ObjectOld.(*appsv1.ReplicaSet)
+	rsNew := e.ObjectNew.(*appsv1.ReplicaSet)
+	if (rsOld.Status.Replicas == 0) != (rsNew.Status.Replicas == 0) {
+		h.enqueueImpactedIngresses(rsNew, queue)
+	}
+}
+
+// Delete is called in response to a delete event - e.g. Pod Deleted.
+func (h *EnqueueRequestsForReplicaSetEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.Object.(*appsv1.ReplicaSet), queue)
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile Autoscaling, or a Webhook.
+func (h *EnqueueRequestsForReplicaSetEvent) Generic(event.GenericEvent, workqueue.RateLimitingInterface) {
+}
+
+// enqueueImpactedIngresses enqueues ingresses using a service whose selector matches the pods of rs as backend.
+func (h *EnqueueRequestsForReplicaSetEvent) enqueueImpactedIngresses(rs *appsv1.ReplicaSet, queue workqueue.RateLimitingInterface) {
+	serviceList := &corev1.ServiceList{}
+	if err := h.Cache.List(context.Background(), client.InNamespace(rs.Namespace), serviceList); err != nil {
+		glog.Errorf("failed to fetch services impacted by replicaSet due to %v", err)
+		return
+	}
+	for _, service := range serviceList.Items {
+		if len(service.Spec.Selector) == 0 || !labels.SelectorFromSet(service.Spec.Selector).Matches(labels.Set(rs.Spec.Template.Labels)) {
+			continue
+		}
+		ingressList := &extensions.IngressList{}
+		if err := h.Cache.List(context.Background(), client.MatchingField(FieldBackendService, types.NamespacedName{Namespace: service.Namespace, Name: service.Name}.String()), ingressList); err != nil {
+			glog.Errorf("failed to fetch impacted ingresses by replicaSet due to %v", err)
+			return
+		}
+		for _, ingress := range ingressList.Items {
+			if !class.IsValidIngress(h.IngressClass, &ingress) {
+				continue
+			}
+			queue.Add(reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: ingress.Namespace,
+					Name:      ingress.Name,
+				},
+			})
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ handler.EventHandler = (*EnqueueRequestsForTLSSecretEvent)(nil)
+
+type EnqueueRequestsForTLSSecretEvent struct {
+	IngressClass string
+
+	Cache cache.Cache
+}
+
+// Create is called in response to an create event - e.g. Pod Creation.
+func (h *EnqueueRequestsForTLSSecretEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.Object.(*corev1.Secret), queue)
+}
+
+// Update is called in response to an update event -  e.g. Pod Updated.
+// Ingresses are only enqueued when data of secret changed, e.g. certificate being renewed.
+func (h *EnqueueRequestsForTLSSecretEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	secretOld := e.ObjectOld.(*corev1.Secret)
+	secretNew := e.ObjectNew.(*corev1.Secret)
+	if !reflect.DeepEqual(secretOld.Data, secretNew.Data) {
+		h.enqueueImpactedIngresses(secretNew, queue)
+	}
+}
+
+// Delete is called in response to a delete event - e.g. Pod Deleted.
+func (h *EnqueueRequestsForTLSSecretEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.Object.(*corev1.Secret), queue)
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile Autoscaling, or a Webhook.
+func (h *EnqueueRequestsForTLSSecretEvent) Generic(e event.GenericEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.Object.(*corev1.Secret), queue)
+}
+
+// enqueueImpactedIngresses enqueues ingresses referencing the secret in spec.tls.
+func (h *EnqueueRequestsForTLSSecretEvent) enqueueImpactedIngresses(secret *corev1.Secret, queue workqueue.RateLimitingInterface) {
+	ingressList := &extensions.IngressList{}
+	if err := h.Cache.List(context.Background(), client.MatchingField(FieldTLSSecret, types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}.String()), ingressList); err != nil {
+		glog.Errorf("failed to fetch impacted ingresses by TLS secret due to %v", err)
+		return
+	}
+	for _, ingress := range ingressList.Items {
+		if !class.IsValidIngress(h.IngressClass, &ingress) {
+			continue
+		}
+		queue.Add(reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: ingress.Namespace,
+				Name:      ingress.Name,
+			},
+		})
+	}
+}
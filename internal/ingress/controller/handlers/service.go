@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"reflect"
 
 	"github.com/golang/glog"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
@@ -30,9 +31,13 @@ func (h *EnqueueRequestsForServiceEvent) Create(e event.CreateEvent, queue workq
 }
 
 // Update is called in response to an update event -  e.g. Pod Updated.
+// Ingresses are only enqueued when spec or annotations of service changed, e.g. NodePorts being reallocated.
 func (h *EnqueueRequestsForServiceEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
-	h.enqueueImpactedIngresses(e.ObjectOld.(*corev1.Service), queue)
-	h.enqueueImpactedIngresses(e.ObjectNew.(*corev1.Service), queue)
+	svcOld := e.ObjectOld.(*corev1.Service)
+	svcNew := e.ObjectNew.(*corev1.Service)
+	if !reflect.DeepEqual(svcOld.Spec, svcNew.Spec) || !reflect.DeepEqual(svcOld.Annotations, svcNew.Annotations) {
+		h.enqueueImpactedIngresses(svcNew, queue)
+	}
 }
 
 // Delete is called in response to a delete event - e.g. Pod Deleted.
@@ -46,10 +51,10 @@ func (h *EnqueueRequestsForServiceEvent) Generic(e event.GenericEvent, queue wor
 	h.enqueueImpactedIngresses(e.Object.(*corev1.Service), queue)
 }
 
-//TODO: this can be further optimized to only included ingresses referenced this service :D
+// enqueueImpactedIngresses enqueues ingresses using the service as backend.
 func (h *EnqueueRequestsForServiceEvent) enqueueImpactedIngresses(service *corev1.Service, queue workqueue.RateLimitingInterface) {
 	ingressList := &extensions.IngressList{}
-	if err := h.Cache.List(context.Background(), client.InNamespace(service.Namespace), ingressList); err != nil {
+	if err := h.Cache.List(context.Background(), client.MatchingField(FieldBackendService, types.NamespacedName{Namespace: service.Namespace, Name: service.Name}.String()), ingressList); err != nil {
 		glog.Errorf("failed to fetch impacted ingresses by service due to %v", err)
 		return
 	}
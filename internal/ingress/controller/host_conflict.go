@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// hostClaim is a host and path of the rules of an ingress.
+type hostClaim struct {
+	ingress *extensions.Ingress
+	path    string
+}
+
+// hostConflictDetector finds ingresses claiming hosts already claimed by other ingresses of the cluster. Since each ingress
+// gets its own LoadBalancer, such hosts are served by several LoadBalancers, and DNS decides which one serves requests.
+// The oldest ingress claiming a host owns it, ordered by creation timestamp then namespace/name, so that the conflict is
+// reported on the newer ingresses only.
+type hostConflictDetector struct {
+	reader       client.Reader
+	ingressClass string
+}
+
+func newHostConflictDetector(reader client.Reader, ingressClass string) *hostConflictDetector {
+	return &hostConflictDetector{
+		reader:       reader,
+		ingressClass: ingressClass,
+	}
+}
+
+// conflicts returns the conflicts of the hosts claimed by ingress with older ingresses, sorted by host.
+func (d *hostConflictDetector) conflicts(ctx context.Context, ingress *extensions.Ingress) ([]string, error) {
+	claimedHosts := ingressHosts(ingress)
+	if len(claimedHosts) == 0 {
+		return nil, nil
+	}
+	ingressList := &extensions.IngressList{}
+	if err := d.reader.List(ctx, &client.ListOptions{}, ingressList); err != nil {
+		return nil, fmt.Errorf("failed to list ingresses for host conflicts due to %v", err)
+	}
+	claims := d.indexClaims(ingressList.Items, claimedHosts)
+
+	ingressKey := types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}
+	var conflicts []string
+	for host := range claimedHosts {
+		var owner *extensions.Ingress
+		var paths []string
+		for _, claim := range claims[host] {
+			claimant := claim.ingress
+			if (types.NamespacedName{Namespace: claimant.Namespace, Name: claimant.Name}) == ingressKey || !olderIngress(claimant, ingress) {
+				continue
+			}
+			if owner == nil || olderIngress(claimant, owner) {
+				owner = claimant
+				paths = nil
+			}
+			if claimant == owner {
+				paths = append(paths, claim.path)
+			}
+		}
+		if owner != nil {
+			conflicts = append(conflicts, fmt.Sprintf("host %v is already claimed by ingress %v/%v for paths %v",
+				host, owner.Namespace, owner.Name, strings.Join(paths, ",")))
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts, nil
+}
+
+// indexClaims returns the claims of ingresses on hosts by host.
+func (d *hostConflictDetector) indexClaims(ingresses []extensions.Ingress, hosts map[string]bool) map[string][]hostClaim {
+	claims := make(map[string][]hostClaim)
+	for i := range ingresses {
+		item := &ingresses[i]
+		if !class.IsValidIngress(d.ingressClass, item) || item.DeletionTimestamp != nil {
+			continue
+		}
+		for _, rule := range item.Spec.Rules {
+			if !hosts[rule.Host] || rule.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.HTTP.Paths {
+				p := path.Path
+				if p == "" {
+					p = "/*"
+				}
+				claims[rule.Host] = append(claims[rule.Host], hostClaim{ingress: item, path: p})
+			}
+		}
+	}
+	return claims
+}
+
+// ingressHosts returns the hosts of the rules of ingress, rules without host match any host and claim none.
+func ingressHosts(ingress *extensions.Ingress) map[string]bool {
+	hosts := make(map[string]bool)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host != "" {
+			hosts[rule.Host] = true
+		}
+	}
+	return hosts
+}
+
+// olderIngress returns whether a was created before b, ingresses created at the same time are ordered by namespace/name.
+func olderIngress(a *extensions.Ingress, b *extensions.Ingress) bool {
+	if !a.CreationTimestamp.Time.Equal(b.CreationTimestamp.Time) {
+		return a.CreationTimestamp.Time.Before(b.CreationTimestamp.Time)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
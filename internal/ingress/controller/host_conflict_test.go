@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newHostConflictTestIngress(namespace string, name string, created time.Time, hostPaths map[string][]string) *extensions.Ingress {
+	ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Namespace:         namespace,
+		Name:              name,
+		CreationTimestamp: metav1.NewTime(created),
+	}}
+	for host, paths := range hostPaths {
+		rule := extensions.IngressRule{Host: host, IngressRuleValue: extensions.IngressRuleValue{HTTP: &extensions.HTTPIngressRuleValue{}}}
+		for _, path := range paths {
+			rule.HTTP.Paths = append(rule.HTTP.Paths, extensions.HTTPIngressPath{Path: path})
+		}
+		ingress.Spec.Rules = append(ingress.Spec.Rules, rule)
+	}
+	return ingress
+}
+
+func TestHostConflictDetector_Conflicts(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	oldest := newHostConflictTestIngress("team-a", "oldest", now, map[string][]string{
+		"a.example.com": {"/api/*", "/web/*"},
+	})
+	older := newHostConflictTestIngress("team-b", "older", now.Add(time.Minute), map[string][]string{
+		"a.example.com": {"/"},
+		"b.example.com": {""},
+	})
+	newest := newHostConflictTestIngress("team-c", "newest", now.Add(2*time.Minute), map[string][]string{
+		"a.example.com": {"/api/*"},
+		"b.example.com": {"/*"},
+		"c.example.com": {"/*"},
+	})
+	catchAll := newHostConflictTestIngress("team-c", "catch-all", now.Add(3*time.Minute), map[string][]string{
+		"": {"/*"},
+	})
+	detector := newHostConflictDetector(fake.NewFakeClient(oldest, older, newest, catchAll), "")
+
+	conflicts, err := detector.conflicts(ctx, oldest)
+	assert.NoError(t, err)
+	assert.Empty(t, conflicts)
+
+	conflicts, err = detector.conflicts(ctx, older)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"host a.example.com is already claimed by ingress team-a/oldest for paths /api/*,/web/*",
+	}, conflicts)
+
+	// hosts are owned by the oldest ingress claiming them.
+	conflicts, err = detector.conflicts(ctx, newest)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"host a.example.com is already claimed by ingress team-a/oldest for paths /api/*,/web/*",
+		"host b.example.com is already claimed by ingress team-b/older for paths /*",
+	}, conflicts)
+
+	// rules without host claim none.
+	conflicts, err = detector.conflicts(ctx, catchAll)
+	assert.NoError(t, err)
+	assert.Empty(t, conflicts)
+}
+
+func Test_olderIngress(t *testing.T) {
+	now := time.Now()
+	a := newHostConflictTestIngress("ns", "a", now, nil)
+	b := newHostConflictTestIngress("ns", "b", now, nil)
+	c := newHostConflictTestIngress("ns", "a", now.Add(-time.Second), nil)
+	assert.True(t, olderIngress(a, b))
+	assert.False(t, olderIngress(b, a))
+	assert.True(t, olderIngress(c, b))
+	assert.False(t, olderIngress(a, a))
+}
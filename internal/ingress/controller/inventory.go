@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// inventoryRefresher periodically refreshes the inventory of AWS resources of the cluster, so that reconciles see the
+// resources created or deleted outside of controller without describing them.
+type inventoryRefresher struct {
+	cloud    aws.CloudAPI
+	interval time.Duration
+}
+
+func newInventoryRefresher(cloud aws.CloudAPI, interval time.Duration) *inventoryRefresher {
+	return &inventoryRefresher{
+		cloud:    cloud,
+		interval: interval,
+	}
+}
+
+// Start implements manager.Runnable
+func (r *inventoryRefresher) Start(stop <-chan struct{}) error {
+	wait.Until(r.refresh, r.interval, stop)
+	return nil
+}
+
+func (r *inventoryRefresher) refresh() {
+	ctx := albctx.SetLogger(context.Background(), log.New("inventory"))
+	if err := r.cloud.RefreshInventory(ctx); err != nil {
+		glog.Errorf("failed to refresh inventory due to %v", err)
+	}
+}
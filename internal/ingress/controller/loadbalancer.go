@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/classparams"
+	ingerrors "github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/standalone"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// LoadBalancerReconciler reconciles standalone LoadBalancers through the ingresses built from them,
+// with the AWS controllers of the ingress Reconciler.
+// Ingress specific features, i.e. model snapshots, smoke tests, rule schedules and LoadBalancer quotas don't apply to LoadBalancers.
+type LoadBalancerReconciler struct {
+	reconciler   *Reconciler
+	ingressClass string
+}
+
+// Reconcile will reconcile the aws resources with k8s state of LoadBalancer.
+func (r *LoadBalancerReconciler) Reconcile(request reconcile.Request) (result reconcile.Result, err error) {
+	ingressKey := standalone.IngressKey(request.NamespacedName)
+	defer r.reconciler.diagnostics.ReconcileStarted()()
+	defer r.reconciler.recoverPanic(reconcile.Request{NamespacedName: ingressKey}, &result, &err)
+	if r.reconciler.waitingForInformers(ingressKey) {
+		return reconcile.Result{RequeueAfter: informerSyncRequeueDelay}, nil
+	}
+	ctx := context.Background()
+	loadBalancer := &standalone.LoadBalancer{}
+	if err := r.reconciler.cache.Get(ctx, request.NamespacedName, loadBalancer); err != nil {
+		if !errors.IsNotFound(err) {
+			r.reconciler.metricCollector.IncReconcileErrorCount(ingressKey.String())
+			return reconcile.Result{}, err
+		}
+		if err := r.reconciler.apiReader.Get(ctx, request.NamespacedName, loadBalancer); err == nil {
+			log.New(ingressKey.String()).Warnf("LoadBalancer is missing from cache but still exists in API server, skipping deletion")
+			r.reconciler.recorder.Eventf(loadBalancer, corev1.EventTypeWarning, "STALE_CACHE", "Skipping deletion of LoadBalancer missing from cache but still existing in API server")
+			return reconcile.Result{RequeueAfter: staleCacheRequeueDelay}, nil
+		} else if !errors.IsNotFound(err) {
+			r.reconciler.metricCollector.IncReconcileErrorCount(ingressKey.String())
+			return reconcile.Result{}, fmt.Errorf("failed to confirm deletion of LoadBalancer with API server due to %v", err)
+		}
+
+		requeueAfter, err := r.reconciler.deleteIngress(ctx, ingressKey)
+		if err != nil {
+			r.reconciler.metricCollector.IncReconcileErrorCount(ingressKey.String())
+			return reconcile.Result{}, err
+		}
+		r.reconciler.metricCollector.IncReconcileCount()
+		r.reconciler.metricCollector.RemoveSync(ingressKey.Namespace, ingressKey.Name)
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	ingress := standalone.Ingress(loadBalancer)
+	if !class.IsValidIngress(r.ingressClass, ingress) {
+		return reconcile.Result{}, nil
+	}
+	requeueAfter, err := r.reconcileLoadBalancer(ctx, ingressKey, loadBalancer, ingress)
+	if err != nil {
+		r.reconciler.metricCollector.IncReconcileErrorCount(ingressKey.String())
+		r.reconciler.metricCollector.ObserveSync(ingressKey.Namespace, ingressKey.Name, false)
+		return reconcile.Result{}, err
+	}
+
+	r.reconciler.metricCollector.IncReconcileCount()
+	r.reconciler.metricCollector.ObserveSync(ingressKey.Namespace, ingressKey.Name, true)
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcileLoadBalancer reconciles the AWS resources for ingress built from loadBalancer, and returns the duration after which
+// loadBalancer needs another reconcile, or zero if not needed.
+func (r *LoadBalancerReconciler) reconcileLoadBalancer(ctx context.Context, ingressKey types.NamespacedName, loadBalancer *standalone.LoadBalancer, ingress *extensions.Ingress) (time.Duration, error) {
+	ingress, err := r.reconciler.restrictAnnotations(ingress, loadBalancer)
+	if err != nil {
+		return 0, err
+	}
+	classDefaults, err := r.reconciler.store.GetIngressClassDefaults()
+	if err != nil {
+		return 0, err
+	}
+	ingress = classparams.Apply(ingress, classDefaults)
+	ctx = r.reconciler.buildReconcileContext(ctx, ingressKey, ingress)
+	// the ingress doesn't exist in the cluster, so its events are emitted on loadBalancer.
+	ctx = albctx.SetEventf(ctx, func(eventType string, reason string, messageFmt string, args ...interface{}) {
+		r.reconciler.recorder.Eventf(loadBalancer, eventType, reason, messageFmt, args...)
+	})
+	waitRecorder := &albctx.WaitRecorder{}
+	ctx = albctx.SetWaitRecorder(ctx, waitRecorder)
+	if _, err := r.reconciler.store.GetIngressAnnotations(ingressKey.String()); err != nil {
+		if invalid, ok := err.(ingerrors.InvalidAnnotations); ok {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "INVALID_ANNOTATIONS", "%v invalid annotations: %v", len(invalid), invalid)
+		}
+		return 0, err
+	}
+
+	lbInfo, err := r.reconciler.lbController.Reconcile(ctx, ingress)
+	if err != nil {
+		return 0, err
+	}
+	if err := r.updateLoadBalancerStatus(ctx, loadBalancer, lbInfo); err != nil {
+		return 0, err
+	}
+	return r.reconciler.waitForTransitions(ctx, ingressKey, waitRecorder)
+}
+
+func (r *LoadBalancerReconciler) updateLoadBalancerStatus(ctx context.Context, loadBalancer *standalone.LoadBalancer, lbInfo *lb.LoadBalancer) error {
+	if len(loadBalancer.Status.LoadBalancer.Ingress) != 1 ||
+		loadBalancer.Status.LoadBalancer.Ingress[0].IP != "" ||
+		loadBalancer.Status.LoadBalancer.Ingress[0].Hostname != lbInfo.DNSName {
+		loadBalancer.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{
+			{
+				Hostname: lbInfo.DNSName,
+			},
+		}
+		return r.reconciler.client.Status().Update(ctx, loadBalancer)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// quotaTracker enforces the maximum numbers of LoadBalancers in the cluster and per namespace, and exposes their usage as metrics.
+// Ingresses are admitted once given a LoadBalancer, which they keep until they're deleted, so only ingresses without one are rejected.
+type quotaTracker struct {
+	reader           client.Reader
+	mc               metric.Collector
+	ingressClass     string
+	maxLoadBalancers int
+	maxPerNamespace  int
+	namespaceQuotas  map[string]int
+
+	mutex sync.Mutex
+	// admitted contains the ingresses admitted since controller started, whose status may not have a LoadBalancer yet.
+	admitted map[types.NamespacedName]bool
+	// namespaces contains the namespaces with quota metrics.
+	namespaces map[string]bool
+}
+
+func newQuotaTracker(reader client.Reader, mc metric.Collector, cfg *config.Configuration) *quotaTracker {
+	return &quotaTracker{
+		reader:           reader,
+		mc:               mc,
+		ingressClass:     cfg.IngressClass,
+		maxLoadBalancers: cfg.MaxLoadBalancers,
+		maxPerNamespace:  cfg.MaxLoadBalancersPerNamespace,
+		namespaceQuotas:  cfg.NamespaceLoadBalancerQuotas,
+		admitted:         make(map[types.NamespacedName]bool),
+		namespaces:       make(map[string]bool),
+	}
+}
+
+// enabled returns whether any quota is configured.
+func (t *quotaTracker) enabled() bool {
+	if t.maxLoadBalancers > 0 || t.maxPerNamespace > 0 {
+		return true
+	}
+	for _, quota := range t.namespaceQuotas {
+		if quota > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceQuota returns the maximum number of LoadBalancers of namespace, zero if unlimited.
+func (t *quotaTracker) namespaceQuota(namespace string) int {
+	if quota, ok := t.namespaceQuotas[namespace]; ok {
+		return quota
+	}
+	return t.maxPerNamespace
+}
+
+// admit returns the reason ingress is rejected if it doesn't have a LoadBalancer yet, and creating one would exceed the quota
+// of the cluster or of its namespace. It returns an empty reason if ingress is admitted.
+func (t *quotaTracker) admit(ctx context.Context, ingress *extensions.Ingress) (string, error) {
+	if !t.enabled() {
+		return "", nil
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	ingressList := &extensions.IngressList{}
+	if err := t.reader.List(ctx, &client.ListOptions{}, ingressList); err != nil {
+		return "", fmt.Errorf("failed to list ingresses for LoadBalancer quotas due to %v", err)
+	}
+	ingressKey := types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}
+	existing := make(map[types.NamespacedName]bool)
+	usedByNamespace := make(map[string]int)
+	used := 0
+	for i := range ingressList.Items {
+		item := &ingressList.Items[i]
+		if !class.IsValidIngress(t.ingressClass, item) {
+			continue
+		}
+		key := types.NamespacedName{Namespace: item.Namespace, Name: item.Name}
+		existing[key] = true
+		if key == ingressKey {
+			// the cache may lag behind the ingress being reconciled.
+			item = ingress
+		}
+		if t.admitted[key] || hasLoadBalancer(item) {
+			usedByNamespace[item.Namespace]++
+			used++
+		}
+	}
+	for key := range t.admitted {
+		if !existing[key] {
+			delete(t.admitted, key)
+		}
+	}
+
+	var reason string
+	if !t.admitted[ingressKey] && !hasLoadBalancer(ingress) {
+		namespaceQuota := t.namespaceQuota(ingress.Namespace)
+		if t.maxLoadBalancers > 0 && used >= t.maxLoadBalancers {
+			reason = fmt.Sprintf("cluster already has %v of its %v LoadBalancers", used, t.maxLoadBalancers)
+		} else if namespaceQuota > 0 && usedByNamespace[ingress.Namespace] >= namespaceQuota {
+			reason = fmt.Sprintf("namespace %v already has %v of its %v LoadBalancers", ingress.Namespace, usedByNamespace[ingress.Namespace], namespaceQuota)
+		} else {
+			t.admitted[ingressKey] = true
+			usedByNamespace[ingress.Namespace]++
+			used++
+		}
+	}
+	t.recordUsage(used, usedByNamespace)
+	return reason, nil
+}
+
+// recordUsage sets the quota metrics of the cluster and namespaces.
+func (t *quotaTracker) recordUsage(used int, usedByNamespace map[string]int) {
+	t.mc.SetLoadBalancerQuota("", used, t.maxLoadBalancers)
+	for namespace := range t.namespaces {
+		if _, ok := usedByNamespace[namespace]; !ok {
+			t.mc.SetLoadBalancerQuota(namespace, 0, t.namespaceQuota(namespace))
+		}
+	}
+	for namespace, namespaceUsed := range usedByNamespace {
+		t.namespaces[namespace] = true
+		t.mc.SetLoadBalancerQuota(namespace, namespaceUsed, t.namespaceQuota(namespace))
+	}
+}
+
+// hasLoadBalancer returns whether the status of ingress has a LoadBalancer.
+func hasLoadBalancer(ingress *extensions.Ingress) bool {
+	for _, lbIngress := range ingress.Status.LoadBalancer.Ingress {
+		if lbIngress.Hostname != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type quotaCollector struct {
+	metric.DummyCollector
+	used  map[string]int
+	limit map[string]int
+}
+
+func (c *quotaCollector) SetLoadBalancerQuota(namespace string, used int, limit int) {
+	c.used[namespace] = used
+	c.limit[namespace] = limit
+}
+
+func newQuotaTestIngress(namespace string, name string, hostname string) *extensions.Ingress {
+	ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	if hostname != "" {
+		ingress.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: hostname}}
+	}
+	return ingress
+}
+
+func TestQuotaTracker_Admit(t *testing.T) {
+	ctx := context.Background()
+	existing := newQuotaTestIngress("team-a", "existing", "existing.elb.amazonaws.com")
+	first := newQuotaTestIngress("team-a", "first", "")
+	second := newQuotaTestIngress("team-a", "second", "")
+	other := newQuotaTestIngress("team-b", "other", "")
+	client := fake.NewFakeClient(existing, first, second, other)
+	mc := &quotaCollector{used: make(map[string]int), limit: make(map[string]int)}
+	tracker := newQuotaTracker(client, mc, &config.Configuration{
+		MaxLoadBalancers:             4,
+		MaxLoadBalancersPerNamespace: 2,
+		NamespaceLoadBalancerQuotas:  map[string]int{"team-b": 0},
+	})
+
+	for _, ingress := range []*extensions.Ingress{existing, first, other} {
+		reason, err := tracker.admit(ctx, ingress)
+		assert.NoError(t, err)
+		assert.Empty(t, reason)
+	}
+	assert.Equal(t, map[string]int{"": 3, "team-a": 2, "team-b": 1}, mc.used)
+	assert.Equal(t, map[string]int{"": 4, "team-a": 2, "team-b": 0}, mc.limit)
+
+	// ingresses already admitted are never rejected.
+	reason, err := tracker.admit(ctx, first)
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+
+	reason, err = tracker.admit(ctx, second)
+	assert.NoError(t, err)
+	assert.Equal(t, "namespace team-a already has 2 of its 2 LoadBalancers", reason)
+
+	// deleted ingresses release their LoadBalancer.
+	assert.NoError(t, client.Delete(ctx, first))
+	reason, err = tracker.admit(ctx, second)
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+	assert.Equal(t, map[string]int{"": 3, "team-a": 2, "team-b": 1}, mc.used)
+
+	tracker.maxLoadBalancers = 3
+	reason, err = tracker.admit(ctx, newQuotaTestIngress("team-b", "another", ""))
+	assert.NoError(t, err)
+	assert.Equal(t, "cluster already has 3 of its 3 LoadBalancers", reason)
+}
+
+func TestQuotaTracker_Admit_Disabled(t *testing.T) {
+	mc := &quotaCollector{used: make(map[string]int), limit: make(map[string]int)}
+	tracker := newQuotaTracker(fake.NewFakeClient(), mc, &config.Configuration{})
+	reason, err := tracker.admit(context.Background(), newQuotaTestIngress("namespace", "ingress", ""))
+	assert.NoError(t, err)
+	assert.Empty(t, reason)
+	assert.Empty(t, mc.used)
+}
@@ -2,38 +2,113 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/restriction"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/classparams"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/snapshot"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/diagnostics"
+	ingerrors "github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/smoketest"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/sets"
+	toolscache "k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const (
+	// confirmRemovalsAnnotation confirms removals of targets or rules beyond the removal-safety-threshold for an ingress.
+	confirmRemovalsAnnotation = "confirm-removals"
+
+	// smokeTestAnnotation enables requests against the LoadBalancer of an ingress after each reconcile.
+	smokeTestAnnotation = "smoke-test"
+
+	// reconcileIDLength is the length of the random ID identifying each reconcile.
+	reconcileIDLength = 8
+
+	// staleCacheRequeueDelay is the delay before reconciling again an ingress missing from the informer cache but not from the API server.
+	staleCacheRequeueDelay = 30 * time.Second
+
+	// informerSyncRequeueDelay is the delay before reconciling again an ingress while informers are still syncing.
+	informerSyncRequeueDelay = 5 * time.Second
+)
+
 // Reconciler reconciles an single ingress object
 type Reconciler struct {
 	client   client.Client
 	cache    cache.Cache
 	recorder record.EventRecorder
+	// apiReader reads from the API server directly, to confirm the deletion of ingresses missing from cache.
+	apiReader client.Reader
 
 	// TODO: move things out of store, and start to rely on functionality provided by client & cache
 	store store.Storer
+	// informersSynced returns whether the informers backing store are synced, reconciles against partially synced stores
+	// would deregister the targets missing from them.
+	informersSynced toolscache.InformerSynced
 
 	lbController lb.Controller
 
+	// snapshotStore persists the applied AWS model when ModelSnapshot feature is enabled.
+	snapshotStore snapshot.Store
+	// restoredIngresses contains ingresses whose snapshot has been examined since controller started.
+	restoredIngresses sets.String
+
 	metricCollector metric.Collector
+	diagnostics     *diagnostics.Diagnostics
+
+	// waitTracker computes requeue delays for ingresses waiting on AWS resources in transitional states.
+	waitTracker *waitTracker
+
+	// smokeTester requests LoadBalancers of ingresses with smoke-test enabled after reconcile.
+	smokeTester *smoketest.Tester
+
+	// quotaTracker rejects ingresses without LoadBalancer beyond the LoadBalancer quotas of the cluster and namespaces.
+	quotaTracker *quotaTracker
+	// hostConflicts finds ingresses claiming hosts already claimed by other ingresses.
+	hostConflicts *hostConflictDetector
+
+	// forceSync flushes cached AWS responses before reconciling ingresses whose resync is forced.
+	forceSync *ForceSync
+}
+
+// waitingForInformers returns whether the reconcile of ingressKey must wait for the informers backing store to sync.
+func (r *Reconciler) waitingForInformers(ingressKey types.NamespacedName) bool {
+	if r.informersSynced == nil || r.informersSynced() {
+		return false
+	}
+	log.New(ingressKey.String()).Infof("informers are still syncing, requeueing")
+	return true
 }
 
 // Reconcile will reconcile the aws resources with k8s state of ingress.
-func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+func (r *Reconciler) Reconcile(request reconcile.Request) (result reconcile.Result, err error) {
+	defer r.diagnostics.ReconcileStarted()()
+	defer r.recoverPanic(request, &result, &err)
+	if r.waitingForInformers(request.NamespacedName) {
+		return reconcile.Result{RequeueAfter: informerSyncRequeueDelay}, nil
+	}
 	ctx := context.Background()
 	ingress := &extensions.Ingress{}
 	if err := r.cache.Get(ctx, request.NamespacedName, ingress); err != nil {
@@ -41,44 +116,276 @@ func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, err
 			r.metricCollector.IncReconcileErrorCount(request.NamespacedName.String())
 			return reconcile.Result{}, err
 		}
+		// the informer cache can lag behind or miss events after watch errors, and deleting the LoadBalancer of an ingress that still exists is an outage.
+		if err := r.apiReader.Get(ctx, request.NamespacedName, ingress); err == nil {
+			log.New(request.NamespacedName.String()).Warnf("ingress is missing from cache but still exists in API server, skipping deletion")
+			r.recorder.Eventf(ingress, corev1.EventTypeWarning, "STALE_CACHE", "Skipping deletion of ingress missing from cache but still existing in API server")
+			return reconcile.Result{RequeueAfter: staleCacheRequeueDelay}, nil
+		} else if !errors.IsNotFound(err) {
+			r.metricCollector.IncReconcileErrorCount(request.NamespacedName.String())
+			return reconcile.Result{}, fmt.Errorf("failed to confirm deletion of ingress with API server due to %v", err)
+		}
 
-		if err := r.deleteIngress(ctx, request.NamespacedName); err != nil {
+		r.forceSync.prepare(request.NamespacedName, nil)
+		requeueAfter, err := r.deleteIngress(ctx, request.NamespacedName)
+		if err != nil {
 			r.metricCollector.IncReconcileErrorCount(request.NamespacedName.String())
 			return reconcile.Result{}, err
 		}
 
 		r.metricCollector.IncReconcileCount()
-		return reconcile.Result{}, nil
+		r.metricCollector.RemoveSync(request.Namespace, request.Name)
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
 	}
 
-	if err := r.reconcileIngress(ctx, request.NamespacedName, ingress); err != nil {
+	requeueAfter, err := r.reconcileIngress(ctx, request.NamespacedName, ingress)
+	if err != nil {
 		r.metricCollector.IncReconcileErrorCount(request.NamespacedName.String())
+		r.metricCollector.ObserveSync(request.Namespace, request.Name, false)
 		return reconcile.Result{}, err
 	}
 
 	r.metricCollector.IncReconcileCount()
-	return reconcile.Result{}, nil
+	r.metricCollector.ObserveSync(request.Namespace, request.Name, true)
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
 
-func (r *Reconciler) reconcileIngress(ctx context.Context, ingressKey types.NamespacedName, ingress *extensions.Ingress) error {
+// recoverPanic recovers from a panic during the reconcile of request, so that a single malformed ingress can't crash-loop the controller.
+// The panic is logged with its stack and counted, and the reconcile fails so that the ingress is requeued with backoff.
+func (r *Reconciler) recoverPanic(request reconcile.Request, result *reconcile.Result, err *error) {
+	p := recover()
+	if p == nil {
+		return
+	}
+	log.New(request.NamespacedName.String()).Errorf("reconcile panicked: %v\n%s", p, debug.Stack())
+	r.metricCollector.IncReconcilePanicCount(request.NamespacedName.String())
+	r.metricCollector.IncReconcileErrorCount(request.NamespacedName.String())
+	*result = reconcile.Result{}
+	*err = fmt.Errorf("reconcile panicked: %v", p)
+}
+
+// reconcileIngress reconciles the AWS resources for ingress, and returns the duration after which ingress needs another reconcile, or zero if not needed.
+func (r *Reconciler) reconcileIngress(ctx context.Context, ingressKey types.NamespacedName, ingress *extensions.Ingress) (time.Duration, error) {
+	// status is updated on the original ingress, while AWS resources are reconciled without denied annotations and with class defaults.
+	original := ingress
+	ingress, err := r.restrictAnnotations(ingress, original)
+	if err != nil {
+		return 0, err
+	}
+	classDefaults, err := r.store.GetIngressClassDefaults()
+	if err != nil {
+		return 0, err
+	}
+	ingress = classparams.Apply(ingress, classDefaults)
+	if err := r.checkQuota(ctx, original); err != nil {
+		return 0, err
+	}
+	if err := r.checkHostConflicts(ctx, original); err != nil {
+		return 0, err
+	}
 	ctx = r.buildReconcileContext(ctx, ingressKey, ingress)
+	forced := r.forceSync.prepare(ingressKey, original)
+	if forced {
+		albctx.GetLogger(ctx).Infof("forced resync, cached AWS responses flushed")
+	}
+	waitRecorder := &albctx.WaitRecorder{}
+	ctx = albctx.SetWaitRecorder(ctx, waitRecorder)
+	ingressAnnos, err := r.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
+	if err != nil {
+		if invalid, ok := err.(ingerrors.InvalidAnnotations); ok {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "INVALID_ANNOTATIONS", "%v invalid annotations: %v", len(invalid), invalid)
+		}
+		return 0, err
+	}
+
+	// rules of scheduled hosts may need changes even if ingress is unchanged since the snapshot, and forced resyncs must reach AWS.
+	snapshotEnabled := r.store.GetConfig().FeatureGate.Enabled(config.ModelSnapshot)
+	if snapshotEnabled && !forced && len(ingressAnnos.Schedule.GetSchedules()) == 0 {
+		if lbInfo, ok := r.restoreFromSnapshot(ctx, ingressKey, ingress); ok {
+			return 0, r.updateIngressStatus(ctx, original, lbInfo)
+		}
+	}
+
 	lbInfo, err := r.lbController.Reconcile(ctx, ingress)
 	if err != nil {
-		return err
+		return 0, err
+	}
+	if err := r.updateIngressStatus(ctx, original, lbInfo); err != nil {
+		return 0, err
+	}
+	if snapshotEnabled {
+		if err := r.snapshotStore.Save(ctx, ingress, lbInfo); err != nil {
+			albctx.GetLogger(ctx).Warnf("failed to save model snapshot due to %v", err)
+		}
+	}
+
+	waitDelay, err := r.waitForTransitions(ctx, ingressKey, waitRecorder)
+	if err != nil {
+		return 0, err
+	}
+	r.runSmokeTest(ctx, ingressKey, ingress, lbInfo, waitRecorder)
+	scheduleDelay := r.nextScheduledChange(ctx, ingressAnnos)
+	if waitDelay != 0 && (scheduleDelay == 0 || waitDelay < scheduleDelay) {
+		return waitDelay, nil
+	}
+	return scheduleDelay, nil
+}
+
+// restrictAnnotations returns a copy of ingress without the annotations denied for its namespace, and emits events about them on obj.
+// The original ingress is returned if nothing is denied. It fails if any denied annotation is rejected.
+func (r *Reconciler) restrictAnnotations(ingress *extensions.Ingress, obj runtime.Object) (*extensions.Ingress, error) {
+	allowed, violations := r.store.GetConfig().AnnotationRestrictions.Apply(ingress.Namespace, ingress.Annotations)
+	if len(violations) == 0 {
+		return ingress, nil
 	}
-	if err := r.updateIngressStatus(ctx, ingress, lbInfo); err != nil {
+	var rejected []string
+	for _, violation := range violations {
+		if violation.Action == restriction.ActionReject {
+			rejected = append(rejected, violation.String())
+			r.recorder.Eventf(obj, corev1.EventTypeWarning, "ANNOTATION_DENIED", "%v in namespace %v, ingress is rejected", violation, ingress.Namespace)
+		} else {
+			r.recorder.Eventf(obj, corev1.EventTypeWarning, "ANNOTATION_DENIED", "%v in namespace %v, annotation is ignored", violation, ingress.Namespace)
+		}
+	}
+	if len(rejected) != 0 {
+		return nil, fmt.Errorf("ingress rejected due to denied annotations: %v", strings.Join(rejected, ", "))
+	}
+	ingress = ingress.DeepCopy()
+	ingress.Annotations = allowed
+	return ingress, nil
+}
+
+// checkQuota emits an event and fails if ingress is rejected by LoadBalancer quotas.
+func (r *Reconciler) checkQuota(ctx context.Context, ingress *extensions.Ingress) error {
+	reason, err := r.quotaTracker.admit(ctx, ingress)
+	if err != nil {
 		return err
 	}
+	if reason != "" {
+		r.recorder.Eventf(ingress, corev1.EventTypeWarning, "QUOTA_EXCEEDED", "ingress is rejected since %v", reason)
+		return fmt.Errorf("ingress rejected due to LoadBalancer quota: %v", reason)
+	}
+	return nil
+}
 
+// checkHostConflicts emits events about the hosts of ingress already claimed by older ingresses, and fails if they're rejected
+// and ingress doesn't have a LoadBalancer yet.
+func (r *Reconciler) checkHostConflicts(ctx context.Context, ingress *extensions.Ingress) error {
+	if r.hostConflicts == nil {
+		return nil
+	}
+	policy := r.store.GetConfig().HostConflicts
+	if policy == "" || policy == config.HostConflictsIgnore {
+		return nil
+	}
+	conflicts, err := r.hostConflicts.conflicts(ctx, ingress)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	if policy == config.HostConflictsReject && !hasLoadBalancer(ingress) {
+		for _, conflict := range conflicts {
+			r.recorder.Eventf(ingress, corev1.EventTypeWarning, "HOST_CONFLICT", "ingress is rejected since %v", conflict)
+		}
+		return fmt.Errorf("ingress rejected due to host conflicts: %v", strings.Join(conflicts, "; "))
+	}
+	for _, conflict := range conflicts {
+		r.recorder.Eventf(ingress, corev1.EventTypeWarning, "HOST_CONFLICT", "%v, requests may be served by either LoadBalancer", conflict)
+	}
 	return nil
 }
 
-func (r *Reconciler) deleteIngress(ctx context.Context, ingressKey types.NamespacedName) error {
+// waitForTransitions emits events about AWS resources found in transitional states during reconcile,
+// and returns the back-off delay before ingress should be reconciled again, or zero if there are none.
+func (r *Reconciler) waitForTransitions(ctx context.Context, ingressKey types.NamespacedName, waitRecorder *albctx.WaitRecorder) (time.Duration, error) {
+	waits := waitRecorder.Waits()
+	delay, err := r.waitTracker.next(ingressKey, waits)
+	if err != nil {
+		return 0, err
+	}
+	for _, wait := range waits {
+		albctx.GetLogger(ctx).Infof("waiting for %v to leave %v state, requeue after %v", wait.Resource, wait.State, delay)
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "WAITING", "waiting for %v to leave %v state, requeue after %v", wait.Resource, wait.State, delay)
+	}
+	return delay, nil
+}
+
+// nextScheduledChange emits an event about the next change by rule-schedules of ingress, and returns the duration until it, or zero if there is none.
+func (r *Reconciler) nextScheduledChange(ctx context.Context, ingressAnnos *annotations.Ingress) time.Duration {
+	now := time.Now()
+	sch, changeTime, ok := ingressAnnos.Schedule.NextChange(now)
+	if !ok {
+		return 0
+	}
+	state := "disabled"
+	if sch.Enabled(now) {
+		state = "enabled"
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "SCHEDULE", "rules for host %v are %v until %v", sch.Host, state, changeTime.Format(time.RFC3339))
+	// reconcile right after the change, so the rules are evaluated in the new state.
+	return changeTime.Sub(now) + time.Second
+}
+
+// runSmokeTest requests the LoadBalancer for each host and path of ingress in background if smoke-test is enabled for ingress,
+// so that misconfigurations like securityGroups blocking traffic or rules without healthy targets are reported as events.
+func (r *Reconciler) runSmokeTest(ctx context.Context, ingressKey types.NamespacedName, ingress *extensions.Ingress, lbInfo *lb.LoadBalancer, waitRecorder *albctx.WaitRecorder) {
+	enabled, _ := parser.GetBoolAnnotation(smokeTestAnnotation, ingress)
+	if !aws.BoolValue(enabled) {
+		return
+	}
+	for _, wait := range waitRecorder.Waits() {
+		// the DNS name of LoadBalancer doesn't resolve until it's active.
+		if wait.State == albctx.WaitStateProvisioning {
+			return
+		}
+	}
+	r.smokeTester.Run(ingressKey.String(), smoketest.BuildProbes(ingress, lbInfo), albctx.GetEventf(ctx))
+}
+
+// restoreFromSnapshot returns the model in snapshot for the first sync of an ingress after controller starts,
+// if the ingress is unchanged since the snapshot was saved and the AWS resources in it still exist.
+// This avoids describing every AWS resource for every ingress on controller restart, while targets, listeners and rules
+// are still reconciled on the restored model, since endpoints, nodes or pods may have changed while controller was down.
+func (r *Reconciler) restoreFromSnapshot(ctx context.Context, ingressKey types.NamespacedName, ingress *extensions.Ingress) (*lb.LoadBalancer, bool) {
+	if r.restoredIngresses.Has(ingressKey.String()) {
+		return nil, false
+	}
+	r.restoredIngresses.Insert(ingressKey.String())
+
+	s, err := r.snapshotStore.Load(ctx, ingressKey)
+	if err != nil {
+		albctx.GetLogger(ctx).Warnf("failed to load model snapshot due to %v", err)
+		return nil, false
+	}
+	if s == nil {
+		return nil, false
+	}
+	checksum, err := snapshot.Checksum(ingress)
+	if err != nil || checksum != s.Checksum {
+		return nil, false
+	}
+	if err := r.lbController.Verify(ctx, s.Model); err != nil {
+		albctx.GetLogger(ctx).Infof("discarding model snapshot due to %v", err)
+		return nil, false
+	}
+	if err := r.lbController.ReconcileRestored(ctx, ingress, s.Model); err != nil {
+		albctx.GetLogger(ctx).Infof("discarding model snapshot due to %v", err)
+		return nil, false
+	}
+	albctx.GetLogger(ctx).Infof("restored model snapshot for LoadBalancer %v", s.Model.Arn)
+	return s.Model, true
+}
+
+func (r *Reconciler) deleteIngress(ctx context.Context, ingressKey types.NamespacedName) (time.Duration, error) {
 	ctx = r.buildReconcileContext(ctx, ingressKey, nil)
+	waitRecorder := &albctx.WaitRecorder{}
+	ctx = albctx.SetWaitRecorder(ctx, waitRecorder)
 	if err := r.lbController.Delete(ctx, ingressKey); err != nil {
-		return err
+		return 0, err
 	}
-	return nil
+	return r.waitForTransitions(ctx, ingressKey, waitRecorder)
 }
 
 func (r *Reconciler) updateIngressStatus(ctx context.Context, ingress *extensions.Ingress, lbInfo *lb.LoadBalancer) error {
@@ -97,10 +404,21 @@ func (r *Reconciler) updateIngressStatus(ctx context.Context, ingress *extension
 
 func (r *Reconciler) buildReconcileContext(ctx context.Context, ingressKey types.NamespacedName, ingress *extensions.Ingress) context.Context {
 	ctx = albctx.SetLogger(ctx, log.New(ingressKey.String()))
+	ctx = albctx.SetIngressKey(ctx, ingressKey)
+	// AWS calls carry the reconcile ID in their user agent, so they can be correlated with the reconcile in CloudTrail.
+	reconcileID := rand.String(reconcileIDLength)
+	ctx = albctx.SetReconcileID(ctx, reconcileID)
+	ctx = albctx.SetAuditBefores(ctx, &albctx.AuditBefores{})
+	albctx.GetLogger(ctx).Infof("reconciling with ID %v", reconcileID)
 	if ingress != nil {
 		ctx = albctx.SetEventf(ctx, func(eventType string, reason string, messageFmt string, args ...interface{}) {
 			r.recorder.Eventf(ingress, eventType, reason, messageFmt, args...)
 		})
+		confirmed, _ := parser.GetBoolAnnotation(confirmRemovalsAnnotation, ingress)
+		ctx = albctx.SetRemovalGuard(ctx, albctx.RemovalGuard{
+			ThresholdPercent: r.store.GetConfig().RemovalSafetyThreshold,
+			Confirmed:        aws.BoolValue(confirmed),
+		})
 	}
 	return ctx
 }
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/diagnostics"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks/controller-runtime/cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type panicCountingCollector struct {
+	metric.DummyCollector
+	panics map[string]int
+}
+
+func (c *panicCountingCollector) IncReconcilePanicCount(ingress string) {
+	c.panics[ingress]++
+}
+
+func TestReconciler_Reconcile_recoversPanic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ingressKey := types.NamespacedName{Namespace: "namespace", Name: "ingress"}
+	cache := mock_cache.NewMockCache(ctrl)
+	cache.EXPECT().Get(gomock.Any(), ingressKey, gomock.Any()).Do(func(context.Context, types.NamespacedName, runtime.Object) {
+		panic("malformed ingress")
+	})
+	mc := &panicCountingCollector{panics: make(map[string]int)}
+	diag := diagnostics.New(prometheus.NewRegistry())
+	r := &Reconciler{cache: cache, metricCollector: mc, diagnostics: diag}
+
+	result, err := r.Reconcile(reconcile.Request{NamespacedName: ingressKey})
+	assert.EqualError(t, err, "reconcile panicked: malformed ingress")
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.Equal(t, map[string]int{"namespace/ingress": 1}, mc.panics)
+	assert.True(t, diag.WaitIdle(0))
+}
+
+func TestReconciler_Reconcile_waitsForInformers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ingressKey := types.NamespacedName{Namespace: "namespace", Name: "ingress"}
+	cache := mock_cache.NewMockCache(ctrl)
+	diag := diagnostics.New(prometheus.NewRegistry())
+	r := &Reconciler{cache: cache, diagnostics: diag, informersSynced: func() bool { return false }}
+
+	result, err := r.Reconcile(reconcile.Request{NamespacedName: ingressKey})
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{RequeueAfter: informerSyncRequeueDelay}, result)
+	assert.True(t, diag.WaitIdle(0))
+}
@@ -0,0 +1,161 @@
+package snapshot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// configMapNamePrefix is the prefix of configMaps that holds the model for an ingress.
+	configMapNamePrefix = "alb-model-"
+
+	// modelDataKey is the key inside configMap data that holds the serialized model.
+	modelDataKey = "model.json"
+
+	// checksumDataKey is the key inside configMap data that holds the checksum of ingress the model is applied for.
+	checksumDataKey = "checksum"
+)
+
+// Snapshot is the AWS model applied for an ingress.
+type Snapshot struct {
+	// Checksum of the ingress when Model is applied, see Checksum.
+	Checksum string
+
+	Model *lb.LoadBalancer
+}
+
+// Store persists the AWS model last successfully applied for ingresses.
+type Store interface {
+	// Save persists the model applied for ingress.
+	Save(ctx context.Context, ingress *extensions.Ingress, model *lb.LoadBalancer) error
+
+	// Load returns the snapshot last persisted for ingress, or nil if there is none.
+	Load(ctx context.Context, ingressKey types.NamespacedName) (*Snapshot, error)
+}
+
+// NewStore constructs new Store that persists models as configMaps next to ingresses.
+func NewStore(client client.Client) Store {
+	return &configMapStore{
+		client: client,
+	}
+}
+
+type configMapStore struct {
+	client client.Client
+}
+
+var _ Store = (*configMapStore)(nil)
+
+func (s *configMapStore) Save(ctx context.Context, ingress *extensions.Ingress, model *lb.LoadBalancer) error {
+	payload, err := json.MarshalIndent(model, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize model due to %v", err)
+	}
+	checksum, err := Checksum(ingress)
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{}
+	configMapKey := ConfigMapKey(types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name})
+	if err := s.client.Get(ctx, configMapKey, configMap); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get configMap %v due to %v", configMapKey, err)
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: configMapKey.Namespace,
+				Name:      configMapKey.Name,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: extensions.SchemeGroupVersion.String(),
+						Kind:       "Ingress",
+						Name:       ingress.Name,
+						UID:        ingress.UID,
+					},
+				},
+			},
+			Data: map[string]string{
+				modelDataKey:    string(payload),
+				checksumDataKey: checksum,
+			},
+		}
+		if err := s.client.Create(ctx, configMap); err != nil {
+			return fmt.Errorf("failed to create configMap %v due to %v", configMapKey, err)
+		}
+		return nil
+	}
+
+	if configMap.Data[modelDataKey] == string(payload) && configMap.Data[checksumDataKey] == checksum {
+		return nil
+	}
+	configMap = configMap.DeepCopy()
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+	configMap.Data[modelDataKey] = string(payload)
+	configMap.Data[checksumDataKey] = checksum
+	if err := s.client.Update(ctx, configMap); err != nil {
+		return fmt.Errorf("failed to update configMap %v due to %v", configMapKey, err)
+	}
+	return nil
+}
+
+func (s *configMapStore) Load(ctx context.Context, ingressKey types.NamespacedName) (*Snapshot, error) {
+	configMap := &corev1.ConfigMap{}
+	configMapKey := ConfigMapKey(ingressKey)
+	if err := s.client.Get(ctx, configMapKey, configMap); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get configMap %v due to %v", configMapKey, err)
+	}
+
+	payload, ok := configMap.Data[modelDataKey]
+	if !ok {
+		return nil, nil
+	}
+	model := &lb.LoadBalancer{}
+	if err := json.Unmarshal([]byte(payload), model); err != nil {
+		return nil, fmt.Errorf("failed to deserialize model in configMap %v due to %v", configMapKey, err)
+	}
+	return &Snapshot{
+		Checksum: configMap.Data[checksumDataKey],
+		Model:    model,
+	}, nil
+}
+
+// Checksum computes a checksum over the spec and annotations of ingress, which changes whenever the ingress needs reconcile.
+func Checksum(ingress *extensions.Ingress) (string, error) {
+	payload, err := json.Marshal(struct {
+		Annotations map[string]string
+		Spec        extensions.IngressSpec
+	}{
+		Annotations: ingress.Annotations,
+		Spec:        ingress.Spec,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to compute checksum of ingress due to %v", err)
+	}
+	hash := sha256.Sum256(payload)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// ConfigMapKey returns the key of configMap that holds the model for ingress.
+func ConfigMapKey(ingressKey types.NamespacedName) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: ingressKey.Namespace,
+		Name:      configMapNamePrefix + ingressKey.Name,
+	}
+}
@@ -0,0 +1,84 @@
+package snapshot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfigMapStore(t *testing.T) {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "namespace",
+			Name:      "ingress",
+			UID:       "uid",
+		},
+	}
+	ingressKey := types.NamespacedName{Namespace: "namespace", Name: "ingress"}
+	model := &lb.LoadBalancer{
+		Arn:     "lbArn",
+		DNSName: "dnsName",
+		Name:    "lbName",
+		Subnets: []string{"subnet-1", "subnet-2"},
+		Listeners: []lb.Listener{
+			{Port: 80, Protocol: "HTTP"},
+		},
+		TargetGroups: []lb.TargetGroup{
+			{ServiceName: "service", ServicePort: "80", Arn: "tgArn", TargetType: "instance"},
+		},
+	}
+
+	client := fake.NewFakeClient()
+	store := NewStore(client)
+
+	loaded, err := store.Load(context.Background(), ingressKey)
+	assert.NoError(t, err)
+	assert.Nil(t, loaded)
+
+	assert.NoError(t, store.Save(context.Background(), ingress, model))
+	loaded, err = store.Load(context.Background(), ingressKey)
+	assert.NoError(t, err)
+	assert.Equal(t, model, loaded.Model)
+	checksum, err := Checksum(ingress)
+	assert.NoError(t, err)
+	assert.Equal(t, checksum, loaded.Checksum)
+
+	configMap := &corev1.ConfigMap{}
+	assert.NoError(t, client.Get(context.Background(), ConfigMapKey(ingressKey), configMap))
+	assert.Equal(t, types.UID("uid"), configMap.OwnerReferences[0].UID)
+
+	model.DNSName = "newDNSName"
+	assert.NoError(t, store.Save(context.Background(), ingress, model))
+	loaded, err = store.Load(context.Background(), ingressKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "newDNSName", loaded.Model.DNSName)
+}
+
+func TestChecksum(t *testing.T) {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "namespace",
+			Name:        "ingress",
+			Annotations: map[string]string{"alb.ingress.kubernetes.io/scheme": "internal"},
+		},
+	}
+	checksum, err := Checksum(ingress)
+	assert.NoError(t, err)
+
+	ingress.ResourceVersion = "2"
+	unchanged, err := Checksum(ingress)
+	assert.NoError(t, err)
+	assert.Equal(t, checksum, unchanged)
+
+	ingress.Annotations["alb.ingress.kubernetes.io/scheme"] = "internet-facing"
+	changed, err := Checksum(ingress)
+	assert.NoError(t, err)
+	assert.NotEqual(t, checksum, changed)
+}
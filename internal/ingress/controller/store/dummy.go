@@ -4,6 +4,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 )
@@ -19,6 +20,8 @@ type Dummy struct {
 	GetClusterInstanceIDsFunc func() ([]string, error)
 
 	GetServiceEndpointsFunc func(string) (*corev1.Endpoints, error)
+	GetPodFunc              func(string) (*corev1.Pod, error)
+	ListReplicaSetsFunc     func(string) []*appsv1.ReplicaSet
 }
 
 // GetConfigMap ...
@@ -36,6 +39,11 @@ func (d Dummy) GetServiceEndpoints(key string) (*corev1.Endpoints, error) {
 	return d.GetServiceEndpointsFunc(key)
 }
 
+// GetPod ...
+func (d Dummy) GetPod(key string) (*corev1.Pod, error) {
+	return d.GetPodFunc(key)
+}
+
 // GetServiceAnnotations ...
 func (d Dummy) GetServiceAnnotations(key string, ingress *annotations.Ingress) (*annotations.Service, error) {
 	return d.GetServiceAnnotationsResponse, nil
@@ -73,6 +81,11 @@ func (d Dummy) GetConfig() *config.Configuration {
 	return &config.Configuration{}
 }
 
+// GetIngressClassDefaults ...
+func (d Dummy) GetIngressClassDefaults() (map[string]string, error) {
+	return nil, nil
+}
+
 // SetConfig ...
 func (d *Dummy) SetConfig(c *config.Configuration) {
 	d.cfg = c
@@ -93,6 +106,11 @@ func (d *Dummy) GetClusterInstanceIDs() ([]string, error) {
 	return d.GetClusterInstanceIDsFunc()
 }
 
+// ListReplicaSets ...
+func (d Dummy) ListReplicaSets(namespace string) []*appsv1.ReplicaSet {
+	return d.ListReplicaSetsFunc(namespace)
+}
+
 func NewDummy() *Dummy {
 	return &Dummy{
 		GetServiceFunc:                func(_ string) (*corev1.Service, error) { return dummy.NewService(), nil },
@@ -100,6 +118,8 @@ func NewDummy() *Dummy {
 		GetNodeInstanceIDFunc:         func(*corev1.Node) (string, error) { return "", nil },
 		GetClusterInstanceIDsFunc:     func() ([]string, error) { return nil, nil },
 		GetServiceEndpointsFunc:       func(string) (*corev1.Endpoints, error) { return nil, nil },
+		GetPodFunc:                    func(string) (*corev1.Pod, error) { return nil, nil },
+		ListReplicaSetsFunc:           func(string) []*appsv1.ReplicaSet { return nil },
 		GetIngressAnnotationsResponse: annotations.NewIngressDummy(),
 		GetServiceAnnotationsResponse: annotations.NewServiceDummy(),
 	}
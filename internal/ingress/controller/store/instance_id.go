@@ -0,0 +1,97 @@
+package store
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// instanceIDPattern matches the IDs of EC2 instances, e.g. i-0123456789abcdef0 or i-01234567.
+var instanceIDPattern = regexp.MustCompile(`^i-[0-9a-f]{8}([0-9a-f]{9})?$`)
+
+// InstanceLookup finds the EC2 instances of nodes whose providerID doesn't identify them.
+type InstanceLookup interface {
+	// GetInstanceIDByPrivateDNSName returns the ID of the instance with privateDNSName in vpc, empty if there's none.
+	GetInstanceIDByPrivateDNSName(privateDNSName string) (string, error)
+}
+
+// nodeInstanceIDs resolves the instance IDs of nodes from their providerID, or by looking up their private DNS names with
+// instanceLookup. Looked up instance IDs are cached by node, until nodes are deleted.
+type nodeInstanceIDs struct {
+	instanceLookup InstanceLookup
+
+	mutex sync.Mutex
+	// lookedUp are the instance IDs looked up by the UID of their nodes.
+	lookedUp map[types.UID]string
+}
+
+func newNodeInstanceIDs(instanceLookup InstanceLookup) *nodeInstanceIDs {
+	return &nodeInstanceIDs{
+		instanceLookup: instanceLookup,
+		lookedUp:       make(map[types.UID]string),
+	}
+}
+
+// get returns the instance ID of node.
+func (ids *nodeInstanceIDs) get(node *corev1.Node) (string, error) {
+	if instanceID, ok := parseProviderID(node.Spec.ProviderID); ok {
+		return instanceID, nil
+	}
+	if ids == nil || ids.instanceLookup == nil {
+		if node.Spec.ProviderID == "" {
+			return "", fmt.Errorf("No providerID found for node %s", node.Name)
+		}
+		return "", fmt.Errorf("providerID %v of node %s doesn't identify an EC2 instance", node.Spec.ProviderID, node.Name)
+	}
+
+	ids.mutex.Lock()
+	defer ids.mutex.Unlock()
+	if instanceID, ok := ids.lookedUp[node.UID]; ok {
+		return instanceID, nil
+	}
+	for _, dnsName := range nodePrivateDNSNames(node) {
+		instanceID, err := ids.instanceLookup.GetInstanceIDByPrivateDNSName(dnsName)
+		if err != nil {
+			return "", fmt.Errorf("failed to find instance of node %s by private DNS name %v due to %v", node.Name, dnsName, err)
+		}
+		if instanceID != "" {
+			ids.lookedUp[node.UID] = instanceID
+			return instanceID, nil
+		}
+	}
+	return "", fmt.Errorf("no instance found for node %s, neither from providerID %q nor by private DNS name", node.Name, node.Spec.ProviderID)
+}
+
+// forget drops the instance ID looked up for node.
+func (ids *nodeInstanceIDs) forget(node *corev1.Node) {
+	ids.mutex.Lock()
+	defer ids.mutex.Unlock()
+	delete(ids.lookedUp, node.UID)
+}
+
+// parseProviderID returns the instance ID in providerID, and whether it has one. Besides the usual aws:///us-west-2a/i-0123456789abcdef0,
+// providerIDs without availability zone, with extra slashes, or bare instance IDs are accepted, while those of Fargate nodes aren't.
+func parseProviderID(providerID string) (string, bool) {
+	segments := strings.Split(providerID, "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if segments[i] != "" {
+			return segments[i], instanceIDPattern.MatchString(segments[i])
+		}
+	}
+	return "", false
+}
+
+// nodePrivateDNSNames returns the internal DNS names of node, followed by its name, which is usually its private DNS name on EC2.
+func nodePrivateDNSNames(node *corev1.Node) []string {
+	var names []string
+	for _, addr := range node.Status.Addresses {
+		if addr.Type == corev1.NodeInternalDNS && addr.Address != node.Name {
+			names = append(names, addr.Address)
+		}
+	}
+	return append(names, node.Name)
+}
@@ -0,0 +1,110 @@
+package store
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeInstanceLookup struct {
+	instanceIDs map[string]string
+	err         error
+	calls       []string
+}
+
+func (l *fakeInstanceLookup) GetInstanceIDByPrivateDNSName(privateDNSName string) (string, error) {
+	l.calls = append(l.calls, privateDNSName)
+	return l.instanceIDs[privateDNSName], l.err
+}
+
+func Test_parseProviderID(t *testing.T) {
+	for _, tc := range []struct {
+		providerID string
+		instanceID string
+		ok         bool
+	}{
+		{providerID: "aws:///us-west-2a/i-0123456789abcdef0", instanceID: "i-0123456789abcdef0", ok: true},
+		{providerID: "aws:///i-0123456789abcdef0", instanceID: "i-0123456789abcdef0", ok: true},
+		{providerID: "aws:////i-01234567", instanceID: "i-01234567", ok: true},
+		{providerID: "aws:///us-west-2a/i-01234567/", instanceID: "i-01234567", ok: true},
+		{providerID: "i-0123456789abcdef0", instanceID: "i-0123456789abcdef0", ok: true},
+		{providerID: "aws:///us-west-2a/fargate-ip-192-168-1-1.us-west-2.compute.internal", ok: false},
+		{providerID: "aws:///us-west-2a/i-0123", ok: false},
+		{providerID: "", ok: false},
+	} {
+		t.Run(tc.providerID, func(t *testing.T) {
+			instanceID, ok := parseProviderID(tc.providerID)
+			assert.Equal(t, tc.ok, ok)
+			if tc.ok {
+				assert.Equal(t, tc.instanceID, instanceID)
+			}
+		})
+	}
+}
+
+func TestNodeInstanceIDs_Get(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1", UID: "uid-1"},
+		Spec:       corev1.NodeSpec{ProviderID: "custom://node-1"},
+		Status: corev1.NodeStatus{Addresses: []corev1.NodeAddress{
+			{Type: corev1.NodeInternalIP, Address: "192.168.1.1"},
+			{Type: corev1.NodeInternalDNS, Address: "ip-192-168-1-1.us-west-2.compute.internal"},
+		}},
+	}
+
+	t.Run("providerID identifies instance", func(t *testing.T) {
+		lookup := &fakeInstanceLookup{}
+		ids := newNodeInstanceIDs(lookup)
+		instanceID, err := ids.get(&corev1.Node{Spec: corev1.NodeSpec{ProviderID: "aws:///us-west-2a/i-0123456789abcdef0"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "i-0123456789abcdef0", instanceID)
+		assert.Empty(t, lookup.calls)
+	})
+
+	t.Run("looked up by private DNS name then cached", func(t *testing.T) {
+		lookup := &fakeInstanceLookup{instanceIDs: map[string]string{"ip-192-168-1-1.us-west-2.compute.internal": "i-0123456789abcdef0"}}
+		ids := newNodeInstanceIDs(lookup)
+		instanceID, err := ids.get(node)
+		assert.NoError(t, err)
+		assert.Equal(t, "i-0123456789abcdef0", instanceID)
+		instanceID, err = ids.get(node)
+		assert.NoError(t, err)
+		assert.Equal(t, "i-0123456789abcdef0", instanceID)
+		assert.Equal(t, []string{"ip-192-168-1-1.us-west-2.compute.internal"}, lookup.calls)
+
+		ids.forget(node)
+		_, err = ids.get(node)
+		assert.NoError(t, err)
+		assert.Len(t, lookup.calls, 2)
+	})
+
+	t.Run("looked up by node name", func(t *testing.T) {
+		lookup := &fakeInstanceLookup{instanceIDs: map[string]string{"node-1": "i-01234567"}}
+		ids := newNodeInstanceIDs(lookup)
+		instanceID, err := ids.get(node)
+		assert.NoError(t, err)
+		assert.Equal(t, "i-01234567", instanceID)
+		assert.Equal(t, []string{"ip-192-168-1-1.us-west-2.compute.internal", "node-1"}, lookup.calls)
+	})
+
+	t.Run("no instance found", func(t *testing.T) {
+		ids := newNodeInstanceIDs(&fakeInstanceLookup{})
+		_, err := ids.get(node)
+		assert.EqualError(t, err, `no instance found for node node-1, neither from providerID "custom://node-1" nor by private DNS name`)
+	})
+
+	t.Run("lookup fails", func(t *testing.T) {
+		ids := newNodeInstanceIDs(&fakeInstanceLookup{err: errors.New("throttled")})
+		_, err := ids.get(node)
+		assert.EqualError(t, err, "failed to find instance of node node-1 by private DNS name ip-192-168-1-1.us-west-2.compute.internal due to throttled")
+	})
+
+	t.Run("without lookup", func(t *testing.T) {
+		ids := newNodeInstanceIDs(nil)
+		_, err := ids.get(node)
+		assert.EqualError(t, err, "providerID custom://node-1 of node node-1 doesn't identify an EC2 instance")
+	})
+}
@@ -5,6 +5,7 @@ package store
 import annotations "github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 import config "github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 import mock "github.com/stretchr/testify/mock"
+import appsv1 "k8s.io/api/apps/v1"
 import v1 "k8s.io/api/core/v1"
 
 // MockStorer is an autogenerated mock type for the Storer type
@@ -74,6 +75,29 @@ func (_m *MockStorer) GetIngressAnnotations(key string) (*annotations.Ingress, e
 	return r0, r1
 }
 
+// GetIngressClassDefaults provides a mock function with given fields:
+func (_m *MockStorer) GetIngressClassDefaults() (map[string]string, error) {
+	ret := _m.Called()
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func() map[string]string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetInstanceIDFromPodIP provides a mock function with given fields: _a0
 func (_m *MockStorer) GetInstanceIDFromPodIP(_a0 string) (string, error) {
 	ret := _m.Called(_a0)
@@ -116,6 +140,29 @@ func (_m *MockStorer) GetNodeInstanceID(node *v1.Node) (string, error) {
 	return r0, r1
 }
 
+// GetPod provides a mock function with given fields: key
+func (_m *MockStorer) GetPod(key string) (*v1.Pod, error) {
+	ret := _m.Called(key)
+
+	var r0 *v1.Pod
+	if rf, ok := ret.Get(0).(func(string) *v1.Pod); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Pod)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetService provides a mock function with given fields: key
 func (_m *MockStorer) GetService(key string) (*v1.Service, error) {
 	ret := _m.Called(key)
@@ -200,3 +247,19 @@ func (_m *MockStorer) ListNodes() []*v1.Node {
 
 	return r0
 }
+
+// ListReplicaSets provides a mock function with given fields: namespace
+func (_m *MockStorer) ListReplicaSets(namespace string) []*appsv1.ReplicaSet {
+	ret := _m.Called(namespace)
+
+	var r0 []*appsv1.ReplicaSet
+	if rf, ok := ret.Get(0).(func(string) []*appsv1.ReplicaSet); ok {
+		r0 = rf(namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*appsv1.ReplicaSet)
+		}
+	}
+
+	return r0
+}
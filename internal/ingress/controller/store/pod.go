@@ -17,6 +17,7 @@ limitations under the License.
 package store
 
 import (
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -24,3 +25,15 @@ import (
 type PodLister struct {
 	cache.Store
 }
+
+// ByKey returns the Pod matching key in the local Pod Store.
+func (pl *PodLister) ByKey(key string) (*apiv1.Pod, error) {
+	p, exists, err := pl.GetByKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, NotExistsError(key)
+	}
+	return p.(*apiv1.Pod), nil
+}
@@ -0,0 +1,23 @@
+package store
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ReplicaSetLister makes a Store that lists ReplicaSets.
+type ReplicaSetLister struct {
+	cache.Store
+}
+
+// ByNamespace returns the ReplicaSets in namespace from the local ReplicaSet Store.
+func (rl *ReplicaSetLister) ByNamespace(namespace string) []*appsv1.ReplicaSet {
+	var replicaSets []*appsv1.ReplicaSet
+	for _, item := range rl.List() {
+		rs := item.(*appsv1.ReplicaSet)
+		if rs.Namespace == namespace {
+			replicaSets = append(replicaSets, rs)
+		}
+	}
+	return replicaSets
+}
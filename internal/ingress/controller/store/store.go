@@ -19,18 +19,24 @@ package store
 import (
 	"fmt"
 	"reflect"
-	"strings"
 	"sync"
 
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/blang/semver"
 	"github.com/golang/glog"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/classparams"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/standalone"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -45,6 +51,9 @@ type Storer interface {
 	// GetServiceEndpoints returns the Endpoints of a Service matching key.
 	GetServiceEndpoints(key string) (*corev1.Endpoints, error)
 
+	// GetPod returns the Pod matching key.
+	GetPod(key string) (*corev1.Pod, error)
+
 	// GetServiceAnnotations returns the parsed annotations of an Service matching key. if ingress is non-nil, merges ingress annotations into the service.
 	GetServiceAnnotations(key string, ingress *annotations.Ingress) (*annotations.Service, error)
 
@@ -57,6 +66,10 @@ type Storer interface {
 	// GetConfig returns the controller configuration
 	GetConfig() *config.Configuration
 
+	// GetIngressClassDefaults returns the annotations defaulted by the IngressClassParams of the watched ingress class,
+	// or nil if IngressClassParams are disabled or missing.
+	GetIngressClassDefaults() (map[string]string, error)
+
 	// GetInstanceIDFromPodIP gets the instance id of the node running a pod
 	GetInstanceIDFromPodIP(string) (string, error)
 
@@ -65,15 +78,34 @@ type Storer interface {
 
 	// GetClusterInstanceIDs gets id of all instances inside cluster
 	GetClusterInstanceIDs() ([]string, error)
+
+	// ListReplicaSets returns a list of all ReplicaSets in namespace.
+	ListReplicaSets(namespace string) []*appsv1.ReplicaSet
 }
 
 // Informer defines the required SharedIndexInformers that interact with the API server.
 type Informer struct {
-	Ingress  cache.SharedIndexInformer
-	Service  cache.SharedIndexInformer
-	Endpoint cache.SharedIndexInformer
-	Node     cache.SharedIndexInformer
-	Pod      cache.SharedIndexInformer
+	Ingress    cache.SharedIndexInformer
+	Service    cache.SharedIndexInformer
+	Endpoint   cache.SharedIndexInformer
+	Node       cache.SharedIndexInformer
+	Pod        cache.SharedIndexInformer
+	ReplicaSet cache.SharedIndexInformer
+	// ClassParams is nil unless the IngressClassParams feature is enabled.
+	ClassParams cache.SharedIndexInformer
+	// LoadBalancer is nil unless the LoadBalancerCRD feature is enabled.
+	LoadBalancer cache.SharedIndexInformer
+}
+
+// HasSynced returns true once the stores of all enabled informers are synced. The cache of manager only waits for the informers
+// it manages, the Endpoints and Pods informers restricted by label selector are run separately and may still be syncing.
+func (i *Informer) HasSynced() bool {
+	for _, informer := range []cache.SharedIndexInformer{i.Ingress, i.Service, i.Endpoint, i.Node, i.Pod, i.ReplicaSet, i.ClassParams, i.LoadBalancer} {
+		if informer != nil && !informer.HasSynced() {
+			return false
+		}
+	}
+	return true
 }
 
 // Lister contains object listers (stores).
@@ -83,6 +115,7 @@ type Lister struct {
 	Endpoint          EndpointLister
 	Node              NodeLister
 	Pod               PodLister
+	ReplicaSet        ReplicaSetLister
 	IngressAnnotation IngressAnnotationsLister
 	ServiceAnnotation ServiceAnnotationsLister
 }
@@ -111,54 +144,128 @@ type k8sStore struct {
 
 	// mu protects against simultaneous invocations of syncSecret
 	mu *sync.Mutex
-}
-
-// New creates a new object store to be used in the ingress controller
-func New(mgr manager.Manager, cfg *config.Configuration) (Storer, error) {
-	store := &k8sStore{
-		informers: &Informer{},
-		listers:   &Lister{},
-		cfg:       cfg,
-		mu:        &sync.Mutex{},
-	}
 
-	// k8sStore fulfils resolver.Resolver interface
-	store.ingannotations = annotations.NewIngressAnnotationExtractor(store)
-	store.svcannotations = annotations.NewServiceAnnotationExtractor(store)
-	store.listers.IngressAnnotation.Store = cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
-	store.listers.ServiceAnnotation.Store = cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+	// instanceIDs resolves the instance IDs of nodes.
+	instanceIDs *nodeInstanceIDs
+}
 
+// NewInformers creates the informers used by store. The Pod and Endpoints informers are restricted by label selectors of cfg,
+// and are not created when targetGroups are restricted to instance targets, which don't use them.
+func NewInformers(mgr manager.Manager, cfg *config.Configuration) (*Informer, error) {
+	informers := &Informer{}
 	mgrCache := mgr.GetCache()
 	var err error
-	store.informers.Ingress, err = mgrCache.GetInformer(&extensions.Ingress{})
+	informers.Ingress, err = mgrCache.GetInformer(&extensions.Ingress{})
 	if err != nil {
 		return nil, err
 	}
-	store.listers.Ingress.Store = store.informers.Ingress.GetStore()
-
-	store.informers.Service, err = mgrCache.GetInformer(&corev1.Service{})
+	informers.Service, err = mgrCache.GetInformer(&corev1.Service{})
 	if err != nil {
 		return nil, err
 	}
-	store.listers.Service.Store = store.informers.Service.GetStore()
-
-	store.informers.Endpoint, err = mgrCache.GetInformer(&corev1.Endpoints{})
+	informers.Node, err = mgrCache.GetInformer(&corev1.Node{})
 	if err != nil {
 		return nil, err
 	}
-	store.listers.Endpoint.Store = store.informers.Endpoint.GetStore()
+	informers.ReplicaSet, err = mgrCache.GetInformer(&appsv1.ReplicaSet{})
+	if err != nil {
+		return nil, err
+	}
+	if cfg.FeatureGate.Enabled(config.IngressClassParams) {
+		if err := classparams.AddToScheme(mgr.GetScheme()); err != nil {
+			return nil, err
+		}
+		informers.ClassParams, err = mgrCache.GetInformer(&classparams.IngressClassParams{})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.FeatureGate.Enabled(config.LoadBalancerCRD) {
+		if err := standalone.AddToScheme(mgr.GetScheme()); err != nil {
+			return nil, err
+		}
+		informers.LoadBalancer, err = mgrCache.GetInformer(&standalone.LoadBalancer{})
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	store.informers.Node, err = mgrCache.GetInformer(&corev1.Node{})
+	if cfg.RestrictTargetType && cfg.DefaultTargetType == elbv2.TargetTypeEnumInstance {
+		glog.Infof("Pod and Endpoints informers are disabled since targetGroups are restricted to %v targets", cfg.DefaultTargetType)
+		return informers, nil
+	}
+	informers.Endpoint, err = newInformer(mgr, &corev1.Endpoints{}, "endpoints", cfg.EndpointsLabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	informers.Pod, err = newInformer(mgr, &corev1.Pod{}, "pods", cfg.PodLabelSelector)
 	if err != nil {
 		return nil, err
 	}
-	store.listers.Node.Store = store.informers.Node.GetStore()
+	return informers, nil
+}
 
-	store.informers.Pod, err = mgrCache.GetInformer(&corev1.Pod{})
+// newInformer returns the informer for core resource of obj. The shared informer from cache of mgr is used without labelSelector,
+// otherwise a dedicated informer that only watches objects matching labelSelector is created and run by mgr.
+func newInformer(mgr manager.Manager, obj runtime.Object, resource string, labelSelector string) (cache.SharedIndexInformer, error) {
+	if labelSelector == "" {
+		return mgr.GetCache().GetInformer(obj)
+	}
+	clientSet, err := kubernetes.NewForConfig(mgr.GetConfig())
 	if err != nil {
 		return nil, err
 	}
-	store.listers.Pod.Store = store.informers.Pod.GetStore()
+	lw := cache.NewFilteredListWatchFromClient(clientSet.CoreV1().RESTClient(), resource, metav1.NamespaceAll, func(options *metav1.ListOptions) {
+		options.LabelSelector = labelSelector
+	})
+	informer := cache.NewSharedIndexInformer(lw, obj, 0, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := mgr.Add(informerRunnable{informer}); err != nil {
+		return nil, err
+	}
+	return informer, nil
+}
+
+// informerRunnable runs an informer that isn't managed by cache of manager.
+type informerRunnable struct {
+	cache.SharedIndexInformer
+}
+
+// Start runs the informer until stop is closed.
+func (r informerRunnable) Start(stop <-chan struct{}) error {
+	r.Run(stop)
+	return nil
+}
+
+// New creates a new object store to be used in the ingress controller
+// The instances of nodes whose providerID doesn't identify them are looked up with instanceLookup.
+func New(informers *Informer, cfg *config.Configuration, instanceLookup InstanceLookup) (Storer, error) {
+	store := &k8sStore{
+		informers:   informers,
+		listers:     &Lister{},
+		cfg:         cfg,
+		mu:          &sync.Mutex{},
+		instanceIDs: newNodeInstanceIDs(instanceLookup),
+	}
+
+	// k8sStore fulfils resolver.Resolver interface
+	store.ingannotations = annotations.NewIngressAnnotationExtractor(store)
+	store.svcannotations = annotations.NewServiceAnnotationExtractor(store)
+	store.listers.IngressAnnotation.Store = cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+	store.listers.ServiceAnnotation.Store = cache.NewStore(cache.DeletionHandlingMetaNamespaceKeyFunc)
+
+	store.listers.Ingress.Store = informers.Ingress.GetStore()
+	store.listers.Service.Store = informers.Service.GetStore()
+	store.listers.Node.Store = informers.Node.GetStore()
+	store.listers.ReplicaSet.Store = informers.ReplicaSet.GetStore()
+	// disabled informers are substituted by empty stores.
+	store.listers.Endpoint.Store = cache.NewStore(cache.MetaNamespaceKeyFunc)
+	if informers.Endpoint != nil {
+		store.listers.Endpoint.Store = informers.Endpoint.GetStore()
+	}
+	store.listers.Pod.Store = cache.NewStore(cache.MetaNamespaceKeyFunc)
+	if informers.Pod != nil {
+		store.listers.Pod.Store = informers.Pod.GetStore()
+	}
 
 	ingEventHandler := cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
@@ -214,18 +321,116 @@ func New(mgr manager.Manager, cfg *config.Configuration) (Storer, error) {
 		},
 	}
 
-	store.informers.Ingress.AddEventHandler(ingEventHandler)
-	store.informers.Service.AddEventHandler(svcEventHandler)
+	informers.Ingress.AddEventHandler(ingEventHandler)
+	informers.Service.AddEventHandler(svcEventHandler)
+	// instance IDs looked up for nodes are forgotten along with them.
+	informers.Node.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			node, ok := obj.(*corev1.Node)
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					glog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				node, ok = tombstone.Obj.(*corev1.Node)
+				if !ok {
+					glog.Errorf("Tombstone contained object that is not a Node: %#v", obj)
+					return
+				}
+			}
+			store.instanceIDs.forget(node)
+		},
+	})
+	// ingress annotations are extracted again once the defaults of their ingress class change.
+	if informers.ClassParams != nil {
+		informers.ClassParams.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				store.extractAllIngressAnnotations()
+			},
+			DeleteFunc: func(obj interface{}) {
+				store.extractAllIngressAnnotations()
+			},
+			UpdateFunc: func(old, cur interface{}) {
+				if !reflect.DeepEqual(old, cur) {
+					store.extractAllIngressAnnotations()
+				}
+			},
+		})
+	}
+	// LoadBalancers are reconciled through the ingresses built from them, whose annotations are extracted like those of ingresses.
+	if informers.LoadBalancer != nil {
+		informers.LoadBalancer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				store.extractLoadBalancerAnnotations(obj.(*standalone.LoadBalancer))
+			},
+			DeleteFunc: func(obj interface{}) {
+				lb, ok := obj.(*standalone.LoadBalancer)
+				if !ok {
+					tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+					if !ok {
+						glog.Errorf("couldn't get object from tombstone %#v", obj)
+						return
+					}
+					lb, ok = tombstone.Obj.(*standalone.LoadBalancer)
+					if !ok {
+						glog.Errorf("Tombstone contained object that is not a LoadBalancer: %#v", obj)
+						return
+					}
+				}
+				_ = store.listers.IngressAnnotation.Delete(standalone.Ingress(lb))
+			},
+			UpdateFunc: func(old, cur interface{}) {
+				store.extractLoadBalancerAnnotations(cur.(*standalone.LoadBalancer))
+			},
+		})
+	}
 	return store, nil
 }
 
+// extractAllIngressAnnotations parses the annotations of all ingresses and LoadBalancers of the watched ingress class
+func (s *k8sStore) extractAllIngressAnnotations() {
+	for _, item := range s.listers.Ingress.List() {
+		ing := item.(*extensions.Ingress)
+		if class.IsValidIngress(s.cfg.IngressClass, ing) {
+			s.extractIngressAnnotations(ing)
+		}
+	}
+	if s.informers.LoadBalancer != nil {
+		for _, item := range s.informers.LoadBalancer.GetStore().List() {
+			s.extractLoadBalancerAnnotations(item.(*standalone.LoadBalancer))
+		}
+	}
+}
+
+// extractLoadBalancerAnnotations parses the annotations of the ingress built from lb, if lb is of the watched ingress class.
+// Annotations of LoadBalancers moved to another ingress class are removed.
+func (s *k8sStore) extractLoadBalancerAnnotations(lb *standalone.LoadBalancer) {
+	ing := standalone.Ingress(lb)
+	if !class.IsValidIngress(s.cfg.IngressClass, ing) {
+		_ = s.listers.IngressAnnotation.Delete(ing)
+		return
+	}
+	s.extractIngressAnnotations(ing)
+}
+
 // extractIngressAnnotations parses ingress annotations converting the value of the
 // annotation to a go struct and also information about the referenced secrets
 func (s *k8sStore) extractIngressAnnotations(ing *extensions.Ingress) {
 	key := k8s.MetaNamespaceKey(ing)
 	glog.V(3).Infof("updating annotations information for ingress %v", key)
 
-	anns := s.ingannotations.ExtractIngress(ing)
+	// annotations denied for the namespace are never parsed, so they can't take effect even if ingress isn't rejected.
+	if allowed, violations := s.cfg.AnnotationRestrictions.Apply(ing.Namespace, ing.Annotations); len(violations) != 0 {
+		ing = ing.DeepCopy()
+		ing.Annotations = allowed
+	}
+	var anns *annotations.Ingress
+	if defaults, err := s.GetIngressClassDefaults(); err != nil {
+		anns = &annotations.Ingress{ObjectMeta: ing.ObjectMeta, Error: err}
+	} else {
+		anns = s.ingannotations.ExtractIngress(classparams.Apply(ing, defaults))
+	}
 
 	err := s.listers.IngressAnnotation.Update(anns)
 	if err != nil {
@@ -267,11 +472,32 @@ func (s k8sStore) ListNodes() []*corev1.Node {
 	return nodes
 }
 
+// ListReplicaSets returns the list of ReplicaSets in namespace
+func (s k8sStore) ListReplicaSets(namespace string) []*appsv1.ReplicaSet {
+	return s.listers.ReplicaSet.ByNamespace(namespace)
+}
+
 // GetConfig returns the controller configuration.
 func (s k8sStore) GetConfig() *config.Configuration {
 	return s.cfg
 }
 
+// GetIngressClassDefaults returns the annotations defaulted by the IngressClassParams of the watched ingress class.
+func (s k8sStore) GetIngressClassDefaults() (map[string]string, error) {
+	if s.informers.ClassParams == nil {
+		return nil, nil
+	}
+	name := class.Name(s.cfg.IngressClass)
+	obj, exists, err := s.informers.ClassParams.GetStore().GetByKey(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get IngressClassParams %v due to %v", name, err)
+	}
+	if !exists {
+		return nil, nil
+	}
+	return obj.(*classparams.IngressClassParams).Defaults(), nil
+}
+
 // GetIngressAnnotations returns the parsed annotations of an Ingress matching key.
 func (s k8sStore) GetIngressAnnotations(key string) (*annotations.Ingress, error) {
 	ia, err := s.listers.IngressAnnotation.ByKey(key)
@@ -296,6 +522,11 @@ func (s k8sStore) GetServiceAnnotations(key string, ingress *annotations.Ingress
 	return sa, nil
 }
 
+// GetPod returns the Pod matching key.
+func (s k8sStore) GetPod(key string) (*corev1.Pod, error) {
+	return s.listers.Pod.ByKey(key)
+}
+
 // GetServiceEndpoints returns the Endpoints of a Service matching key.
 func (s k8sStore) GetServiceEndpoints(key string) (*corev1.Endpoints, error) {
 	return s.listers.Endpoint.ByKey(key)
@@ -307,13 +538,7 @@ func (s *k8sStore) GetNodeInstanceID(node *corev1.Node) (string, error) {
 		return node.Spec.DoNotUse_ExternalID, nil
 	}
 
-	providerID := node.Spec.ProviderID
-	if providerID == "" {
-		return "", fmt.Errorf("No providerID found for node %s", node.ObjectMeta.Name)
-	}
-
-	p := strings.Split(providerID, "/")
-	return p[len(p)-1], nil
+	return s.instanceIDs.get(node)
 }
 
 func (s *k8sStore) GetInstanceIDFromPodIP(ip string) (string, error) {
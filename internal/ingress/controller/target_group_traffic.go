@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric/collectors"
+	"github.com/prometheus/client_golang/prometheus/push"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// pushgatewayJob is the job name of targetGroup traffic metrics pushed to Pushgateway.
+const pushgatewayJob = "alb-ingress-controller"
+
+// targetGroupTrafficMonitor periodically propagates the request rate and response time of targetGroups reported by CloudWatch into metrics,
+// so workloads can autoscale on them through a metrics adapter, optionally pushing them to a Pushgateway.
+type targetGroupTrafficMonitor struct {
+	reader         client.Reader
+	cloud          aws.CloudAPI
+	lbNameGen      lb.NameGenerator
+	tgNameTagGen   tg.NameTagGenerator
+	mc             metric.Collector
+	ingressClass   string
+	interval       time.Duration
+	pushgatewayURL string
+
+	// monitored contains the ingresses with traffic metrics set.
+	monitored map[types.NamespacedName]bool
+}
+
+func newTargetGroupTrafficMonitor(reader client.Reader, cloud aws.CloudAPI, lbNameGen lb.NameGenerator, tgNameTagGen tg.NameTagGenerator,
+	mc metric.Collector, ingressClass string, interval time.Duration, pushgatewayURL string) *targetGroupTrafficMonitor {
+	return &targetGroupTrafficMonitor{
+		reader:         reader,
+		cloud:          cloud,
+		lbNameGen:      lbNameGen,
+		tgNameTagGen:   tgNameTagGen,
+		mc:             mc,
+		ingressClass:   ingressClass,
+		interval:       interval,
+		pushgatewayURL: pushgatewayURL,
+		monitored:      make(map[types.NamespacedName]bool),
+	}
+}
+
+// Start implements manager.Runnable
+func (m *targetGroupTrafficMonitor) Start(stop <-chan struct{}) error {
+	wait.Until(m.check, m.interval, stop)
+	return nil
+}
+
+func (m *targetGroupTrafficMonitor) check() {
+	ctx := context.Background()
+	ingressList := &extensions.IngressList{}
+	if err := m.reader.List(ctx, &client.ListOptions{}, ingressList); err != nil {
+		glog.Errorf("failed to list ingresses for targetGroup traffic due to %v", err)
+		return
+	}
+
+	monitored := make(map[types.NamespacedName]bool)
+	for i := range ingressList.Items {
+		ingress := &ingressList.Items[i]
+		if !class.IsValidIngress(m.ingressClass, ingress) {
+			continue
+		}
+		ingressKey := types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}
+		ok, err := m.checkIngress(ctx, ingressKey)
+		if err != nil {
+			glog.Errorf("failed to check targetGroup traffic of ingress %v due to %v", ingressKey, err)
+			// keep the last traffic on transient failures.
+			if m.monitored[ingressKey] {
+				monitored[ingressKey] = true
+			}
+			continue
+		}
+		if ok {
+			monitored[ingressKey] = true
+		}
+	}
+	for ingressKey := range m.monitored {
+		if !monitored[ingressKey] {
+			m.mc.SetTargetGroupTraffic(ingressKey.Namespace, ingressKey.Name, nil)
+		}
+	}
+	m.monitored = monitored
+
+	if m.pushgatewayURL != "" {
+		if err := push.New(m.pushgatewayURL, pushgatewayJob).Collector(m.mc.TargetGroupTrafficCollector()).Push(); err != nil {
+			glog.Errorf("failed to push targetGroup traffic to %v due to %v", m.pushgatewayURL, err)
+		}
+	}
+}
+
+// checkIngress sets the traffic metrics for the targetGroups of ingress, and returns whether the LoadBalancer exists.
+func (m *targetGroupTrafficMonitor) checkIngress(ctx context.Context, ingressKey types.NamespacedName) (bool, error) {
+	lbName := m.lbNameGen.NameLB(ingressKey.Namespace, ingressKey.Name)
+	instance, err := m.cloud.GetLoadBalancerByName(ctx, lbName)
+	if err != nil {
+		return false, fmt.Errorf("failed to get LoadBalancer %v due to %v", lbName, err)
+	}
+	if instance == nil {
+		return false, nil
+	}
+	lbArn := aws.StringValue(instance.LoadBalancerArn)
+
+	tagFilters := make(map[string][]string)
+	for k, v := range m.tgNameTagGen.TagTGGroup(ingressKey.Namespace, ingressKey.Name) {
+		tagFilters[k] = []string{v}
+	}
+	tgArns, err := m.cloud.GetResourcesByFilters(tagFilters, aws.ResourceTypeEnumELBTargetGroup)
+	if err != nil {
+		return false, fmt.Errorf("failed to get targetGroups due to %v", err)
+	}
+	if len(tgArns) == 0 {
+		m.mc.SetTargetGroupTraffic(ingressKey.Namespace, ingressKey.Name, nil)
+		return true, nil
+	}
+	resp, err := m.cloud.DescribeELBV2TagsWithContext(ctx, &elbv2.DescribeTagsInput{ResourceArns: aws.StringSlice(tgArns)})
+	if err != nil {
+		return false, fmt.Errorf("failed to describe tags of targetGroups due to %v", err)
+	}
+
+	traffic := make([]collectors.TargetGroupTraffic, 0, len(resp.TagDescriptions))
+	for _, desc := range resp.TagDescriptions {
+		tgArn := aws.StringValue(desc.ResourceArn)
+		t := collectors.TargetGroupTraffic{}
+		for _, tag := range desc.Tags {
+			switch aws.StringValue(tag.Key) {
+			case generator.TagKeyServiceName:
+				t.ServiceName = aws.StringValue(tag.Value)
+			case generator.TagKeyServicePort:
+				t.ServicePort = aws.StringValue(tag.Value)
+			}
+		}
+		// RequestCount is only reported for periods with requests, so no datapoint means no traffic.
+		requestCount, _, err := m.cloud.GetLatestTargetGroupStatistic(ctx, lbArn, tgArn, "RequestCount", cloudwatch.StatisticSum)
+		if err != nil {
+			return false, fmt.Errorf("failed to get request count of targetGroup %v due to %v", tgArn, err)
+		}
+		t.RequestRate = requestCount / time.Minute.Seconds()
+		t.ResponseTime, _, err = m.cloud.GetLatestTargetGroupStatistic(ctx, lbArn, tgArn, "TargetResponseTime", cloudwatch.StatisticAverage)
+		if err != nil {
+			return false, fmt.Errorf("failed to get target response time of targetGroup %v due to %v", tgArn, err)
+		}
+		traffic = append(traffic, t)
+	}
+	m.mc.SetTargetGroupTraffic(ingressKey.Namespace, ingressKey.Name, traffic)
+	return true, nil
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric/collectors"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type trafficCollector struct {
+	metric.DummyCollector
+	traffic map[string][]collectors.TargetGroupTraffic
+}
+
+func (c *trafficCollector) SetTargetGroupTraffic(namespace string, ingress string, traffic []collectors.TargetGroupTraffic) {
+	if len(traffic) == 0 {
+		delete(c.traffic, namespace+"/"+ingress)
+		return
+	}
+	c.traffic[namespace+"/"+ingress] = traffic
+}
+
+func TestTargetGroupTrafficMonitor_Check(t *testing.T) {
+	ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"}}
+	client := fake.NewFakeClient(ingress)
+	nameTagGen := &tg.MockNameTagGenerator{}
+	nameTagGen.On("TagTGGroup", "namespace", "ingress").Return(map[string]string{"ingress.k8s.aws/stack": "namespace/ingress"})
+	cloud := &mocks.CloudAPI{}
+	cloud.On("GetLoadBalancerByName", mock.Anything, "namespace-ingress").Return(&elbv2.LoadBalancer{LoadBalancerArn: aws.String("lbArn")}, nil)
+	cloud.On("GetResourcesByFilters", map[string][]string{"ingress.k8s.aws/stack": {"namespace/ingress"}}, aws.ResourceTypeEnumELBTargetGroup).
+		Return([]string{"tgArn"}, nil)
+	cloud.On("DescribeELBV2TagsWithContext", mock.Anything, &elbv2.DescribeTagsInput{ResourceArns: aws.StringSlice([]string{"tgArn"})}).
+		Return(&elbv2.DescribeTagsOutput{TagDescriptions: []*elbv2.TagDescription{
+			{
+				ResourceArn: aws.String("tgArn"),
+				Tags: []*elbv2.Tag{
+					{Key: aws.String(generator.TagKeyServiceName), Value: aws.String("service")},
+					{Key: aws.String(generator.TagKeyServicePort), Value: aws.String("http")},
+				},
+			},
+		}}, nil)
+	cloud.On("GetLatestTargetGroupStatistic", mock.Anything, "lbArn", "tgArn", "RequestCount", cloudwatch.StatisticSum).Return(600.0, true, nil)
+	cloud.On("GetLatestTargetGroupStatistic", mock.Anything, "lbArn", "tgArn", "TargetResponseTime", cloudwatch.StatisticAverage).Return(0.25, true, nil)
+	mc := &trafficCollector{traffic: make(map[string][]collectors.TargetGroupTraffic)}
+	monitor := newTargetGroupTrafficMonitor(client, cloud, lbNameGenerator{}, nameTagGen, mc, "", 0, "")
+
+	monitor.check()
+	assert.Equal(t, []collectors.TargetGroupTraffic{
+		{ServiceName: "service", ServicePort: "http", RequestRate: 10, ResponseTime: 0.25},
+	}, mc.traffic["namespace/ingress"])
+
+	assert.NoError(t, client.Delete(context.Background(), ingress))
+	monitor.check()
+	assert.Empty(t, mc.traffic)
+}
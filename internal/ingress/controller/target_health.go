@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// targetHealthMonitor periodically propagates the health of targets reported by ALB into metrics, and into events of ingresses when it changes.
+type targetHealthMonitor struct {
+	reader       client.Reader
+	cloud        aws.CloudAPI
+	nameTagGen   tg.NameTagGenerator
+	recorder     record.EventRecorder
+	mc           metric.Collector
+	ingressClass string
+	interval     time.Duration
+	gracePeriod  time.Duration
+
+	// stateByTarget contains the last health state of targets, by ingress and targetGroup.
+	stateByTarget map[types.NamespacedName]map[string]map[string]string
+	// firstSeenByTarget contains the time targets were first seen, by ingress and targetGroup.
+	firstSeenByTarget map[types.NamespacedName]map[string]map[string]time.Time
+}
+
+func newTargetHealthMonitor(reader client.Reader, cloud aws.CloudAPI, nameTagGen tg.NameTagGenerator, recorder record.EventRecorder,
+	mc metric.Collector, ingressClass string, interval time.Duration, gracePeriod time.Duration) *targetHealthMonitor {
+	return &targetHealthMonitor{
+		reader:            reader,
+		cloud:             cloud,
+		nameTagGen:        nameTagGen,
+		recorder:          recorder,
+		mc:                mc,
+		ingressClass:      ingressClass,
+		interval:          interval,
+		gracePeriod:       gracePeriod,
+		stateByTarget:     make(map[types.NamespacedName]map[string]map[string]string),
+		firstSeenByTarget: make(map[types.NamespacedName]map[string]map[string]time.Time),
+	}
+}
+
+// Start implements manager.Runnable
+func (m *targetHealthMonitor) Start(stop <-chan struct{}) error {
+	wait.Until(m.check, m.interval, stop)
+	return nil
+}
+
+func (m *targetHealthMonitor) check() {
+	ctx := context.Background()
+	ingressList := &extensions.IngressList{}
+	if err := m.reader.List(ctx, &client.ListOptions{}, ingressList); err != nil {
+		glog.Errorf("failed to list ingresses for target health due to %v", err)
+		return
+	}
+
+	checked := make(map[types.NamespacedName]bool)
+	for i := range ingressList.Items {
+		ingress := &ingressList.Items[i]
+		if !class.IsValidIngress(m.ingressClass, ingress) {
+			continue
+		}
+		ingressKey := types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}
+		checked[ingressKey] = true
+		if err := m.checkIngress(ctx, ingressKey, ingress); err != nil {
+			glog.Errorf("failed to check target health of ingress %v due to %v", ingressKey, err)
+		}
+	}
+	for ingressKey := range m.stateByTarget {
+		if !checked[ingressKey] {
+			m.mc.RemoveTargetHealth(ingressKey.String(), nil)
+			delete(m.stateByTarget, ingressKey)
+			delete(m.firstSeenByTarget, ingressKey)
+		}
+	}
+}
+
+func (m *targetHealthMonitor) checkIngress(ctx context.Context, ingressKey types.NamespacedName, ingress *extensions.Ingress) error {
+	tagFilters := make(map[string][]string)
+	for k, v := range m.nameTagGen.TagTGGroup(ingress.Namespace, ingress.Name) {
+		tagFilters[k] = []string{v}
+	}
+	tgArns, err := m.cloud.GetResourcesByFilters(tagFilters, aws.ResourceTypeEnumELBTargetGroup)
+	if err != nil {
+		return fmt.Errorf("failed to get targetGroups due to %v", err)
+	}
+
+	now := time.Now()
+	lastStates := m.stateByTarget[ingressKey]
+	lastFirstSeen := m.firstSeenByTarget[ingressKey]
+	states := make(map[string]map[string]string, len(tgArns))
+	firstSeen := make(map[string]map[string]time.Time, len(tgArns))
+	for _, tgArn := range tgArns {
+		resp, err := m.cloud.DescribeTargetHealthWithContext(ctx, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(tgArn)})
+		if err != nil {
+			return fmt.Errorf("failed to describe health of targetGroup %v due to %v", tgArn, err)
+		}
+		stateByTarget := make(map[string]string, len(resp.TargetHealthDescriptions))
+		firstSeenByTarget := make(map[string]time.Time, len(resp.TargetHealthDescriptions))
+		for _, desc := range resp.TargetHealthDescriptions {
+			target := fmt.Sprintf("%v:%v", aws.StringValue(desc.Target.Id), aws.Int64Value(desc.Target.Port))
+			state := aws.StringValue(desc.TargetHealth.State)
+			stateByTarget[target] = state
+			targetFirstSeen, ok := lastFirstSeen[tgArn][target]
+			if !ok {
+				targetFirstSeen = now
+			}
+			firstSeenByTarget[target] = targetFirstSeen
+			if lastState, ok := lastStates[tgArn][target]; ok && lastState != state {
+				// unhealthy targets within grace period are likely still starting, so we don't warn about them.
+				if state == elbv2.TargetHealthStateEnumUnhealthy && now.Sub(targetFirstSeen) < m.gracePeriod {
+					continue
+				}
+				m.recordChange(ingress, tgArn, target, lastState, desc.TargetHealth)
+			}
+		}
+		states[tgArn] = stateByTarget
+		firstSeen[tgArn] = firstSeenByTarget
+		m.mc.SetTargetHealth(ingressKey.String(), tgArn, stateByTarget)
+	}
+	m.mc.RemoveTargetHealth(ingressKey.String(), tgArns)
+	m.stateByTarget[ingressKey] = states
+	m.firstSeenByTarget[ingressKey] = firstSeen
+	return nil
+}
+
+func (m *targetHealthMonitor) recordChange(ingress *extensions.Ingress, tgArn string, target string, lastState string, health *elbv2.TargetHealth) {
+	state := aws.StringValue(health.State)
+	eventType := corev1.EventTypeNormal
+	if state == elbv2.TargetHealthStateEnumUnhealthy {
+		eventType = corev1.EventTypeWarning
+	}
+	message := fmt.Sprintf("target %v of targetGroup %v changed from %v to %v", target, tgArn, lastState, state)
+	if description := aws.StringValue(health.Description); description != "" {
+		message = fmt.Sprintf("%v: %v", message, description)
+	}
+	m.recorder.Event(ingress, eventType, "TARGET_HEALTH", message)
+}
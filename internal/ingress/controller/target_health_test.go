@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func targetHealthOutput(states ...string) *elbv2.DescribeTargetHealthOutput {
+	output := &elbv2.DescribeTargetHealthOutput{}
+	for i, state := range states {
+		health := &elbv2.TargetHealth{State: aws.String(state)}
+		if state == elbv2.TargetHealthStateEnumUnhealthy {
+			health.Description = aws.String("Health checks failed")
+		}
+		output.TargetHealthDescriptions = append(output.TargetHealthDescriptions, &elbv2.TargetHealthDescription{
+			Target:       &elbv2.TargetDescription{Id: aws.String([]string{"i-1", "i-2"}[i]), Port: aws.Int64(80)},
+			TargetHealth: health,
+		})
+	}
+	return output
+}
+
+func drainEvents(fake *record.FakeRecorder) (events []string) {
+	for {
+		select {
+		case e := <-fake.Events:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func TestTargetHealthMonitor_Check(t *testing.T) {
+	ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"}}
+	client := fake.NewFakeClient(ingress)
+	cloud := &mocks.CloudAPI{}
+	nameTagGen := &tg.MockNameTagGenerator{}
+	nameTagGen.On("TagTGGroup", "namespace", "ingress").Return(map[string]string{"key": "value"})
+	cloud.On("GetResourcesByFilters", map[string][]string{"key": {"value"}}, aws.ResourceTypeEnumELBTargetGroup).Return([]string{"tgArn"}, nil)
+	recorder := record.NewFakeRecorder(10)
+	monitor := newTargetHealthMonitor(client, cloud, nameTagGen, recorder, metric.DummyCollector{}, "", 0, 0)
+
+	for _, tc := range []struct {
+		name           string
+		states         []string
+		expectedEvents []string
+	}{
+		{
+			name:   "first check emits no events",
+			states: []string{elbv2.TargetHealthStateEnumHealthy, elbv2.TargetHealthStateEnumInitial},
+		},
+		{
+			name:   "changed health emits events",
+			states: []string{elbv2.TargetHealthStateEnumUnhealthy, elbv2.TargetHealthStateEnumHealthy},
+			expectedEvents: []string{
+				"Warning TARGET_HEALTH target i-1:80 of targetGroup tgArn changed from healthy to unhealthy: Health checks failed",
+				"Normal TARGET_HEALTH target i-2:80 of targetGroup tgArn changed from initial to healthy",
+			},
+		},
+		{
+			name:   "unchanged health emits no events",
+			states: []string{elbv2.TargetHealthStateEnumUnhealthy, elbv2.TargetHealthStateEnumHealthy},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cloud.On("DescribeTargetHealthWithContext", mock.Anything, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String("tgArn")}).Return(targetHealthOutput(tc.states...), nil).Once()
+			monitor.check()
+			assert.Equal(t, tc.expectedEvents, drainEvents(recorder))
+		})
+	}
+	cloud.AssertExpectations(t)
+	nameTagGen.AssertExpectations(t)
+
+	assert.NoError(t, client.Delete(context.Background(), ingress))
+	monitor.check()
+	assert.Empty(t, monitor.stateByTarget)
+}
+
+func TestTargetHealthMonitor_CheckGracePeriod(t *testing.T) {
+	ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"}}
+	client := fake.NewFakeClient(ingress)
+	cloud := &mocks.CloudAPI{}
+	nameTagGen := &tg.MockNameTagGenerator{}
+	nameTagGen.On("TagTGGroup", "namespace", "ingress").Return(map[string]string{"key": "value"})
+	cloud.On("GetResourcesByFilters", map[string][]string{"key": {"value"}}, aws.ResourceTypeEnumELBTargetGroup).Return([]string{"tgArn"}, nil)
+	recorder := record.NewFakeRecorder(10)
+	monitor := newTargetHealthMonitor(client, cloud, nameTagGen, recorder, metric.DummyCollector{}, "", 0, time.Hour)
+
+	for _, tc := range []struct {
+		name           string
+		states         []string
+		expectedEvents []string
+	}{
+		{
+			name:   "first check emits no events",
+			states: []string{elbv2.TargetHealthStateEnumInitial},
+		},
+		{
+			name:   "unhealthy target within grace period emits no events",
+			states: []string{elbv2.TargetHealthStateEnumUnhealthy},
+		},
+		{
+			name:   "healthy target within grace period emits events",
+			states: []string{elbv2.TargetHealthStateEnumHealthy},
+			expectedEvents: []string{
+				"Normal TARGET_HEALTH target i-1:80 of targetGroup tgArn changed from unhealthy to healthy",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cloud.On("DescribeTargetHealthWithContext", mock.Anything, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String("tgArn")}).Return(targetHealthOutput(tc.states...), nil).Once()
+			monitor.check()
+			assert.Equal(t, tc.expectedEvents, drainEvents(recorder))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+const (
+	// initialWaitDelay is the delay before the first requeue for a resource in transitional state.
+	initialWaitDelay = 5 * time.Second
+
+	// maxWaitDelay caps the delay between requeues for a resource in transitional state.
+	maxWaitDelay = 2 * time.Minute
+)
+
+// maxWaitByState is the maximum duration resources are expected to stay in each transitional state,
+// reconcile fails once a resource stays in the state longer than that.
+var maxWaitByState = map[string]time.Duration{
+	albctx.WaitStateProvisioning: 10 * time.Minute,
+	albctx.WaitStateInUse:        5 * time.Minute,
+}
+
+type waitKey struct {
+	ingressKey types.NamespacedName
+	albctx.Wait
+}
+
+type waitProgress struct {
+	since    time.Time
+	attempts int
+}
+
+// waitTracker computes back-off delays for ingresses waiting on AWS resources in transitional states.
+type waitTracker struct {
+	clock clock.Clock
+
+	mutex    sync.Mutex
+	progress map[waitKey]*waitProgress
+}
+
+func newWaitTracker(clk clock.Clock) *waitTracker {
+	return &waitTracker{
+		clock:    clk,
+		progress: make(map[waitKey]*waitProgress),
+	}
+}
+
+// next returns the delay before ingress should be reconciled again for waits, or zero if there are no waits.
+// It returns an error if any resource has been in its transitional state longer than allowed.
+func (t *waitTracker) next(ingressKey types.NamespacedName, waits []albctx.Wait) (time.Duration, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := t.clock.Now()
+	current := make(map[waitKey]bool, len(waits))
+	for _, wait := range waits {
+		current[waitKey{ingressKey: ingressKey, Wait: wait}] = true
+	}
+	for key := range t.progress {
+		if key.ingressKey == ingressKey && !current[key] {
+			delete(t.progress, key)
+		}
+	}
+
+	var delay time.Duration
+	for key := range current {
+		progress, ok := t.progress[key]
+		if !ok {
+			progress = &waitProgress{since: now}
+			t.progress[key] = progress
+		}
+		if maxWait, ok := maxWaitByState[key.State]; ok && now.Sub(progress.since) > maxWait {
+			delete(t.progress, key)
+			return 0, fmt.Errorf("%v is still %v after %v", key.Resource, key.State, maxWait)
+		}
+		keyDelay := initialWaitDelay << uint(progress.attempts)
+		if keyDelay >= maxWaitDelay {
+			keyDelay = maxWaitDelay
+		} else {
+			progress.attempts++
+		}
+		if delay == 0 || keyDelay < delay {
+			delay = keyDelay
+		}
+	}
+	return delay, nil
+}
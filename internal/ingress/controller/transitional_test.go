@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+func TestWaitTracker_Next(t *testing.T) {
+	ingressKey := types.NamespacedName{Namespace: "namespace", Name: "ingress"}
+	provisioning := albctx.Wait{Resource: "lbArn", State: albctx.WaitStateProvisioning}
+	inUse := albctx.Wait{Resource: "tgArn", State: albctx.WaitStateInUse}
+	clk := clock.NewFakeClock(time.Unix(0, 0))
+	tracker := newWaitTracker(clk)
+
+	delay, err := tracker.next(ingressKey, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), delay)
+
+	for _, expected := range []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second, 80 * time.Second, 2 * time.Minute, 2 * time.Minute} {
+		delay, err := tracker.next(ingressKey, []albctx.Wait{provisioning})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, delay)
+		clk.Step(delay)
+	}
+
+	// the shortest delay among waits is used, and back-off restarts for new waits.
+	delay, err = tracker.next(ingressKey, []albctx.Wait{provisioning, inUse})
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, delay)
+
+	clk.Step(10 * time.Minute)
+	_, err = tracker.next(ingressKey, []albctx.Wait{provisioning})
+	assert.EqualError(t, err, "lbArn is still provisioning after 10m0s")
+
+	// waits that are gone are forgotten.
+	delay, err = tracker.next(ingressKey, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), delay)
+	delay, err = tracker.next(ingressKey, []albctx.Wait{inUse})
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, delay)
+}
@@ -0,0 +1,202 @@
+package diagnostics
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// queueLengthMetric is the metric of controller-runtime that reports the depth of the reconcile queue per controller.
+	queueLengthMetric = "controller_runtime_reconcile_queue_length"
+
+	// awsAPIWindowSeconds is the window over which AWS API calls are summarized.
+	awsAPIWindowSeconds = 60
+	// idlePollInterval is the interval at which WaitIdle checks for in-flight reconciles and AWS API calls.
+	idlePollInterval = 100 * time.Millisecond
+)
+
+// Report is the runtime state of controller served by the /debug/controller endpoint.
+type Report struct {
+	Goroutines         int                `json:"goroutines"`
+	ActiveReconciles   int64              `json:"activeReconciles"`
+	QueueDepths        map[string]float64 `json:"queueDepths"`
+	InformerCacheSizes map[string]int     `json:"informerCacheSizes"`
+	AWSAPI             AWSAPIReport       `json:"awsAPI"`
+}
+
+// AWSAPIReport summarizes AWS API calls within the last minute.
+// A high ThrottledRatio means the controller is saturating the AWS API rate limits.
+type AWSAPIReport struct {
+	InFlight       int64   `json:"inFlight"`
+	Attempts       int64   `json:"attempts"`
+	Throttled      int64   `json:"throttled"`
+	ThrottledRatio float64 `json:"throttledRatio"`
+}
+
+// Diagnostics collects the runtime state of controller for performance debugging.
+type Diagnostics struct {
+	gatherer prometheus.Gatherer
+	now      func() time.Time
+
+	activeReconciles int64
+	awsInFlight      int64
+
+	mu        sync.Mutex
+	informers map[string]cache.SharedIndexInformer
+	awsAPI    [awsAPIWindowSeconds]awsAPIBucket
+}
+
+// awsAPIBucket counts attempts of AWS API calls within second.
+type awsAPIBucket struct {
+	second    int64
+	attempts  int64
+	throttled int64
+}
+
+// New constructs new Diagnostics, which reads reconcile queue depths from gatherer.
+func New(gatherer prometheus.Gatherer) *Diagnostics {
+	return &Diagnostics{
+		gatherer:  gatherer,
+		now:       time.Now,
+		informers: make(map[string]cache.SharedIndexInformer),
+	}
+}
+
+// AddInformer registers informer whose cache size is reported under name.
+func (d *Diagnostics) AddInformer(name string, informer cache.SharedIndexInformer) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.informers[name] = informer
+}
+
+// ReconcileStarted records a reconcile has started, the returned func must be invoked when it finishes.
+func (d *Diagnostics) ReconcileStarted() func() {
+	atomic.AddInt64(&d.activeReconciles, 1)
+	return func() {
+		atomic.AddInt64(&d.activeReconciles, -1)
+	}
+}
+
+// WaitIdle waits until no reconcile nor AWS API call is in flight, for at most timeout. It returns whether controller became idle.
+func (d *Diagnostics) WaitIdle(timeout time.Duration) bool {
+	deadline := d.now().Add(timeout)
+	for {
+		if atomic.LoadInt64(&d.activeReconciles) == 0 && atomic.LoadInt64(&d.awsInFlight) == 0 {
+			return true
+		}
+		if !d.now().Before(deadline) {
+			return false
+		}
+		time.Sleep(idlePollInterval)
+	}
+}
+
+// AWSRequestStarted records an AWS API call has started.
+func (d *Diagnostics) AWSRequestStarted() {
+	atomic.AddInt64(&d.awsInFlight, 1)
+}
+
+// AWSRequestCompleted records an AWS API call has completed, after all its attempts.
+func (d *Diagnostics) AWSRequestCompleted() {
+	atomic.AddInt64(&d.awsInFlight, -1)
+}
+
+// AWSAttemptSent records an attempt of AWS API call has been sent.
+func (d *Diagnostics) AWSAttemptSent() {
+	d.observeAWSAttempt(func(bucket *awsAPIBucket) {
+		bucket.attempts++
+	})
+}
+
+// AWSAttemptThrottled records an attempt of AWS API call has been throttled.
+func (d *Diagnostics) AWSAttemptThrottled() {
+	d.observeAWSAttempt(func(bucket *awsAPIBucket) {
+		bucket.throttled++
+	})
+}
+
+func (d *Diagnostics) observeAWSAttempt(observe func(bucket *awsAPIBucket)) {
+	second := d.now().Unix()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	bucket := &d.awsAPI[second%awsAPIWindowSeconds]
+	if bucket.second != second {
+		*bucket = awsAPIBucket{second: second}
+	}
+	observe(bucket)
+}
+
+// Report returns the current runtime state of controller.
+func (d *Diagnostics) Report() Report {
+	report := Report{
+		Goroutines:         runtime.NumGoroutine(),
+		ActiveReconciles:   atomic.LoadInt64(&d.activeReconciles),
+		QueueDepths:        d.queueDepths(),
+		InformerCacheSizes: make(map[string]int),
+		AWSAPI: AWSAPIReport{
+			InFlight: atomic.LoadInt64(&d.awsInFlight),
+		},
+	}
+
+	second := d.now().Unix()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for name, informer := range d.informers {
+		report.InformerCacheSizes[name] = len(informer.GetStore().ListKeys())
+	}
+	for _, bucket := range d.awsAPI {
+		if second-bucket.second < awsAPIWindowSeconds {
+			report.AWSAPI.Attempts += bucket.attempts
+			report.AWSAPI.Throttled += bucket.throttled
+		}
+	}
+	if report.AWSAPI.Attempts > 0 {
+		report.AWSAPI.ThrottledRatio = float64(report.AWSAPI.Throttled) / float64(report.AWSAPI.Attempts)
+	}
+	return report
+}
+
+// queueDepths returns the depth of reconcile queues by controller name.
+func (d *Diagnostics) queueDepths() map[string]float64 {
+	depths := make(map[string]float64)
+	families, err := d.gatherer.Gather()
+	if err != nil {
+		glog.Warningf("failed to gather reconcile queue length due to %v", err)
+		return depths
+	}
+	for _, family := range families {
+		if family.GetName() != queueLengthMetric {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			// the queue is labeled by name of controller.
+			name := ""
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "name" || label.GetName() == "controller" {
+					name = label.GetValue()
+				}
+			}
+			depths[name] = m.GetGauge().GetValue()
+		}
+	}
+	return depths
+}
+
+// ServeHTTP serves the current runtime state of controller as JSON.
+func (d *Diagnostics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	payload, err := json.MarshalIndent(d.Report(), "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(payload)
+}
@@ -0,0 +1,70 @@
+package diagnostics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestDiagnostics_Report(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	queueLength := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: queueLengthMetric}, []string{"name"})
+	registry.MustRegister(queueLength)
+	queueLength.WithLabelValues("alb-ingress-controller").Set(3)
+
+	now := time.Unix(1000, 0)
+	d := New(registry)
+	d.now = func() time.Time { return now }
+
+	informer := cache.NewSharedIndexInformer(&cache.ListWatch{}, &corev1.Pod{}, 0, cache.Indexers{})
+	assert.NoError(t, informer.GetStore().Add(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pod"}}))
+	d.AddInformer("pod", informer)
+
+	done := d.ReconcileStarted()
+	d.ReconcileStarted()
+	done()
+
+	d.AWSRequestStarted()
+	d.AWSAttemptSent()
+	d.AWSAttemptThrottled()
+	d.AWSAttemptSent()
+	d.AWSRequestCompleted()
+	d.AWSRequestStarted()
+	d.AWSAttemptSent()
+	now = now.Add(30 * time.Second)
+	d.AWSRequestStarted()
+	d.AWSAttemptSent()
+	d.AWSRequestCompleted()
+
+	report := d.Report()
+	assert.Equal(t, int64(1), report.ActiveReconciles)
+	assert.Equal(t, map[string]float64{"alb-ingress-controller": 3}, report.QueueDepths)
+	assert.Equal(t, map[string]int{"pod": 1}, report.InformerCacheSizes)
+	assert.Equal(t, AWSAPIReport{InFlight: 1, Attempts: 4, Throttled: 1, ThrottledRatio: 0.25}, report.AWSAPI)
+
+	// calls older than the window are no longer reported.
+	now = now.Add(45 * time.Second)
+	report = d.Report()
+	assert.Equal(t, AWSAPIReport{InFlight: 1, Attempts: 1}, report.AWSAPI)
+}
+
+func TestDiagnostics_WaitIdle(t *testing.T) {
+	d := New(prometheus.NewRegistry())
+	assert.True(t, d.WaitIdle(0))
+
+	reconcileDone := d.ReconcileStarted()
+	d.AWSRequestStarted()
+	assert.False(t, d.WaitIdle(2*idlePollInterval))
+
+	go func() {
+		time.Sleep(idlePollInterval)
+		d.AWSRequestCompleted()
+		reconcileDone()
+	}()
+	assert.True(t, d.WaitIdle(time.Minute))
+}
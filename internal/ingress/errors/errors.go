@@ -18,6 +18,8 @@ package errors
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 )
@@ -55,6 +57,49 @@ func (e InvalidContent) Error() string {
 	return e.Name
 }
 
+// InvalidAnnotation is the error of an annotation with an invalid value
+type InvalidAnnotation struct {
+	Name   string
+	Value  string
+	Reason string
+}
+
+func (e InvalidAnnotation) Error() string {
+	return fmt.Sprintf("annotation %v has invalid value %q: %v", e.Name, e.Value, e.Reason)
+}
+
+// InvalidAnnotations aggregates the errors of all invalid annotations of an object,
+// so that they are reported together instead of one per reconcile
+type InvalidAnnotations []error
+
+func (e InvalidAnnotations) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Append returns e with err appended unless nil, flattening err if it aggregates errors too
+func (e InvalidAnnotations) Append(err error) InvalidAnnotations {
+	if err == nil {
+		return e
+	}
+	if errs, ok := err.(InvalidAnnotations); ok {
+		return append(e, errs...)
+	}
+	return append(e, err)
+}
+
+// ErrOrNil returns e sorted by message, or nil if it's empty
+func (e InvalidAnnotations) ErrOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	sort.Slice(e, func(i, j int) bool { return e[i].Error() < e[j].Error() })
+	return e
+}
+
 // IsMissingAnnotations checks if the err is an error which
 // indicates the ingress does not contain annotations
 func IsMissingAnnotations(e error) bool {
@@ -64,8 +109,11 @@ func IsMissingAnnotations(e error) bool {
 // IsInvalidContent checks if the err is an error which
 // indicates an annotations value is not valid
 func IsInvalidContent(e error) bool {
-	_, ok := e.(InvalidContent)
-	return ok
+	switch e.(type) {
+	case InvalidContent, InvalidAnnotation, InvalidAnnotations:
+		return true
+	}
+	return false
 }
 
 // New returns a new error
@@ -36,3 +36,22 @@ func TestInvalidContent(t *testing.T) {
 		t.Error("expected false")
 	}
 }
+
+func TestInvalidAnnotations(t *testing.T) {
+	var errs InvalidAnnotations
+	if errs.ErrOrNil() != nil {
+		t.Error("expected nil")
+	}
+
+	errs = errs.Append(nil)
+	errs = errs.Append(InvalidAnnotation{Name: "b", Value: "1", Reason: "must be 2"})
+	errs = errs.Append(InvalidAnnotations{InvalidAnnotation{Name: "a", Value: "x", Reason: "must be y"}})
+	err := errs.ErrOrNil()
+	if !IsInvalidContent(err) {
+		t.Error("expected true")
+	}
+	expected := `annotation a has invalid value "x": must be y; annotation b has invalid value "1": must be 2`
+	if err.Error() != expected {
+		t.Errorf("expected %v, got %v", expected, err.Error())
+	}
+}
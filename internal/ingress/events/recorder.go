@@ -0,0 +1,216 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	DefaultDedupWindow = 5 * time.Minute
+	DefaultBurst       = 10
+)
+
+// Severity classifies an event by how actionable it is for the Ingress owner.
+type Severity int
+
+const (
+	// SeverityInfo is used for Normal events, e.g. resource creation or modification.
+	SeverityInfo Severity = iota
+	// SeverityWarning is used for Warning events that don't indicate a failed reconcile.
+	SeverityWarning
+	// SeverityError is used for Warning events that indicate a failed AWS call or reconcile.
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return "unknown"
+}
+
+// ClassifySeverity returns the Severity for an event based on its type and reason.
+func ClassifySeverity(eventType, reason string) Severity {
+	if eventType != corev1.EventTypeWarning {
+		return SeverityInfo
+	}
+	if reason == "ERROR" {
+		return SeverityError
+	}
+	return SeverityWarning
+}
+
+// Options configures the deduplicating recorder.
+type Options struct {
+	// DedupWindow is the period during which identical events for an object are collapsed into one.
+	DedupWindow time.Duration
+
+	// Burst is the maximum number of distinct non-error events emitted for one object within DedupWindow.
+	// Events with SeverityError are never dropped by this limit.
+	Burst int
+}
+
+// NewRecorder wraps an EventRecorder so that identical events emitted for the same object within
+// DedupWindow are suppressed, and the number of suppressed occurrences is appended to the next emitted one.
+func NewRecorder(delegate record.EventRecorder, opts Options) record.EventRecorder {
+	return newRecorder(delegate, opts, clock.RealClock{})
+}
+
+func newRecorder(delegate record.EventRecorder, opts Options, clk clock.Clock) *recorder {
+	if opts.DedupWindow <= 0 {
+		opts.DedupWindow = DefaultDedupWindow
+	}
+	if opts.Burst <= 0 {
+		opts.Burst = DefaultBurst
+	}
+	return &recorder{
+		delegate: delegate,
+		opts:     opts,
+		clock:    clk,
+		entries:  make(map[entryKey]*entry),
+		emitted:  make(map[string][]time.Time),
+	}
+}
+
+type entryKey struct {
+	object    string
+	eventType string
+	reason    string
+	message   string
+}
+
+type entry struct {
+	lastEmitted time.Time
+	suppressed  int
+}
+
+type recorder struct {
+	delegate record.EventRecorder
+	opts     Options
+	clock    clock.Clock
+
+	mutex     sync.Mutex
+	entries   map[entryKey]*entry
+	emitted   map[string][]time.Time
+	lastSweep time.Time
+}
+
+var _ record.EventRecorder = (*recorder)(nil)
+
+func (r *recorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if message, ok := r.admit(object, eventtype, reason, message); ok {
+		r.delegate.Event(object, eventtype, reason, message)
+	}
+}
+
+func (r *recorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	if message, ok := r.admit(object, eventtype, reason, fmt.Sprintf(messageFmt, args...)); ok {
+		r.delegate.Event(object, eventtype, reason, message)
+	}
+}
+
+func (r *recorder) PastEventf(object runtime.Object, timestamp metav1.Time, eventtype, reason, messageFmt string, args ...interface{}) {
+	if message, ok := r.admit(object, eventtype, reason, fmt.Sprintf(messageFmt, args...)); ok {
+		r.delegate.PastEventf(object, timestamp, eventtype, reason, "%s", message)
+	}
+}
+
+func (r *recorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	if message, ok := r.admit(object, eventtype, reason, fmt.Sprintf(messageFmt, args...)); ok {
+		r.delegate.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+	}
+}
+
+// admit decides whether an event should be passed to the delegate recorder, and returns the message to emit.
+func (r *recorder) admit(object runtime.Object, eventType, reason, message string) (string, bool) {
+	objKey, err := objectKey(object)
+	if err != nil {
+		return message, true
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := r.clock.Now()
+	r.sweep(now)
+
+	key := entryKey{object: objKey, eventType: eventType, reason: reason, message: message}
+	e, exists := r.entries[key]
+	if exists && now.Sub(e.lastEmitted) < r.opts.DedupWindow {
+		e.suppressed++
+		return "", false
+	}
+
+	emitted := r.recentEmissions(objKey, now)
+	if len(emitted) >= r.opts.Burst && ClassifySeverity(eventType, reason) != SeverityError {
+		if exists {
+			e.suppressed++
+		}
+		return "", false
+	}
+
+	if !exists {
+		e = &entry{}
+		r.entries[key] = e
+	}
+	if e.suppressed > 0 {
+		message = fmt.Sprintf("%s (repeated %d times in the last %v)", message, e.suppressed+1, now.Sub(e.lastEmitted).Round(time.Second))
+	}
+	e.lastEmitted = now
+	e.suppressed = 0
+	r.emitted[objKey] = append(emitted, now)
+	return message, true
+}
+
+// recentEmissions returns the emission timestamps for object that are still within DedupWindow.
+func (r *recorder) recentEmissions(objKey string, now time.Time) []time.Time {
+	emitted := r.emitted[objKey]
+	i := 0
+	for ; i < len(emitted); i++ {
+		if now.Sub(emitted[i]) < r.opts.DedupWindow {
+			break
+		}
+	}
+	return emitted[i:]
+}
+
+// sweep drops bookkeeping for events that haven't been seen for a while, so the recorder doesn't grow unbounded.
+func (r *recorder) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < r.opts.DedupWindow {
+		return
+	}
+	r.lastSweep = now
+	for key, e := range r.entries {
+		if now.Sub(e.lastEmitted) >= 2*r.opts.DedupWindow {
+			delete(r.entries, key)
+		}
+	}
+	for objKey := range r.emitted {
+		if emitted := r.recentEmissions(objKey, now); len(emitted) == 0 {
+			delete(r.emitted, objKey)
+		} else {
+			r.emitted[objKey] = emitted
+		}
+	}
+}
+
+func objectKey(object runtime.Object) (string, error) {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s/%s", accessor.GetNamespace(), accessor.GetName(), accessor.GetUID()), nil
+}
@@ -0,0 +1,86 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/client-go/tools/record"
+)
+
+func drain(fake *record.FakeRecorder) (events []string) {
+	for {
+		select {
+		case e := <-fake.Events:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func TestClassifySeverity(t *testing.T) {
+	for _, tc := range []struct {
+		eventType string
+		reason    string
+		expected  Severity
+	}{
+		{eventType: corev1.EventTypeNormal, reason: "CREATE", expected: SeverityInfo},
+		{eventType: corev1.EventTypeWarning, reason: "MODIFY", expected: SeverityWarning},
+		{eventType: corev1.EventTypeWarning, reason: "ERROR", expected: SeverityError},
+	} {
+		assert.Equal(t, tc.expected, ClassifySeverity(tc.eventType, tc.reason))
+	}
+}
+
+func TestRecorder_Deduplication(t *testing.T) {
+	ing := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ingress", UID: "uid"}}
+	fake := record.NewFakeRecorder(100)
+	clk := clock.NewFakeClock(time.Unix(0, 0))
+	r := newRecorder(fake, Options{DedupWindow: time.Minute, Burst: 10}, clk)
+
+	for i := 0; i < 3; i++ {
+		r.Eventf(ing, corev1.EventTypeWarning, "ERROR", "failed %s", "foo")
+	}
+	r.Eventf(ing, corev1.EventTypeWarning, "ERROR", "failed %s", "bar")
+	assert.Equal(t, []string{
+		"Warning ERROR failed foo",
+		"Warning ERROR failed bar",
+	}, drain(fake))
+
+	clk.Step(time.Minute)
+	r.Eventf(ing, corev1.EventTypeWarning, "ERROR", "failed %s", "foo")
+	r.Eventf(ing, corev1.EventTypeWarning, "ERROR", "failed %s", "bar")
+	assert.Equal(t, []string{
+		"Warning ERROR failed foo (repeated 3 times in the last 1m0s)",
+		"Warning ERROR failed bar",
+	}, drain(fake))
+}
+
+func TestRecorder_Burst(t *testing.T) {
+	ing := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ingress", UID: "uid"}}
+	other := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other", UID: "other-uid"}}
+	fake := record.NewFakeRecorder(100)
+	clk := clock.NewFakeClock(time.Unix(0, 0))
+	r := newRecorder(fake, Options{DedupWindow: time.Minute, Burst: 2}, clk)
+
+	r.Event(ing, corev1.EventTypeNormal, "MODIFY", "a")
+	r.Event(ing, corev1.EventTypeNormal, "MODIFY", "b")
+	r.Event(ing, corev1.EventTypeNormal, "MODIFY", "c")
+	r.Event(ing, corev1.EventTypeWarning, "ERROR", "d")
+	r.Event(other, corev1.EventTypeNormal, "MODIFY", "a")
+	assert.Equal(t, []string{
+		"Normal MODIFY a",
+		"Normal MODIFY b",
+		"Warning ERROR d",
+		"Normal MODIFY a",
+	}, drain(fake))
+
+	clk.Step(time.Minute)
+	r.Event(ing, corev1.EventTypeNormal, "MODIFY", "c")
+	assert.Equal(t, []string{"Normal MODIFY c"}, drain(fake))
+}
@@ -30,6 +30,7 @@ type Controller struct {
 
 	reconcileOperation       *prometheus.CounterVec
 	reconcileOperationErrors *prometheus.CounterVec
+	reconcilePanics          *prometheus.CounterVec
 	managedIngresses         *prometheus.GaugeVec
 
 	labels prometheus.Labels
@@ -60,6 +61,14 @@ func NewController(class string) *Controller {
 			},
 			[]string{"class", "ingress"},
 		),
+		reconcilePanics: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "reconcile_panics",
+				Help:      `Cumulative number of Ingress controller panics recovered during reconcile operations`,
+			},
+			[]string{"class", "ingress"},
+		),
 		managedIngresses: prometheus.NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: PrometheusNamespace,
@@ -87,6 +96,15 @@ func (cm *Controller) IncReconcileErrorCount(name string) {
 	cm.reconcileOperationErrors.With(l).Inc()
 }
 
+// IncReconcilePanicCount increment the reconcile panic counter
+func (cm *Controller) IncReconcilePanicCount(name string) {
+	l := prometheus.Labels{
+		"class": cm.labels["class"],
+	}
+	l["ingress"] = name
+	cm.reconcilePanics.With(l).Inc()
+}
+
 // SetManagedIngresses sets the number of managed ingresses
 func (cm *Controller) SetManagedIngresses(nsmap map[string]int, registry prometheus.Gatherer) {
 	l := prometheus.Labels{
@@ -137,6 +155,7 @@ func (cm *Controller) SetManagedIngresses(nsmap map[string]int, registry prometh
 func (cm Controller) Describe(ch chan<- *prometheus.Desc) {
 	cm.reconcileOperation.Describe(ch)
 	cm.reconcileOperationErrors.Describe(ch)
+	cm.reconcilePanics.Describe(ch)
 	cm.managedIngresses.Describe(ch)
 }
 
@@ -144,6 +163,7 @@ func (cm Controller) Describe(ch chan<- *prometheus.Desc) {
 func (cm Controller) Collect(ch chan<- prometheus.Metric) {
 	cm.reconcileOperation.Collect(ch)
 	cm.reconcileOperationErrors.Collect(ch)
+	cm.reconcilePanics.Collect(ch)
 	cm.managedIngresses.Collect(ch)
 }
 
@@ -154,4 +174,5 @@ func (cm *Controller) RemoveMetrics(name string) {
 	}
 	l["ingress"] = name
 	cm.reconcileOperationErrors.Delete(l)
+	cm.reconcilePanics.Delete(l)
 }
@@ -64,6 +64,27 @@ func TestControllerCounters(t *testing.T) {
 			`,
 			metrics: []string{"aws_alb_ingress_controller_errors"},
 		},
+		{
+			name: "single increase in panic count should return 1",
+			test: func(cm *Controller) {
+				cm.IncReconcilePanicCount("namespace/ingressName")
+			},
+			want: `
+				# HELP aws_alb_ingress_controller_reconcile_panics Cumulative number of Ingress controller panics recovered during reconcile operations
+				# TYPE aws_alb_ingress_controller_reconcile_panics counter
+				aws_alb_ingress_controller_reconcile_panics{class="alb",ingress="namespace/ingressName"} 1
+			`,
+			metrics: []string{"aws_alb_ingress_controller_reconcile_panics"},
+		},
+		{
+			name: "removing metrics of ingress should remove its panic count",
+			test: func(cm *Controller) {
+				cm.IncReconcilePanicCount("namespace/ingressName")
+				cm.RemoveMetrics("namespace/ingressName")
+			},
+			want:    ``,
+			metrics: []string{"aws_alb_ingress_controller_reconcile_panics"},
+		},
 	}
 
 	for _, c := range cases {
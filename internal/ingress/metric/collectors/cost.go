@@ -0,0 +1,68 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CostController defines metrics about the estimated cost of LoadBalancers
+type CostController struct {
+	prometheus.Collector
+
+	consumedLCUs  *prometheus.GaugeVec
+	estimatedCost *prometheus.GaugeVec
+}
+
+// NewCostController creates a new prometheus collector for the
+// estimated cost of LoadBalancers
+func NewCostController() *CostController {
+	return &CostController{
+		consumedLCUs: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "load_balancer_consumed_lcus",
+				Help:      `Average number of LCUs consumed by the ALB of ingress`,
+			},
+			[]string{"namespace", "ingress"},
+		),
+		estimatedCost: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "load_balancer_estimated_monthly_cost",
+				Help:      `Estimated monthly cost of the ALB of ingress, from hourly price and average LCU consumption`,
+			},
+			[]string{"namespace", "ingress"},
+		),
+	}
+}
+
+// SetLoadBalancerCost sets the consumed LCUs and estimated monthly cost of the LoadBalancer of ingress
+func (c *CostController) SetLoadBalancerCost(namespace string, ingress string, consumedLCUs float64, monthlyCost float64) {
+	l := prometheus.Labels{
+		"namespace": namespace,
+		"ingress":   ingress,
+	}
+	c.consumedLCUs.With(l).Set(consumedLCUs)
+	c.estimatedCost.With(l).Set(monthlyCost)
+}
+
+// RemoveLoadBalancerCost removes the cost metrics of the LoadBalancer of ingress
+func (c *CostController) RemoveLoadBalancerCost(namespace string, ingress string) {
+	l := prometheus.Labels{
+		"namespace": namespace,
+		"ingress":   ingress,
+	}
+	c.consumedLCUs.Delete(l)
+	c.estimatedCost.Delete(l)
+}
+
+// Describe implements prometheus.Collector
+func (c *CostController) Describe(ch chan<- *prometheus.Desc) {
+	c.consumedLCUs.Describe(ch)
+	c.estimatedCost.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *CostController) Collect(ch chan<- prometheus.Metric) {
+	c.consumedLCUs.Collect(ch)
+	c.estimatedCost.Collect(ch)
+}
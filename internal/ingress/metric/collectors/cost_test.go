@@ -0,0 +1,68 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestCost(t *testing.T) {
+	const metadata = `
+		# HELP aws_alb_ingress_controller_load_balancer_consumed_lcus Average number of LCUs consumed by the ALB of ingress
+		# TYPE aws_alb_ingress_controller_load_balancer_consumed_lcus gauge
+		# HELP aws_alb_ingress_controller_load_balancer_estimated_monthly_cost Estimated monthly cost of the ALB of ingress, from hourly price and average LCU consumption
+		# TYPE aws_alb_ingress_controller_load_balancer_estimated_monthly_cost gauge
+	`
+	cases := []struct {
+		name string
+		test func(*CostController)
+		want string
+	}{
+		{
+			name: "should report cost of each ingress",
+			test: func(c *CostController) {
+				c.SetLoadBalancerCost("namespace", "ingress", 2, 28.105)
+				c.SetLoadBalancerCost("other", "ingress", 0.5, 19.345)
+			},
+			want: metadata + `
+				aws_alb_ingress_controller_load_balancer_consumed_lcus{ingress="ingress",namespace="namespace"} 2
+				aws_alb_ingress_controller_load_balancer_consumed_lcus{ingress="ingress",namespace="other"} 0.5
+				aws_alb_ingress_controller_load_balancer_estimated_monthly_cost{ingress="ingress",namespace="namespace"} 28.105
+				aws_alb_ingress_controller_load_balancer_estimated_monthly_cost{ingress="ingress",namespace="other"} 19.345
+			`,
+		},
+		{
+			name: "should remove cost of ingress",
+			test: func(c *CostController) {
+				c.SetLoadBalancerCost("namespace", "ingress", 2, 28.105)
+				c.SetLoadBalancerCost("other", "ingress", 0.5, 19.345)
+				c.RemoveLoadBalancerCost("namespace", "ingress")
+			},
+			want: metadata + `
+				aws_alb_ingress_controller_load_balancer_consumed_lcus{ingress="ingress",namespace="other"} 0.5
+				aws_alb_ingress_controller_load_balancer_estimated_monthly_cost{ingress="ingress",namespace="other"} 19.345
+			`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cc := NewCostController()
+			reg := prometheus.NewPedanticRegistry()
+			if err := reg.Register(cc); err != nil {
+				t.Errorf("registering collector failed: %s", err)
+			}
+
+			c.test(cc)
+
+			if err := GatherAndCompare(cc, c.want, []string{
+				"aws_alb_ingress_controller_load_balancer_consumed_lcus",
+				"aws_alb_ingress_controller_load_balancer_estimated_monthly_cost",
+			}, reg); err != nil {
+				t.Errorf("unexpected error collecting result:\n%s", err)
+			}
+
+			reg.Unregister(cc)
+		})
+	}
+}
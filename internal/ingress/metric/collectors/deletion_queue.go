@@ -0,0 +1,57 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DeletionQueueController defines metrics about AWS resources whose deletion is deferred
+type DeletionQueueController struct {
+	prometheus.Collector
+
+	depth     *prometheus.GaugeVec
+	oldestAge *prometheus.GaugeVec
+}
+
+// NewDeletionQueueController creates a new prometheus collector for the
+// AWS resources whose deletion is deferred
+func NewDeletionQueueController() *DeletionQueueController {
+	return &DeletionQueueController{
+		depth: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "deletion_queue_depth",
+				Help:      `Number of AWS resources whose deletion is deferred because they are still in use`,
+			},
+			[]string{"kind"},
+		),
+		oldestAge: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "deletion_queue_oldest_age_seconds",
+				Help:      `Seconds since the first deletion attempt of the oldest AWS resource whose deletion is deferred`,
+			},
+			[]string{"kind"},
+		),
+	}
+}
+
+// SetDeletionQueue sets the number of deferred deletions of resources of kind, and the age of the oldest one
+func (c *DeletionQueueController) SetDeletionQueue(kind string, depth int, oldestAge float64) {
+	l := prometheus.Labels{
+		"kind": kind,
+	}
+	c.depth.With(l).Set(float64(depth))
+	c.oldestAge.With(l).Set(oldestAge)
+}
+
+// Describe implements prometheus.Collector
+func (c *DeletionQueueController) Describe(ch chan<- *prometheus.Desc) {
+	c.depth.Describe(ch)
+	c.oldestAge.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *DeletionQueueController) Collect(ch chan<- prometheus.Metric) {
+	c.depth.Collect(ch)
+	c.oldestAge.Collect(ch)
+}
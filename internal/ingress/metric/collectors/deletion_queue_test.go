@@ -0,0 +1,67 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestDeletionQueue(t *testing.T) {
+	const metadata = `
+		# HELP aws_alb_ingress_controller_deletion_queue_depth Number of AWS resources whose deletion is deferred because they are still in use
+		# TYPE aws_alb_ingress_controller_deletion_queue_depth gauge
+		# HELP aws_alb_ingress_controller_deletion_queue_oldest_age_seconds Seconds since the first deletion attempt of the oldest AWS resource whose deletion is deferred
+		# TYPE aws_alb_ingress_controller_deletion_queue_oldest_age_seconds gauge
+	`
+	cases := []struct {
+		name string
+		test func(*DeletionQueueController)
+		want string
+	}{
+		{
+			name: "should report deferred deletions of each kind",
+			test: func(c *DeletionQueueController) {
+				c.SetDeletionQueue("securityGroup", 2, 120)
+				c.SetDeletionQueue("targetGroup", 0, 0)
+			},
+			want: metadata + `
+				aws_alb_ingress_controller_deletion_queue_depth{kind="securityGroup"} 2
+				aws_alb_ingress_controller_deletion_queue_depth{kind="targetGroup"} 0
+				aws_alb_ingress_controller_deletion_queue_oldest_age_seconds{kind="securityGroup"} 120
+				aws_alb_ingress_controller_deletion_queue_oldest_age_seconds{kind="targetGroup"} 0
+			`,
+		},
+		{
+			name: "should update deferred deletions of kind",
+			test: func(c *DeletionQueueController) {
+				c.SetDeletionQueue("securityGroup", 2, 120)
+				c.SetDeletionQueue("securityGroup", 1, 60)
+			},
+			want: metadata + `
+				aws_alb_ingress_controller_deletion_queue_depth{kind="securityGroup"} 1
+				aws_alb_ingress_controller_deletion_queue_oldest_age_seconds{kind="securityGroup"} 60
+			`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dc := NewDeletionQueueController()
+			reg := prometheus.NewPedanticRegistry()
+			if err := reg.Register(dc); err != nil {
+				t.Errorf("registering collector failed: %s", err)
+			}
+
+			c.test(dc)
+
+			if err := GatherAndCompare(dc, c.want, []string{
+				"aws_alb_ingress_controller_deletion_queue_depth",
+				"aws_alb_ingress_controller_deletion_queue_oldest_age_seconds",
+			}, reg); err != nil {
+				t.Errorf("unexpected error collecting result:\n%s", err)
+			}
+
+			reg.Unregister(dc)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// QuotaController defines metrics about the usage of LoadBalancer quotas
+type QuotaController struct {
+	prometheus.Collector
+
+	clusterUsed  prometheus.Gauge
+	clusterLimit *prometheus.GaugeVec
+	used         *prometheus.GaugeVec
+	limit        *prometheus.GaugeVec
+}
+
+// NewQuotaController creates a new prometheus collector for the
+// usage of LoadBalancer quotas of the cluster and namespaces
+func NewQuotaController() *QuotaController {
+	return &QuotaController{
+		clusterUsed: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "cluster_load_balancer_quota_used",
+				Help:      `Number of ALBs counting towards the quota of the cluster`,
+			},
+		),
+		clusterLimit: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "cluster_load_balancer_quota_limit",
+				Help:      `Maximum number of ALBs of the cluster, absent if unlimited`,
+			},
+			nil,
+		),
+		used: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "load_balancer_quota_used",
+				Help:      `Number of ALBs counting towards the quota of namespace`,
+			},
+			[]string{"namespace"},
+		),
+		limit: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "load_balancer_quota_limit",
+				Help:      `Maximum number of ALBs of namespace, absent if unlimited`,
+			},
+			[]string{"namespace"},
+		),
+	}
+}
+
+// SetLoadBalancerQuota sets the number of LoadBalancers used in namespace, or in the cluster if namespace is empty,
+// and its quota, which is unlimited if zero
+func (c *QuotaController) SetLoadBalancerQuota(namespace string, used int, limit int) {
+	if namespace == "" {
+		c.clusterUsed.Set(float64(used))
+		setLimit(c.clusterLimit, prometheus.Labels{}, limit)
+		return
+	}
+	l := prometheus.Labels{
+		"namespace": namespace,
+	}
+	c.used.With(l).Set(float64(used))
+	setLimit(c.limit, l, limit)
+}
+
+// setLimit sets the quota limit with labels l, or removes it if limit is zero
+func setLimit(vec *prometheus.GaugeVec, l prometheus.Labels, limit int) {
+	if limit > 0 {
+		vec.With(l).Set(float64(limit))
+	} else {
+		vec.Delete(l)
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *QuotaController) Describe(ch chan<- *prometheus.Desc) {
+	c.clusterUsed.Describe(ch)
+	c.clusterLimit.Describe(ch)
+	c.used.Describe(ch)
+	c.limit.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *QuotaController) Collect(ch chan<- prometheus.Metric) {
+	c.clusterUsed.Collect(ch)
+	c.clusterLimit.Collect(ch)
+	c.used.Collect(ch)
+	c.limit.Collect(ch)
+}
@@ -0,0 +1,79 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestQuota(t *testing.T) {
+	cases := []struct {
+		name string
+		test func(*QuotaController)
+		want string
+	}{
+		{
+			name: "should report usage and limit of quotas",
+			test: func(c *QuotaController) {
+				c.SetLoadBalancerQuota("", 3, 10)
+				c.SetLoadBalancerQuota("team-a", 2, 2)
+				c.SetLoadBalancerQuota("team-b", 1, 0)
+			},
+			want: `
+				# HELP aws_alb_ingress_controller_cluster_load_balancer_quota_limit Maximum number of ALBs of the cluster, absent if unlimited
+				# TYPE aws_alb_ingress_controller_cluster_load_balancer_quota_limit gauge
+				aws_alb_ingress_controller_cluster_load_balancer_quota_limit 10
+				# HELP aws_alb_ingress_controller_cluster_load_balancer_quota_used Number of ALBs counting towards the quota of the cluster
+				# TYPE aws_alb_ingress_controller_cluster_load_balancer_quota_used gauge
+				aws_alb_ingress_controller_cluster_load_balancer_quota_used 3
+				# HELP aws_alb_ingress_controller_load_balancer_quota_limit Maximum number of ALBs of namespace, absent if unlimited
+				# TYPE aws_alb_ingress_controller_load_balancer_quota_limit gauge
+				aws_alb_ingress_controller_load_balancer_quota_limit{namespace="team-a"} 2
+				# HELP aws_alb_ingress_controller_load_balancer_quota_used Number of ALBs counting towards the quota of namespace
+				# TYPE aws_alb_ingress_controller_load_balancer_quota_used gauge
+				aws_alb_ingress_controller_load_balancer_quota_used{namespace="team-a"} 2
+				aws_alb_ingress_controller_load_balancer_quota_used{namespace="team-b"} 1
+			`,
+		},
+		{
+			name: "should remove limit of quota becoming unlimited",
+			test: func(c *QuotaController) {
+				c.SetLoadBalancerQuota("", 3, 10)
+				c.SetLoadBalancerQuota("", 3, 0)
+				c.SetLoadBalancerQuota("team-a", 2, 2)
+				c.SetLoadBalancerQuota("team-a", 2, 0)
+			},
+			want: `
+				# HELP aws_alb_ingress_controller_cluster_load_balancer_quota_used Number of ALBs counting towards the quota of the cluster
+				# TYPE aws_alb_ingress_controller_cluster_load_balancer_quota_used gauge
+				aws_alb_ingress_controller_cluster_load_balancer_quota_used 3
+				# HELP aws_alb_ingress_controller_load_balancer_quota_used Number of ALBs counting towards the quota of namespace
+				# TYPE aws_alb_ingress_controller_load_balancer_quota_used gauge
+				aws_alb_ingress_controller_load_balancer_quota_used{namespace="team-a"} 2
+			`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			qc := NewQuotaController()
+			reg := prometheus.NewPedanticRegistry()
+			if err := reg.Register(qc); err != nil {
+				t.Errorf("registering collector failed: %s", err)
+			}
+
+			c.test(qc)
+
+			if err := GatherAndCompare(qc, c.want, []string{
+				"aws_alb_ingress_controller_cluster_load_balancer_quota_limit",
+				"aws_alb_ingress_controller_cluster_load_balancer_quota_used",
+				"aws_alb_ingress_controller_load_balancer_quota_limit",
+				"aws_alb_ingress_controller_load_balancer_quota_used",
+			}, reg); err != nil {
+				t.Errorf("unexpected error collecting result:\n%s", err)
+			}
+
+			reg.Unregister(qc)
+		})
+	}
+}
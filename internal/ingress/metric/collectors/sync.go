@@ -0,0 +1,100 @@
+package collectors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+// SyncController defines metrics about the freshness of ingress syncs, so that alerts can detect a stuck controller
+type SyncController struct {
+	prometheus.Collector
+
+	clock clock.Clock
+
+	mutex sync.Mutex
+	// syncedAt contains the time of the last successful sync by ingress, or of the first sync attempt if none succeeded yet.
+	syncedAt map[types.NamespacedName]time.Time
+
+	lastSuccess  *prometheus.GaugeVec
+	maxStaleness prometheus.GaugeFunc
+}
+
+// NewSyncController creates a new prometheus collector for the
+// last successful syncs of ingresses and the staleness of the most outdated one
+func NewSyncController(clk clock.Clock) *SyncController {
+	c := &SyncController{
+		clock:    clk,
+		syncedAt: make(map[types.NamespacedName]time.Time),
+		lastSuccess: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "last_successful_sync_timestamp_seconds",
+				Help:      `Unix timestamp of the last successful sync of ingress`,
+			},
+			[]string{"namespace", "ingress"},
+		),
+	}
+	c.maxStaleness = prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Namespace: PrometheusNamespace,
+			Name:      "max_sync_staleness_seconds",
+			Help:      `Seconds since the last successful sync of the most outdated ingress, or since its first sync attempt if none succeeded`,
+		},
+		c.staleness,
+	)
+	return c
+}
+
+// ObserveSync records a sync attempt of ingress, which updates its last successful sync if succeeded
+func (c *SyncController) ObserveSync(namespace string, ingress string, succeeded bool) {
+	key := types.NamespacedName{Namespace: namespace, Name: ingress}
+	now := c.clock.Now()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if succeeded {
+		c.syncedAt[key] = now
+		c.lastSuccess.With(prometheus.Labels{"namespace": namespace, "ingress": ingress}).Set(float64(now.Unix()))
+	} else if _, ok := c.syncedAt[key]; !ok {
+		c.syncedAt[key] = now
+	}
+}
+
+// RemoveSync removes the sync metrics of ingress
+func (c *SyncController) RemoveSync(namespace string, ingress string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.syncedAt, types.NamespacedName{Namespace: namespace, Name: ingress})
+	c.lastSuccess.Delete(prometheus.Labels{"namespace": namespace, "ingress": ingress})
+}
+
+// staleness returns the seconds since the oldest sync of ingresses
+func (c *SyncController) staleness() float64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var oldest time.Time
+	for _, syncedAt := range c.syncedAt {
+		if oldest.IsZero() || syncedAt.Before(oldest) {
+			oldest = syncedAt
+		}
+	}
+	if oldest.IsZero() {
+		return 0
+	}
+	return c.clock.Since(oldest).Seconds()
+}
+
+// Describe implements prometheus.Collector
+func (c *SyncController) Describe(ch chan<- *prometheus.Desc) {
+	c.lastSuccess.Describe(ch)
+	c.maxStaleness.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *SyncController) Collect(ch chan<- prometheus.Metric) {
+	c.lastSuccess.Collect(ch)
+	c.maxStaleness.Collect(ch)
+}
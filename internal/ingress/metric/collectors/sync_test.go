@@ -0,0 +1,86 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+func TestSync(t *testing.T) {
+	cases := []struct {
+		name string
+		test func(*SyncController, *clock.FakeClock)
+		want string
+	}{
+		{
+			name: "should report last successful syncs and max staleness",
+			test: func(c *SyncController, clk *clock.FakeClock) {
+				c.ObserveSync("default", "ingress-a", true)
+				clk.Step(10 * time.Second)
+				c.ObserveSync("default", "ingress-b", true)
+				clk.Step(20 * time.Second)
+				c.ObserveSync("default", "ingress-a", false)
+				c.ObserveSync("default", "ingress-b", true)
+			},
+			want: `
+				# HELP aws_alb_ingress_controller_last_successful_sync_timestamp_seconds Unix timestamp of the last successful sync of ingress
+				# TYPE aws_alb_ingress_controller_last_successful_sync_timestamp_seconds gauge
+				aws_alb_ingress_controller_last_successful_sync_timestamp_seconds{ingress="ingress-a",namespace="default"} 1000
+				aws_alb_ingress_controller_last_successful_sync_timestamp_seconds{ingress="ingress-b",namespace="default"} 1030
+				# HELP aws_alb_ingress_controller_max_sync_staleness_seconds Seconds since the last successful sync of the most outdated ingress, or since its first sync attempt if none succeeded
+				# TYPE aws_alb_ingress_controller_max_sync_staleness_seconds gauge
+				aws_alb_ingress_controller_max_sync_staleness_seconds 30
+			`,
+		},
+		{
+			name: "should report staleness of ingress never synced successfully",
+			test: func(c *SyncController, clk *clock.FakeClock) {
+				c.ObserveSync("default", "ingress-a", false)
+				clk.Step(10 * time.Second)
+				c.ObserveSync("default", "ingress-a", false)
+			},
+			want: `
+				# HELP aws_alb_ingress_controller_max_sync_staleness_seconds Seconds since the last successful sync of the most outdated ingress, or since its first sync attempt if none succeeded
+				# TYPE aws_alb_ingress_controller_max_sync_staleness_seconds gauge
+				aws_alb_ingress_controller_max_sync_staleness_seconds 10
+			`,
+		},
+		{
+			name: "should remove sync metrics of deleted ingress",
+			test: func(c *SyncController, clk *clock.FakeClock) {
+				c.ObserveSync("default", "ingress-a", true)
+				clk.Step(10 * time.Second)
+				c.RemoveSync("default", "ingress-a")
+			},
+			want: `
+				# HELP aws_alb_ingress_controller_max_sync_staleness_seconds Seconds since the last successful sync of the most outdated ingress, or since its first sync attempt if none succeeded
+				# TYPE aws_alb_ingress_controller_max_sync_staleness_seconds gauge
+				aws_alb_ingress_controller_max_sync_staleness_seconds 0
+			`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			clk := clock.NewFakeClock(time.Unix(1000, 0))
+			sc := NewSyncController(clk)
+			reg := prometheus.NewPedanticRegistry()
+			if err := reg.Register(sc); err != nil {
+				t.Errorf("registering collector failed: %s", err)
+			}
+
+			c.test(sc, clk)
+
+			if err := GatherAndCompare(sc, c.want, []string{
+				"aws_alb_ingress_controller_last_successful_sync_timestamp_seconds",
+				"aws_alb_ingress_controller_max_sync_staleness_seconds",
+			}, reg); err != nil {
+				t.Errorf("unexpected error collecting result:\n%s", err)
+			}
+
+			reg.Unregister(sc)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TargetGroupTraffic is the traffic reported by ALB for the targetGroup of a service port.
+type TargetGroupTraffic struct {
+	ServiceName string
+	ServicePort string
+
+	// RequestRate is the number of requests per second routed to targets.
+	RequestRate float64
+	// ResponseTime is the average time in seconds for targets to respond.
+	ResponseTime float64
+}
+
+// TargetGroupTrafficController defines metrics about the traffic of targetGroups reported by ALB
+type TargetGroupTrafficController struct {
+	prometheus.Collector
+
+	requestRate  *prometheus.GaugeVec
+	responseTime *prometheus.GaugeVec
+
+	mutex sync.Mutex
+	// series contains the labels of series set for each ingress, by namespace/name
+	series map[string][]prometheus.Labels
+}
+
+// NewTargetGroupTrafficController creates a new prometheus collector for the
+// traffic of targetGroups
+func NewTargetGroupTrafficController() *TargetGroupTrafficController {
+	return &TargetGroupTrafficController{
+		requestRate: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "target_group_request_rate",
+				Help:      `Requests per second routed by ALB to the targetGroup of a service port`,
+			},
+			[]string{"namespace", "ingress", "service", "service_port"},
+		),
+		responseTime: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "target_group_response_time_seconds",
+				Help:      `Average time for targets in the targetGroup of a service port to respond to ALB`,
+			},
+			[]string{"namespace", "ingress", "service", "service_port"},
+		),
+		series: make(map[string][]prometheus.Labels),
+	}
+}
+
+// SetTargetGroupTraffic sets the traffic of targetGroups of ingress, replacing previous traffic of ingress
+func (c *TargetGroupTrafficController) SetTargetGroupTraffic(namespace string, ingress string, traffic []TargetGroupTraffic) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := namespace + "/" + ingress
+	for _, l := range c.series[key] {
+		c.requestRate.Delete(l)
+		c.responseTime.Delete(l)
+	}
+	if len(traffic) == 0 {
+		delete(c.series, key)
+		return
+	}
+	series := make([]prometheus.Labels, 0, len(traffic))
+	for _, t := range traffic {
+		l := prometheus.Labels{
+			"namespace":    namespace,
+			"ingress":      ingress,
+			"service":      t.ServiceName,
+			"service_port": t.ServicePort,
+		}
+		c.requestRate.With(l).Set(t.RequestRate)
+		c.responseTime.With(l).Set(t.ResponseTime)
+		series = append(series, l)
+	}
+	c.series[key] = series
+}
+
+// Describe implements prometheus.Collector
+func (c *TargetGroupTrafficController) Describe(ch chan<- *prometheus.Desc) {
+	c.requestRate.Describe(ch)
+	c.responseTime.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *TargetGroupTrafficController) Collect(ch chan<- prometheus.Metric) {
+	c.requestRate.Collect(ch)
+	c.responseTime.Collect(ch)
+}
@@ -0,0 +1,70 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestTargetGroupTraffic(t *testing.T) {
+	const metadata = `
+		# HELP aws_alb_ingress_controller_target_group_request_rate Requests per second routed by ALB to the targetGroup of a service port
+		# TYPE aws_alb_ingress_controller_target_group_request_rate gauge
+		# HELP aws_alb_ingress_controller_target_group_response_time_seconds Average time for targets in the targetGroup of a service port to respond to ALB
+		# TYPE aws_alb_ingress_controller_target_group_response_time_seconds gauge
+	`
+	cases := []struct {
+		name string
+		test func(*TargetGroupTrafficController)
+		want string
+	}{
+		{
+			name: "should report traffic of each service port",
+			test: func(c *TargetGroupTrafficController) {
+				c.SetTargetGroupTraffic("namespace", "ingress", []TargetGroupTraffic{
+					{ServiceName: "service", ServicePort: "80", RequestRate: 12.5, ResponseTime: 0.25},
+				})
+			},
+			want: metadata + `
+				aws_alb_ingress_controller_target_group_request_rate{ingress="ingress",namespace="namespace",service="service",service_port="80"} 12.5
+				aws_alb_ingress_controller_target_group_response_time_seconds{ingress="ingress",namespace="namespace",service="service",service_port="80"} 0.25
+			`,
+		},
+		{
+			name: "should replace previous traffic of ingress",
+			test: func(c *TargetGroupTrafficController) {
+				c.SetTargetGroupTraffic("namespace", "ingress", []TargetGroupTraffic{
+					{ServiceName: "service", ServicePort: "80", RequestRate: 12.5, ResponseTime: 0.25},
+				})
+				c.SetTargetGroupTraffic("namespace", "ingress", []TargetGroupTraffic{
+					{ServiceName: "other", ServicePort: "http", RequestRate: 3, ResponseTime: 0.5},
+				})
+			},
+			want: metadata + `
+				aws_alb_ingress_controller_target_group_request_rate{ingress="ingress",namespace="namespace",service="other",service_port="http"} 3
+				aws_alb_ingress_controller_target_group_response_time_seconds{ingress="ingress",namespace="namespace",service="other",service_port="http"} 0.5
+			`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tc := NewTargetGroupTrafficController()
+			reg := prometheus.NewPedanticRegistry()
+			if err := reg.Register(tc); err != nil {
+				t.Errorf("registering collector failed: %s", err)
+			}
+
+			c.test(tc)
+
+			if err := GatherAndCompare(tc, c.want, []string{
+				"aws_alb_ingress_controller_target_group_request_rate",
+				"aws_alb_ingress_controller_target_group_response_time_seconds",
+			}, reg); err != nil {
+				t.Errorf("unexpected error collecting result:\n%s", err)
+			}
+
+			reg.Unregister(tc)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TargetHealthController defines metrics about the health of targets reported by ALB
+type TargetHealthController struct {
+	prometheus.Collector
+
+	targetHealth *prometheus.GaugeVec
+
+	mutex sync.Mutex
+	// series contains the labels of series set for each targetGroup of each ingress
+	series map[string]map[string][]prometheus.Labels
+}
+
+// NewTargetHealthController creates a new prometheus collector for the
+// health of targets
+func NewTargetHealthController() *TargetHealthController {
+	return &TargetHealthController{
+		targetHealth: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "target_health",
+				Help:      `Health state of targets reported by ALB, 1 for the current state of each target`,
+			},
+			[]string{"ingress", "target_group", "target", "state"},
+		),
+		series: make(map[string]map[string][]prometheus.Labels),
+	}
+}
+
+// SetTargetHealth sets the health state of targets in targetGroup of ingress, replacing previous states of that targetGroup
+func (c *TargetHealthController) SetTargetHealth(ingress string, targetGroup string, stateByTarget map[string]string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.series[ingress] == nil {
+		c.series[ingress] = make(map[string][]prometheus.Labels)
+	}
+	for _, l := range c.series[ingress][targetGroup] {
+		c.targetHealth.Delete(l)
+	}
+	series := make([]prometheus.Labels, 0, len(stateByTarget))
+	for target, state := range stateByTarget {
+		l := prometheus.Labels{
+			"ingress":      ingress,
+			"target_group": targetGroup,
+			"target":       target,
+			"state":        state,
+		}
+		c.targetHealth.With(l).Set(1)
+		series = append(series, l)
+	}
+	c.series[ingress][targetGroup] = series
+}
+
+// RemoveTargetHealth removes the health state of targets in all targetGroups of ingress, except those in keep
+func (c *TargetHealthController) RemoveTargetHealth(ingress string, keep []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	kept := make(map[string]bool, len(keep))
+	for _, targetGroup := range keep {
+		kept[targetGroup] = true
+	}
+	for targetGroup, series := range c.series[ingress] {
+		if kept[targetGroup] {
+			continue
+		}
+		for _, l := range series {
+			c.targetHealth.Delete(l)
+		}
+		delete(c.series[ingress], targetGroup)
+	}
+	if len(c.series[ingress]) == 0 {
+		delete(c.series, ingress)
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *TargetHealthController) Describe(ch chan<- *prometheus.Desc) {
+	c.targetHealth.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *TargetHealthController) Collect(ch chan<- prometheus.Metric) {
+	c.targetHealth.Collect(ch)
+}
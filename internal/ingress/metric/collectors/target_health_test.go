@@ -0,0 +1,69 @@
+package collectors
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestTargetHealth(t *testing.T) {
+	const metadata = `
+		# HELP aws_alb_ingress_controller_target_health Health state of targets reported by ALB, 1 for the current state of each target
+		# TYPE aws_alb_ingress_controller_target_health gauge
+	`
+	cases := []struct {
+		name string
+		test func(*TargetHealthController)
+		want string
+	}{
+		{
+			name: "should report state of each target",
+			test: func(c *TargetHealthController) {
+				c.SetTargetHealth("namespace/ingress", "tg1", map[string]string{"i-1:80": "healthy", "i-2:80": "unhealthy"})
+			},
+			want: metadata + `
+				aws_alb_ingress_controller_target_health{ingress="namespace/ingress",state="healthy",target="i-1:80",target_group="tg1"} 1
+				aws_alb_ingress_controller_target_health{ingress="namespace/ingress",state="unhealthy",target="i-2:80",target_group="tg1"} 1
+			`,
+		},
+		{
+			name: "should replace previous states of targetGroup",
+			test: func(c *TargetHealthController) {
+				c.SetTargetHealth("namespace/ingress", "tg1", map[string]string{"i-1:80": "healthy", "i-2:80": "unhealthy"})
+				c.SetTargetHealth("namespace/ingress", "tg1", map[string]string{"i-2:80": "healthy"})
+			},
+			want: metadata + `
+				aws_alb_ingress_controller_target_health{ingress="namespace/ingress",state="healthy",target="i-2:80",target_group="tg1"} 1
+			`,
+		},
+		{
+			name: "should remove states of targetGroups not kept",
+			test: func(c *TargetHealthController) {
+				c.SetTargetHealth("namespace/ingress", "tg1", map[string]string{"i-1:80": "healthy"})
+				c.SetTargetHealth("namespace/ingress", "tg2", map[string]string{"i-2:80": "draining"})
+				c.RemoveTargetHealth("namespace/ingress", []string{"tg2"})
+			},
+			want: metadata + `
+				aws_alb_ingress_controller_target_health{ingress="namespace/ingress",state="draining",target="i-2:80",target_group="tg2"} 1
+			`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tc := NewTargetHealthController()
+			reg := prometheus.NewPedanticRegistry()
+			if err := reg.Register(tc); err != nil {
+				t.Errorf("registering collector failed: %s", err)
+			}
+
+			c.test(tc)
+
+			if err := GatherAndCompare(tc, c.want, []string{"aws_alb_ingress_controller_target_health"}, reg); err != nil {
+				t.Errorf("unexpected error collecting result:\n%s", err)
+			}
+
+			reg.Unregister(tc)
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package metric
 
 import (
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric/collectors"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -29,6 +30,9 @@ func (dc DummyCollector) IncReconcileCount() {}
 // IncReloadErrorCount ...
 func (dc DummyCollector) IncReconcileErrorCount(string) {}
 
+// IncReconcilePanicCount ...
+func (dc DummyCollector) IncReconcilePanicCount(string) {}
+
 // SetManagedIngresses ...
 func (dc DummyCollector) SetManagedIngresses(map[string]int) {}
 
@@ -41,6 +45,36 @@ func (dc DummyCollector) IncAPIErrorCount(prometheus.Labels) {}
 // IncAPIRetryCount ...
 func (dc DummyCollector) IncAPIRetryCount(prometheus.Labels) {}
 
+// SetTargetHealth ...
+func (dc DummyCollector) SetTargetHealth(string, string, map[string]string) {}
+
+// RemoveTargetHealth ...
+func (dc DummyCollector) RemoveTargetHealth(string, []string) {}
+
+// SetLoadBalancerCost ...
+func (dc DummyCollector) SetLoadBalancerCost(string, string, float64, float64) {}
+
+// RemoveLoadBalancerCost ...
+func (dc DummyCollector) RemoveLoadBalancerCost(string, string) {}
+
+// SetTargetGroupTraffic ...
+func (dc DummyCollector) SetTargetGroupTraffic(string, string, []collectors.TargetGroupTraffic) {}
+
+// TargetGroupTrafficCollector ...
+func (dc DummyCollector) TargetGroupTrafficCollector() prometheus.Collector { return nil }
+
+// SetDeletionQueue ...
+func (dc DummyCollector) SetDeletionQueue(string, int, float64) {}
+
+// SetLoadBalancerQuota ...
+func (dc DummyCollector) SetLoadBalancerQuota(string, int, int) {}
+
+// ObserveSync ...
+func (dc DummyCollector) ObserveSync(string, string, bool) {}
+
+// RemoveSync ...
+func (dc DummyCollector) RemoveSync(string, string) {}
+
 // Start ...
 func (dc DummyCollector) Start() {}
 
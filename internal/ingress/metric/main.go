@@ -18,6 +18,7 @@ package metric
 
 import (
 	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/clock"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric/collectors"
 )
@@ -26,12 +27,32 @@ import (
 type Collector interface {
 	IncReconcileCount()
 	IncReconcileErrorCount(string)
+	IncReconcilePanicCount(string)
 	SetManagedIngresses(map[string]int)
 
 	IncAPIRequestCount(prometheus.Labels)
 	IncAPIErrorCount(prometheus.Labels)
 	IncAPIRetryCount(prometheus.Labels)
 
+	SetTargetHealth(ingress string, targetGroup string, stateByTarget map[string]string)
+	RemoveTargetHealth(ingress string, keep []string)
+
+	SetLoadBalancerCost(namespace string, ingress string, consumedLCUs float64, monthlyCost float64)
+	RemoveLoadBalancerCost(namespace string, ingress string)
+
+	SetTargetGroupTraffic(namespace string, ingress string, traffic []collectors.TargetGroupTraffic)
+	// TargetGroupTrafficCollector returns the collector of targetGroup traffic metrics, for pushing them to a Pushgateway.
+	TargetGroupTrafficCollector() prometheus.Collector
+
+	SetDeletionQueue(kind string, depth int, oldestAge float64)
+
+	// SetLoadBalancerQuota sets the usage of the LoadBalancer quota of namespace, or of the cluster if namespace is empty.
+	SetLoadBalancerQuota(namespace string, used int, limit int)
+
+	// ObserveSync records a sync attempt of ingress, and RemoveSync removes its sync metrics once deleted.
+	ObserveSync(namespace string, ingress string, succeeded bool)
+	RemoveSync(namespace string, ingress string)
+
 	RemoveMetrics(string)
 
 	Start()
@@ -41,6 +62,12 @@ type Collector interface {
 type collector struct {
 	ingressController *collectors.Controller
 	awsAPIController  *collectors.AWSAPIController
+	targetHealth      *collectors.TargetHealthController
+	cost              *collectors.CostController
+	tgTraffic         *collectors.TargetGroupTrafficController
+	deletionQueue     *collectors.DeletionQueueController
+	quota             *collectors.QuotaController
+	sync              *collectors.SyncController
 
 	registry *prometheus.Registry
 }
@@ -49,10 +76,22 @@ type collector struct {
 func NewCollector(registry *prometheus.Registry, ingressClass string) (Collector, error) {
 	ic := collectors.NewController(ingressClass)
 	ac := collectors.NewAWSAPIController()
+	th := collectors.NewTargetHealthController()
+	cc := collectors.NewCostController()
+	tt := collectors.NewTargetGroupTrafficController()
+	dq := collectors.NewDeletionQueueController()
+	qc := collectors.NewQuotaController()
+	sc := collectors.NewSyncController(clock.RealClock{})
 
 	return Collector(&collector{
 		ingressController: ic,
 		awsAPIController:  ac,
+		targetHealth:      th,
+		cost:              cc,
+		tgTraffic:         tt,
+		deletionQueue:     dq,
+		quota:             qc,
+		sync:              sc,
 		registry:          registry,
 	}), nil
 }
@@ -65,6 +104,10 @@ func (c *collector) IncReconcileErrorCount(s string) {
 	c.ingressController.IncReconcileErrorCount(s)
 }
 
+func (c *collector) IncReconcilePanicCount(s string) {
+	c.ingressController.IncReconcilePanicCount(s)
+}
+
 func (c *collector) SetManagedIngresses(i map[string]int) {
 	c.ingressController.SetManagedIngresses(i, c.registry)
 }
@@ -81,6 +124,46 @@ func (c *collector) IncAPIRetryCount(l prometheus.Labels) {
 	c.awsAPIController.IncAPIRetryCount(l)
 }
 
+func (c *collector) SetTargetHealth(ingress string, targetGroup string, stateByTarget map[string]string) {
+	c.targetHealth.SetTargetHealth(ingress, targetGroup, stateByTarget)
+}
+
+func (c *collector) RemoveTargetHealth(ingress string, keep []string) {
+	c.targetHealth.RemoveTargetHealth(ingress, keep)
+}
+
+func (c *collector) SetLoadBalancerCost(namespace string, ingress string, consumedLCUs float64, monthlyCost float64) {
+	c.cost.SetLoadBalancerCost(namespace, ingress, consumedLCUs, monthlyCost)
+}
+
+func (c *collector) RemoveLoadBalancerCost(namespace string, ingress string) {
+	c.cost.RemoveLoadBalancerCost(namespace, ingress)
+}
+
+func (c *collector) SetTargetGroupTraffic(namespace string, ingress string, traffic []collectors.TargetGroupTraffic) {
+	c.tgTraffic.SetTargetGroupTraffic(namespace, ingress, traffic)
+}
+
+func (c *collector) TargetGroupTrafficCollector() prometheus.Collector {
+	return c.tgTraffic
+}
+
+func (c *collector) SetDeletionQueue(kind string, depth int, oldestAge float64) {
+	c.deletionQueue.SetDeletionQueue(kind, depth, oldestAge)
+}
+
+func (c *collector) SetLoadBalancerQuota(namespace string, used int, limit int) {
+	c.quota.SetLoadBalancerQuota(namespace, used, limit)
+}
+
+func (c *collector) ObserveSync(namespace string, ingress string, succeeded bool) {
+	c.sync.ObserveSync(namespace, ingress, succeeded)
+}
+
+func (c *collector) RemoveSync(namespace string, ingress string) {
+	c.sync.RemoveSync(namespace, ingress)
+}
+
 func (c *collector) RemoveMetrics(ingressName string) {
 	c.ingressController.RemoveMetrics(ingressName)
 }
@@ -88,9 +171,21 @@ func (c *collector) RemoveMetrics(ingressName string) {
 func (c *collector) Start() {
 	c.registry.MustRegister(c.ingressController)
 	c.registry.MustRegister(c.awsAPIController)
+	c.registry.MustRegister(c.targetHealth)
+	c.registry.MustRegister(c.cost)
+	c.registry.MustRegister(c.tgTraffic)
+	c.registry.MustRegister(c.deletionQueue)
+	c.registry.MustRegister(c.quota)
+	c.registry.MustRegister(c.sync)
 }
 
 func (c *collector) Stop() {
 	c.registry.Unregister(c.ingressController)
 	c.registry.Unregister(c.awsAPIController)
+	c.registry.Unregister(c.targetHealth)
+	c.registry.Unregister(c.cost)
+	c.registry.Unregister(c.tgTraffic)
+	c.registry.Unregister(c.deletionQueue)
+	c.registry.Unregister(c.quota)
+	c.registry.Unregister(c.sync)
 }
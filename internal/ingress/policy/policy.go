@@ -0,0 +1,190 @@
+package policy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/ghodss/yaml"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// CheckRequireWAF requires LoadBalancers to be associated with a WAF web ACL.
+	CheckRequireWAF = "require-waf"
+
+	// CheckHTTPSOnly requires all listeners of LoadBalancers to be HTTPS.
+	CheckHTTPSOnly = "https-only"
+
+	// CheckDenyCIDRs denies the CIDRs of rule in the inbound CIDRs of LoadBalancers.
+	CheckDenyCIDRs = "deny-cidrs"
+
+	// CheckTags requires the tags applied to LoadBalancers to have the required keys of rule, with values matching its patterns.
+	CheckTags = "tags"
+)
+
+const (
+	// SeverityError blocks reconcile of LoadBalancers violating the rule.
+	SeverityError = "error"
+
+	// SeverityWarning only emits events for LoadBalancers violating the rule.
+	SeverityWarning = "warning"
+)
+
+// Rule is an org policy that LoadBalancers must conform to.
+type Rule struct {
+	// Name identifies rule in violations.
+	Name string `json:"name"`
+
+	// Check is the check performed, one of CheckRequireWAF, CheckHTTPSOnly, CheckDenyCIDRs and CheckTags.
+	Check string `json:"check"`
+
+	// Scheme restricts rule to LoadBalancers of the scheme, rule applies to all LoadBalancers if empty.
+	Scheme string `json:"scheme,omitempty"`
+
+	// Severity is the severity of violations, SeverityError or SeverityWarning.
+	Severity string `json:"severity"`
+
+	// CIDRs are the CIDRs denied by CheckDenyCIDRs.
+	CIDRs []string `json:"cidrs,omitempty"`
+
+	// RequiredTagKeys are the keys of tags required by CheckTags.
+	RequiredTagKeys []string `json:"requiredTagKeys,omitempty"`
+
+	// TagValuePatterns are regular expressions by tag key, the whole value of tags with the key must match for CheckTags.
+	TagValuePatterns map[string]string `json:"tagValuePatterns,omitempty"`
+}
+
+// Policy is a set of rules.
+type Policy struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Violation is a violation of rule by a LoadBalancer.
+type Violation struct {
+	Rule    Rule
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%v: %v", v.Rule.Name, v.Message)
+}
+
+// Load loads the policy from YAML or JSON file at path.
+func Load(path string) (*Policy, error) {
+	payload, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file due to %v", err)
+	}
+	policy := &Policy{}
+	if err := yaml.Unmarshal(payload, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file due to %v", err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// Validate checks the rules of policy are well-formed.
+func (p *Policy) Validate() error {
+	names := sets.NewString()
+	for _, rule := range p.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("name of policy rule must be specified")
+		}
+		if names.Has(rule.Name) {
+			return fmt.Errorf("policy rule %v is duplicated", rule.Name)
+		}
+		names.Insert(rule.Name)
+		switch rule.Check {
+		case CheckRequireWAF, CheckHTTPSOnly:
+		case CheckDenyCIDRs:
+			if len(rule.CIDRs) == 0 {
+				return fmt.Errorf("policy rule %v must specify cidrs for check %v", rule.Name, rule.Check)
+			}
+		case CheckTags:
+			if len(rule.RequiredTagKeys) == 0 && len(rule.TagValuePatterns) == 0 {
+				return fmt.Errorf("policy rule %v must specify requiredTagKeys or tagValuePatterns for check %v", rule.Name, rule.Check)
+			}
+			for key, pattern := range rule.TagValuePatterns {
+				if _, err := regexp.Compile(pattern); err != nil {
+					return fmt.Errorf("policy rule %v has invalid pattern for tag %v due to %v", rule.Name, key, err)
+				}
+			}
+		default:
+			return fmt.Errorf("policy rule %v has unknown check %v", rule.Name, rule.Check)
+		}
+		if rule.Severity != SeverityError && rule.Severity != SeverityWarning {
+			return fmt.Errorf("policy rule %v must have severity %v or %v", rule.Name, SeverityError, SeverityWarning)
+		}
+		if rule.Scheme != "" && rule.Scheme != elbv2.LoadBalancerSchemeEnumInternal && rule.Scheme != elbv2.LoadBalancerSchemeEnumInternetFacing {
+			return fmt.Errorf("policy rule %v has unknown scheme %v", rule.Name, rule.Scheme)
+		}
+	}
+	return nil
+}
+
+// Evaluate returns the violations of policy by the LoadBalancer configuration lbConfig, and the tags applied to its resources.
+// A nil policy is never violated.
+func (p *Policy) Evaluate(lbConfig *loadbalancer.Config, tags map[string]string) []Violation {
+	if p == nil {
+		return nil
+	}
+	var violations []Violation
+	for _, rule := range p.Rules {
+		if rule.Scheme != "" && rule.Scheme != aws.StringValue(lbConfig.Scheme) {
+			continue
+		}
+		if message, ok := evaluate(rule, lbConfig, tags); !ok {
+			violations = append(violations, Violation{Rule: rule, Message: message})
+		}
+	}
+	return violations
+}
+
+func evaluate(rule Rule, lbConfig *loadbalancer.Config, tags map[string]string) (string, bool) {
+	switch rule.Check {
+	case CheckRequireWAF:
+		if aws.StringValue(lbConfig.WebACLId) == "" {
+			return fmt.Sprintf("%v LoadBalancer must be associated with a WAF web ACL", aws.StringValue(lbConfig.Scheme)), false
+		}
+	case CheckHTTPSOnly:
+		for _, port := range lbConfig.Ports {
+			if port.Scheme != elbv2.ProtocolEnumHttps {
+				return fmt.Sprintf("listener %v:%v is not HTTPS", port.Scheme, port.Port), false
+			}
+		}
+	case CheckDenyCIDRs:
+		// inbound CIDRs are ignored when securityGroups are specified explicitly.
+		if len(lbConfig.SecurityGroups) != 0 {
+			return "", true
+		}
+		denied := sets.NewString(rule.CIDRs...)
+		for _, cidr := range lbConfig.InboundCidrs {
+			if denied.Has(cidr) {
+				return fmt.Sprintf("inbound CIDR %v is not allowed", cidr), false
+			}
+		}
+	case CheckTags:
+		for _, key := range rule.RequiredTagKeys {
+			if _, ok := tags[key]; !ok {
+				return fmt.Sprintf("tag %v is required", key), false
+			}
+		}
+		for _, key := range sets.StringKeySet(rule.TagValuePatterns).List() {
+			value, ok := tags[key]
+			if !ok {
+				continue
+			}
+			pattern := rule.TagValuePatterns[key]
+			if !regexp.MustCompile("^(?:" + pattern + ")$").MatchString(value) {
+				return fmt.Sprintf("value %v of tag %v doesn't match %v", value, key, pattern), false
+			}
+		}
+	}
+	return "", true
+}
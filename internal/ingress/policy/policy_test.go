@@ -0,0 +1,192 @@
+package policy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "policy")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "policy.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`
+rules:
+- name: internet-facing-requires-waf
+  check: require-waf
+  scheme: internet-facing
+  severity: error
+- name: internal-denies-world
+  check: deny-cidrs
+  scheme: internal
+  cidrs: ["0.0.0.0/0"]
+  severity: warning
+`), 0644))
+	policy, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, &Policy{
+		Rules: []Rule{
+			{Name: "internet-facing-requires-waf", Check: CheckRequireWAF, Scheme: "internet-facing", Severity: SeverityError},
+			{Name: "internal-denies-world", Check: CheckDenyCIDRs, Scheme: "internal", CIDRs: []string{"0.0.0.0/0"}, Severity: SeverityWarning},
+		},
+	}, policy)
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		rules         []Rule
+		expectedError string
+	}{
+		{
+			name:  "valid",
+			rules: []Rule{{Name: "https", Check: CheckHTTPSOnly, Severity: SeverityError}},
+		},
+		{
+			name:          "unknown check",
+			rules:         []Rule{{Name: "https", Check: "http-only", Severity: SeverityError}},
+			expectedError: "policy rule https has unknown check http-only",
+		},
+		{
+			name:          "unknown severity",
+			rules:         []Rule{{Name: "https", Check: CheckHTTPSOnly, Severity: "fatal"}},
+			expectedError: "policy rule https must have severity error or warning",
+		},
+		{
+			name:          "missing cidrs",
+			rules:         []Rule{{Name: "cidrs", Check: CheckDenyCIDRs, Severity: SeverityError}},
+			expectedError: "policy rule cidrs must specify cidrs for check deny-cidrs",
+		},
+		{
+			name:          "missing tag keys and patterns",
+			rules:         []Rule{{Name: "tags", Check: CheckTags, Severity: SeverityError}},
+			expectedError: "policy rule tags must specify requiredTagKeys or tagValuePatterns for check tags",
+		},
+		{
+			name:          "invalid tag value pattern",
+			rules:         []Rule{{Name: "tags", Check: CheckTags, TagValuePatterns: map[string]string{"Environment": "prod("}, Severity: SeverityError}},
+			expectedError: "policy rule tags has invalid pattern for tag Environment due to error parsing regexp: missing closing ): `prod(`",
+		},
+		{
+			name: "duplicated name",
+			rules: []Rule{
+				{Name: "https", Check: CheckHTTPSOnly, Severity: SeverityError},
+				{Name: "https", Check: CheckHTTPSOnly, Severity: SeverityWarning},
+			},
+			expectedError: "policy rule https is duplicated",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&Policy{Rules: tc.rules}).Validate()
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	policy := &Policy{
+		Rules: []Rule{
+			{Name: "waf", Check: CheckRequireWAF, Scheme: "internet-facing", Severity: SeverityError},
+			{Name: "https", Check: CheckHTTPSOnly, Severity: SeverityWarning},
+			{Name: "cidrs", Check: CheckDenyCIDRs, Scheme: "internal", CIDRs: []string{"0.0.0.0/0"}, Severity: SeverityError},
+			{Name: "tags", Check: CheckTags, RequiredTagKeys: []string{"CostCenter"}, TagValuePatterns: map[string]string{"Environment": "prod|staging"}, Severity: SeverityError},
+		},
+	}
+	conformingTags := map[string]string{"CostCenter": "1234", "Environment": "prod"}
+	for _, tc := range []struct {
+		name               string
+		lbConfig           *loadbalancer.Config
+		tags               map[string]string
+		expectedViolations []string
+	}{
+		{
+			name: "conforming internet-facing",
+			lbConfig: &loadbalancer.Config{
+				Scheme:       aws.String("internet-facing"),
+				WebACLId:     aws.String("webACLId"),
+				Ports:        []loadbalancer.PortData{{Port: 443, Scheme: "HTTPS"}},
+				InboundCidrs: []string{"0.0.0.0/0"},
+			},
+		},
+		{
+			name: "violating internet-facing",
+			lbConfig: &loadbalancer.Config{
+				Scheme:       aws.String("internet-facing"),
+				Ports:        []loadbalancer.PortData{{Port: 80, Scheme: "HTTP"}, {Port: 443, Scheme: "HTTPS"}},
+				InboundCidrs: []string{"0.0.0.0/0"},
+			},
+			expectedViolations: []string{
+				"waf: internet-facing LoadBalancer must be associated with a WAF web ACL",
+				"https: listener HTTP:80 is not HTTPS",
+			},
+		},
+		{
+			name: "violating internal",
+			lbConfig: &loadbalancer.Config{
+				Scheme:       aws.String("internal"),
+				Ports:        []loadbalancer.PortData{{Port: 443, Scheme: "HTTPS"}},
+				InboundCidrs: []string{"10.0.0.0/8", "0.0.0.0/0"},
+			},
+			expectedViolations: []string{
+				"cidrs: inbound CIDR 0.0.0.0/0 is not allowed",
+			},
+		},
+		{
+			name: "internal with securityGroups",
+			lbConfig: &loadbalancer.Config{
+				Scheme:         aws.String("internal"),
+				Ports:          []loadbalancer.PortData{{Port: 443, Scheme: "HTTPS"}},
+				InboundCidrs:   []string{"0.0.0.0/0"},
+				SecurityGroups: []string{"sg-1"},
+			},
+		},
+		{
+			name: "missing required tag",
+			lbConfig: &loadbalancer.Config{
+				Scheme: aws.String("internal"),
+				Ports:  []loadbalancer.PortData{{Port: 443, Scheme: "HTTPS"}},
+			},
+			tags: map[string]string{"Environment": "prod"},
+			expectedViolations: []string{
+				"tags: tag CostCenter is required",
+			},
+		},
+		{
+			name: "tag value not matching pattern",
+			lbConfig: &loadbalancer.Config{
+				Scheme: aws.String("internal"),
+				Ports:  []loadbalancer.PortData{{Port: 443, Scheme: "HTTPS"}},
+			},
+			tags: map[string]string{"CostCenter": "1234", "Environment": "production"},
+			expectedViolations: []string{
+				"tags: value production of tag Environment doesn't match prod|staging",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var violations []string
+			tags := tc.tags
+			if tags == nil {
+				tags = conformingTags
+			}
+			for _, violation := range policy.Evaluate(tc.lbConfig, tags) {
+				violations = append(violations, violation.String())
+			}
+			assert.Equal(t, tc.expectedViolations, violations)
+		})
+	}
+
+	var nilPolicy *Policy
+	assert.Empty(t, nilPolicy.Evaluate(&loadbalancer.Config{}, nil))
+}
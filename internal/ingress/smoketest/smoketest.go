@@ -0,0 +1,220 @@
+package smoketest
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// wildcardHostLabel substitutes the wildcard label of wildcard hosts, so that requests match the rules of such hosts.
+const wildcardHostLabel = "smoke-test"
+
+// Probe is a request against a LoadBalancer for a host and path of ingress.
+type Probe struct {
+	// URL addresses the LoadBalancer by its DNS name, with the listener port and path of the rule.
+	URL string
+
+	// Host is the Host header of request, it's empty for rules without host.
+	Host string
+}
+
+func (p Probe) String() string {
+	if p.Host == "" {
+		return p.URL
+	}
+	return fmt.Sprintf("%v (host %v)", p.URL, p.Host)
+}
+
+// Prober performs probes.
+type Prober interface {
+	// Probe returns an error if LoadBalancer didn't serve the probe.
+	Probe(ctx context.Context, probe Probe) error
+}
+
+// BuildProbes builds a probe for each listener of model and each host and path of ingress.
+func BuildProbes(ingress *extensions.Ingress, model *lb.LoadBalancer) []Probe {
+	type hostPath struct {
+		host string
+		path string
+	}
+	var hostPaths []hostPath
+	if ingress.Spec.Backend != nil {
+		hostPaths = append(hostPaths, hostPath{path: "/"})
+	}
+	for _, rule := range ingress.Spec.Rules {
+		host := rule.Host
+		if strings.HasPrefix(host, "*.") {
+			host = wildcardHostLabel + host[1:]
+		}
+		if rule.HTTP == nil {
+			hostPaths = append(hostPaths, hostPath{host: host, path: "/"})
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			p := strings.Replace(path.Path, "*", "", -1)
+			if !strings.HasPrefix(p, "/") {
+				p = "/" + p
+			}
+			hostPaths = append(hostPaths, hostPath{host: host, path: p})
+		}
+	}
+
+	var probes []Probe
+	for _, listener := range model.Listeners {
+		scheme := strings.ToLower(listener.Protocol)
+		for _, hp := range hostPaths {
+			probes = append(probes, Probe{
+				URL:  fmt.Sprintf("%v://%v:%v%v", scheme, model.DNSName, listener.Port, hp.path),
+				Host: hp.host,
+			})
+		}
+	}
+	return probes
+}
+
+// NewHTTPProber constructs a Prober that requests LoadBalancers from inside the cluster.
+// Probes fail on connection errors and 5xx responses, which indicate LoadBalancers that cannot reach healthy targets.
+func NewHTTPProber(timeout time.Duration) Prober {
+	return &httpProber{
+		timeout: timeout,
+	}
+}
+
+type httpProber struct {
+	timeout time.Duration
+}
+
+func (p *httpProber) Probe(ctx context.Context, probe Probe) error {
+	req, err := http.NewRequest(http.MethodGet, probe.URL, nil)
+	if err != nil {
+		return err
+	}
+	serverName := probe.Host
+	if probe.Host != "" {
+		req.Host = probe.Host
+	} else {
+		serverName = req.URL.Hostname()
+	}
+	client := &http.Client{
+		Timeout: p.timeout,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			DialContext:     (&net.Dialer{Timeout: p.timeout}).DialContext,
+			TLSClientConfig: &tls.Config{ServerName: serverName},
+		},
+		// redirects, e.g. from HTTP to HTTPS, are served by LoadBalancer itself.
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("unexpected status %v", resp.Status)
+	}
+	return nil
+}
+
+// NewRemoteProber constructs a Prober that delegates probes to an external prober, e.g. one running where clients of LoadBalancers are.
+// The prober is requested with the url and host of probe as query parameters, and must respond 2xx if LoadBalancer served the probe.
+func NewRemoteProber(proberURL string, timeout time.Duration) Prober {
+	return &remoteProber{
+		proberURL: proberURL,
+		client:    &http.Client{Timeout: timeout},
+	}
+}
+
+type remoteProber struct {
+	proberURL string
+	client    *http.Client
+}
+
+func (p *remoteProber) Probe(ctx context.Context, probe Probe) error {
+	u, err := url.Parse(p.proberURL)
+	if err != nil {
+		return err
+	}
+	query := u.Query()
+	query.Set("url", probe.URL)
+	if probe.Host != "" {
+		query.Set("host", probe.Host)
+	}
+	u.RawQuery = query.Encode()
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to request prober due to %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("prober responded %v", resp.Status)
+	}
+	return nil
+}
+
+// Tester runs probes for ingresses in background, and reports the results as events.
+type Tester struct {
+	prober Prober
+
+	mutex sync.Mutex
+	// running contains ingresses whose probes are running.
+	running sets.String
+}
+
+// NewTester constructs new Tester that performs probes with prober.
+func NewTester(prober Prober) *Tester {
+	return &Tester{
+		prober:  prober,
+		running: sets.NewString(),
+	}
+}
+
+// Run performs probes for ingress in background and reports the results with eventf, unless probes for ingress are already running.
+func (t *Tester) Run(ingressKey string, probes []Probe, eventf albctx.Eventf) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if len(probes) == 0 || t.running.Has(ingressKey) {
+		return
+	}
+	t.running.Insert(ingressKey)
+
+	go func() {
+		defer func() {
+			t.mutex.Lock()
+			defer t.mutex.Unlock()
+			t.running.Delete(ingressKey)
+		}()
+		t.probe(probes, eventf)
+	}()
+}
+
+func (t *Tester) probe(probes []Probe, eventf albctx.Eventf) {
+	failures := 0
+	for _, probe := range probes {
+		if err := t.prober.Probe(context.Background(), probe); err != nil {
+			failures++
+			eventf(corev1.EventTypeWarning, "SMOKE_TEST", "smoke test of %v failed due to %v", probe, err)
+		}
+	}
+	if failures == 0 {
+		eventf(corev1.EventTypeNormal, "SMOKE_TEST", "smoke test passed for %v requests", len(probes))
+	}
+}
@@ -0,0 +1,140 @@
+package smoketest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestBuildProbes(t *testing.T) {
+	ingress := &extensions.Ingress{
+		Spec: extensions.IngressSpec{
+			Backend: &extensions.IngressBackend{ServiceName: "default", ServicePort: intstr.FromInt(80)},
+			Rules: []extensions.IngressRule{
+				{
+					Host: "www.example.com",
+					IngressRuleValue: extensions.IngressRuleValue{
+						HTTP: &extensions.HTTPIngressRuleValue{
+							Paths: []extensions.HTTPIngressPath{
+								{Path: "/api/*"},
+								{Path: ""},
+							},
+						},
+					},
+				},
+				{Host: "*.example.com"},
+			},
+		},
+	}
+	model := &lb.LoadBalancer{
+		DNSName: "lb.elb.amazonaws.com",
+		Listeners: []lb.Listener{
+			{Port: 80, Protocol: "HTTP"},
+			{Port: 443, Protocol: "HTTPS"},
+		},
+	}
+	assert.Equal(t, []Probe{
+		{URL: "http://lb.elb.amazonaws.com:80/"},
+		{URL: "http://lb.elb.amazonaws.com:80/api/", Host: "www.example.com"},
+		{URL: "http://lb.elb.amazonaws.com:80/", Host: "www.example.com"},
+		{URL: "http://lb.elb.amazonaws.com:80/", Host: "smoke-test.example.com"},
+		{URL: "https://lb.elb.amazonaws.com:443/"},
+		{URL: "https://lb.elb.amazonaws.com:443/api/", Host: "www.example.com"},
+		{URL: "https://lb.elb.amazonaws.com:443/", Host: "www.example.com"},
+		{URL: "https://lb.elb.amazonaws.com:443/", Host: "smoke-test.example.com"},
+	}, BuildProbes(ingress, model))
+}
+
+func TestHTTPProber(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Host {
+		case "unhealthy.example.com":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "redirect.example.com":
+			http.Redirect(w, r, "https://redirect.example.com/", http.StatusMovedPermanently)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	prober := NewHTTPProber(time.Second)
+	assert.NoError(t, prober.Probe(context.Background(), Probe{URL: server.URL}))
+	assert.NoError(t, prober.Probe(context.Background(), Probe{URL: server.URL, Host: "redirect.example.com"}))
+	assert.EqualError(t, prober.Probe(context.Background(), Probe{URL: server.URL, Host: "unhealthy.example.com"}), "unexpected status 503 Service Unavailable")
+}
+
+func TestRemoteProber(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		if r.URL.Query().Get("host") == "unhealthy.example.com" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	prober := NewRemoteProber(server.URL+"/probe?token=abc", time.Second)
+	assert.NoError(t, prober.Probe(context.Background(), Probe{URL: "http://lb:80/", Host: "www.example.com"}))
+	assert.Equal(t, "host=www.example.com&token=abc&url=http%3A%2F%2Flb%3A80%2F", query)
+	assert.EqualError(t, prober.Probe(context.Background(), Probe{URL: "http://lb:80/", Host: "unhealthy.example.com"}), "prober responded 502 Bad Gateway")
+}
+
+type proberFunc func(ctx context.Context, probe Probe) error
+
+func (f proberFunc) Probe(ctx context.Context, probe Probe) error {
+	return f(ctx, probe)
+}
+
+func TestTester_Run(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		prober         Prober
+		expectedEvents []string
+	}{
+		{
+			name:           "all probes pass",
+			prober:         proberFunc(func(context.Context, Probe) error { return nil }),
+			expectedEvents: []string{"Normal SMOKE_TEST smoke test passed for 2 requests"},
+		},
+		{
+			name: "probes fail",
+			prober: proberFunc(func(_ context.Context, probe Probe) error {
+				if probe.Host == "" {
+					return nil
+				}
+				return errors.New("i/o timeout")
+			}),
+			expectedEvents: []string{"Warning SMOKE_TEST smoke test of http://lb:80/ (host www.example.com) failed due to i/o timeout"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewTester(tc.prober)
+			events := make(chan string, 10)
+			eventf := func(eventType, reason, format string, vals ...interface{}) {
+				events <- fmt.Sprintf("%v %v %v", eventType, reason, fmt.Sprintf(format, vals...))
+			}
+			probes := []Probe{{URL: "http://lb:80/"}, {URL: "http://lb:80/", Host: "www.example.com"}}
+			tester.Run("namespace/ingress", probes, eventf)
+
+			var actualEvents []string
+			for range tc.expectedEvents {
+				select {
+				case e := <-events:
+					actualEvents = append(actualEvents, e)
+				case <-time.After(time.Second):
+				}
+			}
+			assert.Equal(t, tc.expectedEvents, actualEvents)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package standalone
+
+import (
+	"encoding/json"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ingressNamePrefix prefixes the names of ingresses built from LoadBalancers. Names of Kubernetes objects can't contain ':',
+// so the AWS resources of a LoadBalancer never conflict with those of an ingress of the same name.
+const ingressNamePrefix = "loadbalancer:"
+
+// IngressKey returns the key of the ingress built from the LoadBalancer of key.
+func IngressKey(key types.NamespacedName) types.NamespacedName {
+	return types.NamespacedName{Namespace: key.Namespace, Name: ingressNamePrefix + key.Name}
+}
+
+// Ingress returns the ingress built from lb, which is reconciled in place of it.
+// The ingress has the annotations of lb, and its listen-ports annotation is overridden by the listeners of lb.
+func Ingress(lb *LoadBalancer) *extensions.Ingress {
+	key := IngressKey(types.NamespacedName{Namespace: lb.Namespace, Name: lb.Name})
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       key.Namespace,
+			Name:            key.Name,
+			UID:             lb.UID,
+			ResourceVersion: lb.ResourceVersion,
+			Generation:      lb.Generation,
+			Labels:          lb.Labels,
+			Annotations:     make(map[string]string, len(lb.Annotations)+1),
+		},
+	}
+	for k, v := range lb.Annotations {
+		ingress.Annotations[k] = v
+	}
+	if len(lb.Spec.Listeners) != 0 {
+		ports := make([]map[string]int64, 0, len(lb.Spec.Listeners))
+		for _, listener := range lb.Spec.Listeners {
+			ports = append(ports, map[string]int64{listener.Protocol: listener.Port})
+		}
+		// marshaling maps of string keys never fails.
+		payload, _ := json.Marshal(ports)
+		ingress.Annotations[parser.GetAnnotationWithPrefix("listen-ports")] = string(payload)
+	}
+
+	spec := lb.Spec.DeepCopy()
+	ingress.Spec = extensions.IngressSpec{
+		Backend: spec.Backend,
+		Rules:   spec.Rules,
+		TLS:     spec.TLS,
+	}
+	return ingress
+}
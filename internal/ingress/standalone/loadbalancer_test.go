@@ -0,0 +1,90 @@
+package standalone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestIngressKey(t *testing.T) {
+	key := IngressKey(types.NamespacedName{Namespace: "namespace", Name: "lb"})
+	assert.Equal(t, types.NamespacedName{Namespace: "namespace", Name: "loadbalancer:lb"}, key)
+}
+
+func TestIngress(t *testing.T) {
+	backend := extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(80)}
+	rules := []extensions.IngressRule{
+		{
+			Host: "example.com",
+			IngressRuleValue: extensions.IngressRuleValue{
+				HTTP: &extensions.HTTPIngressRuleValue{
+					Paths: []extensions.HTTPIngressPath{{Path: "/api", Backend: backend}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		lb     *LoadBalancer
+		expect *extensions.Ingress
+	}{
+		{
+			name: "without listeners",
+			lb: &LoadBalancer{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "namespace",
+					Name:        "lb",
+					Annotations: map[string]string{"alb.ingress.kubernetes.io/scheme": "internal"},
+				},
+				Spec: LoadBalancerSpec{Backend: &backend},
+			},
+			expect: &extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "namespace",
+					Name:        "loadbalancer:lb",
+					Annotations: map[string]string{"alb.ingress.kubernetes.io/scheme": "internal"},
+				},
+				Spec: extensions.IngressSpec{Backend: &backend},
+			},
+		},
+		{
+			name: "listeners override listen-ports annotation",
+			lb: &LoadBalancer{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "namespace",
+					Name:      "lb",
+					Annotations: map[string]string{
+						"kubernetes.io/ingress.class":            "alb",
+						"alb.ingress.kubernetes.io/listen-ports": `[{"HTTP": 8080}]`,
+					},
+				},
+				Spec: LoadBalancerSpec{
+					Listeners: []Listener{{Protocol: "HTTP", Port: 80}, {Protocol: "HTTPS", Port: 443}},
+					Rules:     rules,
+				},
+			},
+			expect: &extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "namespace",
+					Name:      "loadbalancer:lb",
+					Annotations: map[string]string{
+						"kubernetes.io/ingress.class":            "alb",
+						"alb.ingress.kubernetes.io/listen-ports": `[{"HTTP":80},{"HTTPS":443}]`,
+					},
+				},
+				Spec: extensions.IngressSpec{Rules: rules},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			original := tc.lb.DeepCopy()
+			assert.Equal(t, tc.expect, Ingress(tc.lb))
+			assert.Equal(t, original, tc.lb)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package standalone
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// SchemeGroupVersion is the group version of LoadBalancer.
+	SchemeGroupVersion = schema.GroupVersion{Group: "alb.ingress.kubernetes.io", Version: "v1alpha1"}
+
+	// SchemeBuilder registers LoadBalancer into schemes.
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+
+	// AddToScheme adds LoadBalancer to scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion, &LoadBalancer{}, &LoadBalancerList{})
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	return nil
+}
+
+// LoadBalancer provisions an ALB with its listeners and targetGroups without an ingress.
+// It's configured by the same annotations as ingresses, including the ingress class.
+type LoadBalancer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LoadBalancerSpec   `json:"spec,omitempty"`
+	Status LoadBalancerStatus `json:"status,omitempty"`
+}
+
+// LoadBalancerSpec defines the listeners of LoadBalancer, and how their requests are routed to services.
+type LoadBalancerSpec struct {
+	// Listeners are the listeners of the ALB, they override the listen-ports annotation.
+	Listeners []Listener `json:"listeners,omitempty"`
+	// Backend receives the requests matching no rule.
+	Backend *extensions.IngressBackend `json:"backend,omitempty"`
+	// Rules route the requests of all listeners to backends by host and path.
+	Rules []extensions.IngressRule `json:"rules,omitempty"`
+	// TLS configures the hosts of certificates requested or imported into ACM.
+	TLS []extensions.IngressTLS `json:"tls,omitempty"`
+}
+
+// Listener is a listener of the ALB.
+type Listener struct {
+	// Protocol is HTTP or HTTPS.
+	Protocol string `json:"protocol"`
+	// Port is the port of the listener.
+	Port int64 `json:"port"`
+}
+
+// LoadBalancerStatus is the observed state of LoadBalancer.
+type LoadBalancerStatus struct {
+	// LoadBalancer contains the DNS name of the ALB once it's provisioned.
+	LoadBalancer corev1.LoadBalancerStatus `json:"loadBalancer,omitempty"`
+}
+
+// LoadBalancerList is a list of LoadBalancer.
+type LoadBalancerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []LoadBalancer `json:"items"`
+}
+
+// DeepCopyInto copies l into out.
+func (l *LoadBalancer) DeepCopyInto(out *LoadBalancer) {
+	*out = *l
+	out.TypeMeta = l.TypeMeta
+	l.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	l.Spec.DeepCopyInto(&out.Spec)
+	l.Status.LoadBalancer.DeepCopyInto(&out.Status.LoadBalancer)
+}
+
+// DeepCopy returns a deep copy of l.
+func (l *LoadBalancer) DeepCopy() *LoadBalancer {
+	if l == nil {
+		return nil
+	}
+	out := new(LoadBalancer)
+	l.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (l *LoadBalancer) DeepCopyObject() runtime.Object {
+	if c := l.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto copies s into out.
+func (s *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
+	*out = *s
+	if s.Listeners != nil {
+		out.Listeners = make([]Listener, len(s.Listeners))
+		copy(out.Listeners, s.Listeners)
+	}
+	if s.Backend != nil {
+		out.Backend = s.Backend.DeepCopy()
+	}
+	if s.Rules != nil {
+		out.Rules = make([]extensions.IngressRule, len(s.Rules))
+		for i := range s.Rules {
+			s.Rules[i].DeepCopyInto(&out.Rules[i])
+		}
+	}
+	if s.TLS != nil {
+		out.TLS = make([]extensions.IngressTLS, len(s.TLS))
+		for i := range s.TLS {
+			s.TLS[i].DeepCopyInto(&out.TLS[i])
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of s.
+func (s *LoadBalancerSpec) DeepCopy() *LoadBalancerSpec {
+	if s == nil {
+		return nil
+	}
+	out := new(LoadBalancerSpec)
+	s.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto copies l into out.
+func (l *LoadBalancerList) DeepCopyInto(out *LoadBalancerList) {
+	*out = *l
+	out.TypeMeta = l.TypeMeta
+	l.ListMeta.DeepCopyInto(&out.ListMeta)
+	if l.Items != nil {
+		out.Items = make([]LoadBalancer, len(l.Items))
+		for i := range l.Items {
+			l.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+}
+
+// DeepCopy returns a deep copy of l.
+func (l *LoadBalancerList) DeepCopy() *LoadBalancerList {
+	if l == nil {
+		return nil
+	}
+	out := new(LoadBalancerList)
+	l.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (l *LoadBalancerList) DeepCopyObject() runtime.Object {
+	if c := l.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
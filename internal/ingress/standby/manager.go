@@ -0,0 +1,133 @@
+package standby
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// Same timings as the leader election of controller-runtime, so replicas of both modes can take over from each other.
+const (
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+
+	inClusterNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// Options configures the leader election of Manager.
+type Options struct {
+	LeaderElectionID        string
+	LeaderElectionNamespace string
+}
+
+// Manager is a manager.Manager for replicas running on standby.
+// The wrapped manager must be created without leader election, so its cache starts and syncs on every replica.
+// Runnables added to Manager, such as controllers, only start once the replica is elected leader,
+// while events keep filling the queues of controllers, so a new leader reconciles without waiting for caches to sync.
+type Manager struct {
+	manager.Manager
+
+	elector *leaderelection.LeaderElector
+	elected chan struct{}
+}
+
+// New creates a Manager wrapping mgr, which elects leader with the configMap lock named by opts.
+func New(mgr manager.Manager, opts Options) (*Manager, error) {
+	namespace := opts.LeaderElectionNamespace
+	if namespace == "" {
+		b, err := ioutil.ReadFile(inClusterNamespacePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine leader election namespace due to %v", err)
+		}
+		namespace = strings.TrimSpace(string(b))
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	clientSet, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+	lock, err := resourcelock.New(resourcelock.ConfigMapsResourceLock, namespace, opts.LeaderElectionID, clientSet.CoreV1(),
+		resourcelock.ResourceLockConfig{
+			Identity:      hostname + "_" + string(uuid.NewUUID()),
+			EventRecorder: mgr.GetRecorder(opts.LeaderElectionID),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manager{
+		Manager: mgr,
+		elected: make(chan struct{}),
+	}
+	m.elector, err = leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: leaseDuration,
+		RenewDeadline: renewDeadline,
+		RetryPeriod:   retryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(_ context.Context) {
+				glog.Infof("elected leader with identity %v", lock.Identity())
+				close(m.elected)
+			},
+			OnStoppedLeading: func() {
+				glog.Fatal("leader election lost")
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Add adds runnable to the wrapped manager, to be started once elected leader.
+// Informers are started right away to keep their caches warm.
+func (m *Manager) Add(runnable manager.Runnable) error {
+	if _, ok := runnable.(cache.SharedIndexInformer); ok {
+		return m.Manager.Add(runnable)
+	}
+	if err := m.Manager.SetFields(runnable); err != nil {
+		return err
+	}
+	return m.Manager.Add(&leaderRunnable{runnable: runnable, elected: m.elected})
+}
+
+// Start runs leader election and the wrapped manager until stop is closed.
+func (m *Manager) Start(stop <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go m.elector.Run(ctx)
+	glog.Infof("waiting on standby for leader election, caches are synced meanwhile")
+	return m.Manager.Start(stop)
+}
+
+// leaderRunnable starts runnable once elected is closed.
+type leaderRunnable struct {
+	runnable manager.Runnable
+	elected  <-chan struct{}
+}
+
+// Start waits for leadership, then starts runnable until stop is closed.
+func (r *leaderRunnable) Start(stop <-chan struct{}) error {
+	select {
+	case <-r.elected:
+		return r.runnable.Start(stop)
+	case <-stop:
+		return nil
+	}
+}
@@ -0,0 +1,85 @@
+package standby
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+type fakeManager struct {
+	manager.Manager
+	runnables []manager.Runnable
+	fields    []interface{}
+}
+
+func (m *fakeManager) Add(runnable manager.Runnable) error {
+	m.runnables = append(m.runnables, runnable)
+	return nil
+}
+
+func (m *fakeManager) SetFields(i interface{}) error {
+	m.fields = append(m.fields, i)
+	return nil
+}
+
+type runnableFunc func(stop <-chan struct{}) error
+
+func (f runnableFunc) Start(stop <-chan struct{}) error {
+	return f(stop)
+}
+
+type informerRunnable struct {
+	cache.SharedIndexInformer
+}
+
+func (r informerRunnable) Start(stop <-chan struct{}) error {
+	return nil
+}
+
+func TestManager_Add(t *testing.T) {
+	mgr := &fakeManager{}
+	m := &Manager{Manager: mgr, elected: make(chan struct{})}
+
+	informer := informerRunnable{cache.NewSharedIndexInformer(&cache.ListWatch{}, &corev1.Pod{}, 0, cache.Indexers{})}
+	assert.NoError(t, m.Add(informer))
+	started := make(chan struct{})
+	controller := runnableFunc(func(stop <-chan struct{}) error {
+		close(started)
+		return nil
+	})
+	assert.NoError(t, m.Add(controller))
+
+	assert.Len(t, mgr.runnables, 2)
+	assert.Equal(t, informer, mgr.runnables[0])
+	assert.Len(t, mgr.fields, 1)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	errs := make(chan error)
+	go func() { errs <- mgr.runnables[1].Start(stop) }()
+	select {
+	case <-started:
+		t.Fatal("runnable started before elected leader")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(m.elected)
+	<-started
+	assert.NoError(t, <-errs)
+}
+
+func TestLeaderRunnable_Stopped(t *testing.T) {
+	stop := make(chan struct{})
+	close(stop)
+	r := &leaderRunnable{
+		runnable: runnableFunc(func(stop <-chan struct{}) error {
+			t.Fatal("runnable started without being elected leader")
+			return nil
+		}),
+		elected: make(chan struct{}),
+	}
+	assert.NoError(t, r.Start(stop))
+}
@@ -2,11 +2,16 @@
 
 package mocks
 
+import acm "github.com/aws/aws-sdk-go/service/acm"
+import cloudwatch "github.com/aws/aws-sdk-go/service/cloudwatch"
 import context "context"
 import ec2 "github.com/aws/aws-sdk-go/service/ec2"
 import elbv2 "github.com/aws/aws-sdk-go/service/elbv2"
 import mock "github.com/stretchr/testify/mock"
 import resourcegroupstaggingapi "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+import route53 "github.com/aws/aws-sdk-go/service/route53"
+import s3 "github.com/aws/aws-sdk-go/service/s3"
+import time "time"
 import types "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
 import waf "github.com/aws/aws-sdk-go/service/waf"
 import wafregional "github.com/aws/aws-sdk-go/service/wafregional"
@@ -30,6 +35,22 @@ func (_m *CloudAPI) ACMAvailable() bool {
 	return r0
 }
 
+// AccessLogsDeliveryPrincipal provides a mock function with given fields:
+func (_m *CloudAPI) AccessLogsDeliveryPrincipal() map[string]string {
+	ret := _m.Called()
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func() map[string]string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	return r0
+}
+
 // AddELBV2TagsWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) AddELBV2TagsWithContext(_a0 context.Context, _a1 *elbv2.AddTagsInput) (*elbv2.AddTagsOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -76,6 +97,29 @@ func (_m *CloudAPI) AddListenerCertificates(_a0 context.Context, _a1 *elbv2.AddL
 	return r0, r1
 }
 
+// AddTagsToCertificateWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) AddTagsToCertificateWithContext(_a0 context.Context, _a1 *acm.AddTagsToCertificateInput) (*acm.AddTagsToCertificateOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *acm.AddTagsToCertificateOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *acm.AddTagsToCertificateInput) *acm.AddTagsToCertificateOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*acm.AddTagsToCertificateOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *acm.AddTagsToCertificateInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AssociateWAF provides a mock function with given fields: ctx, resourceArn, webACLId
 func (_m *CloudAPI) AssociateWAF(ctx context.Context, resourceArn *string, webACLId *string) (*wafregional.AssociateWebACLOutput, error) {
 	ret := _m.Called(ctx, resourceArn, webACLId)
@@ -99,6 +143,29 @@ func (_m *CloudAPI) AssociateWAF(ctx context.Context, resourceArn *string, webAC
 	return r0, r1
 }
 
+// AuthorizeSecurityGroupEgressWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) AuthorizeSecurityGroupEgressWithContext(_a0 context.Context, _a1 *ec2.AuthorizeSecurityGroupEgressInput) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *ec2.AuthorizeSecurityGroupEgressOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.AuthorizeSecurityGroupEgressInput) *ec2.AuthorizeSecurityGroupEgressOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ec2.AuthorizeSecurityGroupEgressOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ec2.AuthorizeSecurityGroupEgressInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AuthorizeSecurityGroupIngressWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) AuthorizeSecurityGroupIngressWithContext(_a0 context.Context, _a1 *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -122,6 +189,43 @@ func (_m *CloudAPI) AuthorizeSecurityGroupIngressWithContext(_a0 context.Context
 	return r0, r1
 }
 
+// ChangeResourceRecordSetsWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) ChangeResourceRecordSetsWithContext(_a0 context.Context, _a1 *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *route53.ChangeResourceRecordSetsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *route53.ChangeResourceRecordSetsInput) *route53.ChangeResourceRecordSetsOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*route53.ChangeResourceRecordSetsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *route53.ChangeResourceRecordSetsInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateBucket provides a mock function with given fields: ctx, bucket
+func (_m *CloudAPI) CreateBucket(ctx context.Context, bucket string) error {
+	ret := _m.Called(ctx, bucket)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, bucket)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateEC2TagsWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) CreateEC2TagsWithContext(_a0 context.Context, _a1 *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -283,6 +387,52 @@ func (_m *CloudAPI) DeleteEC2TagsWithContext(_a0 context.Context, _a1 *ec2.Delet
 	return r0, r1
 }
 
+// DeleteAlarmsWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) DeleteAlarmsWithContext(_a0 context.Context, _a1 *cloudwatch.DeleteAlarmsInput) (*cloudwatch.DeleteAlarmsOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *cloudwatch.DeleteAlarmsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *cloudwatch.DeleteAlarmsInput) *cloudwatch.DeleteAlarmsOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cloudwatch.DeleteAlarmsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *cloudwatch.DeleteAlarmsInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteCertificateWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) DeleteCertificateWithContext(_a0 context.Context, _a1 *acm.DeleteCertificateInput) (*acm.DeleteCertificateOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *acm.DeleteCertificateOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *acm.DeleteCertificateInput) *acm.DeleteCertificateOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*acm.DeleteCertificateOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *acm.DeleteCertificateInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteListenersByArn provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) DeleteListenersByArn(_a0 context.Context, _a1 string) error {
 	ret := _m.Called(_a0, _a1)
@@ -385,6 +535,29 @@ func (_m *CloudAPI) DeregisterTargetsWithContext(_a0 context.Context, _a1 *elbv2
 	return r0, r1
 }
 
+// DescribeCertificateWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) DescribeCertificateWithContext(_a0 context.Context, _a1 *acm.DescribeCertificateInput) (*acm.DescribeCertificateOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *acm.DescribeCertificateOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *acm.DescribeCertificateInput) *acm.DescribeCertificateOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*acm.DescribeCertificateOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *acm.DescribeCertificateInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DescribeELBV2TagsWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) DescribeELBV2TagsWithContext(_a0 context.Context, _a1 *elbv2.DescribeTagsInput) (*elbv2.DescribeTagsOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -523,6 +696,124 @@ func (_m *CloudAPI) DisassociateWAF(ctx context.Context, resourceArn *string) (*
 	return r0, r1
 }
 
+// FlushCache provides a mock function with given fields:
+func (_m *CloudAPI) FlushCache() {
+	_m.Called()
+}
+
+// GetAlarmsByPrefix provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) GetAlarmsByPrefix(_a0 context.Context, _a1 string) ([]*cloudwatch.MetricAlarm, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []*cloudwatch.MetricAlarm
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*cloudwatch.MetricAlarm); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*cloudwatch.MetricAlarm)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAverageConsumedLCUs provides a mock function with given fields: ctx, lbArn, duration
+func (_m *CloudAPI) GetAverageConsumedLCUs(ctx context.Context, lbArn string, duration time.Duration) (float64, error) {
+	ret := _m.Called(ctx, lbArn, duration)
+
+	var r0 float64
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) float64); ok {
+		r0 = rf(ctx, lbArn, duration)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, lbArn, duration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBucketEncryptionWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) GetBucketEncryptionWithContext(_a0 context.Context, _a1 *s3.GetBucketEncryptionInput) (*s3.GetBucketEncryptionOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *s3.GetBucketEncryptionOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *s3.GetBucketEncryptionInput) *s3.GetBucketEncryptionOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*s3.GetBucketEncryptionOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *s3.GetBucketEncryptionInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBucketLifecycleConfigurationWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) GetBucketLifecycleConfigurationWithContext(_a0 context.Context, _a1 *s3.GetBucketLifecycleConfigurationInput) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *s3.GetBucketLifecycleConfigurationOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *s3.GetBucketLifecycleConfigurationInput) *s3.GetBucketLifecycleConfigurationOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*s3.GetBucketLifecycleConfigurationOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *s3.GetBucketLifecycleConfigurationInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBucketPolicyWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) GetBucketPolicyWithContext(_a0 context.Context, _a1 *s3.GetBucketPolicyInput) (*s3.GetBucketPolicyOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *s3.GetBucketPolicyOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *s3.GetBucketPolicyInput) *s3.GetBucketPolicyOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*s3.GetBucketPolicyOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *s3.GetBucketPolicyInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetClusterSubnets provides a mock function with given fields:
 func (_m *CloudAPI) GetClusterSubnets() (map[string]types.EC2Tags, error) {
 	ret := _m.Called()
@@ -546,6 +837,27 @@ func (_m *CloudAPI) GetClusterSubnets() (map[string]types.EC2Tags, error) {
 	return r0, r1
 }
 
+// GetInstanceIDByPrivateDNSName provides a mock function with given fields: _a0
+func (_m *CloudAPI) GetInstanceIDByPrivateDNSName(_a0 string) (string, error) {
+	ret := _m.Called(_a0)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetInstancesByIDs provides a mock function with given fields: _a0
 func (_m *CloudAPI) GetInstancesByIDs(_a0 []string) ([]*ec2.Instance, error) {
 	ret := _m.Called(_a0)
@@ -569,6 +881,34 @@ func (_m *CloudAPI) GetInstancesByIDs(_a0 []string) ([]*ec2.Instance, error) {
 	return r0, r1
 }
 
+// GetLatestTargetGroupStatistic provides a mock function with given fields: ctx, lbArn, tgArn, metricName, statistic
+func (_m *CloudAPI) GetLatestTargetGroupStatistic(ctx context.Context, lbArn string, tgArn string, metricName string, statistic string) (float64, bool, error) {
+	ret := _m.Called(ctx, lbArn, tgArn, metricName, statistic)
+
+	var r0 float64
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) float64); ok {
+		r0 = rf(ctx, lbArn, tgArn, metricName, statistic)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) bool); ok {
+		r1 = rf(ctx, lbArn, tgArn, metricName, statistic)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string, string) error); ok {
+		r2 = rf(ctx, lbArn, tgArn, metricName, statistic)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetLoadBalancerByArn provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) GetLoadBalancerByArn(_a0 context.Context, _a1 string) (*elbv2.LoadBalancer, error) {
 	ret := _m.Called(_a0, _a1)
@@ -645,6 +985,29 @@ func (_m *CloudAPI) GetResourcesByFilters(tagFilters map[string][]string, resour
 	return r0, r1
 }
 
+// GetRouteTablesBySubnetID provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) GetRouteTablesBySubnetID(_a0 context.Context, _a1 []string) (map[string]*ec2.RouteTable, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 map[string]*ec2.RouteTable
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]*ec2.RouteTable); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*ec2.RouteTable)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRules provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) GetRules(_a0 context.Context, _a1 string) ([]*elbv2.Rule, error) {
 	ret := _m.Called(_a0, _a1)
@@ -829,6 +1192,75 @@ func (_m *CloudAPI) GetWebACLSummary(ctx context.Context, resourceArn *string) (
 	return r0, r1
 }
 
+// GetZoneIDsByName provides a mock function with given fields: _a0
+func (_m *CloudAPI) GetZoneIDsByName(_a0 context.Context) (map[string]string, error) {
+	ret := _m.Called(_a0)
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func(context.Context) map[string]string); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HeadBucketWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) HeadBucketWithContext(_a0 context.Context, _a1 *s3.HeadBucketInput) (*s3.HeadBucketOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *s3.HeadBucketOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *s3.HeadBucketInput) *s3.HeadBucketOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*s3.HeadBucketOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *s3.HeadBucketInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportCertificateWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) ImportCertificateWithContext(_a0 context.Context, _a1 *acm.ImportCertificateInput) (*acm.ImportCertificateOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *acm.ImportCertificateOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *acm.ImportCertificateInput) *acm.ImportCertificateOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*acm.ImportCertificateOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *acm.ImportCertificateInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsNodeHealthy provides a mock function with given fields: _a0
 func (_m *CloudAPI) IsNodeHealthy(_a0 string) (bool, error) {
 	ret := _m.Called(_a0)
@@ -850,6 +1282,29 @@ func (_m *CloudAPI) IsNodeHealthy(_a0 string) (bool, error) {
 	return r0, r1
 }
 
+// ListIssuedCertificates provides a mock function with given fields: ctx
+func (_m *CloudAPI) ListIssuedCertificates(ctx context.Context) ([]*acm.CertificateSummary, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*acm.CertificateSummary
+	if rf, ok := ret.Get(0).(func(context.Context) []*acm.CertificateSummary); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*acm.CertificateSummary)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListListenersByLoadBalancer provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) ListListenersByLoadBalancer(_a0 context.Context, _a1 string) ([]*elbv2.Listener, error) {
 	ret := _m.Called(_a0, _a1)
@@ -873,6 +1328,29 @@ func (_m *CloudAPI) ListListenersByLoadBalancer(_a0 context.Context, _a1 string)
 	return r0, r1
 }
 
+// ListTagsForCertificateWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) ListTagsForCertificateWithContext(_a0 context.Context, _a1 *acm.ListTagsForCertificateInput) (*acm.ListTagsForCertificateOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *acm.ListTagsForCertificateOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *acm.ListTagsForCertificateInput) *acm.ListTagsForCertificateOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*acm.ListTagsForCertificateOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *acm.ListTagsForCertificateInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ModifyListenerWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) ModifyListenerWithContext(_a0 context.Context, _a1 *elbv2.ModifyListenerInput) (*elbv2.ModifyListenerOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1011,6 +1489,112 @@ func (_m *CloudAPI) ModifyTargetGroupWithContext(_a0 context.Context, _a1 *elbv2
 	return r0, r1
 }
 
+// PutBucketEncryptionWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) PutBucketEncryptionWithContext(_a0 context.Context, _a1 *s3.PutBucketEncryptionInput) (*s3.PutBucketEncryptionOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *s3.PutBucketEncryptionOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *s3.PutBucketEncryptionInput) *s3.PutBucketEncryptionOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*s3.PutBucketEncryptionOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *s3.PutBucketEncryptionInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PutBucketLifecycleConfigurationWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) PutBucketLifecycleConfigurationWithContext(_a0 context.Context, _a1 *s3.PutBucketLifecycleConfigurationInput) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *s3.PutBucketLifecycleConfigurationOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *s3.PutBucketLifecycleConfigurationInput) *s3.PutBucketLifecycleConfigurationOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*s3.PutBucketLifecycleConfigurationOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *s3.PutBucketLifecycleConfigurationInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PutBucketPolicyWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) PutBucketPolicyWithContext(_a0 context.Context, _a1 *s3.PutBucketPolicyInput) (*s3.PutBucketPolicyOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *s3.PutBucketPolicyOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *s3.PutBucketPolicyInput) *s3.PutBucketPolicyOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*s3.PutBucketPolicyOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *s3.PutBucketPolicyInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PutMetricAlarmWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) PutMetricAlarmWithContext(_a0 context.Context, _a1 *cloudwatch.PutMetricAlarmInput) (*cloudwatch.PutMetricAlarmOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *cloudwatch.PutMetricAlarmOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *cloudwatch.PutMetricAlarmInput) *cloudwatch.PutMetricAlarmOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cloudwatch.PutMetricAlarmOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *cloudwatch.PutMetricAlarmInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefreshInventory provides a mock function with given fields: ctx
+func (_m *CloudAPI) RefreshInventory(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RegisterTargetsWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) RegisterTargetsWithContext(_a0 context.Context, _a1 *elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1080,6 +1664,52 @@ func (_m *CloudAPI) RemoveListenerCertificates(_a0 context.Context, _a1 *elbv2.R
 	return r0, r1
 }
 
+// RequestCertificateWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) RequestCertificateWithContext(_a0 context.Context, _a1 *acm.RequestCertificateInput) (*acm.RequestCertificateOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *acm.RequestCertificateOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *acm.RequestCertificateInput) *acm.RequestCertificateOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*acm.RequestCertificateOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *acm.RequestCertificateInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeSecurityGroupEgressWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) RevokeSecurityGroupEgressWithContext(_a0 context.Context, _a1 *ec2.RevokeSecurityGroupEgressInput) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *ec2.RevokeSecurityGroupEgressOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.RevokeSecurityGroupEgressInput) *ec2.RevokeSecurityGroupEgressOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ec2.RevokeSecurityGroupEgressOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ec2.RevokeSecurityGroupEgressInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RevokeSecurityGroupIngressWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) RevokeSecurityGroupIngressWithContext(_a0 context.Context, _a1 *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1103,6 +1733,20 @@ func (_m *CloudAPI) RevokeSecurityGroupIngressWithContext(_a0 context.Context, _
 	return r0, r1
 }
 
+// S3ObjectArn provides a mock function with given fields: bucket, key
+func (_m *CloudAPI) S3ObjectArn(bucket string, key string) string {
+	ret := _m.Called(bucket, key)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(bucket, key)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // SetIpAddressTypeWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) SetIpAddressTypeWithContext(_a0 context.Context, _a1 *elbv2.SetIpAddressTypeInput) (*elbv2.SetIpAddressTypeOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1126,6 +1770,29 @@ func (_m *CloudAPI) SetIpAddressTypeWithContext(_a0 context.Context, _a1 *elbv2.
 	return r0, r1
 }
 
+// SetRulePrioritiesWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) SetRulePrioritiesWithContext(_a0 context.Context, _a1 *elbv2.SetRulePrioritiesInput) (*elbv2.SetRulePrioritiesOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *elbv2.SetRulePrioritiesOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *elbv2.SetRulePrioritiesInput) *elbv2.SetRulePrioritiesOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.SetRulePrioritiesOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *elbv2.SetRulePrioritiesInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetSecurityGroupsWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) SetSecurityGroupsWithContext(_a0 context.Context, _a1 *elbv2.SetSecurityGroupsInput) (*elbv2.SetSecurityGroupsOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1282,6 +1949,43 @@ func (_m *CloudAPI) UntagResourcesWithContext(_a0 context.Context, _a1 *resource
 	return r0, r1
 }
 
+// UpdateSecurityGroupRuleDescriptionsIngressWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) UpdateSecurityGroupRuleDescriptionsIngressWithContext(_a0 context.Context, _a1 *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput) (*ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput) *ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ec2.UpdateSecurityGroupRuleDescriptionsIngressOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ec2.UpdateSecurityGroupRuleDescriptionsIngressInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateARN provides a mock function with given fields: arn
+func (_m *CloudAPI) ValidateARN(arn string) error {
+	ret := _m.Called(arn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(arn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // WAFRegionalAvailable provides a mock function with given fields:
 func (_m *CloudAPI) WAFRegionalAvailable() bool {
 	ret := _m.Called()
@@ -1296,6 +2000,20 @@ func (_m *CloudAPI) WAFRegionalAvailable() bool {
 	return r0
 }
 
+// WaitLoadBalancerActive provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) WaitLoadBalancerActive(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // WebACLExists provides a mock function with given fields: ctx, webACLId
 func (_m *CloudAPI) WebACLExists(ctx context.Context, webACLId *string) (bool, error) {
 	ret := _m.Called(ctx, webACLId)
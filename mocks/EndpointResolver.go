@@ -2,6 +2,7 @@
 
 package mocks
 
+import context "context"
 import elbv2 "github.com/aws/aws-sdk-go/service/elbv2"
 import mock "github.com/stretchr/testify/mock"
 import v1beta1 "k8s.io/api/extensions/v1beta1"
@@ -33,3 +34,26 @@ func (_m *EndpointResolver) Resolve(_a0 *v1beta1.Ingress, _a1 *v1beta1.IngressBa
 
 	return r0, r1
 }
+
+// ResolveLive provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *EndpointResolver) ResolveLive(_a0 context.Context, _a1 *v1beta1.Ingress, _a2 *v1beta1.IngressBackend, _a3 string) ([]*elbv2.TargetDescription, error) {
+	ret := _m.Called(_a0, _a1, _a2, _a3)
+
+	var r0 []*elbv2.TargetDescription
+	if rf, ok := ret.Get(0).(func(context.Context, *v1beta1.Ingress, *v1beta1.IngressBackend, string) []*elbv2.TargetDescription); ok {
+		r0 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*elbv2.TargetDescription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *v1beta1.Ingress, *v1beta1.IngressBackend, string) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
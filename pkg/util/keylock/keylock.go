@@ -0,0 +1,48 @@
+package keylock
+
+import "sync"
+
+// KeyLock provides a mutex per key, e.g. per name of resources to create, locks of keys are freed once unlocked.
+type KeyLock struct {
+	mutex sync.Mutex
+	locks map[string]*lock
+}
+
+type lock struct {
+	sync.Mutex
+	// waiters is the count of holders of lock, and callers of Lock waiting for it.
+	waiters int
+}
+
+// New constructs a new KeyLock.
+func New() *KeyLock {
+	return &KeyLock{locks: make(map[string]*lock)}
+}
+
+// Lock locks key, blocking until it is unlocked if it is already locked.
+func (k *KeyLock) Lock(key string) {
+	k.mutex.Lock()
+	l, ok := k.locks[key]
+	if !ok {
+		l = &lock{}
+		k.locks[key] = l
+	}
+	l.waiters++
+	k.mutex.Unlock()
+	l.Lock()
+}
+
+// Unlock unlocks key, it panics if key isn't locked.
+func (k *KeyLock) Unlock(key string) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+	l, ok := k.locks[key]
+	if !ok {
+		panic("keylock: unlock of unlocked key " + key)
+	}
+	l.waiters--
+	if l.waiters == 0 {
+		delete(k.locks, key)
+	}
+	l.Unlock()
+}
@@ -0,0 +1,36 @@
+package keylock
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyLock(t *testing.T) {
+	k := New()
+	counts := map[string]*int{"a": new(int), "b": new(int)}
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		for _, key := range []string{"a", "b"} {
+			wg.Add(1)
+			go func(key string) {
+				defer wg.Done()
+				k.Lock(key)
+				defer k.Unlock(key)
+				*counts[key]++
+			}(key)
+		}
+	}
+	wg.Wait()
+	assert.Equal(t, 100, *counts["a"])
+	assert.Equal(t, 100, *counts["b"])
+	assert.Empty(t, k.locks)
+}
+
+func TestKeyLock_Unlock(t *testing.T) {
+	k := New()
+	k.Lock("a")
+	k.Unlock("a")
+	assert.Panics(t, func() { k.Unlock("a") })
+}
@@ -0,0 +1,116 @@
+package log
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Level is the minimum severity of the messages logged by a module.
+type Level int
+
+const (
+	// LevelUnset is the level of modules without a level, which log debug messages only when glog verbosity is at least 2.
+	LevelUnset Level = iota
+	LevelDebug
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = map[string]Level{
+	"debug": LevelDebug,
+	"info":  LevelInfo,
+	"warn":  LevelWarn,
+	"error": LevelError,
+}
+
+// defaultModule is the key of the level applied to modules without their own level.
+const defaultModule = "*"
+
+// Levels are the log levels of modules, keyed by module. A module is the package logging the message, identified by its
+// directory name, e.g. `ls` for the listener and rule reconciliation of internal/alb/ls.
+// It implements pflag.Value, parsing comma separated module=level pairs, where a level without module applies to other modules.
+type Levels map[string]Level
+
+func (l *Levels) String() string {
+	var pairs []string
+	for module, level := range *l {
+		pairs = append(pairs, fmt.Sprintf("%v=%v", module, level))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (l *Levels) Set(value string) error {
+	if *l == nil {
+		*l = make(Levels)
+	}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		module, name := defaultModule, pair
+		if i := strings.Index(pair, "="); i >= 0 {
+			module, name = strings.TrimSpace(pair[:i]), strings.TrimSpace(pair[i+1:])
+		}
+		level, ok := levelNames[strings.ToLower(name)]
+		if !ok || module == "" {
+			return fmt.Errorf("invalid log level %q, must be module=level or level, with level one of debug, info, warn or error", pair)
+		}
+		(*l)[module] = level
+	}
+	return nil
+}
+
+func (l *Levels) Type() string {
+	return "levels"
+}
+
+func (level Level) String() string {
+	for name, l := range levelNames {
+		if l == level {
+			return name
+		}
+	}
+	return "unset"
+}
+
+var (
+	levelsMutex sync.RWMutex
+	levels      Levels
+
+	// modulesByPC caches the modules of callers, keyed by their program counter.
+	modulesByPC sync.Map
+)
+
+// SetLevels sets the log levels of modules.
+func SetLevels(l Levels) {
+	levelsMutex.Lock()
+	defer levelsMutex.Unlock()
+	levels = l
+}
+
+// callerLevel returns the level of the module of the caller skip frames above the caller of callerLevel.
+func callerLevel(skip int) Level {
+	levelsMutex.RLock()
+	defer levelsMutex.RUnlock()
+	if len(levels) == 0 {
+		return LevelUnset
+	}
+	if pc, file, _, ok := runtime.Caller(skip + 1); ok {
+		module, cached := modulesByPC.Load(pc)
+		if !cached {
+			module = filepath.Base(filepath.Dir(file))
+			modulesByPC.Store(pc, module)
+		}
+		if level, ok := levels[module.(string)]; ok {
+			return level
+		}
+	}
+	return levels[defaultModule]
+}
@@ -0,0 +1,60 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevels_Set(t *testing.T) {
+	for _, tc := range []struct {
+		value    string
+		expected Levels
+		err      bool
+	}{
+		{
+			value:    "lb=warn,ls=debug",
+			expected: Levels{"lb": LevelWarn, "ls": LevelDebug},
+		},
+		{
+			value:    "error, tg = INFO",
+			expected: Levels{"*": LevelError, "tg": LevelInfo},
+		},
+		{
+			value: "lb=verbose",
+			err:   true,
+		},
+		{
+			value: "=debug",
+			err:   true,
+		},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			var levels Levels
+			err := levels.Set(tc.value)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, levels)
+		})
+	}
+}
+
+func Test_callerLevel(t *testing.T) {
+	defer SetLevels(nil)
+
+	assert.Equal(t, LevelUnset, callerLevel(0))
+
+	SetLevels(Levels{"*": LevelWarn})
+	assert.Equal(t, LevelWarn, callerLevel(0))
+
+	// the module of this test is the directory of its package.
+	SetLevels(Levels{"*": LevelWarn, "log": LevelDebug})
+	assert.Equal(t, LevelDebug, callerLevel(0))
+	assert.True(t, debugEnabled(callerLevel(0)))
+
+	SetLevels(Levels{"log": LevelError})
+	assert.False(t, debugEnabled(callerLevel(0)))
+}
@@ -22,22 +22,30 @@ func New(name string) *Logger {
 
 // Debugf will print debug messages if debug logging is enabled
 func (l *Logger) Debugf(format string, args ...interface{}) {
-	debugf(format, l.name, 2, args...)
+	if debugEnabled(callerLevel(1)) {
+		debugf(format, l.name, 2, args...)
+	}
 }
 
 // DebugLevelf will print debug messages if debug logging is enabled
 func (l *Logger) DebugLevelf(level int, format string, args ...interface{}) {
-	debugf(format, l.name, level, args...)
+	if debugEnabled(callerLevel(1)) {
+		debugf(format, l.name, level, args...)
+	}
 }
 
-// Infof will print info level messages
+// Infof will print info level messages, unless the module of the caller logs warnings or errors only
 func (l *Logger) Infof(format string, args ...interface{}) {
-	infof(format, l.name, args...)
+	if callerLevel(1) <= LevelInfo {
+		infof(format, l.name, args...)
+	}
 }
 
-// Warnf will print warning level messages
+// Warnf will print warning level messages, unless the module of the caller logs errors only
 func (l *Logger) Warnf(format string, args ...interface{}) {
-	warnf(format, l.name, args...)
+	if callerLevel(1) <= LevelWarn {
+		warnf(format, l.name, args...)
+	}
 }
 
 // Errorf will print error level messages
@@ -55,13 +63,20 @@ func (l *Logger) Exitf(format string, args ...interface{}) {
 	exitf(format, l.name, args...)
 }
 
-// debugf will print debug messages if debug logging is enabled
+// debugEnabled returns whether debug messages are printed for a module of level.
+// Modules without a level print them if glog verbosity is at least 2.
+func debugEnabled(level Level) bool {
+	if level == LevelUnset {
+		return bool(glog.V(2))
+	}
+	return level <= LevelDebug
+}
+
+// debugf will print debug messages
 func debugf(format, ingressName string, level int, args ...interface{}) {
-	if glog.V(2) {
-		prefix := fmt.Sprintf("%s: ", ingressName)
-		for _, line := range strings.Split(fmt.Sprintf(format, args...), "\n") {
-			glog.InfoDepth(level, prefix, line)
-		}
+	prefix := fmt.Sprintf("%s: ", ingressName)
+	for _, line := range strings.Split(fmt.Sprintf(format, args...), "\n") {
+		glog.InfoDepth(level, prefix, line)
 	}
 }
 